@@ -93,13 +93,31 @@ func run(args []string, config db.StoreConfig, projectID string) error {
 		fmt.Printf("Signal '%s' cleared for project '%s'.\n", key, projectName)
 
 	case "blocker":
-		if len(args) < 3 {
-			return fmt.Errorf("usage: agent-bridge blocker <message>")
+		// Usage: agent-bridge blocker [--type <type>] [--feature <id>] [--action <suggestion>] <message>
+		blocker := db.Blocker{Type: db.BlockerOther}
+		var words []string
+		for i := 2; i < len(args); i++ {
+			switch {
+			case args[i] == "--type" && i+1 < len(args):
+				blocker.Type = args[i+1]
+				i++
+			case args[i] == "--feature" && i+1 < len(args):
+				blocker.RelatedFeature = args[i+1]
+				i++
+			case args[i] == "--action" && i+1 < len(args):
+				blocker.SuggestedAction = args[i+1]
+				i++
+			default:
+				words = append(words, args[i])
+			}
+		}
+		blocker.Message = strings.Join(words, " ")
+		if blocker.Message == "" {
+			return fmt.Errorf("usage: agent-bridge blocker [--type <%s>] [--feature <id>] [--action <suggestion>] <message>", strings.Join(db.BlockerTypes, "|"))
 		}
-		message := args[2]
-		cmdErr = store.SetSignal(projectID, "BLOCKER", message)
+		cmdErr = db.SetBlocker(store, projectID, blocker)
 		if cmdErr == nil {
-			fmt.Println("Blocker signal set.")
+			fmt.Printf("Blocker signal set.\n%s\n", blocker)
 		}
 
 	case "qa":
@@ -152,9 +170,8 @@ func run(args []string, config db.StoreConfig, projectID string) error {
 
 					if allDone {
 						// Optionally clear blocker if it was a UI blocker
-						msg, _ := store.GetSignal(projectID, "BLOCKER")
-						if strings.Contains(msg, "UI Verification Required") {
-							store.DeleteSignal(projectID, "BLOCKER")
+						if blocker, _ := db.GetBlocker(store, projectID); blocker != nil && blocker.Type == db.BlockerUIVerification {
+							store.DeleteSignal(projectID, db.BlockerSignal)
 							fmt.Println("All UI verifications complete. Clearing blocker.")
 						}
 					}
@@ -166,6 +183,81 @@ func run(args []string, config db.StoreConfig, projectID string) error {
 			return fmt.Errorf("could not read %s", uiPath)
 		}
 
+	case "approve", "reject":
+		// Usage: agent-bridge approve [ticket] | agent-bridge reject [ticket] [reason]
+		project := projectID
+		if len(args) >= 3 {
+			project = args[2]
+		}
+		state, err := store.GetSignal(project, "PENDING_HUMAN_SIGNOFF")
+		if err != nil {
+			return fmt.Errorf("failed to read sign-off state: %w", err)
+		}
+		if state != "pending" {
+			return fmt.Errorf("no human sign-off is pending for project '%s'", project)
+		}
+
+		if command == "approve" {
+			cmdErr = store.SetSignal(project, "PENDING_HUMAN_SIGNOFF", "approved")
+			if cmdErr == nil {
+				fmt.Printf("Sign-off approved for project '%s'.\n", project)
+			}
+			break
+		}
+
+		if len(args) >= 4 {
+			reason := strings.Join(args[3:], " ")
+			if err := store.SetSignal(project, "PENDING_HUMAN_SIGNOFF_REASON", reason); err != nil {
+				return fmt.Errorf("failed to save rejection reason: %w", err)
+			}
+		}
+		cmdErr = store.SetSignal(project, "PENDING_HUMAN_SIGNOFF", "rejected")
+		if cmdErr == nil {
+			fmt.Printf("Sign-off rejected for project '%s'. Returning to coding phase.\n", project)
+		}
+
+	case "confirm", "deny":
+		// Usage: agent-bridge confirm <token> [ticket] | agent-bridge deny <token> [ticket] [reason]
+		if len(args) < 3 {
+			return fmt.Errorf("usage: agent-bridge %s <token> [ticket]", command)
+		}
+		token := args[2]
+		project := projectID
+		if len(args) >= 4 {
+			project = args[3]
+		}
+		state, err := store.GetSignal(project, "PENDING_COMMAND")
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation state: %w", err)
+		}
+		pendingToken, err := store.GetSignal(project, "PENDING_COMMAND_TOKEN")
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation token: %w", err)
+		}
+		if state != "pending" || pendingToken != token {
+			return fmt.Errorf("no command is awaiting confirmation with token '%s' for project '%s'", token, project)
+		}
+		script, _ := store.GetSignal(project, "PENDING_COMMAND_SCRIPT")
+
+		if command == "confirm" {
+			cmdErr = store.SetSignal(project, "PENDING_COMMAND", "approved")
+			if cmdErr == nil {
+				fmt.Printf("Confirmed for project '%s':\n%s\n", project, script)
+			}
+			break
+		}
+
+		if len(args) >= 5 {
+			reason := strings.Join(args[4:], " ")
+			if err := store.SetSignal(project, "PENDING_COMMAND_REASON", reason); err != nil {
+				return fmt.Errorf("failed to save denial reason: %w", err)
+			}
+		}
+		cmdErr = store.SetSignal(project, "PENDING_COMMAND", "rejected")
+		if cmdErr == nil {
+			fmt.Printf("Denied for project '%s':\n%s\n", project, script)
+		}
+
 	case "signal":
 		if len(args) < 4 {
 			return fmt.Errorf("usage: agent-bridge signal <key> <value>")
@@ -175,9 +267,12 @@ func run(args []string, config db.StoreConfig, projectID string) error {
 
 		// PROTECT PRIVILEGED SIGNALS
 		privilegedSignals := map[string]bool{
-			"PROJECT_SIGNED_OFF": true,
-			"TRIGGER_QA":         true,
-			"TRIGGER_MANAGER":    true,
+			"PROJECT_SIGNED_OFF":    true,
+			"TRIGGER_QA":            true,
+			"TRIGGER_MANAGER":       true,
+			"PENDING_HUMAN_SIGNOFF": true,
+			"PENDING_COMMAND":       true,
+			"PENDING_COMMAND_TOKEN": true,
 		}
 		if privilegedSignals[key] {
 			return fmt.Errorf("signal '%s' is privileged and cannot be set via agent-bridge", key)
@@ -293,9 +388,14 @@ func run(args []string, config db.StoreConfig, projectID string) error {
 func printUsage() {
 	fmt.Println("Usage: agent-bridge <command> [arguments]")
 	fmt.Println("Commands:")
-	fmt.Println("  blocker <message>      Set a blocker signal")
+	fmt.Println("  blocker [--type <type>] [--feature <id>] [--action <suggestion>] <message> Set a blocker signal")
+	fmt.Printf("                         Types: %s\n", strings.Join(db.BlockerTypes, ", "))
 	fmt.Println("  qa                     Trigger QA process")
 	fmt.Println("  manager                Trigger Manager review")
+	fmt.Println("  approve [ticket]       Approve a pending human sign-off")
+	fmt.Println("  reject [ticket] [reason] Reject a pending human sign-off")
+	fmt.Println("  confirm <token> [ticket] Run a command held by safe mode")
+	fmt.Println("  deny <token> [ticket] [reason] Refuse a command held by safe mode")
 	fmt.Println("  verify <id> <pass/fail> Update UI verification request")
 	fmt.Println("  signal <key> <value>   Set a generic signal")
 	fmt.Println("  feature set <id> --status <status> --passes <true/false> Update feature status")
@@ -24,6 +24,38 @@ func TestRun_Blocker(t *testing.T) {
 	// We trust SetSignal is covered by db tests. Here we test the CLI wiring.
 }
 
+func TestRun_BlockerTyped(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), ".recac.db")
+	config := db.StoreConfig{Type: "sqlite", ConnectionString: dbPath}
+	projectID := "test-project"
+
+	args := []string{"agent-bridge", "blocker", "--type", "credentials", "--feature", "F-2", "--action", "Add STRIPE_KEY", "Stripe", "key", "missing"}
+	if err := run(args, config, projectID); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	store, err := db.NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	defer store.Close()
+	blocker, err := db.GetBlocker(store, projectID)
+	if err != nil || blocker == nil {
+		t.Fatalf("Expected a blocker, got %v, %v", blocker, err)
+	}
+	want := db.Blocker{Type: db.BlockerCredentials, Message: "Stripe key missing", SuggestedAction: "Add STRIPE_KEY", RelatedFeature: "F-2"}
+	if *blocker != want {
+		t.Errorf("Expected %+v, got %+v", want, *blocker)
+	}
+
+	if err := run([]string{"agent-bridge", "blocker", "--type", "weather", "It is raining"}, config, projectID); err == nil {
+		t.Error("Expected an error for an unknown blocker type")
+	}
+	if err := run([]string{"agent-bridge", "blocker", "--type", "dependency"}, config, projectID); err == nil {
+		t.Error("Expected an error for a blocker without a message")
+	}
+}
+
 func TestRun_QA(t *testing.T) {
 	tmpDir := t.TempDir()
 	dbPath := filepath.Join(tmpDir, ".recac.db")
@@ -143,3 +175,123 @@ func TestRun_Invalid(t *testing.T) {
 		t.Error("Expected error for verify missing file")
 	}
 }
+
+func TestRun_ApproveReject(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".recac.db")
+	config := db.StoreConfig{Type: "sqlite", ConnectionString: dbPath}
+	projectID := "test-project"
+
+	// Nothing pending yet
+	if err := run([]string{"agent-bridge", "approve"}, config, projectID); err == nil {
+		t.Error("Expected error when no sign-off is pending")
+	}
+
+	// Privileged: cannot be forged via the generic signal command
+	if err := run([]string{"agent-bridge", "signal", "PENDING_HUMAN_SIGNOFF", "approved"}, config, projectID); err == nil {
+		t.Error("Expected error for privileged signal")
+	}
+
+	setPending := func(project string) {
+		store, err := db.NewStore(config)
+		if err != nil {
+			t.Fatalf("failed to open store: %v", err)
+		}
+		defer store.Close()
+		if err := store.SetSignal(project, "PENDING_HUMAN_SIGNOFF", "pending"); err != nil {
+			t.Fatalf("failed to set signal: %v", err)
+		}
+	}
+	getSignal := func(project, key string) string {
+		store, err := db.NewStore(config)
+		if err != nil {
+			t.Fatalf("failed to open store: %v", err)
+		}
+		defer store.Close()
+		val, _ := store.GetSignal(project, key)
+		return val
+	}
+
+	// Approve using the default project
+	setPending(projectID)
+	if err := run([]string{"agent-bridge", "approve"}, config, projectID); err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+	if got := getSignal(projectID, "PENDING_HUMAN_SIGNOFF"); got != "approved" {
+		t.Errorf("Expected approved, got %q", got)
+	}
+
+	// Reject an explicit ticket with a reason
+	setPending("PROJ-1")
+	if err := run([]string{"agent-bridge", "reject", "PROJ-1", "tests", "are", "flaky"}, config, projectID); err != nil {
+		t.Fatalf("reject failed: %v", err)
+	}
+	if got := getSignal("PROJ-1", "PENDING_HUMAN_SIGNOFF"); got != "rejected" {
+		t.Errorf("Expected rejected, got %q", got)
+	}
+	if got := getSignal("PROJ-1", "PENDING_HUMAN_SIGNOFF_REASON"); got != "tests are flaky" {
+		t.Errorf("Expected reason to be saved, got %q", got)
+	}
+}
+
+func TestRun_ConfirmDeny(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".recac.db")
+	config := db.StoreConfig{Type: "sqlite", ConnectionString: dbPath}
+	projectID := "test-project"
+
+	withStore := func(fn func(store db.Store)) {
+		store, err := db.NewStore(config)
+		if err != nil {
+			t.Fatalf("failed to open store: %v", err)
+		}
+		defer store.Close()
+		fn(store)
+	}
+	setPending := func(project, token string) {
+		withStore(func(store db.Store) {
+			store.SetSignal(project, "PENDING_COMMAND_TOKEN", token)
+			store.SetSignal(project, "PENDING_COMMAND_SCRIPT", "rm -rf build")
+			store.SetSignal(project, "PENDING_COMMAND", "pending")
+		})
+	}
+	getSignal := func(project, key string) (val string) {
+		withStore(func(store db.Store) { val, _ = store.GetSignal(project, key) })
+		return val
+	}
+
+	// Nothing pending yet
+	if err := run([]string{"agent-bridge", "confirm", "abcd1234"}, config, projectID); err == nil {
+		t.Error("Expected error when no command is pending")
+	}
+
+	// The agent cannot confirm its own command
+	if err := run([]string{"agent-bridge", "signal", "PENDING_COMMAND", "approved"}, config, projectID); err == nil {
+		t.Error("Expected error for privileged signal")
+	}
+
+	// Wrong token
+	setPending(projectID, "abcd1234")
+	if err := run([]string{"agent-bridge", "confirm", "00000000"}, config, projectID); err == nil {
+		t.Error("Expected error for a token that does not match")
+	}
+
+	if err := run([]string{"agent-bridge", "confirm", "abcd1234"}, config, projectID); err != nil {
+		t.Fatalf("confirm failed: %v", err)
+	}
+	if got := getSignal(projectID, "PENDING_COMMAND"); got != "approved" {
+		t.Errorf("Expected approved, got %q", got)
+	}
+
+	// Deny for an explicit ticket with a reason
+	setPending("PROJ-1", "feed5678")
+	if err := run([]string{"agent-bridge", "deny", "feed5678", "PROJ-1", "keep", "the", "build", "cache"}, config, projectID); err != nil {
+		t.Fatalf("deny failed: %v", err)
+	}
+	if got := getSignal("PROJ-1", "PENDING_COMMAND"); got != "rejected" {
+		t.Errorf("Expected rejected, got %q", got)
+	}
+	if got := getSignal("PROJ-1", "PENDING_COMMAND_REASON"); got != "keep the build cache" {
+		t.Errorf("Expected reason to be saved, got %q", got)
+	}
+}
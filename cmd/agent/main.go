@@ -9,6 +9,7 @@ import (
 
 	"recac/internal/cmdutils"
 	"recac/internal/config"
+	"recac/internal/docker"
 	"recac/internal/telemetry"
 	"recac/internal/workflow"
 
@@ -24,64 +25,133 @@ func initFlags(cfgFile *string) {
 	pflag.String("path", "", "Project path")
 	pflag.Int("max-iterations", 30, "Maximum number of iterations")
 	pflag.Int("manager-frequency", 5, "Frequency of manager reviews")
+	pflag.Int("progress-interval", 0, "Iterations between progress notifications (0 = manager frequency)")
+	pflag.Bool("safe-mode", false, "Pause before risky commands (rm -rf, force pushes, ...) until a human runs agent-bridge confirm <token>")
+	pflag.Bool("host-mode", false, "Run agent commands directly on this machine instead of in a container (no isolation)")
+	pflag.Duration("checkpoint-interval", 0, "Commit the agent container to an image this often so a crashed session can resume from it (e.g. 15m; 0 = disabled)")
+	pflag.String("max-workspace-size", "", "Block the session when the workspace exceeds this size (e.g. 10GB; empty = unlimited)")
+	pflag.Int("max-qa-rejections", 3, "Block the session after this many QA/Manager rejections (0 = unlimited)")
 	pflag.Int("max-agents", 1, "Maximum number of parallel agents")
+	pflag.Bool("isolate-worktrees", false, "Give each parallel agent its own git worktree, merged back after each sprint")
 	pflag.Int("task-max-iterations", 10, "Maximum iterations for sub-tasks")
 	pflag.Bool("detached", false, "Run session in background (detached mode)")
 	pflag.String("name", "", "Name for the session (required for detached mode)")
 	pflag.String("jira", "", "Jira Ticket ID to start session from (e.g. PROJ-123)")
 	pflag.Bool("manager-first", false, "Run the Manager Agent before the first coding session")
 	pflag.Bool("stream", false, "Stream agent output to the console")
+	pflag.Bool("print-prompt", false, "Print the full prompt sent to the agent each iteration (for debugging prompt templates)")
+	pflag.String("select-task", "", "Focus the session on a single feature by ID (see recac tasks list)")
 	pflag.Bool("allow-dirty", false, "Allow running with uncommitted git changes")
+	pflag.Bool("fresh", false, "Delete the workspace's session database, agent state and signals before starting (discards previous progress)")
+	pflag.Bool("cache-responses", false, "Serve repeated prompts from an on-disk response cache (for reproducible runs)")
+	pflag.String("conflict-strategy", "reset", "How to handle conflicts merging the base branch at sign-off: reset or resolve")
 
 	pflag.Bool("auto-merge", false, "Automatically merge PRs if checks pass")
+	pflag.StringSlice("required-check", nil, "CI check that must pass on the feature branch before auto-merge (repeatable)")
 	pflag.Bool("skip-qa", false, "Skip QA phase and auto-complete (use with caution)")
 	pflag.String("image", "ghcr.io/process-failed-successfully/recac-agent:latest", "Docker image to use for the agent session")
+	pflag.String("image-digest", "", "Expected image digest (sha256:...); the session fails if the image does not match")
+	pflag.String("network", "bridge", "Network of the agent container: bridge, none (no network access), host or a named network")
+	pflag.String("http-proxy", "", "Egress proxy for HTTP requests from the agent container (e.g. http://proxy:3128)")
+	pflag.String("https-proxy", "", "Egress proxy for HTTPS requests from the agent container (defaults to --http-proxy)")
+	pflag.String("no-proxy", "", "Comma-separated hosts the agent container reaches without the proxy (default loopback)")
 	pflag.Bool("cleanup", true, "Cleanup temporary workspace after session ends")
+	pflag.String("cleanup-policy", "", "Workspace cleanup policy: always, on-success or never (overrides --cleanup)")
 	pflag.String("project", "", "Project name override")
 
 	pflag.String("repo-url", "", "Repository URL to clone (bypasses Jira if provided)")
+	pflag.StringSlice("repo-urls", nil, "Additional repository to clone into a subdirectory of the workspace, branched and pushed separately (repeatable)")
 	pflag.String("summary", "", "Task summary (bypasses Jira if provided)")
 	pflag.String("description", "", "Task description")
 
 	pflag.String("provider", "", "Agent provider override")
 	pflag.String("model", "", "Agent model override")
+	pflag.String("manager-provider", "", "Provider for the Manager agent (defaults to --provider)")
+	pflag.String("manager-model", "", "Model for the Manager agent (defaults to --model)")
+	pflag.String("qa-provider", "", "Provider for the QA agent (defaults to --provider)")
+	pflag.String("qa-model", "", "Model for the QA agent (defaults to --model)")
 	pflag.Bool("mock", false, "Mock mode")
+	pflag.String("status-addr", "", "Serve a live session status page on this address (e.g. :8090)")
 }
 
 func runApp(ctx context.Context) error {
 
 	// Bindings
-	viper.BindPFlag("verbose", pflag.Lookup("verbose"))
-	viper.BindPFlag("path", pflag.Lookup("path"))
-	viper.BindPFlag("max_iterations", pflag.Lookup("max-iterations"))
-	viper.BindPFlag("manager_frequency", pflag.Lookup("manager-frequency"))
-	viper.BindPFlag("max_agents", pflag.Lookup("max-agents"))
-	viper.BindPFlag("task_max_iterations", pflag.Lookup("task-max-iterations"))
-	viper.BindPFlag("detached", pflag.Lookup("detached"))
-	viper.BindPFlag("name", pflag.Lookup("name"))
-	viper.BindPFlag("jira", pflag.Lookup("jira"))
-	viper.BindPFlag("manager_first", pflag.Lookup("manager-first"))
-	viper.BindPFlag("stream", pflag.Lookup("stream"))
-	viper.BindPFlag("allow_dirty", pflag.Lookup("allow-dirty"))
-	viper.BindPFlag("auto_merge", pflag.Lookup("auto-merge"))
-	viper.BindPFlag("skip_qa", pflag.Lookup("skip-qa"))
-	viper.BindPFlag("image", pflag.Lookup("image"))
-	viper.BindPFlag("cleanup", pflag.Lookup("cleanup"))
-	viper.BindPFlag("project", pflag.Lookup("project"))
-	viper.BindPFlag("repo_url", pflag.Lookup("repo-url"))
-	viper.BindPFlag("summary", pflag.Lookup("summary"))
-	viper.BindPFlag("description", pflag.Lookup("description"))
-	viper.BindPFlag("provider", pflag.Lookup("provider"))
-	viper.BindPFlag("model", pflag.Lookup("model"))
-	viper.BindPFlag("mock", pflag.Lookup("mock"))
+	config.BindPFlag("verbose", pflag.Lookup("verbose"))
+	config.BindPFlag("path", pflag.Lookup("path"))
+	config.BindPFlag("max_iterations", pflag.Lookup("max-iterations"))
+	config.BindPFlag("manager_frequency", pflag.Lookup("manager-frequency"))
+	config.BindPFlag("progress_interval", pflag.Lookup("progress-interval"))
+	config.BindPFlag("checkpoint_interval", pflag.Lookup("checkpoint-interval"))
+	config.BindPFlag("safe_mode", pflag.Lookup("safe-mode"))
+	config.BindPFlag("host_mode", pflag.Lookup("host-mode"))
+	config.BindPFlag("max_workspace_size", pflag.Lookup("max-workspace-size"))
+	config.BindPFlag("max_qa_rejections", pflag.Lookup("max-qa-rejections"))
+	config.BindPFlag("max_agents", pflag.Lookup("max-agents"))
+	config.BindPFlag("isolate_worktrees", pflag.Lookup("isolate-worktrees"))
+	config.BindPFlag("conflict_strategy", pflag.Lookup("conflict-strategy"))
+	config.BindPFlag("task_max_iterations", pflag.Lookup("task-max-iterations"))
+	config.BindPFlag("detached", pflag.Lookup("detached"))
+	config.BindPFlag("name", pflag.Lookup("name"))
+	config.BindPFlag("jira", pflag.Lookup("jira"))
+	config.BindPFlag("manager_first", pflag.Lookup("manager-first"))
+	config.BindPFlag("stream", pflag.Lookup("stream"))
+	config.BindPFlag("print_prompt", pflag.Lookup("print-prompt"))
+	config.BindPFlag("select_task", pflag.Lookup("select-task"))
+	config.BindPFlag("allow_dirty", pflag.Lookup("allow-dirty"))
+	config.BindPFlag("fresh", pflag.Lookup("fresh"))
+	config.BindPFlag("cache_responses", pflag.Lookup("cache-responses"))
+	config.BindPFlag("auto_merge", pflag.Lookup("auto-merge"))
+	config.BindPFlag("auto_merge_required_checks", pflag.Lookup("required-check"))
+	config.BindPFlag("skip_qa", pflag.Lookup("skip-qa"))
+	config.BindPFlag("image", pflag.Lookup("image"))
+	config.BindPFlag("image_digest", pflag.Lookup("image-digest"))
+	config.BindPFlag("network", pflag.Lookup("network"))
+	config.BindPFlag("proxy.http", pflag.Lookup("http-proxy"))
+	config.BindPFlag("proxy.https", pflag.Lookup("https-proxy"))
+	config.BindPFlag("proxy.no_proxy", pflag.Lookup("no-proxy"))
+	config.BindPFlag("cleanup", pflag.Lookup("cleanup"))
+	config.BindPFlag("cleanup_policy", pflag.Lookup("cleanup-policy"))
+	config.BindPFlag("project", pflag.Lookup("project"))
+	config.BindPFlag("repo_url", pflag.Lookup("repo-url"))
+	config.BindPFlag("repo_urls", pflag.Lookup("repo-urls"))
+	config.BindPFlag("summary", pflag.Lookup("summary"))
+	config.BindPFlag("description", pflag.Lookup("description"))
+	config.BindPFlag("provider", pflag.Lookup("provider"))
+	config.BindPFlag("model", pflag.Lookup("model"))
+	config.BindPFlag("agents.manager.provider", pflag.Lookup("manager-provider"))
+	config.BindPFlag("agents.manager.model", pflag.Lookup("manager-model"))
+	config.BindPFlag("agents.qa.provider", pflag.Lookup("qa-provider"))
+	config.BindPFlag("agents.qa.model", pflag.Lookup("qa-model"))
+	config.BindPFlag("mock", pflag.Lookup("mock"))
+	config.BindPFlag("status_addr", pflag.Lookup("status-addr"))
 
 	viper.BindEnv("max_iterations", "RECAC_MAX_ITERATIONS")
 	viper.BindEnv("manager_frequency", "RECAC_MANAGER_FREQUENCY")
 	viper.BindEnv("task_max_iterations", "RECAC_TASK_MAX_ITERATIONS")
+	viper.BindEnv("github.issue", "GITHUB_ISSUE")
+	viper.BindEnv("github.issue_repo", "GITHUB_ISSUE_REPO")
+	viper.BindEnv("status_addr", "RECAC_STATUS_ADDR")
+	viper.BindEnv("fresh", "RECAC_FRESH")
+	viper.BindEnv("checkpoint_interval", "RECAC_CHECKPOINT_INTERVAL")
+	viper.BindEnv("safe_mode", "RECAC_SAFE_MODE")
+	viper.BindEnv("host_mode", "RECAC_HOST_MODE")
+	viper.BindEnv("print_prompt", "RECAC_PRINT_PROMPT")
+	viper.BindEnv("select_task", "RECAC_SELECT_TASK")
+	viper.BindEnv("network", "RECAC_NETWORK")
+	viper.BindEnv("proxy.http", "RECAC_PROXY_HTTP")
+	viper.BindEnv("proxy.https", "RECAC_PROXY_HTTPS")
+	viper.BindEnv("proxy.no_proxy", "RECAC_PROXY_NO_PROXY")
+	viper.BindEnv("cache_responses", "RECAC_CACHE_RESPONSES")
+	viper.BindEnv("response_cache_dir", "RECAC_RESPONSE_CACHE_DIR")
 
 	// Explicitly bind Provider/Model to ensure Env vars take precedence over config file
 	viper.BindEnv("provider", "RECAC_PROVIDER", "RECAC_AGENT_PROVIDER")
 	viper.BindEnv("model", "RECAC_MODEL", "RECAC_AGENT_MODEL")
+	viper.BindEnv("agents.manager.provider", "RECAC_MANAGER_PROVIDER")
+	viper.BindEnv("agents.manager.model", "RECAC_MANAGER_MODEL")
+	viper.BindEnv("agents.qa.provider", "RECAC_QA_PROVIDER")
+	viper.BindEnv("agents.qa.model", "RECAC_QA_MODEL")
 
 	// Init Logger
 	telemetry.InitLogger(viper.GetBool("verbose"), "", false)
@@ -94,37 +164,62 @@ func runApp(ctx context.Context) error {
 		"env_recac_provider", os.Getenv("RECAC_PROVIDER"),
 	)
 
+	proxy := docker.ProxyConfig{
+		HTTP:    viper.GetString("proxy.http"),
+		HTTPS:   viper.GetString("proxy.https"),
+		NoProxy: viper.GetString("proxy.no_proxy"),
+	}
+
 	// Construct SessionConfig
 	cfg := workflow.SessionConfig{
-		ProjectPath:       viper.GetString("path"),
-		IsMock:            viper.GetBool("mock"),
-		MaxIterations:     viper.GetInt("max_iterations"),
-		ManagerFrequency:  viper.GetInt("manager_frequency"),
-		MaxAgents:         viper.GetInt("max_agents"),
-		TaskMaxIterations: viper.GetInt("task_max_iterations"),
-		Detached:          viper.GetBool("detached"),
-		SessionName:       viper.GetString("name"),
-		AllowDirty:        viper.GetBool("allow_dirty"),
-		Stream:            viper.GetBool("stream"),
-		AutoMerge:         viper.GetBool("auto_merge"),
-		SkipQA:            viper.GetBool("skip_qa"),
-		ManagerFirst:      viper.GetBool("manager_first"),
-		Image:             viper.GetString("image"),
-		Debug:             viper.GetBool("verbose"),
-		Provider:          viper.GetString("provider"),
-		Model:             viper.GetString("model"),
-		Cleanup:           viper.GetBool("cleanup"),
-		ProjectName:       viper.GetString("project"),
-		RepoURL:           viper.GetString("repo_url"),
-		Summary:           viper.GetString("summary"),
-		Description:       viper.GetString("description"),
-		JiraTicketID:      viper.GetString("jira"),
-		Logger:            logger,
-		CommandPrefix:     []string{}, // Agent binary doesn't use subcommands, unless needed.
+		ProjectPath:         viper.GetString("path"),
+		IsMock:              viper.GetBool("mock"),
+		MaxIterations:       viper.GetInt("max_iterations"),
+		ManagerFrequency:    viper.GetInt("manager_frequency"),
+		MaxAgents:           viper.GetInt("max_agents"),
+		TaskMaxIterations:   viper.GetInt("task_max_iterations"),
+		Detached:            viper.GetBool("detached"),
+		SessionName:         viper.GetString("name"),
+		AllowDirty:          viper.GetBool("allow_dirty"),
+		Fresh:               viper.GetBool("fresh"),
+		Stream:              viper.GetBool("stream"),
+		PrintPrompt:         viper.GetBool("print_prompt"),
+		SelectedTaskID:      viper.GetString("select_task"),
+		AutoMerge:           viper.GetBool("auto_merge"),
+		SkipQA:              viper.GetBool("skip_qa"),
+		ManagerFirst:        viper.GetBool("manager_first"),
+		RequireHumanSignoff: viper.GetBool("require_human_signoff"),
+		Image:               viper.GetString("image"),
+		ImageDigest:         viper.GetString("image_digest"),
+		ContainerEntrypoint: viper.GetStringSlice("container_entrypoint"),
+		ContainerCommand:    viper.GetStringSlice("container_command"),
+		Network:             viper.GetString("network"),
+		Proxy:               proxy,
+		Debug:               viper.GetBool("verbose"),
+		Provider:            viper.GetString("provider"),
+		Model:               viper.GetString("model"),
+		ManagerProvider:     viper.GetString("agents.manager.provider"),
+		ManagerModel:        viper.GetString("agents.manager.model"),
+		QAProvider:          viper.GetString("agents.qa.provider"),
+		QAModel:             viper.GetString("agents.qa.model"),
+		Cleanup:             viper.GetBool("cleanup"),
+		CleanupPolicy:       viper.GetString("cleanup_policy"),
+		ProjectName:         viper.GetString("project"),
+		RepoURL:             viper.GetString("repo_url"),
+		RepoURLs:            cmdutils.GetStringList("repo_urls"),
+		Summary:             viper.GetString("summary"),
+		Description:         viper.GetString("description"),
+		JiraTicketID:        viper.GetString("jira"),
+		GitHubIssue:         viper.GetInt("github.issue"),
+		GitHubRepo:          viper.GetString("github.issue_repo"),
+		Logger:              logger,
+		CommandPrefix:       []string{}, // Agent binary doesn't use subcommands, unless needed.
+		StatusAddr:          viper.GetString("status_addr"),
 	}
 
 	// Logic
-	if cfg.JiraTicketID != "" {
+	// GitHub work items reuse the ticket ID (gh-<n>) but are not Jira tickets
+	if cfg.JiraTicketID != "" && cfg.GitHubIssue == 0 {
 		jClient, err := cmdutils.GetJiraClient(ctx)
 		if err != nil {
 			return err
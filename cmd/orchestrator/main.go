@@ -2,12 +2,12 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"recac/internal/agent"
 	"recac/internal/cmdutils"
 	"recac/internal/config"
 	"recac/internal/docker"
@@ -26,24 +26,42 @@ func main() {
 	pflag.StringVar(&cfgFile, "config", "", "config file (default is $HOME/.recac.yaml)")
 	pflag.BoolP("verbose", "v", false, "Enable verbose/debug logging")
 
-	pflag.String("mode", "local", "Orchestrator mode: 'local' (Docker) or 'k8s' (Kubernetes Job)")
+	pflag.String("mode", "local", "Orchestrator mode: 'local' (Docker), 'k8s' (Kubernetes Job), or 'mock' (record spawns only)")
 	pflag.String("jira-label", "recac-agent", "Jira label to poll for")
 	pflag.String("image", "ghcr.io/process-failed-successfully/recac-agent:latest", "Agent image to spawn")
+	pflag.String("image-digest", "", "Pin the agent image to this digest (sha256:...)")
 	pflag.String("namespace", "default", "Kubernetes namespace (for k8s mode)")
 	pflag.Duration("interval", 1*time.Minute, "Polling interval")
+	pflag.Float64("interval-jitter", 0, "Randomize each polling interval by up to this fraction (0.2 = ±20%)")
+	pflag.Bool("once", false, "Poll once, spawn any pending agents, and exit (for cron-driven setups)")
 	pflag.String("agent-provider", "openrouter", "Provider for spawned agents")
 	pflag.String("agent-model", "mistralai/devstral-2512:free", "Model for spawned agents")
+	pflag.Bool("provider-health", true, "Verify the agent provider and model are reachable at startup")
 	pflag.String("image-pull-policy", "Always", "Image pull policy for agents (Always, IfNotPresent, Never)")
 
 	pflag.String("jira-query", "", "Custom JQL query (overrides label)")
-	pflag.String("poller", "jira", "Poller type: 'jira', 'github', 'file', or 'file-dir'")
+	pflag.StringSlice("jira-exclude-types", nil, "Issue types to skip, e.g. Epic,Sub-task (ignored with --jira-query)")
+	pflag.StringSlice("jira-statuses", nil, "Only pick up issues in these statuses (default: any status not Done; ignored with --jira-query)")
+	pflag.Int("max-items", 0, "Maximum number of Jira work items to spawn per poll (0 = unlimited)")
+	pflag.String("poller", "jira", "Poller type: 'jira', 'github', 'github-project', 'discord', 'file', 'file-dir', or 'mock'")
 	pflag.String("work-file", "work_items.json", "Work items file (for 'file' poller)")
 	pflag.String("watch-dir", "", "Directory to watch for work item files (for 'file-dir' poller)")
+	pflag.String("file-lifecycle", orchestrator.FileLifecycleMove, "How spawned work files are retired: 'move' (to processed/) or 'index' (for 'file-dir' poller)")
 
 	pflag.String("github-token", "", "GitHub API Token (for 'github' poller)")
 	pflag.String("github-owner", "", "GitHub Repository Owner (for 'github' poller)")
 	pflag.String("github-repo", "", "GitHub Repository Name (for 'github' poller)")
 	pflag.String("github-label", "", "GitHub Label to poll for (defaults to jira-label if not set)")
+	pflag.Int("github-project", 0, "GitHub organization project number (for 'github-project' poller; the org is --github-owner)")
+	pflag.String("github-project-column", "Todo", "Project column (Status value) to pick up items from (for 'github-project' poller)")
+
+	pflag.String("discord-repo-url", "", "Repository used when a /recac command names none (for 'discord' poller)")
+	pflag.StringSlice("discord-guilds", nil, "Guild IDs /recac is accepted from (for 'discord' poller; default any)")
+	pflag.StringSlice("discord-channels", nil, "Channel IDs /recac is accepted from (for 'discord' poller; default any)")
+	pflag.StringSlice("discord-users", nil, "User IDs allowed to run /recac (for 'discord' poller)")
+	pflag.StringSlice("discord-roles", nil, "Role IDs whose members may run /recac (for 'discord' poller)")
+	pflag.StringSlice("discord-repos", nil, "Repositories /recac may name besides --discord-repo-url (for 'discord' poller)")
+	pflag.Int("mock-items", 3, "Number of generated work items (for 'mock' poller)")
 
 	pflag.Parse()
 
@@ -53,23 +71,40 @@ func main() {
 	// Bind Flags
 	viper.BindPFlag("verbose", pflag.Lookup("verbose"))
 	viper.BindPFlag("orchestrator.jira_query", pflag.Lookup("jira-query"))
+	viper.BindPFlag("orchestrator.jira_exclude_types", pflag.Lookup("jira-exclude-types"))
+	viper.BindPFlag("orchestrator.jira_statuses", pflag.Lookup("jira-statuses"))
+	viper.BindPFlag("orchestrator.max_items", pflag.Lookup("max-items"))
 	viper.BindPFlag("orchestrator.poller", pflag.Lookup("poller"))
 	viper.BindPFlag("orchestrator.work_file", pflag.Lookup("work-file"))
 	viper.BindPFlag("orchestrator.watch_dir", pflag.Lookup("watch-dir"))
+	viper.BindPFlag("orchestrator.file_lifecycle", pflag.Lookup("file-lifecycle"))
 
 	viper.BindPFlag("orchestrator.github_token", pflag.Lookup("github-token"))
 	viper.BindPFlag("orchestrator.github_owner", pflag.Lookup("github-owner"))
 	viper.BindPFlag("orchestrator.github_repo", pflag.Lookup("github-repo"))
 	viper.BindPFlag("orchestrator.github_label", pflag.Lookup("github-label"))
+	viper.BindPFlag("orchestrator.github_project", pflag.Lookup("github-project"))
+	viper.BindPFlag("orchestrator.github_project_column", pflag.Lookup("github-project-column"))
+	viper.BindPFlag("orchestrator.discord_repo_url", pflag.Lookup("discord-repo-url"))
+	viper.BindPFlag("orchestrator.discord_guilds", pflag.Lookup("discord-guilds"))
+	viper.BindPFlag("orchestrator.discord_channels", pflag.Lookup("discord-channels"))
+	viper.BindPFlag("orchestrator.discord_users", pflag.Lookup("discord-users"))
+	viper.BindPFlag("orchestrator.discord_roles", pflag.Lookup("discord-roles"))
+	viper.BindPFlag("orchestrator.discord_repos", pflag.Lookup("discord-repos"))
+	viper.BindPFlag("orchestrator.mock_items", pflag.Lookup("mock-items"))
 
 	viper.BindPFlag("orchestrator.mode", pflag.Lookup("mode"))
 	viper.BindPFlag("orchestrator.jira_label", pflag.Lookup("jira-label"))
 	viper.BindPFlag("orchestrator.image", pflag.Lookup("image"))
+	viper.BindPFlag("orchestrator.image_digest", pflag.Lookup("image-digest"))
 	viper.BindPFlag("orchestrator.namespace", pflag.Lookup("namespace"))
 	viper.BindPFlag("orchestrator.interval", pflag.Lookup("interval"))
+	viper.BindPFlag("orchestrator.interval_jitter", pflag.Lookup("interval-jitter"))
+	viper.BindPFlag("orchestrator.once", pflag.Lookup("once"))
 	viper.BindPFlag("orchestrator.agent_provider", pflag.Lookup("agent-provider"))
 	viper.BindPFlag("orchestrator.agent_model", pflag.Lookup("agent-model"))
 	viper.BindPFlag("orchestrator.image_pull_policy", pflag.Lookup("image-pull-policy"))
+	viper.BindPFlag("orchestrator.provider_health", pflag.Lookup("provider-health"))
 
 	// Explicitly bind cleaner env vars
 	viper.BindEnv("orchestrator.agent_provider", "RECAC_AGENT_PROVIDER")
@@ -77,15 +112,31 @@ func main() {
 	viper.BindEnv("orchestrator.poller", "RECAC_POLLER")
 	viper.BindEnv("orchestrator.work_file", "RECAC_WORK_FILE")
 	viper.BindEnv("orchestrator.watch_dir", "RECAC_WATCH_DIR")
+	viper.BindEnv("orchestrator.file_lifecycle", "RECAC_FILE_LIFECYCLE")
 	viper.BindEnv("orchestrator.github_token", "RECAC_GITHUB_TOKEN", "GITHUB_TOKEN")
 	viper.BindEnv("orchestrator.github_owner", "RECAC_GITHUB_OWNER")
 	viper.BindEnv("orchestrator.github_repo", "RECAC_GITHUB_REPO")
 	viper.BindEnv("orchestrator.github_label", "RECAC_GITHUB_LABEL")
+	viper.BindEnv("orchestrator.github_project", "RECAC_GITHUB_PROJECT")
+	viper.BindEnv("orchestrator.github_project_column", "RECAC_GITHUB_PROJECT_COLUMN")
+	viper.BindEnv("orchestrator.discord_token", "DISCORD_BOT_TOKEN")
+	viper.BindEnv("orchestrator.discord_repo_url", "RECAC_DISCORD_REPO_URL")
+	viper.BindEnv("orchestrator.discord_guilds", "RECAC_DISCORD_GUILDS")
+	viper.BindEnv("orchestrator.discord_channels", "RECAC_DISCORD_CHANNELS")
+	viper.BindEnv("orchestrator.discord_users", "RECAC_DISCORD_USERS")
+	viper.BindEnv("orchestrator.discord_roles", "RECAC_DISCORD_ROLES")
+	viper.BindEnv("orchestrator.discord_repos", "RECAC_DISCORD_REPOS")
+	viper.BindEnv("orchestrator.mock_items", "RECAC_MOCK_ITEMS")
 	viper.BindEnv("orchestrator.mode", "RECAC_ORCHESTRATOR_MODE")
 	viper.BindEnv("orchestrator.image", "RECAC_ORCHESTRATOR_IMAGE")
+	viper.BindEnv("orchestrator.image_digest", "RECAC_ORCHESTRATOR_IMAGE_DIGEST")
 	viper.BindEnv("orchestrator.namespace", "RECAC_ORCHESTRATOR_NAMESPACE")
 	viper.BindEnv("orchestrator.interval", "RECAC_ORCHESTRATOR_INTERVAL")
+	viper.BindEnv("orchestrator.interval_jitter", "RECAC_ORCHESTRATOR_INTERVAL_JITTER")
+	viper.BindEnv("orchestrator.max_items", "RECAC_ORCHESTRATOR_MAX_ITEMS")
+	viper.BindEnv("orchestrator.once", "RECAC_ORCHESTRATOR_ONCE")
 	viper.BindEnv("orchestrator.image_pull_policy", "RECAC_IMAGE_PULL_POLICY")
+	viper.BindEnv("orchestrator.provider_health", "RECAC_ORCHESTRATOR_PROVIDER_HEALTH")
 	viper.BindEnv("orchestrator.max_iterations", "RECAC_MAX_ITERATIONS")
 	viper.BindEnv("orchestrator.manager_frequency", "RECAC_MANAGER_FREQUENCY")
 	viper.BindEnv("orchestrator.task_max_iterations", "RECAC_TASK_MAX_ITERATIONS")
@@ -105,8 +156,18 @@ func main() {
 	interval := viper.GetDuration("orchestrator.interval") // e.g. "1m"
 	agentProvider := viper.GetString("orchestrator.agent_provider")
 
+	// Pin the agent image to a digest so the runtime refuses any other content
+	if digest := viper.GetString("orchestrator.image_digest"); digest != "" {
+		pinned, err := docker.PinImage(image, digest)
+		if err != nil {
+			logger.Error("Invalid image digest", "image", image, "error", err)
+			os.Exit(1)
+		}
+		image = pinned
+	}
+
 	query := viper.GetString("orchestrator.jira_query")
-	logger.Info("Starting Orchestrator", "mode", mode, "label", label, "query", query, "interval", interval, "agent_provider", agentProvider)
+	logger.Info("Starting Orchestrator", "mode", mode, "label", label, "query", query, "interval", interval, "once", viper.GetBool("orchestrator.once"), "agent_provider", agentProvider)
 
 	// 1. Poller
 	var poller orchestrator.Poller
@@ -119,13 +180,19 @@ func main() {
 			logger.Error("Watch directory must be specified in file-dir poller mode")
 			os.Exit(1)
 		}
-		var err error
-		poller, err = orchestrator.NewFileDirPoller(watchDir)
+		lifecycle := viper.GetString("orchestrator.file_lifecycle")
+		if lifecycle != orchestrator.FileLifecycleMove && lifecycle != orchestrator.FileLifecycleIndex {
+			logger.Error("File lifecycle must be 'move' or 'index'", "file_lifecycle", lifecycle)
+			os.Exit(1)
+		}
+		dirPoller, err := orchestrator.NewFileDirPoller(watchDir)
 		if err != nil {
 			logger.Error("Failed to initialize file directory poller", "error", err)
 			os.Exit(1)
 		}
-		logger.Info("Using file directory poller", "directory", watchDir)
+		dirPoller.Lifecycle = lifecycle
+		poller = dirPoller
+		logger.Info("Using file directory poller", "directory", watchDir, "lifecycle", lifecycle)
 	case "file", "filesystem":
 		workFile := viper.GetString("orchestrator.work_file")
 		if workFile == "" {
@@ -143,12 +210,69 @@ func main() {
 			ghLabel = label // Fallback to jira-label
 		}
 
-		if token == "" || owner == "" || repo == "" {
-			logger.Error("GitHub token, owner, and repo must be specified in github poller mode")
+		app, err := cmdutils.GetGitHubApp()
+		if err != nil {
+			logger.Error("Failed to load GitHub App", "error", err)
 			os.Exit(1)
 		}
-		poller = orchestrator.NewGitHubPoller(token, owner, repo, ghLabel)
+		if (token == "" && app == nil) || owner == "" || repo == "" {
+			logger.Error("GitHub token (or GitHub App), owner, and repo must be specified in github poller mode")
+			os.Exit(1)
+		}
+		githubPoller := orchestrator.NewGitHubPoller(token, owner, repo, ghLabel)
+		if app != nil {
+			app.Authorize(githubPoller.Client)
+		}
+		poller = githubPoller
 		logger.Info("Using GitHub poller", "owner", owner, "repo", repo, "label", ghLabel)
+	case "github-project":
+		token := viper.GetString("orchestrator.github_token")
+		org := viper.GetString("orchestrator.github_owner")
+		number := viper.GetInt("orchestrator.github_project")
+		column := viper.GetString("orchestrator.github_project_column")
+		app, err := cmdutils.GetGitHubApp()
+		if err != nil {
+			logger.Error("Failed to load GitHub App", "error", err)
+			os.Exit(1)
+		}
+		if (token == "" && app == nil) || org == "" || number == 0 || column == "" {
+			logger.Error("GitHub token (or GitHub App), owner, project and column must be specified in github-project poller mode")
+			os.Exit(1)
+		}
+		projectPoller := orchestrator.NewGitHubProjectPoller(token, org, number, column)
+		if app != nil {
+			app.Authorize(projectPoller.Client)
+		}
+		poller = projectPoller
+		logger.Info("Using GitHub project poller", "org", org, "project", number, "column", column)
+	case "discord":
+		token := viper.GetString("orchestrator.discord_token")
+		if token == "" {
+			logger.Error("DISCORD_BOT_TOKEN must be set in discord poller mode")
+			os.Exit(1)
+		}
+		discordPoller := orchestrator.NewDiscordPoller(token, viper.GetString("orchestrator.discord_repo_url"))
+		discordPoller.Allow = orchestrator.DiscordAllowlist{
+			Guilds:   cmdutils.GetStringList("orchestrator.discord_guilds"),
+			Channels: cmdutils.GetStringList("orchestrator.discord_channels"),
+			Users:    cmdutils.GetStringList("orchestrator.discord_users"),
+			Roles:    cmdutils.GetStringList("orchestrator.discord_roles"),
+			Repos:    cmdutils.GetStringList("orchestrator.discord_repos"),
+		}
+		if len(discordPoller.Allow.Users) == 0 && len(discordPoller.Allow.Roles) == 0 {
+			logger.Error("--discord-users or --discord-roles must be set in discord poller mode")
+			os.Exit(1)
+		}
+		go func() {
+			if err := discordPoller.Listen(ctx, logger); err != nil && ctx.Err() == nil {
+				logger.Error("Discord listener stopped", "error", err)
+			}
+		}()
+		poller = discordPoller
+		logger.Info("Using Discord poller", "command", "/"+orchestrator.DiscordCommandName, "default_repo", discordPoller.DefaultRepoURL)
+	case "mock":
+		poller = orchestrator.NewMockPoller(orchestrator.MockWorkItems(viper.GetInt("orchestrator.mock_items")))
+		logger.Info("Using mock poller", "items", viper.GetInt("orchestrator.mock_items"))
 	default:
 		// Default to Jira
 		jClient, err := cmdutils.GetJiraClient(ctx) // Use shared cmdutils
@@ -157,11 +281,13 @@ func main() {
 			os.Exit(1)
 		}
 		jql := viper.GetString("orchestrator.jira_query")
-		if jql == "" && label != "" {
-			jql = fmt.Sprintf("labels = \"%s\" AND statusCategory != Done ORDER BY created ASC", label)
+		if jql == "" {
+			jql = orchestrator.BuildJQL(label, cmdutils.GetStringList("orchestrator.jira_exclude_types"), cmdutils.GetStringList("orchestrator.jira_statuses"))
 		}
-		poller = orchestrator.NewJiraPoller(jClient, jql)
-		logger.Info("Using Jira poller", "label", label, "query", jql)
+		jiraPoller := orchestrator.NewJiraPoller(jClient, jql)
+		jiraPoller.MaxItems = viper.GetInt("orchestrator.max_items")
+		poller = jiraPoller
+		logger.Info("Using Jira poller", "label", label, "query", jql, "max_items", jiraPoller.MaxItems)
 	}
 
 	// 2. Spawner
@@ -169,17 +295,46 @@ func main() {
 	var err error
 	agentModel := viper.GetString("orchestrator.agent_model")
 
+	// Provider preflight: surface bad credentials or an unknown model before spawning anything.
+	// Mock mode never runs an agent, so there is nothing to check.
+	if viper.GetBool("orchestrator.provider_health") && mode != "mock" {
+		healthAgent, err := cmdutils.GetAgentClient(ctx, agentProvider, agentModel, "", "recac-orchestrator")
+		if err != nil {
+			logger.Error("Failed to initialize agent for provider health check", "error", err)
+			os.Exit(1)
+		}
+		if err := agent.Ping(ctx, healthAgent); err != nil {
+			logger.Error("Provider health check failed", "provider", agentProvider, "model", agentModel, "error", err)
+			os.Exit(1)
+		}
+		logger.Info("Provider health check passed", "provider", agentProvider, "model", agentModel)
+	}
+
+	// Agents get installation tokens minted here; the App's private key stays on this host
+	githubApp, err := cmdutils.GetGitHubApp()
+	if err != nil {
+		logger.Error("Failed to load GitHub App", "error", err)
+		os.Exit(1)
+	}
+
 	switch mode {
 	case "k8s", "kubernetes":
 		pullPolicy := corev1.PullPolicy(viper.GetString("orchestrator.image_pull_policy"))
 		if pullPolicy == "" {
 			pullPolicy = corev1.PullAlways
 		}
-		spawner, err = orchestrator.NewK8sSpawner(logger, image, namespace, agentProvider, agentModel, pullPolicy)
+		var k8sSpawner *orchestrator.K8sSpawner
+		k8sSpawner, err = orchestrator.NewK8sSpawner(logger, image, namespace, agentProvider, agentModel, pullPolicy)
 		if err != nil {
 			logger.Error("Failed to initialize K8s spawner", "error", err)
 			os.Exit(1)
 		}
+		k8sSpawner.RegistryAuth = cmdutils.GetRegistryAuth()
+		k8sSpawner.ImagePullSecret = viper.GetString("registry.pull_secret")
+		if githubApp != nil {
+			k8sSpawner.GitHubToken = githubApp
+		}
+		spawner = k8sSpawner
 	case "local", "docker":
 		projectName := "recac-orchestrator" // Or similar
 		dockerCli, err := docker.NewClient(projectName)
@@ -187,6 +342,7 @@ func main() {
 			logger.Error("Failed to initialize Docker client", "error", err)
 			os.Exit(1)
 		}
+		dockerCli.RegistryAuth = cmdutils.GetRegistryAuth()
 
 		sm, err := runner.NewSessionManager()
 		if err != nil {
@@ -194,14 +350,29 @@ func main() {
 			os.Exit(1)
 		}
 
-		spawner = orchestrator.NewDockerSpawner(logger, dockerCli, image, projectName, poller, agentProvider, agentModel, sm)
+		dockerSpawner := orchestrator.NewDockerSpawner(logger, dockerCli, image, projectName, poller, agentProvider, agentModel, sm)
+		if githubApp != nil {
+			dockerSpawner.GitHubToken = githubApp
+		}
+		spawner = dockerSpawner
+	case "mock":
+		mockSpawner := orchestrator.NewMockSpawner()
+		mockSpawner.Logger = logger
+		spawner = mockSpawner
+		logger.Info("Using mock spawner, no agents will be launched")
 	default:
-		logger.Error("Invalid mode. Use 'local' or 'k8s'", "mode", mode)
+		logger.Error("Invalid mode. Use 'local', 'k8s', or 'mock'", "mode", mode)
 		os.Exit(1)
 	}
 
 	// 3. Orchestrator
 	orch := orchestrator.New(poller, spawner, interval)
+	orch.IntervalJitter = viper.GetFloat64("orchestrator.interval_jitter")
+	orch.Once = viper.GetBool("orchestrator.once")
+	if orch.Once && (mode == "local" || mode == "docker") {
+		// Docker agents run inside this process; k8s Jobs outlive it
+		logger.Warn("--once in local mode exits after spawning; agent runs are stopped with the orchestrator. Use k8s mode for cron-driven setups.")
+	}
 	if err := orch.Run(ctx, logger); err != nil {
 		if ctx.Err() != nil {
 			// Graceful shutdown
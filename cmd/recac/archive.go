@@ -10,26 +10,57 @@ import (
 var archiveCmd = &cobra.Command{
 	Use:   "archive [SESSION_NAME]...",
 	Short: "Archive one or more sessions",
-	Long:  `Archive one or more sessions. This moves the session's state and log files to a separate directory, hiding it from the main list.`,
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Archive one or more sessions. This moves the session's state and log files to a separate directory, hiding it from the main list.
+
+Use --all-completed instead of names to archive every completed session, optionally
+narrowed with --older-than and --tag.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		allCompleted, _ := cmd.Flags().GetBool("all-completed")
+		if allCompleted && len(args) > 0 {
+			return fmt.Errorf("cannot combine session names with --all-completed")
+		}
+		if !allCompleted && len(args) == 0 {
+			return fmt.Errorf("requires at least 1 session name or --all-completed")
+		}
+
 		sm, err := sessionManagerFactory()
 		if err != nil {
 			return fmt.Errorf("failed to create session manager: %w", err)
 		}
 
-		var errorMessages []string
-		for _, sessionName := range args {
+		names := args
+		if allCompleted {
+			filter, _, err := sessionFilterFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			filter.Status = "completed"
+			names, err = selectSessions(sm, filter)
+			if err != nil {
+				return err
+			}
+			if len(names) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No matching sessions.")
+				return nil
+			}
+		}
+
+		result := &bulkResult{}
+		for _, sessionName := range names {
 			err := sm.ArchiveSession(sessionName)
 			if err != nil {
-				errorMessages = append(errorMessages, fmt.Sprintf("Failed to archive session '%s': %s", sessionName, err.Error()))
+				result.errors = append(result.errors, fmt.Sprintf("Failed to archive session '%s': %s", sessionName, err.Error()))
 				continue
 			}
 			fmt.Fprintf(cmd.OutOrStdout(), "Archived session '%s'\n", sessionName)
+			result.acted++
 		}
 
-		if len(errorMessages) > 0 {
-			return fmt.Errorf("encountered errors:\n- %s", strings.Join(errorMessages, "\n- "))
+		if allCompleted {
+			return result.finish(cmd, "Archived")
+		}
+		if len(result.errors) > 0 {
+			return fmt.Errorf("encountered errors:\n- %s", strings.Join(result.errors, "\n- "))
 		}
 
 		return nil
@@ -37,5 +68,8 @@ var archiveCmd = &cobra.Command{
 }
 
 func init() {
+	archiveCmd.Flags().Bool("all-completed", false, "Archive all completed sessions")
+	archiveCmd.Flags().String("older-than", "", "With --all-completed, only archive sessions inactive for longer than a duration (e.g., '7d')")
+	archiveCmd.Flags().String("tag", "", "With --all-completed, only archive sessions with this tag")
 	rootCmd.AddCommand(archiveCmd)
 }
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"recac/internal/runner"
+	"recac/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// sessionFilter selects local sessions for bulk operations, using the same
+// rules as the corresponding 'ps' filters.
+type sessionFilter struct {
+	Status    string
+	OlderThan time.Duration
+	Tag       string
+}
+
+// addSessionFilterFlags registers the --status, --older-than and --tag flags.
+func addSessionFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("status", "", "Only act on sessions with this status (e.g., 'running', 'completed', 'error')")
+	cmd.Flags().String("older-than", "", "Only act on sessions inactive for longer than a duration (e.g., '3d', '24h')")
+	cmd.Flags().String("tag", "", "Only act on sessions with this tag")
+}
+
+// sessionFilterFromFlags reads the filter flags registered by addSessionFilterFlags.
+// The returned bool reports whether any filter was set.
+func sessionFilterFromFlags(cmd *cobra.Command) (sessionFilter, bool, error) {
+	status, _ := cmd.Flags().GetString("status")
+	olderThan, _ := cmd.Flags().GetString("older-than")
+	tag, _ := cmd.Flags().GetString("tag")
+
+	f := sessionFilter{Status: status, Tag: tag}
+	if olderThan != "" {
+		duration, err := utils.ParseStaleDuration(olderThan)
+		if err != nil {
+			return f, false, fmt.Errorf("invalid duration format for --older-than: %w", err)
+		}
+		f.OlderThan = duration
+	}
+	return f, status != "" || olderThan != "" || tag != "", nil
+}
+
+// matches reports whether s passes every filter that is set.
+func (f sessionFilter) matches(s *runner.SessionState, now time.Time) bool {
+	if f.Status != "" && !strings.EqualFold(s.Status, f.Status) {
+		return false
+	}
+	if f.Tag != "" && !hasTag(s.Tags, f.Tag) {
+		return false
+	}
+	if f.OlderThan > 0 && !isStale(sessionActivity(s), now.Add(-f.OlderThan)) {
+		return false
+	}
+	return true
+}
+
+// selectSessions returns the names of the local sessions matching f, sorted by name.
+func selectSessions(sm ISessionManager, f sessionFilter) ([]string, error) {
+	sessions, err := sm.ListSessions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	now := time.Now()
+	var names []string
+	for _, s := range sessions {
+		if f.matches(s, now) {
+			names = append(names, s.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// bulkResult tracks the outcome of a bulk session operation.
+type bulkResult struct {
+	acted   int
+	skipped int
+	errors  []string
+}
+
+// finish prints a one-line summary and returns an error if any session failed.
+func (r *bulkResult) finish(cmd *cobra.Command, verb string) error {
+	summary := fmt.Sprintf("\n%s %d session(s)", verb, r.acted)
+	if r.skipped > 0 {
+		summary += fmt.Sprintf(", skipped %d", r.skipped)
+	}
+	if len(r.errors) > 0 {
+		summary += fmt.Sprintf(", %d failed", len(r.errors))
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), summary+".")
+
+	if len(r.errors) > 0 {
+		return fmt.Errorf("encountered errors:\n- %s", strings.Join(r.errors, "\n- "))
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"recac/internal/runner"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBulkMockSessions(t *testing.T) *MockSessionManager {
+	mockSM := NewMockSessionManager()
+	now := time.Now()
+	mockSM.Sessions["run-a"] = &runner.SessionState{Name: "run-a", Status: "running", PID: 101, StartTime: now, Tags: []string{"experiment"}}
+	mockSM.Sessions["run-b"] = &runner.SessionState{Name: "run-b", Status: "running", PID: 102, StartTime: now}
+	mockSM.Sessions["done-old"] = &runner.SessionState{Name: "done-old", Status: "completed", StartTime: now.Add(-10 * 24 * time.Hour), EndTime: now.Add(-5 * 24 * time.Hour)}
+	mockSM.Sessions["done-new"] = &runner.SessionState{Name: "done-new", Status: "completed", StartTime: now.Add(-time.Hour), EndTime: now}
+	mockSM.Sessions["failed"] = &runner.SessionState{Name: "failed", Status: "error", StartTime: now.Add(-10 * 24 * time.Hour)}
+
+	origFactory := sessionManagerFactory
+	sessionManagerFactory = func() (ISessionManager, error) { return mockSM, nil }
+	t.Cleanup(func() { sessionManagerFactory = origFactory })
+	return mockSM
+}
+
+func TestStopCmd_Bulk(t *testing.T) {
+	t.Run("stop all running sessions", func(t *testing.T) {
+		mockSM := setupBulkMockSessions(t)
+		output, err := executeCommand(rootCmd, "stop", "--all")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Session 'run-a' stopped successfully")
+		assert.Contains(t, output, "Session 'run-b' stopped successfully")
+		assert.Contains(t, output, "Stopped 2 session(s).")
+		assert.Equal(t, "stopped", mockSM.Sessions["run-a"].Status)
+		assert.Equal(t, "completed", mockSM.Sessions["done-new"].Status)
+	})
+
+	t.Run("stop by tag", func(t *testing.T) {
+		mockSM := setupBulkMockSessions(t)
+		output, err := executeCommand(rootCmd, "stop", "--tag", "experiment")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Stopped 1 session(s).")
+		assert.Equal(t, "stopped", mockSM.Sessions["run-a"].Status)
+		assert.Equal(t, "running", mockSM.Sessions["run-b"].Status)
+	})
+
+	t.Run("name and --all are exclusive", func(t *testing.T) {
+		setupBulkMockSessions(t)
+		_, err := executeCommand(rootCmd, "stop", "run-a", "--all")
+		assert.ErrorContains(t, err, "cannot combine")
+	})
+}
+
+func TestRmCmd_Bulk(t *testing.T) {
+	t.Run("remove completed sessions older than 3d", func(t *testing.T) {
+		mockSM := setupBulkMockSessions(t)
+		output, err := executeCommand(rootCmd, "rm", "--status", "completed", "--older-than", "3d")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Removed session 'done-old'")
+		assert.Contains(t, output, "Removed 1 session(s).")
+		assert.NotContains(t, mockSM.Sessions, "done-old")
+		assert.Contains(t, mockSM.Sessions, "done-new")
+		assert.Contains(t, mockSM.Sessions, "failed")
+	})
+
+	t.Run("remove all skips running sessions without --force", func(t *testing.T) {
+		mockSM := setupBulkMockSessions(t)
+		output, err := executeCommand(rootCmd, "rm", "--all")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Skipping running session 'run-a'")
+		assert.Contains(t, output, "Removed 3 session(s), skipped 2.")
+		assert.Len(t, mockSM.Sessions, 2)
+	})
+
+	t.Run("remove all with --force", func(t *testing.T) {
+		mockSM := setupBulkMockSessions(t)
+		output, err := executeCommand(rootCmd, "rm", "--all", "--force")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Removed 5 session(s).")
+		assert.Empty(t, mockSM.Sessions)
+	})
+
+	t.Run("no matching sessions", func(t *testing.T) {
+		setupBulkMockSessions(t)
+		output, err := executeCommand(rootCmd, "rm", "--tag", "missing")
+		require.NoError(t, err)
+		assert.Contains(t, output, "No matching sessions.")
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		setupBulkMockSessions(t)
+		_, err := executeCommand(rootCmd, "rm", "--older-than", "soon")
+		assert.ErrorContains(t, err, "invalid duration format")
+	})
+}
+
+func TestArchiveCmd_AllCompleted(t *testing.T) {
+	sm, cleanup := setupTestSessionManager(t)
+	defer cleanup()
+
+	for _, s := range []*runner.SessionState{
+		{Name: "archive-done-1", Status: "completed", StartTime: time.Now()},
+		{Name: "archive-done-2", Status: "completed", StartTime: time.Now()},
+		{Name: "archive-failed", Status: "error", StartTime: time.Now()},
+	} {
+		s.LogFile = filepath.Join(sm.SessionsDir(), s.Name+".log")
+		require.NoError(t, os.WriteFile(s.LogFile, []byte("log"), 0644))
+		require.NoError(t, sm.SaveSession(s))
+	}
+
+	output, err := executeCommand(rootCmd, "archive", "--all-completed")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Archived session 'archive-done-1'")
+	assert.Contains(t, output, "Archived session 'archive-done-2'")
+	assert.Contains(t, output, "Archived 2 session(s).")
+
+	archived, err := sm.ListArchivedSessions()
+	require.NoError(t, err)
+	assert.Len(t, archived, 2)
+	_, err = sm.LoadSession("archive-failed")
+	assert.NoError(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -51,20 +52,30 @@ var defaultPersonas = map[string]Persona{
 	},
 }
 
-var chatPersona string
+var (
+	chatPersona string
+	chatPrompt  string
+)
 
 var chatCmd = &cobra.Command{
 	Use:   "chat",
 	Short: "Interactive chat with the AI agent",
 	Long: `Start an interactive chat session with the AI agent.
 You can choose a specific persona to roleplay different stakeholders.
-Type '/help' during the chat for available commands.`,
+Type '/help' during the chat for available commands.
+
+With --prompt, the prompt is sent once, the response is streamed to stdout and the
+command exits, so it can be used from scripts and pipes. Use --prompt - to read the
+prompt from stdin:
+
+  git diff | recac chat --prompt - --persona security`,
 	RunE: runChat,
 }
 
 func init() {
 	rootCmd.AddCommand(chatCmd)
 	chatCmd.Flags().StringVarP(&chatPersona, "persona", "p", "default", "Initial persona (default, security, product, junior, skeptic, teacher)")
+	chatCmd.Flags().StringVar(&chatPrompt, "prompt", "", "Send a single prompt, print the response and exit (\"-\" reads the prompt from stdin)")
 }
 
 type ChatSession struct {
@@ -87,6 +98,10 @@ func runChat(cmd *cobra.Command, args []string) error {
 		ContextFiles:   make(map[string]string),
 	}
 
+	if chatPrompt != "" {
+		return runChatOnce(cmd, session, chatPrompt)
+	}
+
 	// Print Welcome
 	fmt.Fprintln(cmd.OutOrStdout(), "💬 RECAC Chat Session Started")
 	fmt.Fprintf(cmd.OutOrStdout(), "👤 Persona: %s - %s\n", p.Name, p.Description)
@@ -156,6 +171,37 @@ func runChat(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runChatOnce sends a single prompt and streams the response to stdout, with
+// none of the interactive session's banners.
+func runChatOnce(cmd *cobra.Command, session *ChatSession, input string) error {
+	if input == "-" {
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("failed to read prompt from stdin: %w", err)
+		}
+		input = string(data)
+	}
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return fmt.Errorf("prompt is empty")
+	}
+
+	ctx := context.Background()
+	cwd, _ := os.Getwd()
+	ag, err := agentClientFactory(ctx, viper.GetString("provider"), viper.GetString("model"), cwd, "recac-chat")
+	if err != nil {
+		return fmt.Errorf("failed to create agent: %w", err)
+	}
+
+	if _, err := ag.SendStream(ctx, buildChatPrompt(session, input), func(chunk string) {
+		fmt.Fprint(cmd.OutOrStdout(), chunk)
+	}); err != nil {
+		return fmt.Errorf("agent request failed: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout())
+	return nil
+}
+
 func handleChatCommand(cmd *cobra.Command, session *ChatSession, input string) bool {
 	parts := strings.Fields(input)
 	command := parts[0]
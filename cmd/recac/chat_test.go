@@ -149,3 +149,77 @@ func TestRunChat_Integration(t *testing.T) {
 		t.Error("Missing persona switch message")
 	}
 }
+
+// ChatMockAgent records the prompt it was sent.
+type ChatMockAgent struct {
+	CapturedPrompt string
+	Response       string
+}
+
+func (m *ChatMockAgent) Send(ctx context.Context, prompt string) (string, error) {
+	m.CapturedPrompt = prompt
+	return m.Response, nil
+}
+
+func (m *ChatMockAgent) SendStream(ctx context.Context, prompt string, onChunk func(string)) (string, error) {
+	m.CapturedPrompt = prompt
+	onChunk(m.Response)
+	return m.Response, nil
+}
+
+func TestRunChat_Prompt(t *testing.T) {
+	origFactory := agentClientFactory
+	defer func() { agentClientFactory = origFactory }()
+	defer func() { chatPrompt = "" }()
+
+	mockAgent := &ChatMockAgent{Response: "One-shot answer"}
+	agentClientFactory = func(ctx context.Context, provider, model, projectPath, projectName string) (agent.Agent, error) {
+		return mockAgent, nil
+	}
+
+	cmd := chatCmd
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	chatPrompt = "What is 2+2?"
+	if err := runChat(cmd, []string{}); err != nil {
+		t.Fatalf("runChat failed: %v", err)
+	}
+
+	if out.String() != "One-shot answer\n" {
+		t.Errorf("Expected only the response on stdout, got %q", out.String())
+	}
+	if !strings.Contains(mockAgent.CapturedPrompt, "User: What is 2+2?") {
+		t.Errorf("Prompt not sent to agent, got %q", mockAgent.CapturedPrompt)
+	}
+}
+
+func TestRunChat_PromptFromStdin(t *testing.T) {
+	origFactory := agentClientFactory
+	defer func() { agentClientFactory = origFactory }()
+	defer func() { chatPrompt = "" }()
+
+	mockAgent := &ChatMockAgent{Response: "Looks safe"}
+	agentClientFactory = func(ctx context.Context, provider, model, projectPath, projectName string) (agent.Agent, error) {
+		return mockAgent, nil
+	}
+
+	cmd := chatCmd
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(strings.NewReader("Review this diff\n"))
+
+	chatPrompt = "-"
+	if err := runChat(cmd, []string{}); err != nil {
+		t.Fatalf("runChat failed: %v", err)
+	}
+	if !strings.Contains(mockAgent.CapturedPrompt, "User: Review this diff") {
+		t.Errorf("Stdin prompt not sent to agent, got %q", mockAgent.CapturedPrompt)
+	}
+
+	// An empty prompt is an error rather than a request
+	cmd.SetIn(strings.NewReader("  \n"))
+	if err := runChat(cmd, []string{}); err == nil {
+		t.Error("Expected an error for an empty prompt")
+	}
+}
@@ -4,58 +4,251 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"recac/internal/runner"
+	"recac/internal/utils"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	cleanOlderThan  string
+	cleanArchived   bool
+	cleanWorkspaces bool
+	cleanDryRun     bool
+)
+
+// workspacePrefixes are the temp directory prefixes used for agent workspaces.
+var workspacePrefixes = []string{"recac-agent-", "recac-direct-", "recac-jira-"}
+
+// cleanTempDir is the directory scanned for orphaned workspaces (mockable).
+var cleanTempDir = os.TempDir
+
 func init() {
+	cleanCmd.Flags().StringVar(&cleanOlderThan, "older-than", "", "Remove sessions inactive for longer than a duration (e.g., 7d, 24h)")
+	cleanCmd.Flags().BoolVar(&cleanArchived, "archived", false, "Also remove archived sessions (requires --older-than)")
+	cleanCmd.Flags().BoolVar(&cleanWorkspaces, "workspaces", false, "Also remove orphaned temp workspaces (requires --older-than)")
+	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "Show what would be removed without deleting anything")
 	rootCmd.AddCommand(cleanCmd)
 }
 
 var cleanCmd = &cobra.Command{
 	Use:   "clean",
-	Short: "Remove temporary files created during the session",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Cleaning up temporary files...")
+	Short: "Remove temporary files, stale sessions and orphaned workspaces",
+	Long: `Without flags, clean removes the temporary files recorded in temp_files.txt,
+except those matching .recacignore (gitignore syntax).
+
+With --older-than, clean also removes session state and logs for sessions that have
+been inactive for longer than the duration (the same staleness rule as 'ps --stale').
+Running sessions are never removed.
+Use --archived to include archived sessions, --workspaces to remove orphaned temp
+workspaces (recac-agent-*, recac-direct-*, recac-jira-*), and --dry-run to preview.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cleanOlderThan == "" {
+			if cleanArchived || cleanWorkspaces {
+				return fmt.Errorf("--archived and --workspaces require --older-than")
+			}
+			cleanTempFiles()
+			return nil
+		}
 
-		tempFilesPath := "temp_files.txt"
-		lines, err := readLines(tempFilesPath)
+		duration, err := utils.ParseStaleDuration(cleanOlderThan)
+		if err != nil {
+			return fmt.Errorf("invalid duration format for --older-than: %w", err)
+		}
+		return cleanStale(cmd, time.Now().Add(-duration))
+	},
+}
+
+// cleanTempFiles removes the files listed in temp_files.txt.
+func cleanTempFiles() {
+	fmt.Println("Cleaning up temporary files...")
+
+	tempFilesPath := "temp_files.txt"
+	lines, err := readLines(tempFilesPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Println("No temporary files to clean.")
+			return
+		}
+		fmt.Printf("Error opening %s: %v\n", tempFilesPath, err)
+		return
+	}
+
+	var filesToRemove []string
+	for _, line := range lines {
+		if line != "" {
+			filesToRemove = append(filesToRemove, line)
+		}
+	}
+
+	// Files matching .recacignore are never removed, even when listed
+	ignore, err := utils.LoadIgnore(".")
+	if err != nil {
+		fmt.Printf("Warning: failed to read %s: %v\n", utils.IgnoreFileName, err)
+		ignore = &utils.IgnoreMatcher{}
+	}
+	cwd, _ := os.Getwd()
+
+	for _, f := range filesToRemove {
+		absPath, err := filepath.Abs(f)
+		if err != nil {
+			fmt.Printf("Error resolving path %s: %v\n", f, err)
+			continue
+		}
+		if rel, err := filepath.Rel(cwd, absPath); err == nil && !strings.HasPrefix(rel, "..") {
+			info, statErr := os.Lstat(absPath)
+			if ignore.Match(rel, statErr == nil && info.IsDir()) {
+				fmt.Printf("Kept %s (protected by %s)\n", absPath, utils.IgnoreFileName)
+				continue
+			}
+		}
+		err = os.Remove(absPath)
 		if err != nil {
 			if os.IsNotExist(err) {
-				fmt.Println("No temporary files to clean.")
-				return
+				fmt.Printf("File %s already gone.\n", absPath)
+			} else {
+				fmt.Printf("Error removing %s: %v\n", absPath, err)
 			}
-			fmt.Printf("Error opening %s: %v\n", tempFilesPath, err)
-			return
+		} else {
+			fmt.Printf("Removed %s\n", absPath)
+		}
+	}
+
+	// Optionally remove the temp_files.txt itself or truncate it
+	os.Remove(tempFilesPath)
+	fmt.Println("Cleanup complete.")
+}
+
+// cleanStale removes sessions (and optionally archived sessions and orphaned
+// workspaces) whose last activity is before cutoff.
+func cleanStale(cmd *cobra.Command, cutoff time.Time) error {
+	sm, err := sessionManagerFactory()
+	if err != nil {
+		return fmt.Errorf("failed to create session manager: %w", err)
+	}
+
+	sessions, err := sm.ListSessions()
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	verb := "Removed"
+	if cleanDryRun {
+		verb = "Would remove"
+	}
+
+	removed := 0
+	referenced := make(map[string]bool)
+	for _, s := range sessions {
+		running := s.Status == "running" || (s.PID > 0 && sm.IsProcessRunning(s.PID))
+		if running || !isStale(sessionActivity(s), cutoff) {
+			if s.Workspace != "" {
+				referenced[filepath.Clean(s.Workspace)] = true
+			}
+			continue
 		}
 
-		var filesToRemove []string
-		for _, line := range lines {
-			if line != "" {
-				filesToRemove = append(filesToRemove, line)
+		if !cleanDryRun {
+			if err := sm.RemoveSession(s.Name, false); err != nil {
+				cmd.PrintErrf("Failed to remove session %s: %v\n", s.Name, err)
+				continue
 			}
 		}
+		cmd.Printf("%s session: %s (status: %s)\n", verb, s.Name, s.Status)
+		removed++
+	}
 
-		for _, f := range filesToRemove {
-			absPath, err := filepath.Abs(f)
-			if err != nil {
-				fmt.Printf("Error resolving path %s: %v\n", f, err)
+	if cleanArchived {
+		archived, err := sm.ListArchivedSessions()
+		if err != nil {
+			return fmt.Errorf("failed to list archived sessions: %w", err)
+		}
+		for _, s := range archived {
+			if !isStale(sessionActivity(s), cutoff) {
 				continue
 			}
-			err = os.Remove(absPath)
-			if err != nil {
-				if os.IsNotExist(err) {
-					fmt.Printf("File %s already gone.\n", absPath)
-				} else {
-					fmt.Printf("Error removing %s: %v\n", absPath, err)
+			if !cleanDryRun {
+				if err := sm.RemoveArchivedSession(s.Name); err != nil {
+					cmd.PrintErrf("Failed to remove archived session %s: %v\n", s.Name, err)
+					continue
 				}
-			} else {
-				fmt.Printf("Removed %s\n", absPath)
 			}
+			cmd.Printf("%s archived session: %s\n", verb, s.Name)
+			removed++
 		}
+	}
 
-		// Optionally remove the temp_files.txt itself or truncate it
-		os.Remove(tempFilesPath)
-		fmt.Println("Cleanup complete.")
-	},
+	if cleanWorkspaces {
+		for _, dir := range orphanedWorkspaces(referenced, cutoff) {
+			if !cleanDryRun {
+				if err := os.RemoveAll(dir); err != nil {
+					cmd.PrintErrf("Failed to remove workspace %s: %v\n", dir, err)
+					continue
+				}
+			}
+			cmd.Printf("%s workspace: %s\n", verb, dir)
+			removed++
+		}
+	}
+
+	if removed == 0 {
+		cmd.Println("Nothing to clean.")
+		return nil
+	}
+	if cleanDryRun {
+		cmd.Printf("\nDry run: %d item(s) would be removed.\n", removed)
+	} else {
+		cmd.Printf("\nRemoved %d item(s).\n", removed)
+	}
+	return nil
+}
+
+// sessionActivity returns the last known activity time of a local session:
+// the agent's last interaction, falling back to the end and then start time.
+func sessionActivity(s *runner.SessionState) time.Time {
+	if state, err := loadAgentState(s.AgentStateFile); err == nil && !state.LastActivity.IsZero() {
+		return state.LastActivity
+	}
+	if !s.EndTime.IsZero() {
+		return s.EndTime
+	}
+	return s.StartTime
+}
+
+// orphanedWorkspaces lists temp workspaces not referenced by any remaining
+// session and last modified before cutoff.
+func orphanedWorkspaces(referenced map[string]bool, cutoff time.Time) []string {
+	tempDir := cleanTempDir()
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		return nil
+	}
+
+	var orphans []string
+	for _, entry := range entries {
+		if !entry.IsDir() || !hasWorkspacePrefix(entry.Name()) {
+			continue
+		}
+		path := filepath.Join(tempDir, entry.Name())
+		if referenced[filepath.Clean(path)] {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !isStale(info.ModTime(), cutoff) {
+			continue
+		}
+		orphans = append(orphans, path)
+	}
+	return orphans
+}
+
+func hasWorkspacePrefix(name string) bool {
+	for _, prefix := range workspacePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"recac/internal/runner"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanCommand_OlderThan(t *testing.T) {
+	sm, cleanup := setupTestSessionManager(t)
+	defer cleanup()
+
+	tempDir := t.TempDir()
+	origTempDir := cleanTempDir
+	cleanTempDir = func() string { return tempDir }
+	defer func() { cleanTempDir = origTempDir }()
+
+	now := time.Now()
+	oldWorkspace := filepath.Join(tempDir, "recac-agent-old-1")
+	keptWorkspace := filepath.Join(tempDir, "recac-agent-kept-1")
+	orphanWorkspace := filepath.Join(tempDir, "recac-direct-orphan")
+	unrelatedDir := filepath.Join(tempDir, "other-tool-cache")
+	for _, dir := range []string{oldWorkspace, keptWorkspace, orphanWorkspace, unrelatedDir} {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.Chtimes(dir, now.Add(-10*24*time.Hour), now.Add(-10*24*time.Hour)))
+	}
+
+	sessions := []*runner.SessionState{
+		{Name: "running-old", Status: "running", StartTime: now.Add(-30 * 24 * time.Hour), PID: os.Getpid()},
+		{Name: "completed-old", Status: "completed", StartTime: now.Add(-10 * 24 * time.Hour), EndTime: now.Add(-9 * 24 * time.Hour), Workspace: oldWorkspace},
+		{Name: "completed-recent", Status: "completed", StartTime: now.Add(-2 * time.Hour), Workspace: keptWorkspace},
+		{Name: "archived-old", Status: "completed", StartTime: now.Add(-20 * 24 * time.Hour)},
+	}
+	save := func() {
+		for _, s := range sessions {
+			s.LogFile = filepath.Join(sm.SessionsDir(), s.Name+".log")
+			require.NoError(t, os.WriteFile(s.LogFile, []byte("log"), 0644))
+			require.NoError(t, sm.SaveSession(s))
+		}
+		require.NoError(t, sm.ArchiveSession("archived-old"))
+	}
+	save()
+
+	// Dry run removes nothing
+	output, err := executeCommand(rootCmd, "clean", "--older-than", "7d", "--archived", "--workspaces", "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Would remove session: completed-old")
+	assert.Contains(t, output, "Would remove archived session: archived-old")
+	assert.Contains(t, output, "Would remove workspace: "+oldWorkspace)
+	assert.Contains(t, output, "Would remove workspace: "+orphanWorkspace)
+	assert.NotContains(t, output, keptWorkspace)
+	assert.NotContains(t, output, unrelatedDir)
+	_, err = sm.LoadSession("completed-old")
+	assert.NoError(t, err)
+
+	output, err = executeCommand(rootCmd, "clean", "--older-than", "7d", "--archived", "--workspaces")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Removed 4 item(s).")
+
+	_, err = sm.LoadSession("completed-old")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = sm.LoadSession("running-old")
+	assert.NoError(t, err, "running sessions are never cleaned")
+	_, err = sm.LoadSession("completed-recent")
+	assert.NoError(t, err)
+	archived, err := sm.ListArchivedSessions()
+	require.NoError(t, err)
+	assert.Empty(t, archived)
+
+	for _, dir := range []string{oldWorkspace, orphanWorkspace} {
+		_, err := os.Stat(dir)
+		assert.True(t, os.IsNotExist(err), "expected %s to be removed", dir)
+	}
+	for _, dir := range []string{keptWorkspace, unrelatedDir} {
+		_, err := os.Stat(dir)
+		assert.NoError(t, err, "expected %s to be kept", dir)
+	}
+}
+
+func TestCleanCommand_FlagValidation(t *testing.T) {
+	_, err := executeCommand(rootCmd, "clean", "--workspaces")
+	assert.ErrorContains(t, err, "require --older-than")
+
+	_, err = executeCommand(rootCmd, "clean", "--older-than", "soon")
+	assert.ErrorContains(t, err, "invalid duration format")
+}
@@ -247,6 +247,25 @@ func TestCommands(t *testing.T) {
 
 	})
 
+	t.Run("Clean Command Honors recacignore", func(t *testing.T) {
+		os.Chdir(t.TempDir())
+		os.WriteFile(".recacignore", []byte("keep.txt\n"), 0644)
+		os.WriteFile("temp_files.txt", []byte("keep.txt\ndummy.txt"), 0644)
+		os.WriteFile("keep.txt", []byte("content"), 0644)
+		os.WriteFile("dummy.txt", []byte("content"), 0644)
+
+		_, err := executeCommand(rootCmd, "clean")
+		if err != nil {
+			t.Errorf("Clean failed: %v", err)
+		}
+		if _, err := os.Stat("keep.txt"); err != nil {
+			t.Error("Clean removed a file protected by .recacignore")
+		}
+		if _, err := os.Stat("dummy.txt"); !os.IsNotExist(err) {
+			t.Error("Clean failed to remove file")
+		}
+	})
+
 	t.Run("Version Command", func(t *testing.T) {
 
 		executeCommand(rootCmd, "version")
@@ -100,6 +100,18 @@ func (m *MockGitClientCommit) CreatePR(directory, title, body, base string) (str
 	return "", nil
 }
 
+func (m *MockGitClientCommit) AddWorktree(directory, path, branch string) error {
+	return nil
+}
+
+func (m *MockGitClientCommit) RemoveWorktree(directory, path string) error {
+	return nil
+}
+
+func (m *MockGitClientCommit) DefaultBranch(directory string) (string, error) {
+	return "main", nil
+}
+
 func TestCommitCmd(t *testing.T) {
 	// Setup mocks
 	origGitFactory := gitClientFactory
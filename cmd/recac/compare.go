@@ -115,8 +115,8 @@ func displayComparison(cmd *cobra.Command, sA, sB *runner.SessionState, stA, stB
 	fmt.Fprintf(w, "Tokens (Resp)\t%d\t%d\n", stA.TokenUsage.TotalResponseTokens, stB.TokenUsage.TotalResponseTokens)
 
 	// Cost
-	costA := agent.CalculateCost(stA.Model, stA.TokenUsage)
-	costB := agent.CalculateCost(stB.Model, stB.TokenUsage)
+	costA := agent.CalculateProviderCost(stA.Provider, stA.Model, stA.TokenUsage)
+	costB := agent.CalculateProviderCost(stB.Provider, stB.Model, stB.TokenUsage)
 	fmt.Fprintf(w, "Est. Cost\t$%.4f\t$%.4f\n", costA, costB)
 
 	// Commit Difference
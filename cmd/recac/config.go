@@ -26,5 +26,7 @@ var listModelsCmd = &cobra.Command{
 func init() {
 	configCmd.AddCommand(listKeysCmd)
 	configCmd.AddCommand(listModelsCmd)
+	configCmd.AddCommand(showConfigCmd)
+	configCmd.AddCommand(validateConfigCmd)
 	rootCmd.AddCommand(configCmd)
 }
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"recac/internal/cmdutils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+var showConfigCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the fully resolved configuration",
+	Long: `Print the configuration after merging defaults, the config file, RECAC_* environment
+variables and flags. Secrets are redacted. Use --sources to see where each value came from.`,
+	RunE: showConfig,
+}
+
+var validateConfigCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration for missing or invalid settings",
+	Long: `Check that the selected provider, poller and orchestrator mode are known and that the
+credentials they need are present. Exits with a non-zero status if problems are found.`,
+	RunE: validateConfig,
+}
+
+func init() {
+	showConfigCmd.Flags().Bool("sources", false, "Show where each value was resolved from (flag, env, file, default)")
+}
+
+// showConfig prints the resolved configuration as YAML, or as a KEY/VALUE/SOURCE table with --sources.
+func showConfig(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+
+	if file := viper.ConfigFileUsed(); file != "" {
+		fmt.Fprintf(out, "# Config file: %s\n", file)
+	} else {
+		fmt.Fprintln(out, "# Config file: (none)")
+	}
+
+	if sources, _ := cmd.Flags().GetBool("sources"); sources {
+		keys := viper.AllKeys()
+		sort.Strings(keys)
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		defer w.Flush()
+		fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+		fmt.Fprintln(w, "---\t-----\t------")
+		for _, key := range keys {
+			value := viper.Get(key)
+			if _, nested := value.(map[string]interface{}); nested {
+				// Parent of nested keys; its leaves are listed individually
+				continue
+			}
+			if isSensitive(key) {
+				value = "[REDACTED]"
+			}
+			fmt.Fprintf(w, "%s\t%v\t%s\n", key, value, configSource(cmd, key))
+		}
+		return nil
+	}
+
+	data, err := yaml.Marshal(redactSettings(viper.AllSettings(), ""))
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	fmt.Fprint(out, string(data))
+	return nil
+}
+
+// redactSettings returns a copy of the nested settings map with sensitive leaf values redacted.
+func redactSettings(settings map[string]interface{}, prefix string) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(settings))
+	for k, v := range settings {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			redacted[k] = redactSettings(nested, key)
+			continue
+		}
+		if isSensitive(key) && fmt.Sprint(v) != "" {
+			v = "[REDACTED]"
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+// configSource reports which layer a key's value was resolved from, following viper's precedence.
+func configSource(cmd *cobra.Command, key string) string {
+	if flag := cmd.Root().PersistentFlags().Lookup(key); flag != nil && flag.Changed {
+		return "flag (--" + flag.Name + ")"
+	}
+	envKey := "RECAC_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+	if val, ok := os.LookupEnv(envKey); ok && val != "" {
+		return "env (" + envKey + ")"
+	}
+	if viper.InConfig(key) {
+		return "file"
+	}
+	return "default"
+}
+
+// configProviders maps each supported agent provider to the env var holding its API key
+// ("" for providers that don't need one) and the CLI binary it shells out to, if any.
+var configProviders = map[string]struct {
+	keyEnv string
+	binary string
+}{
+	"gemini":     {keyEnv: "GEMINI_API_KEY"},
+	"openai":     {keyEnv: "OPENAI_API_KEY"},
+	"openrouter": {keyEnv: "OPENROUTER_API_KEY"},
+	"anthropic":  {keyEnv: "ANTHROPIC_API_KEY"},
+	"ollama":     {},
+	"gemini-cli": {binary: "gemini"},
+	"cursor-cli": {binary: "cursor-agent"},
+	"opencode":   {binary: "opencode"},
+}
+
+// validateConfig checks the resolved configuration and reports problems and warnings.
+func validateConfig(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+	problems, warnings := resolveConfigProblems()
+
+	for _, w := range warnings {
+		fmt.Fprintf(out, "WARN  %s\n", w)
+	}
+	for _, p := range problems {
+		fmt.Fprintf(out, "ERROR %s\n", p)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("configuration is invalid: %d problem(s) found", len(problems))
+	}
+	fmt.Fprintln(out, "Configuration is valid.")
+	return nil
+}
+
+// resolveConfigProblems returns blocking problems and non-blocking warnings for the current configuration.
+func resolveConfigProblems() (problems, warnings []string) {
+	// Agent provider
+	provider := viper.GetString("provider")
+	if provider == "" {
+		provider = "gemini"
+	}
+	spec, ok := configProviders[provider]
+	if !ok {
+		problems = append(problems, fmt.Sprintf("unknown provider %q (supported: %s)", provider, strings.Join(knownProviders(), ", ")))
+	} else {
+		if spec.keyEnv != "" && viper.GetString("api_key") == "" && os.Getenv("API_KEY") == "" && os.Getenv(spec.keyEnv) == "" && os.Getenv(spec.keyEnv+"S") == "" {
+			problems = append(problems, fmt.Sprintf("missing API key for provider %q: set api_key in config, API_KEY, %s or %sS", provider, spec.keyEnv, spec.keyEnv))
+		}
+		if spec.binary != "" {
+			if _, err := exec.LookPath(spec.binary); err != nil {
+				warnings = append(warnings, fmt.Sprintf("provider %q requires the %q binary, which was not found in PATH", provider, spec.binary))
+			}
+		}
+	}
+	if viper.GetString("model") == "" {
+		warnings = append(warnings, fmt.Sprintf("no model configured; the %s provider default will be used", provider))
+	}
+
+	// Orchestrator poller
+	switch poller := viper.GetString("orchestrator.poller"); poller {
+	case "", "jira":
+		if viper.GetString("jira.url") == "" && os.Getenv("JIRA_URL") == "" {
+			problems = append(problems, "jira poller: missing Jira URL (jira.url or JIRA_URL)")
+		}
+		if viper.GetString("jira.username") == "" && os.Getenv("JIRA_USERNAME") == "" && os.Getenv("JIRA_EMAIL") == "" {
+			problems = append(problems, "jira poller: missing Jira username (jira.username or JIRA_USERNAME)")
+		}
+		if viper.GetString("jira.api_token") == "" && os.Getenv("JIRA_API_TOKEN") == "" {
+			problems = append(problems, "jira poller: missing Jira API token (jira.api_token or JIRA_API_TOKEN)")
+		}
+	case "github":
+		if viper.GetString("orchestrator.github_token") == "" && os.Getenv("GITHUB_TOKEN") == "" && viper.GetInt64("github.app_id") == 0 {
+			problems = append(problems, "github poller: missing token (orchestrator.github_token, GITHUB_TOKEN or github.app_id)")
+		}
+		if viper.GetString("orchestrator.github_owner") == "" || viper.GetString("orchestrator.github_repo") == "" {
+			problems = append(problems, "github poller: orchestrator.github_owner and orchestrator.github_repo are required")
+		}
+	case "github-project":
+		if viper.GetString("orchestrator.github_token") == "" && os.Getenv("GITHUB_TOKEN") == "" && viper.GetInt64("github.app_id") == 0 {
+			problems = append(problems, "github-project poller: missing token (orchestrator.github_token, GITHUB_TOKEN or github.app_id)")
+		}
+		if viper.GetString("orchestrator.github_owner") == "" {
+			problems = append(problems, "github-project poller: orchestrator.github_owner (the organization) is required")
+		}
+		if viper.GetInt("orchestrator.github_project") == 0 {
+			problems = append(problems, "github-project poller: orchestrator.github_project (the project number) is required")
+		}
+	case "discord":
+		if viper.GetString("orchestrator.discord_token") == "" && os.Getenv("DISCORD_BOT_TOKEN") == "" {
+			problems = append(problems, "discord poller: missing bot token (orchestrator.discord_token or DISCORD_BOT_TOKEN)")
+		}
+		if len(cmdutils.GetStringList("orchestrator.discord_users")) == 0 && len(cmdutils.GetStringList("orchestrator.discord_roles")) == 0 {
+			problems = append(problems, "discord poller: orchestrator.discord_users or orchestrator.discord_roles must allow someone to run /recac")
+		}
+	case "file", "filesystem":
+		workFile := viper.GetString("orchestrator.work_file")
+		if workFile == "" {
+			problems = append(problems, "file poller: orchestrator.work_file is required")
+		} else if _, err := os.Stat(workFile); err != nil {
+			warnings = append(warnings, fmt.Sprintf("file poller: work file %q does not exist yet", workFile))
+		}
+	case "file-dir":
+		if viper.GetString("orchestrator.watch_dir") == "" {
+			problems = append(problems, "file-dir poller: orchestrator.watch_dir is required")
+		}
+		if lifecycle := viper.GetString("orchestrator.file_lifecycle"); lifecycle != "" && lifecycle != "move" && lifecycle != "index" {
+			problems = append(problems, fmt.Sprintf("file-dir poller: orchestrator.file_lifecycle must be 'move' or 'index', got %q", lifecycle))
+		}
+	case "mock":
+	default:
+		problems = append(problems, fmt.Sprintf("unknown poller %q (supported: jira, github, github-project, discord, file, file-dir, mock)", poller))
+	}
+
+	// Orchestrator mode
+	switch mode := viper.GetString("orchestrator.mode"); mode {
+	case "", "local", "docker", "k8s", "kubernetes", "mock":
+	default:
+		problems = append(problems, fmt.Sprintf("unknown orchestrator mode %q (supported: local, k8s, mock)", mode))
+	}
+
+	return problems, warnings
+}
+
+func knownProviders() []string {
+	names := make([]string, 0, len(configProviders))
+	for name := range configProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowConfigCommand(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("provider", "openai")
+	viper.Set("jira.api_token", "super-secret-token")
+	viper.Set("orchestrator.poller", "file")
+
+	output, err := executeCommand(rootCmd, "config", "show")
+	require.NoError(t, err)
+	require.Contains(t, output, "provider: openai")
+	require.Contains(t, output, "poller: file")
+	require.Contains(t, output, "api_token: '[REDACTED]'")
+	require.NotContains(t, output, "super-secret-token")
+
+	t.Setenv("RECAC_MODEL", "gpt-4o")
+	viper.SetEnvPrefix("RECAC")
+	viper.AutomaticEnv()
+	viper.SetDefault("model", "gpt-4")
+
+	output, err = executeCommand(rootCmd, "config", "show", "--sources")
+	require.NoError(t, err)
+	require.Regexp(t, `model\s+gpt-4o\s+env \(RECAC_MODEL\)`, output)
+	require.Regexp(t, `jira\.api_token\s+\[REDACTED\]`, output)
+	require.NotContains(t, output, "super-secret-token")
+}
+
+func TestValidateConfigCommand(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	t.Setenv("API_KEY", "")
+	t.Setenv("OPENAI_API_KEY", "")
+
+	// Missing credentials for the selected provider and poller
+	viper.Set("provider", "openai")
+	viper.Set("model", "gpt-4o")
+	viper.Set("orchestrator.poller", "github")
+	output, err := executeCommand(rootCmd, "config", "validate")
+	require.Error(t, err)
+	require.Contains(t, output, `missing API key for provider "openai"`)
+	require.Contains(t, output, "github poller")
+
+	// The github-project poller needs a token, the organization and the project number
+	t.Setenv("GITHUB_TOKEN", "")
+	viper.Set("orchestrator.poller", "github-project")
+	output, err = executeCommand(rootCmd, "config", "validate")
+	require.Error(t, err)
+	require.Contains(t, output, "github-project poller: missing token")
+	require.Contains(t, output, "orchestrator.github_owner")
+	require.Contains(t, output, "orchestrator.github_project")
+
+	// The discord poller needs a bot token and an allowlist
+	t.Setenv("DISCORD_BOT_TOKEN", "")
+	viper.Set("orchestrator.poller", "discord")
+	output, err = executeCommand(rootCmd, "config", "validate")
+	require.Error(t, err)
+	require.Contains(t, output, "discord poller: missing bot token")
+	require.Contains(t, output, "orchestrator.discord_users or orchestrator.discord_roles")
+
+	viper.Set("orchestrator.discord_token", "bot-token")
+	viper.Set("orchestrator.discord_roles", "123,456")
+	output, _ = executeCommand(rootCmd, "config", "validate")
+	require.NotContains(t, output, "discord poller")
+
+	// Unknown values are reported
+	viper.Set("provider", "nope")
+	viper.Set("orchestrator.poller", "carrier-pigeon")
+	output, err = executeCommand(rootCmd, "config", "validate")
+	require.Error(t, err)
+	require.Contains(t, output, `unknown provider "nope"`)
+	require.Contains(t, output, `unknown poller "carrier-pigeon"`)
+
+	// Valid configuration
+	viper.Set("provider", "openai")
+	viper.Set("api_key", "sk-test")
+	viper.Set("orchestrator.poller", "file-dir")
+	viper.Set("orchestrator.watch_dir", t.TempDir())
+	output, err = executeCommand(rootCmd, "config", "validate")
+	require.NoError(t, err)
+	require.Contains(t, output, "Configuration is valid.")
+
+	// The mock poller and mode need nothing else
+	viper.Set("orchestrator.poller", "mock")
+	viper.Set("orchestrator.mode", "mock")
+	output, err = executeCommand(rootCmd, "config", "validate")
+	require.NoError(t, err)
+	require.Contains(t, output, "Configuration is valid.")
+}
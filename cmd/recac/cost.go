@@ -19,15 +19,22 @@ func init() {
 }
 
 var costCmd = &cobra.Command{
-	Use:   "cost",
+	Use:   "cost [session]",
 	Short: "Analyze and display session costs",
-	Long:  `Provides a detailed breakdown of costs associated with all sessions. Use the --watch flag for a live, real-time monitoring TUI.`,
+	Long: `Provides a detailed breakdown of costs associated with all sessions. Use the --watch flag for a live, real-time monitoring TUI.
+
+With a session name, breaks that session's spend down by role (Coding, Manager, QA) and by iteration.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		sm, err := sessionManagerFactory()
 		if err != nil {
 			return fmt.Errorf("could not create session manager: %w", err)
 		}
 
+		if len(args) == 1 {
+			return showSessionCostBreakdown(cmd, sm, args[0])
+		}
+
 		watch, _ := cmd.Flags().GetBool("watch")
 		if watch {
 			// Inject the agent state loader from this package into the ui package
@@ -109,17 +116,18 @@ func analyzeSessionCosts(sessions []*runner.SessionState, limit int) (*CostAnaly
 			agentState.Model = "unknown"
 		}
 
-		cost := agent.CalculateCost(agentState.Model, agentState.TokenUsage)
+		cost := agent.CalculateProviderCost(agentState.Provider, agentState.Model, agentState.TokenUsage)
 
 		// Aggregate total stats
 		totalCost += cost
 		totalTokens += agentState.TokenUsage.TotalTokens
 
-		// Aggregate by model
-		if _, ok := modelCosts[agentState.Model]; !ok {
-			modelCosts[agentState.Model] = &ModelCost{Name: agentState.Model}
+		// Aggregate by model, keeping the same model served by different providers apart
+		name := modelLabel(agentState.Provider, agentState.Model)
+		if _, ok := modelCosts[name]; !ok {
+			modelCosts[name] = &ModelCost{Name: name}
 		}
-		model := modelCosts[agentState.Model]
+		model := modelCosts[name]
 		model.TotalTokens += agentState.TokenUsage.TotalTokens
 		model.TotalPromptTokens += agentState.TokenUsage.TotalPromptTokens
 		model.TotalResponseTokens += agentState.TokenUsage.TotalResponseTokens
@@ -128,7 +136,7 @@ func analyzeSessionCosts(sessions []*runner.SessionState, limit int) (*CostAnaly
 		// Store session cost for sorting later
 		sessionCosts = append(sessionCosts, &SessionCost{
 			Name:        session.Name,
-			Model:       agentState.Model,
+			Model:       name,
 			Cost:        cost,
 			TotalTokens: agentState.TokenUsage.TotalTokens,
 		})
@@ -161,6 +169,14 @@ func analyzeSessionCosts(sessions []*runner.SessionState, limit int) (*CostAnaly
 	}, nil
 }
 
+// modelLabel names a model together with the provider serving it, if known.
+func modelLabel(provider, model string) string {
+	if provider == "" {
+		return model
+	}
+	return fmt.Sprintf("%s (%s)", model, provider)
+}
+
 func displayCostAnalysis(cmd *cobra.Command, analysis *CostAnalysis) {
 	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
 
@@ -192,3 +208,137 @@ func displayCostAnalysis(cmd *cobra.Command, analysis *CostAnalysis) {
 
 	w.Flush()
 }
+
+// RoleCost holds the cost of one role within a session.
+type RoleCost struct {
+	Role           string
+	Model          string
+	PromptTokens   int
+	ResponseTokens int
+	TotalTokens    int
+	Cost           float64
+}
+
+// IterationCost holds the cost of one role during one iteration of a session.
+type IterationCost struct {
+	Iteration      int
+	Role           string
+	PromptTokens   int
+	ResponseTokens int
+	Cost           float64
+}
+
+// analyzeRoleCosts breaks a session's recorded usage down by role (most
+// expensive first) and by iteration. Roles are priced at the model they ran on.
+func analyzeRoleCosts(state *agent.State) ([]*RoleCost, []*IterationCost) {
+	roleModel := func(role string) string {
+		if usage, ok := state.RoleUsage[role]; ok && usage.Model != "" {
+			return usage.Model
+		}
+		if state.Model != "" {
+			return state.Model
+		}
+		return "unknown"
+	}
+	// Roles only record their model; the session's provider applies to its own model
+	roleProvider := func(model string) string {
+		if model == state.Model {
+			return state.Provider
+		}
+		return ""
+	}
+
+	roles := make([]*RoleCost, 0, len(state.RoleUsage))
+	for role, usage := range state.RoleUsage {
+		model := roleModel(role)
+		roles = append(roles, &RoleCost{
+			Role:           role,
+			Model:          model,
+			PromptTokens:   usage.TotalPromptTokens,
+			ResponseTokens: usage.TotalResponseTokens,
+			TotalTokens:    usage.TotalTokens,
+			Cost:           agent.CalculateProviderCost(roleProvider(model), model, usage.TokenUsage),
+		})
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		if roles[i].Cost != roles[j].Cost {
+			return roles[i].Cost > roles[j].Cost
+		}
+		return roles[i].Role < roles[j].Role
+	})
+
+	iterations := make([]*IterationCost, 0, len(state.IterationUsage))
+	for _, usage := range state.IterationUsage {
+		iterations = append(iterations, &IterationCost{
+			Iteration:      usage.Iteration,
+			Role:           usage.Role,
+			PromptTokens:   usage.PromptTokens,
+			ResponseTokens: usage.ResponseTokens,
+			Cost: agent.CalculateProviderCost(roleProvider(roleModel(usage.Role)), roleModel(usage.Role), agent.TokenUsage{
+				TotalPromptTokens:   usage.PromptTokens,
+				TotalResponseTokens: usage.ResponseTokens,
+				TotalTokens:         usage.PromptTokens + usage.ResponseTokens,
+			}),
+		})
+	}
+
+	return roles, iterations
+}
+
+func showSessionCostBreakdown(cmd *cobra.Command, sm ISessionManager, name string) error {
+	session, err := sm.LoadSession(name)
+	if err != nil {
+		return fmt.Errorf("could not load session %s: %w", name, err)
+	}
+	if session.AgentStateFile == "" {
+		return fmt.Errorf("session %s has no agent state", name)
+	}
+	state, err := loadAgentState(session.AgentStateFile)
+	if err != nil {
+		return fmt.Errorf("could not load agent state for session %s: %w", name, err)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	defer w.Flush()
+
+	if len(state.RoleUsage) == 0 {
+		fmt.Fprintf(w, "No per-role usage recorded for session %s.\n", name)
+		fmt.Fprintf(w, "Total Tokens:\t%d\n", state.TokenUsage.TotalTokens)
+		return nil
+	}
+
+	roles, iterations := analyzeRoleCosts(state)
+	var totalCost float64
+	var totalTokens int
+	for _, role := range roles {
+		totalCost += role.Cost
+		totalTokens += role.TotalTokens
+	}
+
+	fmt.Fprintln(w, "COST BY ROLE")
+	fmt.Fprintln(w, "------------")
+	fmt.Fprintln(w, "ROLE\tMODEL\tCOST\tSHARE\tTOTAL TOKENS\tPROMPT TOKENS\tRESPONSE TOKENS")
+	for _, role := range roles {
+		share := 0.0
+		if totalCost > 0 {
+			share = role.Cost / totalCost * 100
+		}
+		fmt.Fprintf(w, "%s\t%s\t$%.4f\t%.1f%%\t%d\t%d\t%d\n",
+			role.Role, role.Model, role.Cost, share, role.TotalTokens, role.PromptTokens, role.ResponseTokens)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "COST BY ITERATION")
+	fmt.Fprintln(w, "-----------------")
+	fmt.Fprintln(w, "ITERATION\tROLE\tCOST\tPROMPT TOKENS\tRESPONSE TOKENS")
+	for _, it := range iterations {
+		fmt.Fprintf(w, "%d\t%s\t$%.4f\t%d\t%d\n", it.Iteration, it.Role, it.Cost, it.PromptTokens, it.ResponseTokens)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "TOTALS")
+	fmt.Fprintln(w, "------")
+	fmt.Fprintf(w, "Total Estimated Cost:\t$%.4f\n", totalCost)
+	fmt.Fprintf(w, "Total Tokens:\t%d\n", totalTokens)
+	return nil
+}
@@ -142,6 +142,33 @@ func TestCostCommand_Static(t *testing.T) {
 	require.NotContains(t, output, "test-session-4-no-state")
 }
 
+func TestAnalyzeSessionCosts_Provider(t *testing.T) {
+	dir := t.TempDir()
+	usage := agent.TokenUsage{TotalPromptTokens: 1000000, TotalResponseTokens: 1000000, TotalTokens: 2000000}
+
+	var sessions []*runner.SessionState
+	for _, provider := range []string{"openai", "openrouter"} {
+		stateFile := filepath.Join(dir, provider+".json")
+		data, err := json.Marshal(&agent.State{Provider: provider, Model: "gpt-4o", TokenUsage: usage})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(stateFile, data, 0644))
+		sessions = append(sessions, &runner.SessionState{Name: provider + "-session", AgentStateFile: stateFile})
+	}
+
+	analysis, err := analyzeSessionCosts(sessions, 10)
+	require.NoError(t, err)
+
+	// The same model is priced and listed per provider
+	require.Len(t, analysis.Models, 2)
+	costs := map[string]float64{}
+	for _, m := range analysis.Models {
+		costs[m.Name] = m.TotalCost
+	}
+	assert.InDelta(t, 20.00, costs["gpt-4o (openai)"], 0.0001)
+	assert.InDelta(t, 12.50, costs["gpt-4o (openrouter)"], 0.0001)
+	assert.InDelta(t, 32.50, analysis.TotalCost, 0.0001)
+}
+
 func TestCostCommand_WatchFlag(t *testing.T) {
 	// --- Setup ---
 	tempDir := t.TempDir()
@@ -192,3 +219,52 @@ func TestCostCommand_Flags(t *testing.T) {
 	require.Equal(t, "false", flag.DefValue, "the --watch flag should default to false")
 	require.Equal(t, "Launch a real-time TUI to monitor session costs", flag.Usage, "the --watch flag should have the correct usage message")
 }
+
+func TestCostCommand_SessionBreakdown(t *testing.T) {
+	sessionsDir := t.TempDir()
+	session := &runner.SessionState{
+		Name:           "roles",
+		Status:         "COMPLETED",
+		StartTime:      time.Now(),
+		AgentStateFile: filepath.Join(sessionsDir, "agent_state_roles.json"),
+	}
+	state := &agent.State{
+		Model: "gpt-4-turbo",
+		RoleUsage: map[string]agent.RoleUsage{
+			agent.RoleCoding:  {TokenUsage: agent.TokenUsage{TotalPromptTokens: 100000, TotalResponseTokens: 10000, TotalTokens: 110000}},
+			agent.RoleManager: {Model: "gemini-1.5-pro-latest", TokenUsage: agent.TokenUsage{TotalPromptTokens: 200000, TotalResponseTokens: 50000, TotalTokens: 250000}},
+		},
+		IterationUsage: []agent.IterationUsage{
+			{Iteration: 1, Role: agent.RoleCoding, PromptTokens: 100000, ResponseTokens: 10000},
+			{Iteration: 2, Role: agent.RoleManager, PromptTokens: 200000, ResponseTokens: 50000},
+		},
+	}
+
+	sessionBytes, err := json.Marshal(session)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(sessionsDir, "roles.json"), sessionBytes, 0644))
+	stateBytes, err := json.Marshal(state)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(session.AgentStateFile, stateBytes, 0644))
+
+	originalFactory := sessionManagerFactory
+	sessionManagerFactory = func() (ISessionManager, error) {
+		return runner.NewSessionManagerWithDir(sessionsDir)
+	}
+	defer func() { sessionManagerFactory = originalFactory }()
+
+	rootCmd, _, _ := newRootCmd()
+	output, err := executeCommand(rootCmd, "cost", "roles")
+	require.NoError(t, err)
+
+	// Manager: 0.2M * $7 + 0.05M * $21 = $2.45; Coding: 0.1M * $10 + 0.01M * $30 = $1.30
+	require.Contains(t, output, "COST BY ROLE")
+	require.Regexp(t, `Manager\s+gemini-1.5-pro-latest\s+\$2.4500\s+65.3%\s+250000`, output)
+	require.Regexp(t, `Coding\s+gpt-4-turbo\s+\$1.3000\s+34.7%\s+110000`, output)
+	require.Contains(t, output, "COST BY ITERATION")
+	require.Regexp(t, `2\s+Manager\s+\$2.4500`, output)
+	require.Regexp(t, `Total Estimated Cost:\s+\$3.7500`, output)
+
+	_, err = executeCommand(rootCmd, "cost", "missing")
+	require.Error(t, err)
+}
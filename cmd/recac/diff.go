@@ -2,24 +2,37 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var diffCmd = &cobra.Command{
-	Use:   "diff [session_a] [session_b]",
-	Short: "Compare two sessions",
-	Long:  "Compares two sessions.",
-	Args:  cobra.ExactArgs(2),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		sessionAName := args[0]
-		sessionBName := args[1]
+	Use:   "diff <session> | diff [session_a] [session_b]",
+	Short: "Show a session's changes, or compare two sessions",
+	Long: `With one session, shows the full patch of what the agent changed: the unified
+diff between the session's start and end commits, or against HEAD while the
+session is still running. Use --stat for a summary. Long output is shown in
+$PAGER (default "less -FRX") when writing to a terminal.
 
+With two sessions, compares their metadata and logs.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
 		sm, err := sessionManagerFactory()
 		if err != nil {
 			return fmt.Errorf("failed to initialize session manager: %w", err)
 		}
 
+		if len(args) == 1 {
+			return showSessionPatch(cmd, sm, args[0])
+		}
+
+		sessionAName := args[0]
+		sessionBName := args[1]
+
 		sessionA, err := sm.LoadSession(sessionAName)
 		if err != nil {
 			return fmt.Errorf("failed to load session %s: %w", sessionAName, err)
@@ -35,5 +48,62 @@ var diffCmd = &cobra.Command{
 }
 
 func init() {
+	diffCmd.Flags().Bool("stat", false, "Show a diffstat summary instead of the full patch")
+	diffCmd.Flags().Bool("no-pager", false, "Write the patch directly instead of through $PAGER")
 	rootCmd.AddCommand(diffCmd)
 }
+
+// showSessionPatch writes the git changes of a single session.
+func showSessionPatch(cmd *cobra.Command, sm ISessionManager, name string) error {
+	if _, err := sm.LoadSession(name); err != nil {
+		return fmt.Errorf("failed to load session %s: %w", name, err)
+	}
+
+	stat, _ := cmd.Flags().GetBool("stat")
+	var diff string
+	var err error
+	if stat {
+		diff, err = sm.GetSessionGitDiffStat(name)
+	} else {
+		diff, err = sm.GetSessionGitDiff(name)
+	}
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(diff) == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "No changes recorded for session '%s'.\n", name)
+		return nil
+	}
+	if !strings.HasSuffix(diff, "\n") {
+		diff += "\n"
+	}
+
+	noPager, _ := cmd.Flags().GetBool("no-pager")
+	if !noPager && cmd.OutOrStdout() == os.Stdout && isTerminal(os.Stdout) {
+		if err := page(diff); err == nil {
+			return nil
+		}
+	}
+	_, err = io.WriteString(cmd.OutOrStdout(), diff)
+	return err
+}
+
+// isTerminal reports whether f is an interactive terminal.
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	return err == nil && (stat.Mode()&os.ModeCharDevice) != 0
+}
+
+// page shows text through $PAGER, falling back to "less -FRX", which exits
+// right away when the text fits on one screen.
+func page(text string) error {
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less", "-FRX"}
+	}
+	p := exec.Command(pager[0], pager[1:]...)
+	p.Stdin = strings.NewReader(text)
+	p.Stdout = os.Stdout
+	p.Stderr = os.Stderr
+	return p.Run()
+}
@@ -138,3 +138,48 @@ func TestDiffCmd(t *testing.T) {
 		require.Contains(t, err.Error(), "session not found")
 	})
 }
+
+func TestDiffCmd_SingleSession(t *testing.T) {
+	mockSM := NewMockSessionManager()
+	mockSM.Sessions["done"] = &runner.SessionState{Name: "done", Status: "completed", StartCommitSHA: "a1", EndCommitSHA: "b2"}
+	mockSM.Sessions["running"] = &runner.SessionState{Name: "running", Status: "running", StartCommitSHA: "a1"}
+	mockSM.Sessions["empty"] = &runner.SessionState{Name: "empty", Status: "completed"}
+
+	originalFactory := sessionManagerFactory
+	sessionManagerFactory = func() (ISessionManager, error) {
+		return mockSM, nil
+	}
+	defer func() { sessionManagerFactory = originalFactory }()
+
+	t.Run("shows the full patch", func(t *testing.T) {
+		output, err := executeCommand(rootCmd, "diff", "done")
+		require.NoError(t, err)
+		require.Contains(t, output, "diff --git a/README.md b/README.md")
+		require.Contains(t, output, "+new")
+	})
+
+	t.Run("diffs running sessions against HEAD", func(t *testing.T) {
+		output, err := executeCommand(rootCmd, "diff", "running")
+		require.NoError(t, err)
+		require.Contains(t, output, "+new")
+	})
+
+	t.Run("shows the stat with --stat", func(t *testing.T) {
+		output, err := executeCommand(rootCmd, "diff", "done", "--stat")
+		require.NoError(t, err)
+		require.Contains(t, output, "1 file changed")
+		require.NotContains(t, output, "diff --git")
+	})
+
+	t.Run("reports sessions without changes", func(t *testing.T) {
+		output, err := executeCommand(rootCmd, "diff", "empty")
+		require.NoError(t, err)
+		require.Contains(t, output, "No changes recorded for session 'empty'.")
+	})
+
+	t.Run("fails for unknown sessions", func(t *testing.T) {
+		_, err := executeCommand(rootCmd, "diff", "missing")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "session not found")
+	})
+}
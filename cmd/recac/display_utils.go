@@ -58,7 +58,7 @@ func displayStatus(cmd *cobra.Command, session *runner.SessionState, state *agen
 	// --- Token Usage & Cost ---
 	fmt.Fprintln(cmd.OutOrStdout(), "\n--- Usage ---")
 	wUsage := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
-	cost := agent.CalculateCost(state.Model, state.TokenUsage)
+	cost := agent.CalculateProviderCost(state.Provider, state.Model, state.TokenUsage)
 
 	costStr := fmt.Sprintf("$%.6f", cost)
 	if cost > 0.5 {
@@ -138,7 +138,7 @@ func DisplaySessionDetail(cmd *cobra.Command, session *runner.SessionState, full
 			fmt.Fprintf(w, "Prompt Tokens:\t%d\n", agentState.TokenUsage.TotalPromptTokens)
 			fmt.Fprintf(w, "Completion Tokens:\t%d\n", agentState.TokenUsage.TotalResponseTokens)
 			fmt.Fprintf(w, "Total Tokens:\t%d\n", agentState.TokenUsage.TotalTokens)
-			cost := agent.CalculateCost(agentState.Model, agentState.TokenUsage)
+			cost := agent.CalculateProviderCost(agentState.Provider, agentState.Model, agentState.TokenUsage)
 			fmt.Fprintf(w, "Estimated Cost:\t$%.6f\n", cost)
 			w.Flush()
 		} else if !os.IsNotExist(err) {
@@ -225,13 +225,13 @@ func printMetadataDiff(cmd *cobra.Command, sA, sB *runner.SessionState) {
 	modelA, modelB := "N/A", "N/A"
 
 	if errA == nil && stateA != nil {
-		cost := agent.CalculateCost(stateA.Model, stateA.TokenUsage)
+		cost := agent.CalculateProviderCost(stateA.Provider, stateA.Model, stateA.TokenUsage)
 		costA = fmt.Sprintf("$%.4f", cost)
 		tokensA = fmt.Sprintf("%d", stateA.TokenUsage.TotalPromptTokens+stateA.TokenUsage.TotalResponseTokens)
 		modelA = stateA.Model
 	}
 	if errB == nil && stateB != nil {
-		cost := agent.CalculateCost(stateB.Model, stateB.TokenUsage)
+		cost := agent.CalculateProviderCost(stateB.Provider, stateB.Model, stateB.TokenUsage)
 		costB = fmt.Sprintf("$%.4f", cost)
 		tokensB = fmt.Sprintf("%d", stateB.TokenUsage.TotalPromptTokens+stateB.TokenUsage.TotalResponseTokens)
 		modelB = stateB.Model
@@ -14,7 +14,7 @@ func init() {
 var doctorCmd = &cobra.Command{
 	Use:   "doctor",
 	Short: "Check the RECAC environment for potential issues",
-	Long:  `Runs a series of checks to ensure that the RECAC environment is set up correctly. This includes checking for a valid configuration file, required dependencies like git and docker, and connectivity to the Docker daemon.`,
+	Long:  `Runs a series of checks to ensure that the RECAC environment is set up correctly. This includes checking for a valid configuration file, required dependencies like git and docker (plus optional gh and kubectl), connectivity to the Docker daemon, the configured agent provider's API key and reachability, and Jira authentication. Failed checks include remediation hints.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Fprint(cmd.OutOrStdout(), ui.GetDoctor())
 	},
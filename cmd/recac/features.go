@@ -0,0 +1,260 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+
+	"recac/internal/db"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	featuresCmd.PersistentFlags().StringP("workspace", "w", "", "Project workspace (default: current directory)")
+	featuresCmd.PersistentFlags().String("project", "", "Project name in the database (default: workspace directory name)")
+
+	featuresListCmd.Flags().Bool("json", false, "Print the raw feature list JSON")
+	featuresSetCmd.Flags().String("status", "", "New feature status (e.g. todo, in_progress, done)")
+	featuresSetCmd.Flags().String("passes", "", "Whether the feature passes (true/false)")
+
+	featuresCmd.AddCommand(featuresListCmd)
+	featuresCmd.AddCommand(featuresSetCmd)
+	rootCmd.AddCommand(featuresCmd)
+}
+
+var featuresCmd = &cobra.Command{
+	Use:   "features",
+	Short: "Inspect and edit a project's feature list",
+	Long: `Inspect and edit the feature list tracked by the agent, from the host.
+
+Reads the project's .recac.db and falls back to feature_list.json when the
+database has no features. Mirrors 'agent-bridge feature' inside the container.`,
+}
+
+var featuresListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List features and their status",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workspace, project, err := featuresTarget(cmd)
+		if err != nil {
+			return err
+		}
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		fl, err := loadWorkspaceFeatures(workspace, project)
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			data, err := json.MarshalIndent(fl, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		}
+
+		if len(fl.Features) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No features found.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tSTATUS\tPASSES\tDESCRIPTION")
+		done := 0
+		for _, f := range fl.Features {
+			if f.Passes {
+				done++
+			}
+			fmt.Fprintf(w, "%s\t%s\t%v\t%s\n", f.ID, f.Status, f.Passes, truncateString(firstLine(f.Description), 70))
+		}
+		w.Flush()
+		fmt.Fprintf(cmd.OutOrStdout(), "\n%d/%d features passing\n", done, len(fl.Features))
+		return nil
+	},
+}
+
+var featuresSetCmd = &cobra.Command{
+	Use:   "set <id>",
+	Short: "Update a feature's status and passes flag",
+	Long: `Update a feature's status and/or passes flag, e.g.
+
+  recac features set AUTH-1 --status done --passes true
+
+Unset flags keep the feature's current value. The database is updated with
+UpdateFeatureStatus and feature_list.json is kept in sync when present.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		workspace, project, err := featuresTarget(cmd)
+		if err != nil {
+			return err
+		}
+
+		statusFlag, _ := cmd.Flags().GetString("status")
+		passesFlag, _ := cmd.Flags().GetString("passes")
+		if statusFlag == "" && passesFlag == "" {
+			return fmt.Errorf("nothing to update: pass --status and/or --passes")
+		}
+		var passes bool
+		if passesFlag != "" {
+			if passes, err = strconv.ParseBool(passesFlag); err != nil {
+				return fmt.Errorf("invalid --passes value %q: expected true or false", passesFlag)
+			}
+		}
+
+		fl, err := loadWorkspaceFeatures(workspace, project)
+		if err != nil {
+			return err
+		}
+		idx := -1
+		for i, f := range fl.Features {
+			if f.ID == id {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return fmt.Errorf("feature %s not found", id)
+		}
+
+		feature := &fl.Features[idx]
+		if statusFlag != "" {
+			feature.Status = statusFlag
+		}
+		if passesFlag != "" {
+			feature.Passes = passes
+		}
+
+		if store, err := openWorkspaceStore(workspace); err != nil {
+			return err
+		} else if store != nil {
+			defer store.Close()
+			// Seed the DB when the list only existed on disk so the update has a target.
+			if content, _ := store.GetFeatures(project); content == "" {
+				data, err := json.Marshal(fl)
+				if err != nil {
+					return err
+				}
+				if err := store.SaveFeatures(project, string(data)); err != nil {
+					return fmt.Errorf("failed to save features: %w", err)
+				}
+			}
+			if err := store.UpdateFeatureStatus(project, id, feature.Status, feature.Passes); err != nil {
+				return fmt.Errorf("failed to update feature: %w", err)
+			}
+		}
+
+		if err := syncFeatureFile(workspace, *feature); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Feature %s updated: status=%s, passes=%v\n", id, feature.Status, feature.Passes)
+		return nil
+	},
+}
+
+// featuresTarget resolves the workspace and the project name its session uses.
+func featuresTarget(cmd *cobra.Command) (string, string, error) {
+	workspace, _ := cmd.Flags().GetString("workspace")
+	project, _ := cmd.Flags().GetString("project")
+	if workspace == "" {
+		workspace = "."
+	}
+	abs, err := filepath.Abs(workspace)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve workspace: %w", err)
+	}
+	if project == "" {
+		project = filepath.Base(abs)
+	}
+	return abs, project, nil
+}
+
+// openWorkspaceStore opens the workspace's .recac.db, returning nil if it does not exist.
+func openWorkspaceStore(workspace string) (*db.SQLiteStore, error) {
+	dbPath := filepath.Join(workspace, ".recac.db")
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+	store, err := db.NewSQLiteStore(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return store, nil
+}
+
+// loadWorkspaceFeatures reads the feature list from the DB, falling back to feature_list.json.
+func loadWorkspaceFeatures(workspace, project string) (db.FeatureList, error) {
+	var fl db.FeatureList
+
+	store, err := openWorkspaceStore(workspace)
+	if err != nil {
+		return fl, err
+	}
+	if store != nil {
+		defer store.Close()
+		content, err := store.GetFeatures(project)
+		if err != nil {
+			return fl, fmt.Errorf("failed to get features: %w", err)
+		}
+		if content != "" {
+			if err := json.Unmarshal([]byte(content), &fl); err != nil {
+				return fl, fmt.Errorf("invalid feature list in database: %w", err)
+			}
+			if len(fl.Features) > 0 {
+				return fl, nil
+			}
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(workspace, "feature_list.json"))
+	if os.IsNotExist(err) {
+		return fl, nil
+	}
+	if err != nil {
+		return fl, fmt.Errorf("failed to read feature_list.json: %w", err)
+	}
+	if err := json.Unmarshal(data, &fl); err != nil {
+		return fl, fmt.Errorf("invalid feature_list.json: %w", err)
+	}
+	return fl, nil
+}
+
+// syncFeatureFile applies an updated feature to feature_list.json if the file exists.
+func syncFeatureFile(workspace string, feature db.Feature) error {
+	listPath := filepath.Join(workspace, "feature_list.json")
+	data, err := os.ReadFile(listPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read feature_list.json: %w", err)
+	}
+
+	var fl db.FeatureList
+	if err := json.Unmarshal(data, &fl); err != nil {
+		return fmt.Errorf("invalid feature_list.json: %w", err)
+	}
+	for i := range fl.Features {
+		if fl.Features[i].ID == feature.ID {
+			fl.Features[i].Status = feature.Status
+			fl.Features[i].Passes = feature.Passes
+		}
+	}
+
+	out, err := json.MarshalIndent(fl, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(listPath, out, 0644); err != nil {
+		return fmt.Errorf("failed to write feature_list.json: %w", err)
+	}
+	return nil
+}
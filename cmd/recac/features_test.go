@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"recac/internal/db"
+)
+
+const testFeatureList = `{"project_name":"proj","features":[
+	{"id":"F1","description":"Login page","status":"done","passes":true},
+	{"id":"F2","description":"Logout button","status":"todo","passes":false}
+]}`
+
+func TestFeaturesList_FromFile(t *testing.T) {
+	workspace := t.TempDir()
+	os.WriteFile(filepath.Join(workspace, "feature_list.json"), []byte(testFeatureList), 0644)
+
+	output, err := executeCommand(rootCmd, "features", "list", "--workspace", workspace)
+	if err != nil {
+		t.Fatalf("list failed: %v\n%s", err, output)
+	}
+	for _, want := range []string{"F1", "Login page", "F2", "todo", "1/2 features passing"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestFeaturesSet_UpdatesDBAndFile(t *testing.T) {
+	workspace := t.TempDir()
+	listPath := filepath.Join(workspace, "feature_list.json")
+	os.WriteFile(listPath, []byte(testFeatureList), 0644)
+
+	store, err := db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveFeatures("proj", testFeatureList); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	output, err := executeCommand(rootCmd, "features", "set", "F2", "--workspace", workspace, "--project", "proj", "--status", "done", "--passes", "true")
+	if err != nil {
+		t.Fatalf("set failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Feature F2 updated: status=done, passes=true") {
+		t.Errorf("unexpected output: %s", output)
+	}
+
+	store, _ = db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	defer store.Close()
+	content, _ := store.GetFeatures("proj")
+	var fromDB db.FeatureList
+	json.Unmarshal([]byte(content), &fromDB)
+	if f := fromDB.Features[1]; f.Status != "done" || !f.Passes {
+		t.Errorf("expected DB feature updated, got %+v", f)
+	}
+
+	data, _ := os.ReadFile(listPath)
+	var fromFile db.FeatureList
+	json.Unmarshal(data, &fromFile)
+	if f := fromFile.Features[1]; f.Status != "done" || !f.Passes {
+		t.Errorf("expected feature_list.json updated, got %+v", f)
+	}
+}
+
+func TestFeaturesSet_Errors(t *testing.T) {
+	workspace := t.TempDir()
+	os.WriteFile(filepath.Join(workspace, "feature_list.json"), []byte(testFeatureList), 0644)
+
+	if _, err := executeCommand(rootCmd, "features", "set", "F1", "--workspace", workspace); err == nil {
+		t.Error("expected error when no update flags are given")
+	}
+	if _, err := executeCommand(rootCmd, "features", "set", "F1", "--workspace", workspace, "--passes", "maybe"); err == nil {
+		t.Error("expected error for invalid --passes value")
+	}
+	if _, err := executeCommand(rootCmd, "features", "set", "NOPE", "--workspace", workspace, "--status", "done"); err == nil {
+		t.Error("expected error for unknown feature")
+	}
+}
@@ -73,6 +73,18 @@ func (m *MockGitClientLog) CreatePR(directory, title, body, base string) (string
 	return "", nil
 }
 
+func (m *MockGitClientLog) AddWorktree(directory, path, branch string) error {
+	return nil
+}
+
+func (m *MockGitClientLog) RemoveWorktree(directory, path string) error {
+	return nil
+}
+
+func (m *MockGitClientLog) DefaultBranch(directory string) (string, error) {
+	return "main", nil
+}
+
 func TestGitLogCmd(t *testing.T) {
 	// Setup Mocks
 	origGitFactory := gitClientFactory
@@ -24,12 +24,14 @@ with the autonomous coding agent.`,
 		// Use Viper as it handles env vars and persistent flags binding
 		provider := viper.GetString("provider")
 		model := viper.GetString("model")
-		RunInteractive(provider, model)
+		exportPath, _ := cmd.Flags().GetString("export-on-quit")
+		RunInteractive(provider, model, exportPath)
 	},
 }
 
-// RunInteractive starts the interactive TUI session.
-func RunInteractive(provider, model string) {
+// RunInteractive starts the interactive TUI session. With exportPath set, the
+// conversation is exported there as Markdown when the session ends.
+func RunInteractive(provider, model, exportPath string) {
 	// Redirect logs to file to avoid TUI corruption
 	// We do this by re-initializing the logger
 	f, err := os.OpenFile("recac-tui.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
@@ -74,6 +76,41 @@ func RunInteractive(provider, model string) {
 		},
 	})
 
+	commands = append(commands, ui.SlashCommand{
+		Name:        "/theme",
+		Description: "Switch the markdown style (dark, light, notty, auto or a JSON style file)",
+		Action: func(m *ui.InteractiveModel, args []string) tea.Cmd {
+			if len(args) == 0 {
+				return func() tea.Msg {
+					return ui.StatusMsg(fmt.Sprintf("Markdown style: %s (usage: /theme dark|light|notty|auto|<style.json>)", ui.MarkdownStyle()))
+				}
+			}
+			if err := m.SetTheme(args[0]); err != nil {
+				return func() tea.Msg {
+					return ui.StatusMsg(err.Error())
+				}
+			}
+			return nil
+		},
+	})
+
+	commands = append(commands, ui.SlashCommand{
+		Name:        "/export",
+		Description: "Export the conversation to a Markdown file",
+		Action: func(m *ui.InteractiveModel, args []string) tea.Cmd {
+			status := "Usage: /export <file.md>"
+			if len(args) > 0 {
+				status = fmt.Sprintf("Conversation exported to %s", args[0])
+				if err := m.ExportMarkdown(args[0]); err != nil {
+					status = err.Error()
+				}
+			}
+			return func() tea.Msg {
+				return ui.StatusMsg(status)
+			}
+		},
+	})
+
 	// Add dynamic commands from Cobra
 	for _, c := range rootCmd.Commands() {
 		if c.Name() == "interactive" || c.Name() == "help" || c.Name() == "completion" {
@@ -115,14 +152,26 @@ func RunInteractive(provider, model string) {
 	}
 
 	p := tea.NewProgram(ui.NewInteractiveModel(commands, provider, model))
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
 		exit(1)
 	}
+
+	if exportPath != "" {
+		if m, ok := final.(ui.InteractiveModel); ok {
+			if err := m.ExportMarkdown(exportPath); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			} else {
+				fmt.Printf("Conversation exported to %s\n", exportPath)
+			}
+		}
+	}
 }
 
 func init() {
 	rootCmd.AddCommand(interactiveCmd)
+	interactiveCmd.Flags().String("export-on-quit", "", "Export the conversation to this Markdown file when the session ends")
 }
 
 var surveyAskOne = survey.AskOne
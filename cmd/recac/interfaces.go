@@ -31,9 +31,11 @@ type ISessionManager interface {
 	RenameSession(oldName, newName string) error
 	SessionsDir() string
 	GetSessionGitDiffStat(name string) (string, error)
+	GetSessionGitDiff(name string) (string, error)
 	ArchiveSession(name string) error
 	UnarchiveSession(name string) error
 	ListArchivedSessions() ([]*runner.SessionState, error)
+	RemoveArchivedSession(name string) error
 }
 
 // IGitClient defines the interface for git operations.
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"recac/internal/agent"
 	"recac/internal/agent/prompts"
@@ -183,12 +184,137 @@ type ticketNode struct {
 	Children           []ticketNode `json:"children"`
 }
 
+// ticketIDRegex matches the ID marker embedded in generated titles: ID:[SQL] or ID:SQL-1
+var ticketIDRegex = regexp.MustCompile(`(?i)ID:\[?([\w-]+)\]?`)
+
+// ticketMarker returns the ID marker of a generated ticket title, or "" if it has none.
+func ticketMarker(title string) string {
+	if matches := ticketIDRegex.FindStringSubmatch(title); len(matches) > 1 {
+		return matches[1]
+	}
+	return ""
+}
+
+// ticketReuse makes generation idempotent: tickets whose ID marker was
+// created by an earlier run are reused instead of duplicated.
+type ticketReuse struct {
+	existing map[string]string // ID marker -> ticket key
+	update   bool              // Refresh descriptions/criteria of reused tickets
+
+	checkpoint *ticketCheckpoint // Tickets created by an interrupted run, by title
+}
+
+// ticketBatch records the tickets of one generation run. Its semaphore bounds
+// how many Jira requests are in flight; with room for more than one, siblings
+// are created in parallel once their parent exists.
+type ticketBatch struct {
+	sem chan struct{}
+
+	mu         sync.Mutex
+	titleToKey map[string]string
+	checkpoint *ticketCheckpoint // Saved after every ticket, if set
+}
+
+func newTicketBatch(concurrency int) *ticketBatch {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &ticketBatch{
+		sem:        make(chan struct{}, concurrency),
+		titleToKey: make(map[string]string),
+	}
+}
+
+// acquire waits for a free request slot, returning a func that releases it.
+func (b *ticketBatch) acquire(ctx context.Context) (func(), error) {
+	select {
+	case b.sem <- struct{}{}:
+		return func() { <-b.sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *ticketBatch) record(title, key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.titleToKey[title] = key
+
+	if b.checkpoint != nil {
+		b.checkpoint.Created[title] = key
+		if err := b.checkpoint.save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to save checkpoint %s: %v\n", b.checkpoint.path, err)
+		}
+	}
+}
+
+// checkpointed returns the key of a ticket an interrupted run already created.
+func (b *ticketBatch) checkpointed(title string) (string, bool) {
+	if b.checkpoint == nil {
+		return "", false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	key, ok := b.checkpoint.Created[title]
+	return key, ok
+}
+
+// ticketSearcher finds tickets created by earlier runs.
+type ticketSearcher interface {
+	SearchIssues(ctx context.Context, jql string) ([]map[string]interface{}, error)
+}
+
+// ticketUpdater refreshes the description of a reused ticket.
+type ticketUpdater interface {
+	UpdateDescription(ctx context.Context, key, description string) error
+}
+
+// findExistingTickets maps the ID markers of tickets already in the project
+// (restricted to any of labels, if given) to their keys. The oldest ticket
+// wins when an earlier, non-idempotent run left duplicates.
+func findExistingTickets(ctx context.Context, searcher ticketSearcher, projectKey string, labels []string) (map[string]string, error) {
+	clauses := []string{`summary ~ "ID"`}
+	if projectKey != "" {
+		clauses = append([]string{fmt.Sprintf("project = %q", projectKey)}, clauses...)
+	}
+	if len(labels) > 0 {
+		quoted := make([]string, len(labels))
+		for i, label := range labels {
+			quoted[i] = fmt.Sprintf("%q", label)
+		}
+		clauses = append(clauses, fmt.Sprintf("labels in (%s)", strings.Join(quoted, ", ")))
+	}
+	jql := strings.Join(clauses, " AND ") + " ORDER BY created ASC"
+
+	issues, err := searcher.SearchIssues(ctx, jql)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]string)
+	for _, issue := range issues {
+		key, _ := issue["key"].(string)
+		fields, _ := issue["fields"].(map[string]interface{})
+		summary, _ := fields["summary"].(string)
+		if marker := ticketMarker(summary); marker != "" && key != "" {
+			if _, seen := existing[marker]; !seen {
+				existing[marker] = key
+			}
+		}
+	}
+	return existing, nil
+}
+
 // jiraGenerateFromSpecCmd represents the jira generate-from-spec command
 var jiraGenerateFromSpecCmd = &cobra.Command{
 	Use:   "generate-from-spec",
 	Short: "Generate Jira tickets from app_spec.txt",
-	Long:  "Reads app_spec.txt, uses an LLM to decompose it into Epics and Stories, and creates them in Jira.",
-	Run:   runGenerateTicketsCmd,
+	Long: `Reads app_spec.txt, uses an LLM to decompose it into Epics and Stories, and creates them in Jira.
+
+The ticket plan and every created ticket are recorded in a checkpoint
+(` + defaultTicketCheckpoint + `). If a run is interrupted, re-run with
+--resume <checkpoint.json> to reuse its plan and create only the missing tickets.`,
+	Run: runGenerateTicketsCmd,
 }
 
 func runGenerateTicketsCmd(cmd *cobra.Command, args []string) {
@@ -243,10 +369,23 @@ func runGenerateTicketsCmd(cmd *cobra.Command, args []string) {
 	fmt.Printf("Using labels for all tickets: %v\n", allLabels)
 
 	repoURL, _ := cmd.Flags().GetString("repo-url")
+	incremental, _ := cmd.Flags().GetBool("incremental")
+
+	// Progress is checkpointed so an interrupted run can pick up where it stopped
+	checkpoint := newTicketCheckpoint(defaultTicketCheckpoint)
+	if resumePath, _ := cmd.Flags().GetString("resume"); resumePath != "" {
+		if checkpoint, err = loadTicketCheckpoint(resumePath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exit(1)
+		}
+	}
 
-	createdTickets, err := generateTickets(ctx, string(specContent), projectKey, repoURL, allLabels, jiraClient, ag)
+	createdTickets, err := generateTickets(ctx, string(specContent), projectKey, repoURL, allLabels, jiraClient, ag, incremental, checkpoint)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if len(checkpoint.Tickets) > 0 {
+			fmt.Fprintf(os.Stderr, "Progress was saved; re-run with --resume %s to continue without duplicating tickets.\n", checkpoint.path)
+		}
 		exit(1)
 	}
 
@@ -267,7 +406,43 @@ func runGenerateTicketsCmd(cmd *cobra.Command, args []string) {
 }
 
 // generateTickets contains the core logic for ticket generation, decoupled from flags for testing.
-func generateTickets(ctx context.Context, specContent, projectKey, repoURL string, allLabels []string, jiraClient jira.ClientInterface, ag agent.Agent) (map[string]string, error) {
+// With incremental, only top-level tickets are created now; see deferChildren.
+// A non-nil checkpoint records progress; one loaded from an interrupted run
+// supplies the ticket plan, and the tickets it lists are not created again.
+func generateTickets(ctx context.Context, specContent, projectKey, repoURL string, allLabels []string, jiraClient jira.ClientInterface, ag agent.Agent, incremental bool, checkpoint *ticketCheckpoint) (map[string]string, error) {
+	var tickets []ticketNode
+	if checkpoint != nil && len(checkpoint.Tickets) > 0 {
+		fmt.Printf("Resuming from %s (%d tickets already created)\n", checkpoint.path, len(checkpoint.Created))
+		tickets = checkpoint.Tickets
+	} else {
+		var err error
+		if tickets, err = planTickets(ctx, specContent, ag); err != nil {
+			return nil, err
+		}
+		if checkpoint != nil {
+			checkpoint.Tickets = tickets
+			if err := checkpoint.save(); err != nil {
+				return nil, fmt.Errorf("failed to save checkpoint %s: %w", checkpoint.path, err)
+			}
+		}
+	}
+
+	if incremental {
+		var err error
+		if tickets, err = deferChildren(tickets, repoURL); err != nil {
+			return nil, err
+		}
+	}
+
+	var reuse *ticketReuse
+	if checkpoint != nil {
+		reuse = &ticketReuse{checkpoint: checkpoint}
+	}
+	return createTicketsFromNodes(ctx, tickets, projectKey, repoURL, allLabels, jiraClient, reuse, 1)
+}
+
+// planTickets asks the agent to decompose the spec into a ticket tree.
+func planTickets(ctx context.Context, specContent string, ag agent.Agent) ([]ticketNode, error) {
 	// 5. Generate Tickets JSON
 	prompt, err := prompts.GetPrompt(prompts.TPMAgent, map[string]string{"spec": specContent})
 	if err != nil {
@@ -304,42 +479,28 @@ func generateTickets(ctx context.Context, specContent, projectKey, repoURL strin
 	if err := json.Unmarshal([]byte(jsonStr), &tickets); err != nil {
 		return nil, fmt.Errorf("failed to parse agent response as JSON: %w\nResponse was:\n%s", err, resp)
 	}
-
-	return createTicketsFromNodes(ctx, tickets, projectKey, repoURL, allLabels, jiraClient)
+	return tickets, nil
 }
 
-func createTicketsFromNodes(ctx context.Context, tickets []ticketNode, projectKey, repoURL string, allLabels []string, jiraClient jira.ClientInterface) (map[string]string, error) {
+// createTicketsFromNodes creates the ticket tree and returns ID markers mapped to keys.
+// With a non-nil reuse, tickets matching an existing ID marker are not created again.
+// Up to concurrency tickets are created at a time; links are added once all exist.
+func createTicketsFromNodes(ctx context.Context, tickets []ticketNode, projectKey, repoURL string, allLabels []string, jiraClient jira.ClientInterface, reuse *ticketReuse, concurrency int) (map[string]string, error) {
 	fmt.Printf("Found %d top-level items. Creating tickets...\n", len(tickets))
 
-	// Validate repository in descriptions
-	repoRegex := regexp.MustCompile(`(?i)Repo: (https?://\S+)`)
-	// Helper for recursive validation
-	var validate func([]ticketNode) error
-	validate = func(nodes []ticketNode) error {
-		for _, node := range nodes {
-			// If repoURL is provided via flag, we don't strictly enforce it in description during validation
-			// because we will inject it. But if NOT provided via flag, we enforce it.
-			if repoURL == "" && !repoRegex.MatchString(node.Description) {
-				return fmt.Errorf("Item '%s' description missing repository URL (Repo: https://...)", node.Title)
-			}
-			if err := validate(node.Children); err != nil {
-				return err
-			}
-		}
-		return nil
-	}
-	if err := validate(tickets); err != nil {
+	if err := validateTicketRepos(tickets, repoURL); err != nil {
 		return nil, err
 	}
 
 	// Keep track of titles to keys for linking
-	titleToKey := make(map[string]string)
-
-	for _, node := range tickets {
-		if err := createTicketRecursively(ctx, node, "", projectKey, repoURL, allLabels, jiraClient, titleToKey); err != nil {
-			return nil, err
-		}
+	batch := newTicketBatch(concurrency)
+	if reuse != nil {
+		batch.checkpoint = reuse.checkpoint
+	}
+	if err := createTicketNodes(ctx, tickets, "", projectKey, repoURL, allLabels, jiraClient, batch, reuse); err != nil {
+		return nil, err
 	}
+	titleToKey := batch.titleToKey
 
 	// Create Links for Blockers
 	fmt.Println("Creating issue links for blockers...")
@@ -366,13 +527,10 @@ func createTicketsFromNodes(ctx context.Context, tickets []ticketNode, projectKe
 
 	// 4. Map logical IDs back from titles
 	idToKey := make(map[string]string)
-	idRegex := regexp.MustCompile(`(?i)ID:\[?([\w-]+)\]?`) // Match ID:[SQL] or ID:SQL-1
-
 	for title, key := range titleToKey {
-		matches := idRegex.FindStringSubmatch(title)
-		if len(matches) > 1 {
-			idToKey[matches[1]] = key
-			fmt.Printf("Mapped ID %s -> %s\n", matches[1], key)
+		if marker := ticketMarker(title); marker != "" {
+			idToKey[marker] = key
+			fmt.Printf("Mapped ID %s -> %s\n", marker, key)
 		}
 	}
 
@@ -380,7 +538,62 @@ func createTicketsFromNodes(ctx context.Context, tickets []ticketNode, projectKe
 	return idToKey, nil
 }
 
-func createTicketRecursively(ctx context.Context, node ticketNode, parentKey, projectKey, repoURL string, allLabels []string, jiraClient jira.ClientInterface, titleToKey map[string]string) error {
+// validateTicketRepos checks that every ticket names its repository.
+func validateTicketRepos(nodes []ticketNode, repoURL string) error {
+	repoRegex := regexp.MustCompile(`(?i)Repo: (https?://\S+)`)
+	var validate func([]ticketNode) error
+	validate = func(nodes []ticketNode) error {
+		for _, node := range nodes {
+			// If repoURL is provided via flag, we don't strictly enforce it in description during validation
+			// because we will inject it. But if NOT provided via flag, we enforce it.
+			if repoURL == "" && !repoRegex.MatchString(node.Description) {
+				return fmt.Errorf("Item '%s' description missing repository URL (Repo: https://...)", node.Title)
+			}
+			if err := validate(node.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return validate(nodes)
+}
+
+// createTicketNodes creates nodes and their children under parentKey, in
+// order when batch allows a single request at a time and in parallel otherwise.
+// The first failure cancels the tickets not yet started.
+func createTicketNodes(ctx context.Context, nodes []ticketNode, parentKey, projectKey, repoURL string, allLabels []string, jiraClient jira.ClientInterface, batch *ticketBatch, reuse *ticketReuse) error {
+	if cap(batch.sem) == 1 || len(nodes) == 1 {
+		for _, node := range nodes {
+			if err := createTicketRecursively(ctx, node, parentKey, projectKey, repoURL, allLabels, jiraClient, batch, reuse); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node ticketNode) {
+			defer wg.Done()
+			if err := createTicketRecursively(ctx, node, parentKey, projectKey, repoURL, allLabels, jiraClient, batch, reuse); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(node)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+func createTicketRecursively(ctx context.Context, node ticketNode, parentKey, projectKey, repoURL string, allLabels []string, jiraClient jira.ClientInterface, batch *ticketBatch, reuse *ticketReuse) error {
 	issueType := node.Type
 	if issueType == "" {
 		// Inference fallback
@@ -396,24 +609,27 @@ func createTicketRecursively(ctx context.Context, node ticketNode, parentKey, pr
 		indent = "  "
 	}
 
-	fmt.Printf("%sCreating %s: %s\n", indent, issueType, node.Title)
+	fullDescription := ticketDescription(node, repoURL)
 
-	// Combine Description and Acceptance Criteria
-	fullDescription := node.Description
-	if len(node.AcceptanceCriteria) > 0 {
-		fullDescription += "\n\nAcceptance Criteria:\n"
-		for _, ac := range node.AcceptanceCriteria {
-			fullDescription += fmt.Sprintf("- %s\n", ac)
+	if key, ok := batch.checkpointed(node.Title); ok {
+		fmt.Printf("%sSkipping %s from checkpoint: %s\n", indent, key, node.Title)
+		batch.record(node.Title, key)
+		return createTicketNodes(ctx, node.Children, key, projectKey, repoURL, allLabels, jiraClient, batch, reuse)
+	}
+
+	if reuse != nil {
+		if key, ok := reuse.existing[ticketMarker(node.Title)]; ok {
+			return reuseTicket(ctx, node, key, fullDescription, indent, projectKey, repoURL, allLabels, jiraClient, batch, reuse)
 		}
 	}
 
-	// Inject Repo URL if provided and missing
-	if repoURL != "" && !strings.Contains(strings.ToLower(fullDescription), "repo: http") {
-		fullDescription += fmt.Sprintf("\n\nRepo: %s", repoURL)
+	release, err := batch.acquire(ctx)
+	if err != nil {
+		return err
 	}
+	fmt.Printf("%sCreating %s: %s\n", indent, issueType, node.Title)
 
 	var key string
-	var err error
 
 	if parentKey == "" {
 		// Top level
@@ -440,28 +656,116 @@ func createTicketRecursively(ctx context.Context, node ticketNode, parentKey, pr
 		}
 
 		if err != nil {
+			release()
 			return fmt.Errorf("failed to create ticket '%s': %w", node.Title, err)
 		}
 		issueType = fallbackType // update for log
 	}
+	release()
 
 	fmt.Printf("%s-> Created %s %s\n", indent, issueType, key)
-	titleToKey[node.Title] = key
+	batch.record(node.Title, key)
 
-	for _, child := range node.Children {
-		if err := createTicketRecursively(ctx, child, key, projectKey, repoURL, allLabels, jiraClient, titleToKey); err != nil {
+	return createTicketNodes(ctx, node.Children, key, projectKey, repoURL, allLabels, jiraClient, batch, reuse)
+}
+
+// ticketDescription combines the description and acceptance criteria of node,
+// injecting repoURL when the description does not name a repository.
+func ticketDescription(node ticketNode, repoURL string) string {
+	fullDescription := node.Description
+	if len(node.AcceptanceCriteria) > 0 {
+		fullDescription += "\n\nAcceptance Criteria:\n"
+		for _, ac := range node.AcceptanceCriteria {
+			fullDescription += fmt.Sprintf("- %s\n", ac)
+		}
+	}
+
+	// Inject Repo URL if provided and missing
+	if repoURL != "" && !strings.Contains(strings.ToLower(fullDescription), "repo: http") {
+		fullDescription += fmt.Sprintf("\n\nRepo: %s", repoURL)
+	}
+	return fullDescription
+}
+
+// deferChildren moves the children of each top-level ticket into its
+// description, so they are created only when the orchestrator picks the
+// ticket up (see jira.ExpandPendingChildren).
+func deferChildren(tickets []ticketNode, repoURL string) ([]ticketNode, error) {
+	if err := validateTicketRepos(tickets, repoURL); err != nil {
+		return nil, err
+	}
+
+	var plans func([]ticketNode) []jira.TicketPlan
+	plans = func(nodes []ticketNode) []jira.TicketPlan {
+		var out []jira.TicketPlan
+		for _, node := range nodes {
+			out = append(out, jira.TicketPlan{
+				Title:       node.Title,
+				Description: ticketDescription(node, repoURL),
+				Type:        node.Type,
+				BlockedBy:   node.BlockedBy,
+				Children:    plans(node.Children),
+			})
+		}
+		return out
+	}
+
+	deferred := make([]ticketNode, 0, len(tickets))
+	for _, node := range tickets {
+		if len(node.Children) > 0 {
+			// The pending block must stay last, so fold everything else in first
+			description, err := jira.AppendPendingChildren(ticketDescription(node, repoURL), plans(node.Children))
+			if err != nil {
+				return nil, err
+			}
+			node.Description = description
+			node.AcceptanceCriteria = nil
+			node.Children = nil
+		}
+		deferred = append(deferred, node)
+	}
+	return deferred, nil
+}
+
+// reuseTicket records an existing ticket in place of creating node, optionally
+// refreshing its description, and continues with node's children.
+func reuseTicket(ctx context.Context, node ticketNode, key, description, indent, projectKey, repoURL string, allLabels []string, jiraClient jira.ClientInterface, batch *ticketBatch, reuse *ticketReuse) error {
+	if reuse.update {
+		updater, ok := jiraClient.(ticketUpdater)
+		if !ok {
+			return fmt.Errorf("jira client cannot update existing ticket %s", key)
+		}
+		release, err := batch.acquire(ctx)
+		if err != nil {
 			return err
 		}
+		err = updater.UpdateDescription(ctx, key, description)
+		release()
+		if err != nil {
+			return fmt.Errorf("failed to update ticket %s ('%s'): %w", key, node.Title, err)
+		}
+		fmt.Printf("%sUpdated existing %s: %s\n", indent, key, node.Title)
+	} else {
+		fmt.Printf("%sSkipping existing %s: %s\n", indent, key, node.Title)
 	}
-	return nil
+	batch.record(node.Title, key)
+
+	return createTicketNodes(ctx, node.Children, key, projectKey, repoURL, allLabels, jiraClient, batch, reuse)
 }
 
 // jiraGenerateFromArchCmd represents the jira generate-from-arch command
 var jiraGenerateFromArchCmd = &cobra.Command{
 	Use:   "generate-from-arch",
 	Short: "Generate Jira tickets from architecture.yaml",
-	Long:  "Reads architecture.yaml, and deterministically creates Epics for components and Stories for their inputs/outputs.",
-	Run:   runGenerateFromArchCmd,
+	Long: `Reads architecture.yaml, and deterministically creates Epics for components and Stories for their inputs/outputs.
+
+Re-running is safe: tickets whose ID:[...] marker already exists in the project
+(among tickets with any of the --label labels, if given) are skipped, or
+refreshed with --update, instead of being created again.
+
+Up to --concurrency tickets are created at a time, each only after its parent;
+blocker links are added once every ticket exists.`,
+	Run: runGenerateFromArchCmd,
 }
 
 func runGenerateFromArchCmd(cmd *cobra.Command, args []string) {
@@ -598,18 +902,26 @@ func runGenerateFromArchCmd(cmd *cobra.Command, args []string) {
 	userLabels, _ := cmd.Flags().GetStringSlice("label")
 	allLabels := append([]string{runLabel}, userLabels...)
 
-	// 5. Create tickets using existing helper
-	// We need to slightly refactor createTickets to be reusable or just call it.
-	// For now, I'll copy-paste the creation loop from generateTickets or move it to a helper.
+	// 5. Reuse tickets from earlier runs, matched by their ID marker
+	update, _ := cmd.Flags().GetBool("update")
+	existing, err := findExistingTickets(ctx, jiraClient, projectKey, userLabels)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to search for existing tickets: %v\n", err)
+		exit(1)
+	}
+	if len(existing) > 0 {
+		fmt.Printf("Found %d existing tickets from earlier runs\n", len(existing))
+	}
 
-	// Actually, let's just use the logic from generateTickets but pass the pre-built nodes.
-	createdTickets, err := createTicketsFromNodes(ctx, tickets, projectKey, repoUrl, allLabels, jiraClient)
+	// 6. Create tickets using existing helper
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	createdTickets, err := createTicketsFromNodes(ctx, tickets, projectKey, repoUrl, allLabels, jiraClient, &ticketReuse{existing: existing, update: update}, concurrency)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating tickets: %v\n", err)
 		exit(1)
 	}
 
-	// 6. Output JSON
+	// 7. Output JSON
 	outputPath, _ := cmd.Flags().GetString("output-json")
 	if outputPath != "" {
 		data, _ := json.MarshalIndent(createdTickets, "", "  ")
@@ -633,6 +945,8 @@ func init() {
 	jiraGenerateFromSpecCmd.Flags().StringSliceP("label", "l", []string{}, "Custom labels to add to generated tickets")
 	jiraGenerateFromSpecCmd.Flags().String("output-json", "", "Path to write the created ticket mapping (Title -> Key) in JSON format")
 	jiraGenerateFromSpecCmd.Flags().String("repo-url", "", "Repository URL to include in ticket descriptions")
+	jiraGenerateFromSpecCmd.Flags().Bool("incremental", false, "Create only top-level tickets now; their children are created when the orchestrator picks them up")
+	jiraGenerateFromSpecCmd.Flags().String("resume", "", "Checkpoint of an interrupted run to continue (default checkpoint: "+defaultTicketCheckpoint+")")
 	jiraCmd.AddCommand(jiraGenerateFromSpecCmd)
 
 	jiraGenerateFromArchCmd.Flags().String("arch", ".recac/architecture/architecture.yaml", "Path to architecture.yaml")
@@ -641,6 +955,8 @@ func init() {
 	jiraGenerateFromArchCmd.Flags().String("repo-url", "", "Repository URL to include in descriptions")
 	jiraGenerateFromArchCmd.Flags().StringSliceP("label", "l", []string{}, "Labels")
 	jiraGenerateFromArchCmd.Flags().String("output-json", "", "Output JSON path")
+	jiraGenerateFromArchCmd.Flags().Bool("update", false, "Update descriptions/criteria of tickets created by earlier runs (default: skip them)")
+	jiraGenerateFromArchCmd.Flags().Int("concurrency", 4, "Maximum number of tickets created at once; children wait for their parent")
 	viper.BindPFlag("repo_url", jiraGenerateFromArchCmd.Flags().Lookup("repo-url"))
 	jiraCmd.AddCommand(jiraGenerateFromArchCmd)
 }
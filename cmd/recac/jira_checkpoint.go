@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// defaultTicketCheckpoint is where generate-from-spec records its progress
+// unless --resume names another checkpoint.
+const defaultTicketCheckpoint = ".recac/jira-checkpoint.json"
+
+// ticketCheckpoint records the ticket plan of a generation run and the tickets
+// created so far, so an interrupted run can be resumed without duplicates.
+type ticketCheckpoint struct {
+	path string
+
+	Tickets []ticketNode      `json:"tickets"`
+	Created map[string]string `json:"created"` // Title -> ticket key
+}
+
+func newTicketCheckpoint(path string) *ticketCheckpoint {
+	return &ticketCheckpoint{path: path, Created: make(map[string]string)}
+}
+
+// loadTicketCheckpoint reads the checkpoint written by an earlier run.
+func loadTicketCheckpoint(path string) (*ticketCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", path, err)
+	}
+	checkpoint := newTicketCheckpoint(path)
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	if len(checkpoint.Tickets) == 0 {
+		return nil, fmt.Errorf("checkpoint %s has no ticket plan", path)
+	}
+	if checkpoint.Created == nil {
+		checkpoint.Created = make(map[string]string)
+	}
+	return checkpoint, nil
+}
+
+// save writes the checkpoint through a temporary file, so an interruption
+// never leaves it half written.
+func (c *ticketCheckpoint) save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
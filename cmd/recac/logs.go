@@ -58,6 +58,19 @@ var logsCmd = &cobra.Command{
 			exit(1)
 		}
 
+		// Helper to process line
+		processLine := func(line string) {
+			if filter == "" || strings.Contains(line, filter) {
+				fmt.Fprint(cmd.OutOrStdout(), line)
+			}
+		}
+
+		// Rotated files first, oldest first
+		if err := readRotatedLogs(logFile, processLine); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error reading log file: %v\n", err)
+			exit(1)
+		}
+
 		file, err := os.Open(logFile)
 		if err != nil {
 			fmt.Fprintf(cmd.ErrOrStderr(), "Error: failed to open log file: %v\n", err)
@@ -66,17 +79,12 @@ var logsCmd = &cobra.Command{
 		defer file.Close()
 
 		reader := bufio.NewReader(file)
-
-		// Helper to process line
-		processLine := func(line string) {
-			if filter == "" || strings.Contains(line, filter) {
-				fmt.Fprint(cmd.OutOrStdout(), line)
-			}
-		}
+		var offset int64
 
 		// Initial read
 		for {
 			line, err := reader.ReadString('\n')
+			offset += int64(len(line))
 			if err != nil {
 				if err == io.EOF {
 					if line != "" {
@@ -94,8 +102,15 @@ var logsCmd = &cobra.Command{
 			// Follow mode
 			for {
 				line, err := reader.ReadString('\n')
+				offset += int64(len(line))
 				if err != nil {
 					if err == io.EOF {
+						// The session rotated its log: continue from the start of the truncated file
+						if info, statErr := file.Stat(); statErr == nil && info.Size() < offset {
+							file.Seek(0, io.SeekStart)
+							reader.Reset(file)
+							offset = 0
+						}
 						time.Sleep(500 * time.Millisecond)
 						continue
 					}
@@ -140,6 +155,10 @@ func streamAllRunningSessions(cmd *cobra.Command, sm ISessionManager, follow boo
 				return
 			}
 
+			readRotatedLogs(logFile, func(line string) {
+				logChan <- fmt.Sprintf("[%s] %s", s.Name, line)
+			})
+
 			file, err := os.Open(logFile)
 			if err != nil {
 				logChan <- fmt.Sprintf("[%s] Error: failed to open log file: %v\n", s.Name, err)
@@ -185,3 +204,21 @@ func streamAllRunningSessions(cmd *cobra.Command, sm ISessionManager, follow boo
 		}
 	}
 }
+
+// readRotatedLogs passes each line of the rotated files of logFile, oldest
+// first, to processLine. The log itself is not read.
+func readRotatedLogs(logFile string, processLine func(string)) error {
+	files := runner.SessionLogFiles(logFile)
+	for _, rotated := range files[:len(files)-1] {
+		data, err := os.ReadFile(rotated)
+		if err != nil {
+			return err
+		}
+		for _, line := range strings.SplitAfter(string(data), "\n") {
+			if line != "" {
+				processLine(line)
+			}
+		}
+	}
+	return nil
+}
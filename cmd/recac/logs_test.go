@@ -96,6 +96,23 @@ func TestLogsCmd(t *testing.T) {
 		assert.NotContains(t, output, "session 2")
 	})
 
+	t.Run("logs reads rotated files first", func(t *testing.T) {
+		mockSM, cleanup := setupLogsTest(t)
+		defer cleanup()
+
+		logFile := mockSM.Sessions["session1"].LogFile
+		require.NoError(t, os.WriteFile(logFile+".2", []byte("oldest line\n"), 0644))
+		require.NoError(t, os.WriteFile(logFile+".1", []byte("rotated line\n"), 0644))
+
+		output, err := executeCommand(rootCmd, "logs", "session1")
+		require.NoError(t, err)
+		assert.Equal(t, "oldest line\nrotated line\nsession 1 log line 1\nsession 1 log line 2\n", output)
+
+		output, err = executeCommand(rootCmd, "logs", "--all", "--filter", "rotated")
+		require.NoError(t, err)
+		assert.Contains(t, output, "[session1] rotated line")
+	})
+
 	t.Run("logs --all with filter", func(t *testing.T) {
 		_, cleanup := setupLogsTest(t)
 		defer cleanup()
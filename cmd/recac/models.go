@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	modelsHTTPClient    = &http.Client{Timeout: 30 * time.Second}
+	openRouterModelsURL = "https://openrouter.ai/api/v1/models"
+	geminiModelsURL     = "https://generativelanguage.googleapis.com/v1beta/models"
+)
+
+// modelCatalogFiles maps providers to the model catalog file read by the TUI and 'config list-models'.
+var modelCatalogFiles = map[string]string{
+	"openrouter": "openrouter-models.json",
+	"gemini":     "gemini-models.json",
+}
+
+// catalogModel is a single entry in a model catalog file.
+type catalogModel struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+var modelsCmd = &cobra.Command{
+	Use:   "models",
+	Short: "Manage provider model catalogs",
+}
+
+var modelsRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Refresh a provider's model catalog",
+	Long: `Fetch the current model catalog from a provider's API and write the JSON file
+used by the /model picker and 'recac config list-models'.
+
+Supported providers: openrouter, gemini (requires GEMINI_API_KEY).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, _ := cmd.Flags().GetString("provider")
+		output, _ := cmd.Flags().GetString("output")
+
+		filename, ok := modelCatalogFiles[provider]
+		if !ok {
+			return fmt.Errorf("unsupported provider %q: expected openrouter or gemini", provider)
+		}
+		if output == "" {
+			output = defaultModelCatalogPath(filename)
+		}
+
+		ctx := context.Background()
+		var models []catalogModel
+		var err error
+		switch provider {
+		case "openrouter":
+			models, err = fetchOpenRouterModels(ctx)
+		case "gemini":
+			models, err = fetchGeminiModels(ctx, geminiAPIKey())
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s models: %w", provider, err)
+		}
+		if len(models) == 0 {
+			return fmt.Errorf("%s returned no models", provider)
+		}
+
+		if err := writeModelCatalog(output, models); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d %s models to %s\n", len(models), provider, output)
+		return nil
+	},
+}
+
+func init() {
+	modelsRefreshCmd.Flags().String("provider", "openrouter", "Provider to refresh (openrouter, gemini)")
+	modelsRefreshCmd.Flags().StringP("output", "o", "", "Output file (default: internal/data/<provider>-models.json if present, else ./<provider>-models.json)")
+	modelsCmd.AddCommand(modelsRefreshCmd)
+	rootCmd.AddCommand(modelsCmd)
+}
+
+// defaultModelCatalogPath mirrors the lookup order of loadModelsFromFile.
+func defaultModelCatalogPath(filename string) string {
+	dataDir := filepath.Join("internal", "data")
+	if info, err := os.Stat(dataDir); err == nil && info.IsDir() {
+		return filepath.Join(dataDir, filename)
+	}
+	return filename
+}
+
+// geminiAPIKey prefers GEMINI_API_KEY, since the global api_key may belong to
+// another provider.
+func geminiAPIKey() string {
+	if key := os.Getenv("GEMINI_API_KEY"); key != "" {
+		return key
+	}
+	return viper.GetString("api_key")
+}
+
+// fetchOpenRouterModels lists the models available through OpenRouter.
+func fetchOpenRouterModels(ctx context.Context) ([]catalogModel, error) {
+	var response struct {
+		Data []struct {
+			ID          string `json:"id"`
+			Name        string `json:"name"`
+			Description string `json:"description"`
+		} `json:"data"`
+	}
+	if err := getModelsJSON(ctx, openRouterModelsURL, nil, &response); err != nil {
+		return nil, err
+	}
+
+	var models []catalogModel
+	for _, m := range response.Data {
+		models = append(models, catalogModel{Name: m.ID, DisplayName: m.Name, Description: firstLine(m.Description)})
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].Name < models[j].Name })
+	return models, nil
+}
+
+// fetchGeminiModels lists the Gemini models that support content generation.
+func fetchGeminiModels(ctx context.Context, apiKey string) ([]catalogModel, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("GEMINI_API_KEY is required")
+	}
+	headers := map[string]string{"x-goog-api-key": apiKey}
+
+	var models []catalogModel
+	pageToken := ""
+	for {
+		query := url.Values{"pageSize": {"1000"}}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		var response struct {
+			Models []struct {
+				Name                       string   `json:"name"`
+				DisplayName                string   `json:"displayName"`
+				Description                string   `json:"description"`
+				SupportedGenerationMethods []string `json:"supportedGenerationMethods"`
+			} `json:"models"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := getModelsJSON(ctx, geminiModelsURL+"?"+query.Encode(), headers, &response); err != nil {
+			return nil, err
+		}
+
+		for _, m := range response.Models {
+			if !slices.Contains(m.SupportedGenerationMethods, "generateContent") {
+				continue
+			}
+			models = append(models, catalogModel{Name: m.Name, DisplayName: m.DisplayName, Description: m.Description})
+		}
+
+		if response.NextPageToken == "" {
+			break
+		}
+		pageToken = response.NextPageToken
+	}
+	return models, nil
+}
+
+func getModelsJSON(ctx context.Context, endpoint string, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := modelsHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// writeModelCatalog writes models in the {"models": [...]} format read by loadModelsFromFile.
+func writeModelCatalog(path string, models []catalogModel) error {
+	data, err := json.MarshalIndent(map[string][]catalogModel{"models": models}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode models: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
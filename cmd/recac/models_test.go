@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func readCatalog(t *testing.T, path string) []catalogModel {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read catalog: %v", err)
+	}
+	var catalog struct {
+		Models []catalogModel `json:"models"`
+	}
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		t.Fatalf("invalid catalog JSON: %v", err)
+	}
+	return catalog.Models
+}
+
+func TestModelsRefresh_OpenRouter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[
+			{"id":"z-ai/glm-4","name":"GLM 4","description":"Line one\nLine two"},
+			{"id":"anthropic/claude-sonnet-4.5","name":"Claude Sonnet 4.5","description":"Anthropic model"}
+		]}`))
+	}))
+	defer server.Close()
+
+	origURL := openRouterModelsURL
+	openRouterModelsURL = server.URL
+	defer func() { openRouterModelsURL = origURL }()
+
+	out := filepath.Join(t.TempDir(), "openrouter-models.json")
+	output, err := executeCommand(rootCmd, "models", "refresh", "--provider", "openrouter", "--output", out)
+	if err != nil {
+		t.Fatalf("refresh failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Wrote 2 openrouter models") {
+		t.Errorf("unexpected output: %s", output)
+	}
+
+	models := readCatalog(t, out)
+	if len(models) != 2 || models[0].Name != "anthropic/claude-sonnet-4.5" {
+		t.Fatalf("expected models sorted by id, got %+v", models)
+	}
+	if models[1].DisplayName != "GLM 4" || models[1].Description != "Line one" {
+		t.Errorf("unexpected model entry: %+v", models[1])
+	}
+}
+
+func TestModelsRefresh_Gemini(t *testing.T) {
+	t.Setenv("GEMINI_API_KEY", "test-key")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-goog-api-key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("pageToken") == "" {
+			w.Write([]byte(`{"models":[
+				{"name":"models/gemini-2.5-flash","displayName":"Gemini 2.5 Flash","supportedGenerationMethods":["generateContent"]},
+				{"name":"models/text-embedding-004","supportedGenerationMethods":["embedContent"]}
+			],"nextPageToken":"page2"}`))
+			return
+		}
+		w.Write([]byte(`{"models":[{"name":"models/gemini-2.5-pro","supportedGenerationMethods":["countTokens","generateContent"]}]}`))
+	}))
+	defer server.Close()
+
+	origURL := geminiModelsURL
+	geminiModelsURL = server.URL
+	defer func() { geminiModelsURL = origURL }()
+
+	out := filepath.Join(t.TempDir(), "gemini-models.json")
+	if output, err := executeCommand(rootCmd, "models", "refresh", "--provider", "gemini", "--output", out); err != nil {
+		t.Fatalf("refresh failed: %v\n%s", err, output)
+	}
+
+	models := readCatalog(t, out)
+	if len(models) != 2 || models[0].Name != "models/gemini-2.5-flash" || models[1].Name != "models/gemini-2.5-pro" {
+		t.Errorf("expected only generateContent models across pages, got %+v", models)
+	}
+}
+
+func TestModelsRefresh_UnsupportedProvider(t *testing.T) {
+	_, err := executeCommand(rootCmd, "models", "refresh", "--provider", "ollama", "--output", filepath.Join(t.TempDir(), "x.json"))
+	if err == nil || !strings.Contains(err.Error(), "unsupported provider") {
+		t.Errorf("expected unsupported provider error, got %v", err)
+	}
+}
+
+func TestGeminiAPIKey(t *testing.T) {
+	viper.Set("api_key", "openrouter-key")
+	defer viper.Set("api_key", "")
+
+	t.Setenv("GEMINI_API_KEY", "gemini-key")
+	if got := geminiAPIKey(); got != "gemini-key" {
+		t.Errorf("Expected GEMINI_API_KEY to win over api_key, got %q", got)
+	}
+
+	t.Setenv("GEMINI_API_KEY", "")
+	if got := geminiAPIKey(); got != "openrouter-key" {
+		t.Errorf("Expected the api_key fallback, got %q", got)
+	}
+}
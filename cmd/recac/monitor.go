@@ -9,9 +9,12 @@ import (
 )
 
 var monitorCmd = &cobra.Command{
-	Use:   "monitor",
-	Short: "Interactive session control center",
-	Long:  `Launches a Terminal UI (TUI) to monitor and control active sessions. Allows listing, killing, pausing, resuming, and viewing logs of sessions.`,
+	Use:     "monitor",
+	Aliases: []string{"sessions"},
+	Short:   "Interactive session control center",
+	Long: `Launches a Terminal UI (TUI) to monitor and control sessions. The list refreshes
+every few seconds and allows killing, pausing, resuming, archiving, renaming,
+viewing logs and viewing the git diff stat of sessions.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		sm, err := sessionManagerFactory()
 		if err != nil {
@@ -49,6 +52,15 @@ var monitorCmd = &cobra.Command{
 			GetLogs: func(name string) (string, error) {
 				return sm.GetSessionLogContent(name, 1000)
 			},
+			Archive: func(name string) error {
+				return sm.ArchiveSession(name)
+			},
+			Rename: func(oldName, newName string) error {
+				return sm.RenameSession(oldName, newName)
+			},
+			GetDiff: func(name string) (string, error) {
+				return sm.GetSessionGitDiffStat(name)
+			},
 		}
 
 		return ui.StartMonitorDashboard(callbacks)
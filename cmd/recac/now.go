@@ -146,7 +146,7 @@ func getCostFromState(s *runner.SessionState) (float64, int) {
 	if s.AgentStateFile != "" {
 		state, err := loadAgentState(s.AgentStateFile)
 		if err == nil && state != nil {
-			cost := agent.CalculateCost(state.Model, state.TokenUsage)
+			cost := agent.CalculateProviderCost(state.Provider, state.Model, state.TokenUsage)
 			tokens := state.TokenUsage.TotalTokens
 			return cost, tokens
 		}
@@ -2,12 +2,12 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"recac/internal/agent"
 	"recac/internal/cmdutils"
 	"recac/internal/docker"
 	"recac/internal/orchestrator"
@@ -40,6 +40,16 @@ var orchestrateCmd = &cobra.Command{
 		agentProvider := viper.GetString("orchestrator.agent_provider")
 		agentModel := viper.GetString("orchestrator.agent_model")
 
+		// Pin the agent image to a digest so the runtime refuses any other content
+		if digest := viper.GetString("orchestrator.image_digest"); digest != "" {
+			pinned, err := docker.PinImage(image, digest)
+			if err != nil {
+				logger.Error("Invalid image digest", "image", image, "error", err)
+				os.Exit(1)
+			}
+			image = pinned
+		}
+
 		query := viper.GetString("orchestrator.jira_query")
 		logger.Info("Starting Orchestrator", "mode", mode, "label", label, "query", query, "interval", interval, "agent_provider", agentProvider)
 
@@ -54,13 +64,19 @@ var orchestrateCmd = &cobra.Command{
 				logger.Error("Watch directory must be specified in file-dir poller mode")
 				os.Exit(1)
 			}
-			var err error
-			poller, err = orchestrator.NewFileDirPoller(watchDir)
+			lifecycle := viper.GetString("orchestrator.file_lifecycle")
+			if lifecycle != orchestrator.FileLifecycleMove && lifecycle != orchestrator.FileLifecycleIndex {
+				logger.Error("File lifecycle must be 'move' or 'index'", "file_lifecycle", lifecycle)
+				os.Exit(1)
+			}
+			dirPoller, err := orchestrator.NewFileDirPoller(watchDir)
 			if err != nil {
 				logger.Error("Failed to initialize file directory poller", "error", err)
 				os.Exit(1)
 			}
-			logger.Info("Using file directory poller", "directory", watchDir)
+			dirPoller.Lifecycle = lifecycle
+			poller = dirPoller
+			logger.Info("Using file directory poller", "directory", watchDir, "lifecycle", lifecycle)
 		case "file", "filesystem":
 			workFile := viper.GetString("orchestrator.work_file")
 			if workFile == "" {
@@ -69,6 +85,54 @@ var orchestrateCmd = &cobra.Command{
 			}
 			poller = orchestrator.NewFilePoller(workFile)
 			logger.Info("Using filesystem poller", "file", workFile)
+		case "github-project":
+			token := viper.GetString("orchestrator.github_token")
+			org := viper.GetString("orchestrator.github_owner")
+			number := viper.GetInt("orchestrator.github_project")
+			column := viper.GetString("orchestrator.github_project_column")
+			app, err := cmdutils.GetGitHubApp()
+			if err != nil {
+				logger.Error("Failed to load GitHub App", "error", err)
+				os.Exit(1)
+			}
+			if (token == "" && app == nil) || org == "" || number == 0 || column == "" {
+				logger.Error("GitHub token (or GitHub App), owner, project and column must be specified in github-project poller mode")
+				os.Exit(1)
+			}
+			projectPoller := orchestrator.NewGitHubProjectPoller(token, org, number, column)
+			if app != nil {
+				app.Authorize(projectPoller.Client)
+			}
+			poller = projectPoller
+			logger.Info("Using GitHub project poller", "org", org, "project", number, "column", column)
+		case "discord":
+			token := viper.GetString("orchestrator.discord_token")
+			if token == "" {
+				logger.Error("DISCORD_BOT_TOKEN must be set in discord poller mode")
+				os.Exit(1)
+			}
+			discordPoller := orchestrator.NewDiscordPoller(token, viper.GetString("orchestrator.discord_repo_url"))
+			discordPoller.Allow = orchestrator.DiscordAllowlist{
+				Guilds:   cmdutils.GetStringList("orchestrator.discord_guilds"),
+				Channels: cmdutils.GetStringList("orchestrator.discord_channels"),
+				Users:    cmdutils.GetStringList("orchestrator.discord_users"),
+				Roles:    cmdutils.GetStringList("orchestrator.discord_roles"),
+				Repos:    cmdutils.GetStringList("orchestrator.discord_repos"),
+			}
+			if len(discordPoller.Allow.Users) == 0 && len(discordPoller.Allow.Roles) == 0 {
+				logger.Error("--discord-users or --discord-roles must be set in discord poller mode")
+				os.Exit(1)
+			}
+			go func() {
+				if err := discordPoller.Listen(ctx, logger); err != nil && ctx.Err() == nil {
+					logger.Error("Discord listener stopped", "error", err)
+				}
+			}()
+			poller = discordPoller
+			logger.Info("Using Discord poller", "command", "/"+orchestrator.DiscordCommandName, "default_repo", discordPoller.DefaultRepoURL)
+		case "mock":
+			poller = orchestrator.NewMockPoller(orchestrator.MockWorkItems(viper.GetInt("orchestrator.mock_items")))
+			logger.Info("Using mock poller", "items", viper.GetInt("orchestrator.mock_items"))
 		default:
 			// Default to Jira
 			jClient, err := cmdutils.GetJiraClient(ctx)
@@ -77,26 +141,57 @@ var orchestrateCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			jql := viper.GetString("orchestrator.jira_query")
-			if jql == "" && label != "" {
-				jql = fmt.Sprintf("labels = \"%s\" AND statusCategory != Done ORDER BY created ASC", label)
+			if jql == "" {
+				jql = orchestrator.BuildJQL(label, cmdutils.GetStringList("orchestrator.jira_exclude_types"), cmdutils.GetStringList("orchestrator.jira_statuses"))
 			}
-			poller = orchestrator.NewJiraPoller(jClient, jql)
-			logger.Info("Using Jira poller", "label", label, "query", jql)
+			jiraPoller := orchestrator.NewJiraPoller(jClient, jql)
+			jiraPoller.MaxItems = viper.GetInt("orchestrator.max_items")
+			poller = jiraPoller
+			logger.Info("Using Jira poller", "label", label, "query", jql, "max_items", jiraPoller.MaxItems)
+		}
+
+		// Provider preflight: surface bad credentials or an unknown model before spawning anything.
+		// Mock mode never runs an agent, so there is nothing to check.
+		if viper.GetBool("orchestrator.provider_health") && mode != "mock" {
+			healthAgent, err := agentClientFactory(ctx, agentProvider, agentModel, "", "recac-orchestrator")
+			if err != nil {
+				logger.Error("Failed to initialize agent for provider health check", "error", err)
+				os.Exit(1)
+			}
+			if err := agent.Ping(ctx, healthAgent); err != nil {
+				logger.Error("Provider health check failed", "provider", agentProvider, "model", agentModel, "error", err)
+				os.Exit(1)
+			}
+			logger.Info("Provider health check passed", "provider", agentProvider, "model", agentModel)
 		}
 
 		// 3. Spawner
 		var spawner orchestrator.Spawner
+		// Agents get installation tokens minted here; the App's private key stays on this host
+		githubApp, err := cmdutils.GetGitHubApp()
+		if err != nil {
+			logger.Error("Failed to load GitHub App", "error", err)
+			os.Exit(1)
+		}
+
 		switch mode {
 		case "k8s", "kubernetes":
 			pullPolicy := corev1.PullPolicy(viper.GetString("orchestrator.image_pull_policy"))
 			if pullPolicy == "" {
 				pullPolicy = corev1.PullAlways
 			}
-			spawner, err = orchestrator.NewK8sSpawner(logger, image, namespace, agentProvider, agentModel, pullPolicy)
+			var k8sSpawner *orchestrator.K8sSpawner
+			k8sSpawner, err = orchestrator.NewK8sSpawner(logger, image, namespace, agentProvider, agentModel, pullPolicy)
 			if err != nil {
 				logger.Error("Failed to initialize K8s spawner", "error", err)
 				os.Exit(1)
 			}
+			k8sSpawner.RegistryAuth = cmdutils.GetRegistryAuth()
+			k8sSpawner.ImagePullSecret = viper.GetString("registry.pull_secret")
+			if githubApp != nil {
+				k8sSpawner.GitHubToken = githubApp
+			}
+			spawner = k8sSpawner
 		case "local", "docker":
 			projectName := "recac-orchestrator" // Or similar
 			dockerCli, err := docker.NewClient(projectName)
@@ -104,19 +199,30 @@ var orchestrateCmd = &cobra.Command{
 				logger.Error("Failed to initialize Docker client", "error", err)
 				os.Exit(1)
 			}
+			dockerCli.RegistryAuth = cmdutils.GetRegistryAuth()
 			sm, err := runner.NewSessionManager()
 			if err != nil {
 				logger.Error("Failed to initialize Session Manager", "error", err)
 				os.Exit(1)
 			}
-			spawner = orchestrator.NewDockerSpawner(logger, dockerCli, image, projectName, poller, agentProvider, agentModel, sm)
+			dockerSpawner := orchestrator.NewDockerSpawner(logger, dockerCli, image, projectName, poller, agentProvider, agentModel, sm)
+			if githubApp != nil {
+				dockerSpawner.GitHubToken = githubApp
+			}
+			spawner = dockerSpawner
+		case "mock":
+			mockSpawner := orchestrator.NewMockSpawner()
+			mockSpawner.Logger = logger
+			spawner = mockSpawner
+			logger.Info("Using mock spawner, no agents will be launched")
 		default:
-			logger.Error("Invalid mode. Use 'local' or 'k8s'", "mode", mode)
+			logger.Error("Invalid mode. Use 'local', 'k8s', or 'mock'", "mode", mode)
 			os.Exit(1)
 		}
 
 		// 4. Orchestrator
 		orch := orchestrator.New(poller, spawner, interval)
+		orch.IntervalJitter = viper.GetFloat64("orchestrator.interval_jitter")
 		if err := orch.Run(ctx, logger); err != nil {
 			if ctx.Err() != nil {
 				// Graceful shutdown
@@ -129,33 +235,69 @@ var orchestrateCmd = &cobra.Command{
 }
 
 func init() {
-	orchestrateCmd.Flags().String("mode", "local", "Orchestrator mode: 'local' (Docker) or 'k8s' (Kubernetes Job)")
+	orchestrateCmd.Flags().String("mode", "local", "Orchestrator mode: 'local' (Docker), 'k8s' (Kubernetes Job), or 'mock' (record spawns only)")
 	orchestrateCmd.Flags().String("jira-label", "recac-agent", "Jira label to poll for")
 	orchestrateCmd.Flags().String("image", "ghcr.io/process-failed-successfully/recac-agent:latest", "Agent image to spawn")
+	orchestrateCmd.Flags().String("image-digest", "", "Pin the agent image to this digest (sha256:...)")
 	orchestrateCmd.Flags().String("namespace", "default", "Kubernetes namespace (for k8s mode)")
 	orchestrateCmd.Flags().Duration("interval", 1*time.Minute, "Polling interval")
+	orchestrateCmd.Flags().Float64("interval-jitter", 0, "Randomize each polling interval by up to this fraction (0.2 = ±20%)")
 	orchestrateCmd.Flags().String("agent-provider", "openrouter", "Provider for spawned agents")
 	orchestrateCmd.Flags().String("agent-model", "mistralai/devstral-2512:free", "Model for spawned agents")
 	orchestrateCmd.Flags().String("image-pull-policy", "Always", "Image pull policy for agents (Always, IfNotPresent, Never)")
+	orchestrateCmd.Flags().Bool("provider-health", true, "Verify the agent provider and model are reachable at startup")
 
 	orchestrateCmd.Flags().String("jira-query", "", "Custom JQL query (overrides label)")
-	orchestrateCmd.Flags().String("poller", "jira", "Poller type: 'jira', 'file', or 'file-dir'")
+	orchestrateCmd.Flags().StringSlice("jira-exclude-types", nil, "Issue types to skip, e.g. Epic,Sub-task (ignored with --jira-query)")
+	orchestrateCmd.Flags().StringSlice("jira-statuses", nil, "Only pick up issues in these statuses (default: any status not Done; ignored with --jira-query)")
+	orchestrateCmd.Flags().Int("max-items", 0, "Maximum number of Jira work items to spawn per poll (0 = unlimited)")
+	orchestrateCmd.Flags().String("poller", "jira", "Poller type: 'jira', 'github-project', 'discord', 'file', 'file-dir', or 'mock'")
 	orchestrateCmd.Flags().String("work-file", "work_items.json", "Work items file (for 'file' poller)")
 	orchestrateCmd.Flags().String("watch-dir", "", "Directory to watch for work item files (for 'file-dir' poller)")
+	orchestrateCmd.Flags().String("file-lifecycle", orchestrator.FileLifecycleMove, "How spawned work files are retired: 'move' (to processed/) or 'index' (for 'file-dir' poller)")
+	orchestrateCmd.Flags().String("github-token", "", "GitHub API token (for 'github-project' poller)")
+	orchestrateCmd.Flags().String("github-owner", "", "GitHub organization owning the project (for 'github-project' poller)")
+	orchestrateCmd.Flags().Int("github-project", 0, "GitHub organization project number (for 'github-project' poller)")
+	orchestrateCmd.Flags().String("github-project-column", "Todo", "Project column (Status value) to pick up items from (for 'github-project' poller)")
+	orchestrateCmd.Flags().String("discord-repo-url", "", "Repository used when a /recac command names none (for 'discord' poller)")
+	orchestrateCmd.Flags().StringSlice("discord-guilds", nil, "Guild IDs /recac is accepted from (for 'discord' poller; default any)")
+	orchestrateCmd.Flags().StringSlice("discord-channels", nil, "Channel IDs /recac is accepted from (for 'discord' poller; default any)")
+	orchestrateCmd.Flags().StringSlice("discord-users", nil, "User IDs allowed to run /recac (for 'discord' poller)")
+	orchestrateCmd.Flags().StringSlice("discord-roles", nil, "Role IDs whose members may run /recac (for 'discord' poller)")
+	orchestrateCmd.Flags().StringSlice("discord-repos", nil, "Repositories /recac may name besides --discord-repo-url (for 'discord' poller)")
+	orchestrateCmd.Flags().Int("mock-items", 3, "Number of generated work items (for 'mock' poller)")
 
 	viper.BindPFlag("orchestrator.jira_query", orchestrateCmd.Flags().Lookup("jira-query"))
+	viper.BindPFlag("orchestrator.jira_exclude_types", orchestrateCmd.Flags().Lookup("jira-exclude-types"))
+	viper.BindPFlag("orchestrator.jira_statuses", orchestrateCmd.Flags().Lookup("jira-statuses"))
+	viper.BindPFlag("orchestrator.max_items", orchestrateCmd.Flags().Lookup("max-items"))
 	viper.BindPFlag("orchestrator.poller", orchestrateCmd.Flags().Lookup("poller"))
 	viper.BindPFlag("orchestrator.work_file", orchestrateCmd.Flags().Lookup("work-file"))
 	viper.BindPFlag("orchestrator.watch_dir", orchestrateCmd.Flags().Lookup("watch-dir"))
+	viper.BindPFlag("orchestrator.file_lifecycle", orchestrateCmd.Flags().Lookup("file-lifecycle"))
+	viper.BindPFlag("orchestrator.github_token", orchestrateCmd.Flags().Lookup("github-token"))
+	viper.BindPFlag("orchestrator.github_owner", orchestrateCmd.Flags().Lookup("github-owner"))
+	viper.BindPFlag("orchestrator.github_project", orchestrateCmd.Flags().Lookup("github-project"))
+	viper.BindPFlag("orchestrator.github_project_column", orchestrateCmd.Flags().Lookup("github-project-column"))
+	viper.BindPFlag("orchestrator.discord_repo_url", orchestrateCmd.Flags().Lookup("discord-repo-url"))
+	viper.BindPFlag("orchestrator.discord_guilds", orchestrateCmd.Flags().Lookup("discord-guilds"))
+	viper.BindPFlag("orchestrator.discord_channels", orchestrateCmd.Flags().Lookup("discord-channels"))
+	viper.BindPFlag("orchestrator.discord_users", orchestrateCmd.Flags().Lookup("discord-users"))
+	viper.BindPFlag("orchestrator.discord_roles", orchestrateCmd.Flags().Lookup("discord-roles"))
+	viper.BindPFlag("orchestrator.discord_repos", orchestrateCmd.Flags().Lookup("discord-repos"))
+	viper.BindPFlag("orchestrator.mock_items", orchestrateCmd.Flags().Lookup("mock-items"))
 
 	viper.BindPFlag("orchestrator.mode", orchestrateCmd.Flags().Lookup("mode"))
 	viper.BindPFlag("orchestrator.jira_label", orchestrateCmd.Flags().Lookup("jira-label"))
 	viper.BindPFlag("orchestrator.image", orchestrateCmd.Flags().Lookup("image"))
+	viper.BindPFlag("orchestrator.image_digest", orchestrateCmd.Flags().Lookup("image-digest"))
 	viper.BindPFlag("orchestrator.namespace", orchestrateCmd.Flags().Lookup("namespace"))
 	viper.BindPFlag("orchestrator.interval", orchestrateCmd.Flags().Lookup("interval"))
+	viper.BindPFlag("orchestrator.interval_jitter", orchestrateCmd.Flags().Lookup("interval-jitter"))
 	viper.BindPFlag("orchestrator.agent_provider", orchestrateCmd.Flags().Lookup("agent-provider"))
 	viper.BindPFlag("orchestrator.agent_model", orchestrateCmd.Flags().Lookup("agent-model"))
 	viper.BindPFlag("orchestrator.image_pull_policy", orchestrateCmd.Flags().Lookup("image-pull-policy"))
+	viper.BindPFlag("orchestrator.provider_health", orchestrateCmd.Flags().Lookup("provider-health"))
 
 	// Explicitly bind cleaner env vars
 	viper.BindEnv("orchestrator.agent_provider", "RECAC_AGENT_PROVIDER")
@@ -163,11 +305,28 @@ func init() {
 	viper.BindEnv("orchestrator.poller", "RECAC_POLLER")
 	viper.BindEnv("orchestrator.work_file", "RECAC_WORK_FILE")
 	viper.BindEnv("orchestrator.watch_dir", "RECAC_WATCH_DIR")
+	viper.BindEnv("orchestrator.file_lifecycle", "RECAC_FILE_LIFECYCLE")
+	viper.BindEnv("orchestrator.github_token", "RECAC_GITHUB_TOKEN", "GITHUB_TOKEN")
+	viper.BindEnv("orchestrator.github_owner", "RECAC_GITHUB_OWNER")
+	viper.BindEnv("orchestrator.github_project", "RECAC_GITHUB_PROJECT")
+	viper.BindEnv("orchestrator.github_project_column", "RECAC_GITHUB_PROJECT_COLUMN")
+	viper.BindEnv("orchestrator.discord_token", "DISCORD_BOT_TOKEN")
+	viper.BindEnv("orchestrator.discord_repo_url", "RECAC_DISCORD_REPO_URL")
+	viper.BindEnv("orchestrator.discord_guilds", "RECAC_DISCORD_GUILDS")
+	viper.BindEnv("orchestrator.discord_channels", "RECAC_DISCORD_CHANNELS")
+	viper.BindEnv("orchestrator.discord_users", "RECAC_DISCORD_USERS")
+	viper.BindEnv("orchestrator.discord_roles", "RECAC_DISCORD_ROLES")
+	viper.BindEnv("orchestrator.discord_repos", "RECAC_DISCORD_REPOS")
+	viper.BindEnv("orchestrator.mock_items", "RECAC_MOCK_ITEMS")
 	viper.BindEnv("orchestrator.mode", "RECAC_ORCHESTRATOR_MODE")
 	viper.BindEnv("orchestrator.image", "RECAC_ORCHESTRATOR_IMAGE")
+	viper.BindEnv("orchestrator.image_digest", "RECAC_ORCHESTRATOR_IMAGE_DIGEST")
 	viper.BindEnv("orchestrator.namespace", "RECAC_ORCHESTRATOR_NAMESPACE")
 	viper.BindEnv("orchestrator.interval", "RECAC_ORCHESTRATOR_INTERVAL")
+	viper.BindEnv("orchestrator.interval_jitter", "RECAC_ORCHESTRATOR_INTERVAL_JITTER")
+	viper.BindEnv("orchestrator.max_items", "RECAC_ORCHESTRATOR_MAX_ITEMS")
 	viper.BindEnv("orchestrator.image_pull_policy", "RECAC_IMAGE_PULL_POLICY")
+	viper.BindEnv("orchestrator.provider_health", "RECAC_ORCHESTRATOR_PROVIDER_HEALTH")
 	viper.BindEnv("orchestrator.max_iterations", "RECAC_MAX_ITERATIONS")
 	viper.BindEnv("orchestrator.manager_frequency", "RECAC_MANAGER_FREQUENCY")
 	viper.BindEnv("orchestrator.task_max_iterations", "RECAC_TASK_MAX_ITERATIONS")
@@ -11,3 +11,17 @@ func TestOrchestrateFlags(t *testing.T) {
 	flag := orchestrateCmd.Flags().Lookup("watch-dir")
 	assert.NotNil(t, flag, "watch-dir flag should exist")
 }
+
+func TestOrchestrateProviderHealthFlag(t *testing.T) {
+	flag := orchestrateCmd.Flags().Lookup("provider-health")
+	if assert.NotNil(t, flag, "provider-health flag should exist") {
+		assert.Equal(t, "true", flag.DefValue, "the preflight runs by default")
+	}
+}
+
+func TestOrchestrateGitHubProjectFlags(t *testing.T) {
+	for _, name := range []string{"github-token", "github-owner", "github-project", "github-project-column"} {
+		assert.NotNil(t, orchestrateCmd.Flags().Lookup(name), "%s flag should exist", name)
+	}
+	assert.Equal(t, "Todo", orchestrateCmd.Flags().Lookup("github-project-column").DefValue)
+}
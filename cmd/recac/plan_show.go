@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"recac/internal/db"
+	"recac/internal/runner"
+
+	"github.com/spf13/cobra"
+)
+
+// newPlanShowCmd shows the running plan the Manager keeps across reviews.
+func newPlanShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show [session-name]",
+		Short: "Show the Manager's plan for a session",
+		Long: `Displays the plan the Manager agent keeps across reviews: the remaining steps,
+its standing notes and the outcome of its most recent reviews.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sm, err := sessionManagerFactory()
+			if err != nil {
+				return fmt.Errorf("failed to initialize session manager: %w", err)
+			}
+			session, err := sm.LoadSession(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to load session '%s': %w", args[0], err)
+			}
+			project, _ := cmd.Flags().GetString("project")
+			asJSON, _ := cmd.Flags().GetBool("json")
+			return showManagerPlan(cmd, session, project, asJSON)
+		},
+	}
+	cmd.Flags().String("project", "", "Project ID the plan was saved under (defaults to the workspace directory name)")
+	cmd.Flags().Bool("json", false, "Print the plan as JSON")
+	return cmd
+}
+
+// showManagerPlan prints the Manager plan stored in the session's database.
+func showManagerPlan(cmd *cobra.Command, session *runner.SessionState, project string, asJSON bool) error {
+	if session.Workspace == "" {
+		return fmt.Errorf("session '%s' has no workspace", session.Name)
+	}
+	if project == "" {
+		project = filepath.Base(session.Workspace)
+	}
+
+	dbPath := filepath.Join(session.Workspace, ".recac.db")
+	if _, err := os.Stat(dbPath); err != nil {
+		return fmt.Errorf("database not found at %s: %w", dbPath, err)
+	}
+	store, err := db.NewSQLiteStore(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer store.Close()
+
+	plan, err := runner.LoadManagerPlan(store, project)
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		cmd.Printf("No Manager plan recorded for project '%s' yet.\n", project)
+		return nil
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode plan: %w", err)
+		}
+		cmd.Println(string(data))
+		return nil
+	}
+	cmd.Printf("Manager plan for session '%s' (updated %s)\n\n", session.Name, plan.UpdatedAt.Format("2006-01-02 15:04"))
+	cmd.Println(plan.String())
+	return nil
+}
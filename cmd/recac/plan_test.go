@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"os"
@@ -9,6 +10,7 @@ import (
 
 	"recac/internal/agent"
 	"recac/internal/db"
+	"recac/internal/runner"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -192,3 +194,64 @@ Hope this helps!
 	require.NoError(t, err)
 	assert.Equal(t, "Text Project", list.ProjectName)
 }
+
+func TestPlanShowCmd(t *testing.T) {
+	workspace := filepath.Join(t.TempDir(), "proj")
+	require.NoError(t, os.MkdirAll(workspace, 0755))
+
+	store, err := db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	mockSM := NewMockSessionManager()
+	mockSM.Sessions["plan-session"] = &runner.SessionState{Name: "plan-session", Workspace: workspace, Status: "completed"}
+	originalFactory := sessionManagerFactory
+	sessionManagerFactory = func() (ISessionManager, error) { return mockSM, nil }
+	defer func() { sessionManagerFactory = originalFactory }()
+
+	t.Run("no plan yet", func(t *testing.T) {
+		cmd := NewPlanCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"show", "plan-session"})
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, out.String(), "No Manager plan recorded for project 'proj' yet.")
+	})
+
+	store, err = db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	require.NoError(t, err)
+	require.NoError(t, store.SetSignal("proj", runner.ManagerPlanSignal, `{"steps": ["Fix the login form"], "decisions": [{"iteration": 4, "approved": false, "completion_ratio": 0.5}]}`))
+	require.NoError(t, store.Close())
+
+	t.Run("text", func(t *testing.T) {
+		cmd := NewPlanCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"show", "plan-session"})
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, out.String(), "Manager plan for session 'plan-session'")
+		assert.Contains(t, out.String(), "1. Fix the login form")
+		assert.Contains(t, out.String(), "- Iteration 4")
+		assert.Contains(t, out.String(), "rejected at 50% complete")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		cmd := NewPlanCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"show", "plan-session", "--json"})
+		require.NoError(t, cmd.Execute())
+
+		var plan runner.ManagerPlan
+		require.NoError(t, json.Unmarshal(out.Bytes(), &plan))
+		assert.Equal(t, []string{"Fix the login form"}, plan.Steps)
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		cmd := NewPlanCmd()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"show", "missing"})
+		assert.Error(t, cmd.Execute())
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"recac/internal/agent"
 	"recac/internal/model"
+	"recac/internal/runner"
 	"recac/internal/ui"
 	"recac/internal/utils"
 	"sort"
@@ -45,6 +46,12 @@ func init() {
 	if psCmd.Flags().Lookup("stale") == nil {
 		psCmd.Flags().String("stale", "", "Filter sessions that have been inactive for a given duration (e.g., '7d', '24h')")
 	}
+	if psCmd.Flags().Lookup("tag") == nil {
+		psCmd.Flags().String("tag", "", "Filter sessions by tag")
+	}
+	if psCmd.Flags().Lookup("diff") == nil {
+		psCmd.Flags().Bool("diff", false, "Show lines added/removed by each session (running sessions are compared against HEAD)")
+	}
 	if psCmd.Flags().Lookup("watch") == nil {
 		psCmd.Flags().BoolP("watch", "w", false, "Enter watch mode with real-time updates")
 	}
@@ -67,13 +74,16 @@ var psCmd = &cobra.Command{
 		sessionName, _ := cmd.Flags().GetString("session")
 		watch, _ := cmd.Flags().GetBool("watch")
 		logLines, _ := cmd.Flags().GetInt("logs")
+		showChurn, _ := cmd.Flags().GetBool("diff")
 
 		filters := model.PsFilters{
 			Status:   cmd.Flag("status").Value.String(),
 			Since:    cmd.Flag("since").Value.String(),
 			Stale:    cmd.Flag("stale").Value.String(),
+			Tag:      cmd.Flag("tag").Value.String(),
 			Remote:   cmd.Flag("remote").Value.String() == "true",
 			LogLines: logLines,
+			Diff:     showChurn,
 		}
 
 		// --- Handle Watch Mode ---
@@ -116,7 +126,10 @@ var psCmd = &cobra.Command{
 
 		// --- Print Output ---
 		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
-		header := "NAME\tSTATUS\tCPU\tMEM\tLOCATION\tLAST USED\tGOAL"
+		header := "NAME\tSTATUS\tCPU\tMEM\tLOCATION\tLAST USED\tTAGS\tGOAL"
+		if showChurn {
+			header = "NAME\tSTATUS\tCPU\tMEM\tLOCATION\tLAST USED\tTAGS\tDIFF\tGOAL"
+		}
 		if showCosts {
 			header += "\tPROMPT_TOKENS\tCOMPLETION_TOKENS\tTOTAL_TOKENS\tCOST"
 		}
@@ -134,8 +147,21 @@ var psCmd = &cobra.Command{
 				goal = goal[:57] + "..."
 			}
 
-			baseOutput := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s",
-				s.Name, s.Status, s.CPU, s.Memory, s.Location, lastUsed, goal)
+			tags := strings.Join(s.Tags, ",")
+			if tags == "" {
+				tags = "-"
+			}
+
+			diffCol := ""
+			if showChurn {
+				diffCol = "-\t"
+				if s.Churn != "" {
+					diffCol = s.Churn + "\t"
+				}
+			}
+
+			baseOutput := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s%s",
+				s.Name, s.Status, s.CPU, s.Memory, s.Location, lastUsed, tags, diffCol, goal)
 
 			if showCosts {
 				if s.HasCost {
@@ -206,11 +232,12 @@ func getUnifiedSessions(cmd *cobra.Command, filters model.PsFilters) ([]model.Un
 			StartTime: s.StartTime,
 			EndTime:   s.EndTime,
 			Location:  "local",
+			Tags:      s.Tags,
 		}
 		// Calculate cost and tokens for local sessions
 		agentState, err := loadAgentState(s.AgentStateFile)
 		if err == nil {
-			us.Cost = agent.CalculateCost(agentState.Model, agentState.TokenUsage)
+			us.Cost = agent.CalculateProviderCost(agentState.Provider, agentState.Model, agentState.TokenUsage)
 			us.Tokens = agentState.TokenUsage
 			us.HasCost = true
 			us.LastActivity = agentState.LastActivity
@@ -241,6 +268,11 @@ func getUnifiedSessions(cmd *cobra.Command, filters model.PsFilters) ([]model.Un
 			}
 		}
 
+		// --- Get Diff churn if requested ---
+		if filters.Diff {
+			us.Churn = sessionChurn(s)
+		}
+
 		// --- Get Logs if requested ---
 		if filters.LogLines > 0 {
 			logs, err := sm.GetSessionLogContent(s.Name, filters.LogLines)
@@ -284,6 +316,17 @@ func getUnifiedSessions(cmd *cobra.Command, filters model.PsFilters) ([]model.Un
 		allSessions = filteredSessions
 	}
 
+	// --- Filter by Tag ---
+	if filters.Tag != "" {
+		var filteredSessions []model.UnifiedSession
+		for _, s := range allSessions {
+			if hasTag(s.Tags, filters.Tag) {
+				filteredSessions = append(filteredSessions, s)
+			}
+		}
+		allSessions = filteredSessions
+	}
+
 	// --- Filter by Stale ---
 	if filters.Stale != "" {
 		duration, err := utils.ParseStaleDuration(filters.Stale)
@@ -297,7 +340,7 @@ func getUnifiedSessions(cmd *cobra.Command, filters model.PsFilters) ([]model.Un
 			if s.Location == "k8s" {
 				activityTime = s.StartTime
 			}
-			if !activityTime.IsZero() && activityTime.Before(staleTime) {
+			if isStale(activityTime, staleTime) {
 				filteredSessions = append(filteredSessions, s)
 			}
 		}
@@ -365,3 +408,64 @@ func handleSingleSessionDiff(cmd *cobra.Command, sm ISessionManager, sessionName
 	cmd.Println(diff)
 	return nil
 }
+
+// sessionChurn returns the "+N/-N" lines changed between a session's start
+// commit and its end commit, or HEAD while the session has not recorded one.
+// It returns "" when the churn cannot be determined.
+func sessionChurn(session *runner.SessionState) string {
+	if session.StartCommitSHA == "" || session.Workspace == "" {
+		return ""
+	}
+	gitClient := gitClientFactory()
+	endSHA := session.EndCommitSHA
+	if endSHA == "" {
+		head, err := gitClient.CurrentCommitSHA(session.Workspace)
+		if err != nil {
+			return ""
+		}
+		endSHA = head
+	}
+	stat, err := gitClient.DiffStat(session.Workspace, session.StartCommitSHA, endSHA)
+	if err != nil {
+		return ""
+	}
+	insertions, deletions := parseDiffStatChurn(stat)
+	return fmt.Sprintf("+%d/-%d", insertions, deletions)
+}
+
+// parseDiffStatChurn extracts the insertion and deletion counts from the
+// summary line of `git diff --stat` output.
+func parseDiffStatChurn(stat string) (insertions, deletions int) {
+	lines := strings.Split(strings.TrimSpace(stat), "\n")
+	summary := lines[len(lines)-1]
+	for _, part := range strings.Split(summary, ",") {
+		var n int
+		part = strings.TrimSpace(part)
+		if _, err := fmt.Sscanf(part, "%d", &n); err != nil {
+			continue
+		}
+		switch {
+		case strings.Contains(part, "insertion"):
+			insertions = n
+		case strings.Contains(part, "deletion"):
+			deletions = n
+		}
+	}
+	return insertions, deletions
+}
+
+// isStale reports whether activity happened before staleTime.
+// Sessions with no recorded activity are never considered stale.
+func isStale(activity, staleTime time.Time) bool {
+	return !activity.IsZero() && activity.Before(staleTime)
+}
+
+// hasTag reports whether tags contains tag (case-insensitive).
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -606,3 +606,101 @@ func TestPsCommandWithSinceFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestPsCommandWithTagFilter(t *testing.T) {
+	sm, cleanup := setupTestSessionManager(t)
+	defer cleanup()
+
+	require.NoError(t, sm.SaveSession(&runner.SessionState{Name: "session-alpha", Status: "completed", StartTime: time.Now(), Tags: []string{"customer-a", "experiment"}}))
+	require.NoError(t, sm.SaveSession(&runner.SessionState{Name: "session-beta", Status: "completed", StartTime: time.Now(), Tags: []string{"customer-b"}}))
+	require.NoError(t, sm.SaveSession(&runner.SessionState{Name: "session-untagged", Status: "completed", StartTime: time.Now()}))
+
+	t.Run("tags are persisted and shown", func(t *testing.T) {
+		loaded, err := sm.LoadSession("session-alpha")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"customer-a", "experiment"}, loaded.Tags)
+
+		output, err := executeCommand(rootCmd, "ps")
+		require.NoError(t, err)
+		assert.Contains(t, output, "TAGS")
+		assert.Contains(t, output, "customer-a,experiment")
+		assert.Contains(t, output, "session-untagged")
+	})
+
+	t.Run("filter by tag", func(t *testing.T) {
+		output, err := executeCommand(rootCmd, "ps", "--tag", "Experiment")
+		require.NoError(t, err)
+		assert.Contains(t, output, "session-alpha")
+		assert.NotContains(t, output, "session-beta")
+		assert.NotContains(t, output, "session-untagged")
+	})
+
+	t.Run("filter with no matching tag", func(t *testing.T) {
+		output, err := executeCommand(rootCmd, "ps", "--tag", "customer-c")
+		require.NoError(t, err)
+		assert.Contains(t, output, "No sessions found.")
+	})
+}
+
+func TestPsCommandWithDiff(t *testing.T) {
+	sm, cleanup := setupTestSessionManager(t)
+	defer cleanup()
+
+	originalGitFactory := gitClientFactory
+	defer func() { gitClientFactory = originalGitFactory }()
+	var diffedTo []string
+	gitClientFactory = func() IGitClient {
+		return &MockGitClient{
+			CurrentCommitSHAFunc: func(repoPath string) (string, error) { return "head", nil },
+			DiffStatFunc: func(repoPath, commitA, commitB string) (string, error) {
+				diffedTo = append(diffedTo, commitB)
+				return " main.go | 14 +++++++++-----\n 1 file changed, 9 insertions(+), 5 deletions(-)", nil
+			},
+		}
+	}
+
+	workspace := t.TempDir()
+	require.NoError(t, sm.SaveSession(&runner.SessionState{Name: "session-done", Status: "completed", StartTime: time.Now(), Workspace: workspace, StartCommitSHA: "start", EndCommitSHA: "end"}))
+	require.NoError(t, sm.SaveSession(&runner.SessionState{Name: "session-nosha", Status: "completed", StartTime: time.Now()}))
+
+	output, err := executeCommand(rootCmd, "ps", "--diff")
+	require.NoError(t, err)
+	assert.Contains(t, output, "DIFF")
+	assert.Contains(t, output, "+9/-5")
+	assert.Equal(t, []string{"end"}, diffedTo)
+
+	output, err = executeCommand(rootCmd, "ps")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "DIFF")
+}
+
+func TestParseDiffStatChurn(t *testing.T) {
+	ins, del := parseDiffStatChurn(" a.go | 3 ++-\n 2 files changed, 12 insertions(+), 1 deletion(-)")
+	assert.Equal(t, 12, ins)
+	assert.Equal(t, 1, del)
+
+	ins, del = parseDiffStatChurn(" 1 file changed, 4 insertions(+)")
+	assert.Equal(t, 4, ins)
+	assert.Equal(t, 0, del)
+
+	ins, del = parseDiffStatChurn("")
+	assert.Equal(t, 0, ins)
+	assert.Equal(t, 0, del)
+}
+
+func TestSessionChurn_RunningUsesHead(t *testing.T) {
+	originalGitFactory := gitClientFactory
+	defer func() { gitClientFactory = originalGitFactory }()
+	gitClientFactory = func() IGitClient {
+		return &MockGitClient{
+			CurrentCommitSHAFunc: func(repoPath string) (string, error) { return "head-sha", nil },
+			DiffStatFunc: func(repoPath, commitA, commitB string) (string, error) {
+				assert.Equal(t, "head-sha", commitB)
+				return " 1 file changed, 2 deletions(-)", nil
+			},
+		}
+	}
+
+	churn := sessionChurn(&runner.SessionState{Name: "live", Status: "running", Workspace: "/ws", StartCommitSHA: "start"})
+	assert.Equal(t, "+0/-2", churn)
+}
@@ -3,20 +3,43 @@ package main
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
+
+	"recac/internal/db"
+	"recac/internal/notify"
+	"recac/internal/runner"
+	"recac/internal/security"
+	"recac/internal/telemetry"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 func init() {
 	rootCmd.AddCommand(replayCmd)
+	replayCmd.Flags().Bool("live", false, "Start a new session with the original command, re-querying the model")
+	replayCmd.Flags().String("project", "", "Project ID the observations were saved under (defaults to the workspace directory name)")
+	replayCmd.Flags().Bool("on-host", false, "Allow replayed commands to run directly on this machine (implied by host_mode)")
 }
 
 var replayCmd = &cobra.Command{
 	Use:   "replay [session-name]",
 	Short: "Replay a previous session",
-	Long: `Replay a previous session by starting a new one with the same command, workspace, and initial git state.
-The workspace will be checked out to the starting commit of the original session before execution.`,
+	Long: `Replay a previous session to reproduce its command execution.
+The workspace will be checked out to the starting commit of the original session before execution.
+
+By default, the agent responses stored in the session's database are replayed in order: the commands
+in each response are run again locally in the workspace, without querying the model, and the output is
+compared with the output recorded at the time. The commands run on this machine without container
+isolation, so this requires --on-host (or host_mode), and risky commands wait for confirmation as in
+safe mode.
+
+With --live, a new session is started with the same command, workspace, and initial git state, so the
+model is queried again.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		sessionName := args[0]
@@ -37,6 +60,12 @@ The workspace will be checked out to the starting commit of the original session
 			return errors.New("cannot replay a running session, please stop it first")
 		}
 
+		live, _ := cmd.Flags().GetBool("live")
+		onHost, _ := cmd.Flags().GetBool("on-host")
+		if !live && !onHost && !viper.GetBool("host_mode") {
+			return errors.New("replaying observations runs the recorded commands directly on this machine; pass --on-host (or enable host_mode) to allow it, or use --live")
+		}
+
 		// Restore original git state if possible
 		if originalSession.StartCommitSHA != "" {
 			gitClient := gitClientFactory()
@@ -47,6 +76,11 @@ The workspace will be checked out to the starting commit of the original session
 			}
 		}
 
+		if !live {
+			project, _ := cmd.Flags().GetString("project")
+			return replayObservations(cmd, originalSession, project)
+		}
+
 		// Find the next available replay name
 		replayName, err := findNextReplayName(sm, originalSession.Name)
 		if err != nil {
@@ -103,3 +137,88 @@ func findNextReplayName(sm ISessionManager, baseName string) (string, error) {
 
 	return fmt.Sprintf("%s%d", prefix, maxReplayNum+1), nil
 }
+
+// replayObservations re-runs the commands of every agent response stored in
+// the session's database, oldest first, and reports whether each output still
+// matches the one recorded after it. The model is never queried and no
+// observations are written to the database. Commands run in safe mode, so a
+// risky one waits for agent-bridge confirm/deny.
+func replayObservations(cmd *cobra.Command, session *runner.SessionState, project string) error {
+	if session.Workspace == "" {
+		return fmt.Errorf("session '%s' has no workspace", session.Name)
+	}
+	if project == "" {
+		project = filepath.Base(session.Workspace)
+	}
+
+	dbPath := filepath.Join(session.Workspace, ".recac.db")
+	if _, err := os.Stat(dbPath); err != nil {
+		return fmt.Errorf("database not found at %s: %w", dbPath, err)
+	}
+	store, err := db.NewSQLiteStore(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer store.Close()
+
+	history, err := store.QueryHistoryPage(project, db.HistoryQuery{Ascending: true})
+	if err != nil {
+		return fmt.Errorf("failed to read observations: %w", err)
+	}
+	if len(history) == 0 {
+		cmd.Printf("No observations recorded for project '%s'.\n", project)
+		return nil
+	}
+
+	policy, err := security.LoadCommandPolicy(filepath.Join(session.Workspace, runner.CommandPolicyFile))
+	if err != nil {
+		return err
+	}
+	notifier := notify.NewManager(telemetry.LogInfof)
+	notifier.SetProject(project)
+	replay := &runner.Session{
+		Workspace:     session.Workspace,
+		Project:       project,
+		UseLocalAgent: true,
+		CommandPolicy: policy,
+		SafeMode:      true,
+		DBStore:       store,
+		Notifier:      notifier,
+		Logger:        slog.New(slog.NewTextHandler(cmd.ErrOrStderr(), &slog.HandlerOptions{Level: slog.LevelWarn})),
+	}
+
+	steps, diverged := 0, 0
+	for i, obs := range history {
+		// Execution output and human sign-offs are what the responses are checked against
+		if obs.AgentID == "System" || obs.AgentID == "Human" {
+			continue
+		}
+		steps++
+		cmd.Printf("=== Step %d: %s response (%s) ===\n", steps, obs.AgentID, obs.CreatedAt.Format(time.RFC3339))
+
+		output, err := replay.ProcessResponse(cmd.Context(), obs.Content)
+		if err != nil {
+			cmd.Printf("Error: %v\n", err)
+		}
+		if output == "" {
+			cmd.Println("(no commands executed)")
+		} else {
+			cmd.Print(output)
+		}
+
+		// The loop saves the execution output right after the response, if there was any
+		recorded := ""
+		if i+1 < len(history) && history[i+1].AgentID == "System" {
+			recorded = history[i+1].Content
+		}
+		if output == recorded {
+			cmd.Println("Output matches the recorded run.")
+		} else {
+			diverged++
+			cmd.Println("Output differs from the recorded run.")
+		}
+	}
+
+	cmd.Printf("\nReplayed %d responses, %d diverged from the recorded run.\n", steps, diverged)
+	return nil
+}
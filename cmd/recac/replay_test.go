@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
 	"os"
+	"path/filepath"
+	"recac/internal/db"
 	"recac/internal/runner"
 	"testing"
 
@@ -49,7 +52,7 @@ func TestReplayCmd(t *testing.T) {
 
 	// Execute the command
 	cmd, _, _ := newRootCmd()
-	output, err := executeCommand(cmd, "replay", "test-session")
+	output, err := executeCommand(cmd, "replay", "test-session", "--live")
 
 	// Assertions
 	require.NoError(t, err)
@@ -63,6 +66,80 @@ func TestReplayCmd(t *testing.T) {
 	assert.Equal(t, originalSession.Workspace, replayedSession.Workspace)
 }
 
+func TestReplayCmd_Observations(t *testing.T) {
+	workspace := filepath.Join(t.TempDir(), "proj")
+	require.NoError(t, os.MkdirAll(workspace, 0755))
+
+	store, err := db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	require.NoError(t, err)
+	require.NoError(t, store.SaveObservation("proj", "Coding", "```bash\necho same\n```"))
+	require.NoError(t, store.SaveObservation("proj", "System", "Command Output:\nsame\n\n"))
+	require.NoError(t, store.SaveObservation("proj", "Human", "Looks good"))
+	require.NoError(t, store.SaveObservation("proj", "Coding", "```bash\necho changed\n```"))
+	require.NoError(t, store.SaveObservation("proj", "System", "Command Output:\noriginal\n\n"))
+	require.NoError(t, store.Close())
+
+	mockSM := NewMockSessionManager()
+	mockSM.IsProcessRunningFunc = func(pid int) bool { return false }
+	mockSM.Sessions["obs-session"] = &runner.SessionState{
+		Name:      "obs-session",
+		Command:   []string{"/bin/echo", "hello"},
+		Workspace: workspace,
+		Status:    "completed",
+	}
+
+	originalFactory := sessionManagerFactory
+	sessionManagerFactory = func() (ISessionManager, error) {
+		return mockSM, nil
+	}
+	defer func() { sessionManagerFactory = originalFactory }()
+
+	cmd, _, _ := newRootCmd()
+	replayCmd.SetContext(context.Background()) // Earlier tests may leave a cancelled context behind
+	output, err := executeCommand(cmd, "replay", "obs-session", "--on-host")
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "=== Step 1: Coding response")
+	assert.Contains(t, output, "Command Output:\nchanged")
+	assert.Contains(t, output, "Output matches the recorded run.")
+	assert.Contains(t, output, "Output differs from the recorded run.")
+	assert.Contains(t, output, "Replayed 2 responses, 1 diverged from the recorded run.")
+	assert.NotContains(t, mockSM.Sessions, "obs-session-replay-1", "replaying observations must not start a session")
+}
+
+func TestReplayCmd_ObservationsRequireOnHost(t *testing.T) {
+	mockSM := NewMockSessionManager()
+	mockSM.IsProcessRunningFunc = func(pid int) bool { return false }
+	mockSM.Sessions["obs-session"] = &runner.SessionState{
+		Name:           "obs-session",
+		Workspace:      t.TempDir(),
+		Status:         "completed",
+		StartCommitSHA: "abcdef123",
+	}
+
+	originalSMFactory := sessionManagerFactory
+	sessionManagerFactory = func() (ISessionManager, error) {
+		return mockSM, nil
+	}
+	defer func() { sessionManagerFactory = originalSMFactory }()
+
+	checkedOut := false
+	originalGitFactory := gitClientFactory
+	gitClientFactory = func() IGitClient {
+		return &MockGitClient{CheckoutFunc: func(repoPath, commitOrBranch string) error {
+			checkedOut = true
+			return nil
+		}}
+	}
+	defer func() { gitClientFactory = originalGitFactory }()
+
+	cmd, _, _ := newRootCmd()
+	_, err := executeCommand(cmd, "replay", "obs-session")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--on-host")
+	assert.False(t, checkedOut, "the workspace must not be touched when the replay is refused")
+}
+
 func TestReplayCmd_RunningSession(t *testing.T) {
 	// Setup: Create a mock session manager with a running session
 	mockSM := NewMockSessionManager()
@@ -12,47 +12,84 @@ import (
 
 var (
 	forceRemove bool
+	removeAll   bool
 )
 
 var rmCmd = &cobra.Command{
 	Use:   "rm [SESSION_NAME]...",
 	Short: "Remove one or more sessions",
-	Long:  `Remove one or more sessions. This will delete the session's state and log files.`,
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Remove one or more sessions. This will delete the session's state and log files.
+
+Use --all or the --status, --older-than and --tag filters instead of names to remove
+sessions in bulk, e.g. 'recac rm --status completed --older-than 3d'.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, filtered, err := sessionFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		bulk := removeAll || filtered
+		if bulk && len(args) > 0 {
+			return fmt.Errorf("cannot combine session names with --all or filters")
+		}
+		if !bulk && len(args) == 0 {
+			return fmt.Errorf("requires at least 1 session name, --all, or a filter (--status, --older-than, --tag)")
+		}
+
 		sm, err := sessionManagerFactory()
 		if err != nil {
 			return fmt.Errorf("failed to create session manager: %w", err)
 		}
-		return runRmCmd(sm, cmd, args)
+		if !bulk {
+			return runRmCmd(sm, cmd, args)
+		}
+
+		names, err := selectSessions(sm, filter)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No matching sessions.")
+			return nil
+		}
+		result := removeSessions(sm, cmd, names)
+		return result.finish(cmd, "Removed")
 	},
 }
 
 func runRmCmd(sm ISessionManager, cmd *cobra.Command, args []string) error {
-	var errorMessages []string
-	for _, sessionName := range args {
+	result := removeSessions(sm, cmd, args)
+	if len(result.errors) > 0 {
+		return fmt.Errorf("encountered errors:\n- %s", strings.Join(result.errors, "\n- "))
+	}
+
+	return nil
+}
+
+// removeSessions removes each named session, skipping running ones unless --force is set.
+func removeSessions(sm ISessionManager, cmd *cobra.Command, names []string) *bulkResult {
+	result := &bulkResult{}
+	for _, sessionName := range names {
 		err := sm.RemoveSession(sessionName, forceRemove)
 		if err != nil {
 			// Check for the specific error to provide a clean user message.
 			if errors.Is(err, runner.ErrSessionRunning) {
 				// This is a specific condition, not a failure.
 				fmt.Fprintf(cmd.ErrOrStderr(), "Skipping running session '%s'. Use --force to remove.\n", sessionName)
+				result.skipped++
 			} else {
-				errorMessages = append(errorMessages, fmt.Sprintf("Failed to remove session '%s': %s", sessionName, err.Error()))
+				result.errors = append(result.errors, fmt.Sprintf("Failed to remove session '%s': %s", sessionName, err.Error()))
 			}
 			continue
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "Removed session '%s'\n", sessionName)
+		result.acted++
 	}
-
-	if len(errorMessages) > 0 {
-		return fmt.Errorf("encountered errors:\n- %s", strings.Join(errorMessages, "\n- "))
-	}
-
-	return nil
+	return result
 }
 
 func init() {
 	rmCmd.Flags().BoolVarP(&forceRemove, "force", "f", false, "Force remove a running session")
+	rmCmd.Flags().BoolVar(&removeAll, "all", false, "Remove all sessions (running sessions require --force)")
+	addSessionFilterFlags(rmCmd)
 	rootCmd.AddCommand(rmCmd)
 }
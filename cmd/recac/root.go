@@ -67,7 +67,7 @@ func init() {
 		// Default behavior: Run Interactive Mode using flags
 		provider, _ := cmd.Flags().GetString("provider")
 		model, _ := cmd.Flags().GetString("model")
-		RunInteractive(provider, model)
+		RunInteractive(provider, model, "")
 	}
 	cobra.OnInitialize(initConfig)
 
@@ -84,11 +84,13 @@ func init() {
 	rootCmd.PersistentFlags().String("model", "", "Model to use (overrides config and RECAC_MODEL env var)")
 	rootCmd.PersistentFlags().String("provider", "", "Agent provider (gemini, openai, openrouter, etc)")
 	rootCmd.PersistentFlags().Bool("mock", false, "Start in mock mode (no Docker or API keys required)")
+	rootCmd.PersistentFlags().Bool("cache-responses", false, "Serve repeated prompts from an on-disk response cache (for reproducible runs)")
 
-	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
-	viper.BindPFlag("model", rootCmd.PersistentFlags().Lookup("model"))
-	viper.BindPFlag("provider", rootCmd.PersistentFlags().Lookup("provider"))
-	viper.BindPFlag("mock", rootCmd.PersistentFlags().Lookup("mock"))
+	config.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	config.BindPFlag("model", rootCmd.PersistentFlags().Lookup("model"))
+	config.BindPFlag("provider", rootCmd.PersistentFlags().Lookup("provider"))
+	config.BindPFlag("mock", rootCmd.PersistentFlags().Lookup("mock"))
+	config.BindPFlag("cache_responses", rootCmd.PersistentFlags().Lookup("cache-responses"))
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
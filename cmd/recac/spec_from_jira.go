@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"recac/internal/agent/prompts"
+	"recac/internal/cmdutils"
+	"recac/internal/jira"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	specFromJiraID              string
+	specFromJiraIncludeChildren bool
+	specFromJiraOutput          string
+	specFromJiraSummarize       bool
+	specFromJiraForce           bool
+)
+
+var specFromJiraCmd = &cobra.Command{
+	Use:   "spec-from-jira",
+	Short: "Generate app_spec.txt from a Jira ticket",
+	Long: `Fetches a Jira ticket (typically an Epic describing the whole application)
+and writes its summary and description to app_spec.txt.
+
+With --include-children, child issues are fetched too (recursively) and
+appended under the parent. With --summarize, the assembled content is sent
+through a summarization prompt so the spec reads as one document.
+
+This is the inverse of 'recac jira generate-from-spec'.`,
+	Args: cobra.NoArgs,
+	RunE: runSpecFromJira,
+}
+
+func init() {
+	rootCmd.AddCommand(specFromJiraCmd)
+	specFromJiraCmd.Flags().StringVar(&specFromJiraID, "id", "", "Jira ticket ID to build the spec from (e.g. PROJ-1)")
+	specFromJiraCmd.Flags().BoolVar(&specFromJiraIncludeChildren, "include-children", false, "Include child issues of the ticket")
+	specFromJiraCmd.Flags().StringVarP(&specFromJiraOutput, "output", "o", "app_spec.txt", "Path to write the specification to")
+	specFromJiraCmd.Flags().BoolVar(&specFromJiraSummarize, "summarize", false, "Consolidate the tickets into a spec with the agent")
+	specFromJiraCmd.Flags().BoolVar(&specFromJiraForce, "force", false, "Overwrite the output file if it exists")
+	_ = specFromJiraCmd.MarkFlagRequired("id")
+}
+
+func runSpecFromJira(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(specFromJiraOutput); err == nil && !specFromJiraForce {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", specFromJiraOutput)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to stat %s: %w", specFromJiraOutput, err)
+	}
+
+	ctx := context.Background()
+	client, err := cmdutils.GetJiraClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	spec, count, err := assembleJiraSpec(ctx, client, specFromJiraID, specFromJiraIncludeChildren)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Fetched %d ticket(s) from %s\n", count, specFromJiraID)
+
+	if specFromJiraSummarize {
+		prompt, err := prompts.GetPrompt(prompts.SpecWriter, map[string]string{
+			"tickets": spec,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load spec writer prompt: %w", err)
+		}
+
+		ag, err := agentClientFactory(ctx, viper.GetString("provider"), viper.GetString("model"), ".", "recac-spec-from-jira")
+		if err != nil {
+			return fmt.Errorf("failed to create agent: %w", err)
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), "Summarizing tickets into a specification...")
+		resp, err := ag.Send(ctx, prompt)
+		if err != nil {
+			return fmt.Errorf("agent failed: %w", err)
+		}
+		if strings.TrimSpace(resp) == "" {
+			return fmt.Errorf("agent returned an empty specification")
+		}
+		spec = strings.TrimSpace(resp) + "\n"
+	}
+
+	if err := os.WriteFile(specFromJiraOutput, []byte(spec), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", specFromJiraOutput, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s (%d bytes)\n", specFromJiraOutput, len(spec))
+	return nil
+}
+
+// assembleJiraSpec renders ticketID, and optionally its descendants, as a
+// Markdown spec. It returns the spec and the number of tickets included.
+func assembleJiraSpec(ctx context.Context, client *jira.Client, ticketID string, includeChildren bool) (string, int, error) {
+	ticket, err := client.GetTicket(ctx, ticketID)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to fetch ticket %s: %w", ticketID, err)
+	}
+
+	var sb strings.Builder
+	writeSpecTicket(&sb, client, ticket, 1)
+	count := 1
+
+	if !includeChildren {
+		return sb.String(), count, nil
+	}
+
+	seen := map[string]bool{ticketID: true}
+	var addChildren func(parentKey string, level int) error
+	addChildren = func(parentKey string, level int) error {
+		children, err := client.SearchIssues(ctx, fmt.Sprintf("parent = %s ORDER BY key ASC", parentKey))
+		if err != nil {
+			return fmt.Errorf("failed to fetch children of %s: %w", parentKey, err)
+		}
+		for _, child := range children {
+			key, _ := child["key"].(string)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			writeSpecTicket(&sb, client, child, level)
+			count++
+			if err := addChildren(key, level+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := addChildren(ticketID, 2); err != nil {
+		return "", 0, err
+	}
+	return sb.String(), count, nil
+}
+
+// writeSpecTicket appends a ticket as a Markdown section at the given heading level.
+func writeSpecTicket(sb *strings.Builder, client *jira.Client, ticket map[string]interface{}, level int) {
+	key, _ := ticket["key"].(string)
+	summary := ""
+	if fields, ok := ticket["fields"].(map[string]interface{}); ok {
+		summary, _ = fields["summary"].(string)
+	}
+
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(sb, "%s %s (%s)\n", strings.Repeat("#", min(level, 6)), summary, key)
+	if description := strings.TrimSpace(client.ParseDescription(ticket)); description != "" {
+		fmt.Fprintf(sb, "\n%s\n", description)
+	}
+}
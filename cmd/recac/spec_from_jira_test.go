@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"recac/internal/agent"
+	"recac/internal/cmdutils"
+	"recac/internal/jira"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func adfIssue(key, summary, text string) map[string]interface{} {
+	return map[string]interface{}{
+		"key": key,
+		"fields": map[string]interface{}{
+			"summary": summary,
+			"description": map[string]interface{}{
+				"type": "doc",
+				"content": []interface{}{
+					map[string]interface{}{
+						"type":    "paragraph",
+						"content": []interface{}{map[string]interface{}{"type": "text", "text": text}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func mockSpecJira(t *testing.T) {
+	t.Helper()
+	children := map[string][]map[string]interface{}{
+		"parent = PROJ-1 ORDER BY key ASC": {adfIssue("PROJ-2", "Login", "Users sign in with email.")},
+		"parent = PROJ-2 ORDER BY key ASC": {adfIssue("PROJ-3", "Password reset", "Send a reset link.")},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issue/PROJ-1":
+			json.NewEncoder(w).Encode(adfIssue("PROJ-1", "Accounts app", "An app for managing accounts."))
+		case "/rest/api/3/search/jql":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"issues": children[r.URL.Query().Get("jql")],
+				"isLast": true,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	originalFactory := cmdutils.GetJiraClient
+	cmdutils.GetJiraClient = func(ctx context.Context) (*jira.Client, error) {
+		return jira.NewClient(ts.URL, "user", "token"), nil
+	}
+	t.Cleanup(func() { cmdutils.GetJiraClient = originalFactory })
+}
+
+func runSpecFromJiraWith(t *testing.T, output string, includeChildren, summarize, force bool) (string, error) {
+	t.Helper()
+	origID, origChildren, origOutput := specFromJiraID, specFromJiraIncludeChildren, specFromJiraOutput
+	origSummarize, origForce := specFromJiraSummarize, specFromJiraForce
+	defer func() {
+		specFromJiraID, specFromJiraIncludeChildren, specFromJiraOutput = origID, origChildren, origOutput
+		specFromJiraSummarize, specFromJiraForce = origSummarize, origForce
+	}()
+	specFromJiraID, specFromJiraIncludeChildren, specFromJiraOutput = "PROJ-1", includeChildren, output
+	specFromJiraSummarize, specFromJiraForce = summarize, force
+
+	var out bytes.Buffer
+	specFromJiraCmd.SetOut(&out)
+	defer specFromJiraCmd.SetOut(nil)
+	err := runSpecFromJira(specFromJiraCmd, nil)
+	return out.String(), err
+}
+
+func TestSpecFromJira(t *testing.T) {
+	mockSpecJira(t)
+	output := filepath.Join(t.TempDir(), "app_spec.txt")
+
+	t.Run("ticket only", func(t *testing.T) {
+		out, err := runSpecFromJiraWith(t, output, false, false, false)
+		require.NoError(t, err)
+		assert.Contains(t, out, "Fetched 1 ticket(s)")
+
+		spec, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Equal(t, "# Accounts app (PROJ-1)\n\nAn app for managing accounts.\n", string(spec))
+	})
+
+	t.Run("refuses to overwrite", func(t *testing.T) {
+		_, err := runSpecFromJiraWith(t, output, true, false, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+	})
+
+	t.Run("includes children", func(t *testing.T) {
+		out, err := runSpecFromJiraWith(t, output, true, false, true)
+		require.NoError(t, err)
+		assert.Contains(t, out, "Fetched 3 ticket(s)")
+
+		spec, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Equal(t, "# Accounts app (PROJ-1)\n\nAn app for managing accounts.\n"+
+			"\n## Login (PROJ-2)\n\nUsers sign in with email.\n"+
+			"\n### Password reset (PROJ-3)\n\nSend a reset link.\n", string(spec))
+	})
+
+	t.Run("summarizes with the agent", func(t *testing.T) {
+		origFactory := agentClientFactory
+		defer func() { agentClientFactory = origFactory }()
+		mockAgent := new(MockAgentClient)
+		agentClientFactory = func(ctx context.Context, provider, model, dir, id string) (agent.Agent, error) {
+			return mockAgent, nil
+		}
+		mockAgent.On("Send", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+			return strings.Contains(prompt, "## Login (PROJ-2)")
+		})).Return("Accounts app\n\nUsers sign in and reset passwords.", nil).Once()
+
+		_, err := runSpecFromJiraWith(t, output, true, true, true)
+		require.NoError(t, err)
+
+		spec, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Equal(t, "Accounts app\n\nUsers sign in and reset passwords.\n", string(spec))
+		mockAgent.AssertExpectations(t)
+	})
+}
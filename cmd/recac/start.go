@@ -15,6 +15,7 @@ import (
 
 	"recac/internal/agent"
 	"recac/internal/cmdutils"
+	"recac/internal/config"
 	"recac/internal/docker"
 	"recac/internal/git"
 	"recac/internal/jira"
@@ -31,39 +32,70 @@ func init() {
 	startCmd.Flags().String("path", "", "Project path (skips wizard)")
 	startCmd.Flags().Int("max-iterations", 30, "Maximum number of iterations")
 	startCmd.Flags().Int("manager-frequency", 5, "Frequency of manager reviews")
+	startCmd.Flags().Int("progress-interval", 0, "Iterations between progress notifications (0 = manager frequency)")
+	startCmd.Flags().Bool("safe-mode", false, "Pause before risky commands (rm -rf, force pushes, ...) until a human runs agent-bridge confirm <token>")
+	startCmd.Flags().Bool("host-mode", false, "Run agent commands directly on this machine instead of in a container (no isolation)")
+	startCmd.Flags().Duration("checkpoint-interval", 0, "Commit the agent container to an image this often so a crashed session can resume from it (e.g. 15m; 0 = disabled)")
+	startCmd.Flags().String("max-workspace-size", "", "Block the session when the workspace exceeds this size (e.g. 10GB; empty = unlimited)")
+	startCmd.Flags().Int("max-qa-rejections", 3, "Block the session after this many QA/Manager rejections (0 = unlimited)")
 	startCmd.Flags().Int("max-agents", 1, "Maximum number of parallel agents")
+	startCmd.Flags().Bool("isolate-worktrees", false, "Give each parallel agent its own git worktree, merged back after each sprint")
 	startCmd.Flags().Int("task-max-iterations", 10, "Maximum iterations for sub-tasks")
 	startCmd.Flags().Bool("detached", false, "Run session in background (detached mode)")
 	startCmd.Flags().String("name", "", "Name for the session (required for detached mode)")
+	startCmd.Flags().StringSlice("tag", nil, "Tag the session for grouping and filtering (repeatable, e.g. --tag customer-a --tag experiment)")
 	startCmd.Flags().String("jira", "", "Jira Ticket ID to start session from (e.g. PROJ-123)")
 	startCmd.Flags().Bool("manager-first", false, "Run the Manager Agent before the first coding session")
 	startCmd.Flags().Bool("stream", false, "Stream agent output to the console")
 	startCmd.Flags().Bool("allow-dirty", false, "Allow running with uncommitted git changes")
-	viper.BindPFlag("path", startCmd.Flags().Lookup("path"))
-	viper.BindPFlag("max_iterations", startCmd.Flags().Lookup("max-iterations"))
-	viper.BindPFlag("manager_frequency", startCmd.Flags().Lookup("manager-frequency"))
-	viper.BindPFlag("max_agents", startCmd.Flags().Lookup("max-agents"))
-	viper.BindPFlag("task_max_iterations", startCmd.Flags().Lookup("task-max-iterations"))
-	viper.BindPFlag("detached", startCmd.Flags().Lookup("detached"))
-	viper.BindPFlag("name", startCmd.Flags().Lookup("name"))
-	viper.BindPFlag("jira", startCmd.Flags().Lookup("jira"))
-	viper.BindPFlag("manager_first", startCmd.Flags().Lookup("manager-first"))
-	viper.BindPFlag("stream", startCmd.Flags().Lookup("stream"))
-	viper.BindPFlag("allow_dirty", startCmd.Flags().Lookup("allow-dirty"))
+	startCmd.Flags().String("conflict-strategy", "reset", "How to handle conflicts merging the base branch at sign-off: reset or resolve")
+	startCmd.Flags().String("select-task", "", "Focus the session on a single feature by ID (see recac tasks list)")
+	config.BindPFlag("path", startCmd.Flags().Lookup("path"))
+	config.BindPFlag("max_iterations", startCmd.Flags().Lookup("max-iterations"))
+	config.BindPFlag("manager_frequency", startCmd.Flags().Lookup("manager-frequency"))
+	config.BindPFlag("progress_interval", startCmd.Flags().Lookup("progress-interval"))
+	config.BindPFlag("checkpoint_interval", startCmd.Flags().Lookup("checkpoint-interval"))
+	config.BindPFlag("safe_mode", startCmd.Flags().Lookup("safe-mode"))
+	config.BindPFlag("host_mode", startCmd.Flags().Lookup("host-mode"))
+	config.BindPFlag("max_workspace_size", startCmd.Flags().Lookup("max-workspace-size"))
+	config.BindPFlag("max_qa_rejections", startCmd.Flags().Lookup("max-qa-rejections"))
+	config.BindPFlag("max_agents", startCmd.Flags().Lookup("max-agents"))
+	config.BindPFlag("isolate_worktrees", startCmd.Flags().Lookup("isolate-worktrees"))
+	config.BindPFlag("conflict_strategy", startCmd.Flags().Lookup("conflict-strategy"))
+	config.BindPFlag("task_max_iterations", startCmd.Flags().Lookup("task-max-iterations"))
+	config.BindPFlag("detached", startCmd.Flags().Lookup("detached"))
+	config.BindPFlag("name", startCmd.Flags().Lookup("name"))
+	config.BindPFlag("jira", startCmd.Flags().Lookup("jira"))
+	config.BindPFlag("manager_first", startCmd.Flags().Lookup("manager-first"))
+	config.BindPFlag("stream", startCmd.Flags().Lookup("stream"))
+	config.BindPFlag("allow_dirty", startCmd.Flags().Lookup("allow-dirty"))
+	config.BindPFlag("select_task", startCmd.Flags().Lookup("select-task"))
 	startCmd.Flags().String("jira-label", "", "Jira Label to find tickets (e.g. agent-work)")
 	startCmd.Flags().Int("max-parallel-tickets", 1, "Maximum number of Jira tickets to process in parallel")
-	viper.BindPFlag("jira_label", startCmd.Flags().Lookup("jira-label"))
-	viper.BindPFlag("max_parallel_tickets", startCmd.Flags().Lookup("max-parallel-tickets"))
+	config.BindPFlag("jira_label", startCmd.Flags().Lookup("jira-label"))
+	config.BindPFlag("max_parallel_tickets", startCmd.Flags().Lookup("max-parallel-tickets"))
 	startCmd.Flags().Bool("auto-merge", false, "Automatically merge PRs if checks pass")
-	viper.BindPFlag("auto_merge", startCmd.Flags().Lookup("auto-merge"))
+	config.BindPFlag("auto_merge", startCmd.Flags().Lookup("auto-merge"))
+	startCmd.Flags().StringSlice("required-check", nil, "CI check that must pass on the feature branch before auto-merge (repeatable)")
+	config.BindPFlag("auto_merge_required_checks", startCmd.Flags().Lookup("required-check"))
 	startCmd.Flags().Bool("skip-qa", false, "Skip QA phase and auto-complete (use with caution)")
-	viper.BindPFlag("skip_qa", startCmd.Flags().Lookup("skip-qa"))
+	config.BindPFlag("skip_qa", startCmd.Flags().Lookup("skip-qa"))
 	startCmd.Flags().String("image", "ghcr.io/process-failed-successfully/recac-agent:latest", "Docker image to use for the agent session")
-	viper.BindPFlag("image", startCmd.Flags().Lookup("image"))
+	config.BindPFlag("image", startCmd.Flags().Lookup("image"))
 	startCmd.Flags().Bool("cleanup", true, "Cleanup temporary workspace after session ends")
-	viper.BindPFlag("cleanup", startCmd.Flags().Lookup("cleanup"))
+	config.BindPFlag("cleanup", startCmd.Flags().Lookup("cleanup"))
+	startCmd.Flags().String("cleanup-policy", "", "Workspace cleanup policy: always, on-success or never (overrides --cleanup)")
+	config.BindPFlag("cleanup_policy", startCmd.Flags().Lookup("cleanup-policy"))
 	startCmd.Flags().String("project", "", "Project name override")
-	viper.BindPFlag("project", startCmd.Flags().Lookup("project"))
+	config.BindPFlag("project", startCmd.Flags().Lookup("project"))
+	startCmd.Flags().String("manager-provider", "", "Provider for the Manager agent (defaults to --provider)")
+	startCmd.Flags().String("manager-model", "", "Model for the Manager agent (defaults to --model)")
+	startCmd.Flags().String("qa-provider", "", "Provider for the QA agent (defaults to --provider)")
+	startCmd.Flags().String("qa-model", "", "Model for the QA agent (defaults to --model)")
+	config.BindPFlag("agents.manager.provider", startCmd.Flags().Lookup("manager-provider"))
+	config.BindPFlag("agents.manager.model", startCmd.Flags().Lookup("manager-model"))
+	config.BindPFlag("agents.qa.provider", startCmd.Flags().Lookup("qa-provider"))
+	config.BindPFlag("agents.qa.model", startCmd.Flags().Lookup("qa-model"))
 
 	// Internal flag for resuming sessions
 	startCmd.Flags().String("resume-from", "", "Resume from a specific workspace path")
@@ -72,12 +104,16 @@ func init() {
 	startCmd.Flags().String("repo-url", "", "Repository URL to clone (bypasses Jira if provided)")
 	startCmd.Flags().String("summary", "", "Task summary (bypasses Jira if provided)")
 	startCmd.Flags().String("description", "", "Task description")
-	viper.BindPFlag("repo_url", startCmd.Flags().Lookup("repo-url"))
-	viper.BindPFlag("summary", startCmd.Flags().Lookup("summary"))
-	viper.BindPFlag("description", startCmd.Flags().Lookup("description"))
+	config.BindPFlag("repo_url", startCmd.Flags().Lookup("repo-url"))
+	config.BindPFlag("summary", startCmd.Flags().Lookup("summary"))
+	config.BindPFlag("description", startCmd.Flags().Lookup("description"))
 
 	viper.BindEnv("max_iterations", "RECAC_MAX_ITERATIONS")
 	viper.BindEnv("manager_frequency", "RECAC_MANAGER_FREQUENCY")
+	viper.BindEnv("agents.manager.provider", "RECAC_MANAGER_PROVIDER")
+	viper.BindEnv("agents.manager.model", "RECAC_MANAGER_MODEL")
+	viper.BindEnv("agents.qa.provider", "RECAC_QA_PROVIDER")
+	viper.BindEnv("agents.qa.model", "RECAC_QA_MODEL")
 	viper.BindEnv("task_max_iterations", "RECAC_TASK_MAX_ITERATIONS")
 
 	rootCmd.AddCommand(startCmd)
@@ -101,6 +137,11 @@ var startCmd = &cobra.Command{
 		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 		defer stop()
 
+		// Detached sessions rotate the log their output is redirected to
+		if logFile := os.Getenv(runner.SessionLogEnv); logFile != "" {
+			go runner.WatchSessionLog(ctx, logFile)
+		}
+
 		debug := viper.GetBool("debug")
 		isMock, _ := cmd.Flags().GetBool("mock")
 		if !isMock {
@@ -158,31 +199,41 @@ var startCmd = &cobra.Command{
 		repoURL, _ := cmd.Flags().GetString("repo-url")
 		summary, _ := cmd.Flags().GetString("summary")
 		description, _ := cmd.Flags().GetString("description")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
 
 		// Global Configuration
 		cfg := SessionConfig{
-			ProjectPath:       projectPath,
-			IsMock:            isMock,
-			MaxIterations:     maxIterations,
-			ManagerFrequency:  managerFrequency,
-			MaxAgents:         maxAgents,
-			TaskMaxIterations: taskMaxIterations,
-			Detached:          detached,
-			SessionName:       sessionName,
-			AllowDirty:        viper.GetBool("allow_dirty"),
-			Stream:            viper.GetBool("stream"),
-			AutoMerge:         autoMergeFlag || viper.GetBool("auto_merge"),
-			SkipQA:            skipQAFlag || viper.GetBool("skip_qa"),
-			ManagerFirst:      viper.GetBool("manager_first"),
-			Image:             viper.GetString("image"),
-			Debug:             debug,
-			Provider:          provider,
-			Model:             model,
-			Cleanup:           viper.GetBool("cleanup"),
-			ProjectName:       projectName,
-			RepoURL:           repoURL,
-			Summary:           summary,
-			Description:       description,
+			ProjectPath:         projectPath,
+			IsMock:              isMock,
+			MaxIterations:       maxIterations,
+			ManagerFrequency:    managerFrequency,
+			MaxAgents:           maxAgents,
+			TaskMaxIterations:   taskMaxIterations,
+			Detached:            detached,
+			SessionName:         sessionName,
+			AllowDirty:          viper.GetBool("allow_dirty"),
+			Stream:              viper.GetBool("stream"),
+			AutoMerge:           autoMergeFlag || viper.GetBool("auto_merge"),
+			SkipQA:              skipQAFlag || viper.GetBool("skip_qa"),
+			ManagerFirst:        viper.GetBool("manager_first"),
+			Image:               viper.GetString("image"),
+			Debug:               debug,
+			Provider:            provider,
+			Model:               model,
+			ManagerProvider:     viper.GetString("agents.manager.provider"),
+			ManagerModel:        viper.GetString("agents.manager.model"),
+			QAProvider:          viper.GetString("agents.qa.provider"),
+			QAModel:             viper.GetString("agents.qa.model"),
+			Cleanup:             viper.GetBool("cleanup"),
+			CleanupPolicy:       viper.GetString("cleanup_policy"),
+			ProjectName:         projectName,
+			RepoURL:             repoURL,
+			Summary:             summary,
+			Description:         description,
+			Tags:                tags,
+			SelectedTaskID:      viper.GetString("select_task"),
+			ContainerEntrypoint: viper.GetStringSlice("container_entrypoint"),
+			ContainerCommand:    viper.GetStringSlice("container_command"),
 		}
 
 		// Handle session resumption
@@ -406,33 +457,42 @@ var startCmd = &cobra.Command{
 
 // SessionConfig holds all parameters for a RECAC session
 type SessionConfig struct {
-	Goal              string
-	ProjectPath       string
-	ProjectName       string
-	IsMock            bool
-	MaxIterations     int
-	ManagerFrequency  int
-	MaxAgents         int
-	TaskMaxIterations int
-	Detached          bool
-	SessionName       string
-	JiraEpicKey       string
-	AllowDirty        bool
-	Stream            bool
-	AutoMerge         bool
-	SkipQA            bool
-	ManagerFirst      bool
-	Debug             bool
-	JiraClient        *jira.Client
-	JiraTicketID      string
-	RepoURL           string
-	Image             string
-	Provider          string
-	Model             string
-	Cleanup           bool
-	Summary           string
-	Description       string
-	Logger            *slog.Logger
+	Goal                string
+	ProjectPath         string
+	ProjectName         string
+	IsMock              bool
+	MaxIterations       int
+	ManagerFrequency    int
+	MaxAgents           int
+	TaskMaxIterations   int
+	Detached            bool
+	SessionName         string
+	JiraEpicKey         string
+	AllowDirty          bool
+	Stream              bool
+	AutoMerge           bool
+	SkipQA              bool
+	ManagerFirst        bool
+	Debug               bool
+	JiraClient          *jira.Client
+	JiraTicketID        string
+	RepoURL             string
+	Image               string
+	Provider            string
+	Model               string
+	ManagerProvider     string // Provider for the Manager agent; empty uses agents.manager.* or Provider
+	ManagerModel        string
+	QAProvider          string // Provider for the QA agent; empty uses agents.qa.* or Provider
+	QAModel             string
+	Cleanup             bool
+	CleanupPolicy       string
+	Summary             string
+	Description         string
+	Tags                []string
+	SelectedTaskID      string   // Focus the session on this feature (--select-task)
+	ContainerEntrypoint []string // Overrides the agent image entrypoint (container_entrypoint)
+	ContainerCommand    []string // Overrides the agent container command (container_command)
+	Logger              *slog.Logger
 }
 
 // processDirectTask handles a coding session from a direct repository and task description
@@ -502,6 +562,12 @@ func processJiraTicket(ctx context.Context, jiraTicketID string, jClient *jira.C
 	logger := cfg.Logger.With("ticket_id", jiraTicketID)
 	cfg.Logger = logger // Pass it down
 
+	cleanupPolicy, err := runner.ResolveCleanupPolicy(cfg.CleanupPolicy, cfg.Cleanup)
+	if err != nil {
+		logger.Error("Invalid cleanup policy", "error", err)
+		return
+	}
+
 	// 2. Fetch Ticket
 	ticket, err := jClient.GetTicket(ctx, jiraTicketID)
 	if err != nil {
@@ -591,15 +657,11 @@ func processJiraTicket(ctx context.Context, jiraTicketID string, jClient *jira.C
 
 	logger.Info("Workspace created", "path", tempWorkspace)
 
-	// Auto-cleanup
-	if cfg.Cleanup {
-		defer func() {
-			logger.Info("Cleaning up workspace", "path", tempWorkspace)
-			if err := os.RemoveAll(tempWorkspace); err != nil {
-				logger.Error("Failed to cleanup workspace", "path", tempWorkspace, "error", err)
-			}
-		}()
-	}
+	// Auto-cleanup, depending on the session outcome
+	var sessionErr error
+	defer func() {
+		cleanupPolicy.CleanupWorkspace(logger, tempWorkspace, sessionErr)
+	}()
 
 	// 5. Transition Ticket Status
 	transition := viper.GetString("jira.transition")
@@ -624,13 +686,43 @@ func processJiraTicket(ctx context.Context, jiraTicketID string, jClient *jira.C
 	cfg.RepoURL = repoURL
 
 	// Run Workflow
-	if err := runWorkflow(ctx, cfg); err != nil {
-		logger.Error("Session failed", "error", err)
+	if sessionErr = runWorkflow(ctx, cfg); sessionErr != nil {
+		logger.Error("Session failed", "error", sessionErr)
 	} else {
 		logger.Info("Session completed successfully")
 	}
 }
 
+// applyProjectSettings applies the settings pinned by projectPath's
+// .recac/config.yaml to the fields of cfg that the session does not read
+// itself. Fields that differ from the global config were set explicitly (by
+// flags or the wizard) and are kept.
+func applyProjectSettings(cfg *SessionConfig, projectPath string) {
+	settings, path, err := config.ProjectSettings(projectPath)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+		return
+	}
+	if path == "" {
+		return
+	}
+	for key, field := range map[string]*string{"provider": &cfg.Provider, "model": &cfg.Model} {
+		if *field == viper.GetString(key) {
+			*field = settings.GetString(key)
+		}
+	}
+	for key, field := range map[string]*int{
+		"max_iterations":      &cfg.MaxIterations,
+		"task_max_iterations": &cfg.TaskMaxIterations,
+		"manager_frequency":   &cfg.ManagerFrequency,
+		"max_agents":          &cfg.MaxAgents,
+	} {
+		if *field == viper.GetInt(key) {
+			*field = settings.GetInt(key)
+		}
+	}
+}
+
 // runWorkflow handles the execution of a single project session (local or Jira-based)
 func runWorkflow(ctx context.Context, cfg SessionConfig) error {
 	// Determine the goal for the session
@@ -691,6 +783,19 @@ func runWorkflow(ctx context.Context, cfg SessionConfig) error {
 		if cfg.AllowDirty {
 			command = append(command, "--allow-dirty")
 		}
+		if cfg.SelectedTaskID != "" {
+			command = append(command, "--select-task", cfg.SelectedTaskID)
+		}
+		for _, role := range []struct{ flag, value string }{
+			{"--manager-provider", cfg.ManagerProvider},
+			{"--manager-model", cfg.ManagerModel},
+			{"--qa-provider", cfg.QAProvider},
+			{"--qa-model", cfg.QAModel},
+		} {
+			if role.value != "" {
+				command = append(command, role.flag, role.value)
+			}
+		}
 
 		projectPath := cfg.ProjectPath
 		if projectPath == "" {
@@ -717,11 +822,12 @@ func runWorkflow(ctx context.Context, cfg SessionConfig) error {
 			return fmt.Errorf("failed to start detached session: %v", err)
 		}
 
-		// Save the start commit SHA to the session state
-		if startSHA != "" {
+		// Save the start commit SHA and tags to the session state
+		if startSHA != "" || len(cfg.Tags) > 0 {
 			session.StartCommitSHA = startSHA
+			session.Tags = cfg.Tags
 			if err := sm.SaveSession(session); err != nil {
-				fmt.Printf("Warning: failed to save start commit SHA for session %s: %v\n", cfg.SessionName, err)
+				fmt.Printf("Warning: failed to save session state for session %s: %v\n", cfg.SessionName, err)
 			}
 		}
 
@@ -757,11 +863,15 @@ func runWorkflow(ctx context.Context, cfg SessionConfig) error {
 		session.AutoMerge = cfg.AutoMerge
 		session.SkipQA = cfg.SkipQA
 		session.ManagerFirst = cfg.ManagerFirst
+		session.SelectedTaskID = cfg.SelectedTaskID
 
 		if cfg.JiraEpicKey != "" {
 			session.BaseBranch = fmt.Sprintf("agent-epic/%s", cfg.JiraEpicKey)
 		}
 
+		if err := session.ValidateSelectedTask(); err != nil {
+			return err
+		}
 		if err := session.Start(ctx); err != nil {
 			if ctx.Err() != nil {
 				return nil
@@ -812,16 +922,17 @@ func runWorkflow(ctx context.Context, cfg SessionConfig) error {
 	if err != nil {
 		fmt.Printf("Warning: Failed to initialize Docker client: %v. Proceeding in restricted mode.\n", err)
 		dockerCli = nil
+	} else {
+		dockerCli.RegistryAuth = cmdutils.GetRegistryAuth()
 	}
 
-	provider := cfg.Provider
-	model := cfg.Model
-	agentClient, err := agentClientFactory(ctx, provider, model, projectPath, projectName)
+	applyProjectSettings(&cfg, projectPath)
+	agentClient, err := agentClientFactory(ctx, cfg.Provider, cfg.Model, projectPath, projectName)
 	if err != nil {
 		return fmt.Errorf("failed to initialize agent: %v", err)
 	}
 
-	session := runner.NewSession(dockerCli, agentClient, projectPath, cfg.Image, projectName, provider, model, cfg.MaxAgents)
+	session := runner.NewSession(dockerCli, agentClient, projectPath, cfg.Image, projectName, cfg.Provider, cfg.Model, cfg.MaxAgents)
 	if cfg.Logger != nil {
 		session.Logger = cfg.Logger
 	}
@@ -832,9 +943,17 @@ func runWorkflow(ctx context.Context, cfg SessionConfig) error {
 	session.StreamOutput = cfg.Stream
 	session.AutoMerge = cfg.AutoMerge
 	session.SkipQA = cfg.SkipQA
+	session.SelectedTaskID = cfg.SelectedTaskID
 	session.JiraClient = cfg.JiraClient
 	session.JiraTicketID = cfg.JiraTicketID
 	session.RepoURL = cfg.RepoURL
+	session.ContainerEntrypoint = cfg.ContainerEntrypoint
+	session.ContainerCommand = cfg.ContainerCommand
+	if app, err := cmdutils.GetGitHubApp(); err != nil {
+		fmt.Printf("Warning: GitHub App auth disabled: %v\n", err)
+	} else {
+		session.GitHubApp = app
+	}
 
 	if cfg.JiraEpicKey != "" {
 		session.BaseBranch = fmt.Sprintf("agent-epic/%s", cfg.JiraEpicKey)
@@ -866,6 +985,9 @@ func runWorkflow(ctx context.Context, cfg SessionConfig) error {
 		fmt.Printf("Warning: could not get start commit SHA: %v\n", err)
 	}
 
+	if err := session.ValidateSelectedTask(); err != nil {
+		return err
+	}
 	if err := session.Start(ctx); err != nil {
 		if ctx.Err() != nil {
 			return nil
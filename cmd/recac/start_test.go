@@ -10,6 +10,7 @@ import (
 	"recac/internal/agent"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -135,3 +136,26 @@ func TestStartCommand_NormalMode_Restricted(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, output, "Starting RECAC session")
 }
+
+func TestApplyProjectSettings(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("provider", "gemini")
+	viper.Set("model", "gemini-pro")
+	viper.Set("manager_frequency", 5)
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".recac"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".recac", "config.yaml"), []byte("provider: openai\nmodel: gpt-4o\nmanager_frequency: 2\n"), 0644))
+
+	cfg := SessionConfig{Provider: "gemini", Model: "explicit-model", ManagerFrequency: 5}
+	applyProjectSettings(&cfg, dir)
+	assert.Equal(t, "openai", cfg.Provider)
+	assert.Equal(t, "explicit-model", cfg.Model, "explicit values are kept")
+	assert.Equal(t, 2, cfg.ManagerFrequency)
+	assert.Equal(t, "gemini", viper.GetString("provider"), "the global config is not modified")
+
+	cfg = SessionConfig{Provider: "gemini", Model: "gemini-pro"}
+	applyProjectSettings(&cfg, t.TempDir())
+	assert.Equal(t, "gemini", cfg.Provider, "nothing changes without a project config")
+}
@@ -72,7 +72,7 @@ func calculateStats(sm ISessionManager) (*AggregateStats, error) {
 		stats.TotalResponseTokens += agentState.TokenUsage.TotalResponseTokens
 
 		// Calculate cost
-		stats.TotalCost += agent.CalculateCost(agentState.Model, agentState.TokenUsage)
+		stats.TotalCost += agent.CalculateProviderCost(agentState.Provider, agentState.Model, agentState.TokenUsage)
 	}
 
 	return stats, nil
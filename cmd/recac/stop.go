@@ -9,23 +9,41 @@ import (
 )
 
 func init() {
+	stopCmd.Flags().Bool("all", false, "Stop all running sessions")
+	addSessionFilterFlags(stopCmd)
 	rootCmd.AddCommand(stopCmd)
 }
 
 var stopCmd = &cobra.Command{
 	Use:   "stop [session-name]",
 	Short: "Stop a running session",
-	Long:  `Stop a running session gracefully. Sends SIGTERM first, then SIGKILL if needed.`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Stop a running session gracefully. Sends SIGTERM first, then SIGKILL if needed.
+
+Use --all or the --status, --older-than and --tag filters instead of a name to stop
+sessions in bulk, e.g. 'recac stop --all --tag experiment'.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var sessionName string
 		var err error
 
+		stopAll, _ := cmd.Flags().GetBool("all")
+		filter, filtered, err := sessionFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if (stopAll || filtered) && len(args) > 0 {
+			return fmt.Errorf("cannot combine a session name with --all or filters")
+		}
+
 		sm, err := sessionManagerFactory()
 		if err != nil {
 			return fmt.Errorf("failed to create session manager: %w", err)
 		}
 
+		if stopAll || filtered {
+			return stopSessions(cmd, sm, filter)
+		}
+
 		if len(args) == 0 {
 			sessionName, err = interactiveSessionSelect(sm, "running", "Choose a session to stop:")
 			if err != nil {
@@ -62,3 +80,31 @@ var stopCmd = &cobra.Command{
 		return nil
 	},
 }
+
+// stopSessions stops every session matching filter. Only running sessions can be
+// stopped, so the status filter defaults to "running".
+func stopSessions(cmd *cobra.Command, sm ISessionManager, filter sessionFilter) error {
+	if filter.Status == "" {
+		filter.Status = "running"
+	}
+
+	names, err := selectSessions(sm, filter)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No matching sessions.")
+		return nil
+	}
+
+	result := &bulkResult{}
+	for _, name := range names {
+		if err := sm.StopSession(name); err != nil {
+			result.errors = append(result.errors, fmt.Sprintf("Failed to stop session '%s': %s", name, err.Error()))
+			continue
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Session '%s' stopped successfully\n", name)
+		result.acted++
+	}
+	return result.finish(cmd, "Stopped")
+}
@@ -66,7 +66,7 @@ func doSummary(cmd *cobra.Command) error {
 		if s.AgentStateFile != "" {
 			state, err := loadAgentState(s.AgentStateFile)
 			if err == nil && state != nil {
-				cost := agent.CalculateCost(state.Model, state.TokenUsage)
+				cost := agent.CalculateProviderCost(state.Provider, state.Model, state.TokenUsage)
 				totalCost += cost
 				totalTokens += state.TokenUsage.TotalTokens
 				sessionCosts[s.Name] = cost
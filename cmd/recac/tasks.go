@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	tasksCmd.PersistentFlags().StringP("workspace", "w", "", "Project workspace (default: current directory)")
+	tasksCmd.PersistentFlags().String("project", "", "Project name in the database (default: workspace directory name)")
+
+	tasksCmd.AddCommand(tasksListCmd)
+	rootCmd.AddCommand(tasksCmd)
+}
+
+var tasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "List the tasks a session can be focused on",
+	Long: `List the tasks a session can be focused on with --select-task.
+
+Tasks are the features of the project's feature list, read from .recac.db
+with feature_list.json as the fallback, the same list a session loads.`,
+}
+
+var tasksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List task IDs and their status",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workspace, project, err := featuresTarget(cmd)
+		if err != nil {
+			return err
+		}
+
+		fl, err := loadWorkspaceFeatures(workspace, project)
+		if err != nil {
+			return err
+		}
+		if len(fl.Features) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No tasks found.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tSTATUS\tDESCRIPTION")
+		for _, f := range fl.Features {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", f.ID, f.Status, truncateString(firstLine(f.Description), 70))
+		}
+		w.Flush()
+		fmt.Fprintf(cmd.OutOrStdout(), "\nFocus a session on one with: recac start --select-task <id>\n")
+		return nil
+	},
+}
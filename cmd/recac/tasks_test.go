@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTasksList(t *testing.T) {
+	workspace := t.TempDir()
+	os.WriteFile(filepath.Join(workspace, "feature_list.json"), []byte(testFeatureList), 0644)
+
+	output, err := executeCommand(rootCmd, "tasks", "list", "--workspace", workspace)
+	if err != nil {
+		t.Fatalf("list failed: %v\n%s", err, output)
+	}
+	for _, want := range []string{"F1", "done", "F2", "todo", "Logout button", "--select-task"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	output, err = executeCommand(rootCmd, "tasks", "list", "--workspace", t.TempDir())
+	if err != nil {
+		t.Fatalf("list failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "No tasks found.") {
+		t.Errorf("unexpected output for an empty workspace: %s", output)
+	}
+}
@@ -47,6 +47,7 @@ type MockSessionManager struct {
 	IsProcessRunningFunc      func(pid int) bool
 	SessionsDirFunc           func() string
 	GetSessionGitDiffStatFunc func(name string) (string, error)
+	GetSessionGitDiffFunc     func(name string) (string, error)
 }
 
 func (m *MockSessionManager) SessionsDir() string {
@@ -217,6 +218,19 @@ func (m *MockSessionManager) RenameSession(oldName, newName string) error {
 	return nil
 }
 
+func (m *MockSessionManager) GetSessionGitDiff(name string) (string, error) {
+	if m.GetSessionGitDiffFunc != nil {
+		return m.GetSessionGitDiffFunc(name)
+	}
+	if session, ok := m.Sessions[name]; ok {
+		if session.StartCommitSHA != "" && (session.EndCommitSHA != "" || session.Status == "running") {
+			return "diff --git a/README.md b/README.md\n--- a/README.md\n+++ b/README.md\n@@ -1 +1 @@\n-old\n+new\n", nil
+		}
+		return "", nil
+	}
+	return "", fmt.Errorf("session not found")
+}
+
 func (m *MockSessionManager) GetSessionGitDiffStat(name string) (string, error) {
 	if m.GetSessionGitDiffStatFunc != nil {
 		return m.GetSessionGitDiffStatFunc(name)
@@ -263,6 +277,14 @@ func (m *MockSessionManager) ListArchivedSessions() ([]*runner.SessionState, err
 	return archived, nil
 }
 
+func (m *MockSessionManager) RemoveArchivedSession(name string) error {
+	if session, ok := m.Sessions[name]; ok && session.Status == "archived" {
+		delete(m.Sessions, name)
+		return nil
+	}
+	return fmt.Errorf("archived session '%s' not found", name)
+}
+
 // executeCommand executes a cobra command and returns its output.
 func executeCommand(root *cobra.Command, args ...string) (output string, err error) {
 	resetFlags(root)
@@ -353,6 +375,9 @@ type MockGitClient struct {
 	RunFunc               func(repoPath string, args ...string) (string, error)
 	DeleteLocalBranchFunc func(repoPath, branch string) error
 	CreatePRFunc          func(repoPath, title, body, base string) (string, error)
+	AddWorktreeFunc       func(repoPath, path, branch string) error
+	RemoveWorktreeFunc    func(repoPath, path string) error
+	DefaultBranchFunc     func(repoPath string) (string, error)
 }
 
 func (m *MockGitClient) CreatePR(repoPath, title, body, base string) (string, error) {
@@ -362,6 +387,27 @@ func (m *MockGitClient) CreatePR(repoPath, title, body, base string) (string, er
 	return "https://github.com/example/repo/pull/1", nil
 }
 
+func (m *MockGitClient) AddWorktree(repoPath, path, branch string) error {
+	if m.AddWorktreeFunc != nil {
+		return m.AddWorktreeFunc(repoPath, path, branch)
+	}
+	return nil
+}
+
+func (m *MockGitClient) RemoveWorktree(repoPath, path string) error {
+	if m.RemoveWorktreeFunc != nil {
+		return m.RemoveWorktreeFunc(repoPath, path)
+	}
+	return nil
+}
+
+func (m *MockGitClient) DefaultBranch(repoPath string) (string, error) {
+	if m.DefaultBranchFunc != nil {
+		return m.DefaultBranchFunc(repoPath)
+	}
+	return "main", nil
+}
+
 func (m *MockGitClient) DeleteLocalBranch(repoPath, branch string) error {
 	if m.DeleteLocalBranchFunc != nil {
 		return m.DeleteLocalBranchFunc(repoPath, branch)
@@ -3,7 +3,14 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
+
+	"recac/internal/jira"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -76,20 +83,64 @@ func TestGenerateTickets(t *testing.T) {
 	mockJira.On("CreateTicket", mock.Anything, projectKey, "Epic 1", mock.Anything, "Epic", labels).Return("PROJ-1", nil)
 	mockJira.On("CreateChildTicket", mock.Anything, projectKey, "Story 1", mock.Anything, "Story", "PROJ-1", labels).Return("PROJ-2", nil)
 
-	_, err := generateTickets(context.Background(), specContent, projectKey, "", labels, mockJira, mockAgent)
+	_, err := generateTickets(context.Background(), specContent, projectKey, "", labels, mockJira, mockAgent, false, nil)
 	assert.NoError(t, err)
 
 	mockJira.AssertExpectations(t)
 	mockAgent.AssertExpectations(t)
 }
 
+func TestGenerateTickets_Incremental(t *testing.T) {
+	mockJira := new(MockJiraClient)
+	mockAgent := new(MockAgent)
+
+	tickets := []ticketNode{
+		{
+			Title:              "Epic 1",
+			Description:        "Description of Epic 1",
+			Type:               "Epic",
+			AcceptanceCriteria: []string{"AC1"},
+			Children: []ticketNode{
+				{Title: "Story 1", Description: "Description of Story 1", AcceptanceCriteria: []string{"AC2"}},
+				{Title: "Story 2", Description: "Description of Story 2", BlockedBy: []string{"Story 1"}},
+			},
+		},
+		{Title: "Epic 2", Description: "Description of Epic 2", Type: "Epic", BlockedBy: []string{"Epic 1"}},
+	}
+	jsonBytes, _ := json.Marshal(tickets)
+	mockAgent.On("Send", mock.Anything, mock.Anything).Return(string(jsonBytes), nil)
+
+	var epicDescription string
+	mockJira.On("CreateTicket", mock.Anything, "PROJ", "Epic 1", mock.Anything, "Epic", []string{}).
+		Run(func(args mock.Arguments) { epicDescription = args.String(3) }).Return("PROJ-1", nil)
+	mockJira.On("CreateTicket", mock.Anything, "PROJ", "Epic 2", mock.Anything, "Epic", []string{}).Return("PROJ-2", nil)
+	mockJira.On("AddIssueLink", mock.Anything, "PROJ-1", "PROJ-2", "Blocks").Return(nil)
+
+	_, err := generateTickets(context.Background(), "spec", "PROJ", "https://github.com/example/repo", []string{}, mockJira, mockAgent, true, nil)
+	assert.NoError(t, err)
+	mockJira.AssertExpectations(t)
+	mockJira.AssertNotCalled(t, "CreateChildTicket", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// The children travel in the epic description, ready to be created later
+	rest, children, err := jira.SplitPendingChildren(epicDescription)
+	assert.NoError(t, err)
+	assert.Contains(t, rest, "- AC1")
+	assert.Contains(t, rest, "Repo: https://github.com/example/repo")
+	if assert.Len(t, children, 2) {
+		assert.Equal(t, "Story 1", children[0].Title)
+		assert.Contains(t, children[0].Description, "- AC2")
+		assert.Contains(t, children[0].Description, "Repo: https://github.com/example/repo")
+		assert.Equal(t, []string{"Story 1"}, children[1].BlockedBy)
+	}
+}
+
 func TestGenerateTickets_AgentFailure(t *testing.T) {
 	mockJira := new(MockJiraClient)
 	mockAgent := new(MockAgent)
 
 	mockAgent.On("Send", mock.Anything, mock.Anything).Return("", assert.AnError)
 
-	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent)
+	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent, false, nil)
 	assert.Error(t, err)
 }
 
@@ -107,7 +158,7 @@ func TestGenerateTickets_InvalidRepo(t *testing.T) {
 	jsonBytes, _ := json.Marshal(tickets)
 	mockAgent.On("Send", mock.Anything, mock.Anything).Return(string(jsonBytes), nil)
 
-	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent)
+	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent, false, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "missing repository URL")
 }
@@ -118,7 +169,7 @@ func TestGenerateTickets_InvalidJSON(t *testing.T) {
 
 	mockAgent.On("Send", mock.Anything, mock.Anything).Return("not json", nil)
 
-	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent)
+	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent, false, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse agent response")
 }
@@ -149,7 +200,7 @@ func TestGenerateTickets_JiraCreateError(t *testing.T) {
 	// Expect Fallback to Task
 	mockJira.On("CreateTicket", mock.Anything, "PROJ", "Epic 1", mock.Anything, "Task", mock.Anything).Return("", assert.AnError)
 
-	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent)
+	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent, false, nil)
 	assert.Error(t, err) // It should fail after fallback
 
 	mockJira.AssertExpectations(t)
@@ -219,7 +270,7 @@ func TestGenerateTickets_ChildAndLinkLogic(t *testing.T) {
 	// Expect Link
 	mockJira.On("AddIssueLink", mock.Anything, "PROJ-10", "PROJ-11", "Blocks").Return(nil)
 
-	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent)
+	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent, false, nil)
 	assert.NoError(t, err)
 
 	mockJira.AssertExpectations(t)
@@ -251,7 +302,7 @@ func TestGenerateTickets_LinkError(t *testing.T) {
 	// Mock Link Failure
 	mockJira.On("AddIssueLink", mock.Anything, "PROJ-1", "PROJ-2", "Blocks").Return(assert.AnError)
 
-	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent)
+	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent, false, nil)
 	assert.NoError(t, err) // Should continue despite link error
 
 	mockJira.AssertExpectations(t)
@@ -283,7 +334,7 @@ func TestGenerateTickets_Defaults(t *testing.T) {
 	// Verify "Story" string is passed
 	mockJira.On("CreateChildTicket", mock.Anything, "PROJ", "Story 1", mock.Anything, "Story", "PROJ-1", mock.Anything).Return("PROJ-2", nil)
 
-	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent)
+	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent, false, nil)
 	assert.NoError(t, err)
 
 	mockJira.AssertExpectations(t)
@@ -307,7 +358,7 @@ func TestGenerateTickets_MarkdownStripping(t *testing.T) {
 	mockAgent.On("Send", mock.Anything, mock.Anything).Return(jsonStr1, nil).Once()
 	mockJira.On("CreateTicket", mock.Anything, "PROJ", "Epic", mock.Anything, "Epic", mock.Anything).Return("PROJ-1", nil).Once()
 
-	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent)
+	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent, false, nil)
 	assert.NoError(t, err)
 
 	// Test Case 2: Generic code block
@@ -315,7 +366,7 @@ func TestGenerateTickets_MarkdownStripping(t *testing.T) {
 	mockAgent.On("Send", mock.Anything, mock.Anything).Return(jsonStr2, nil).Once()
 	mockJira.On("CreateTicket", mock.Anything, "PROJ", "Epic", mock.Anything, "Epic", mock.Anything).Return("PROJ-2", nil).Once()
 
-	_, err = generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent)
+	_, err = generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent, false, nil)
 	assert.NoError(t, err)
 
 	mockJira.AssertExpectations(t)
@@ -342,7 +393,229 @@ func TestGenerateTickets_StoryInvalidRepo(t *testing.T) {
 	jsonBytes, _ := json.Marshal(tickets)
 	mockAgent.On("Send", mock.Anything, mock.Anything).Return(string(jsonBytes), nil)
 
-	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent)
+	_, err := generateTickets(context.Background(), "spec", "PROJ", "", []string{}, mockJira, mockAgent, false, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "missing repository URL")
 }
+
+// MockReusingJiraClient can also search and update existing tickets
+type MockReusingJiraClient struct {
+	MockJiraClient
+}
+
+func (m *MockReusingJiraClient) SearchIssues(ctx context.Context, jql string) ([]map[string]interface{}, error) {
+	args := m.Called(ctx, jql)
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *MockReusingJiraClient) UpdateDescription(ctx context.Context, key, description string) error {
+	args := m.Called(ctx, key, description)
+	return args.Error(0)
+}
+
+func TestFindExistingTickets(t *testing.T) {
+	mockJira := new(MockReusingJiraClient)
+	mockJira.On("SearchIssues", mock.Anything, `project = "PROJ" AND summary ~ "ID" AND labels in ("team-a") ORDER BY created ASC`).Return([]map[string]interface{}{
+		{"key": "PROJ-1", "fields": map[string]interface{}{"summary": "ID:[SYSTEM] Shop Architecture"}},
+		{"key": "PROJ-2", "fields": map[string]interface{}{"summary": "ID:[API-STEP-1] Set up router"}},
+		{"key": "PROJ-9", "fields": map[string]interface{}{"summary": "ID:[API-STEP-1] Set up router"}},
+		{"key": "PROJ-3", "fields": map[string]interface{}{"summary": "Unrelated ticket"}},
+	}, nil)
+
+	existing, err := findExistingTickets(context.Background(), mockJira, "PROJ", []string{"team-a"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"SYSTEM": "PROJ-1", "API-STEP-1": "PROJ-2"}, existing)
+}
+
+func TestCreateTicketsFromNodes_ReusesExistingTickets(t *testing.T) {
+	tickets := []ticketNode{
+		{
+			Title:       "ID:[SYSTEM] Shop Architecture",
+			Description: "Repo: https://example.com",
+			Type:        "Epic",
+			Children: []ticketNode{
+				{Title: "ID:[API] [Service] API", Description: "Repo: https://example.com", Type: "Story"},
+				{Title: "ID:[DB] [Service] DB", Description: "Repo: https://example.com", Type: "Story"},
+			},
+		},
+	}
+	existing := map[string]string{"SYSTEM": "PROJ-1", "API": "PROJ-2"}
+
+	t.Run("Skip", func(t *testing.T) {
+		mockJira := new(MockReusingJiraClient)
+		mockJira.On("CreateChildTicket", mock.Anything, "PROJ", "ID:[DB] [Service] DB", mock.Anything, "Story", "PROJ-1", mock.Anything).Return("PROJ-3", nil).Once()
+
+		idToKey, err := createTicketsFromNodes(context.Background(), tickets, "PROJ", "", nil, mockJira, &ticketReuse{existing: existing}, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"SYSTEM": "PROJ-1", "API": "PROJ-2", "DB": "PROJ-3"}, idToKey)
+		mockJira.AssertExpectations(t)
+		mockJira.AssertNotCalled(t, "CreateTicket", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockJira.AssertNotCalled(t, "UpdateDescription", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		mockJira := new(MockReusingJiraClient)
+		mockJira.On("UpdateDescription", mock.Anything, "PROJ-1", "Repo: https://example.com").Return(nil).Once()
+		mockJira.On("UpdateDescription", mock.Anything, "PROJ-2", "Repo: https://example.com").Return(nil).Once()
+		mockJira.On("CreateChildTicket", mock.Anything, "PROJ", "ID:[DB] [Service] DB", mock.Anything, "Story", "PROJ-1", mock.Anything).Return("PROJ-3", nil).Once()
+
+		_, err := createTicketsFromNodes(context.Background(), tickets, "PROJ", "", nil, mockJira, &ticketReuse{existing: existing, update: true}, 1)
+		assert.NoError(t, err)
+		mockJira.AssertExpectations(t)
+	})
+}
+
+// countingJiraClient records the peak number of concurrent create requests.
+type countingJiraClient struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+	next     int
+	parents  map[string]string // Title -> parent key
+	links    []string
+}
+
+func (c *countingJiraClient) create(summary, parentKey string) string {
+	c.mu.Lock()
+	c.inFlight++
+	c.peak = max(c.peak, c.inFlight)
+	c.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight--
+	c.next++
+	c.parents[summary] = parentKey
+	return fmt.Sprintf("PROJ-%d", c.next)
+}
+
+func (c *countingJiraClient) CreateTicket(ctx context.Context, projectKey, summary, description, issueType string, labels []string) (string, error) {
+	return c.create(summary, ""), nil
+}
+
+func (c *countingJiraClient) CreateChildTicket(ctx context.Context, projectKey, summary, description, issueType, parentKey string, labels []string) (string, error) {
+	return c.create(summary, parentKey), nil
+}
+
+func (c *countingJiraClient) AddIssueLink(ctx context.Context, inwardKey, outwardKey, linkType string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.links = append(c.links, inwardKey+" "+linkType+" "+outwardKey)
+	return nil
+}
+
+func TestCreateTicketsFromNodes_Concurrency(t *testing.T) {
+	root := ticketNode{Title: "ID:[SYSTEM] Shop", Description: "Repo: https://example.com", Type: "Epic"}
+	for i := 0; i < 4; i++ {
+		story := ticketNode{Title: fmt.Sprintf("ID:[C%d] Component", i), Description: "Repo: https://example.com", Type: "Story"}
+		for j := 0; j < 3; j++ {
+			story.Children = append(story.Children, ticketNode{Title: fmt.Sprintf("ID:[C%d-FUNC-%d] Func", i, j), Description: "Repo: https://example.com", Type: "Subtask"})
+		}
+		if i > 0 {
+			story.BlockedBy = []string{"ID:[C0] Component"}
+		}
+		root.Children = append(root.Children, story)
+	}
+
+	for _, concurrency := range []int{1, 3} {
+		t.Run(fmt.Sprintf("concurrency=%d", concurrency), func(t *testing.T) {
+			client := &countingJiraClient{parents: make(map[string]string)}
+
+			idToKey, err := createTicketsFromNodes(context.Background(), []ticketNode{root}, "PROJ", "", nil, client, nil, concurrency)
+			assert.NoError(t, err)
+			assert.Len(t, idToKey, 17)
+			assert.LessOrEqual(t, client.peak, concurrency)
+			if concurrency > 1 {
+				assert.Greater(t, client.peak, 1)
+			}
+
+			// Every child was created under the key its parent received
+			assert.Equal(t, "", client.parents["ID:[SYSTEM] Shop"])
+			for i := 0; i < 4; i++ {
+				assert.Equal(t, idToKey["SYSTEM"], client.parents[fmt.Sprintf("ID:[C%d] Component", i)])
+				for j := 0; j < 3; j++ {
+					assert.Equal(t, idToKey[fmt.Sprintf("C%d", i)], client.parents[fmt.Sprintf("ID:[C%d-FUNC-%d] Func", i, j)])
+				}
+			}
+
+			assert.ElementsMatch(t, []string{
+				idToKey["C0"] + " Blocks " + idToKey["C1"],
+				idToKey["C0"] + " Blocks " + idToKey["C2"],
+				idToKey["C0"] + " Blocks " + idToKey["C3"],
+			}, client.links)
+		})
+	}
+}
+
+func TestCreateTicketsFromNodes_ConcurrencyStopsOnError(t *testing.T) {
+	mockJira := new(MockJiraClient)
+	mockJira.On("CreateTicket", mock.Anything, "PROJ", "Epic A", mock.Anything, mock.Anything, mock.Anything).Return("PROJ-1", nil)
+	mockJira.On("CreateTicket", mock.Anything, "PROJ", "Epic B", mock.Anything, mock.Anything, mock.Anything).Return("", fmt.Errorf("boom"))
+	tickets := []ticketNode{
+		{Title: "Epic A", Description: "Repo: https://example.com", Type: "Epic"},
+		{Title: "Epic B", Description: "Repo: https://example.com", Type: "Epic"},
+	}
+
+	_, err := createTicketsFromNodes(context.Background(), tickets, "PROJ", "", nil, mockJira, nil, 2)
+	assert.ErrorContains(t, err, "failed to create ticket 'Epic B'")
+	mockJira.AssertNotCalled(t, "AddIssueLink", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGenerateTickets_ResumeFromCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	tickets := []ticketNode{
+		{
+			Title:       "Epic 1",
+			Description: "Repo: https://example.com",
+			Type:        "Epic",
+			Children: []ticketNode{
+				{Title: "Story 1", Description: "Repo: https://example.com", Type: "Story"},
+				{Title: "Story 2", Description: "Repo: https://example.com", Type: "Story"},
+			},
+		},
+	}
+	jsonBytes, _ := json.Marshal(tickets)
+
+	// First run fails on the second story, e.g. after a Jira outage
+	mockAgent := new(MockAgent)
+	mockAgent.On("Send", mock.Anything, mock.Anything).Return(string(jsonBytes), nil).Once()
+	mockJira := new(MockJiraClient)
+	mockJira.On("CreateTicket", mock.Anything, "PROJ", "Epic 1", mock.Anything, "Epic", mock.Anything).Return("PROJ-1", nil).Once()
+	mockJira.On("CreateChildTicket", mock.Anything, "PROJ", "Story 1", mock.Anything, "Story", "PROJ-1", mock.Anything).Return("PROJ-2", nil).Once()
+	mockJira.On("CreateChildTicket", mock.Anything, "PROJ", "Story 2", mock.Anything, mock.Anything, "PROJ-1", mock.Anything).Return("", fmt.Errorf("503 Service Unavailable"))
+
+	_, err := generateTickets(context.Background(), "spec", "PROJ", "", nil, mockJira, mockAgent, false, newTicketCheckpoint(path))
+	assert.Error(t, err)
+
+	checkpoint, err := loadTicketCheckpoint(path)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Epic 1": "PROJ-1", "Story 1": "PROJ-2"}, checkpoint.Created)
+
+	// The resumed run reuses the saved plan and creates only the missing story
+	resumeJira := new(MockJiraClient)
+	resumeJira.On("CreateChildTicket", mock.Anything, "PROJ", "Story 2", mock.Anything, "Story", "PROJ-1", mock.Anything).Return("PROJ-3", nil).Once()
+	resumeAgent := new(MockAgent)
+
+	_, err = generateTickets(context.Background(), "spec", "PROJ", "", nil, resumeJira, resumeAgent, false, checkpoint)
+	assert.NoError(t, err)
+	resumeJira.AssertExpectations(t)
+	resumeAgent.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+
+	checkpoint, err = loadTicketCheckpoint(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "PROJ-3", checkpoint.Created["Story 2"])
+}
+
+func TestLoadTicketCheckpoint_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := loadTicketCheckpoint(filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "failed to read checkpoint")
+
+	empty := filepath.Join(dir, "empty.json")
+	assert.NoError(t, os.WriteFile(empty, []byte(`{"created": {}}`), 0644))
+	_, err = loadTicketCheckpoint(empty)
+	assert.ErrorContains(t, err, "has no ticket plan")
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"recac/internal/db"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	unblockCmd.Flags().String("message", "", "Resolution note recorded for the agent when it resumes")
+	unblockCmd.Flags().String("project", "", "Project ID the blocker was raised under (defaults to the workspace directory name)")
+	rootCmd.AddCommand(unblockCmd)
+}
+
+var unblockCmd = &cobra.Command{
+	Use:   "unblock <session|workspace>",
+	Short: "Clear the blocker that paused a session",
+	Long: `Clears the BLOCKER signal a session is waiting on, so its run loop continues.
+With --message, the resolution is recorded as a System observation, so the
+agent sees what was done when it resumes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workspace, err := unblockWorkspace(args[0])
+		if err != nil {
+			return err
+		}
+		project, _ := cmd.Flags().GetString("project")
+		if project == "" {
+			project = filepath.Base(workspace)
+		}
+		message, _ := cmd.Flags().GetString("message")
+
+		store, err := openWorkspaceStore(workspace)
+		if err != nil {
+			return err
+		}
+		if store == nil {
+			return fmt.Errorf("database not found at %s", filepath.Join(workspace, ".recac.db"))
+		}
+		defer store.Close()
+
+		blocker, err := db.GetBlocker(store, project)
+		if err != nil {
+			return fmt.Errorf("failed to read blocker: %w", err)
+		}
+		if blocker == nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "Project '%s' is not blocked.\n", project)
+			return nil
+		}
+
+		if message != "" {
+			note := fmt.Sprintf("Blocker resolved by a human.\nBlocker: [%s] %s\nResolution: %s", blocker.Type, blocker.Message, message)
+			if err := store.SaveObservation(project, "System", note); err != nil {
+				return fmt.Errorf("failed to record resolution: %w", err)
+			}
+		}
+		if err := store.DeleteSignal(project, db.BlockerSignal); err != nil {
+			return fmt.Errorf("failed to clear blocker: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Blocker cleared for project '%s': [%s] %s\n", project, blocker.Type, blocker.Message)
+		return nil
+	},
+}
+
+// unblockWorkspace resolves target, a workspace directory or a session name,
+// to an absolute workspace path.
+func unblockWorkspace(target string) (string, error) {
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		return filepath.Abs(target)
+	}
+
+	sm, err := sessionManagerFactory()
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize session manager: %w", err)
+	}
+	session, err := sm.LoadSession(target)
+	if err != nil {
+		return "", fmt.Errorf("'%s' is neither a workspace nor a session: %w", target, err)
+	}
+	if session.Workspace == "" {
+		return "", fmt.Errorf("session '%s' has no workspace", target)
+	}
+	return session.Workspace, nil
+}
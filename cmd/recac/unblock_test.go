@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"recac/internal/db"
+	"recac/internal/runner"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnblockCmd(t *testing.T) {
+	workspace := filepath.Join(t.TempDir(), "proj")
+	require.NoError(t, os.MkdirAll(workspace, 0755))
+	store, err := db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	require.NoError(t, err)
+	require.NoError(t, db.SetBlocker(store, "proj", db.Blocker{Type: db.BlockerCredentials, Message: "No Stripe key"}))
+	require.NoError(t, store.Close())
+
+	mockSM := NewMockSessionManager()
+	mockSM.Sessions["blocked-session"] = &runner.SessionState{Name: "blocked-session", Workspace: workspace, Status: "running"}
+	originalFactory := sessionManagerFactory
+	sessionManagerFactory = func() (ISessionManager, error) { return mockSM, nil }
+	defer func() { sessionManagerFactory = originalFactory }()
+
+	cmd, _, _ := newRootCmd()
+	output, err := executeCommand(cmd, "unblock", "blocked-session", "--message", "Added STRIPE_KEY to the agent env")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Blocker cleared for project 'proj': [credentials] No Stripe key")
+
+	store, err = db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	require.NoError(t, err)
+	defer store.Close()
+	blocker, err := db.GetBlocker(store, "proj")
+	require.NoError(t, err)
+	assert.Nil(t, blocker)
+	history, err := store.QueryHistory("proj", 1)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "System", history[0].AgentID)
+	assert.Contains(t, history[0].Content, "Resolution: Added STRIPE_KEY to the agent env")
+
+	// Unblocking by workspace path when nothing is blocked
+	output, err = executeCommand(cmd, "unblock", workspace)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Project 'proj' is not blocked.")
+
+	_, err = executeCommand(cmd, "unblock", "no-such-session")
+	assert.Error(t, err)
+}
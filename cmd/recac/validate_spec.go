@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"recac/internal/agent/prompts"
+	"recac/internal/db"
+	"recac/internal/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	validateSpecPath   string
+	validateSpecDryRun bool
+)
+
+var validateSpecCmd = &cobra.Command{
+	Use:   "validate-spec",
+	Short: "Check app_spec.txt before starting a session",
+	Long: `Checks that the application specification exists and is not empty, so
+spec problems surface before a container is launched.
+
+With --dry-run, the spec is also sent through the Initializer prompt and the
+reply must contain a parseable feature_list.json with at least one feature.
+Nothing is written to the workspace or the database.`,
+	Args: cobra.NoArgs,
+	RunE: runValidateSpec,
+}
+
+func init() {
+	rootCmd.AddCommand(validateSpecCmd)
+	validateSpecCmd.Flags().StringVar(&validateSpecPath, "path", "app_spec.txt", "Path to the application specification")
+	validateSpecCmd.Flags().BoolVar(&validateSpecDryRun, "dry-run", false, "Run the Initializer prompt and check the model returns a valid feature list")
+}
+
+func runValidateSpec(cmd *cobra.Command, args []string) error {
+	spec, err := readSpecFile(validateSpecPath)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Spec %s is present (%d bytes)\n", validateSpecPath, len(spec))
+
+	if !validateSpecDryRun {
+		return nil
+	}
+
+	prompt, err := prompts.GetPrompt(prompts.Initializer, map[string]string{
+		"spec": spec,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load initializer prompt: %w", err)
+	}
+
+	ctx := context.Background()
+	projectPath, _ := filepath.Abs(filepath.Dir(validateSpecPath))
+	ag, err := agentClientFactory(ctx, viper.GetString("provider"), viper.GetString("model"), projectPath, filepath.Base(projectPath))
+	if err != nil {
+		return fmt.Errorf("failed to create agent: %w", err)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "Running Initializer prompt (dry run)...")
+	response, err := ag.Send(ctx, prompt)
+	if err != nil {
+		return fmt.Errorf("agent failed: %w", err)
+	}
+
+	fl, err := parseInitializerFeatures(response)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Model produced a valid feature list with %d features\n", len(fl.Features))
+	return nil
+}
+
+// readSpecFile returns the spec at path, failing if it is missing or blank.
+func readSpecFile(path string) (string, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("spec file %s not found", path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to stat spec file: %w", err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("spec path %s is a directory", path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read spec file: %w", err)
+	}
+	if strings.TrimSpace(string(content)) == "" {
+		return "", fmt.Errorf("spec file %s is empty", path)
+	}
+	return string(content), nil
+}
+
+// heredocRegex captures the body of a `cat << 'EOF' | agent-bridge import` block,
+// which is how the Initializer prompt asks the model to submit features.
+var heredocRegex = regexp.MustCompile(`(?s)<<\s*'?EOF'?[^\n]*\n(.*?)\n\s*EOF`)
+
+// parseInitializerFeatures extracts the feature list from an Initializer reply.
+func parseInitializerFeatures(response string) (*db.FeatureList, error) {
+	raw := utils.CleanJSONBlock(response)
+	if match := heredocRegex.FindStringSubmatch(response); len(match) > 1 {
+		raw = match[1]
+	}
+
+	var fl db.FeatureList
+	if err := json.Unmarshal([]byte(raw), &fl); err != nil {
+		return nil, fmt.Errorf("model did not return a parseable feature_list.json: %w", err)
+	}
+	if len(fl.Features) == 0 {
+		return nil, fmt.Errorf("model returned a feature list with no features")
+	}
+	return &fl, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"recac/internal/agent"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const initializerReply = "I'll create the features.\n\n```bash\ncat << 'EOF' | agent-bridge import\n" +
+	`{"project_name": "Calc", "features": [{"id": "add", "category": "functional", "description": "Adds numbers", "status": "pending", "steps": ["Run calc 1 + 2"]}]}` +
+	"\nEOF\n```\n"
+
+func runValidateSpecWith(t *testing.T, path string, dryRun bool) (string, error) {
+	t.Helper()
+	origPath, origDryRun := validateSpecPath, validateSpecDryRun
+	defer func() { validateSpecPath, validateSpecDryRun = origPath, origDryRun }()
+	validateSpecPath, validateSpecDryRun = path, dryRun
+
+	var out bytes.Buffer
+	validateSpecCmd.SetOut(&out)
+	defer validateSpecCmd.SetOut(nil)
+	err := runValidateSpec(validateSpecCmd, nil)
+	return out.String(), err
+}
+
+func TestValidateSpec_FileChecks(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := runValidateSpecWith(t, filepath.Join(dir, "app_spec.txt"), false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	_, err = runValidateSpecWith(t, dir, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is a directory")
+
+	blank := filepath.Join(dir, "blank.txt")
+	require.NoError(t, os.WriteFile(blank, []byte("  \n\t\n"), 0644))
+	_, err = runValidateSpecWith(t, blank, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is empty")
+
+	spec := filepath.Join(dir, "app_spec.txt")
+	require.NoError(t, os.WriteFile(spec, []byte("A calculator CLI"), 0644))
+	out, err := runValidateSpecWith(t, spec, false)
+	require.NoError(t, err)
+	assert.Contains(t, out, "is present (16 bytes)")
+}
+
+func TestValidateSpec_DryRun(t *testing.T) {
+	spec := filepath.Join(t.TempDir(), "app_spec.txt")
+	require.NoError(t, os.WriteFile(spec, []byte("A calculator CLI"), 0644))
+
+	origFactory := agentClientFactory
+	defer func() { agentClientFactory = origFactory }()
+	mockAgent := new(MockAgentClient)
+	agentClientFactory = func(ctx context.Context, provider, model, dir, id string) (agent.Agent, error) {
+		return mockAgent, nil
+	}
+
+	t.Run("valid feature list", func(t *testing.T) {
+		mockAgent.On("Send", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+			return strings.Contains(prompt, "A calculator CLI")
+		})).Return(initializerReply, nil).Once()
+
+		out, err := runValidateSpecWith(t, spec, true)
+		require.NoError(t, err)
+		assert.Contains(t, out, "valid feature list with 1 features")
+	})
+
+	t.Run("unparseable reply", func(t *testing.T) {
+		mockAgent.On("Send", mock.Anything, mock.Anything).Return("I cannot do that.", nil).Once()
+
+		_, err := runValidateSpecWith(t, spec, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parseable feature_list.json")
+	})
+
+	t.Run("no features", func(t *testing.T) {
+		mockAgent.On("Send", mock.Anything, mock.Anything).Return("```json\n{\"project_name\": \"Calc\", \"features\": []}\n```", nil).Once()
+
+		_, err := runValidateSpecWith(t, spec, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no features")
+	})
+}
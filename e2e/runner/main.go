@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"recac/internal/jira"
+	"recac/internal/telemetry"
 	"recac/pkg/e2e/manager"
 	"recac/pkg/e2e/scenarios"
 
@@ -225,7 +226,7 @@ func run() error {
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
 
-		log.Printf("Starting orchestrator: %v", cmd.Args)
+		log.Printf("Starting orchestrator: %s", telemetry.Redact(strings.Join(cmd.Args, " ")))
 		if err := cmd.Start(); err != nil {
 			return fmt.Errorf("failed to start local orchestrator: %w", err)
 		}
@@ -275,13 +276,6 @@ func run() error {
 		log.Println("=== Cleaning up old Jobs ===")
 		_ = runCommand("kubectl", "delete", "jobs", "-n", namespace, "-l", "app=recac-agent", "--cascade=foreground", "--wait=true")
 
-		// Wipe Database if postgres is used
-		log.Println("=== Wiping PostgreSQL Database ===")
-		// We use kubectl exec to run psql inside the postgres pod
-		// We ignore errors if pod doesn't exist yet (first run)
-		wipeCmd := "PGPASSWORD=changeit psql -U recac -d recac -c \"TRUNCATE observations, signals, project_features, file_locks;\""
-		_ = runCommand("sh", "-c", fmt.Sprintf("POD_NAME=$(kubectl get pods -l app.kubernetes.io/instance=recac,app.kubernetes.io/name=postgresql -n default -o jsonpath='{.items[0].metadata.name}' 2>/dev/null) && [ -n \"$POD_NAME\" ] && kubectl exec $POD_NAME -n default -- sh -c %s", fmt.Sprintf("'%s'", wipeCmd)))
-
 		log.Println("=== Deploying Helm Chart ===")
 		lastColon := strings.LastIndex(imageName, ":")
 		repoPart := imageName[:lastColon]
@@ -304,13 +298,14 @@ func run() error {
 			"--set", "config.imagePullPolicy=IfNotPresent",
 			"--set", "config.poller=jira",
 			"--set", fmt.Sprintf("config.jira_label=%s", label),
-			"--set", fmt.Sprintf("config.jira_query=labels = \"%s\" AND issuetype != Epic AND statusCategory != Done ORDER BY created ASC", label),
+			"--set", "config.jira_exclude_types=Epic",
 			"--set", "config.verbose=true",
 			"--set", "config.interval=10s",
 			"--set", fmt.Sprintf("config.maxIterations=%s", getEnvOrDefault("MAX_ITERATIONS", "20")),
 			"--set", fmt.Sprintf("config.provider=%s", provider),
 			"--set", fmt.Sprintf("config.model=%s", model),
 			"--set", "config.dbType=postgres",
+			"--set", "config.fresh=true", // Agents start from a clean slate instead of a stale database
 			"--set", "postgresql.enabled=true",
 			"--set", "postgresql.image.repository=bitnami/postgresql",
 			"--set", "postgresql.image.tag=latest",
@@ -490,10 +485,15 @@ func verifyScenario(scenarioName, repo string, ticketMap map[string]string) erro
 	return scenario.Verify(tmpDir, ticketMap)
 }
 
+// runCommand runs an external command, scrubbing secrets (e.g. tokens passed via
+// helm --set or embedded in repo URLs) from its output.
 func runCommand(name string, args ...string) error {
 	cmd := exec.Command(name, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	stdout, stderr := telemetry.NewRedactingWriter(os.Stdout), telemetry.NewRedactingWriter(os.Stderr)
+	defer stdout.Close()
+	defer stderr.Close()
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
 	return cmd.Run()
 }
 
@@ -553,7 +553,7 @@ func prepareRepo(repoURL string, ticketMap map[string]string) error {
 	cmd := exec.Command("git", "ls-remote", "--heads", authRepo)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("failed to ls-remote: %w\nOutput: %s", err, string(output))
+		return fmt.Errorf("failed to ls-remote: %w\nOutput: %s", err, telemetry.Redact(string(output)))
 	}
 
 	lines := strings.Split(string(output), "\n")
@@ -583,7 +583,7 @@ func prepareRepo(repoURL string, ticketMap map[string]string) error {
 		// We can run git push without a local repo by specifying the URL
 		delCmd := exec.Command("git", "push", authRepo, "--delete", branch)
 		if out, err := delCmd.CombinedOutput(); err != nil {
-			log.Printf("Warning: Failed to delete branch %s: %v\nOutput: %s", branch, err, string(out))
+			log.Printf("Warning: Failed to delete branch %s: %v\nOutput: %s", branch, err, telemetry.Redact(string(out)))
 		}
 	}
 
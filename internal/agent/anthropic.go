@@ -0,0 +1,296 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	anthropicVersion   = "2023-06-01"
+	anthropicMaxTokens = 8192
+
+	// anthropicMinCachePrefix is the shortest shared prompt prefix (in characters)
+	// worth marking cacheable. Anthropic ignores breakpoints under ~1024 tokens.
+	anthropicMinCachePrefix = 4096
+)
+
+// AnthropicClient implements the Agent interface for Anthropic's Messages API.
+// Prompt prefixes shared between consecutive requests (e.g. the static part of
+// the Manager/QA templates) are marked with cache_control so repeated
+// iterations are billed at the prompt cache rate.
+type AnthropicClient struct {
+	BaseClient
+	apiKey     string
+	model      string
+	httpClient *http.Client
+	apiURL     string
+	// mockResponder is used for testing to bypass real API calls
+	mockResponder func(string) (string, error)
+
+	mu           sync.Mutex
+	lastPrompt   string
+	cachedPrefix string
+}
+
+// NewAnthropicClient creates a new Anthropic client
+func NewAnthropicClient(apiKey, model, project string) *AnthropicClient {
+	return &AnthropicClient{
+		BaseClient: NewBaseClient(project, model, 200000),
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: &http.Client{
+			Timeout: 600 * time.Second,
+		},
+		apiURL: "https://api.anthropic.com/v1/messages",
+	}
+}
+
+// WithMockResponder sets a mock responder for testing
+func (c *AnthropicClient) WithMockResponder(fn func(string) (string, error)) *AnthropicClient {
+	c.mockResponder = fn
+	return c
+}
+
+// WithStateManager sets the state manager for token tracking
+func (c *AnthropicClient) WithStateManager(sm *StateManager) *AnthropicClient {
+	c.StateManager = sm
+	return c
+}
+
+// Send sends a prompt to Anthropic and returns the generated text
+func (c *AnthropicClient) Send(ctx context.Context, prompt string) (string, error) {
+	return c.SendWithRetry(ctx, prompt, c.sendOnce)
+}
+
+func (c *AnthropicClient) sendOnce(ctx context.Context, prompt string) (string, error) {
+	if c.mockResponder != nil {
+		return c.mockResponder(prompt)
+	}
+
+	apiKey := c.apiKey
+	if c.KeyPool != nil {
+		apiKey = c.KeyPool.Next()
+	}
+	if apiKey == "" {
+		return "", fmt.Errorf("API key is required")
+	}
+
+	requestBody := map[string]interface{}{
+		"model":      c.model,
+		"max_tokens": anthropicMaxTokens,
+		"messages": []map[string]interface{}{
+			{
+				"role":    "user",
+				"content": c.contentBlocks(prompt),
+			},
+		},
+	}
+
+	response, err := c.post(ctx, apiKey, requestBody)
+	if err != nil {
+		return "", err
+	}
+
+	text := response.text()
+	if text == "" {
+		return "", fmt.Errorf("no content in response")
+	}
+
+	return text, nil
+}
+
+// SendWithTools sends a prompt to Anthropic offering tools the model may use.
+func (c *AnthropicClient) SendWithTools(ctx context.Context, prompt string, tools []Tool) (ToolResponse, error) {
+	return c.SendWithToolsRetry(ctx, prompt, func(ctx context.Context, p string) (ToolResponse, error) {
+		return c.sendToolsOnce(ctx, p, tools)
+	})
+}
+
+func (c *AnthropicClient) sendToolsOnce(ctx context.Context, prompt string, tools []Tool) (ToolResponse, error) {
+	if c.mockResponder != nil {
+		text, err := c.mockResponder(prompt)
+		return ToolResponse{Text: text}, err
+	}
+
+	apiKey := c.apiKey
+	if c.KeyPool != nil {
+		apiKey = c.KeyPool.Next()
+	}
+	if apiKey == "" {
+		return ToolResponse{}, fmt.Errorf("API key is required")
+	}
+
+	toolSpecs := make([]map[string]interface{}, len(tools))
+	for i, tool := range tools {
+		toolSpecs[i] = map[string]interface{}{
+			"name":         tool.Name,
+			"description":  tool.Description,
+			"input_schema": tool.Parameters,
+		}
+	}
+
+	requestBody := map[string]interface{}{
+		"model":      c.model,
+		"max_tokens": anthropicMaxTokens,
+		"messages": []map[string]interface{}{
+			{
+				"role":    "user",
+				"content": c.contentBlocks(prompt),
+			},
+		},
+		"tools": toolSpecs,
+	}
+
+	response, err := c.post(ctx, apiKey, requestBody)
+	if err != nil {
+		return ToolResponse{}, err
+	}
+
+	result := ToolResponse{Text: response.text()}
+	for _, block := range response.Content {
+		if block.Type == "tool_use" {
+			result.ToolCalls = append(result.ToolCalls, ToolCall{ID: block.ID, Name: block.Name, Arguments: block.Input})
+		}
+	}
+	return result, nil
+}
+
+// anthropicResponse is the response body of a Messages API request.
+type anthropicResponse struct {
+	Content []struct {
+		Type  string                 `json:"type"`
+		Text  string                 `json:"text"`
+		ID    string                 `json:"id"`
+		Name  string                 `json:"name"`
+		Input map[string]interface{} `json:"input"`
+	} `json:"content"`
+	Usage struct {
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+	} `json:"usage"`
+}
+
+// text joins the text blocks of the response.
+func (r anthropicResponse) text() string {
+	var text strings.Builder
+	for _, block := range r.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	return text.String()
+}
+
+// post sends a Messages API request and records its prompt cache usage.
+func (c *AnthropicClient) post(ctx context.Context, apiKey string, requestBody map[string]interface{}) (anthropicResponse, error) {
+	var response anthropicResponse
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return response, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.apiURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return response, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return response, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		err := newStatusError("", resp.StatusCode, bodyBytes)
+		c.KeyPool.Report(apiKey, err)
+		return response, err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return response, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	c.RecordCacheUsage(response.Usage.CacheReadInputTokens, response.Usage.CacheCreationInputTokens)
+	return response, nil
+}
+
+// SendStream fallback for Anthropic (calls Send and emits once)
+func (c *AnthropicClient) SendStream(ctx context.Context, prompt string, onChunk func(string)) (string, error) {
+	return c.SendStreamWithRetry(ctx, prompt, func(ctx context.Context, p string, oc func(string)) (string, error) {
+		resp, err := c.sendOnce(ctx, p)
+		if err == nil && oc != nil {
+			oc(resp)
+		}
+		return resp, err
+	}, onChunk)
+}
+
+// contentBlocks splits prompt into text blocks, marking the cacheable prefix
+// with an ephemeral cache_control breakpoint.
+func (c *AnthropicClient) contentBlocks(prompt string) []map[string]interface{} {
+	prefix := c.cachePrefix(prompt)
+	if prefix == "" {
+		return []map[string]interface{}{{"type": "text", "text": prompt}}
+	}
+
+	blocks := []map[string]interface{}{{
+		"type":          "text",
+		"text":          prefix,
+		"cache_control": map[string]string{"type": "ephemeral"},
+	}}
+	if rest := prompt[len(prefix):]; rest != "" {
+		blocks = append(blocks, map[string]interface{}{"type": "text", "text": rest})
+	}
+	return blocks
+}
+
+// cachePrefix returns the part of prompt to mark cacheable. The previously
+// cached prefix is reused while prompts still start with it, so the breakpoint
+// stays stable and later requests hit the cache. Otherwise the prefix shared
+// with the last prompt (cut at a line boundary) becomes the new breakpoint.
+func (c *AnthropicClient) cachePrefix(prompt string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer func() { c.lastPrompt = prompt }()
+
+	if c.cachedPrefix != "" && strings.HasPrefix(prompt, c.cachedPrefix) {
+		return c.cachedPrefix
+	}
+
+	prefix := commonPrefix(c.lastPrompt, prompt)
+	if i := strings.LastIndexByte(prefix, '\n'); i >= 0 {
+		prefix = prefix[:i+1]
+	} else {
+		prefix = ""
+	}
+	if len(prefix) < anthropicMinCachePrefix {
+		return ""
+	}
+
+	c.cachedPrefix = prefix
+	return prefix
+}
+
+func commonPrefix(a, b string) string {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	i := 0
+	for i < n && a[i] == b[i] {
+		i++
+	}
+	return a[:i]
+}
@@ -0,0 +1,92 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAnthropicClient_PromptCaching(t *testing.T) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "test-key" || r.Header.Get("anthropic-version") == "" {
+			t.Errorf("missing auth/version headers: %v", r.Header)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("bad request body: %v", err)
+		}
+		requests = append(requests, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"content":[{"type":"text","text":"ok"}],"usage":{"input_tokens":10,"output_tokens":2,"cache_read_input_tokens":1500,"cache_creation_input_tokens":0}}`))
+	}))
+	defer server.Close()
+
+	client := NewAnthropicClient("test-key", "claude-sonnet-4-5", "test-project")
+	client.apiURL = server.URL
+
+	sm := NewStateManager(t.TempDir() + "/agent_state.json")
+	_ = sm.InitializeState(200000, "anthropic", "claude-sonnet-4-5")
+	client.WithStateManager(sm)
+
+	template := strings.Repeat("Static review instructions.\n", 200)
+	for _, dynamic := range []string{"report 1", "report 2", "report 3"} {
+		resp, err := client.Send(context.Background(), template+dynamic)
+		if err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+		if resp != "ok" {
+			t.Errorf("Expected 'ok', got %q", resp)
+		}
+	}
+
+	blocks := func(i int) []interface{} {
+		msg := requests[i]["messages"].([]interface{})[0].(map[string]interface{})
+		return msg["content"].([]interface{})
+	}
+
+	// Nothing is shared yet on the first request
+	if first := blocks(0); len(first) != 1 || first[0].(map[string]interface{})["cache_control"] != nil {
+		t.Errorf("Expected a single uncached block first, got %v", first)
+	}
+	// Later requests mark the shared template prefix cacheable
+	for i := 1; i < 3; i++ {
+		b := blocks(i)
+		if len(b) != 2 {
+			t.Fatalf("Request %d: expected prefix and suffix blocks, got %d", i, len(b))
+		}
+		prefix := b[0].(map[string]interface{})
+		if prefix["text"] != template || prefix["cache_control"] == nil {
+			t.Errorf("Request %d: expected cacheable template prefix, got %v", i, prefix)
+		}
+	}
+
+	state, _ := sm.Load()
+	if state.TokenUsage.CacheReadTokens != 4500 {
+		t.Errorf("Expected 4500 cache read tokens, got %d", state.TokenUsage.CacheReadTokens)
+	}
+}
+
+func TestAnthropicClient_ShortPromptsNotCached(t *testing.T) {
+	client := NewAnthropicClient("test-key", "claude-sonnet-4-5", "test-project")
+
+	client.contentBlocks("short prompt\nA")
+	blocks := client.contentBlocks("short prompt\nB")
+	if len(blocks) != 1 || blocks[0]["cache_control"] != nil {
+		t.Errorf("Expected short shared prefix to stay uncached, got %v", blocks)
+	}
+}
+
+func TestNewAgent_Anthropic(t *testing.T) {
+	a, err := NewAgent("anthropic", "key", "claude-sonnet-4-5", "", "proj")
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+	if _, ok := a.(*AnthropicClient); !ok {
+		t.Errorf("Expected *AnthropicClient, got %T", a)
+	}
+}
@@ -0,0 +1,153 @@
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CachingAgent serves responses to prompts it has seen before from disk and
+// forwards everything else to the wrapped agent, storing its responses. It
+// makes runs against a fixed model reproducible and avoids paying twice for
+// the same prompt.
+type CachingAgent struct {
+	inner Agent
+	dir   string
+}
+
+// cacheEntry is the on-disk form of a cached response. The prompt is kept
+// so entries can be inspected and pruned by hand.
+type cacheEntry struct {
+	Prompt   string `json:"prompt"`
+	Response string `json:"response"`
+}
+
+// NewCachingAgent wraps inner with a response cache stored in cacheDir, one
+// file per prompt. Responses of different models should use different dirs.
+func NewCachingAgent(inner Agent, cacheDir string) *CachingAgent {
+	return &CachingAgent{inner: inner, dir: cacheDir}
+}
+
+// WithResponseCache wraps ag in a CachingAgent when enabled, giving each
+// provider and model its own directory under dir (default
+// ~/.recac/response-cache). Otherwise ag is returned as is.
+func WithResponseCache(ag Agent, enabled bool, dir, provider, model string) Agent {
+	if !enabled {
+		return ag
+	}
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ag
+		}
+		dir = filepath.Join(home, ".recac", "response-cache")
+	}
+	return NewCachingAgent(ag, filepath.Join(dir, provider, strings.ReplaceAll(model, "/", "_")))
+}
+
+// Unwrap returns the wrapped agent.
+func (c *CachingAgent) Unwrap() Agent { return c.inner }
+
+// Send returns the cached response to prompt, or asks the wrapped agent.
+func (c *CachingAgent) Send(ctx context.Context, prompt string) (string, error) {
+	if response, ok := c.lookup(prompt); ok {
+		return response, nil
+	}
+	response, err := c.inner.Send(ctx, prompt)
+	if err != nil {
+		return response, err
+	}
+	c.store(prompt, response)
+	return response, nil
+}
+
+// SendStream is Send for streaming callers; a cached response arrives as a
+// single chunk.
+func (c *CachingAgent) SendStream(ctx context.Context, prompt string, onChunk func(string)) (string, error) {
+	if response, ok := c.lookup(prompt); ok {
+		if onChunk != nil {
+			onChunk(response)
+		}
+		return response, nil
+	}
+	response, err := c.inner.SendStream(ctx, prompt, onChunk)
+	if err != nil {
+		return response, err
+	}
+	c.store(prompt, response)
+	return response, nil
+}
+
+// SendWithTools forwards to the wrapped agent without caching: tool calls
+// are acted on, so replaying them would hide the model's real behaviour. If
+// the wrapped agent has no tool support the prompt is sent as text, leaving
+// the response to be parsed as usual.
+func (c *CachingAgent) SendWithTools(ctx context.Context, prompt string, tools []Tool) (ToolResponse, error) {
+	if ta, ok := c.inner.(ToolAgent); ok {
+		return ta.SendWithTools(ctx, prompt, tools)
+	}
+	response, err := c.Send(ctx, prompt)
+	return ToolResponse{Text: response}, err
+}
+
+// Ping checks the wrapped agent.
+func (c *CachingAgent) Ping(ctx context.Context) error {
+	if p, ok := c.inner.(Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *CachingAgent) path(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *CachingAgent) lookup(prompt string) (string, bool) {
+	data, err := os.ReadFile(c.path(prompt))
+	if err != nil {
+		return "", false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Prompt != prompt {
+		return "", false
+	}
+	return entry.Response, true
+}
+
+// store writes the entry atomically so concurrent agents sharing the cache
+// never read a partial file. Failing to cache is not fatal.
+func (c *CachingAgent) store(prompt, response string) {
+	if err := c.write(prompt, response); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to cache agent response: %v\n", err)
+	}
+}
+
+func (c *CachingAgent) write(prompt, response string) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cacheEntry{Prompt: prompt, Response: response})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(prompt))
+}
@@ -0,0 +1,128 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingAgent answers with a fixed response and counts its calls
+type countingAgent struct {
+	calls    int
+	response string
+	err      error
+}
+
+func (a *countingAgent) Send(ctx context.Context, prompt string) (string, error) {
+	a.calls++
+	return a.response, a.err
+}
+
+func (a *countingAgent) SendStream(ctx context.Context, prompt string, onChunk func(string)) (string, error) {
+	a.calls++
+	if a.err == nil && onChunk != nil {
+		onChunk(a.response)
+	}
+	return a.response, a.err
+}
+
+func TestCachingAgent(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	inner := &countingAgent{response: "answer"}
+	cached := NewCachingAgent(inner, dir)
+	ctx := context.Background()
+
+	resp, err := cached.Send(ctx, "question")
+	require.NoError(t, err)
+	assert.Equal(t, "answer", resp)
+	assert.Equal(t, 1, inner.calls)
+
+	// Served from disk, also by a new wrapper and for streaming callers
+	inner.response = "changed"
+	resp, err = NewCachingAgent(inner, dir).Send(ctx, "question")
+	require.NoError(t, err)
+	assert.Equal(t, "answer", resp)
+
+	var chunks []string
+	resp, err = cached.SendStream(ctx, "question", func(s string) { chunks = append(chunks, s) })
+	require.NoError(t, err)
+	assert.Equal(t, "answer", resp)
+	assert.Equal(t, []string{"answer"}, chunks)
+	assert.Equal(t, 1, inner.calls)
+
+	// Other prompts reach the inner agent
+	resp, err = cached.SendStream(ctx, "other", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "changed", resp)
+	assert.Equal(t, 2, inner.calls)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestCachingAgent_ErrorsNotCached(t *testing.T) {
+	dir := t.TempDir()
+	inner := &countingAgent{err: errors.New("rate limited")}
+	cached := NewCachingAgent(inner, dir)
+
+	_, err := cached.Send(context.Background(), "question")
+	assert.Error(t, err)
+
+	inner.err = nil
+	inner.response = "answer"
+	resp, err := cached.Send(context.Background(), "question")
+	require.NoError(t, err)
+	assert.Equal(t, "answer", resp)
+	assert.Equal(t, 2, inner.calls)
+}
+
+func TestCachingAgent_UseStateManager(t *testing.T) {
+	inner := NewOpenAIClient("key", "gpt-4", "project")
+	sm := NewStateManager(filepath.Join(t.TempDir(), "state.json"))
+	assert.True(t, UseStateManager(NewCachingAgent(inner, t.TempDir()), sm))
+	assert.Same(t, sm, inner.StateManager)
+}
+
+// toolCountingAgent is a countingAgent with tool support
+type toolCountingAgent struct {
+	countingAgent
+	toolCalls int
+}
+
+func (a *toolCountingAgent) SendWithTools(ctx context.Context, prompt string, tools []Tool) (ToolResponse, error) {
+	a.toolCalls++
+	return ToolResponse{ToolCalls: []ToolCall{{Name: RunShellToolName, Arguments: map[string]interface{}{"command": "ls"}}}}, nil
+}
+
+func TestCachingAgent_SendWithTools(t *testing.T) {
+	ctx := context.Background()
+	inner := &toolCountingAgent{}
+	var ag Agent = NewCachingAgent(inner, t.TempDir())
+	ta, ok := ag.(ToolAgent)
+	require.True(t, ok, "a cached agent keeps tool calling")
+
+	for i := 0; i < 2; i++ {
+		resp, err := ta.SendWithTools(ctx, "list files", []Tool{RunShellTool})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ls"}, resp.ShellCommands())
+	}
+	assert.Equal(t, 2, inner.toolCalls, "tool calls bypass the cache")
+	assert.Equal(t, 0, inner.calls)
+
+	// Without tool support, the prompt is sent (and cached) as text
+	plain := &countingAgent{response: "```bash\nls\n```"}
+	cached := NewCachingAgent(plain, t.TempDir())
+	resp, err := cached.SendWithTools(ctx, "list files", []Tool{RunShellTool})
+	require.NoError(t, err)
+	assert.Equal(t, plain.response, resp.Text)
+	assert.Nil(t, resp.ShellCommands())
+	_, err = cached.SendWithTools(ctx, "list files", []Tool{RunShellTool})
+	require.NoError(t, err)
+	assert.Equal(t, 1, plain.calls)
+}
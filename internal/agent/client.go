@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"recac/internal/telemetry"
+	"recac/internal/tokenize"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -14,21 +16,63 @@ type BaseClient struct {
 	Project      string
 	StateManager *StateManager
 	BackoffFn    func(int) time.Duration
+	MaxRetries   int
 	// DefaultMaxTokens is the default context limit if not set in state
 	DefaultMaxTokens int
+	// KeyPool rotates requests across several API keys (optional, overrides the single key)
+	KeyPool *KeyPool
+	// Counter counts tokens with the model's tokenizer (nil = EstimateTokenCount)
+	Counter tokenize.Counter
+
+	// pendingCache holds prompt cache usage reported by the provider for the
+	// in-flight request, folded into the state by UpdateStateWithResponse.
+	// It is guarded by cacheMu since health checks may run alongside a request.
+	cacheMu      sync.Mutex
+	pendingCache TokenUsage
+	// pendingLabel attributes the in-flight request to a role and iteration.
+	pendingLabel UsageLabel
 }
 
-// NewBaseClient creates a new BaseClient
-func NewBaseClient(project string, defaultMaxTokens int) BaseClient {
+// NewBaseClient creates a new BaseClient, counting tokens with model's tokenizer
+func NewBaseClient(project, model string, defaultMaxTokens int) BaseClient {
 	return BaseClient{
 		Project:          project,
 		DefaultMaxTokens: defaultMaxTokens,
-		BackoffFn: func(retry int) time.Duration {
-			return time.Duration(1<<uint(retry-1)) * time.Second
-		},
+		BackoffFn:        DefaultBackoff,
+		MaxRetries:       DefaultMaxRetries,
+		Counter:          tokenize.ForModel(model),
 	}
 }
 
+// CountTokens counts the tokens in text with the client's tokenizer.
+func (c *BaseClient) CountTokens(text string) int {
+	if c.Counter == nil {
+		return EstimateTokenCount(text)
+	}
+	return c.Counter.Count(text)
+}
+
+// truncateToTokens truncates text to maxTokens as measured by the client's
+// tokenizer. TruncateToTokenLimit budgets with the 4-chars-per-token
+// estimate, so the limit is first scaled by how far off the estimate is for
+// this text, then tightened until the real count fits.
+func (c *BaseClient) truncateToTokens(text string, maxTokens int) string {
+	if c.Counter == nil {
+		return TruncateToTokenLimit(text, maxTokens)
+	}
+
+	limit := maxTokens
+	if actual := c.CountTokens(text); actual > 0 {
+		limit = maxTokens * EstimateTokenCount(text) / actual
+	}
+	result := TruncateToTokenLimit(text, limit)
+	for i := 0; i < 5 && c.CountTokens(result) > maxTokens; i++ {
+		limit = limit * 90 / 100
+		result = TruncateToTokenLimit(text, limit)
+	}
+	return result
+}
+
 // PreparePrompt checks token limits and truncates if necessary.
 // Returns the (possibly truncated) prompt, the state, and a boolean indicating if state should be updated.
 func (c *BaseClient) PreparePrompt(prompt string) (string, State, bool, error) {
@@ -42,7 +86,7 @@ func (c *BaseClient) PreparePrompt(prompt string) (string, State, bool, error) {
 	}
 
 	// Check if prompt exceeds token limit
-	promptTokens := EstimateTokenCount(prompt)
+	promptTokens := c.CountTokens(prompt)
 	maxTokens := state.MaxTokens
 	if maxTokens == 0 {
 		maxTokens = c.DefaultMaxTokens
@@ -56,6 +100,7 @@ func (c *BaseClient) PreparePrompt(prompt string) (string, State, bool, error) {
 	state.History = append(state.History, Message{
 		Role:      "user",
 		Content:   prompt,
+		Tokens:    promptTokens,
 		Timestamp: time.Now(),
 	})
 
@@ -64,8 +109,8 @@ func (c *BaseClient) PreparePrompt(prompt string) (string, State, bool, error) {
 	if promptTokens > availableTokens {
 		// Truncate the prompt for the API call (but the history keeps the full or reasonably trimmed version)
 		telemetry.LogInfo("Prompt exceeds token limit, truncating...", "project", c.Project, "actual", promptTokens, "available", availableTokens)
-		prompt = TruncateToTokenLimit(prompt, availableTokens)
-		promptTokens = EstimateTokenCount(prompt)
+		prompt = c.truncateToTokens(prompt, availableTokens)
+		promptTokens = c.CountTokens(prompt)
 		state.TokenUsage.TruncationCount++
 	}
 
@@ -92,8 +137,20 @@ func (c *BaseClient) UpdateStateWithResponse(state State, response string) {
 		return
 	}
 
-	responseTokens := EstimateTokenCount(response)
+	responseTokens := c.CountTokens(response)
+	label := c.pendingLabel
+	if label.Role == "" {
+		label.Role = RoleCoding
+	}
+	c.pendingLabel = UsageLabel{}
+	// CurrentTokens holds the prompt tokens set by PreparePrompt
+	state.recordUsage(label, state.CurrentTokens, responseTokens)
 	state.TokenUsage.TotalResponseTokens += responseTokens
+	c.cacheMu.Lock()
+	state.TokenUsage.CacheReadTokens += c.pendingCache.CacheReadTokens
+	state.TokenUsage.CacheCreationTokens += c.pendingCache.CacheCreationTokens
+	c.pendingCache = TokenUsage{}
+	c.cacheMu.Unlock()
 	state.TokenUsage.TotalTokens = state.TokenUsage.TotalPromptTokens + state.TokenUsage.TotalResponseTokens
 	state.CurrentTokens += responseTokens
 	telemetry.TrackTokenUsage(c.Project, responseTokens)
@@ -102,6 +159,7 @@ func (c *BaseClient) UpdateStateWithResponse(state State, response string) {
 	state.History = append(state.History, Message{
 		Role:      "assistant",
 		Content:   response,
+		Tokens:    responseTokens,
 		Timestamp: time.Now(),
 	})
 
@@ -134,7 +192,8 @@ func (c *BaseClient) UpdateStateWithResponse(state State, response string) {
 		"max", maxTokens,
 		"total", state.TokenUsage.TotalTokens,
 		"prompt", state.TokenUsage.TotalPromptTokens,
-		"response_total", state.TokenUsage.TotalResponseTokens)
+		"response_total", state.TokenUsage.TotalResponseTokens,
+		"cache_read", state.TokenUsage.CacheReadTokens)
 
 	// Save updated state
 	if err := c.StateManager.Save(state); err != nil {
@@ -142,6 +201,23 @@ func (c *BaseClient) UpdateStateWithResponse(state State, response string) {
 	}
 }
 
+// RecordCacheUsage records prompt cache token counts reported by the provider
+// for the current request. They are added to TokenUsage with the response.
+func (c *BaseClient) RecordCacheUsage(readTokens, creationTokens int) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	c.pendingCache.CacheReadTokens += readTokens
+	c.pendingCache.CacheCreationTokens += creationTokens
+}
+
+// maxRetries returns the configured retry cap, falling back to DefaultMaxRetries.
+func (c *BaseClient) maxRetries() int {
+	if c.MaxRetries <= 0 {
+		return DefaultMaxRetries
+	}
+	return c.MaxRetries
+}
+
 // SendWithRetry handles the common retry loop and telemetry for Send.
 func (c *BaseClient) SendWithRetry(ctx context.Context, prompt string, sendOnce func(context.Context, string) (string, error)) (string, error) {
 	telemetry.TrackAgentIteration(c.Project)
@@ -154,8 +230,9 @@ func (c *BaseClient) SendWithRetry(ctx context.Context, prompt string, sendOnce
 	if err != nil {
 		return "", err
 	}
+	c.pendingLabel = usageLabelFrom(ctx)
 
-	maxRetries := 3
+	maxRetries := c.maxRetries()
 	var lastErr error
 
 	for i := 0; i <= maxRetries; i++ {
@@ -178,6 +255,9 @@ func (c *BaseClient) SendWithRetry(ctx context.Context, prompt string, sendOnce
 		}
 
 		lastErr = err
+		if !IsRetryable(err) {
+			return "", err
+		}
 	}
 
 	return "", fmt.Errorf("failed after %d retries: %w", maxRetries, lastErr)
@@ -195,9 +275,10 @@ func (c *BaseClient) SendStreamWithRetry(ctx context.Context, prompt string, sen
 	if err != nil {
 		return "", err
 	}
+	c.pendingLabel = usageLabelFrom(ctx)
 
 	var fullResponse strings.Builder
-	maxRetries := 3
+	maxRetries := c.maxRetries()
 	var lastErr error
 
 	for i := 0; i <= maxRetries; i++ {
@@ -226,6 +307,9 @@ func (c *BaseClient) SendStreamWithRetry(ctx context.Context, prompt string, sen
 			break
 		}
 		lastErr = err
+		if !IsRetryable(err) {
+			return "", err
+		}
 	}
 
 	if lastErr != nil {
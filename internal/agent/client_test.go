@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,12 +17,12 @@ func TestBaseClient_PreparePrompt(t *testing.T) {
 
 	sm := NewStateManager(stateFile)
 	// Initialize state
-	err := sm.InitializeState(100, "test-model")
+	err := sm.InitializeState(100, "", "test-model")
 	if err != nil {
 		t.Fatalf("Failed to initialize state: %v", err)
 	}
 
-	client := NewBaseClient("test-project", 100)
+	client := NewBaseClient("test-project", "", 100)
 	client.StateManager = sm
 
 	t.Run("Normal Prompt", func(t *testing.T) {
@@ -55,9 +56,9 @@ func TestBaseClient_UpdateStateWithResponse(t *testing.T) {
 	stateFile := filepath.Join(tempDir, "state.json")
 
 	sm := NewStateManager(stateFile)
-	sm.InitializeState(1000, "test-model")
+	sm.InitializeState(1000, "", "test-model")
 
-	client := NewBaseClient("test-project", 1000)
+	client := NewBaseClient("test-project", "", 1000)
 	client.StateManager = sm
 
 	// Load initial state
@@ -85,7 +86,7 @@ func TestBaseClient_UpdateStateWithResponse(t *testing.T) {
 }
 
 func TestBaseClient_SendWithRetry(t *testing.T) {
-	client := NewBaseClient("test-project", 1000)
+	client := NewBaseClient("test-project", "", 1000)
 	// Mock Backoff to be instant
 	client.BackoffFn = func(i int) time.Duration { return 0 }
 	// No StateManager for this test to isolate retry logic (or we can add one if needed)
@@ -128,7 +129,7 @@ func TestBaseClient_SendWithRetry(t *testing.T) {
 }
 
 func TestBaseClient_SendStreamWithRetry(t *testing.T) {
-	client := NewBaseClient("test-project", 1000)
+	client := NewBaseClient("test-project", "", 1000)
 	client.BackoffFn = func(i int) time.Duration { return 0 }
 
 	t.Run("Success Streaming", func(t *testing.T) {
@@ -175,6 +176,36 @@ func TestBaseClient_SendStreamWithRetry(t *testing.T) {
 	})
 }
 
+// byteCounter counts one token per byte, a tokenizer much finer than the estimate
+type byteCounter struct{}
+
+func (byteCounter) Count(text string) int { return len(text) }
+func (byteCounter) Name() string          { return "bytes" }
+
+func TestBaseClient_Counter(t *testing.T) {
+	sm := NewStateManager(filepath.Join(t.TempDir(), "state.json"))
+	sm.InitializeState(200, "", "test-model")
+
+	client := NewBaseClient("test-project", "", 200)
+	client.StateManager = sm
+	client.Counter = byteCounter{}
+
+	// 200 bytes is ~51 tokens by the estimate, within the 100-token budget, but 200 by the real count
+	longPrompt := strings.Repeat("line\n", 40)
+	prepared, state, _, err := client.PreparePrompt(longPrompt)
+	assert.NoError(t, err)
+	assert.Contains(t, prepared, "truncated")
+	assert.LessOrEqual(t, len(prepared), 100)
+	assert.Equal(t, len(prepared), state.CurrentTokens)
+	assert.Equal(t, len(longPrompt), state.History[0].Tokens)
+
+	client.UpdateStateWithResponse(state, "done")
+	saved, err := sm.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, saved.TokenUsage.TotalResponseTokens)
+	assert.Equal(t, 4, saved.History[len(saved.History)-1].Tokens)
+}
+
 func makeString(n int) string {
 	b := make([]byte, n)
 	for i := range b {
@@ -20,6 +20,7 @@ type HTTPClientConfig struct {
 	HTTPClient    *http.Client
 	MockResponder func(string) (string, error)
 	Headers       map[string]string
+	KeyPool       *KeyPool
 }
 
 // SendOnce performs a single non-streaming request
@@ -28,6 +29,10 @@ func SendOnce(ctx context.Context, cfg HTTPClientConfig, prompt string) (string,
 		return cfg.MockResponder(prompt)
 	}
 
+	if cfg.KeyPool != nil {
+		cfg.APIKey = cfg.KeyPool.Next()
+	}
+
 	if cfg.APIKey == "" {
 		return "", fmt.Errorf("API key is required")
 	}
@@ -42,14 +47,104 @@ func SendOnce(ctx context.Context, cfg HTTPClientConfig, prompt string) (string,
 		},
 	}
 
+	var response chatCompletion
+	if err := postChat(ctx, cfg, requestBody, &response); err != nil {
+		return "", err
+	}
+
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("no content in response")
+	}
+
+	return response.Choices[0].Message.Content, nil
+}
+
+// SendToolsOnce performs a single non-streaming request offering tools as
+// functions the model may call.
+func SendToolsOnce(ctx context.Context, cfg HTTPClientConfig, prompt string, tools []Tool) (ToolResponse, error) {
+	if cfg.MockResponder != nil {
+		text, err := cfg.MockResponder(prompt)
+		return ToolResponse{Text: text}, err
+	}
+
+	if cfg.KeyPool != nil {
+		cfg.APIKey = cfg.KeyPool.Next()
+	}
+
+	if cfg.APIKey == "" {
+		return ToolResponse{}, fmt.Errorf("API key is required")
+	}
+
+	functions := make([]map[string]interface{}, len(tools))
+	for i, tool := range tools {
+		functions[i] = map[string]interface{}{
+			"type": "function",
+			"function": map[string]interface{}{
+				"name":        tool.Name,
+				"description": tool.Description,
+				"parameters":  tool.Parameters,
+			},
+		}
+	}
+
+	requestBody := map[string]interface{}{
+		"model": cfg.Model,
+		"messages": []map[string]interface{}{
+			{
+				"role":    "user",
+				"content": prompt,
+			},
+		},
+		"tools": functions,
+	}
+
+	var response chatCompletion
+	if err := postChat(ctx, cfg, requestBody, &response); err != nil {
+		return ToolResponse{}, err
+	}
+
+	if len(response.Choices) == 0 {
+		return ToolResponse{}, fmt.Errorf("no content in response")
+	}
+
+	message := response.Choices[0].Message
+	result := ToolResponse{Text: message.Content}
+	for _, call := range message.ToolCalls {
+		var args map[string]interface{}
+		if err := json.Unmarshal([]byte(call.Function.Arguments), &args); err != nil {
+			return ToolResponse{}, fmt.Errorf("failed to decode arguments of tool call %s: %w", call.Function.Name, err)
+		}
+		result.ToolCalls = append(result.ToolCalls, ToolCall{ID: call.ID, Name: call.Function.Name, Arguments: args})
+	}
+	return result, nil
+}
+
+// chatCompletion is the response body of a chat completions request.
+type chatCompletion struct {
+	Choices []struct {
+		Message struct {
+			Content   string `json:"content"`
+			ToolCalls []struct {
+				ID       string `json:"id"`
+				Function struct {
+					Name      string `json:"name"`
+					Arguments string `json:"arguments"` // JSON-encoded
+				} `json:"function"`
+			} `json:"tool_calls"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+// postChat sends a chat completions request and decodes the response into out.
+func postChat(ctx context.Context, cfg HTTPClientConfig, requestBody map[string]interface{}, out interface{}) error {
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", cfg.APIURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+cfg.APIKey)
@@ -61,36 +156,29 @@ func SendOnce(ctx context.Context, cfg HTTPClientConfig, prompt string) (string,
 
 	resp, err := cfg.HTTPClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	var response struct {
-		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
-		} `json:"choices"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+		err := newStatusError("", resp.StatusCode, bodyBytes)
+		cfg.KeyPool.Report(cfg.APIKey, err)
+		return err
 	}
 
-	if len(response.Choices) == 0 {
-		return "", fmt.Errorf("no content in response")
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
-
-	return response.Choices[0].Message.Content, nil
+	return nil
 }
 
 // SendStreamOnce performs a single streaming request
 func SendStreamOnce(ctx context.Context, cfg HTTPClientConfig, prompt string, onChunk func(string)) (string, error) {
+	if cfg.KeyPool != nil {
+		cfg.APIKey = cfg.KeyPool.Next()
+	}
+
 	requestBody := map[string]interface{}{
 		"model":  cfg.Model,
 		"stream": true,
@@ -127,7 +215,9 @@ func SendStreamOnce(ctx context.Context, cfg HTTPClientConfig, prompt string, on
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		err := newStatusError("", resp.StatusCode, bodyBytes)
+		cfg.KeyPool.Report(cfg.APIKey, err)
+		return "", err
 	}
 
 	var fullResponse strings.Builder
@@ -0,0 +1,117 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxRetries is the number of retries attempted for a retryable agent error.
+const DefaultMaxRetries = 3
+
+// Backoff bounds used by DefaultBackoff.
+const (
+	BackoffBase = 1 * time.Second
+	BackoffMax  = 30 * time.Second
+)
+
+// APIError is returned when a provider responds with a non-200 status code.
+type APIError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Provider != "" {
+		return fmt.Sprintf("%s API returned status %d: %s", e.Provider, e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// AuthError is returned when a provider rejects the configured credentials.
+// Retrying cannot fix it, so sessions abort instead of looping on a bad API key.
+type AuthError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("agent authentication failed (status %d), check the configured API key: %v", e.StatusCode, e.Err)
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// newStatusError builds the typed error for a non-200 provider response.
+func newStatusError(provider string, statusCode int, body []byte) error {
+	apiErr := &APIError{Provider: provider, StatusCode: statusCode, Body: string(body)}
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		return &AuthError{StatusCode: statusCode, Err: apiErr}
+	}
+	return apiErr
+}
+
+// IsRetryable reports whether an agent call that failed with err may succeed on retry.
+// Rate limits (429), server errors (5xx) and network errors are retryable; other
+// client errors (400, 401, ...) are not.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests ||
+			apiErr.StatusCode == http.StatusRequestTimeout ||
+			apiErr.StatusCode >= 500
+	}
+	// Unknown errors are usually transport failures (connection reset, DNS, timeouts)
+	return true
+}
+
+// IsFatal reports whether err means the session cannot make progress at all
+// (bad credentials, malformed request, unknown model) and should be aborted.
+func IsFatal(err error) bool {
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return true
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// Backoff returns an exponential delay for the given retry (starting at 1),
+// capped at max, with jitter so concurrent sessions do not retry in lockstep.
+// The result lies in [d/2, d] where d = min(base*2^(retry-1), max).
+func Backoff(retry int, base, max time.Duration) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+	d := max
+	if retry <= 32 {
+		if exp := base << uint(retry-1); exp > 0 && exp < max {
+			d = exp
+		}
+	}
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// DefaultBackoff is the BackoffFn used by NewBaseClient.
+func DefaultBackoff(retry int) time.Duration {
+	return Backoff(retry, BackoffBase, BackoffMax)
+}
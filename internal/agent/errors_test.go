@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"canceled", context.Canceled, false},
+		{"network", fmt.Errorf("dial tcp: connection refused"), true},
+		{"rate limited", newStatusError("", 429, []byte("slow down")), true},
+		{"server error", newStatusError("", 503, nil), true},
+		{"unauthorized", newStatusError("", 401, []byte("bad key")), false},
+		{"bad request", newStatusError("", 400, nil), false},
+		{"wrapped server error", fmt.Errorf("send: %w", newStatusError("", 500, nil)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsFatal(t *testing.T) {
+	if !IsFatal(newStatusError("", 401, nil)) {
+		t.Error("Expected 401 to be fatal")
+	}
+	if !IsFatal(newStatusError("", 400, nil)) {
+		t.Error("Expected 400 to be fatal")
+	}
+	if IsFatal(newStatusError("", 429, nil)) {
+		t.Error("Expected 429 not to be fatal")
+	}
+	if IsFatal(fmt.Errorf("connection reset")) {
+		t.Error("Expected network error not to be fatal")
+	}
+
+	var authErr *AuthError
+	if !errors.As(fmt.Errorf("wrapped: %w", newStatusError("", 403, nil)), &authErr) {
+		t.Error("Expected 403 to produce an AuthError")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	base := 100 * time.Millisecond
+	max := time.Second
+
+	for retry := 1; retry <= 10; retry++ {
+		want := base << uint(retry-1)
+		if want > max {
+			want = max
+		}
+		for i := 0; i < 20; i++ {
+			got := Backoff(retry, base, max)
+			if got < want/2 || got > want {
+				t.Fatalf("Backoff(%d) = %v, want within [%v, %v]", retry, got, want/2, want)
+			}
+		}
+	}
+
+	// Large retry counts must not overflow
+	if got := Backoff(100, base, max); got < max/2 || got > max {
+		t.Errorf("Backoff(100) = %v, want capped at %v", got, max)
+	}
+}
+
+func TestSendWithRetry_FatalErrorNotRetried(t *testing.T) {
+	calls := 0
+	client := NewGeminiClient("bad-key", "gemini-pro", "test-project")
+	client.BackoffFn = func(i int) time.Duration { return time.Millisecond }
+	client.WithMockResponder(func(prompt string) (string, error) {
+		calls++
+		return "", newStatusError("", 401, []byte("invalid api key"))
+	})
+
+	_, err := client.Send(context.Background(), "test prompt")
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("Expected AuthError, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call for a fatal error, got %d", calls)
+	}
+}
+
+func TestSendWithRetry_MaxRetries(t *testing.T) {
+	calls := 0
+	client := NewGeminiClient("fake-key", "gemini-pro", "test-project")
+	client.BackoffFn = func(i int) time.Duration { return time.Millisecond }
+	client.MaxRetries = 1
+	client.WithMockResponder(func(prompt string) (string, error) {
+		calls++
+		return "", newStatusError("", 503, nil)
+	})
+
+	if _, err := client.Send(context.Background(), "test prompt"); err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls (1 retry), got %d", calls)
+	}
+}
@@ -9,3 +9,6 @@ var execCommandContext = exec.CommandContext
 
 // execCommand allows mocking of exec.Command for testing.
 var execCommand = exec.Command
+
+// lookPath allows mocking of exec.LookPath for testing.
+var lookPath = exec.LookPath
@@ -24,7 +24,7 @@ type GeminiClient struct {
 // NewGeminiClient creates a new Gemini client
 func NewGeminiClient(apiKey, model, project string) *GeminiClient {
 	return &GeminiClient{
-		BaseClient: NewBaseClient(project, 32000), // Default to 32k for Gemini
+		BaseClient: NewBaseClient(project, model, 32000), // Default to 32k for Gemini
 		apiKey:     apiKey,
 		model:      model,
 		httpClient: &http.Client{
@@ -57,11 +57,65 @@ func (c *GeminiClient) sendOnce(ctx context.Context, prompt string) (string, err
 		return c.mockResponder(prompt)
 	}
 
-	if c.apiKey == "" {
+	apiKey := c.apiKey
+	if c.KeyPool != nil {
+		apiKey = c.KeyPool.Next()
+	}
+	if apiKey == "" {
 		return "", fmt.Errorf("API key is required")
 	}
 
-	url := fmt.Sprintf("%s/%s:generateContent", c.apiURL, c.model)
+	requestBody := map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{
+				"parts": []map[string]interface{}{
+					{"text": prompt},
+				},
+			},
+		},
+	}
+
+	response, err := c.post(ctx, apiKey, requestBody)
+	if err != nil {
+		return "", err
+	}
+
+	if len(response.Candidates) == 0 || len(response.Candidates[0].Content.Parts) == 0 {
+		return "", fmt.Errorf("no content in response")
+	}
+
+	return response.Candidates[0].Content.Parts[0].Text, nil
+}
+
+// SendWithTools sends a prompt to Gemini offering tools as function declarations.
+func (c *GeminiClient) SendWithTools(ctx context.Context, prompt string, tools []Tool) (ToolResponse, error) {
+	return c.SendWithToolsRetry(ctx, prompt, func(ctx context.Context, p string) (ToolResponse, error) {
+		return c.sendToolsOnce(ctx, p, tools)
+	})
+}
+
+func (c *GeminiClient) sendToolsOnce(ctx context.Context, prompt string, tools []Tool) (ToolResponse, error) {
+	if c.mockResponder != nil {
+		text, err := c.mockResponder(prompt)
+		return ToolResponse{Text: text}, err
+	}
+
+	apiKey := c.apiKey
+	if c.KeyPool != nil {
+		apiKey = c.KeyPool.Next()
+	}
+	if apiKey == "" {
+		return ToolResponse{}, fmt.Errorf("API key is required")
+	}
+
+	declarations := make([]map[string]interface{}, len(tools))
+	for i, tool := range tools {
+		declarations[i] = map[string]interface{}{
+			"name":        tool.Name,
+			"description": tool.Description,
+			"parameters":  tool.Parameters,
+		}
+	}
 
 	requestBody := map[string]interface{}{
 		"contents": []map[string]interface{}{
@@ -71,50 +125,77 @@ func (c *GeminiClient) sendOnce(ctx context.Context, prompt string) (string, err
 				},
 			},
 		},
+		"tools": []map[string]interface{}{
+			{"functionDeclarations": declarations},
+		},
+	}
+
+	response, err := c.post(ctx, apiKey, requestBody)
+	if err != nil {
+		return ToolResponse{}, err
+	}
+
+	var result ToolResponse
+	if len(response.Candidates) > 0 {
+		for _, part := range response.Candidates[0].Content.Parts {
+			result.Text += part.Text
+			if part.FunctionCall != nil {
+				result.ToolCalls = append(result.ToolCalls, ToolCall{Name: part.FunctionCall.Name, Arguments: part.FunctionCall.Args})
+			}
+		}
 	}
+	return result, nil
+}
+
+// geminiResponse is the response body of a generateContent request.
+type geminiResponse struct {
+	Candidates []struct {
+		Content struct {
+			Parts []struct {
+				Text         string `json:"text"`
+				FunctionCall *struct {
+					Name string                 `json:"name"`
+					Args map[string]interface{} `json:"args"`
+				} `json:"functionCall"`
+			} `json:"parts"`
+		} `json:"content"`
+	} `json:"candidates"`
+}
+
+// post sends a generateContent request to the client's model.
+func (c *GeminiClient) post(ctx context.Context, apiKey string, requestBody map[string]interface{}) (geminiResponse, error) {
+	var response geminiResponse
+	url := fmt.Sprintf("%s/%s:generateContent", c.apiURL, c.model)
 
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return response, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return response, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-goog-api-key", c.apiKey)
+	req.Header.Set("x-goog-api-key", apiKey)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return response, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	var response struct {
-		Candidates []struct {
-			Content struct {
-				Parts []struct {
-					Text string `json:"text"`
-				} `json:"parts"`
-			} `json:"content"`
-		} `json:"candidates"`
+		err := newStatusError("", resp.StatusCode, bodyBytes)
+		c.KeyPool.Report(apiKey, err)
+		return response, err
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+		return response, fmt.Errorf("failed to decode response: %w", err)
 	}
-
-	if len(response.Candidates) == 0 || len(response.Candidates[0].Content.Parts) == 0 {
-		return "", fmt.Errorf("no content in response")
-	}
-
-	return response.Candidates[0].Content.Parts[0].Text, nil
+	return response, nil
 }
 
 // SendStream fallback for Gemini (calls Send and emits once)
@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+)
+
+// pingPrompt is the minimal prompt used to verify a provider accepts our credentials and model.
+const pingPrompt = "Reply with OK."
+
+// Pinger is implemented by agents that support a lightweight provider health check.
+type Pinger interface {
+	// Ping verifies the provider is reachable, the credentials are valid and the model exists.
+	Ping(ctx context.Context) error
+}
+
+// Ping runs the provider health check for a, if it supports one.
+// Agents that do not implement Pinger are assumed healthy.
+func Ping(ctx context.Context, a Agent) error {
+	p, ok := a.(Pinger)
+	if !ok {
+		return nil
+	}
+	if err := p.Ping(ctx); err != nil {
+		return fmt.Errorf("provider health check failed: %w", err)
+	}
+	return nil
+}
+
+// Ping sends a single minimal request to Gemini, bypassing retries and state tracking.
+func (c *GeminiClient) Ping(ctx context.Context) error {
+	_, err := c.sendOnce(ctx, pingPrompt)
+	return err
+}
+
+// Ping sends a single minimal request to Anthropic, bypassing retries and state tracking.
+func (c *AnthropicClient) Ping(ctx context.Context) error {
+	_, err := c.sendOnce(ctx, pingPrompt)
+	return err
+}
+
+// Ping sends a single minimal request to OpenAI, bypassing retries and state tracking.
+func (c *OpenAIClient) Ping(ctx context.Context) error {
+	_, err := c.sendOnce(ctx, pingPrompt)
+	return err
+}
+
+// Ping sends a single minimal request to OpenRouter, bypassing retries and state tracking.
+func (c *OpenRouterClient) Ping(ctx context.Context) error {
+	_, err := c.sendOnce(ctx, pingPrompt)
+	return err
+}
+
+// Ping sends a single minimal request to Ollama, bypassing retries and state tracking.
+func (c *OllamaClient) Ping(ctx context.Context) error {
+	_, err := c.sendOnce(ctx, pingPrompt)
+	return err
+}
+
+// Ping checks that the gemini CLI is installed.
+func (c *GeminiCLIClient) Ping(ctx context.Context) error {
+	return checkBinary("gemini")
+}
+
+// Ping checks that the cursor-agent CLI is installed.
+func (c *CursorCLIClient) Ping(ctx context.Context) error {
+	return checkBinary("cursor-agent")
+}
+
+// Ping checks that the opencode CLI is installed.
+func (c *OpenCodeCLIClient) Ping(ctx context.Context) error {
+	return checkBinary("opencode")
+}
+
+func checkBinary(name string) error {
+	if _, err := lookPath(name); err != nil {
+		return fmt.Errorf("%s CLI not found in PATH: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPing_HTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"invalid key"}`))
+	}))
+	defer server.Close()
+
+	client := NewOpenRouterClient("bad-key", "some/model", "test-project")
+	client.apiURL = server.URL
+
+	err := Ping(context.Background(), client)
+	if err == nil {
+		t.Fatal("expected health check to fail")
+	}
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Errorf("expected AuthError, got %v", err)
+	}
+}
+
+func TestPing_Mock(t *testing.T) {
+	client := NewOpenAIClient("test-key", "gpt-4", "test-project")
+	client.WithMockResponder(func(prompt string) (string, error) {
+		return "OK", nil
+	})
+
+	if err := Ping(context.Background(), client); err != nil {
+		t.Errorf("expected healthy provider, got %v", err)
+	}
+}
+
+func TestPing_CLIBinaryMissing(t *testing.T) {
+	origLookPath := lookPath
+	defer func() { lookPath = origLookPath }()
+	lookPath = func(file string) (string, error) {
+		return "", errors.New("not found")
+	}
+
+	err := Ping(context.Background(), NewCursorCLIClient("", "auto", "test-project"))
+	if err == nil {
+		t.Fatal("expected health check to fail when the CLI is missing")
+	}
+}
+
+func TestPing_NoPinger(t *testing.T) {
+	if err := Ping(context.Background(), NewMockAgent()); err != nil {
+		t.Errorf("expected agents without a health check to pass, got %v", err)
+	}
+}
+
+func TestPing_Anthropic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"type":"authentication_error"}}`))
+	}))
+	defer server.Close()
+
+	client := NewAnthropicClient("bad-key", "claude-3-5-sonnet", "test-project")
+	client.apiURL = server.URL
+
+	err := Ping(context.Background(), client)
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Errorf("expected AuthError, got %v", err)
+	}
+}
@@ -18,6 +18,7 @@ type Agent interface {
 
 // NewAgent is a factory function that returns an Agent based on the provider
 // For Ollama, apiKey is used as baseURL (optional, defaults to http://localhost:11434)
+// If a key pool is configured for the provider (e.g. OPENROUTER_API_KEYS), it takes precedence over apiKey.
 func NewAgent(provider, apiKey, model, workDir, project string) (Agent, error) {
 	// Default to "unknown" if project is empty
 	if project == "" {
@@ -46,15 +47,25 @@ func NewAgent(provider, apiKey, model, workDir, project string) (Agent, error) {
 
 	switch provider {
 	case "gemini":
-		return NewGeminiClient(apiKey, model, project), nil
+		client := NewGeminiClient(apiKey, model, project)
+		client.KeyPool = KeyPoolFor(provider)
+		return client, nil
 	case "gemini-cli":
 		return NewGeminiCLIClient(apiKey, model, workDir, project), nil
 	case "openai":
-		return NewOpenAIClient(apiKey, model, project), nil
+		client := NewOpenAIClient(apiKey, model, project)
+		client.KeyPool = KeyPoolFor(provider)
+		return client, nil
 	case "ollama":
 		return NewOllamaClient(apiKey, model, project), nil
 	case "openrouter":
-		return NewOpenRouterClient(apiKey, model, project), nil
+		client := NewOpenRouterClient(apiKey, model, project)
+		client.KeyPool = KeyPoolFor(provider)
+		return client, nil
+	case "anthropic":
+		client := NewAnthropicClient(apiKey, model, project)
+		client.KeyPool = KeyPoolFor(provider)
+		return client, nil
 	case "cursor-cli":
 		return NewCursorCLIClient(apiKey, model, project), nil
 	case "opencode", "opencode-cli":
@@ -0,0 +1,129 @@
+package agent
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyCooldown is how long a key is skipped after the provider rate-limits it.
+const KeyCooldown = 60 * time.Second
+
+// keyPoolEnv maps providers to the env var holding their comma-separated API key pool.
+var keyPoolEnv = map[string]string{
+	"gemini":     "GEMINI_API_KEYS",
+	"openai":     "OPENAI_API_KEYS",
+	"openrouter": "OPENROUTER_API_KEYS",
+	"anthropic":  "ANTHROPIC_API_KEYS",
+}
+
+// KeyPool hands out API keys round-robin, skipping keys that were recently rate-limited.
+// It is safe for concurrent use, so agents spawned in the same process share the load.
+type KeyPool struct {
+	mu        sync.Mutex
+	keys      []string
+	next      int
+	coolUntil map[string]time.Time
+	now       func() time.Time
+}
+
+// NewKeyPool creates a pool over keys, starting at a random key so that
+// separate processes don't all hammer the first key.
+func NewKeyPool(keys []string) *KeyPool {
+	p := &KeyPool{
+		keys:      keys,
+		coolUntil: make(map[string]time.Time),
+		now:       time.Now,
+	}
+	if len(keys) > 0 {
+		p.next = rand.Intn(len(keys))
+	}
+	return p
+}
+
+// Next returns the next key that is not cooling down. If every key is cooling
+// down, the one that becomes available first is returned.
+func (p *KeyPool) Next() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.keys) == 0 {
+		return ""
+	}
+
+	now := p.now()
+	best := -1
+	for i := 0; i < len(p.keys); i++ {
+		idx := (p.next + i) % len(p.keys)
+		until := p.coolUntil[p.keys[idx]]
+		if !now.Before(until) {
+			best = idx
+			break
+		}
+		if best == -1 || until.Before(p.coolUntil[p.keys[best]]) {
+			best = idx
+		}
+	}
+	p.next = (best + 1) % len(p.keys)
+	return p.keys[best]
+}
+
+// MarkRateLimited puts key on cooldown for KeyCooldown.
+func (p *KeyPool) MarkRateLimited(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.coolUntil[key] = p.now().Add(KeyCooldown)
+}
+
+// Report records the outcome of a request made with key, cooling the key
+// down if the provider rate-limited it. It is a no-op on a nil pool.
+func (p *KeyPool) Report(key string, err error) {
+	if p == nil || err == nil {
+		return
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+		p.MarkRateLimited(key)
+	}
+}
+
+var (
+	keyPoolsMu sync.Mutex
+	keyPools   = map[string]*KeyPool{}
+)
+
+// KeyPoolFor returns the process-wide key pool configured for provider
+// (e.g. OPENROUTER_API_KEYS=key1,key2), or nil if none is configured.
+func KeyPoolFor(provider string) *KeyPool {
+	envVar, ok := keyPoolEnv[provider]
+	if !ok {
+		return nil
+	}
+	raw := os.Getenv(envVar)
+
+	var keys []string
+	for _, k := range strings.Split(raw, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	keyPoolsMu.Lock()
+	defer keyPoolsMu.Unlock()
+
+	// Keyed by the raw value so a changed env var yields a fresh pool
+	cacheKey := provider + "=" + raw
+	if p, ok := keyPools[cacheKey]; ok {
+		return p
+	}
+	p := NewKeyPool(keys)
+	keyPools[cacheKey] = p
+	return p
+}
@@ -0,0 +1,108 @@
+package agent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestKeyPool_RoundRobin(t *testing.T) {
+	p := NewKeyPool([]string{"a", "b", "c"})
+	p.next = 0
+
+	got := []string{p.Next(), p.Next(), p.Next(), p.Next()}
+	want := []string{"a", "b", "c", "a"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestKeyPool_Cooldown(t *testing.T) {
+	now := time.Now()
+	p := NewKeyPool([]string{"a", "b"})
+	p.next = 0
+	p.now = func() time.Time { return now }
+
+	p.Report("a", &APIError{StatusCode: http.StatusTooManyRequests})
+	for i := 0; i < 3; i++ {
+		if k := p.Next(); k != "b" {
+			t.Fatalf("expected rate-limited key to be skipped, got %q", k)
+		}
+	}
+
+	// All keys cooling down: the one available soonest is used
+	now = now.Add(time.Second)
+	p.MarkRateLimited("b")
+	if k := p.Next(); k != "a" {
+		t.Errorf("expected key with the earliest cooldown expiry, got %q", k)
+	}
+
+	now = now.Add(KeyCooldown)
+	if k := p.Next(); k != "a" && k != "b" {
+		t.Errorf("unexpected key %q", k)
+	}
+}
+
+func TestKeyPool_ReportIgnoresOtherErrors(t *testing.T) {
+	p := NewKeyPool([]string{"a", "b"})
+	p.Report("a", &APIError{StatusCode: http.StatusInternalServerError})
+	if _, ok := p.coolUntil["a"]; ok {
+		t.Error("expected only 429 responses to cool a key down")
+	}
+
+	var nilPool *KeyPool
+	nilPool.Report("a", &APIError{StatusCode: http.StatusTooManyRequests}) // must not panic
+}
+
+func TestKeyPoolFor(t *testing.T) {
+	t.Setenv("OPENROUTER_API_KEYS", "k1, k2,,")
+	p := KeyPoolFor("openrouter")
+	if p == nil || len(p.keys) != 2 {
+		t.Fatalf("expected pool with 2 keys, got %+v", p)
+	}
+	if KeyPoolFor("openrouter") != p {
+		t.Error("expected the pool to be shared across agents")
+	}
+	if KeyPoolFor("ollama") != nil {
+		t.Error("expected no pool for providers without key pool support")
+	}
+}
+
+func TestNewAgent_KeyPoolRotatesOn429(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Authorization")
+		seen = append(seen, key)
+		if key == "Bearer limited" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"choices":[{"message":{"content":"ok"}}]}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("OPENAI_API_KEYS", "limited,fresh")
+	a, err := NewAgent("openai", "single-key", "gpt-4", "", "test-project")
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+	client := a.(*OpenAIClient)
+	client.apiURL = server.URL
+	client.BackoffFn = func(int) time.Duration { return 0 }
+	client.KeyPool.next = 0
+
+	resp, err := client.Send(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if resp != "ok" {
+		t.Errorf("expected 'ok', got %q", resp)
+	}
+	if len(seen) != 2 || seen[0] != "Bearer limited" || seen[1] != "Bearer fresh" {
+		t.Errorf("expected rotation from the rate-limited key, got %v", seen)
+	}
+}
@@ -28,7 +28,7 @@ func NewOllamaClient(baseURL, model, project string) *OllamaClient {
 		baseURL = "http://localhost:11434"
 	}
 	return &OllamaClient{
-		BaseClient: NewBaseClient(project, 8192), // Default to 8k for local models
+		BaseClient: NewBaseClient(project, model, 8192), // Default to 8k for local models
 		baseURL:    baseURL,
 		model:      model,
 		httpClient: &http.Client{
@@ -93,7 +93,7 @@ func (c *OllamaClient) sendOnce(ctx context.Context, prompt string) (string, err
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("Ollama API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return "", newStatusError("Ollama", resp.StatusCode, bodyBytes)
 	}
 
 	// Ollama response format
@@ -20,7 +20,7 @@ type OpenAIClient struct {
 // NewOpenAIClient creates a new OpenAI client
 func NewOpenAIClient(apiKey, model, project string) *OpenAIClient {
 	return &OpenAIClient{
-		BaseClient: NewBaseClient(project, 128000), // Default to 128k for GPT-4
+		BaseClient: NewBaseClient(project, model, 128000), // Default to 128k for GPT-4
 		apiKey:     apiKey,
 		model:      model,
 		httpClient: &http.Client{
@@ -50,6 +50,7 @@ func (c *OpenAIClient) getConfig() HTTPClientConfig {
 		APIURL:        c.apiURL,
 		HTTPClient:    c.httpClient,
 		MockResponder: c.mockResponder,
+		KeyPool:       c.KeyPool,
 	}
 }
 
@@ -69,3 +70,10 @@ func (c *OpenAIClient) SendStream(ctx context.Context, prompt string, onChunk fu
 		return SendStreamOnce(ctx, c.getConfig(), p, oc)
 	}, onChunk)
 }
+
+// SendWithTools sends a prompt to OpenAI offering tools as callable functions.
+func (c *OpenAIClient) SendWithTools(ctx context.Context, prompt string, tools []Tool) (ToolResponse, error) {
+	return c.SendWithToolsRetry(ctx, prompt, func(ctx context.Context, p string) (ToolResponse, error) {
+		return SendToolsOnce(ctx, c.getConfig(), p, tools)
+	})
+}
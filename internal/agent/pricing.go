@@ -1,5 +1,7 @@
 package agent
 
+import "strings"
+
 // PricePerMillionTokens defines the cost in USD per million tokens for a given model.
 type PricePerMillionTokens struct {
 	Prompt     float64
@@ -22,6 +24,22 @@ var PricingTable = map[string]PricePerMillionTokens{
 	"claude-3-opus-20240229":   {Prompt: 15.00, Completion: 75.00},
 	"claude-3-sonnet-20240229": {Prompt: 3.00, Completion: 15.00},
 	"claude-3-haiku-20240307":  {Prompt: 0.25, Completion: 1.25},
+	"claude-sonnet-4-5":        {Prompt: 3.00, Completion: 15.00},
+}
+
+// ProviderPricingTable maps a provider to the models it bills differently from
+// PricingTable. The "*" entry applies to every model of the provider.
+var ProviderPricingTable = map[string]map[string]PricePerMillionTokens{
+	// Local models cost nothing per token
+	"ollama": {"*": {}},
+
+	// OpenRouter serves models under vendor-prefixed names at its own prices
+	"openrouter": {
+		"gpt-4o":                      {Prompt: 2.50, Completion: 10.00},
+		"openai/gpt-4o":               {Prompt: 2.50, Completion: 10.00},
+		"anthropic/claude-3.5-sonnet": {Prompt: 3.00, Completion: 15.00},
+		"google/gemini-pro-1.5":       {Prompt: 1.25, Completion: 5.00},
+	},
 }
 
 // CalculateCost calculates the estimated cost based on token usage and model pricing.
@@ -31,8 +49,26 @@ func CalculateCost(model string, usage TokenUsage) float64 {
 		// Fallback for unknown models
 		return float64(usage.TotalTokens) / 1_000_000.0
 	}
+	return price.cost(usage)
+}
+
+// CalculateProviderCost calculates the estimated cost of a model as billed by
+// provider, so the same model name can be priced differently per provider.
+// Models the provider has no specific price for fall back to CalculateCost.
+func CalculateProviderCost(provider, model string, usage TokenUsage) float64 {
+	if prices, ok := ProviderPricingTable[strings.ToLower(provider)]; ok {
+		if price, ok := prices[model]; ok {
+			return price.cost(usage)
+		}
+		if price, ok := prices["*"]; ok {
+			return price.cost(usage)
+		}
+	}
+	return CalculateCost(model, usage)
+}
 
-	promptCost := (float64(usage.TotalPromptTokens) / 1_000_000.0) * price.Prompt
-	completionCost := (float64(usage.TotalResponseTokens) / 1_000_000.0) * price.Completion
+func (p PricePerMillionTokens) cost(usage TokenUsage) float64 {
+	promptCost := (float64(usage.TotalPromptTokens) / 1_000_000.0) * p.Prompt
+	completionCost := (float64(usage.TotalResponseTokens) / 1_000_000.0) * p.Completion
 	return promptCost + completionCost
 }
@@ -69,3 +69,37 @@ func TestCalculateCost(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateProviderCost(t *testing.T) {
+	usage := TokenUsage{
+		TotalPromptTokens:   1000000,
+		TotalResponseTokens: 1000000,
+		TotalTokens:         2000000,
+	}
+
+	tests := []struct {
+		name     string
+		provider string
+		model    string
+		expected float64
+	}{
+		{"OpenAI GPT-4o", "openai", "gpt-4o", 5.00 + 15.00},
+		{"OpenRouter GPT-4o", "openrouter", "gpt-4o", 2.50 + 10.00},
+		{"OpenRouter prefixed name", "openrouter", "openai/gpt-4o", 2.50 + 10.00},
+		{"Provider is case-insensitive", "OpenRouter", "gpt-4o", 2.50 + 10.00},
+		{"OpenRouter model without own price", "openrouter", "gemini-pro", 0.50 + 1.50},
+		{"Ollama is free", "ollama", "llama3", 0},
+		{"Unknown provider", "", "gpt-4o", 5.00 + 15.00},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost := CalculateProviderCost(tt.provider, tt.model, usage)
+
+			epsilon := 0.000001
+			if cost < tt.expected-epsilon || cost > tt.expected+epsilon {
+				t.Errorf("CalculateProviderCost(%q, %q) = %f, expected %f", tt.provider, tt.model, cost, tt.expected)
+			}
+		})
+	}
+}
@@ -13,13 +13,15 @@ var templateFS embed.FS
 
 // List of available prompt templates
 const (
-	Planner        = "planner"
-	ManagerReview  = "manager_review"
-	CodingAgent    = "coding_agent"
-	Initializer    = "initializer"
-	QAAgent        = "qa_agent"
-	TPMAgent       = "tpm_agent"
-	ArchitectAgent = "architect_agent"
+	Planner          = "planner"
+	ManagerReview    = "manager_review"
+	CodingAgent      = "coding_agent"
+	Initializer      = "initializer"
+	QAAgent          = "qa_agent"
+	TPMAgent         = "tpm_agent"
+	ArchitectAgent   = "architect_agent"
+	ConflictResolver = "conflict_resolver"
+	SpecWriter       = "spec_writer"
 )
 
 // ListPrompts returns a list of available embedded prompts.
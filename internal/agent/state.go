@@ -11,7 +11,8 @@ import (
 
 // State represents the persistent state of an agent
 type State struct {
-	Model         string                 `json:"model,omitempty"` // Name of the model used
+	Provider      string                 `json:"provider,omitempty"` // Provider serving the model, for pricing
+	Model         string                 `json:"model,omitempty"`    // Name of the model used
 	Memory        []string               `json:"memory"`
 	History       []Message              `json:"history"`
 	Metadata      map[string]interface{} `json:"metadata"`
@@ -20,6 +21,9 @@ type State struct {
 	MaxTokens     int                    `json:"max_tokens,omitempty"`     // Maximum token limit for context window
 	CurrentTokens int                    `json:"current_tokens,omitempty"` // Current token count in context
 	TokenUsage    TokenUsage             `json:"token_usage,omitempty"`    // Token usage statistics
+	// Token usage broken down by session role and by iteration
+	RoleUsage      map[string]RoleUsage `json:"role_usage,omitempty"`
+	IterationUsage []IterationUsage     `json:"iteration_usage,omitempty"`
 }
 
 // TokenUsage tracks token consumption statistics
@@ -28,12 +32,15 @@ type TokenUsage struct {
 	TotalResponseTokens int `json:"total_response_tokens"` // Total tokens in responses received
 	TotalTokens         int `json:"total_tokens"`          // Total tokens used (prompt + response)
 	TruncationCount     int `json:"truncation_count"`      // Number of times truncation occurred
+	CacheReadTokens     int `json:"cache_read_tokens"`     // Prompt tokens served from the provider's prompt cache
+	CacheCreationTokens int `json:"cache_creation_tokens"` // Prompt tokens written to the provider's prompt cache
 }
 
 // Message represents a chat message
 type Message struct {
 	Role      string    `json:"role"`
 	Content   string    `json:"content"`
+	Tokens    int       `json:"tokens,omitempty"` // Token count of Content, as counted by the client
 	Timestamp time.Time `json:"timestamp"`
 }
 
@@ -92,10 +99,7 @@ func (sm *StateManager) saveState(state State) error {
 	}
 
 	// Truncate history to avoid infinite growth and context overflow
-	const maxHistoryEntries = 50
-	if len(state.History) > maxHistoryEntries {
-		state.History = state.History[len(state.History)-maxHistoryEntries:]
-	}
+	state.History = trimHistory(state.History, state.MaxTokens)
 
 	data, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
@@ -120,6 +124,33 @@ func (sm *StateManager) saveState(state State) error {
 	return nil
 }
 
+// maxHistoryEntries caps the history regardless of its size in tokens.
+const maxHistoryEntries = 50
+
+// trimHistory keeps the newest messages that fit in maxTokens (when set),
+// capped at maxHistoryEntries. The latest message is always kept.
+func trimHistory(history []Message, maxTokens int) []Message {
+	if len(history) > maxHistoryEntries {
+		history = history[len(history)-maxHistoryEntries:]
+	}
+	if maxTokens <= 0 {
+		return history
+	}
+
+	total := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		tokens := history[i].Tokens
+		if tokens == 0 {
+			tokens = EstimateTokenCount(history[i].Content)
+		}
+		total += tokens
+		if total > maxTokens && i < len(history)-1 {
+			return history[i+1:]
+		}
+	}
+	return history
+}
+
 // Save writes the state to disk
 func (sm *StateManager) Save(state State) error {
 	sm.mu.Lock()
@@ -159,8 +190,8 @@ func (sm *StateManager) AddMemory(memoryItem string) error {
 	return sm.saveState(state)
 }
 
-// InitializeState initializes the state with max_tokens and model if not already set
-func (sm *StateManager) InitializeState(maxTokens int, model string) error {
+// InitializeState initializes the state with max_tokens, provider and model if not already set
+func (sm *StateManager) InitializeState(maxTokens int, provider, model string) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
@@ -176,6 +207,11 @@ func (sm *StateManager) InitializeState(maxTokens int, model string) error {
 		needsSave = true
 	}
 
+	if state.Provider == "" && provider != "" {
+		state.Provider = provider
+		needsSave = true
+	}
+
 	if state.Model == "" && model != "" {
 		state.Model = model
 		needsSave = true
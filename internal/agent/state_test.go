@@ -68,3 +68,53 @@ func TestStateManager_CorruptLoad(t *testing.T) {
 		t.Errorf("error message mismatch. Got: %q, Expected start: %q", err.Error(), expectedSnippet)
 	}
 }
+
+func TestTrimHistory(t *testing.T) {
+	history := []Message{
+		{Content: "first", Tokens: 40},
+		{Content: "second", Tokens: 40},
+		{Content: "third", Tokens: 40},
+	}
+
+	// The newest messages that fit are kept
+	trimmed := trimHistory(history, 100)
+	if len(trimmed) != 2 || trimmed[0].Content != "second" {
+		t.Errorf("Expected the last 2 messages, got %+v", trimmed)
+	}
+
+	// The latest message is kept even when it alone exceeds the budget
+	trimmed = trimHistory(history, 10)
+	if len(trimmed) != 1 || trimmed[0].Content != "third" {
+		t.Errorf("Expected only the latest message, got %+v", trimmed)
+	}
+
+	// Without a token limit only the entry cap applies
+	long := make([]Message, maxHistoryEntries+10)
+	if got := len(trimHistory(long, 0)); got != maxHistoryEntries {
+		t.Errorf("Expected %d messages, got %d", maxHistoryEntries, got)
+	}
+}
+
+func TestStateManager_InitializeStateProvider(t *testing.T) {
+	sm := NewStateManager(filepath.Join(t.TempDir(), "state.json"))
+
+	if err := sm.InitializeState(1000, "openrouter", "gpt-4o"); err != nil {
+		t.Fatalf("InitializeState failed: %v", err)
+	}
+	state, err := sm.Load()
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	if state.Provider != "openrouter" || state.Model != "gpt-4o" {
+		t.Errorf("expected openrouter/gpt-4o, got %q/%q", state.Provider, state.Model)
+	}
+
+	// A resumed session keeps the provider it started with
+	if err := sm.InitializeState(1000, "openai", "gpt-4o"); err != nil {
+		t.Fatalf("InitializeState failed: %v", err)
+	}
+	state, _ = sm.Load()
+	if state.Provider != "openrouter" {
+		t.Errorf("expected provider to stay openrouter, got %q", state.Provider)
+	}
+}
@@ -3,20 +3,16 @@ package agent
 import (
 	"strconv"
 	"strings"
+
+	"recac/internal/tokenize"
 )
 
 // EstimateTokenCount estimates the number of tokens in a text string.
 // Uses approximate counting: ~4 characters per token for English text.
-// This is a rough approximation; actual tokenization varies by model.
+// This is a rough approximation; clients count with their model's tokenizer
+// where one is available (see tokenize.ForModel).
 func EstimateTokenCount(text string) int {
-	n := len(text)
-	if n == 0 {
-		return 0
-	}
-	// Approximate: 4 characters per token for English.
-	// We use len(text) (byte count) instead of RuneCountInString for performance (O(1) vs O(N)).
-	// For ASCII, bytes == runes. For UTF-8, bytes >= runes, so this slightly overestimates, which is safer for limits.
-	return (n / 4) + 1
+	return tokenize.Heuristic{}.Count(text)
 }
 
 // TruncateToTokenLimit truncates text to fit within a token limit while preserving important context.
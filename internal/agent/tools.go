@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Tool describes a function the model may call instead of answering in prose.
+type Tool struct {
+	Name        string
+	Description string
+	// Parameters is the JSON Schema of the call's arguments.
+	Parameters map[string]interface{}
+}
+
+// ToolCall is a structured call returned by the model.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments map[string]interface{}
+}
+
+// ToolResponse is the text and tool calls of a tool-enabled response.
+type ToolResponse struct {
+	Text      string
+	ToolCalls []ToolCall
+}
+
+// ToolAgent is implemented by agents whose provider supports structured tool
+// calling. Callers fall back to Send for agents that don't implement it.
+type ToolAgent interface {
+	Agent
+
+	// SendWithTools sends a prompt offering tools, returning the response text
+	// together with any tool calls the model made.
+	SendWithTools(ctx context.Context, prompt string, tools []Tool) (ToolResponse, error)
+}
+
+// RunShellToolName is the name of RunShellTool.
+const RunShellToolName = "run_shell"
+
+// RunShellTool lets the model run a bash command in the workspace, replacing
+// fenced ```bash blocks in its responses.
+var RunShellTool = Tool{
+	Name:        RunShellToolName,
+	Description: "Run a bash command in the project workspace and return its combined output. Commands run in order; later commands are skipped once one fails.",
+	Parameters: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"command": map[string]interface{}{
+				"type":        "string",
+				"description": "The bash script to run.",
+			},
+		},
+		"required": []string{"command"},
+	},
+}
+
+// ShellCommands returns the commands of the run_shell calls in r, in order.
+func (r ToolResponse) ShellCommands() []string {
+	var commands []string
+	for _, call := range r.ToolCalls {
+		if call.Name != RunShellToolName {
+			continue
+		}
+		if command, _ := call.Arguments["command"].(string); strings.TrimSpace(command) != "" {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// String renders r as a plain text response, with run_shell calls written as
+// ```bash blocks so history and logs read the same as without tool calling.
+func (r ToolResponse) String() string {
+	var b strings.Builder
+	b.WriteString(r.Text)
+	for _, call := range r.ToolCalls {
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		if command, ok := call.Arguments["command"].(string); ok && call.Name == RunShellToolName {
+			fmt.Fprintf(&b, "```bash\n%s\n```", command)
+		} else {
+			fmt.Fprintf(&b, "[tool call %s %v]", call.Name, call.Arguments)
+		}
+	}
+	return b.String()
+}
+
+// SendWithToolsRetry runs sendOnce through SendWithRetry, so tool-enabled
+// requests share the retry loop, token tracking and telemetry of Send. The
+// rendered response (see ToolResponse.String) is what the state records.
+func (c *BaseClient) SendWithToolsRetry(ctx context.Context, prompt string, sendOnce func(context.Context, string) (ToolResponse, error)) (ToolResponse, error) {
+	var result ToolResponse
+	_, err := c.SendWithRetry(ctx, prompt, func(ctx context.Context, p string) (string, error) {
+		resp, err := sendOnce(ctx, p)
+		if err != nil {
+			return "", err
+		}
+		if resp.Text == "" && len(resp.ToolCalls) == 0 {
+			return "", fmt.Errorf("no content in response")
+		}
+		result = resp
+		return resp.String(), nil
+	})
+	if err != nil {
+		return ToolResponse{}, err
+	}
+	return result, nil
+}
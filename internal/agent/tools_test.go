@@ -0,0 +1,151 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// toolServer serves body to every request, recording the decoded requests.
+func toolServer(t *testing.T, body string, requests *[]map[string]interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("bad request body: %v", err)
+		}
+		*requests = append(*requests, req)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestToolResponse_ShellCommandsAndString(t *testing.T) {
+	resp := ToolResponse{
+		Text: "Building first.",
+		ToolCalls: []ToolCall{
+			{Name: RunShellToolName, Arguments: map[string]interface{}{"command": "go build ./..."}},
+			{Name: "other", Arguments: map[string]interface{}{"x": 1.0}},
+			{Name: RunShellToolName, Arguments: map[string]interface{}{"command": "  "}},
+			{Name: RunShellToolName, Arguments: map[string]interface{}{"command": "echo '```'"}},
+		},
+	}
+
+	if got, want := resp.ShellCommands(), []string{"go build ./...", "echo '```'"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ShellCommands() = %q, want %q", got, want)
+	}
+
+	rendered := resp.String()
+	if !strings.HasPrefix(rendered, "Building first.\n\n```bash\ngo build ./...\n```") {
+		t.Errorf("unexpected rendering: %q", rendered)
+	}
+	if !strings.Contains(rendered, "[tool call other map[x:1]]") {
+		t.Errorf("expected other tool calls to be rendered, got %q", rendered)
+	}
+}
+
+func TestOpenAIClient_SendWithTools(t *testing.T) {
+	var requests []map[string]interface{}
+	server := toolServer(t, `{"choices":[{"message":{"content":"","tool_calls":[{"id":"call_1","type":"function","function":{"name":"run_shell","arguments":"{\"command\":\"ls -la\"}"}}]}}]}`, &requests)
+
+	client := NewOpenAIClient("test-key", "gpt-4", "test-project")
+	client.apiURL = server.URL
+	sm := NewStateManager(filepath.Join(t.TempDir(), "state.json"))
+	client.WithStateManager(sm)
+
+	resp, err := client.SendWithTools(context.Background(), "list files", []Tool{RunShellTool})
+	if err != nil {
+		t.Fatalf("SendWithTools failed: %v", err)
+	}
+	if got := resp.ShellCommands(); !reflect.DeepEqual(got, []string{"ls -la"}) {
+		t.Errorf("ShellCommands() = %q", got)
+	}
+	if resp.ToolCalls[0].ID != "call_1" {
+		t.Errorf("expected call ID, got %q", resp.ToolCalls[0].ID)
+	}
+
+	tools := requests[0]["tools"].([]interface{})
+	fn := tools[0].(map[string]interface{})["function"].(map[string]interface{})
+	if fn["name"] != RunShellToolName || fn["parameters"] == nil {
+		t.Errorf("unexpected tool spec: %v", fn)
+	}
+
+	// The rendered response is what the state records
+	state, _ := sm.Load()
+	if last := state.History[len(state.History)-1]; last.Content != "```bash\nls -la\n```" {
+		t.Errorf("unexpected history entry: %q", last.Content)
+	}
+}
+
+func TestAnthropicClient_SendWithTools(t *testing.T) {
+	var requests []map[string]interface{}
+	server := toolServer(t, `{"content":[{"type":"text","text":"Running tests."},{"type":"tool_use","id":"toolu_1","name":"run_shell","input":{"command":"go test ./..."}}],"usage":{}}`, &requests)
+
+	client := NewAnthropicClient("test-key", "claude-sonnet-4-5", "test-project")
+	client.apiURL = server.URL
+
+	resp, err := client.SendWithTools(context.Background(), "run tests", []Tool{RunShellTool})
+	if err != nil {
+		t.Fatalf("SendWithTools failed: %v", err)
+	}
+	if resp.Text != "Running tests." {
+		t.Errorf("unexpected text %q", resp.Text)
+	}
+	if got := resp.ShellCommands(); !reflect.DeepEqual(got, []string{"go test ./..."}) {
+		t.Errorf("ShellCommands() = %q", got)
+	}
+
+	tool := requests[0]["tools"].([]interface{})[0].(map[string]interface{})
+	if tool["name"] != RunShellToolName || tool["input_schema"] == nil {
+		t.Errorf("unexpected tool spec: %v", tool)
+	}
+}
+
+func TestGeminiClient_SendWithTools(t *testing.T) {
+	var requests []map[string]interface{}
+	server := toolServer(t, `{"candidates":[{"content":{"parts":[{"functionCall":{"name":"run_shell","args":{"command":"make"}}}]}}]}`, &requests)
+
+	client := NewGeminiClient("test-key", "gemini-pro", "test-project")
+	client.apiURL = server.URL
+
+	resp, err := client.SendWithTools(context.Background(), "build", []Tool{RunShellTool})
+	if err != nil {
+		t.Fatalf("SendWithTools failed: %v", err)
+	}
+	if got := resp.ShellCommands(); !reflect.DeepEqual(got, []string{"make"}) {
+		t.Errorf("ShellCommands() = %q", got)
+	}
+
+	declarations := requests[0]["tools"].([]interface{})[0].(map[string]interface{})["functionDeclarations"].([]interface{})
+	if declarations[0].(map[string]interface{})["name"] != RunShellToolName {
+		t.Errorf("unexpected declarations: %v", declarations)
+	}
+}
+
+func TestSendWithTools_EmptyResponse(t *testing.T) {
+	var requests []map[string]interface{}
+	server := toolServer(t, `{"choices":[{"message":{"content":""}}]}`, &requests)
+
+	client := NewOpenAIClient("test-key", "gpt-4", "test-project")
+	client.apiURL = server.URL
+	client.MaxRetries = 1
+	client.BackoffFn = func(int) time.Duration { return 0 }
+
+	if _, err := client.SendWithTools(context.Background(), "hello", []Tool{RunShellTool}); err == nil || !strings.Contains(err.Error(), "no content") {
+		t.Errorf("expected no content error, got %v", err)
+	}
+}
+
+var (
+	_ ToolAgent = (*OpenAIClient)(nil)
+	_ ToolAgent = (*AnthropicClient)(nil)
+	_ ToolAgent = (*GeminiClient)(nil)
+)
@@ -0,0 +1,99 @@
+package agent
+
+import "context"
+
+// Session roles that token usage is attributed to.
+const (
+	RoleCoding  = "Coding"
+	RoleManager = "Manager"
+	RoleQA      = "QA"
+)
+
+// RoleUsage tracks the tokens spent by one session role.
+type RoleUsage struct {
+	Model string `json:"model,omitempty"` // Model the role last ran on
+	TokenUsage
+}
+
+// IterationUsage tracks the tokens a role spent during one session iteration.
+type IterationUsage struct {
+	Iteration      int    `json:"iteration"`
+	Role           string `json:"role"`
+	PromptTokens   int    `json:"prompt_tokens"`
+	ResponseTokens int    `json:"response_tokens"`
+}
+
+// UsageLabel describes who an agent call is made for.
+type UsageLabel struct {
+	Role      string
+	Model     string
+	Iteration int
+}
+
+type usageLabelKey struct{}
+
+// WithUsageLabel returns a context that attributes the token usage of agent
+// calls made with it to role, model and iteration in State.RoleUsage and
+// State.IterationUsage.
+func WithUsageLabel(ctx context.Context, role, model string, iteration int) context.Context {
+	return context.WithValue(ctx, usageLabelKey{}, UsageLabel{Role: role, Model: model, Iteration: iteration})
+}
+
+// usageLabelFrom returns the label set by WithUsageLabel. Unlabeled calls are
+// attributed to the coding role.
+func usageLabelFrom(ctx context.Context) UsageLabel {
+	label, _ := ctx.Value(usageLabelKey{}).(UsageLabel)
+	if label.Role == "" {
+		label.Role = RoleCoding
+	}
+	return label
+}
+
+// recordUsage adds one call's tokens to the role and iteration breakdowns.
+func (s *State) recordUsage(label UsageLabel, promptTokens, responseTokens int) {
+	if s.RoleUsage == nil {
+		s.RoleUsage = make(map[string]RoleUsage)
+	}
+	usage := s.RoleUsage[label.Role]
+	if label.Model != "" {
+		usage.Model = label.Model
+	}
+	usage.TotalPromptTokens += promptTokens
+	usage.TotalResponseTokens += responseTokens
+	usage.TotalTokens = usage.TotalPromptTokens + usage.TotalResponseTokens
+	s.RoleUsage[label.Role] = usage
+
+	// Consecutive calls by the same role in one iteration share an entry
+	if n := len(s.IterationUsage); n > 0 && s.IterationUsage[n-1].Iteration == label.Iteration && s.IterationUsage[n-1].Role == label.Role {
+		s.IterationUsage[n-1].PromptTokens += promptTokens
+		s.IterationUsage[n-1].ResponseTokens += responseTokens
+		return
+	}
+	s.IterationUsage = append(s.IterationUsage, IterationUsage{
+		Iteration:      label.Iteration,
+		Role:           label.Role,
+		PromptTokens:   promptTokens,
+		ResponseTokens: responseTokens,
+	})
+}
+
+// stateTracker is implemented by clients that embed BaseClient.
+type stateTracker interface {
+	baseClient() *BaseClient
+}
+
+func (c *BaseClient) baseClient() *BaseClient { return c }
+
+// UseStateManager makes a record its token usage in sm, if a tracks state.
+// It reports whether the agent supports state tracking.
+func UseStateManager(a Agent, sm *StateManager) bool {
+	if c, ok := a.(*CachingAgent); ok {
+		return UseStateManager(c.Unwrap(), sm)
+	}
+	t, ok := a.(stateTracker)
+	if !ok {
+		return false
+	}
+	t.baseClient().StateManager = sm
+	return true
+}
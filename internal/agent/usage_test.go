@@ -0,0 +1,50 @@
+package agent
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseClient_RecordsUsageByRole(t *testing.T) {
+	sm := NewStateManager(filepath.Join(t.TempDir(), "state.json"))
+	require.NoError(t, sm.InitializeState(1000, "", "coder-model"))
+
+	client := NewBaseClient("test-project", "", 1000)
+	client.StateManager = sm
+	send := func(ctx context.Context, prompt string) {
+		_, err := client.SendWithRetry(ctx, prompt, func(ctx context.Context, p string) (string, error) {
+			return "12345678", nil // 3 tokens
+		})
+		require.NoError(t, err)
+	}
+
+	send(context.Background(), "1234")                                               // 2 prompt tokens, unlabeled
+	send(WithUsageLabel(context.Background(), RoleCoding, "coder-model", 1), "1234") // same role and iteration
+	send(WithUsageLabel(context.Background(), RoleQA, "qa-model", 1), "12345678")    // 3 prompt tokens
+
+	state, err := sm.Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, RoleUsage{Model: "coder-model", TokenUsage: TokenUsage{TotalPromptTokens: 4, TotalResponseTokens: 6, TotalTokens: 10}}, state.RoleUsage[RoleCoding])
+	assert.Equal(t, RoleUsage{Model: "qa-model", TokenUsage: TokenUsage{TotalPromptTokens: 3, TotalResponseTokens: 3, TotalTokens: 6}}, state.RoleUsage[RoleQA])
+	assert.Equal(t, []IterationUsage{
+		{Iteration: 0, Role: RoleCoding, PromptTokens: 2, ResponseTokens: 3},
+		{Iteration: 1, Role: RoleCoding, PromptTokens: 2, ResponseTokens: 3},
+		{Iteration: 1, Role: RoleQA, PromptTokens: 3, ResponseTokens: 3},
+	}, state.IterationUsage)
+	assert.Equal(t, 16, state.TokenUsage.TotalTokens)
+}
+
+func TestUseStateManager(t *testing.T) {
+	sm := NewStateManager(filepath.Join(t.TempDir(), "state.json"))
+
+	client := NewGeminiClient("key", "gemini-pro", "test-project")
+	assert.True(t, UseStateManager(client, sm))
+	assert.Same(t, sm, client.StateManager)
+
+	assert.False(t, UseStateManager(NewMockAgent(), sm))
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"recac/internal/agent"
+	"recac/internal/docker"
 	"recac/internal/git"
+	"recac/internal/github"
 	"recac/internal/jira"
 	"strings"
 
@@ -43,7 +46,52 @@ var GetJiraClient = func(ctx context.Context) (*jira.Client, error) {
 		return nil, fmt.Errorf("JIRA_API_TOKEN environment variable or jira.api_token config is required")
 	}
 
-	return jira.NewClient(baseURL, username, apiToken), nil
+	client := jira.NewClient(baseURL, username, apiToken)
+	if timeout := viper.GetDuration("jira.timeout"); timeout > 0 {
+		client.HTTPClient.Timeout = timeout
+	}
+	if viper.IsSet("jira.max_retries") {
+		client.MaxRetries = viper.GetInt("jira.max_retries")
+	}
+	return client, nil
+}
+
+// GetGitHubApp returns the GitHub App configured with github.app_id,
+// github.installation_id and github.private_key_path, or nil when github.app_id
+// is unset. The App is shared so its installation token is reused until it
+// nears expiry.
+var GetGitHubApp = func() (*github.App, error) {
+	return github.SharedApp(viper.GetInt64("github.app_id"), viper.GetInt64("github.installation_id"), viper.GetString("github.private_key_path"))
+}
+
+// GetGitHubIssueClient initializes a GitHub issue client for repo ("owner/repo"),
+// authenticated as the GitHub App if one is configured, otherwise with
+// GITHUB_TOKEN or GITHUB_API_KEY
+var GetGitHubIssueClient = func(repo string) (*github.IssueClient, error) {
+	owner, name, err := github.ParseRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	app, err := GetGitHubApp()
+	if err != nil {
+		return nil, err
+	}
+	if app != nil {
+		client := github.NewIssueClient("", owner, name)
+		app.Authorize(client.HTTPClient)
+		return client, nil
+	}
+
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GITHUB_API_KEY")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("GITHUB_TOKEN or GITHUB_API_KEY environment variable is required")
+	}
+
+	return github.NewIssueClient(token, owner, name), nil
 }
 
 // GetAgentClient initializes an Agent client based on provider and configuration
@@ -66,6 +114,8 @@ var GetAgentClient = func(ctx context.Context, provider, model, projectPath, pro
 				apiKey = os.Getenv("OPENAI_API_KEY")
 			case "openrouter":
 				apiKey = os.Getenv("OPENROUTER_API_KEY")
+			case "anthropic":
+				apiKey = os.Getenv("ANTHROPIC_API_KEY")
 			}
 		}
 	}
@@ -85,11 +135,25 @@ var GetAgentClient = func(ctx context.Context, provider, model, projectPath, pro
 				model = "gemini-pro"
 			case "openai":
 				model = "gpt-4"
+			case "anthropic":
+				model = "claude-sonnet-4-5"
 			}
 		}
 	}
 
-	return agent.NewAgent(provider, apiKey, model, projectPath, projectName)
+	ag, err := agent.NewAgent(provider, apiKey, model, projectPath, projectName)
+	if err != nil {
+		return nil, err
+	}
+	return WithResponseCache(ag, provider, model), nil
+}
+
+// WithResponseCache wraps ag in an agent.CachingAgent when cache_responses
+// (--cache-responses, RECAC_CACHE_RESPONSES) is set. Each provider and model
+// gets its own directory under response_cache_dir (default
+// ~/.recac/response-cache).
+func WithResponseCache(ag agent.Agent, provider, model string) agent.Agent {
+	return agent.WithResponseCache(ag, viper.GetBool("cache_responses"), viper.GetString("response_cache_dir"), provider, model)
 }
 
 // SetupWorkspace handles cloning, auth fallback, and Epic branching strategy
@@ -105,10 +169,16 @@ var SetupWorkspace = func(ctx context.Context, gitClient git.IClient, repoURL, w
 		_ = gitClient.ConfigAddGlobal("safe.directory", workspace)
 	}
 
-	// Handle GitHub Auth if token provided
+	// Handle GitHub Auth: an App installation token, or the token provided
 	githubKey := os.Getenv("GITHUB_API_KEY")
-	if githubKey != "" && strings.Contains(repoURL, "github.com") && !strings.Contains(repoURL, "@") {
-		authRepoURL = strings.Replace(repoURL, "https://github.com/", fmt.Sprintf("https://%s@github.com/", githubKey), 1)
+	if app, err := GetGitHubApp(); err != nil {
+		return repoURL, err
+	} else if app != nil && strings.Contains(repoURL, "github.com") && !strings.Contains(repoURL, "@") {
+		if authRepoURL, err = app.CloneURL(ctx, repoURL); err != nil {
+			return repoURL, fmt.Errorf("failed to authenticate as GitHub App: %w", err)
+		}
+	} else if githubKey != "" && strings.Contains(repoURL, "github.com") && !strings.Contains(repoURL, "@") {
+		authRepoURL = github.InsertToken(repoURL, githubKey)
 	}
 
 	// 2. Clone Repository (if not already present)
@@ -161,11 +231,9 @@ var SetupWorkspace = func(ctx context.Context, gitClient git.IClient, repoURL, w
 
 	// Determine Branch Name
 	uniqueNames := viper.GetBool("git.unique_branch_names")
-	var branchName string
+	branchName := git.BranchName(ticketID)
 	if uniqueNames {
-		branchName = fmt.Sprintf("agent/%s-%s", ticketID, timestamp)
-	} else {
-		branchName = fmt.Sprintf("agent/%s", ticketID)
+		branchName = fmt.Sprintf("%s-%s", branchName, timestamp)
 	}
 
 	// Create and Checkout Feature Branch
@@ -205,3 +273,103 @@ var SetupWorkspace = func(ctx context.Context, gitClient git.IClient, repoURL, w
 
 	return repoURL, nil
 }
+
+// ComponentDir returns the workspace subdirectory an additional repository is
+// cloned into: the last element of its URL, without a .git suffix.
+func ComponentDir(repoURL string) string {
+	name := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(repoURL), "/"), ".git")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// SetupComponentRepos clones each of repoURLs into its ComponentDir under
+// workspace and prepares its feature branch as SetupWorkspace does. The
+// subdirectories are excluded from the workspace's own repository so they are
+// never committed to it. It returns the subdirectories in order.
+func SetupComponentRepos(ctx context.Context, gitClient git.IClient, repoURLs []string, workspace, ticketID, timestamp string) ([]string, error) {
+	var dirs []string
+	seen := make(map[string]string)
+	for _, repoURL := range repoURLs {
+		dir := ComponentDir(repoURL)
+		if dir == "" || dir == "." || dir == ".." {
+			return dirs, fmt.Errorf("cannot derive a directory name from repository URL %q", repoURL)
+		}
+		if other, ok := seen[dir]; ok {
+			return dirs, fmt.Errorf("repositories %s and %s would both be cloned into %s", other, repoURL, dir)
+		}
+		seen[dir] = repoURL
+
+		if _, err := SetupWorkspace(ctx, gitClient, repoURL, filepath.Join(workspace, dir), ticketID, "", timestamp); err != nil {
+			return dirs, fmt.Errorf("failed to set up %s: %w", repoURL, err)
+		}
+		if err := excludeFromRepo(workspace, dir); err != nil {
+			fmt.Fprintf(os.Stderr, "[%s] Warning: Failed to exclude %s from the workspace repository: %v\n", ticketID, dir, err)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+// excludeFromRepo adds dir to the info/exclude file of the repository in
+// workspace, if workspace is one.
+func excludeFromRepo(workspace, dir string) error {
+	gitDir := filepath.Join(workspace, ".git")
+	if info, err := os.Stat(gitDir); err != nil || !info.IsDir() {
+		return nil
+	}
+	excludePath := filepath.Join(gitDir, "info", "exclude")
+	pattern := "/" + dir + "/"
+
+	existing, err := os.ReadFile(excludePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(existing), "\n") {
+		if strings.TrimSpace(line) == pattern {
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(excludePath), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(excludePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		pattern = "\n" + pattern
+	}
+	_, err = f.WriteString(pattern + "\n")
+	return err
+}
+
+// GetStringList reads a list setting that may be configured either as a YAML list
+// or as a comma-separated string (e.g. from an environment variable).
+func GetStringList(key string) []string {
+	raw, ok := viper.Get(key).(string)
+	if !ok {
+		return viper.GetStringSlice(key)
+	}
+	var out []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// GetRegistryAuth reads the private registry credentials used to pull the
+// agent image (registry.* in config, or RECAC_REGISTRY_* in the environment).
+func GetRegistryAuth() docker.RegistryAuth {
+	return docker.RegistryAuth{
+		URL:        viper.GetString("registry.url"),
+		Username:   viper.GetString("registry.username"),
+		Password:   viper.GetString("registry.password"),
+		ConfigPath: viper.GetString("registry.config"),
+	}
+}
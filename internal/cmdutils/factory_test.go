@@ -2,12 +2,21 @@ package cmdutils
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"os"
+	"path/filepath"
+	"recac/internal/agent"
 	"recac/internal/git"
+	"recac/internal/jira"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetJiraClient(t *testing.T) {
@@ -38,6 +47,17 @@ func TestGetJiraClient(t *testing.T) {
 		client, err := GetJiraClient(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, client)
+		assert.Equal(t, jira.DefaultMaxRetries, client.MaxRetries)
+	})
+
+	t.Run("Retry Config", func(t *testing.T) {
+		viper.Set("jira.timeout", "30s")
+		viper.Set("jira.max_retries", 0)
+
+		client, err := GetJiraClient(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 30*time.Second, client.HTTPClient.Timeout)
+		assert.Equal(t, 0, client.MaxRetries)
 	})
 
 	t.Run("Environment Variables", func(t *testing.T) {
@@ -270,6 +290,18 @@ func (m *MockGitClient) CreatePR(directory, title, body, base string) (string, e
 	return "", nil
 }
 
+func (m *MockGitClient) AddWorktree(directory, path, branch string) error {
+	return nil
+}
+
+func (m *MockGitClient) RemoveWorktree(directory, path string) error {
+	return nil
+}
+
+func (m *MockGitClient) DefaultBranch(directory string) (string, error) {
+	return "main", nil
+}
+
 func TestSetupWorkspace(t *testing.T) {
 	t.Run("Empty Repo URL", func(t *testing.T) {
 		mockGitClient := &MockGitClient{}
@@ -373,6 +405,24 @@ func TestSetupWorkspace(t *testing.T) {
 		assert.Equal(t, "agent/TEST-1", newBranch)
 	})
 
+	t.Run("Creates feature branch from template", func(t *testing.T) {
+		viper.Set("git.branch_template", "feature/{ticket}")
+		defer viper.Set("git.branch_template", "")
+
+		newBranch := ""
+		mockGitClient := &MockGitClient{
+			repoExists:         true,
+			remoteBranchExists: false,
+			checkoutNewBranchFn: func(directory, branch string) error {
+				newBranch = branch
+				return nil
+			},
+		}
+		_, err := SetupWorkspace(context.Background(), mockGitClient, "https://github.com/example/repo", "/tmp/recac-test", "RECAC-123", "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "feature/RECAC-123", newBranch)
+	})
+
 	t.Run("Checks out existing stable feature branch", func(t *testing.T) {
 		checkedOut := ""
 		mockGitClient := &MockGitClient{
@@ -388,3 +438,106 @@ func TestSetupWorkspace(t *testing.T) {
 		assert.Equal(t, "agent/TEST-1", checkedOut)
 	})
 }
+
+func TestGetStringList(t *testing.T) {
+	defer viper.Reset()
+
+	viper.Set("test.list", []string{"Epic", "Sub-task"})
+	assert.Equal(t, []string{"Epic", "Sub-task"}, GetStringList("test.list"))
+
+	// Comma-separated strings (e.g. env vars) keep spaces inside values
+	viper.Set("test.list", "To Do, In Progress,")
+	assert.Equal(t, []string{"To Do", "In Progress"}, GetStringList("test.list"))
+
+	viper.Set("test.list", "")
+	assert.Empty(t, GetStringList("test.list"))
+}
+
+func TestWithResponseCache(t *testing.T) {
+	defer viper.Reset()
+	inner := agent.NewMockAgent()
+
+	assert.Same(t, inner, WithResponseCache(inner, "openrouter", "mistralai/devstral"))
+
+	dir := t.TempDir()
+	viper.Set("cache_responses", true)
+	viper.Set("response_cache_dir", dir)
+	cached, ok := WithResponseCache(inner, "openrouter", "mistralai/devstral").(*agent.CachingAgent)
+	if assert.True(t, ok) {
+		_, err := cached.Send(context.Background(), "prompt")
+		assert.NoError(t, err)
+		entries, err := os.ReadDir(filepath.Join(dir, "openrouter", "mistralai_devstral"))
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+	}
+}
+
+func TestGetGitHubApp(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	app, err := GetGitHubApp()
+	assert.NoError(t, err)
+	assert.Nil(t, app, "no app without github.app_id")
+
+	viper.Set("github.app_id", 42)
+	viper.Set("github.installation_id", 99)
+	_, err = GetGitHubApp()
+	assert.Error(t, err, "private key path is required")
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+	assert.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600))
+	viper.Set("github.private_key_path", keyPath)
+
+	app, err = GetGitHubApp()
+	assert.NoError(t, err)
+	if assert.NotNil(t, app) {
+		assert.Equal(t, int64(42), app.AppID)
+		assert.Equal(t, int64(99), app.InstallationID)
+	}
+	again, err := GetGitHubApp()
+	assert.NoError(t, err)
+	assert.Same(t, app, again, "the app and its token are shared")
+}
+
+func TestComponentDir(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/example/shared-lib":      "shared-lib",
+		"https://github.com/example/shared-lib.git/": "shared-lib",
+		"git@github.com:example/shared-lib.git":      "shared-lib",
+		"git@host:lib":                               "lib",
+	}
+	for url, want := range tests {
+		assert.Equal(t, want, ComponentDir(url), url)
+	}
+}
+
+func TestSetupComponentRepos(t *testing.T) {
+	workspace := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workspace, ".git", "info"), 0755))
+
+	var cloned []string
+	mockGitClient := &MockGitClient{
+		cloneFn: func(ctx context.Context, repoURL, directory string) error {
+			cloned = append(cloned, directory)
+			return nil
+		},
+	}
+	urls := []string{"https://github.com/example/shared-lib", "https://github.com/example/proto.git"}
+	dirs, err := SetupComponentRepos(context.Background(), mockGitClient, urls, workspace, "TEST-1", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shared-lib", "proto"}, dirs)
+	assert.Equal(t, []string{filepath.Join(workspace, "shared-lib"), filepath.Join(workspace, "proto")}, cloned)
+
+	// Running again does not exclude the directories twice
+	_, err = SetupComponentRepos(context.Background(), mockGitClient, urls, workspace, "TEST-1", "")
+	require.NoError(t, err)
+	exclude, err := os.ReadFile(filepath.Join(workspace, ".git", "info", "exclude"))
+	require.NoError(t, err)
+	assert.Equal(t, "/shared-lib/\n/proto/\n", string(exclude))
+
+	_, err = SetupComponentRepos(context.Background(), mockGitClient, []string{"https://a.com/x/lib", "https://b.com/y/lib"}, workspace, "TEST-1", "")
+	assert.ErrorContains(t, err, "would both be cloned into lib")
+}
@@ -40,14 +40,65 @@ func Load(cfgFile string) {
 	viper.SetDefault("model", "gemini-pro")
 	viper.SetDefault("max_iterations", 20)
 	viper.SetDefault("manager_frequency", 5)
+	viper.SetDefault("progress_interval", 0)
+	viper.SetDefault("max_workspace_size", "")
+	viper.SetDefault("max_response_size", "512KB")
+	viper.SetDefault("session_log.max_size", "100MB")
+	viper.SetDefault("session_log.max_files", 5)
+	viper.SetDefault("checkpoint_interval", "0s")
+	viper.SetDefault("safe_mode", false)
+	viper.SetDefault("host_mode", false)
+	viper.SetDefault("init_script", "init.sh")
+	viper.SetDefault("init_script_env", []string{})
+	viper.SetDefault("init_script_fail_on_error", true)
+	viper.SetDefault("init_script_timeout", "10m")
+	viper.SetDefault("max_qa_rejections", 3)
+	viper.SetDefault("completion.min_pass_ratio", 1.0)
+	viper.SetDefault("completion.required_features", []string{})
+	viper.SetDefault("completion.test_command", "")
+	viper.SetDefault("completion.test_timeout", "10m")
+	viper.SetDefault("isolate_worktrees", false)
+	viper.SetDefault("conflict_strategy", "reset")
+	viper.SetDefault("auto_merge_required_checks", []string{})
+	viper.SetDefault("auto_merge_checks_timeout", "30m")
+	viper.SetDefault("container_entrypoint", []string{})
+	viper.SetDefault("container_command", []string{})
+	viper.SetDefault("command_fences", []string{"bash", "sh", "shell", "console"})
+	viper.SetDefault("network", "bridge")
 	viper.SetDefault("timeout", 300)
 	viper.SetDefault("docker_timeout", 600)
 	viper.SetDefault("bash_timeout", 600)
 	viper.SetDefault("agent_timeout", 300)
 	viper.SetDefault("metrics_port", 2112)
 	viper.SetDefault("verbose", false)
+	viper.SetDefault("ui.markdown_style", "dark")
+	viper.SetDefault("ui.markdown_max_width", 0)
+	viper.SetDefault("event_log", false)
+	viper.SetDefault("require_human_signoff", false)
+	viper.SetDefault("provider_health", false)
+	viper.SetDefault("agent_max_retries", 5)
+	viper.SetDefault("noop_limit", 3)
+	viper.SetDefault("noop_detection", "changes")
+	viper.SetDefault("repetition_window", 5)
+	viper.SetDefault("repetition_threshold", 3)
+	viper.SetDefault("system_prefix", "")
+	viper.SetDefault("tool_calling", true)
+	viper.SetDefault("jira.timeout", "10s")
+	viper.SetDefault("jira.max_retries", 3)
+	viper.SetDefault("jira.attachments", []string{})
 	viper.SetDefault("git_user_email", "recac-agent@example.com")
 	viper.SetDefault("git_user_name", "RECAC Agent")
+	viper.SetDefault("git.branch_template", "agent/{ticket}")
+	viper.SetDefault("git.commit_template", "feat: implemented features for {project}")
+	viper.SetDefault("github.close_issues", false)
+	viper.SetDefault("github.app_id", 0)
+	viper.SetDefault("github.installation_id", 0)
+	viper.SetDefault("github.private_key_path", "")
+	viper.SetDefault("registry.url", "")
+	viper.SetDefault("registry.username", "")
+	viper.SetDefault("registry.password", "")
+	viper.SetDefault("registry.config", "")
+	viper.SetDefault("registry.pull_secret", "")
 
 	// Notification Defaults
 	slackEnabled := false
@@ -55,12 +106,18 @@ func Load(cfgFile string) {
 		slackEnabled = true
 	}
 	viper.SetDefault("notifications.slack.enabled", slackEnabled)
+	viper.SetDefault("notifications.cooldown", "5m")
 	viper.SetDefault("notifications.slack.channel", "#general")
+	viper.SetDefault("notifications.slack.approvers", []string{}) // Slack user IDs allowed to confirm safe mode commands
 	viper.SetDefault("notifications.slack.events.on_start", true)
 	viper.SetDefault("notifications.slack.events.on_success", true)
 	viper.SetDefault("notifications.slack.events.on_failure", true)
 	viper.SetDefault("notifications.slack.events.on_user_interaction", true)
 	viper.SetDefault("notifications.slack.events.on_project_complete", true)
+	viper.SetDefault("notifications.slack.events.on_progress", true)
+	viper.SetDefault("notifications.slack.events.on_digest", true)
+	viper.SetDefault("notifications.webhook.enabled", os.Getenv("NOTIFY_WEBHOOK_URL") != "")
+	viper.SetDefault("notifications.pagerduty.enabled", os.Getenv("PAGERDUTY_ROUTING_KEY") != "")
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigFile is the repository-relative path of the optional
+// per-project config, merged over the global config.
+var ProjectConfigFile = filepath.Join(".recac", "config.yaml")
+
+// projectConfigIgnored are the top-level keys a project config may not set:
+// they run commands on the host, hold credentials or are safety controls set
+// by the operator, and a repository (or the agent working in it) must not be
+// able to change them.
+var projectConfigIgnored = map[string]bool{
+	"auto_merge_checks_timeout":  true,
+	"auto_merge_required_checks": true,
+	"command_policy":             true,
+	"github":                     true,
+	"hooks":                      true,
+	"host_mode":                  true,
+	"jira":                       true,
+	"notifications":              true,
+	"orchestrator":               true,
+	"registry":                   true,
+	"require_human_signoff":      true,
+	"safe_mode":                  true,
+}
+
+// boundFlags are the command-line flags bound with BindPFlag, by config key.
+var (
+	boundFlagsMu sync.Mutex
+	boundFlags   = map[string]*pflag.Flag{}
+)
+
+// BindPFlag binds flag to key like viper.BindPFlag, and remembers the binding
+// so a flag given on the command line also wins over project configs.
+func BindPFlag(key string, flag *pflag.Flag) error {
+	if flag != nil {
+		boundFlagsMu.Lock()
+		boundFlags[key] = flag
+		boundFlagsMu.Unlock()
+	}
+	return viper.BindPFlag(key, flag)
+}
+
+// LoadProjectConfig reads dir's .recac/config.yaml without applying it,
+// dropping the keys a project may not set with a warning. It returns the
+// settings and the path of the file, or nil and "" if dir has none.
+func LoadProjectConfig(dir string) (map[string]interface{}, string, error) {
+	path := filepath.Join(dir, ProjectConfigFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to read project config: %w", err)
+	}
+
+	settings := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, "", fmt.Errorf("invalid project config %s: %w", path, err)
+	}
+
+	var ignored []string
+	for key := range settings {
+		if projectConfigIgnored[strings.ToLower(key)] {
+			ignored = append(ignored, key)
+			delete(settings, key)
+		}
+	}
+	if len(ignored) > 0 {
+		sort.Strings(ignored)
+		fmt.Fprintf(os.Stderr, "Warning: Ignoring %s in %s; set them in the global config\n", strings.Join(ignored, ", "), path)
+	}
+	return settings, path, nil
+}
+
+// ProjectSettings returns the configuration for a session in dir: dir's
+// .recac/config.yaml merged over the global configuration, so each repository
+// can pin its own provider, model, thresholds and guardrails. Flags bound with
+// BindPFlag and RECAC_* environment variables still take precedence.
+//
+// The global configuration is left untouched, so one project's settings never
+// reach another session in the same process. Without a project config (or if
+// it cannot be read) it returns the global instance, and the path of the
+// merged file otherwise.
+func ProjectSettings(dir string) (*viper.Viper, string, error) {
+	settings, path, err := LoadProjectConfig(dir)
+	if err != nil || settings == nil {
+		return viper.GetViper(), "", err
+	}
+
+	// The global values become the defaults the project config is merged over
+	v := viper.New()
+	for _, key := range viper.AllKeys() {
+		v.SetDefault(key, viper.Get(key))
+	}
+	v.SetEnvPrefix("RECAC")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	boundFlagsMu.Lock()
+	for key, flag := range boundFlags {
+		if flag.Changed {
+			_ = v.BindPFlag(key, flag)
+		}
+	}
+	boundFlagsMu.Unlock()
+
+	if err := v.MergeConfigMap(settings); err != nil {
+		return viper.GetViper(), "", fmt.Errorf("failed to merge project config %s: %w", path, err)
+	}
+	return v, path, nil
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/spf13/viper"
@@ -66,6 +67,42 @@ func ValidateConfig() error {
 		}
 	}
 
+	// Validate command timeout (if set): a duration ("10m") or seconds
+	if viper.IsSet("command_timeout") {
+		raw := viper.GetString("command_timeout")
+		var timeout time.Duration
+		if d, err := time.ParseDuration(raw); err == nil {
+			timeout = d
+		} else if s, err := strconv.Atoi(raw); err == nil {
+			timeout = time.Duration(s) * time.Second
+		}
+		if timeout <= 0 {
+			errors = append(errors, fmt.Sprintf("command_timeout must be a positive duration, got: %q", raw))
+		}
+	}
+
+	// Validate init script timeout (if set, must be a positive duration)
+	if viper.IsSet("init_script_timeout") {
+		raw := viper.GetString("init_script_timeout")
+		if d, err := time.ParseDuration(raw); err != nil || d <= 0 {
+			errors = append(errors, fmt.Sprintf("init_script_timeout must be a positive duration, got: %q", raw))
+		}
+	}
+
+	// Validate the completion policy: a pass ratio in (0, 1] and a positive test timeout
+	if viper.IsSet("completion.min_pass_ratio") {
+		ratio := viper.GetFloat64("completion.min_pass_ratio")
+		if ratio <= 0 || ratio > 1 {
+			errors = append(errors, fmt.Sprintf("completion.min_pass_ratio must be between 0 (exclusive) and 1, got: %v", ratio))
+		}
+	}
+	if viper.IsSet("completion.test_timeout") {
+		raw := viper.GetString("completion.test_timeout")
+		if d, err := time.ParseDuration(raw); err != nil || d <= 0 {
+			errors = append(errors, fmt.Sprintf("completion.test_timeout must be a positive duration, got: %q", raw))
+		}
+	}
+
 	// Validate max_iterations (if set, must be positive)
 	if viper.IsSet("max_iterations") {
 		maxIter := viper.GetInt("max_iterations")
@@ -106,6 +106,38 @@ func TestValidateConfig(t *testing.T) {
 			wantError: true,
 			errMsg:    "bash_timeout must be positive",
 		},
+		{
+			name: "Invalid Command Timeout",
+			setup: func() {
+				viper.Set("command_timeout", "soon")
+			},
+			wantError: true,
+			errMsg:    "command_timeout must be a positive duration",
+		},
+		{
+			name: "Invalid Init Script Timeout",
+			setup: func() {
+				viper.Set("init_script_timeout", "0s")
+			},
+			wantError: true,
+			errMsg:    "init_script_timeout must be a positive duration",
+		},
+		{
+			name: "Invalid Completion Pass Ratio",
+			setup: func() {
+				viper.Set("completion.min_pass_ratio", 1.5)
+			},
+			wantError: true,
+			errMsg:    "completion.min_pass_ratio must be between 0 (exclusive) and 1",
+		},
+		{
+			name: "Invalid Completion Test Timeout",
+			setup: func() {
+				viper.Set("completion.test_timeout", "soon")
+			},
+			wantError: true,
+			errMsg:    "completion.test_timeout must be a positive duration",
+		},
 		{
 			name: "Invalid Max Agents",
 			setup: func() {
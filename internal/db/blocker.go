@@ -0,0 +1,115 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// BlockerSignal is the signal that pauses a session until a human resolves it.
+// Its value is a JSON-encoded Blocker; plain text from older agents is still accepted.
+const BlockerSignal = "BLOCKER"
+
+// Blocker types
+const (
+	BlockerCredentials    = "credentials"     // A secret, token or login the agent does not have
+	BlockerUIVerification = "ui_verification" // A human must check the UI and report the result
+	BlockerDependency     = "dependency"      // A package, service or upstream ticket is missing or broken
+	BlockerAmbiguousSpec  = "ambiguous_spec"  // The specification is unclear or contradictory
+	BlockerOther          = "other"
+)
+
+// BlockerTypes lists the blocker types in the order they are documented.
+var BlockerTypes = []string{BlockerCredentials, BlockerUIVerification, BlockerDependency, BlockerAmbiguousSpec, BlockerOther}
+
+const clearBlockerHint = "then run `recac unblock <session> --message \"<what you did>\"`."
+
+var blockerResolutions = map[string]string{
+	BlockerCredentials:    "Provide the missing credentials to the agent (environment variables, config or mounted files), " + clearBlockerHint,
+	BlockerUIVerification: "Check each pending request in ui_verification.json and record the result with `agent-bridge verify <feature> pass|fail`. The blocker clears once none are pending.",
+	BlockerDependency:     "Make the dependency available (install it in the agent image, or finish the upstream ticket or service), " + clearBlockerHint,
+	BlockerAmbiguousSpec:  "Clarify the specification (update the spec or ticket with the missing decision), " + clearBlockerHint,
+	BlockerOther:          "Resolve the issue described, " + clearBlockerHint,
+}
+
+// Blocker is a categorized request for human intervention.
+type Blocker struct {
+	Type            string `json:"type"`
+	Message         string `json:"message"`
+	SuggestedAction string `json:"suggested_action,omitempty"`
+	RelatedFeature  string `json:"related_feature,omitempty"`
+}
+
+// ValidBlockerType reports whether t is one of BlockerTypes.
+func ValidBlockerType(t string) bool {
+	_, ok := blockerResolutions[t]
+	return ok
+}
+
+// Resolution returns the instructions for resolving a blocker of b's type.
+func (b Blocker) Resolution() string {
+	if r, ok := blockerResolutions[b.Type]; ok {
+		return r
+	}
+	return blockerResolutions[BlockerOther]
+}
+
+// String renders the blocker with its resolution instructions.
+func (b Blocker) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "[%s] %s", b.Type, b.Message)
+	if b.RelatedFeature != "" {
+		fmt.Fprintf(&sb, "\nFeature: %s", b.RelatedFeature)
+	}
+	if b.SuggestedAction != "" {
+		fmt.Fprintf(&sb, "\nSuggested action: %s", b.SuggestedAction)
+	}
+	fmt.Fprintf(&sb, "\nTo resolve: %s", b.Resolution())
+	return sb.String()
+}
+
+// ParseBlocker decodes a BLOCKER signal value. Free-text values are treated as
+// blockers of type other.
+func ParseBlocker(value string) Blocker {
+	var b Blocker
+	if err := json.Unmarshal([]byte(value), &b); err == nil && b.Message != "" {
+		if !ValidBlockerType(b.Type) {
+			b.Type = BlockerOther
+		}
+		return b
+	}
+
+	b = Blocker{Type: BlockerOther, Message: strings.TrimSpace(value)}
+	if strings.Contains(value, "UI Verification Required") {
+		b.Type = BlockerUIVerification
+	}
+	return b
+}
+
+// SetBlocker records b as the project's blocker, replacing any previous one.
+func SetBlocker(store Store, projectID string, b Blocker) error {
+	if b.Type == "" {
+		b.Type = BlockerOther
+	}
+	if !ValidBlockerType(b.Type) {
+		return fmt.Errorf("unknown blocker type %q (expected one of %s)", b.Type, strings.Join(BlockerTypes, ", "))
+	}
+	if strings.TrimSpace(b.Message) == "" {
+		return fmt.Errorf("blocker message is required")
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	return store.SetSignal(projectID, BlockerSignal, string(data))
+}
+
+// GetBlocker returns the project's blocker, or nil if it is not blocked.
+func GetBlocker(store Store, projectID string) (*Blocker, error) {
+	value, err := store.GetSignal(projectID, BlockerSignal)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	b := ParseBlocker(value)
+	return &b, nil
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseBlocker(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Blocker
+	}{
+		{`{"type":"dependency","message":"Postgres is down","suggested_action":"Restart it"}`, Blocker{Type: BlockerDependency, Message: "Postgres is down", SuggestedAction: "Restart it"}},
+		{`{"type":"weather","message":"Raining"}`, Blocker{Type: BlockerOther, Message: "Raining"}},
+		{"I am stuck\n", Blocker{Type: BlockerOther, Message: "I am stuck"}},
+		{"UI Verification Required for F-1", Blocker{Type: BlockerUIVerification, Message: "UI Verification Required for F-1"}},
+	}
+	for _, tt := range tests {
+		if got := ParseBlocker(tt.value); got != tt.want {
+			t.Errorf("ParseBlocker(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestBlocker_String(t *testing.T) {
+	b := Blocker{Type: BlockerAmbiguousSpec, Message: "Should prices include tax?", SuggestedAction: "Decide on tax handling", RelatedFeature: "F-3"}
+	got := b.String()
+	for _, want := range []string{"[ambiguous_spec] Should prices include tax?", "Feature: F-3", "Suggested action: Decide on tax handling", "To resolve: Clarify the specification"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in %q", want, got)
+		}
+	}
+}
+
+func TestSetGetBlocker(t *testing.T) {
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if b, err := GetBlocker(store, "p"); err != nil || b != nil {
+		t.Fatalf("Expected no blocker, got %v, %v", b, err)
+	}
+
+	want := Blocker{Type: BlockerCredentials, Message: "No API key", RelatedFeature: "F-1"}
+	if err := SetBlocker(store, "p", want); err != nil {
+		t.Fatalf("SetBlocker failed: %v", err)
+	}
+	if b, err := GetBlocker(store, "p"); err != nil || b == nil || *b != want {
+		t.Errorf("Expected %+v, got %v, %v", want, b, err)
+	}
+
+	if err := SetBlocker(store, "p", Blocker{Type: "weather", Message: "Raining"}); err == nil {
+		t.Error("Expected an error for an unknown type")
+	}
+	if err := SetBlocker(store, "p", Blocker{Type: BlockerDependency}); err == nil {
+		t.Error("Expected an error for an empty message")
+	}
+}
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HistoryQuery selects a page of a project's observations. The zero value
+// selects all of them, newest first.
+type HistoryQuery struct {
+	Limit     int       // Maximum number of observations returned (0 = no limit)
+	Offset    int       // Number of matching observations to skip
+	AfterID   int64     // Cursor: only observations that come after this ID in the chosen order (0 = from the start)
+	Since     time.Time // Only observations created at or after this time (zero = no lower bound)
+	Until     time.Time // Only observations created before this time (zero = no upper bound)
+	Ascending bool      // Oldest first instead of newest first
+}
+
+// historySQL builds the SELECT for q, with PostgreSQL ($n) or SQLite (?)
+// placeholders.
+func historySQL(projectID string, q HistoryQuery, postgres bool) (string, []interface{}) {
+	args := []interface{}{projectID}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		if postgres {
+			return fmt.Sprintf("$%d", len(args))
+		}
+		return "?"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SELECT id, agent_id, content, created_at FROM observations WHERE project_id = ")
+	if postgres {
+		sb.WriteString("$1")
+	} else {
+		sb.WriteString("?")
+	}
+	if !q.Since.IsZero() {
+		sb.WriteString(" AND created_at >= " + arg(q.Since))
+	}
+	if !q.Until.IsZero() {
+		sb.WriteString(" AND created_at < " + arg(q.Until))
+	}
+
+	order, cursor := "DESC", "<"
+	if q.Ascending {
+		order, cursor = "ASC", ">"
+	}
+	if q.AfterID > 0 {
+		fmt.Fprintf(&sb, " AND id %s %s", cursor, arg(q.AfterID))
+	}
+	fmt.Fprintf(&sb, " ORDER BY created_at %s, id %s", order, order)
+
+	if q.Limit > 0 {
+		sb.WriteString(" LIMIT " + arg(q.Limit))
+	} else if q.Offset > 0 && !postgres {
+		sb.WriteString(" LIMIT -1") // SQLite only accepts OFFSET after a LIMIT
+	}
+	if q.Offset > 0 {
+		sb.WriteString(" OFFSET " + arg(q.Offset))
+	}
+	return sb.String(), args
+}
+
+// scanObservations reads and closes rows of id, agent_id, content, created_at.
+func scanObservations(rows *sql.Rows) ([]Observation, error) {
+	defer rows.Close()
+
+	var results []Observation
+	for rows.Next() {
+		var obs Observation
+		if err := rows.Scan(&obs.ID, &obs.AgentID, &obs.Content, &obs.CreatedAt); err != nil {
+			return nil, err
+		}
+		results = append(results, obs)
+	}
+	return results, rows.Err()
+}
+
+// historyPageSize is how many observations StreamHistory loads at a time.
+var historyPageSize = 500
+
+// StreamHistory calls fn for each observation matching q, in order, loading
+// them a page at a time so long histories are never held in memory at once.
+// q.Limit caps the total number of observations; q.Offset and q.AfterID
+// apply to the first page. It stops at the first error fn returns.
+func StreamHistory(store Store, projectID string, q HistoryQuery, fn func(Observation) error) error {
+	remaining := q.Limit
+	for {
+		page := q
+		page.Limit = historyPageSize
+		if remaining > 0 && remaining < page.Limit {
+			page.Limit = remaining
+		}
+
+		observations, err := store.QueryHistoryPage(projectID, page)
+		if err != nil {
+			return err
+		}
+		for _, obs := range observations {
+			if err := fn(obs); err != nil {
+				return err
+			}
+		}
+
+		if remaining > 0 {
+			remaining -= len(observations)
+			if remaining <= 0 {
+				return nil
+			}
+		}
+		if len(observations) < page.Limit {
+			return nil
+		}
+		q.AfterID = observations[len(observations)-1].ID
+		q.Offset = 0
+	}
+}
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newHistoryStore returns a store with observations "obs-1".."obs-n" for project p,
+// created a minute apart starting at base.
+func newHistoryStore(t *testing.T, n int, base time.Time) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	for i := 1; i <= n; i++ {
+		_, err := store.db.Exec(`INSERT INTO observations (project_id, agent_id, content, created_at) VALUES (?, ?, ?, ?)`,
+			"p", "agent", fmt.Sprintf("obs-%d", i), base.Add(time.Duration(i)*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.SaveObservation("other", "agent", "not mine"); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func contents(observations []Observation) []string {
+	var out []string
+	for _, obs := range observations {
+		out = append(out, obs.Content)
+	}
+	return out
+}
+
+func TestSQLiteStore_QueryHistoryPage(t *testing.T) {
+	base := time.Now().Add(-time.Hour)
+	store := newHistoryStore(t, 5, base)
+
+	tests := []struct {
+		name string
+		q    HistoryQuery
+		want []string
+	}{
+		{"all, newest first", HistoryQuery{}, []string{"obs-5", "obs-4", "obs-3", "obs-2", "obs-1"}},
+		{"ascending", HistoryQuery{Ascending: true, Limit: 2}, []string{"obs-1", "obs-2"}},
+		{"offset", HistoryQuery{Limit: 2, Offset: 1}, []string{"obs-4", "obs-3"}},
+		{"offset without limit", HistoryQuery{Offset: 3}, []string{"obs-2", "obs-1"}},
+		{"time range", HistoryQuery{Since: base.Add(2 * time.Minute), Until: base.Add(4 * time.Minute), Ascending: true}, []string{"obs-2", "obs-3"}},
+		{"time range in another zone", HistoryQuery{Since: base.Add(4 * time.Minute).UTC()}, []string{"obs-5", "obs-4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.QueryHistoryPage("p", tt.q)
+			if err != nil {
+				t.Fatalf("QueryHistoryPage failed: %v", err)
+			}
+			if !reflect.DeepEqual(contents(got), tt.want) {
+				t.Errorf("got %v, want %v", contents(got), tt.want)
+			}
+		})
+	}
+
+	// Cursor pagination in both directions
+	first, _ := store.QueryHistoryPage("p", HistoryQuery{Limit: 2})
+	next, _ := store.QueryHistoryPage("p", HistoryQuery{Limit: 2, AfterID: first[1].ID})
+	if want := []string{"obs-3", "obs-2"}; !reflect.DeepEqual(contents(next), want) {
+		t.Errorf("descending cursor: got %v, want %v", contents(next), want)
+	}
+	next, _ = store.QueryHistoryPage("p", HistoryQuery{Limit: 2, AfterID: first[1].ID, Ascending: true})
+	if want := []string{"obs-5"}; !reflect.DeepEqual(contents(next), want) {
+		t.Errorf("ascending cursor: got %v, want %v", contents(next), want)
+	}
+}
+
+func TestStreamHistory(t *testing.T) {
+	store := newHistoryStore(t, 7, time.Now().Add(-time.Hour))
+	defer func(size int) { historyPageSize = size }(historyPageSize)
+	historyPageSize = 3
+
+	var got []string
+	err := StreamHistory(store, "p", HistoryQuery{Ascending: true}, func(obs Observation) error {
+		got = append(got, obs.Content)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamHistory failed: %v", err)
+	}
+	if want := []string{"obs-1", "obs-2", "obs-3", "obs-4", "obs-5", "obs-6", "obs-7"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Limit caps the total across pages
+	got = nil
+	StreamHistory(store, "p", HistoryQuery{Limit: 4, Offset: 1}, func(obs Observation) error {
+		got = append(got, obs.Content)
+		return nil
+	})
+	if want := []string{"obs-6", "obs-5", "obs-4", "obs-3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// fn's error stops the stream
+	stop := errors.New("stop")
+	calls := 0
+	err = StreamHistory(store, "p", HistoryQuery{}, func(obs Observation) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("expected the stream to stop after the first error, got %v after %d calls", err, calls)
+	}
+}
+
+func TestHistorySQL_Postgres(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	query, args := historySQL("p", HistoryQuery{Since: since, AfterID: 9, Offset: 4}, true)
+	want := "SELECT id, agent_id, content, created_at FROM observations WHERE project_id = $1 AND created_at >= $2 AND id < $3 ORDER BY created_at DESC, id DESC OFFSET $4"
+	if query != want {
+		t.Errorf("got query %q, want %q", query, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"p", since, int64(9), 4}) {
+		t.Errorf("unexpected args %v", args)
+	}
+}
@@ -134,17 +134,17 @@ func (s *PostgresStore) QueryHistory(projectID string, limit int) ([]Observation
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return scanObservations(rows)
+}
 
-	var results []Observation
-	for rows.Next() {
-		var obs Observation
-		if err := rows.Scan(&obs.ID, &obs.AgentID, &obs.Content, &obs.CreatedAt); err != nil {
-			return nil, err
-		}
-		results = append(results, obs)
+// QueryHistoryPage retrieves the page of a project's observations selected by q
+func (s *PostgresStore) QueryHistoryPage(projectID string, q HistoryQuery) ([]Observation, error) {
+	query, args := historySQL(projectID, q, true)
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
 	}
-	return results, nil
+	return scanObservations(rows)
 }
 
 // SetSignal sets a signal key-value pair
@@ -328,6 +328,17 @@ func (s *PostgresStore) ReleaseAllLocks(projectID, agentID string) error {
 	return err
 }
 
+// ResetProject deletes everything recorded for a project except its spec,
+// so the next session starts from a clean slate.
+func (s *PostgresStore) ResetProject(projectID string) error {
+	for _, table := range []string{"observations", "signals", "project_features", "file_locks"} {
+		if _, err := s.db.Exec(fmt.Sprintf(`DELETE FROM %s WHERE project_id = $1`, table), projectID); err != nil {
+			return fmt.Errorf("failed to reset %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
 // GetActiveLocks returns all current (not expired) locks.
 func (s *PostgresStore) GetActiveLocks(projectID string) ([]Lock, error) {
 	rows, err := s.db.Query("SELECT path, agent_id, expires_at FROM file_locks WHERE expires_at > $1 AND project_id = $2", time.Now(), projectID)
@@ -112,17 +112,24 @@ func (s *SQLiteStore) QueryHistory(projectID string, limit int) ([]Observation,
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return scanObservations(rows)
+}
 
-	var results []Observation
-	for rows.Next() {
-		var obs Observation
-		if err := rows.Scan(&obs.ID, &obs.AgentID, &obs.Content, &obs.CreatedAt); err != nil {
-			return nil, err
-		}
-		results = append(results, obs)
+// QueryHistoryPage retrieves the page of a project's observations selected by q
+func (s *SQLiteStore) QueryHistoryPage(projectID string, q HistoryQuery) ([]Observation, error) {
+	// Timestamps are stored as text in local time, so bounds must compare in local time too
+	if !q.Since.IsZero() {
+		q.Since = q.Since.Local()
+	}
+	if !q.Until.IsZero() {
+		q.Until = q.Until.Local()
+	}
+	query, args := historySQL(projectID, q, false)
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
 	}
-	return results, nil
+	return scanObservations(rows)
 }
 
 // SetSignal sets a signal key-value pair
@@ -319,6 +326,17 @@ func (s *SQLiteStore) Cleanup() error {
 	return nil
 }
 
+// ResetProject deletes everything recorded for a project except its spec,
+// so the next session starts from a clean slate.
+func (s *SQLiteStore) ResetProject(projectID string) error {
+	for _, table := range []string{"observations", "signals", "project_features", "file_locks"} {
+		if _, err := s.db.Exec(fmt.Sprintf(`DELETE FROM %s WHERE project_id = ?`, table), projectID); err != nil {
+			return fmt.Errorf("failed to reset %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
 // GetActiveLocks returns all current (not expired) locks.
 func (s *SQLiteStore) GetActiveLocks(projectID string) ([]Lock, error) {
 	rows, err := s.db.Query(`SELECT path, agent_id, expires_at FROM file_locks WHERE expires_at > ? AND project_id = ?`, time.Now(), projectID)
@@ -207,3 +207,54 @@ func TestSQLiteStore_AcquireLock_Expired_Highjack(t *testing.T) {
 		t.Errorf("Lock should belong to agent2, got %v", locks)
 	}
 }
+
+func TestSQLiteStore_ResetProject(t *testing.T) {
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "reset.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	for _, projectID := range []string{"proj1", "proj2"} {
+		if err := store.SaveObservation(projectID, "agent1", "note"); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.SetSignal(projectID, "COMPLETED", "true"); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.SaveFeatures(projectID, `{"features": []}`); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.SaveSpec(projectID, "spec"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.AcquireLock(projectID, "main.go", "agent1", time.Second); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.ResetProject("proj1"); err != nil {
+		t.Fatalf("ResetProject failed: %v", err)
+	}
+
+	if history, _ := store.QueryHistory("proj1", 10); len(history) != 0 {
+		t.Errorf("Expected observations to be deleted, got %d", len(history))
+	}
+	if val, _ := store.GetSignal("proj1", "COMPLETED"); val != "" {
+		t.Errorf("Expected signal to be deleted, got %q", val)
+	}
+	if features, _ := store.GetFeatures("proj1"); features != "" {
+		t.Errorf("Expected features to be deleted, got %q", features)
+	}
+	if locks, _ := store.GetActiveLocks("proj1"); len(locks) != 0 {
+		t.Errorf("Expected locks to be deleted, got %d", len(locks))
+	}
+	if spec, _ := store.GetSpec("proj1"); spec != "spec" {
+		t.Errorf("Expected spec to be kept, got %q", spec)
+	}
+
+	// Other projects are untouched
+	if val, _ := store.GetSignal("proj2", "COMPLETED"); val != "true" {
+		t.Errorf("Expected proj2 signal to be kept, got %q", val)
+	}
+}
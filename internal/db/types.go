@@ -17,6 +17,22 @@ type Feature struct {
 	Passes       bool                `json:"passes"`
 	Steps        []string            `json:"steps"`
 	Dependencies FeatureDependencies `json:"dependencies"`
+	// DependsOn is a flat shorthand for Dependencies.DependsOnIDs.
+	DependsOn []string `json:"depends_on,omitempty"`
+}
+
+// DependencyIDs returns the IDs of the features this one depends on, merging
+// DependsOn with Dependencies.DependsOnIDs.
+func (f Feature) DependencyIDs() []string {
+	ids := make([]string, 0, len(f.Dependencies.DependsOnIDs)+len(f.DependsOn))
+	seen := make(map[string]bool)
+	for _, id := range append(f.Dependencies.DependsOnIDs, f.DependsOn...) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
 }
 
 type Lock struct {
@@ -43,6 +59,7 @@ type Store interface {
 	Close() error
 	SaveObservation(projectID, agentID, content string) error
 	QueryHistory(projectID string, limit int) ([]Observation, error)
+	QueryHistoryPage(projectID string, q HistoryQuery) ([]Observation, error) // Paginated, time-filtered history
 	SetSignal(projectID, key, value string) error
 	GetSignal(projectID, key string) (string, error)
 	DeleteSignal(projectID, key string) error
@@ -60,4 +77,5 @@ type Store interface {
 
 	// Maintenance
 	Cleanup() error
+	ResetProject(projectID string) error // Deletes the project's observations, signals, features and locks
 }
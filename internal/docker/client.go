@@ -9,6 +9,8 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/build"
@@ -43,6 +45,7 @@ type APIClient interface {
 	ContainerRemove(ctx context.Context, containerID string, options container.RemoveOptions) error
 	ContainerList(ctx context.Context, options container.ListOptions) ([]types.Container, error)
 	ContainerKill(ctx context.Context, containerID, signal string) error
+	ContainerCommit(ctx context.Context, containerID string, options container.CommitOptions) (container.CommitResponse, error)
 	Close() error
 }
 
@@ -51,6 +54,7 @@ type Client struct {
 	api               APIClient
 	project           string
 	HostWorkspacePath string
+	RegistryAuth      RegistryAuth // Credentials for pulling from a private registry
 }
 
 // NewClient creates a new Docker client instance.
@@ -156,7 +160,11 @@ func (c *Client) CheckImage(ctx context.Context, imageRef string) (bool, error)
 // Progress logging should be handled by the caller.
 func (c *Client) PullImage(ctx context.Context, imageRef string) error {
 	telemetry.TrackDockerOp(c.project)
-	reader, err := c.api.ImagePull(ctx, imageRef, image.PullOptions{})
+	auth, err := c.RegistryAuth.EncodedAuth(imageRef)
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", imageRef, err)
+	}
+	reader, err := c.api.ImagePull(ctx, imageRef, image.PullOptions{RegistryAuth: auth})
 	if err != nil {
 		telemetry.TrackDockerError(c.project)
 		return fmt.Errorf("failed to pull image %s: %w", imageRef, err)
@@ -184,12 +192,29 @@ func (c *Client) PullImage(ctx context.Context, imageRef string) error {
 	return nil
 }
 
+// ContainerOptions customizes how the container process is started.
+type ContainerOptions struct {
+	Entrypoint []string // Overrides the image entrypoint when set
+	Cmd        []string // Overrides the default command (/bin/sh) when set
+	// Network is the network the container joins: bridge (the default), none
+	// (no network access), host, or the name of a user-defined network
+	Network string
+	Env     []string // KEY=VALUE environment of the container
+}
+
 // RunContainer starts a container with the specified image and mounts the workspace.
 // It returns the container ID or an error.
 func (c *Client) RunContainer(ctx context.Context, imageRef string, workspace string, extraBinds []string, ports []string, user string) (string, error) {
+	return c.RunContainerWithOptions(ctx, imageRef, workspace, extraBinds, ports, user, ContainerOptions{})
+}
+
+// RunContainerWithOptions is like RunContainer but allows overriding the
+// container entrypoint and command.
+func (c *Client) RunContainerWithOptions(ctx context.Context, imageRef string, workspace string, extraBinds []string, ports []string, user string, opts ContainerOptions) (string, error) {
 	telemetry.TrackDockerOp(c.project)
-	// 1. Pull Image (Best effort)
-	reader, err := c.api.ImagePull(ctx, imageRef, image.PullOptions{})
+	// 1. Pull Image (Best effort, so unreadable credentials fall back to an anonymous pull)
+	auth, _ := c.RegistryAuth.EncodedAuth(imageRef)
+	reader, err := c.api.ImagePull(ctx, imageRef, image.PullOptions{RegistryAuth: auth})
 	if err == nil {
 		defer reader.Close()
 		io.Copy(io.Discard, reader) // Drain output
@@ -207,6 +232,11 @@ func (c *Client) RunContainer(ctx context.Context, imageRef string, workspace st
 		binds = append(binds, extraBinds...)
 	}
 
+	cmd := []string{"/bin/sh"} // Default command to keep it alive
+	if len(opts.Cmd) > 0 {
+		cmd = opts.Cmd
+	}
+
 	// 2. Create Container
 	resp, err := c.api.ContainerCreate(ctx,
 		&container.Config{
@@ -215,10 +245,13 @@ func (c *Client) RunContainer(ctx context.Context, imageRef string, workspace st
 			Tty:        true, // Keep it running
 			OpenStdin:  true, // Keep stdin open
 			WorkingDir: "/workspace",
-			Cmd:        []string{"/bin/sh"}, // Default command to keep it alive
+			Entrypoint: opts.Entrypoint,
+			Cmd:        cmd,
+			Env:        opts.Env,
 		},
 		&container.HostConfig{
-			Binds: binds,
+			Binds:       binds,
+			NetworkMode: container.NetworkMode(opts.Network),
 		}, nil, nil, "")
 	if err != nil {
 		telemetry.TrackDockerError(c.project)
@@ -236,9 +269,59 @@ func (c *Client) RunContainer(ctx context.Context, imageRef string, workspace st
 
 // Exec executes a command in a running container and returns the output (stdout + stderr).
 func (c *Client) Exec(ctx context.Context, containerID string, cmd []string) (string, error) {
+	return c.ExecStream(ctx, containerID, cmd, nil)
+}
+
+// execSeq numbers execs so a command can be told apart from the others.
+var execSeq atomic.Uint64
+
+// execIDEnv is set in the environment of each exec; its processes and their
+// children inherit it, which is how killExec finds them.
+const execIDEnv = "RECAC_EXEC_ID"
+
+// execKillTimeout bounds the exec that kills a command whose context ended.
+const execKillTimeout = 10 * time.Second
+
+// execKillScript kills every process whose environment contains $0.
+const execKillScript = `for environ in /proc/[0-9]*/environ; do
+	if { tr '\0' '\n' < "$environ"; } 2>/dev/null | grep -qxF "$0"; then
+		pid=${environ#/proc/}
+		kill -KILL "${pid%/environ}" 2>/dev/null
+	fi
+done
+exit 0`
+
+// killExec kills the processes of the exec tagged with execID. Docker has no
+// API to stop an exec, and closing its connection leaves the command running.
+func (c *Client) killExec(containerID, execID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), execKillTimeout)
+	defer cancel()
+	respID, err := c.api.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          []string{"/bin/sh", "-c", execKillScript, execIDEnv + "=" + execID},
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := c.api.ContainerExecAttach(ctx, respID.ID, container.ExecStartOptions{})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+	_, err = io.Copy(io.Discard, resp.Reader)
+	return err
+}
+
+// ExecStream is like Exec but also calls onLine for every line of stdout/stderr
+// as the command produces it. The full output is still returned. A nil onLine
+// disables streaming. When ctx ends first, the command is killed.
+func (c *Client) ExecStream(ctx context.Context, containerID string, cmd []string, onLine func(string)) (string, error) {
 	telemetry.TrackDockerOp(c.project)
+	execID := fmt.Sprintf("%d-%d", os.Getpid(), execSeq.Add(1))
 	execConfig := container.ExecOptions{
 		WorkingDir:   "/workspace",
+		Env:          []string{execIDEnv + "=" + execID},
 		Cmd:          cmd,
 		AttachStdout: true,
 		AttachStderr: true,
@@ -256,22 +339,36 @@ func (c *Client) Exec(ctx context.Context, containerID string, cmd []string) (st
 	defer resp.Close()
 
 	var outBuf, errBuf bytes.Buffer
+	var stdout, stderr io.Writer = &outBuf, &errBuf
+	var lines *lineWriter
+	if onLine != nil {
+		lines = &lineWriter{onLine: onLine}
+		stdout = io.MultiWriter(&outBuf, lines)
+		stderr = io.MultiWriter(&errBuf, lines)
+	}
 	done := make(chan error, 1)
 
 	go func() {
 		// stdcopy.StdCopy demultiplexes the stream if Tty is false.
 		// If Tty is true in ExecConfig, it's a raw stream.
 		// We didn't set Tty in ExecConfig, so it defaults to false.
-		_, err := stdcopy.StdCopy(&outBuf, &errBuf, resp.Reader)
+		_, err := stdcopy.StdCopy(stdout, stderr, resp.Reader)
 		done <- err
 	}()
 
 	select {
 	case <-ctx.Done():
-		// Context cancelled/timed out - close connection to interrupt read
+		// Context cancelled/timed out - close connection to interrupt read,
+		// keeping whatever the command printed before it hung
 		resp.Close()
-		return "", ctx.Err()
+		<-done
+		lines.Flush()
+		if err := c.killExec(containerID, execID); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to kill timed out command in %s: %v\n", containerID, err)
+		}
+		return outBuf.String() + errBuf.String(), ctx.Err()
 	case err := <-done:
+		lines.Flush()
 		if err != nil && err != io.EOF {
 			return "", fmt.Errorf("failed to copy exec output: %w", err)
 		}
@@ -393,6 +490,23 @@ func (c *Client) StopContainer(ctx context.Context, containerID string) error {
 	return nil
 }
 
+// CommitContainer snapshots the filesystem of a container into the image ref
+// and returns the image ID. The container is paused while it is committed.
+// Bind-mounted paths, such as the workspace, are not part of the image.
+func (c *Client) CommitContainer(ctx context.Context, containerID, ref string) (string, error) {
+	telemetry.TrackDockerOp(c.project)
+	resp, err := c.api.ContainerCommit(ctx, containerID, container.CommitOptions{
+		Reference: ref,
+		Comment:   "recac checkpoint",
+		Pause:     true,
+	})
+	if err != nil {
+		telemetry.TrackDockerError(c.project)
+		return "", fmt.Errorf("failed to commit container %s: %w", containerID, err)
+	}
+	return resp.ID, nil
+}
+
 // RemoveContainer removes a container.
 func (c *Client) RemoveContainer(ctx context.Context, containerID string, force bool) error {
 	telemetry.TrackDockerOp(c.project)
@@ -502,3 +616,31 @@ func (c *Client) ImageBuild(ctx context.Context, opts ImageBuildOptions) (string
 
 	return imageID, nil
 }
+
+// lineWriter calls onLine for each complete line written to it.
+type lineWriter struct {
+	onLine func(string)
+	buf    []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.onLine(strings.TrimSuffix(string(w.buf[:i]), "\r"))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush emits any trailing partial line. It is a no-op on a nil writer.
+func (w *lineWriter) Flush() {
+	if w == nil || len(w.buf) == 0 {
+		return
+	}
+	w.onLine(string(w.buf))
+	w.buf = nil
+}
@@ -153,6 +153,46 @@ func TestRunContainer_PullFailureIgnored(t *testing.T) {
 	}
 }
 
+func TestRunContainerWithOptions(t *testing.T) {
+	client, mock := NewMockClient()
+
+	var got *container.Config
+	var gotHost *container.HostConfig
+	mock.ContainerCreateFunc = func(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *specs.Platform, containerName string) (container.CreateResponse, error) {
+		got = config
+		gotHost = hostConfig
+		return container.CreateResponse{ID: "new-id"}, nil
+	}
+
+	if _, err := client.RunContainer(context.Background(), "img", "/ws", nil, nil, ""); err != nil {
+		t.Fatalf("RunContainer failed: %v", err)
+	}
+	if len(got.Entrypoint) != 0 || strings.Join(got.Cmd, " ") != "/bin/sh" {
+		t.Errorf("Expected default entrypoint and /bin/sh command, got %v %v", got.Entrypoint, got.Cmd)
+	}
+	if gotHost.NetworkMode != "" {
+		t.Errorf("Expected the default network, got %q", gotHost.NetworkMode)
+	}
+
+	opts := ContainerOptions{
+		Entrypoint: []string{"/usr/bin/perf", "record", "--"},
+		Cmd:        []string{"/bin/bash"},
+		Network:    "none",
+	}
+	if _, err := client.RunContainerWithOptions(context.Background(), "img", "/ws", nil, nil, "", opts); err != nil {
+		t.Fatalf("RunContainerWithOptions failed: %v", err)
+	}
+	if strings.Join(got.Entrypoint, " ") != "/usr/bin/perf record --" {
+		t.Errorf("Expected custom entrypoint, got %v", got.Entrypoint)
+	}
+	if strings.Join(got.Cmd, " ") != "/bin/bash" {
+		t.Errorf("Expected custom command, got %v", got.Cmd)
+	}
+	if gotHost.NetworkMode != "none" {
+		t.Errorf("Expected network none, got %q", gotHost.NetworkMode)
+	}
+}
+
 func TestExecInteractive_CreateError(t *testing.T) {
 	client, mock := NewMockClient()
 
@@ -279,3 +319,27 @@ func TestImageBuild_IDFallback(t *testing.T) {
 		t.Errorf("Expected fallback to tag 'my-fallback-tag', got %s", id)
 	}
 }
+
+func TestCommitContainer(t *testing.T) {
+	client, mock := NewMockClient()
+
+	var options container.CommitOptions
+	mock.ContainerCommitFunc = func(ctx context.Context, containerID string, opts container.CommitOptions) (container.CommitResponse, error) {
+		options = opts
+		return container.CommitResponse{ID: "sha256:abc"}, nil
+	}
+	id, err := client.CommitContainer(context.Background(), "container-1", "recac-checkpoint:proj")
+	if err != nil || id != "sha256:abc" {
+		t.Fatalf("CommitContainer() = %q, %v", id, err)
+	}
+	if options.Reference != "recac-checkpoint:proj" || !options.Pause {
+		t.Errorf("unexpected commit options %+v", options)
+	}
+
+	mock.ContainerCommitFunc = func(ctx context.Context, containerID string, opts container.CommitOptions) (container.CommitResponse, error) {
+		return container.CommitResponse{}, errors.New("no space left")
+	}
+	if _, err := client.CommitContainer(context.Background(), "container-1", "recac-checkpoint:proj"); err == nil {
+		t.Error("expected an error from CommitContainer")
+	}
+}
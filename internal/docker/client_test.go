@@ -125,6 +125,10 @@ func (m *mockAPIClient) ContainerKill(ctx context.Context, containerID, signal s
 	return nil
 }
 
+func (m *mockAPIClient) ContainerCommit(ctx context.Context, containerID string, options container.CommitOptions) (container.CommitResponse, error) {
+	return container.CommitResponse{}, nil
+}
+
 func (m *mockAPIClient) Close() error {
 	return nil
 }
@@ -0,0 +1,96 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/docker/docker/api/types/image"
+)
+
+var digestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// ValidateDigest checks that digest is a well-formed sha256 image digest.
+func ValidateDigest(digest string) error {
+	if !digestPattern.MatchString(digest) {
+		return fmt.Errorf("invalid image digest %q: expected sha256:<64 hex chars>", digest)
+	}
+	return nil
+}
+
+// SplitDigest splits a pinned reference like "recac-agent@sha256:..." into
+// the repository reference and the digest. The digest is empty if the
+// reference is not pinned.
+func SplitDigest(imageRef string) (string, string) {
+	if i := strings.LastIndex(imageRef, "@"); i >= 0 {
+		return imageRef[:i], imageRef[i+1:]
+	}
+	return imageRef, ""
+}
+
+// PinImage returns imageRef pinned to digest ("repo@sha256:..."), dropping any tag.
+// An empty digest returns imageRef unchanged.
+func PinImage(imageRef, digest string) (string, error) {
+	if digest == "" {
+		return imageRef, nil
+	}
+	if err := ValidateDigest(digest); err != nil {
+		return "", err
+	}
+
+	repo, existing := SplitDigest(imageRef)
+	if existing != "" && existing != digest {
+		return "", fmt.Errorf("image %s is already pinned to a different digest than %s", imageRef, digest)
+	}
+	// Strip the tag, but not a registry port (e.g. localhost:5000/recac-agent)
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo = repo[:i]
+	}
+	return repo + "@" + digest, nil
+}
+
+// DigestMatches reports whether digest matches any of the given repo digests
+// ("repo@sha256:...") or image IDs ("sha256:...").
+func DigestMatches(digests []string, digest string) bool {
+	for _, d := range digests {
+		if d == digest || strings.HasSuffix(d, "@"+digest) {
+			return true
+		}
+	}
+	return false
+}
+
+// ImageDigests returns the repo digests and the image ID of the local image
+// matching imageRef (by tag, repo digest or ID). Pulled images carry repo
+// digests; locally built images only have an ID.
+func (c *Client) ImageDigests(ctx context.Context, imageRef string) ([]string, error) {
+	images, err := c.api.ImageList(ctx, image.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	normalizedRef := imageRef
+	if !strings.Contains(imageRef, ":") {
+		normalizedRef = imageRef + ":latest"
+	}
+
+	for _, img := range images {
+		matched := img.ID == imageRef
+		for _, t := range img.RepoTags {
+			if t == imageRef || t == normalizedRef {
+				matched = true
+			}
+		}
+		for _, d := range img.RepoDigests {
+			if d == imageRef {
+				matched = true
+			}
+		}
+		if matched {
+			return append(append([]string{}, img.RepoDigests...), img.ID), nil
+		}
+	}
+
+	return nil, fmt.Errorf("image %s not found locally", imageRef)
+}
@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+)
+
+var testDigest = "sha256:" + strings.Repeat("ab", 32)
+
+func TestPinImage(t *testing.T) {
+	tests := []struct {
+		image   string
+		digest  string
+		want    string
+		wantErr bool
+	}{
+		{"ghcr.io/org/recac-agent:latest", testDigest, "ghcr.io/org/recac-agent@" + testDigest, false},
+		{"localhost:5000/recac-agent", testDigest, "localhost:5000/recac-agent@" + testDigest, false},
+		{"recac-agent@" + testDigest, testDigest, "recac-agent@" + testDigest, false},
+		{"recac-agent:latest", "", "recac-agent:latest", false},
+		{"recac-agent:latest", "sha256:short", "", true},
+		{"recac-agent@sha256:" + strings.Repeat("cd", 32), testDigest, "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := PinImage(tt.image, tt.digest)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("PinImage(%q, %q) error = %v, wantErr %v", tt.image, tt.digest, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("PinImage(%q, %q) = %q, want %q", tt.image, tt.digest, got, tt.want)
+		}
+	}
+}
+
+func TestImageDigests(t *testing.T) {
+	c, mock := NewMockClient()
+	mock.ImageListFunc = func(ctx context.Context, options image.ListOptions) ([]image.Summary, error) {
+		return []image.Summary{
+			{ID: "sha256:other", RepoTags: []string{"other:latest"}},
+			{ID: "sha256:built", RepoTags: []string{"recac-agent:latest"}, RepoDigests: []string{"ghcr.io/org/recac-agent@" + testDigest}},
+		}, nil
+	}
+
+	digests, err := c.ImageDigests(context.Background(), "recac-agent")
+	if err != nil {
+		t.Fatalf("ImageDigests failed: %v", err)
+	}
+	if !DigestMatches(digests, testDigest) {
+		t.Errorf("expected repo digest match, got %v", digests)
+	}
+	if !DigestMatches(digests, "sha256:built") {
+		t.Errorf("expected image ID match, got %v", digests)
+	}
+	if DigestMatches(digests, "sha256:other") {
+		t.Errorf("unexpected match for another image's ID")
+	}
+
+	if _, err := c.ImageDigests(context.Background(), "missing:latest"); err == nil {
+		t.Error("expected error for missing image")
+	}
+}
@@ -0,0 +1,84 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/client"
+)
+
+// ErrInvalidContainerConfig marks container start errors caused by the
+// requested configuration rather than by the daemon or the image.
+var ErrInvalidContainerConfig = errors.New("invalid container configuration")
+
+// RunFailure classifies why a container failed to start.
+type RunFailure int
+
+const (
+	// RunFailureImage is any failure not known to be transient or caused by
+	// the configuration, typically a missing or corrupt image. Re-pulling or
+	// rebuilding the image may fix it.
+	RunFailureImage RunFailure = iota
+	// RunFailureTransient means the daemon was unreachable or briefly unable
+	// to serve the request. Retrying may fix it.
+	RunFailureTransient
+	// RunFailureConfig means the request itself is invalid (a bad mount, an
+	// unknown network, an invalid reference). Retrying cannot fix it.
+	RunFailureConfig
+)
+
+// configErrorHints and transientErrorHints recognize errors whose type was lost
+// on the way (e.g. flattened into a message by a wrapper).
+var (
+	configErrorHints = []string{
+		"invalid container configuration",
+		"invalid reference format",
+		"invalid mount",
+		"invalid volume",
+		"bind source path does not exist",
+		"no such network",
+		"permission denied",
+		"executable file not found",
+	}
+	transientErrorHints = []string{
+		"cannot connect to the docker daemon",
+		"is the docker daemon running",
+		"connection refused",
+		"connection reset",
+		"i/o timeout",
+		"tls handshake timeout",
+		"service unavailable",
+		"too many requests",
+	}
+)
+
+// ClassifyRunError reports what kind of failure err, returned when starting a
+// container, is.
+func ClassifyRunError(err error) RunFailure {
+	switch {
+	case errors.Is(err, ErrInvalidContainerConfig),
+		cerrdefs.IsInvalidArgument(err),
+		cerrdefs.IsPermissionDenied(err),
+		cerrdefs.IsUnauthorized(err):
+		return RunFailureConfig
+	case client.IsErrConnectionFailed(err),
+		cerrdefs.IsUnavailable(err),
+		errors.Is(err, context.DeadlineExceeded):
+		return RunFailureTransient
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, hint := range configErrorHints {
+		if strings.Contains(msg, hint) {
+			return RunFailureConfig
+		}
+	}
+	for _, hint := range transientErrorHints {
+		if strings.Contains(msg, hint) {
+			return RunFailureTransient
+		}
+	}
+	return RunFailureImage
+}
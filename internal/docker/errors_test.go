@@ -0,0 +1,32 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+)
+
+func TestClassifyRunError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want RunFailure
+	}{
+		{fmt.Errorf("failed to create container: %w", cerrdefs.ErrInvalidArgument), RunFailureConfig},
+		{fmt.Errorf("%w: docker client does not support a custom network", ErrInvalidContainerConfig), RunFailureConfig},
+		{errors.New("Error response from daemon: invalid reference format"), RunFailureConfig},
+		{errors.New("Error response from daemon: network agents-net not found: No such network"), RunFailureConfig},
+		{fmt.Errorf("failed to start container: %w", cerrdefs.ErrUnavailable), RunFailureTransient},
+		{fmt.Errorf("failed to create container: %w", context.DeadlineExceeded), RunFailureTransient},
+		{errors.New("Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?"), RunFailureTransient},
+		{errors.New("Error response from daemon: No such image: recac-agent:latest"), RunFailureImage},
+		{errors.New("failed to register layer: unexpected EOF"), RunFailureImage},
+	}
+	for _, tt := range tests {
+		if got := ClassifyRunError(tt.err); got != tt.want {
+			t.Errorf("ClassifyRunError(%q) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
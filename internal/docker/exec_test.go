@@ -4,9 +4,12 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"net"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -47,3 +50,77 @@ func TestClient_Exec(t *testing.T) {
 		t.Errorf("Expected output 'hello', got %q", output)
 	}
 }
+
+func TestClient_ExecStream(t *testing.T) {
+	client, mock := NewMockClient()
+
+	mock.ContainerExecAttachFunc = func(ctx context.Context, execID string, config container.ExecStartOptions) (types.HijackedResponse, error) {
+		var buf bytes.Buffer
+		frame := func(stream byte, msg string) {
+			header := [8]byte{stream, 0, 0, 0, 0, 0, 0, byte(len(msg))}
+			buf.Write(header[:])
+			buf.Write([]byte(msg))
+		}
+		frame(1, "building\nstep ")
+		frame(1, "2\n")
+		frame(2, "warning\n")
+		frame(1, "done")
+
+		return types.HijackedResponse{
+			Conn:   &fakeConn{},
+			Reader: bufio.NewReader(&buf),
+		}, nil
+	}
+
+	var lines []string
+	output, err := client.ExecStream(context.Background(), "container-id", []string{"make"}, func(line string) {
+		lines = append(lines, line)
+	})
+	if err != nil {
+		t.Fatalf("ExecStream failed: %v", err)
+	}
+
+	expectedLines := []string{"building", "step 2", "warning", "done"}
+	if strings.Join(lines, "|") != strings.Join(expectedLines, "|") {
+		t.Errorf("Expected streamed lines %q, got %q", expectedLines, lines)
+	}
+	if output != "building\nstep 2\ndonewarning\n" {
+		t.Errorf("Expected full output (stdout + stderr), got %q", output)
+	}
+}
+
+func TestClient_ExecStream_TimeoutKillsCommand(t *testing.T) {
+	client, mock := NewMockClient()
+
+	var execs []container.ExecOptions
+	mock.ContainerExecCreateFunc = func(ctx context.Context, containerID string, config container.ExecOptions) (types.IDResponse, error) {
+		execs = append(execs, config)
+		return types.IDResponse{ID: fmt.Sprintf("exec-%d", len(execs))}, nil
+	}
+	mock.ContainerExecAttachFunc = func(ctx context.Context, execID string, config container.ExecStartOptions) (types.HijackedResponse, error) {
+		server, conn := net.Pipe()
+		if execID != "exec-1" {
+			// Only the kill exec finishes; the command hangs until its connection is closed
+			go server.Close()
+		}
+		return types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(conn)}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.ExecStream(ctx, "container-id", []string{"sleep", "3600"}, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a deadline error, got %v", err)
+	}
+
+	if len(execs) != 2 {
+		t.Fatalf("Expected the command and a kill exec, got %d execs", len(execs))
+	}
+	if len(execs[0].Env) != 1 || !strings.HasPrefix(execs[0].Env[0], execIDEnv+"=") {
+		t.Fatalf("Expected the command to be tagged with %s, got env %v", execIDEnv, execs[0].Env)
+	}
+	kill := execs[1].Cmd
+	if kill[len(kill)-1] != execs[0].Env[0] || !strings.Contains(strings.Join(kill, " "), "kill -KILL") {
+		t.Errorf("Expected the kill exec to target %s, got %q", execs[0].Env[0], kill)
+	}
+}
@@ -31,6 +31,7 @@ type MockAPI struct {
 	ContainerRemoveFunc      func(ctx context.Context, containerID string, options container.RemoveOptions) error
 	ContainerListFunc        func(ctx context.Context, options container.ListOptions) ([]types.Container, error)
 	ContainerKillFunc        func(ctx context.Context, containerID, signal string) error
+	ContainerCommitFunc      func(ctx context.Context, containerID string, options container.CommitOptions) (container.CommitResponse, error)
 	CloseFunc                func() error
 }
 
@@ -159,6 +160,13 @@ func (m *MockAPI) ContainerKill(ctx context.Context, containerID, signal string)
 	return nil
 }
 
+func (m *MockAPI) ContainerCommit(ctx context.Context, containerID string, options container.CommitOptions) (container.CommitResponse, error) {
+	if m.ContainerCommitFunc != nil {
+		return m.ContainerCommitFunc(ctx, containerID, options)
+	}
+	return container.CommitResponse{ID: "sha256:mockcommit"}, nil
+}
+
 func (m *MockAPI) Close() error {
 	if m.CloseFunc != nil {
 		return m.CloseFunc()
@@ -0,0 +1,58 @@
+package docker
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// defaultNoProxy keeps loopback traffic, such as a dev server under test,
+// away from the egress proxy.
+const defaultNoProxy = "localhost,127.0.0.1,::1"
+
+// ProxyConfig routes the outbound HTTP(S) traffic of a container through an
+// egress proxy, so its network access can be allowlisted.
+type ProxyConfig struct {
+	HTTP    string // Proxy for http:// requests
+	HTTPS   string // Proxy for https:// requests (defaults to HTTP)
+	NoProxy string // Comma-separated hosts that bypass the proxy (defaults to loopback)
+}
+
+// Enabled reports whether a proxy is configured.
+func (p ProxyConfig) Enabled() bool {
+	return p.HTTP != "" || p.HTTPS != ""
+}
+
+// Env returns the proxy variables to set in the container, in both the
+// upper- and lower-case forms tools look for. It is empty when no proxy is
+// configured.
+func (p ProxyConfig) Env() ([]string, error) {
+	if !p.Enabled() {
+		return nil, nil
+	}
+
+	httpsProxy := p.HTTPS
+	if httpsProxy == "" {
+		httpsProxy = p.HTTP
+	}
+	noProxy := p.NoProxy
+	if noProxy == "" {
+		noProxy = defaultNoProxy
+	}
+
+	var env []string
+	for _, v := range []struct{ name, value string }{
+		{"HTTP_PROXY", p.HTTP},
+		{"HTTPS_PROXY", httpsProxy},
+	} {
+		if v.value == "" {
+			continue
+		}
+		u, err := url.Parse(v.value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: expected scheme://host:port", v.value)
+		}
+		env = append(env, v.name+"="+v.value, strings.ToLower(v.name)+"="+v.value)
+	}
+	return append(env, "NO_PROXY="+noProxy, "no_proxy="+noProxy), nil
+}
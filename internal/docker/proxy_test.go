@@ -0,0 +1,42 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProxyConfig_Env(t *testing.T) {
+	env, err := ProxyConfig{}.Env()
+	if err != nil || env != nil {
+		t.Fatalf("expected no env without a proxy, got %v (err %v)", env, err)
+	}
+
+	env, err = ProxyConfig{HTTP: "http://proxy:3128"}.Env()
+	if err != nil {
+		t.Fatalf("Env failed: %v", err)
+	}
+	want := []string{
+		"HTTP_PROXY=http://proxy:3128", "http_proxy=http://proxy:3128",
+		"HTTPS_PROXY=http://proxy:3128", "https_proxy=http://proxy:3128",
+		"NO_PROXY=localhost,127.0.0.1,::1", "no_proxy=localhost,127.0.0.1,::1",
+	}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("Env() = %v, want %v", env, want)
+	}
+
+	env, err = ProxyConfig{HTTPS: "http://secure:3128", NoProxy: "internal.example.com"}.Env()
+	if err != nil {
+		t.Fatalf("Env failed: %v", err)
+	}
+	want = []string{
+		"HTTPS_PROXY=http://secure:3128", "https_proxy=http://secure:3128",
+		"NO_PROXY=internal.example.com", "no_proxy=internal.example.com",
+	}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("Env() = %v, want %v", env, want)
+	}
+
+	if _, err := (ProxyConfig{HTTP: "proxy:3128"}).Env(); err == nil {
+		t.Error("expected an error for a proxy without a scheme")
+	}
+}
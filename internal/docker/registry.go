@@ -0,0 +1,143 @@
+package docker
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/docker/docker/api/types/registry"
+)
+
+// dockerHub is the registry of images without a registry host, such as
+// "ubuntu" or "library/ubuntu".
+const dockerHub = "docker.io"
+
+// dockerHubConfigKey is the key Docker Hub credentials use in a docker
+// config.json.
+const dockerHubConfigKey = "https://index.docker.io/v1/"
+
+// RegistryAuth holds the credentials used to pull images from a private
+// registry. Without it, pulls only use what the Docker daemon already has.
+type RegistryAuth struct {
+	URL        string // Registry host (e.g. registry.example.com:5000); empty applies the credentials to every registry
+	Username   string
+	Password   string
+	ConfigPath string // Docker config.json to take the credentials from instead of Username/Password
+}
+
+// Enabled reports whether credentials are configured.
+func (a RegistryAuth) Enabled() bool {
+	return a.Username != "" || a.ConfigPath != ""
+}
+
+// dockerConfig is the part of a docker config.json that holds credentials.
+type dockerConfig struct {
+	Auths map[string]registry.AuthConfig `json:"auths"`
+}
+
+// ImageRegistry returns the registry host of imageRef, e.g.
+// "registry.example.com:5000" for "registry.example.com:5000/team/agent:1",
+// or "docker.io" for "recac-agent:latest".
+func ImageRegistry(imageRef string) string {
+	i := strings.Index(imageRef, "/")
+	if i < 0 {
+		return dockerHub
+	}
+	host := imageRef[:i]
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return dockerHub
+	}
+	return normalizeRegistry(host)
+}
+
+// normalizeRegistry reduces a registry URL or config key to its host, so
+// "https://index.docker.io/v1/" and "docker.io" compare equal.
+func normalizeRegistry(url string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	switch host {
+	case "index.docker.io", "registry-1.docker.io":
+		return dockerHub
+	}
+	return host
+}
+
+// EncodedAuth returns the credentials for pulling imageRef in the form the
+// Docker API expects, or "" when none are configured for its registry.
+func (a RegistryAuth) EncodedAuth(imageRef string) (string, error) {
+	if !a.Enabled() {
+		return "", nil
+	}
+	host := ImageRegistry(imageRef)
+	if a.URL != "" && normalizeRegistry(a.URL) != host {
+		return "", nil
+	}
+
+	auth := registry.AuthConfig{Username: a.Username, Password: a.Password, ServerAddress: host}
+	if a.ConfigPath != "" {
+		var ok bool
+		var err error
+		if auth, ok, err = a.configAuth(host); err != nil || !ok {
+			return "", err
+		}
+	}
+	return registry.EncodeAuthConfig(auth)
+}
+
+// configAuth looks up the credentials for host in ConfigPath. Credential
+// helpers (credsStore) are not supported; the credentials must be inline.
+func (a RegistryAuth) configAuth(host string) (registry.AuthConfig, bool, error) {
+	data, err := os.ReadFile(a.ConfigPath)
+	if err != nil {
+		return registry.AuthConfig{}, false, fmt.Errorf("failed to read registry config: %w", err)
+	}
+	var cfg dockerConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return registry.AuthConfig{}, false, fmt.Errorf("failed to parse registry config %s: %w", a.ConfigPath, err)
+	}
+
+	for key, auth := range cfg.Auths {
+		if normalizeRegistry(key) != host {
+			continue
+		}
+		if auth.Auth != "" && auth.Username == "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return registry.AuthConfig{}, false, fmt.Errorf("invalid auth for %s in %s: %w", key, a.ConfigPath, err)
+			}
+			auth.Username, auth.Password, _ = strings.Cut(string(decoded), ":")
+		}
+		auth.Auth = ""
+		auth.ServerAddress = host
+		return auth, true, nil
+	}
+	return registry.AuthConfig{}, false, nil
+}
+
+// DockerConfigJSON returns the credentials as a docker config.json, the
+// format of a Kubernetes image pull secret. A ConfigPath is returned as is.
+func (a RegistryAuth) DockerConfigJSON() ([]byte, error) {
+	if a.ConfigPath != "" {
+		data, err := os.ReadFile(a.ConfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read registry config: %w", err)
+		}
+		return data, nil
+	}
+
+	key := dockerHubConfigKey
+	if a.URL != "" && normalizeRegistry(a.URL) != dockerHub {
+		key = normalizeRegistry(a.URL)
+	}
+	return json.Marshal(dockerConfig{Auths: map[string]registry.AuthConfig{
+		key: {
+			Username: a.Username,
+			Password: a.Password,
+			Auth:     base64.StdEncoding.EncodeToString([]byte(a.Username + ":" + a.Password)),
+		},
+	}})
+}
@@ -0,0 +1,128 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
+)
+
+func TestImageRegistry(t *testing.T) {
+	tests := map[string]string{
+		"recac-agent:latest":                      "docker.io",
+		"library/ubuntu":                          "docker.io",
+		"registry.example.com/team/agent:1":       "registry.example.com",
+		"registry.example.com:5000/agent@sha256:": "registry.example.com:5000",
+		"localhost/agent":                         "localhost",
+		"index.docker.io/library/ubuntu":          "docker.io",
+	}
+	for ref, want := range tests {
+		if got := ImageRegistry(ref); got != want {
+			t.Errorf("ImageRegistry(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func decodeAuth(t *testing.T, encoded string) *registry.AuthConfig {
+	t.Helper()
+	auth, err := registry.DecodeAuthConfig(encoded)
+	if err != nil {
+		t.Fatalf("DecodeAuthConfig failed: %v", err)
+	}
+	return auth
+}
+
+func TestRegistryAuth_EncodedAuth(t *testing.T) {
+	if encoded, err := (RegistryAuth{}).EncodedAuth("recac-agent"); err != nil || encoded != "" {
+		t.Fatalf("expected no auth without credentials, got %q (err %v)", encoded, err)
+	}
+
+	auth := RegistryAuth{URL: "https://registry.example.com", Username: "bot", Password: "secret"}
+	encoded, err := auth.EncodedAuth("registry.example.com/team/agent:1")
+	if err != nil {
+		t.Fatalf("EncodedAuth failed: %v", err)
+	}
+	got := decodeAuth(t, encoded)
+	if got.Username != "bot" || got.Password != "secret" || got.ServerAddress != "registry.example.com" {
+		t.Errorf("unexpected auth %+v", got)
+	}
+
+	// Credentials are not sent to other registries
+	if encoded, _ := auth.EncodedAuth("ghcr.io/other/image"); encoded != "" {
+		t.Error("expected no auth for an image on another registry")
+	}
+}
+
+func TestRegistryAuth_ConfigPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := `{"auths": {"https://registry.example.com/v1/": {"auth": "Ym90OnNlY3JldA=="}}}` // bot:secret
+	if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	auth := RegistryAuth{ConfigPath: path}
+
+	encoded, err := auth.EncodedAuth("registry.example.com/agent")
+	if err != nil {
+		t.Fatalf("EncodedAuth failed: %v", err)
+	}
+	got := decodeAuth(t, encoded)
+	if got.Username != "bot" || got.Password != "secret" {
+		t.Errorf("unexpected auth %+v", got)
+	}
+
+	if encoded, err := auth.EncodedAuth("recac-agent"); err != nil || encoded != "" {
+		t.Errorf("expected no auth for a registry missing from the config, got %q (err %v)", encoded, err)
+	}
+
+	data, err := auth.DockerConfigJSON()
+	if err != nil || string(data) != config {
+		t.Errorf("DockerConfigJSON() = %s (err %v), want the config file", data, err)
+	}
+
+	if _, err := (RegistryAuth{ConfigPath: filepath.Join(t.TempDir(), "missing.json")}).EncodedAuth("agent"); err == nil {
+		t.Error("expected an error for a missing config file")
+	}
+}
+
+func TestRegistryAuth_DockerConfigJSON(t *testing.T) {
+	data, err := RegistryAuth{URL: "registry.example.com", Username: "bot", Password: "secret"}.DockerConfigJSON()
+	if err != nil {
+		t.Fatalf("DockerConfigJSON failed: %v", err)
+	}
+	var cfg dockerConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got := cfg.Auths["registry.example.com"]; got.Auth != "Ym90OnNlY3JldA==" {
+		t.Errorf("unexpected auths %+v", cfg.Auths)
+	}
+
+	data, _ = RegistryAuth{Username: "bot", Password: "secret"}.DockerConfigJSON()
+	if !strings.Contains(string(data), dockerHubConfigKey) {
+		t.Errorf("expected Docker Hub credentials without a URL, got %s", data)
+	}
+}
+
+func TestPullImage_RegistryAuth(t *testing.T) {
+	client, mock := NewMockClient()
+	client.RegistryAuth = RegistryAuth{Username: "bot", Password: "secret"}
+
+	var options image.PullOptions
+	mock.ImagePullFunc = func(ctx context.Context, ref string, opts image.PullOptions) (io.ReadCloser, error) {
+		options = opts
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+
+	if err := client.PullImage(context.Background(), "recac-agent"); err != nil {
+		t.Fatalf("PullImage failed: %v", err)
+	}
+	if got := decodeAuth(t, options.RegistryAuth); got.Username != "bot" {
+		t.Errorf("expected the pull to carry the registry credentials, got %+v", got)
+	}
+}
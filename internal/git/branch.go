@@ -0,0 +1,34 @@
+package git
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultBranchTemplate is the branch naming template used when git.branch_template is not configured.
+const DefaultBranchTemplate = "agent/{ticket}"
+
+// BranchName returns the feature branch name for a ticket, rendered from the
+// git.branch_template config value. The {ticket} placeholder is replaced with the ticket ID.
+func BranchName(ticketID string) string {
+	tmpl := viper.GetString("git.branch_template")
+	if tmpl == "" {
+		tmpl = DefaultBranchTemplate
+	}
+	if !strings.Contains(tmpl, "{ticket}") {
+		// A template without a placeholder would map every ticket to the same branch
+		tmpl = strings.TrimSuffix(tmpl, "/") + "/{ticket}"
+	}
+	return strings.ReplaceAll(tmpl, "{ticket}", ticketID)
+}
+
+// IsAgentBranch reports whether branch was created for ticketID, either with the
+// stable name or with the unique (timestamp-suffixed) variant.
+func IsAgentBranch(branch, ticketID string) bool {
+	if ticketID == "" {
+		return false
+	}
+	name := BranchName(ticketID)
+	return branch == name || strings.HasPrefix(branch, name+"-")
+}
@@ -0,0 +1,42 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranchName(t *testing.T) {
+	defer viper.Set("git.branch_template", "")
+
+	tests := []struct {
+		name     string
+		template string
+		ticket   string
+		expected string
+	}{
+		{"Default", "", "RECAC-1", "agent/RECAC-1"},
+		{"Custom prefix", "feature/{ticket}", "RECAC-123", "feature/RECAC-123"},
+		{"Embedded placeholder", "bot/{ticket}-work", "ABC-9", "bot/ABC-9-work"},
+		{"Missing placeholder", "feature/", "ABC-9", "feature/ABC-9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("git.branch_template", tt.template)
+			assert.Equal(t, tt.expected, BranchName(tt.ticket))
+		})
+	}
+}
+
+func TestIsAgentBranch(t *testing.T) {
+	viper.Set("git.branch_template", "feature/{ticket}")
+	defer viper.Set("git.branch_template", "")
+
+	assert.True(t, IsAgentBranch("feature/RECAC-1", "RECAC-1"))
+	assert.True(t, IsAgentBranch("feature/RECAC-1-20240101-120000", "RECAC-1"))
+	assert.False(t, IsAgentBranch("feature/RECAC-12", "RECAC-1"))
+	assert.False(t, IsAgentBranch("main", "RECAC-1"))
+	assert.False(t, IsAgentBranch("feature/RECAC-1", ""))
+}
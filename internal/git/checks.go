@@ -0,0 +1,55 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Commit check states, normalized across hosting providers.
+const (
+	CheckSuccess = "success"
+	CheckFailure = "failure"
+	CheckPending = "pending"
+)
+
+var checkSeverity = map[string]int{CheckSuccess: 0, CheckPending: 1, CheckFailure: 2}
+
+// MergeCheckState records state for the check name in checks. When a provider
+// reports the same name more than once, failures win over pending checks and
+// pending checks over successes.
+func MergeCheckState(checks map[string]string, name, state string) {
+	if prev, ok := checks[name]; ok && checkSeverity[prev] >= checkSeverity[state] {
+		return
+	}
+	checks[name] = state
+}
+
+// ParseRemoteURL splits a remote URL (https, ssh or scp-like) into its host
+// and repository path, e.g. "github.com" and "owner/repo". Credentials and a
+// trailing ".git" are dropped.
+func ParseRemoteURL(remote string) (string, string, error) {
+	remote = strings.TrimSpace(remote)
+
+	var host, path string
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			// The URL may carry a token, so it is not echoed back
+			return "", "", fmt.Errorf("invalid remote URL")
+		}
+		host, path = u.Hostname(), u.Path
+	} else {
+		// scp-like syntax: [user@]host:owner/repo.git
+		if _, rest, ok := strings.Cut(remote, "@"); ok {
+			remote = rest
+		}
+		host, path, _ = strings.Cut(remote, ":")
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || !strings.Contains(path, "/") {
+		return "", "", fmt.Errorf("remote URL has no host/owner/repo")
+	}
+	return host, path, nil
+}
@@ -90,6 +90,16 @@ func TestClient_DiffStat(t *testing.T) {
 		t.Error("DiffStat missing insertions count")
 	}
 
+	// Without an end commit, uncommitted changes are compared
+	os.WriteFile(filepath.Join(localDir, "f2"), []byte("new file\nmore"), 0644)
+	stat, err = c.DiffStat(localDir, "HEAD", "")
+	if err != nil {
+		t.Fatalf("DiffStat against the working tree failed: %v", err)
+	}
+	if !strings.Contains(stat, "f2") || strings.Contains(stat, "f1") {
+		t.Errorf("Expected only the uncommitted change to f2, got %q", stat)
+	}
+
 	// Test DiffStat with invalid SHAs
 	_, err = c.DiffStat(localDir, "invalid", "invalid")
 	if err == nil {
@@ -128,3 +138,34 @@ func TestClient_Diff(t *testing.T) {
         t.Error("Diff should fail with bad SHAs")
     }
 }
+
+func TestClient_DefaultBranch(t *testing.T) {
+	localDir, remoteDir := setupTestRepo(t)
+	defer os.RemoveAll(localDir)
+	defer os.RemoveAll(remoteDir)
+
+	c := NewClient()
+	if _, err := c.DefaultBranch(localDir); err == nil {
+		t.Error("Expected an error before origin/HEAD is set")
+	}
+
+	os.WriteFile(filepath.Join(localDir, "f1"), []byte("v1"), 0644)
+	c.Commit(localDir, "commit 1")
+	if err := c.CheckoutNewBranch(localDir, "trunk"); err != nil {
+		t.Fatalf("CheckoutNewBranch failed: %v", err)
+	}
+	if err := c.Push(localDir, "trunk"); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if _, err := c.Run(localDir, "remote", "set-head", "origin", "trunk"); err != nil {
+		t.Fatalf("set-head failed: %v", err)
+	}
+
+	branch, err := c.DefaultBranch(localDir)
+	if err != nil {
+		t.Fatalf("DefaultBranch failed: %v", err)
+	}
+	if branch != "trunk" {
+		t.Errorf("Expected trunk, got %q", branch)
+	}
+}
@@ -0,0 +1,48 @@
+package git
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultCommitTemplate is the commit message template used when git.commit_template is not configured.
+const DefaultCommitTemplate = "feat: implemented features for {project}"
+
+// CommitVars holds the values substituted into a commit message template.
+type CommitVars struct {
+	Project  string
+	TicketID string
+	Summary  string
+}
+
+// CommitMessage renders the git.commit_template config value.
+// Supported placeholders are {project}, {ticket} and {summary}. Conventional Commits
+// prefixes (e.g. "fix({ticket}): {summary}") are kept as written; an empty scope is dropped.
+func CommitMessage(vars CommitVars) string {
+	tmpl := viper.GetString("git.commit_template")
+	if tmpl == "" {
+		tmpl = DefaultCommitTemplate
+	}
+
+	// Only the first line of the summary belongs in the subject
+	summary := strings.TrimSpace(vars.Summary)
+	if idx := strings.IndexByte(summary, '\n'); idx >= 0 {
+		summary = strings.TrimSpace(summary[:idx])
+	}
+
+	r := strings.NewReplacer(
+		"{project}", vars.Project,
+		"{ticket}", vars.TicketID,
+		"{summary}", summary,
+	)
+	msg := r.Replace(tmpl)
+
+	// "feat(): ..." is not a valid Conventional Commit, so drop empty scopes
+	msg = strings.Replace(msg, "():", ":", 1)
+	msg = strings.TrimSpace(msg)
+	if msg == "" {
+		return r.Replace(DefaultCommitTemplate)
+	}
+	return msg
+}
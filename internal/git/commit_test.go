@@ -0,0 +1,33 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitMessage(t *testing.T) {
+	defer viper.Set("git.commit_template", "")
+
+	vars := CommitVars{Project: "demo", TicketID: "RECAC-7", Summary: "Add login page\nwith details"}
+
+	tests := []struct {
+		name     string
+		template string
+		vars     CommitVars
+		expected string
+	}{
+		{"Default", "", vars, "feat: implemented features for demo"},
+		{"Conventional scope", "feat({ticket}): {summary}", vars, "feat(RECAC-7): Add login page"},
+		{"Empty scope dropped", "fix({ticket}): {summary}", CommitVars{Project: "demo", Summary: "typo"}, "fix: typo"},
+		{"Body with refs", "chore: {summary}\n\nRefs: {ticket}", vars, "chore: Add login page\n\nRefs: RECAC-7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("git.commit_template", tt.template)
+			assert.Equal(t, tt.expected, CommitMessage(tt.vars))
+		})
+	}
+}
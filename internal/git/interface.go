@@ -44,4 +44,7 @@ type IClient interface {
 	LatestTag(directory string) (string, error)
 	Run(directory string, args ...string) (string, error)
 	CreatePR(directory, title, body, base string) (string, error)
+	AddWorktree(directory, path, branch string) error
+	RemoveWorktree(directory, path string) error
+	DefaultBranch(directory string) (string, error)
 }
@@ -0,0 +1,275 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"recac/internal/git"
+)
+
+// tokenRefreshMargin is how long before expiry an installation token is
+// replaced, so a request never goes out with a token about to lapse.
+const tokenRefreshMargin = 5 * time.Minute
+
+// App mints short-lived installation tokens for a GitHub App installation.
+// Installation tokens last an hour; App caches one and mints a new one
+// shortly before it expires. It is safe for concurrent use.
+type App struct {
+	BaseURL        string
+	AppID          int64
+	InstallationID int64
+	HTTPClient     *http.Client
+
+	key *rsa.PrivateKey
+	now func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewApp creates an App from its ID, the installation ID and the PEM-encoded
+// private key downloaded from the app's settings.
+func NewApp(appID, installationID int64, privateKeyPEM []byte) (*App, error) {
+	if appID == 0 || installationID == 0 {
+		return nil, fmt.Errorf("GitHub App auth requires both an app ID and an installation ID")
+	}
+	key, err := parsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return &App{
+		BaseURL:        DefaultBaseURL,
+		AppID:          appID,
+		InstallationID: installationID,
+		HTTPClient:     &http.Client{Timeout: 10 * time.Second},
+		key:            key,
+		now:            time.Now,
+	}, nil
+}
+
+// LoadApp is NewApp with the private key read from keyPath.
+func LoadApp(appID, installationID int64, keyPath string) (*App, error) {
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+	}
+	return NewApp(appID, installationID, data)
+}
+
+var (
+	sharedAppMu  sync.Mutex
+	sharedApp    *App
+	sharedAppKey string
+)
+
+// SharedApp returns the App for appID, installationID and the private key at
+// keyPath, or nil when appID is 0. The App is shared within the process so its
+// installation token is reused until it nears expiry.
+func SharedApp(appID, installationID int64, keyPath string) (*App, error) {
+	if appID == 0 {
+		return nil, nil
+	}
+	if keyPath == "" {
+		return nil, fmt.Errorf("github.private_key_path is required for GitHub App auth")
+	}
+
+	sharedAppMu.Lock()
+	defer sharedAppMu.Unlock()
+	cacheKey := fmt.Sprintf("%d/%d/%s", appID, installationID, keyPath)
+	if sharedApp != nil && sharedAppKey == cacheKey {
+		return sharedApp, nil
+	}
+	app, err := LoadApp(appID, installationID, keyPath)
+	if err != nil {
+		return nil, err
+	}
+	sharedApp, sharedAppKey = app, cacheKey
+	return app, nil
+}
+
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid GitHub App private key: no PEM block found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("invalid GitHub App private key: not an RSA key")
+	}
+	return key, nil
+}
+
+// Token returns a valid installation token, minting a new one when the
+// cached token is missing or about to expire.
+func (a *App) Token(ctx context.Context) (string, error) {
+	return a.TokenValidFor(ctx, tokenRefreshMargin)
+}
+
+// TokenValidFor is Token for a caller that holds on to the token: the cached
+// token is only returned if it stays valid for at least d, otherwise a new one
+// is minted. A fresh installation token lasts an hour.
+func (a *App) TokenValidFor(ctx context.Context, d time.Duration) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && a.now().Add(d).Before(a.expires) {
+		return a.token, nil
+	}
+
+	jwt, err := a.jwt()
+	if err != nil {
+		return "", err
+	}
+	endpoint := fmt.Sprintf("%s/app/installations/%d/access_tokens", a.BaseURL, a.InstallationID)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "recac")
+
+	httpClient := a.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create installation token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to create installation token: %d", resp.StatusCode)
+	}
+	var out struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("failed to decode installation token: %w", err)
+	}
+	if out.Token == "" {
+		return "", errors.New("GitHub returned an empty installation token")
+	}
+	a.token, a.expires = out.Token, out.ExpiresAt
+	return a.token, nil
+}
+
+// jwt signs the short-lived RS256 token that authenticates as the app
+// itself. It is backdated a minute to allow for clock drift.
+func (a *App) jwt() (string, error) {
+	now := a.now()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]int64{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": a.AppID,
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	sum := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// Transport returns a RoundTripper that authenticates every request with a
+// current installation token, replacing any Authorization header. A nil
+// base uses http.DefaultTransport.
+func (a *App) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &appTransport{app: a, base: base}
+}
+
+type appTransport struct {
+	app  *App
+	base http.RoundTripper
+}
+
+func (t *appTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.app.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "token "+token)
+	return t.base.RoundTrip(req)
+}
+
+// Authorize makes client authenticate as the app installation.
+func (a *App) Authorize(client *http.Client) {
+	client.Transport = a.Transport(client.Transport)
+}
+
+// CloneURL returns repoURL with an installation token embedded, for git
+// operations over HTTPS.
+func (a *App) CloneURL(ctx context.Context, repoURL string) (string, error) {
+	token, err := a.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	return InsertToken(repoURL, token), nil
+}
+
+// RefreshRemote replaces the installation token embedded in the origin remote
+// of dir with a current one, so pushes keep working after the token used for
+// the clone expires. Remotes that are not HTTPS GitHub URLs are left alone.
+func (a *App) RefreshRemote(ctx context.Context, gitClient git.IClient, dir string) error {
+	remote, err := gitClient.Run(dir, "remote", "get-url", "origin")
+	if err != nil {
+		return err
+	}
+	remote = strings.TrimSpace(remote)
+	if !strings.HasPrefix(remote, "https://") || !strings.Contains(remote, "github.com/") {
+		return nil
+	}
+	authURL, err := a.CloneURL(ctx, remote)
+	if err != nil {
+		return fmt.Errorf("failed to refresh GitHub App token: %w", err)
+	}
+	return gitClient.SetRemoteURL(dir, "origin", authURL)
+}
+
+// InsertToken embeds token in an https://github.com/ URL the way git expects
+// installation tokens (x-access-token:<token>@), replacing any credentials
+// already in it. Other URLs are returned as is.
+func InsertToken(repoURL, token string) string {
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Scheme != "https" || u.Host != "github.com" {
+		return repoURL
+	}
+	u.User = url.UserPassword("x-access-token", token)
+	return u.String()
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"recac/internal/git"
+)
+
+// ChecksClient reads CI results for commits in a single GitHub repository.
+type ChecksClient struct {
+	BaseURL    string
+	Token      string
+	Owner      string
+	Repo       string
+	HTTPClient *http.Client
+}
+
+// NewChecksClient creates a client for commit checks in owner/repo.
+func NewChecksClient(token, owner, repo string) *ChecksClient {
+	return &ChecksClient{
+		BaseURL: DefaultBaseURL,
+		Token:   token,
+		Owner:   owner,
+		Repo:    repo,
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// CommitChecks returns the state of every check run and commit status reported
+// for ref, keyed by check run name or status context. States are
+// git.CheckSuccess, git.CheckFailure or git.CheckPending.
+func (c *ChecksClient) CommitChecks(ctx context.Context, ref string) (map[string]string, error) {
+	var runs struct {
+		CheckRuns []struct {
+			Name       string `json:"name"`
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s/check-runs?per_page=100", c.Owner, c.Repo, ref), &runs); err != nil {
+		return nil, fmt.Errorf("failed to list check runs: %w", err)
+	}
+
+	var combined struct {
+		Statuses []struct {
+			Context string `json:"context"`
+			State   string `json:"state"`
+		} `json:"statuses"`
+	}
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s/status?per_page=100", c.Owner, c.Repo, ref), &combined); err != nil {
+		return nil, fmt.Errorf("failed to get commit status: %w", err)
+	}
+
+	checks := make(map[string]string)
+	for _, run := range runs.CheckRuns {
+		git.MergeCheckState(checks, run.Name, checkRunState(run.Status, run.Conclusion))
+	}
+	for _, status := range combined.Statuses {
+		git.MergeCheckState(checks, status.Context, commitStatusState(status.State))
+	}
+	return checks, nil
+}
+
+func checkRunState(status, conclusion string) string {
+	if status != "completed" {
+		return git.CheckPending
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return git.CheckSuccess
+	default:
+		return git.CheckFailure
+	}
+}
+
+func commitStatusState(state string) string {
+	switch state {
+	case "success":
+		return git.CheckSuccess
+	case "pending":
+		return git.CheckPending
+	default:
+		return git.CheckFailure
+	}
+}
+
+func (c *ChecksClient) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+c.Token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "recac")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
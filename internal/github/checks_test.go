@@ -0,0 +1,52 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"recac/internal/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksClient_CommitChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/repos/owner/repo/commits/abc123/check-runs":
+			_, _ = w.Write([]byte(`{"check_runs": [
+				{"name": "build", "status": "completed", "conclusion": "success"},
+				{"name": "test", "status": "in_progress", "conclusion": null},
+				{"name": "lint", "status": "completed", "conclusion": "failure"},
+				{"name": "docs", "status": "completed", "conclusion": "skipped"}
+			]}`))
+		case "/repos/owner/repo/commits/abc123/status":
+			_, _ = w.Write([]byte(`{"statuses": [
+				{"context": "ci/legacy", "state": "success"},
+				{"context": "build", "state": "error"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewChecksClient("secret", "owner", "repo")
+	c.BaseURL = server.URL
+
+	checks, err := c.CommitChecks(context.Background(), "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"build":     git.CheckFailure,
+		"test":      git.CheckPending,
+		"lint":      git.CheckFailure,
+		"docs":      git.CheckSuccess,
+		"ci/legacy": git.CheckSuccess,
+	}, checks)
+
+	_, err = c.CommitChecks(context.Background(), "missing")
+	assert.Error(t, err)
+}
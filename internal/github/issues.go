@@ -0,0 +1,104 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the public GitHub REST API endpoint.
+const DefaultBaseURL = "https://api.github.com"
+
+// IssueClient comments on and closes issues in a single GitHub repository.
+type IssueClient struct {
+	BaseURL    string
+	Token      string
+	Owner      string
+	Repo       string
+	HTTPClient *http.Client
+}
+
+// NewIssueClient creates a client for issues in owner/repo.
+func NewIssueClient(token, owner, repo string) *IssueClient {
+	return &IssueClient{
+		BaseURL: DefaultBaseURL,
+		Token:   token,
+		Owner:   owner,
+		Repo:    repo,
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// ParseRepo splits an "owner/repo" string.
+func ParseRepo(fullName string) (string, string, error) {
+	owner, repo, ok := strings.Cut(strings.Trim(fullName, "/"), "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", fmt.Errorf("invalid GitHub repository %q: expected owner/repo", fullName)
+	}
+	return owner, repo, nil
+}
+
+// IssueURL returns the web URL of issue number.
+func (c *IssueClient) IssueURL(number int) string {
+	return fmt.Sprintf("https://github.com/%s/%s/issues/%d", c.Owner, c.Repo, number)
+}
+
+// AddComment posts a comment on issue number.
+func (c *IssueClient) AddComment(ctx context.Context, number int, body string) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", c.BaseURL, c.Owner, c.Repo, number)
+
+	resp, err := c.do(ctx, "POST", url, map[string]string{"body": body})
+	if err != nil {
+		return fmt.Errorf("failed to post comment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to post comment: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// CloseIssue closes issue number.
+func (c *IssueClient) CloseIssue(ctx context.Context, number int) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d", c.BaseURL, c.Owner, c.Repo, number)
+
+	resp, err := c.do(ctx, "PATCH", url, map[string]string{"state": "closed"})
+	if err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to close issue: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *IssueClient) do(ctx context.Context, method, url string, payload interface{}) (*http.Response, error) {
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "token "+c.Token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "recac")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return httpClient.Do(req)
+}
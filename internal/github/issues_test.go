@@ -0,0 +1,56 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueClient_AddCommentAndClose(t *testing.T) {
+	var comment, state string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		var payload map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/repos/owner/repo/issues/42/comments":
+			comment = payload["body"]
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == "PATCH" && r.URL.Path == "/repos/owner/repo/issues/42":
+			state = payload["state"]
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewIssueClient("secret", "owner", "repo")
+	c.BaseURL = server.URL
+
+	require.NoError(t, c.AddComment(context.Background(), 42, "done"))
+	require.NoError(t, c.CloseIssue(context.Background(), 42))
+	assert.Equal(t, "done", comment)
+	assert.Equal(t, "closed", state)
+
+	assert.Error(t, c.AddComment(context.Background(), 7, "missing"))
+	assert.Equal(t, "https://github.com/owner/repo/issues/42", c.IssueURL(42))
+}
+
+func TestParseRepo(t *testing.T) {
+	owner, repo, err := ParseRepo("owner/repo")
+	require.NoError(t, err)
+	assert.Equal(t, "owner", owner)
+	assert.Equal(t, "repo", repo)
+
+	for _, invalid := range []string{"", "owner", "owner/", "a/b/c"} {
+		_, _, err := ParseRepo(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
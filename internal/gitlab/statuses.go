@@ -0,0 +1,93 @@
+// Package gitlab contains a minimal GitLab REST API client.
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"recac/internal/git"
+)
+
+// DefaultBaseURL is the gitlab.com REST API endpoint.
+const DefaultBaseURL = "https://gitlab.com/api/v4"
+
+// StatusClient reads CI results for commits in a single GitLab project.
+type StatusClient struct {
+	BaseURL    string
+	Token      string
+	Project    string // Project path, e.g. "group/subgroup/repo"
+	HTTPClient *http.Client
+}
+
+// NewStatusClient creates a client for commit statuses in project.
+func NewStatusClient(token, project string) *StatusClient {
+	return &StatusClient{
+		BaseURL: DefaultBaseURL,
+		Token:   token,
+		Project: project,
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// CommitChecks returns the state of every job status reported for ref, keyed
+// by job name. States are git.CheckSuccess, git.CheckFailure or
+// git.CheckPending; failed jobs that are allowed to fail count as successes.
+func (c *StatusClient) CommitChecks(ctx context.Context, ref string) (map[string]string, error) {
+	u := fmt.Sprintf("%s/projects/%s/repository/commits/%s/statuses?per_page=100", c.BaseURL, url.PathEscape(c.Project), url.PathEscape(ref))
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.Token)
+	req.Header.Set("User-Agent", "recac")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commit statuses: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list commit statuses: %d", resp.StatusCode)
+	}
+
+	var statuses []struct {
+		Name         string `json:"name"`
+		Status       string `json:"status"`
+		AllowFailure bool   `json:"allow_failure"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&statuses); err != nil {
+		return nil, fmt.Errorf("failed to decode commit statuses: %w", err)
+	}
+
+	checks := make(map[string]string)
+	for _, status := range statuses {
+		git.MergeCheckState(checks, status.Name, statusState(status.Status, status.AllowFailure))
+	}
+	return checks, nil
+}
+
+func statusState(status string, allowFailure bool) string {
+	switch status {
+	case "success", "skipped":
+		return git.CheckSuccess
+	case "failed", "canceled":
+		if allowFailure {
+			return git.CheckSuccess
+		}
+		return git.CheckFailure
+	default:
+		// created, pending, running, manual, scheduled, ...
+		return git.CheckPending
+	}
+}
@@ -0,0 +1,46 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"recac/internal/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusClient_CommitChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+		if r.URL.EscapedPath() != "/projects/group%2Frepo/repository/commits/abc123/statuses" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"name": "build", "status": "success"},
+			{"name": "test", "status": "running"},
+			{"name": "lint", "status": "failed", "allow_failure": false},
+			{"name": "audit", "status": "failed", "allow_failure": true}
+		]`))
+	}))
+	defer server.Close()
+
+	c := NewStatusClient("secret", "group/repo")
+	c.BaseURL = server.URL
+
+	checks, err := c.CommitChecks(context.Background(), "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"build": git.CheckSuccess,
+		"test":  git.CheckPending,
+		"lint":  git.CheckFailure,
+		"audit": git.CheckSuccess,
+	}, checks)
+
+	c.Project = "other/repo"
+	_, err = c.CommitChecks(context.Background(), "abc123")
+	assert.Error(t, err)
+}
@@ -6,17 +6,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// SearchPageSize is the number of issues requested per page when searching.
+const SearchPageSize = 100
+
 // Client handles Jira API interactions.
 type Client struct {
 	BaseURL    string
 	Username   string
 	APIToken   string
 	HTTPClient *http.Client
+
+	// Transient failures (network errors, 429, 5xx) are retried up to
+	// MaxRetries times, waiting RetryBackoff and doubling on each attempt.
+	MaxRetries   int
+	RetryBackoff time.Duration
 }
 
 // NewClient creates a new Jira client.
@@ -26,8 +38,10 @@ func NewClient(baseURL, username, apiToken string) *Client {
 		Username: username,
 		APIToken: apiToken,
 		HTTPClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: DefaultTimeout,
 		},
+		MaxRetries:   DefaultMaxRetries,
+		RetryBackoff: DefaultRetryBackoff,
 	}
 }
 
@@ -43,7 +57,7 @@ func (c *Client) Authenticate(ctx context.Context) error {
 	req.SetBasicAuth(c.Username, c.APIToken)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -68,7 +82,7 @@ func (c *Client) GetTicket(ctx context.Context, ticketID string) (map[string]int
 	req.SetBasicAuth(c.Username, c.APIToken)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -110,7 +124,7 @@ func (c *Client) TransitionIssue(ctx context.Context, ticketID, transitionID str
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -162,7 +176,7 @@ func (c *Client) AddComment(ctx context.Context, ticketID, commentText string) e
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -175,6 +189,55 @@ func (c *Client) AddComment(ctx context.Context, ticketID, commentText string) e
 	return nil
 }
 
+// AddAttachment uploads the file at filePath to a Jira ticket, keeping its base name.
+func (c *Client) AddAttachment(ctx context.Context, issueKey, filePath string) error {
+	url := fmt.Sprintf("%s/rest/api/3/issue/%s/attachments", c.BaseURL, issueKey)
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open attachment: %w", err)
+	}
+	defer file.Close()
+
+	// Buffered rather than streamed, so retries can replay the body
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finish form: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.SetBasicAuth(c.Username, c.APIToken)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	// Jira rejects attachment uploads without the XSRF opt-out header
+	req.Header.Set("X-Atlassian-Token", "no-check")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to add attachment with status: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
 // DeleteIssue deletes a Jira ticket.
 func (c *Client) DeleteIssue(ctx context.Context, ticketID string) error {
 	url := fmt.Sprintf("%s/rest/api/3/issue/%s", c.BaseURL, ticketID)
@@ -186,7 +249,7 @@ func (c *Client) DeleteIssue(ctx context.Context, ticketID string) error {
 
 	req.SetBasicAuth(c.Username, c.APIToken)
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -246,7 +309,7 @@ func (c *Client) CreateTicket(ctx context.Context, projectKey, summary, descript
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -304,41 +367,74 @@ func extractTextFromADF(node map[string]interface{}) string {
 	return sb.String()
 }
 
-// SearchIssues searches for Jira tickets using JQL.
+// SearchIssues searches for Jira tickets using JQL, following pagination so that
+// all matching issues are returned.
 func (c *Client) SearchIssues(ctx context.Context, jql string) ([]map[string]interface{}, error) {
+	var issues []map[string]interface{}
+	pageToken := ""
+
+	// The /search/jql endpoint pages with an opaque nextPageToken; follow it until isLast
+	for {
+		page, next, err := c.searchIssuesPage(ctx, jql, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, page...)
+
+		if next == "" || next == pageToken || len(page) == 0 {
+			break
+		}
+		pageToken = next
+	}
+
+	return issues, nil
+}
+
+// searchIssuesPage fetches a single page of search results.
+// It returns the issues and the token for the next page ("" if this was the last page).
+func (c *Client) searchIssuesPage(ctx context.Context, jql, pageToken string) ([]map[string]interface{}, string, error) {
 	url := fmt.Sprintf("%s/rest/api/3/search/jql", c.BaseURL)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	q := req.URL.Query()
 	q.Add("jql", jql)
 	q.Add("fields", "summary,description,status,labels,issuelinks,parent")
+	q.Add("maxResults", strconv.Itoa(SearchPageSize))
+	if pageToken != "" {
+		q.Add("nextPageToken", pageToken)
+	}
 	req.URL.RawQuery = q.Encode()
 
 	req.SetBasicAuth(c.Username, c.APIToken)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, "", fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to search issues with status: %d", resp.StatusCode)
+		return nil, "", fmt.Errorf("failed to search issues with status: %d", resp.StatusCode)
 	}
 
 	var result struct {
-		Issues []map[string]interface{} `json:"issues"`
+		Issues        []map[string]interface{} `json:"issues"`
+		NextPageToken string                   `json:"nextPageToken"`
+		IsLast        bool                     `json:"isLast"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, "", fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return result.Issues, nil
+	if result.IsLast {
+		return result.Issues, "", nil
+	}
+	return result.Issues, result.NextPageToken, nil
 }
 
 // LoadLabelIssues fetches issues with a specific label.
@@ -359,7 +455,7 @@ func (c *Client) GetTransitions(ctx context.Context, ticketID string) ([]map[str
 	req.SetBasicAuth(c.Username, c.APIToken)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -483,7 +579,7 @@ func (c *Client) AddIssueLink(ctx context.Context, inwardKey, outwardKey, linkTy
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -524,7 +620,7 @@ func (c *Client) SetParent(ctx context.Context, issueKey, parentKey string) erro
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -562,7 +658,7 @@ func (c *Client) AddLabel(ctx context.Context, key, label string) error {
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -575,6 +671,56 @@ func (c *Client) AddLabel(ctx context.Context, key, label string) error {
 	return nil
 }
 
+// UpdateDescription replaces the description of an existing ticket.
+func (c *Client) UpdateDescription(ctx context.Context, key, description string) error {
+	url := fmt.Sprintf("%s/rest/api/3/issue/%s", c.BaseURL, key)
+	payload := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"description": map[string]interface{}{
+				"type":    "doc",
+				"version": 1,
+				"content": []map[string]interface{}{
+					{
+						"type": "paragraph",
+						"content": []map[string]interface{}{
+							{
+								"type": "text",
+								"text": description,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.SetBasicAuth(c.Username, c.APIToken)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update description with status: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
 func isDoneStatus(status string) bool {
 	doneStatuses := []string{"Done", "Closed", "Resolved", "Finished", "Passed"}
 	for _, s := range doneStatuses {
@@ -595,7 +741,7 @@ func (c *Client) GetFirstProjectKey(ctx context.Context) (string, error) {
 	req.SetBasicAuth(c.Username, c.APIToken)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to execute request: %w", err)
 	}
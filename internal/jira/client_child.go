@@ -58,7 +58,7 @@ func (c *Client) CreateChildTicket(ctx context.Context, projectKey, summary, des
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to execute request: %w", err)
 	}
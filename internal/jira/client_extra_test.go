@@ -3,9 +3,14 @@ package jira
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestCreateTicket_Success(t *testing.T) {
@@ -29,6 +34,29 @@ func TestCreateTicket_Success(t *testing.T) {
 	}
 }
 
+func TestUpdateDescription_Success(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/PROJ-1" || r.Method != "PUT" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		got = (&Client{}).ParseDescription(map[string]interface{}{"fields": payload["fields"]})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "token")
+	if err := client.UpdateDescription(context.Background(), "PROJ-1", "New description"); err != nil {
+		t.Fatalf("UpdateDescription failed: %v", err)
+	}
+	if strings.TrimSpace(got) != "New description" {
+		t.Errorf("Expected description to be sent as ADF, got %q", got)
+	}
+}
+
 func TestDeleteIssue_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/rest/api/3/issue/PROJ-123" || r.Method != "DELETE" {
@@ -275,3 +303,99 @@ func TestGetBlockers(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchIssues_Pagination(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("nextPageToken")
+		tokens = append(tokens, token)
+		if r.URL.Query().Get("maxResults") == "" {
+			t.Error("Expected maxResults to be set")
+		}
+		w.WriteHeader(http.StatusOK)
+		switch token {
+		case "":
+			w.Write([]byte(`{"issues": [{"key": "PROJ-1"}, {"key": "PROJ-2"}], "nextPageToken": "page-2", "isLast": false}`))
+		case "page-2":
+			w.Write([]byte(`{"issues": [{"key": "PROJ-3"}], "isLast": true}`))
+		default:
+			t.Errorf("Unexpected page token %q", token)
+			w.Write([]byte(`{"issues": [], "isLast": true}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "token")
+	issues, err := client.SearchIssues(context.Background(), "project = PROJ")
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("Expected 3 issues across pages, got %d", len(issues))
+	}
+	if issues[2]["key"] != "PROJ-3" {
+		t.Errorf("Expected last issue PROJ-3, got %v", issues[2]["key"])
+	}
+	if len(tokens) != 2 {
+		t.Errorf("Expected 2 page requests, got %d", len(tokens))
+	}
+}
+
+func TestAddAttachment_Success(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coverage.out")
+	if err := os.WriteFile(path, []byte("mode: set"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	attempts := 0
+	var name, content string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/PROJ-1/attachments" || r.Method != "POST" || r.Header.Get("X-Atlassian-Token") != "no-check" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// The first attempt fails transiently, so the body must be replayed
+		if attempts++; attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		name, content = header.Filename, string(data)
+		w.Write([]byte(`[{"filename": "coverage.out"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "token")
+	client.RetryBackoff = time.Millisecond
+	if err := client.AddAttachment(context.Background(), "PROJ-1", path); err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+	if name != "coverage.out" || content != "mode: set" {
+		t.Errorf("unexpected upload %q: %q", name, content)
+	}
+}
+
+func TestAddAttachment_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "token")
+	if err := client.AddAttachment(context.Background(), "PROJ-1", filepath.Join(t.TempDir(), "missing.log")); err == nil || !strings.Contains(err.Error(), "failed to open attachment") {
+		t.Errorf("expected open error, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "build.log")
+	if err := os.WriteFile(path, []byte("log"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddAttachment(context.Background(), "PROJ-1", path); err == nil || !strings.Contains(err.Error(), "status: 413") {
+		t.Errorf("expected status error, got %v", err)
+	}
+}
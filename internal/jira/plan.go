@@ -0,0 +1,133 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PendingChildrenHeader starts the block at the end of a ticket description
+// that holds children whose creation was deferred by
+// `recac jira generate-from-spec --incremental`.
+const PendingChildrenHeader = "RECAC PENDING CHILDREN:"
+
+// TicketPlan is a ticket that has not been created yet. Description is
+// final: acceptance criteria and the repository are already folded in.
+type TicketPlan struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Type        string       `json:"type,omitempty"`
+	BlockedBy   []string     `json:"blocked_by,omitempty"`
+	Children    []TicketPlan `json:"children,omitempty"`
+}
+
+// AppendPendingChildren appends children to description as a pending block.
+func AppendPendingChildren(description string, children []TicketPlan) (string, error) {
+	data, err := json.Marshal(children)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode pending children: %w", err)
+	}
+	return fmt.Sprintf("%s\n\n%s\n%s", strings.TrimRight(description, "\n"), PendingChildrenHeader, data), nil
+}
+
+// SplitPendingChildren separates the pending block from description. It
+// returns the description unchanged and no children when there is none.
+func SplitPendingChildren(description string) (string, []TicketPlan, error) {
+	idx := strings.Index(description, PendingChildrenHeader)
+	if idx < 0 {
+		return description, nil, nil
+	}
+
+	var children []TicketPlan
+	raw := strings.TrimSpace(description[idx+len(PendingChildrenHeader):])
+	if err := json.Unmarshal([]byte(raw), &children); err != nil {
+		return description, nil, fmt.Errorf("failed to parse pending children: %w", err)
+	}
+	return strings.TrimRight(description[:idx], "\n "), children, nil
+}
+
+// planCreator is the subset of the client needed to expand pending children.
+type planCreator interface {
+	CreateChildTicket(ctx context.Context, projectKey, summary, description, issueType, parentKey string, labels []string) (string, error)
+	AddIssueLink(ctx context.Context, inwardKey, outwardKey, linkType string) error
+	UpdateDescription(ctx context.Context, key, description string) error
+}
+
+// ExpandPendingChildren creates the children deferred in issue's description,
+// then removes the pending block so they are created only once. Children
+// inherit the issue's project and labels, so a poller filtering on a label
+// picks them up. It returns the keys of the created tickets.
+func (c *Client) ExpandPendingChildren(ctx context.Context, issue map[string]interface{}) ([]string, error) {
+	key, _ := issue["key"].(string)
+	fields, _ := issue["fields"].(map[string]interface{})
+
+	projectKey, _, _ := strings.Cut(key, "-")
+	if project, ok := fields["project"].(map[string]interface{}); ok {
+		if k, ok := project["key"].(string); ok && k != "" {
+			projectKey = k
+		}
+	}
+
+	var labels []string
+	if rawLabels, ok := fields["labels"].([]interface{}); ok {
+		for _, l := range rawLabels {
+			if label, ok := l.(string); ok {
+				labels = append(labels, label)
+			}
+		}
+	}
+
+	return expandPendingChildren(ctx, c, key, projectKey, c.ParseDescription(issue), labels)
+}
+
+func expandPendingChildren(ctx context.Context, client planCreator, key, projectKey, description string, labels []string) ([]string, error) {
+	rest, children, err := SplitPendingChildren(description)
+	if err != nil || len(children) == 0 {
+		return nil, err
+	}
+
+	var created []string
+	titleToKey := make(map[string]string)
+	var create func(parentKey string, nodes []TicketPlan) error
+	create = func(parentKey string, nodes []TicketPlan) error {
+		for _, node := range nodes {
+			issueType := node.Type
+			if issueType == "" {
+				issueType = "Story"
+			}
+			childKey, err := client.CreateChildTicket(ctx, projectKey, node.Title, node.Description, issueType, parentKey, labels)
+			if err != nil {
+				return fmt.Errorf("failed to create '%s' under %s: %w", node.Title, parentKey, err)
+			}
+			created = append(created, childKey)
+			titleToKey[node.Title] = childKey
+			if err := create(childKey, node.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := create(key, children); err != nil {
+		return created, err
+	}
+
+	// Blockers can only be resolved among the tickets created here
+	var link func(nodes []TicketPlan)
+	link = func(nodes []TicketPlan) {
+		for _, node := range nodes {
+			for _, blocker := range node.BlockedBy {
+				if blockerKey, ok := titleToKey[blocker]; ok {
+					_ = client.AddIssueLink(ctx, blockerKey, titleToKey[node.Title], "Blocks")
+				}
+			}
+			link(node.Children)
+		}
+	}
+	link(children)
+
+	if err := client.UpdateDescription(ctx, key, rest); err != nil {
+		return created, fmt.Errorf("created children of %s but failed to clear its pending block: %w", key, err)
+	}
+	return created, nil
+}
@@ -0,0 +1,74 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPlanCreator struct {
+	created     []string // "parent/summary/type"
+	links       []string
+	description string
+}
+
+func (r *recordingPlanCreator) CreateChildTicket(ctx context.Context, projectKey, summary, description, issueType, parentKey string, labels []string) (string, error) {
+	r.created = append(r.created, fmt.Sprintf("%s/%s/%s", parentKey, summary, issueType))
+	return fmt.Sprintf("%s-%d", projectKey, 10+len(r.created)), nil
+}
+
+func (r *recordingPlanCreator) AddIssueLink(ctx context.Context, inwardKey, outwardKey, linkType string) error {
+	r.links = append(r.links, inwardKey+" "+linkType+" "+outwardKey)
+	return nil
+}
+
+func (r *recordingPlanCreator) UpdateDescription(ctx context.Context, key, description string) error {
+	r.description = description
+	return nil
+}
+
+func TestPendingChildren_RoundTrip(t *testing.T) {
+	children := []TicketPlan{{Title: "Story", Description: "Repo: https://github.com/org/repo", Children: []TicketPlan{{Title: "Sub", Type: "Subtask"}}}}
+	desc, err := AppendPendingChildren("Epic body\n", children)
+	require.NoError(t, err)
+
+	rest, got, err := SplitPendingChildren(desc + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, "Epic body", rest)
+	assert.Equal(t, children, got)
+
+	rest, got, err = SplitPendingChildren("No block")
+	require.NoError(t, err)
+	assert.Equal(t, "No block", rest)
+	assert.Nil(t, got)
+
+	_, _, err = SplitPendingChildren("Body\n" + PendingChildrenHeader + "\n{broken")
+	assert.Error(t, err)
+}
+
+func TestExpandPendingChildren(t *testing.T) {
+	desc, err := AppendPendingChildren("Epic body", []TicketPlan{
+		{Title: "A", Children: []TicketPlan{{Title: "A1", Type: "Subtask"}}},
+		{Title: "B", Type: "Task", BlockedBy: []string{"A", "Elsewhere"}},
+	})
+	require.NoError(t, err)
+
+	creator := &recordingPlanCreator{}
+	keys, err := expandPendingChildren(context.Background(), creator, "PROJ-1", "PROJ", desc, []string{"recac-agent"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"PROJ-11", "PROJ-12", "PROJ-13"}, keys)
+	assert.Equal(t, []string{"PROJ-1/A/Story", "PROJ-11/A1/Subtask", "PROJ-1/B/Task"}, creator.created)
+	assert.Equal(t, []string{"PROJ-11 Blocks PROJ-13"}, creator.links)
+	assert.Equal(t, "Epic body", creator.description)
+
+	// Nothing to do without a pending block
+	creator = &recordingPlanCreator{}
+	keys, err = expandPendingChildren(context.Background(), creator, "PROJ-1", "PROJ", "Epic body", nil)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+	assert.Empty(t, creator.created)
+}
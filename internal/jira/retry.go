@@ -0,0 +1,81 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultTimeout is the per-request timeout of a new Client.
+	DefaultTimeout = 10 * time.Second
+	// DefaultMaxRetries is how many times a new Client retries a failed request.
+	DefaultMaxRetries = 3
+	// DefaultRetryBackoff is the delay before the first retry; it doubles on each attempt.
+	DefaultRetryBackoff = 500 * time.Millisecond
+
+	// maxRetryDelay caps both the backoff and a server's Retry-After.
+	maxRetryDelay = time.Minute
+)
+
+// do sends req, retrying network errors, 429 and 5xx responses up to
+// c.MaxRetries times with exponential backoff. A Retry-After header on the
+// response takes precedence over the backoff.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	backoff := c.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.HTTPClient.Do(req)
+		if attempt >= c.MaxRetries || !shouldRetry(req.Context(), resp, err) {
+			return resp, err
+		}
+
+		wait := backoff
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = retryAfter
+			}
+			resp.Body.Close()
+		}
+		wait = min(wait, maxRetryDelay)
+
+		// Replay the body; requests built from a buffer or reader support this.
+		if req.Body != nil && req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// shouldRetry reports whether a request failed transiently.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,95 @@
+package jira
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_RetriesTransientFailures(t *testing.T) {
+	var calls int
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"key": "PROJ-1"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "token")
+	client.RetryBackoff = time.Millisecond
+
+	key, err := client.CreateTicket(context.Background(), "PROJ", "Summary", "Desc", "Task", nil)
+	if err != nil {
+		t.Fatalf("expected retries to succeed, got %v", err)
+	}
+	if key != "PROJ-1" || calls != 3 {
+		t.Errorf("expected PROJ-1 after 3 calls, got %q after %d", key, calls)
+	}
+	if bodies[0] == "" || bodies[2] != bodies[0] {
+		t.Errorf("expected the request body to be replayed, got %q", bodies)
+	}
+}
+
+func TestClient_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "token")
+	client.MaxRetries = 2
+	client.RetryBackoff = time.Millisecond
+
+	if _, err := client.GetTicket(context.Background(), "PROJ-1"); err == nil {
+		t.Fatal("expected error after retries are exhausted")
+	}
+	if calls != 3 {
+		t.Errorf("expected 1 attempt and 2 retries, got %d calls", calls)
+	}
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "token")
+	client.RetryBackoff = time.Millisecond
+
+	client.GetTicket(context.Background(), "PROJ-1")
+	if calls != 1 {
+		t.Errorf("expected no retries for 404, got %d calls", calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("5"); !ok || d != 5*time.Second {
+		t.Errorf("expected 5s, got %v %v", d, ok)
+	}
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(future); !ok || d < 59*time.Minute {
+		t.Errorf("expected about an hour, got %v %v", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("expected invalid header to be ignored")
+	}
+}
@@ -21,6 +21,8 @@ type UnifiedSession struct {
 	CPU          string
 	Memory       string
 	Logs         string
+	Tags         []string
+	Churn        string // "+N/-N" lines changed since the session's start commit
 }
 
 // PsFilters holds the filter values for the ps command.
@@ -28,6 +30,8 @@ type PsFilters struct {
 	Status   string
 	Since    string
 	Stale    string
+	Tag      string
 	Remote   bool
 	LogLines int
+	Diff     bool
 }
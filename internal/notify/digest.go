@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Digest summarizes a finished session for the end-of-session notification.
+type Digest struct {
+	Project    string
+	Status     string // Final status, e.g. "completed" or "failed: agent call failed"
+	Iterations int
+	Passing    int
+	Total      int
+	Tokens     int
+	Cost       float64
+	Duration   time.Duration
+}
+
+// String formats the digest as a short multi-line wrap-up.
+func (d Digest) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Session summary for %s: %s\n", d.Project, d.Status)
+	fmt.Fprintf(&b, "Iterations: %d\n", d.Iterations)
+	if d.Total > 0 {
+		fmt.Fprintf(&b, "Features: %d/%d passing (%d%%)\n", d.Passing, d.Total, d.Passing*100/d.Total)
+	}
+	if d.Tokens > 0 {
+		fmt.Fprintf(&b, "Cost: $%.4f (%d tokens)\n", d.Cost, d.Tokens)
+	}
+	fmt.Fprintf(&b, "Duration: %s", d.Duration.Round(time.Second))
+	return b.String()
+}
+
+// NotifyDigest posts the end-of-session digest in the session thread. It is
+// sent once per session, so it bypasses deduplication.
+func (m *Manager) NotifyDigest(ctx context.Context, d Digest, threadStateStr string) (string, error) {
+	return m.ForceNotify(ctx, EventDigest, d.String(), threadStateStr)
+}
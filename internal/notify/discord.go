@@ -6,14 +6,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 )
 
+// DiscordAPIBaseURL is the Discord REST API used by the Bot API.
+const DiscordAPIBaseURL = "https://discord.com/api/v10"
+
 // DiscordNotifier sends notifications to Discord via Webhook or Bot API.
 type DiscordNotifier struct {
 	WebhookURL string
 	BotToken   string
 	ChannelID  string
+	APIBaseURL string // Defaults to DiscordAPIBaseURL
 	Client     *http.Client
 }
 
@@ -88,7 +93,7 @@ func (n *DiscordNotifier) sendWebhookMessage(ctx context.Context, message string
 }
 
 func (n *DiscordNotifier) sendBotMessage(ctx context.Context, message, replyToID string) (string, error) {
-	url := fmt.Sprintf("https://discord.com/api/v10/channels/%s/messages", n.ChannelID)
+	url := fmt.Sprintf("%s/channels/%s/messages", n.apiBaseURL(), n.ChannelID)
 
 	payload := map[string]interface{}{
 		"content": message,
@@ -146,7 +151,7 @@ func (n *DiscordNotifier) AddReaction(ctx context.Context, messageID, reaction s
 	// Map common Slack emojis to Discord equivalents
 	reaction = mapEmoji(reaction)
 
-	url := fmt.Sprintf("https://discord.com/api/v10/channels/%s/messages/%s/reactions/%s/@me", n.ChannelID, messageID, reaction)
+	url := fmt.Sprintf("%s/channels/%s/messages/%s/reactions/%s/@me", n.apiBaseURL(), n.ChannelID, messageID, reaction)
 
 	req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
 	if err != nil {
@@ -169,6 +174,81 @@ func (n *DiscordNotifier) AddReaction(ctx context.Context, messageID, reaction s
 	return nil
 }
 
+// StartThread creates a public thread from a channel message and returns the
+// thread's channel ID.
+func (n *DiscordNotifier) StartThread(ctx context.Context, messageID, name string) (string, error) {
+	if n.BotToken == "" || n.ChannelID == "" {
+		return "", fmt.Errorf("bot token and channel id required for threads")
+	}
+	if name = strings.TrimSpace(name); name == "" {
+		name = "recac session"
+	}
+	if len(name) > 100 {
+		name = name[:100]
+	}
+
+	url := fmt.Sprintf("%s/channels/%s/messages/%s/threads", n.apiBaseURL(), n.ChannelID, messageID)
+	var thread struct {
+		ID string `json:"id"`
+	}
+	payload := map[string]interface{}{"name": name, "auto_archive_duration": 1440}
+	if err := n.doJSON(ctx, "POST", url, payload, &thread); err != nil {
+		return "", fmt.Errorf("failed to create discord thread: %w", err)
+	}
+	return thread.ID, nil
+}
+
+// SendToThread posts a message into a thread created by StartThread.
+func (n *DiscordNotifier) SendToThread(ctx context.Context, threadID, message string) (string, error) {
+	thread := *n
+	thread.ChannelID = threadID
+	return thread.sendBotMessage(ctx, message, "")
+}
+
+func (n *DiscordNotifier) doJSON(ctx context.Context, method, url string, in, out interface{}) error {
+	body := new(bytes.Buffer)
+	if in != nil {
+		if err := json.NewEncoder(body).Encode(in); err != nil {
+			return fmt.Errorf("failed to marshal discord payload: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create discord request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.BotToken != "" {
+		req.Header.Set("Authorization", "Bot "+n.BotToken)
+	}
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("discord request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respErr, _ := createResponseError(resp)
+		return respErr
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
+
+func (n *DiscordNotifier) apiBaseURL() string {
+	if n.APIBaseURL != "" {
+		return n.APIBaseURL
+	}
+	return DiscordAPIBaseURL
+}
+
 func mapEmoji(slackEmoji string) string {
 	switch slackEmoji {
 	case "white_check_mark", ":white_check_mark:":
@@ -0,0 +1,307 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// DiscordGatewayURL is the Discord Gateway websocket endpoint.
+const DiscordGatewayURL = "wss://gateway.discord.gg/?v=10&encoding=json"
+
+// Discord Gateway opcodes used by DiscordGateway.
+const (
+	gatewayOpDispatch       = 0
+	gatewayOpHeartbeat      = 1
+	gatewayOpIdentify       = 2
+	gatewayOpReconnect      = 7
+	gatewayOpInvalidSession = 9
+	gatewayOpHello          = 10
+	gatewayOpHeartbeatACK   = 11
+)
+
+// interactionTypeCommand is the interaction type of an application (slash) command.
+const interactionTypeCommand = 2
+
+type gatewayPayload struct {
+	Op   int             `json:"op"`
+	Data json.RawMessage `json:"d,omitempty"`
+	Seq  *int64          `json:"s,omitempty"`
+	Type string          `json:"t,omitempty"`
+}
+
+// DiscordUser is the author of an interaction.
+type DiscordUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// DiscordInteraction is an INTERACTION_CREATE event received over the Gateway.
+type DiscordInteraction struct {
+	ID            string `json:"id"`
+	ApplicationID string `json:"application_id"`
+	Type          int    `json:"type"`
+	Token         string `json:"token"`
+	ChannelID     string `json:"channel_id"`
+	GuildID       string `json:"guild_id,omitempty"`
+	Data          struct {
+		Name    string `json:"name"`
+		Options []struct {
+			Name  string      `json:"name"`
+			Value interface{} `json:"value"`
+		} `json:"options"`
+	} `json:"data"`
+	Member *struct {
+		User  DiscordUser `json:"user"`
+		Roles []string    `json:"roles"`
+	} `json:"member,omitempty"`
+	User *DiscordUser `json:"user,omitempty"`
+}
+
+// IsCommand reports whether the interaction is the named slash command.
+func (i DiscordInteraction) IsCommand(name string) bool {
+	return i.Type == interactionTypeCommand && i.Data.Name == name
+}
+
+// Option returns the string value of a slash command option.
+func (i DiscordInteraction) Option(name string) string {
+	for _, opt := range i.Data.Options {
+		if opt.Name == name {
+			if s, ok := opt.Value.(string); ok {
+				return s
+			}
+			return fmt.Sprint(opt.Value)
+		}
+	}
+	return ""
+}
+
+// Username returns the name of the user who invoked the interaction.
+func (i DiscordInteraction) Username() string {
+	if i.Member != nil {
+		return i.Member.User.Username
+	}
+	if i.User != nil {
+		return i.User.Username
+	}
+	return ""
+}
+
+// UserID returns the ID of the user who invoked the interaction.
+func (i DiscordInteraction) UserID() string {
+	if i.Member != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
+
+// Roles returns the guild role IDs of the invoking member. Commands sent in a
+// direct message have none.
+func (i DiscordInteraction) Roles() []string {
+	if i.Member != nil {
+		return i.Member.Roles
+	}
+	return nil
+}
+
+// DiscordGateway keeps a Gateway connection open and hands slash command
+// interactions to OnInteraction. Interactions need no privileged intents.
+type DiscordGateway struct {
+	Token         string
+	URL           string // Defaults to DiscordGatewayURL
+	OnInteraction func(ctx context.Context, interaction DiscordInteraction)
+	Logger        func(string, ...interface{})
+
+	writeMu sync.Mutex
+}
+
+// NewDiscordGateway creates a gateway client authenticated with a bot token.
+func NewDiscordGateway(token string, onInteraction func(ctx context.Context, interaction DiscordInteraction)) *DiscordGateway {
+	return &DiscordGateway{
+		Token:         token,
+		URL:           DiscordGatewayURL,
+		OnInteraction: onInteraction,
+	}
+}
+
+// Run connects to the Gateway and reconnects with backoff until ctx is done.
+func (g *DiscordGateway) Run(ctx context.Context) error {
+	backoff := time.Second
+	for {
+		err := g.connect(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		g.logf("Discord gateway disconnected: %v. Reconnecting in %s", err, backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+// connect runs a single Gateway session until it is closed or fails.
+func (g *DiscordGateway) connect(ctx context.Context) error {
+	url := g.URL
+	if url == "" {
+		url = DiscordGatewayURL
+	}
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to dial gateway: %w", err)
+	}
+	defer conn.Close()
+
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-sessionCtx.Done()
+		conn.Close()
+	}()
+
+	var hello gatewayPayload
+	if err := conn.ReadJSON(&hello); err != nil {
+		return fmt.Errorf("failed to read hello: %w", err)
+	}
+	if hello.Op != gatewayOpHello {
+		return fmt.Errorf("expected hello, got op %d", hello.Op)
+	}
+	var helloData struct {
+		HeartbeatInterval int `json:"heartbeat_interval"`
+	}
+	if err := json.Unmarshal(hello.Data, &helloData); err != nil || helloData.HeartbeatInterval <= 0 {
+		return fmt.Errorf("invalid hello payload: %s", string(hello.Data))
+	}
+
+	identify := map[string]interface{}{
+		"token":   g.Token,
+		"intents": 0,
+		"properties": map[string]string{
+			"os":      "linux",
+			"browser": "recac",
+			"device":  "recac",
+		},
+	}
+	if err := g.send(conn, gatewayOpIdentify, identify); err != nil {
+		return fmt.Errorf("failed to identify: %w", err)
+	}
+	g.logf("Connected to Discord Gateway")
+
+	var seqMu sync.Mutex
+	var seq *int64
+	heartbeat := func() error {
+		seqMu.Lock()
+		defer seqMu.Unlock()
+		return g.send(conn, gatewayOpHeartbeat, seq)
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(helloData.HeartbeatInterval) * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sessionCtx.Done():
+				return
+			case <-ticker.C:
+				if err := heartbeat(); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		var payload gatewayPayload
+		if err := conn.ReadJSON(&payload); err != nil {
+			return fmt.Errorf("gateway read failed: %w", err)
+		}
+		if payload.Seq != nil {
+			seqMu.Lock()
+			seq = payload.Seq
+			seqMu.Unlock()
+		}
+
+		switch payload.Op {
+		case gatewayOpDispatch:
+			if payload.Type != "INTERACTION_CREATE" || g.OnInteraction == nil {
+				continue
+			}
+			var interaction DiscordInteraction
+			if err := json.Unmarshal(payload.Data, &interaction); err != nil {
+				g.logf("Failed to decode Discord interaction: %v", err)
+				continue
+			}
+			go g.OnInteraction(ctx, interaction)
+		case gatewayOpHeartbeat:
+			if err := heartbeat(); err != nil {
+				return err
+			}
+		case gatewayOpReconnect:
+			return fmt.Errorf("gateway requested reconnect")
+		case gatewayOpInvalidSession:
+			return fmt.Errorf("gateway session invalidated")
+		case gatewayOpHeartbeatACK:
+		}
+	}
+}
+
+func (g *DiscordGateway) send(conn *websocket.Conn, op int, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	g.writeMu.Lock()
+	defer g.writeMu.Unlock()
+	return conn.WriteJSON(gatewayPayload{Op: op, Data: raw})
+}
+
+func (g *DiscordGateway) logf(format string, args ...interface{}) {
+	if g.Logger != nil {
+		g.Logger(format, args...)
+	}
+}
+
+// RespondToInteraction replies to a slash command with a channel message and
+// returns the ID of the reply so later messages can be threaded under it.
+func (n *DiscordNotifier) RespondToInteraction(ctx context.Context, interaction DiscordInteraction, content string) (string, error) {
+	callback := fmt.Sprintf("%s/interactions/%s/%s/callback", n.apiBaseURL(), interaction.ID, interaction.Token)
+	payload := map[string]interface{}{
+		"type": 4, // CHANNEL_MESSAGE_WITH_SOURCE
+		"data": map[string]string{"content": content},
+	}
+	if err := n.doJSON(ctx, "POST", callback, payload, nil); err != nil {
+		return "", err
+	}
+
+	original := fmt.Sprintf("%s/webhooks/%s/%s/messages/@original", n.apiBaseURL(), interaction.ApplicationID, interaction.Token)
+	var message struct {
+		ID string `json:"id"`
+	}
+	if err := n.doJSON(ctx, "GET", original, nil, &message); err != nil {
+		return "", err
+	}
+	return message.ID, nil
+}
+
+// RegisterSlashCommand creates (or updates) a global slash command for the bot's application.
+func (n *DiscordNotifier) RegisterSlashCommand(ctx context.Context, command map[string]interface{}) error {
+	var app struct {
+		ID string `json:"id"`
+	}
+	if err := n.doJSON(ctx, "GET", n.apiBaseURL()+"/oauth2/applications/@me", nil, &app); err != nil {
+		return fmt.Errorf("failed to look up discord application: %w", err)
+	}
+	return n.doJSON(ctx, "POST", fmt.Sprintf("%s/applications/%s/commands", n.apiBaseURL(), app.ID), command, nil)
+}
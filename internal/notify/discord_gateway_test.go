@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestDiscordGateway_DispatchesInteractions(t *testing.T) {
+	identified := make(chan map[string]interface{}, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		conn.WriteJSON(map[string]interface{}{"op": 10, "d": map[string]int{"heartbeat_interval": 60000}})
+
+		var identify struct {
+			Op   int                    `json:"op"`
+			Data map[string]interface{} `json:"d"`
+		}
+		if err := conn.ReadJSON(&identify); err != nil || identify.Op != 2 {
+			t.Errorf("expected identify, got op %d (%v)", identify.Op, err)
+			return
+		}
+		identified <- identify.Data
+
+		conn.WriteJSON(map[string]interface{}{"op": 0, "s": 1, "t": "READY", "d": map[string]string{}})
+		conn.WriteJSON(map[string]interface{}{"op": 0, "s": 2, "t": "INTERACTION_CREATE", "d": json.RawMessage(`{
+			"id": "1", "type": 2, "token": "tok", "channel_id": "chan",
+			"data": {"name": "recac", "options": [{"name": "summary", "value": "Do it"}]},
+			"user": {"id": "u", "username": "bob"}
+		}`)})
+
+		// Keep the connection open until the client goes away
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	received := make(chan DiscordInteraction, 1)
+	gateway := NewDiscordGateway("bot-token", func(ctx context.Context, i DiscordInteraction) {
+		received <- i
+	})
+	gateway.URL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go gateway.Run(ctx)
+
+	select {
+	case data := <-identified:
+		if data["token"] != "bot-token" {
+			t.Errorf("expected bot token in identify, got %v", data["token"])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("gateway never identified")
+	}
+
+	select {
+	case i := <-received:
+		if !i.IsCommand("recac") || i.Option("summary") != "Do it" || i.Username() != "bob" {
+			t.Errorf("unexpected interaction: %+v", i)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("interaction was not dispatched")
+	}
+}
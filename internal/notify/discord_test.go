@@ -262,3 +262,44 @@ func (t *testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// 4. Send using default client (which handles test server local traffic)
 	return http.DefaultClient.Do(targetReq)
 }
+
+func TestDiscordNotifier_Threads(t *testing.T) {
+	var paths []string
+	var threadName string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if strings.HasSuffix(r.URL.Path, "/threads") {
+			threadName, _ = payload["name"].(string)
+			w.Write([]byte(`{"id":"thread_1"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"msg_2"}`))
+	}))
+	defer server.Close()
+
+	notifier := NewDiscordBotNotifier("token", "chan")
+	notifier.APIBaseURL = server.URL
+	ctx := context.Background()
+
+	threadID, err := notifier.StartThread(ctx, "msg_1", "Project demo: Session Started")
+	if err != nil {
+		t.Fatalf("StartThread failed: %v", err)
+	}
+	if threadID != "thread_1" || threadName != "Project demo: Session Started" {
+		t.Errorf("unexpected thread %q named %q", threadID, threadName)
+	}
+
+	if _, err := notifier.SendToThread(ctx, threadID, "progress"); err != nil {
+		t.Fatalf("SendToThread failed: %v", err)
+	}
+
+	want := []string{"/channels/chan/messages/msg_1/threads", "/channels/thread_1/messages"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("expected requests %v, got %v", want, paths)
+	}
+	if notifier.ChannelID != "chan" {
+		t.Errorf("SendToThread must not change the notifier channel, got %q", notifier.ChannelID)
+	}
+}
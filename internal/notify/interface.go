@@ -9,3 +9,27 @@ type Notifier interface {
 	Notify(ctx context.Context, eventType string, message string, threadTS string) (string, error)
 	AddReaction(ctx context.Context, timestamp, reaction string) error
 }
+
+// ForceNotifier is implemented by notifiers that deduplicate notifications
+// and can bypass that for critical events.
+type ForceNotifier interface {
+	ForceNotify(ctx context.Context, eventType string, message string, threadTS string) (string, error)
+}
+
+// DigestNotifier is implemented by notifiers that format the end-of-session digest themselves.
+type DigestNotifier interface {
+	NotifyDigest(ctx context.Context, d Digest, threadTS string) (string, error)
+}
+
+// ReplyReceiver is implemented by notifiers that receive messages addressed to
+// the bot (e.g. Slack app mentions). The handler gets the sender's user ID and
+// the text; its answer is posted back, and an empty answer means the message
+// was not for it.
+type ReplyReceiver interface {
+	OnReply(handler func(ctx context.Context, user, text string) string)
+}
+
+// ProgressNotifier is implemented by notifiers that format feature-progress updates themselves.
+type ProgressNotifier interface {
+	NotifyProgress(ctx context.Context, p Progress, threadTS string) (string, error)
+}
@@ -2,10 +2,14 @@ package notify
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/socketmode"
@@ -19,6 +23,8 @@ const (
 	EventFailure         = "on_failure"
 	EventUserInteraction = "on_user_interaction"
 	EventProjectComplete = "on_project_complete"
+	EventProgress        = "on_progress"
+	EventDigest          = "on_digest"
 )
 
 // SlackPoster defines the interface for Slack operations.
@@ -34,7 +40,14 @@ type DiscordPoster interface {
 	AddReaction(ctx context.Context, messageID, reaction string) error
 }
 
-// Manager handles notifications across different providers (Slack and Discord).
+// DiscordThreader is implemented by Discord posters that can keep a session's
+// messages in a thread instead of replying to the previous message.
+type DiscordThreader interface {
+	StartThread(ctx context.Context, messageID, name string) (string, error)
+	SendToThread(ctx context.Context, threadID, message string) (string, error)
+}
+
+// Manager handles notifications across different providers (Slack, Discord, generic webhooks and PagerDuty).
 type Manager struct {
 	// Slack
 	client       SlackPoster
@@ -44,19 +57,39 @@ type Manager struct {
 	// Discord
 	discordNotifier DiscordPoster
 
+	// Generic HTTP webhook
+	webhookNotifier *WebhookNotifier
+
+	// PagerDuty (failure-class events only)
+	pagerDutyNotifier *PagerDutyNotifier
+
+	// Deduplication: identical (event, project, message) notifications within cooldown are suppressed
+	project  string
+	cooldown time.Duration
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+	now      func() time.Time
+
 	logger func(string, ...interface{})
+
+	// Handles app mentions received over Socket Mode
+	replyHandler func(ctx context.Context, user, text string) string
 }
 
 // ThreadState represents the state of threads across providers
 type ThreadState struct {
-	SlackTS   string `json:"slack_ts,omitempty"`
-	DiscordID string `json:"discord_id,omitempty"`
+	SlackTS         string `json:"slack_ts,omitempty"`
+	DiscordID       string `json:"discord_id,omitempty"`        // Session start message (reactions target it)
+	DiscordThreadID string `json:"discord_thread_id,omitempty"` // Thread created from the start message
 }
 
 // NewManager creates a new Notification Manager.
 func NewManager(logger func(string, ...interface{})) *Manager {
 	m := &Manager{
-		logger: logger,
+		logger:   logger,
+		cooldown: viper.GetDuration("notifications.cooldown"),
+		lastSent: make(map[string]time.Time),
+		now:      time.Now,
 	}
 
 	// Initialize Slack
@@ -65,6 +98,12 @@ func NewManager(logger func(string, ...interface{})) *Manager {
 	// Initialize Discord
 	m.initDiscord()
 
+	// Initialize Webhook
+	m.initWebhook()
+
+	// Initialize PagerDuty
+	m.initPagerDuty()
+
 	return m
 }
 
@@ -120,6 +159,66 @@ func (m *Manager) initDiscord() {
 	}
 }
 
+func (m *Manager) initWebhook() {
+	if !viper.GetBool("notifications.webhook.enabled") {
+		return
+	}
+
+	url := os.Getenv("NOTIFY_WEBHOOK_URL")
+	if url == "" {
+		url = viper.GetString("notifications.webhook.url")
+	}
+	if url == "" {
+		if m.logger != nil {
+			m.logger("Warning: NOTIFY_WEBHOOK_URL not set, webhook notifications disabled")
+		}
+		return
+	}
+
+	secret := os.Getenv("NOTIFY_WEBHOOK_SECRET")
+	if secret == "" {
+		secret = viper.GetString("notifications.webhook.secret")
+	}
+
+	m.webhookNotifier = NewWebhookNotifier(url, secret)
+	// Agents spawned by the orchestrator carry their project and ticket in the environment
+	m.webhookNotifier.Project = os.Getenv("RECAC_PROJECT_ID")
+	m.webhookNotifier.Ticket = os.Getenv("JIRA_TICKET")
+}
+
+func (m *Manager) initPagerDuty() {
+	if !viper.GetBool("notifications.pagerduty.enabled") {
+		return
+	}
+
+	routingKey := os.Getenv("PAGERDUTY_ROUTING_KEY")
+	if routingKey == "" {
+		if m.logger != nil {
+			m.logger("Warning: PAGERDUTY_ROUTING_KEY not set, pagerduty alerts disabled")
+		}
+		return
+	}
+
+	m.pagerDutyNotifier = NewPagerDutyNotifier(routingKey)
+	m.pagerDutyNotifier.Project = os.Getenv("RECAC_PROJECT_ID")
+	m.pagerDutyNotifier.Ticket = os.Getenv("JIRA_TICKET")
+}
+
+// SetProject sets the project reported in webhook payloads and used for PagerDuty and
+// notification dedup keys.
+func (m *Manager) SetProject(project string) {
+	if project == "" {
+		return
+	}
+	m.project = project
+	if m.webhookNotifier != nil {
+		m.webhookNotifier.Project = project
+	}
+	if m.pagerDutyNotifier != nil {
+		m.pagerDutyNotifier.Project = project
+	}
+}
+
 // Start initiates background clients (e.g. Socket Mode) if configured.
 func (m *Manager) Start(ctx context.Context) {
 	if m.socketClient != nil {
@@ -138,8 +237,20 @@ func (m *Manager) Start(ctx context.Context) {
 }
 
 // Notify sends a notification if the event is enabled in configuration.
+// Identical notifications (same event, project and message) within the configured
+// cooldown are suppressed; the thread state is then returned unchanged.
 // It returns a JSON string containing thread IDs for active providers.
 func (m *Manager) Notify(ctx context.Context, eventType string, message string, threadStateStr string) (string, error) {
+	return m.notify(ctx, eventType, message, threadStateStr, false)
+}
+
+// ForceNotify sends a notification like Notify but bypasses deduplication.
+// Use it for critical events that must always be delivered.
+func (m *Manager) ForceNotify(ctx context.Context, eventType string, message string, threadStateStr string) (string, error) {
+	return m.notify(ctx, eventType, message, threadStateStr, true)
+}
+
+func (m *Manager) notify(ctx context.Context, eventType, message, threadStateStr string, force bool) (string, error) {
 	if m.logger != nil {
 		m.logger("Checking notification for event: %s", eventType)
 	}
@@ -148,6 +259,13 @@ func (m *Manager) Notify(ctx context.Context, eventType string, message string,
 		return "", nil
 	}
 
+	if !force && m.isDuplicate(eventType, message) {
+		if m.logger != nil {
+			m.logger("Suppressing duplicate notification for event: %s", eventType)
+		}
+		return threadStateStr, nil
+	}
+
 	if m.logger != nil {
 		m.logger("Sending notification for event: %s", eventType)
 	}
@@ -169,13 +287,24 @@ func (m *Manager) Notify(ctx context.Context, eventType string, message string,
 
 	// Send to Discord
 	if m.discordNotifier != nil && m.isProviderEnabled("discord") {
-		newID, err := m.discordNotifier.Send(ctx, message, ts.DiscordID)
-		if err != nil {
+		m.notifyDiscord(ctx, eventType, message, &ts)
+	}
+
+	// Send to Webhook
+	if m.webhookNotifier != nil && m.isProviderEnabled("webhook") {
+		if _, err := m.webhookNotifier.Notify(ctx, eventType, message, ""); err != nil {
 			if m.logger != nil {
-				m.logger("Failed to send Discord notification: %v", err)
+				m.logger("Failed to send webhook notification: %v", err)
+			}
+		}
+	}
+
+	// Send to PagerDuty (the notifier itself filters out non-failure-class events)
+	if m.pagerDutyNotifier != nil && m.isProviderEnabled("pagerduty") {
+		if _, err := m.pagerDutyNotifier.Notify(ctx, eventType, message, ""); err != nil {
+			if m.logger != nil {
+				m.logger("Failed to send PagerDuty event: %v", err)
 			}
-		} else {
-			ts.DiscordID = newID
 		}
 	}
 
@@ -183,6 +312,74 @@ func (m *Manager) Notify(ctx context.Context, eventType string, message string,
 	return dumpThreadState(ts), nil
 }
 
+// notifyDiscord posts into the session's Discord thread. The EventStart message
+// starts the thread; without thread support messages reply to the previous one.
+func (m *Manager) notifyDiscord(ctx context.Context, eventType, message string, ts *ThreadState) {
+	threader, canThread := m.discordNotifier.(DiscordThreader)
+
+	if canThread && ts.DiscordThreadID != "" {
+		if _, err := threader.SendToThread(ctx, ts.DiscordThreadID, message); err != nil && m.logger != nil {
+			m.logger("Failed to send Discord notification: %v", err)
+		}
+		return
+	}
+
+	newID, err := m.discordNotifier.Send(ctx, message, ts.DiscordID)
+	if err != nil {
+		if m.logger != nil {
+			m.logger("Failed to send Discord notification: %v", err)
+		}
+		return
+	}
+	ts.DiscordID = newID
+
+	if canThread && eventType == EventStart && newID != "" {
+		name, _, _ := strings.Cut(message, "\n")
+		threadID, err := threader.StartThread(ctx, newID, name)
+		if err != nil {
+			if m.logger != nil {
+				m.logger("Failed to create Discord thread: %v", err)
+			}
+			return
+		}
+		ts.DiscordThreadID = threadID
+	}
+}
+
+// isDuplicate reports whether an identical notification was sent within the cooldown,
+// and records this one otherwise.
+func (m *Manager) isDuplicate(eventType, message string) bool {
+	if m.cooldown <= 0 {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(message))
+	key := eventType + "|" + m.project + "|" + hex.EncodeToString(sum[:])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.lastSent == nil {
+		m.lastSent = make(map[string]time.Time)
+	}
+	now := time.Now()
+	if m.now != nil {
+		now = m.now()
+	}
+	if last, ok := m.lastSent[key]; ok && now.Sub(last) < m.cooldown {
+		return true
+	}
+	m.lastSent[key] = now
+
+	// Drop expired entries so long-running processes don't accumulate keys
+	for k, t := range m.lastSent {
+		if now.Sub(t) >= m.cooldown {
+			delete(m.lastSent, k)
+		}
+	}
+	return false
+}
+
 func (m *Manager) notifySlack(ctx context.Context, eventType, message, threadTS string) (string, error) {
 	channelID := m.channelID
 	if channelID == "" {
@@ -238,6 +435,10 @@ func getStyle(eventType string) (string, string) {
 		return "💬 Input Needed", "#f1c40f" // Yellow
 	case EventProjectComplete:
 		return "🏁 Project Complete", "#2eb886" // Green
+	case EventProgress:
+		return "📊 Progress", "#3498db" // Blue
+	case EventDigest:
+		return "🧾 Session Summary", "#808080" // Grey
 	default:
 		return "📢 Notification", "#808080" // Grey
 	}
@@ -247,8 +448,10 @@ func (m *Manager) isEnabled(eventType string) bool {
 	// Check global enabled (if any provider is enabled)
 	slackEnabled := m.isProviderEnabled("slack")
 	discordEnabled := m.isProviderEnabled("discord")
+	webhookEnabled := m.isProviderEnabled("webhook")
+	pagerDutyEnabled := m.isProviderEnabled("pagerduty")
 
-	if !slackEnabled && !discordEnabled {
+	if !slackEnabled && !discordEnabled && !webhookEnabled && !pagerDutyEnabled {
 		return false
 	}
 
@@ -322,7 +525,7 @@ func dumpThreadState(ts ThreadState) string {
 
 	// Optimization: If only Slack is used, return plain string?
 	// This helps readability in logs.
-	if ts.DiscordID == "" && ts.SlackTS != "" {
+	if ts.DiscordID == "" && ts.DiscordThreadID == "" && ts.SlackTS != "" {
 		return ts.SlackTS
 	}
 
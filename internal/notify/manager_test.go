@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/slack-go/slack"
 	"github.com/spf13/viper"
@@ -250,3 +251,102 @@ func TestManager_AddReaction(t *testing.T) {
 	assert.True(t, slackCalled)
 	assert.True(t, discordCalled)
 }
+
+type mockDiscordThreader struct {
+	mockDiscordPoster
+	threads     map[string]string // thread ID -> name
+	threadPosts []string
+}
+
+func (m *mockDiscordThreader) StartThread(ctx context.Context, messageID, name string) (string, error) {
+	if m.threads == nil {
+		m.threads = make(map[string]string)
+	}
+	m.threads["thread_"+messageID] = name
+	return "thread_" + messageID, nil
+}
+
+func (m *mockDiscordThreader) SendToThread(ctx context.Context, threadID, message string) (string, error) {
+	m.threadPosts = append(m.threadPosts, threadID+": "+message)
+	return "post", nil
+}
+
+func TestManager_Notify_DiscordThread(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(func() { viper.Reset() })
+	viper.Set("notifications.discord.enabled", true)
+	viper.Set("notifications.slack.events.on_start", true)
+	viper.Set("notifications.slack.events.on_success", true)
+
+	var sent []string
+	discord := &mockDiscordThreader{
+		mockDiscordPoster: mockDiscordPoster{
+			sendFunc: func(ctx context.Context, message, threadID string) (string, error) {
+				sent = append(sent, message)
+				return "start_msg", nil
+			},
+		},
+	}
+	m := &Manager{discordNotifier: discord}
+	ctx := context.Background()
+
+	state, err := m.Notify(ctx, EventStart, "Project demo: Session Started", "")
+	assert.NoError(t, err)
+	assert.Contains(t, state, `"discord_id":"start_msg"`)
+	assert.Contains(t, state, `"discord_thread_id":"thread_start_msg"`)
+	assert.Equal(t, "Project demo: Session Started", discord.threads["thread_start_msg"])
+
+	state, err = m.Notify(ctx, EventSuccess, "Feature 1 done", state)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Project demo: Session Started"}, sent, "later events must not post to the channel")
+	assert.Equal(t, []string{"thread_start_msg: Feature 1 done"}, discord.threadPosts)
+	// Reactions still target the start message in the channel
+	assert.Contains(t, state, `"discord_id":"start_msg"`)
+}
+
+func TestManager_Notify_Dedup(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(func() { viper.Reset() })
+	viper.Set("notifications.slack.enabled", true)
+	viper.Set("notifications.slack.events.on_start", true)
+
+	calls := 0
+	mockSlack := &mockSlackPoster{
+		postMessageContextFunc: func(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+			calls++
+			return "channel", "slack_ts_1", nil
+		},
+	}
+
+	now := time.Now()
+	m := &Manager{
+		client:    mockSlack,
+		channelID: "#test",
+		project:   "proj",
+		cooldown:  time.Minute,
+		now:       func() time.Time { return now },
+	}
+
+	ctx := context.Background()
+	_, err := m.Notify(ctx, EventStart, "message", "")
+	assert.NoError(t, err)
+
+	// Identical notification within the cooldown is suppressed and keeps the thread state
+	state, err := m.Notify(ctx, EventStart, "message", `{"slack_ts":"orig"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"slack_ts":"orig"}`, state)
+	assert.Equal(t, 1, calls)
+
+	// A different message is not a duplicate
+	_, _ = m.Notify(ctx, EventStart, "other message", "")
+	assert.Equal(t, 2, calls)
+
+	// ForceNotify bypasses deduplication
+	_, _ = m.ForceNotify(ctx, EventStart, "message", "")
+	assert.Equal(t, 3, calls)
+
+	// After the cooldown the notification goes out again
+	now = now.Add(2 * time.Minute)
+	_, _ = m.Notify(ctx, EventStart, "message", "")
+	assert.Equal(t, 4, calls)
+}
@@ -0,0 +1,141 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// PagerDutyEventsURL is the PagerDuty Events API v2 endpoint.
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyNotifier pages on-call via the PagerDuty Events API v2.
+// Failure events trigger an incident; success events resolve it. All other events are ignored.
+type PagerDutyNotifier struct {
+	RoutingKey string
+	EventsURL  string
+	Project    string
+	Ticket     string
+	Client     *http.Client
+}
+
+var _ Notifier = (*PagerDutyNotifier)(nil)
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Component     string            `json:"component,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// NewPagerDutyNotifier creates a new PagerDutyNotifier for the given integration routing key.
+func NewPagerDutyNotifier(routingKey string) *PagerDutyNotifier {
+	return &PagerDutyNotifier{
+		RoutingKey: routingKey,
+		EventsURL:  PagerDutyEventsURL,
+		Client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Start is a no-op; PagerDuty needs no background connection.
+func (p *PagerDutyNotifier) Start(ctx context.Context) {}
+
+// Notify triggers an incident for failure events and resolves it for success events.
+// The thread handle is returned unchanged.
+func (p *PagerDutyNotifier) Notify(ctx context.Context, eventType, message, threadTS string) (string, error) {
+	var action string
+	switch eventType {
+	case EventFailure:
+		action = "trigger"
+	case EventSuccess, EventProjectComplete:
+		// PagerDuty ignores resolves for dedup keys without an open incident
+		action = "resolve"
+	default:
+		return threadTS, nil
+	}
+
+	event := pagerDutyEvent{
+		RoutingKey:  p.RoutingKey,
+		EventAction: action,
+		DedupKey:    p.DedupKey(),
+	}
+	if action == "trigger" {
+		event.Payload = &pagerDutyPayload{
+			Summary:   truncateSummary(message),
+			Source:    "recac",
+			Severity:  "error",
+			Component: p.Project,
+			CustomDetails: map[string]string{
+				"project": p.Project,
+				"ticket":  p.Ticket,
+				"message": message,
+			},
+		}
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return threadTS, fmt.Errorf("failed to marshal pagerduty event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.EventsURL, bytes.NewBuffer(body))
+	if err != nil {
+		return threadTS, fmt.Errorf("failed to create pagerduty request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return threadTS, fmt.Errorf("failed to send pagerduty event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return threadTS, fmt.Errorf("pagerduty event failed with status: %s", resp.Status)
+	}
+
+	return threadTS, nil
+}
+
+// AddReaction is a no-op; PagerDuty incidents have no reactions.
+func (p *PagerDutyNotifier) AddReaction(ctx context.Context, threadTS, reaction string) error {
+	return nil
+}
+
+// DedupKey identifies the incident for this project/ticket so that a later
+// success resolves the incident opened by an earlier failure.
+func (p *PagerDutyNotifier) DedupKey() string {
+	project := p.Project
+	if project == "" {
+		project = "unknown"
+	}
+	if p.Ticket == "" || p.Ticket == project {
+		return "recac/" + project
+	}
+	return "recac/" + project + "/" + p.Ticket
+}
+
+// truncateSummary keeps the summary within PagerDuty's 1024 character limit.
+func truncateSummary(s string) string {
+	const maxSummary = 1024
+	if len(s) <= maxSummary {
+		return s
+	}
+	return s[:maxSummary-3] + "..."
+}
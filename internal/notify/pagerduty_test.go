@@ -0,0 +1,126 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPagerDutyServer(t *testing.T, events *[]pagerDutyEvent) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var ev pagerDutyEvent
+		require.NoError(t, json.Unmarshal(body, &ev))
+		*events = append(*events, ev)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPagerDutyNotifier_TriggerAndResolve(t *testing.T) {
+	var events []pagerDutyEvent
+	server := newPagerDutyServer(t, &events)
+
+	p := NewPagerDutyNotifier("routing-key")
+	p.EventsURL = server.URL
+	p.Project = "proj"
+	p.Ticket = "PROJ-1"
+
+	ctx := context.Background()
+	handle, err := p.Notify(ctx, EventFailure, "Project proj Stalled", "thread")
+	require.NoError(t, err)
+	assert.Equal(t, "thread", handle)
+
+	_, err = p.Notify(ctx, EventSuccess, "Project proj Signed Off", "thread")
+	require.NoError(t, err)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "trigger", events[0].EventAction)
+	assert.Equal(t, "routing-key", events[0].RoutingKey)
+	assert.Equal(t, "recac/proj/PROJ-1", events[0].DedupKey)
+	require.NotNil(t, events[0].Payload)
+	assert.Equal(t, "Project proj Stalled", events[0].Payload.Summary)
+	assert.Equal(t, "error", events[0].Payload.Severity)
+	assert.Equal(t, "PROJ-1", events[0].Payload.CustomDetails["ticket"])
+
+	assert.Equal(t, "resolve", events[1].EventAction)
+	assert.Equal(t, events[0].DedupKey, events[1].DedupKey)
+	assert.Nil(t, events[1].Payload)
+}
+
+func TestPagerDutyNotifier_SuppressesNonFailureEvents(t *testing.T) {
+	var events []pagerDutyEvent
+	server := newPagerDutyServer(t, &events)
+
+	p := NewPagerDutyNotifier("routing-key")
+	p.EventsURL = server.URL
+
+	for _, ev := range []string{EventStart, EventUserInteraction, "on_progress"} {
+		_, err := p.Notify(context.Background(), ev, "msg", "")
+		require.NoError(t, err)
+	}
+	assert.Empty(t, events)
+}
+
+func TestPagerDutyNotifier_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	p := NewPagerDutyNotifier("routing-key")
+	p.EventsURL = server.URL
+	_, err := p.Notify(context.Background(), EventFailure, "boom", "")
+	assert.Error(t, err)
+}
+
+func TestPagerDutyNotifier_DedupKey(t *testing.T) {
+	assert.Equal(t, "recac/unknown", (&PagerDutyNotifier{}).DedupKey())
+	assert.Equal(t, "recac/PROJ-1", (&PagerDutyNotifier{Project: "PROJ-1", Ticket: "PROJ-1"}).DedupKey())
+	assert.Equal(t, "recac/app/PROJ-1", (&PagerDutyNotifier{Project: "app", Ticket: "PROJ-1"}).DedupKey())
+}
+
+func TestTruncateSummary(t *testing.T) {
+	long := strings.Repeat("a", 2000)
+	got := truncateSummary(long)
+	assert.Len(t, got, 1024)
+	assert.True(t, strings.HasSuffix(got, "..."))
+	assert.Equal(t, "short", truncateSummary("short"))
+}
+
+func TestManager_PagerDuty(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(func() { viper.Reset() })
+
+	var events []pagerDutyEvent
+	server := newPagerDutyServer(t, &events)
+
+	t.Setenv("PAGERDUTY_ROUTING_KEY", "routing-key")
+	t.Setenv("JIRA_TICKET", "")
+	viper.Set("notifications.pagerduty.enabled", true)
+	viper.Set("notifications.slack.events.on_start", true)
+	viper.Set("notifications.slack.events.on_failure", true)
+
+	m := NewManager(nil)
+	require.NotNil(t, m.pagerDutyNotifier)
+	m.pagerDutyNotifier.EventsURL = server.URL
+	m.SetProject("proj")
+
+	ctx := context.Background()
+	_, _ = m.Notify(ctx, EventStart, "Session Started", "")
+	_, _ = m.Notify(ctx, EventFailure, "Project proj Failed", "")
+
+	require.Len(t, events, 1)
+	assert.Equal(t, "trigger", events[0].EventAction)
+	assert.Equal(t, "recac/proj", events[0].DedupKey)
+}
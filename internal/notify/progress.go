@@ -0,0 +1,34 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+// Progress is a snapshot of feature completion for a running session.
+type Progress struct {
+	Project string
+	Role    string
+	Passing int
+	Total   int
+}
+
+// String formats the progress as a compact status line, e.g.
+// "my-project: 3/10 features passing (30%) · coding".
+func (p Progress) String() string {
+	percent := 0
+	if p.Total > 0 {
+		percent = p.Passing * 100 / p.Total
+	}
+	line := fmt.Sprintf("%s: %d/%d features passing (%d%%)", p.Project, p.Passing, p.Total, percent)
+	if p.Role != "" {
+		line += " · " + p.Role
+	}
+	return line
+}
+
+// NotifyProgress posts a progress status line in the session thread.
+// Unchanged progress within the cooldown is deduplicated like any other notification.
+func (m *Manager) NotifyProgress(ctx context.Context, p Progress, threadStateStr string) (string, error) {
+	return m.Notify(ctx, EventProgress, p.String(), threadStateStr)
+}
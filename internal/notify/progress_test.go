@@ -0,0 +1,25 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress_String(t *testing.T) {
+	p := Progress{Project: "proj", Role: "coding_agent", Passing: 3, Total: 10}
+	assert.Equal(t, "proj: 3/10 features passing (30%) · coding_agent", p.String())
+
+	p = Progress{Project: "proj", Passing: 0, Total: 0}
+	assert.Equal(t, "proj: 0/0 features passing (0%)", p.String())
+}
+
+func TestDigest_String(t *testing.T) {
+	d := Digest{Project: "proj", Status: "completed", Iterations: 12, Passing: 4, Total: 5, Tokens: 120000, Cost: 0.4215, Duration: 83*time.Minute + 400*time.Millisecond}
+	assert.Equal(t, "Session summary for proj: completed\nIterations: 12\nFeatures: 4/5 passing (80%)\nCost: $0.4215 (120000 tokens)\nDuration: 1h23m0s", d.String())
+
+	// Sessions without features or usage leave those lines out
+	d = Digest{Project: "proj", Status: "blocked", Iterations: 1, Duration: 5 * time.Second}
+	assert.Equal(t, "Session summary for proj: blocked\nIterations: 1\nDuration: 5s", d.String())
+}
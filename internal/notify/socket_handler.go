@@ -9,6 +9,13 @@ import (
 	"github.com/slack-go/slack/socketmode"
 )
 
+// OnReply routes app mentions to handler, posting its answer back to the channel.
+func (m *Manager) OnReply(handler func(ctx context.Context, user, text string) string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.replyHandler = handler
+}
+
 // HandleEvents listens for incoming Socket Mode events.
 // This is a simplified handler to prove the connection works.
 func (m *Manager) HandleEvents(ctx context.Context) {
@@ -49,6 +56,15 @@ func (m *Manager) HandleEvents(ctx context.Context) {
 						if m.logger != nil {
 							m.logger("Received Mention: %s", ev.Text)
 						}
+						m.mu.Lock()
+						handler := m.replyHandler
+						m.mu.Unlock()
+						if handler != nil {
+							if reply := handler(ctx, ev.User, ev.Text); reply != "" {
+								m.client.PostMessage(ev.Channel, slack.MsgOptionText(reply, false))
+								continue
+							}
+						}
 						// Echo back just to prove it works
 						m.client.PostMessage(ev.Channel, slack.MsgOptionText(fmt.Sprintf("Yes, hello! I received: %s", ev.Text), false))
 					}
@@ -0,0 +1,107 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookSignatureHeader carries the hex-encoded HMAC-SHA256 of the request body
+// (prefixed with "sha256=") when a webhook secret is configured.
+const WebhookSignatureHeader = "X-Recac-Signature"
+
+// WebhookPayload is the JSON body POSTed to the webhook URL for every event.
+type WebhookPayload struct {
+	Event     string    `json:"event"`
+	Project   string    `json:"project,omitempty"`
+	Ticket    string    `json:"ticket,omitempty"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// WebhookNotifier POSTs events as JSON to an arbitrary HTTP endpoint.
+type WebhookNotifier struct {
+	URL     string
+	Secret  string
+	Project string
+	Ticket  string
+	Client  *http.Client
+}
+
+var _ Notifier = (*WebhookNotifier)(nil)
+
+// NewWebhookNotifier creates a new WebhookNotifier. If secret is non-empty, requests are signed.
+func NewWebhookNotifier(url, secret string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:    url,
+		Secret: secret,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Start is a no-op; webhooks need no background connection.
+func (w *WebhookNotifier) Start(ctx context.Context) {}
+
+// Notify POSTs the event to the webhook URL. Webhooks have no threads, so the
+// thread handle is returned unchanged.
+func (w *WebhookNotifier) Notify(ctx context.Context, eventType, message, threadTS string) (string, error) {
+	if w.URL == "" {
+		return threadTS, fmt.Errorf("webhook URL is not configured")
+	}
+
+	body, err := json.Marshal(WebhookPayload{
+		Event:     eventType,
+		Project:   w.Project,
+		Ticket:    w.Ticket,
+		Message:   message,
+		Timestamp: time.Now().UTC(),
+	})
+	if err != nil {
+		return threadTS, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", w.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return threadTS, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookPayload(w.Secret, body))
+	}
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return threadTS, fmt.Errorf("failed to send webhook notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return threadTS, fmt.Errorf("webhook notification failed with status: %s", resp.Status)
+	}
+
+	return threadTS, nil
+}
+
+// AddReaction is a no-op; webhooks have no messages to react to.
+func (w *WebhookNotifier) AddReaction(ctx context.Context, threadTS, reaction string) error {
+	return nil
+}
+
+// SignWebhookPayload returns the hex-encoded HMAC-SHA256 of body using secret,
+// so receivers can verify the X-Recac-Signature header.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
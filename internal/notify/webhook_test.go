@@ -0,0 +1,119 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	var received WebhookPayload
+	var signature string
+	var rawBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		rawBody, _ = io.ReadAll(r.Body)
+		_ = json.Unmarshal(rawBody, &received)
+		signature = r.Header.Get(WebhookSignatureHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := NewWebhookNotifier(server.URL, "s3cret")
+	n.Project = "proj-1"
+	n.Ticket = "PROJ-42"
+
+	handle, err := n.Notify(context.Background(), EventFailure, "build broke", "thread-1")
+	require.NoError(t, err)
+	assert.Equal(t, "thread-1", handle, "thread handle should be returned unchanged")
+
+	assert.Equal(t, EventFailure, received.Event)
+	assert.Equal(t, "proj-1", received.Project)
+	assert.Equal(t, "PROJ-42", received.Ticket)
+	assert.Equal(t, "build broke", received.Message)
+	assert.False(t, received.Timestamp.IsZero())
+	assert.Equal(t, "sha256="+SignWebhookPayload("s3cret", rawBody), signature)
+}
+
+func TestWebhookNotifier_NoSecretNoSignature(t *testing.T) {
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(WebhookSignatureHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := NewWebhookNotifier(server.URL, "").Notify(context.Background(), EventStart, "hi", "")
+	require.NoError(t, err)
+	assert.Empty(t, signature)
+}
+
+func TestWebhookNotifier_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	handle, err := NewWebhookNotifier(server.URL, "").Notify(context.Background(), EventStart, "hi", "ts")
+	assert.Error(t, err)
+	assert.Equal(t, "ts", handle)
+
+	_, err = NewWebhookNotifier("", "").Notify(context.Background(), EventStart, "hi", "")
+	assert.Error(t, err)
+
+	assert.NoError(t, NewWebhookNotifier(server.URL, "").AddReaction(context.Background(), "ts", "x"))
+}
+
+func TestManager_Webhook(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(func() { viper.Reset() })
+
+	var received WebhookPayload
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Setenv("NOTIFY_WEBHOOK_URL", server.URL)
+	t.Setenv("JIRA_TICKET", "PROJ-7")
+	viper.Set("notifications.webhook.enabled", true)
+	viper.Set("notifications.slack.events.on_success", true)
+
+	m := NewManager(nil)
+	require.NotNil(t, m.webhookNotifier)
+	m.SetProject("my-project")
+
+	state, err := m.Notify(context.Background(), EventSuccess, "all good", "")
+	require.NoError(t, err)
+	assert.Equal(t, "{}", state)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "my-project", received.Project)
+	assert.Equal(t, "PROJ-7", received.Ticket)
+
+	// Disabled events are not sent
+	_, _ = m.Notify(context.Background(), EventStart, "started", "")
+	assert.Equal(t, 1, calls)
+}
+
+func TestManager_WebhookMissingURL(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(func() { viper.Reset() })
+
+	t.Setenv("NOTIFY_WEBHOOK_URL", "")
+	viper.Set("notifications.webhook.enabled", true)
+
+	m := NewManager(nil)
+	assert.Nil(t, m.webhookNotifier)
+}
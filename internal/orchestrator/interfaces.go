@@ -2,9 +2,13 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"recac/internal/jira"
 	"recac/internal/runner"
+	"strings"
+	"time"
 )
 
 // WorkItem represents a unit of work to be processed, e.g., a Jira ticket.
@@ -12,16 +16,85 @@ type WorkItem struct {
 	ID          string
 	Summary     string
 	Description string
-	RepoURL     string // Repo to clone
+	Priority    string   // Optional priority hint from file-backed work items
+	RepoURL     string   // Repo to clone
+	RepoURLs    []string // Additional repos, each cloned into a subdirectory of the workspace
+	Model       string   // Agent model override for this item (from a model/<name> label)
 	EnvVars     map[string]string
 }
 
+// ModelLabelPrefix marks a ticket label that selects the agent model, e.g. "model/gpt-4o".
+const ModelLabelPrefix = "model/"
+
+// modelFromLabels returns the model named by the first model/<name> label, or "".
+func modelFromLabels(labels []string) string {
+	for _, label := range labels {
+		if model, ok := strings.CutPrefix(label, ModelLabelPrefix); ok && model != "" {
+			return model
+		}
+	}
+	return ""
+}
+
+// itemModel returns the item's model override, falling back to the spawner's model.
+func itemModel(item WorkItem, fallback string) string {
+	if item.Model != "" {
+		return item.Model
+	}
+	return fallback
+}
+
+// GitHubTokenSource mints the GitHub token handed to agents, such as a
+// *github.App minting installation tokens.
+type GitHubTokenSource interface {
+	TokenValidFor(ctx context.Context, d time.Duration) (string, error)
+}
+
+// agentTokenValidity is how long a token handed to an agent must stay valid.
+// An agent cannot refresh its token, so it gets one with close to the full
+// hour an installation token lasts rather than one about to be replaced.
+const agentTokenValidity = 55 * time.Minute
+
+// githubTokenEnv returns the environment giving an agent a token from source,
+// or nil when source is nil. Agents get a short-lived installation token
+// rather than the App's private key, which stays on the orchestrator's host.
+func githubTokenEnv(ctx context.Context, source GitHubTokenSource) (map[string]string, error) {
+	if source == nil {
+		return nil, nil
+	}
+	token, err := source.TokenValidFor(ctx, agentTokenValidity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mint GitHub token for agent: %w", err)
+	}
+	return map[string]string{
+		"GITHUB_TOKEN":         token,
+		"GITHUB_API_KEY":       token,
+		"RECAC_GITHUB_API_KEY": token,
+	}, nil
+}
+
+// ErrNoRepoURL is returned by spawners for work items without a repository to clone.
+var ErrNoRepoURL = errors.New("work item has no repo URL")
+
+// normalizeRepoURL trims whitespace, trailing slashes and a .git suffix so
+// every poller hands spawners the same form of a repository URL.
+func normalizeRepoURL(repoURL string) string {
+	repoURL = strings.TrimSuffix(strings.TrimSpace(repoURL), "/")
+	return strings.TrimSuffix(repoURL, ".git")
+}
+
 // Poller defines the interface for polling for work items.
 type Poller interface {
 	Poll(ctx context.Context, logger *slog.Logger) ([]WorkItem, error)
 	UpdateStatus(ctx context.Context, item WorkItem, status string, comment string) error
 }
 
+// SpawnResultRecorder is implemented by pollers that need to know the outcome
+// of each spawn, e.g. to retire file-backed work items.
+type SpawnResultRecorder interface {
+	RecordSpawnResult(ctx context.Context, item WorkItem, spawnErr error) error
+}
+
 // Spawner defines the interface for spawning an agent to handle a work item.
 type Spawner interface {
 	Spawn(ctx context.Context, item WorkItem) error
@@ -41,6 +114,14 @@ type JiraClient interface {
 // Statically assert that the real client implements our interface.
 var _ JiraClient = (*jira.Client)(nil)
 
+// JiraPlanExpander is implemented by Jira clients that can create the children
+// deferred by `recac jira generate-from-spec --incremental`.
+type JiraPlanExpander interface {
+	ExpandPendingChildren(ctx context.Context, issue map[string]interface{}) ([]string, error)
+}
+
+var _ JiraPlanExpander = (*jira.Client)(nil)
+
 // DockerClient defines the interface for Docker operations, created for mocking.
 type DockerClient interface {
 	RunContainer(ctx context.Context, image string, workspace string, binds []string, env []string, user string) (string, error)
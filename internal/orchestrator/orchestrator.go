@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math/rand"
 	"sync"
 	"time"
 )
@@ -12,6 +13,13 @@ type Orchestrator struct {
 	Poller       Poller
 	Spawner      Spawner
 	PollInterval time.Duration
+	// IntervalJitter randomizes each wait by up to this fraction of
+	// PollInterval in either direction (0.2 = ±20%), so orchestrators
+	// started together do not hit the tracker in lockstep. Zero disables it.
+	IntervalJitter float64
+	// Once makes Run perform a single poll-and-spawn cycle and return,
+	// for use with external schedulers such as cron or Kubernetes CronJobs.
+	Once bool
 }
 
 func New(poller Poller, spawner Spawner, pollInterval time.Duration) *Orchestrator {
@@ -24,52 +32,86 @@ func New(poller Poller, spawner Spawner, pollInterval time.Duration) *Orchestrat
 
 // Run starts the orchestration loop
 func (o *Orchestrator) Run(ctx context.Context, logger *slog.Logger) error {
-	logger.Info("Starting Orchestrator", "interval", o.PollInterval)
-	ticker := time.NewTicker(o.PollInterval)
-	defer ticker.Stop()
-
 	// Use a WaitGroup to track running spawns/jobs if we want graceful shutdown
 	var wg sync.WaitGroup
 
+	if o.Once {
+		logger.Info("Starting Orchestrator (single cycle)")
+		err := o.pollAndSpawn(ctx, logger, &wg)
+		wg.Wait()
+		return err
+	}
+
+	logger.Info("Starting Orchestrator", "interval", o.PollInterval, "jitter", o.IntervalJitter)
+	timer := time.NewTimer(o.nextInterval())
+	defer timer.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			logger.Info("Orchestrator shutting down...")
 			wg.Wait()
 			return ctx.Err()
-		case <-ticker.C:
-			// Poll for work
-			logger.Debug("Polling for work...")
-			items, err := o.Poller.Poll(ctx, logger)
-			if err != nil {
-				logger.Error("Failed to poll for work", "error", err)
-				continue
-			}
+		case <-timer.C:
+			// Poll errors are logged; keep polling on the next tick
+			_ = o.pollAndSpawn(ctx, logger, &wg)
+			timer.Reset(o.nextInterval())
+		}
+	}
+}
 
-			if len(items) == 0 {
-				continue
-			}
+// nextInterval returns PollInterval shifted by a random amount within
+// ±IntervalJitter. The jitter is clamped to [0, 1] so the wait stays positive.
+func (o *Orchestrator) nextInterval() time.Duration {
+	jitter := min(max(o.IntervalJitter, 0), 1)
+	if jitter == 0 {
+		return o.PollInterval
+	}
+	offset := (rand.Float64()*2 - 1) * jitter * float64(o.PollInterval)
+	return max(o.PollInterval+time.Duration(offset), time.Millisecond)
+}
+
+// pollAndSpawn polls for work once and spawns an agent for each item found.
+// Spawns run concurrently and are tracked by wg.
+func (o *Orchestrator) pollAndSpawn(ctx context.Context, logger *slog.Logger, wg *sync.WaitGroup) error {
+	// Poll for work
+	logger.Debug("Polling for work...")
+	items, err := o.Poller.Poll(ctx, logger)
+	if err != nil {
+		logger.Error("Failed to poll for work", "error", err)
+		return fmt.Errorf("failed to poll for work: %w", err)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
 
-			logger.Info("Found work items", "count", len(items))
+	logger.Info("Found work items", "count", len(items))
 
-			for _, item := range items {
-				wg.Add(1)
-				go func(item WorkItem) {
-					defer wg.Done()
-					logger.Info("Spawning agent for item", "id", item.ID)
+	for _, item := range items {
+		wg.Add(1)
+		go func(item WorkItem) {
+			defer wg.Done()
+			logger.Info("Spawning agent for item", "id", item.ID)
 
-					if err := o.Spawner.Spawn(ctx, item); err != nil {
-						logger.Error("Failed to spawn agent", "id", item.ID, "error", err)
-						// Update status to Failed
-						_ = o.Poller.UpdateStatus(ctx, item, "Failed", fmt.Sprintf("Failed to spawn agent: %v", err))
-					} else {
-						// Success? K8s Jobs are fire-and-forget from Spawner perspective usually,
-						// but status updates might happen asynchronously.
-						// For now, Spawn() implies "Started".
-						logger.Info("Agent spawned successfully", "id", item.ID)
-					}
-				}(item)
+			err := o.Spawner.Spawn(ctx, item)
+			if recorder, ok := o.Poller.(SpawnResultRecorder); ok {
+				if recErr := recorder.RecordSpawnResult(ctx, item, err); recErr != nil {
+					logger.Error("Failed to record spawn result", "id", item.ID, "error", recErr)
+				}
 			}
-		}
+
+			if err != nil {
+				logger.Error("Failed to spawn agent", "id", item.ID, "error", err)
+				// Update status to Failed
+				_ = o.Poller.UpdateStatus(ctx, item, "Failed", fmt.Sprintf("Failed to spawn agent: %v", err))
+			} else {
+				// Success? K8s Jobs are fire-and-forget from Spawner perspective usually,
+				// but status updates might happen asynchronously.
+				// For now, Spawn() implies "Started".
+				logger.Info("Agent spawned successfully", "id", item.ID)
+			}
+		}(item)
 	}
+	return nil
 }
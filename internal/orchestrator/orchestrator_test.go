@@ -211,3 +211,54 @@ func TestOrchestrator_Run_GracefulShutdown(t *testing.T) {
 	cancel()
 	wg.Wait()
 }
+
+func TestOrchestrator_Run_Once(t *testing.T) {
+	poller := newMockPoller([]WorkItem{
+		{ID: "TEST-1", Summary: "Task 1"},
+		{ID: "TEST-2", Summary: "Task 2"},
+	})
+	spawner := &mockSpawner{}
+	// A long interval proves Run does not wait for a tick in once mode
+	orch := New(poller, spawner, time.Hour)
+	orch.Once = true
+
+	err := orch.Run(context.Background(), silentLogger)
+	require.NoError(t, err)
+
+	spawner.mu.Lock()
+	defer spawner.mu.Unlock()
+	assert.Len(t, spawner.spawned, 2)
+}
+
+func TestOrchestrator_Run_OncePollError(t *testing.T) {
+	poller := newMockPoller(nil)
+	poller.pollErr = errors.New("poll failed")
+	spawner := &mockSpawner{}
+	orch := New(poller, spawner, time.Hour)
+	orch.Once = true
+
+	err := orch.Run(context.Background(), silentLogger)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "poll failed")
+	assert.Empty(t, spawner.spawned)
+}
+
+func TestOrchestrator_NextInterval(t *testing.T) {
+	orch := New(newMockPoller(nil), &mockSpawner{}, time.Minute)
+	assert.Equal(t, time.Minute, orch.nextInterval(), "no jitter keeps the interval fixed")
+
+	orch.IntervalJitter = 0.2
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 100; i++ {
+		d := orch.nextInterval()
+		assert.GreaterOrEqual(t, d, 48*time.Second)
+		assert.LessOrEqual(t, d, 72*time.Second)
+		seen[d] = true
+	}
+	assert.Greater(t, len(seen), 1, "jittered intervals should vary")
+
+	orch.IntervalJitter = 5
+	for i := 0; i < 100; i++ {
+		assert.Positive(t, orch.nextInterval())
+	}
+}
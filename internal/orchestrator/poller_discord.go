@@ -0,0 +1,234 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"recac/internal/notify"
+)
+
+// DiscordCommandName is the slash command that enqueues work: /recac <summary> [repo].
+const DiscordCommandName = "recac"
+
+// discordCommand is the application command registered for the bot.
+var discordCommand = map[string]interface{}{
+	"name":        DiscordCommandName,
+	"description": "Start a recac agent session",
+	"options": []map[string]interface{}{
+		{"type": 3, "name": "summary", "description": "What the agent should do", "required": true},
+		{"type": 3, "name": "repo", "description": "Repository URL (defaults to the orchestrator's repo)"},
+	},
+}
+
+// discordThread is the channel message that a work item's updates are threaded under.
+type discordThread struct {
+	ChannelID string
+	MessageID string
+}
+
+// DiscordAllowlist limits who may start agents with /recac and against which
+// repositories. Spawned agents receive the orchestrator's credentials, so a
+// command is refused unless its caller is listed in Users or holds one of Roles.
+type DiscordAllowlist struct {
+	Guilds   []string // Guild IDs commands are accepted from; empty allows any guild
+	Channels []string // Channel IDs commands are accepted from; empty allows any channel
+	Users    []string // User IDs allowed to run the command
+	Roles    []string // Role IDs whose members may run the command
+	Repos    []string // Repositories a command may name besides the default one
+}
+
+// DiscordPoller implements the Poller interface for the /recac slash command.
+// Listen receives commands over the Discord Gateway and queues them; Poll hands
+// the queue to the orchestrator, and status updates are posted back as replies
+// to the command's response in the same channel.
+type DiscordPoller struct {
+	Token          string
+	DefaultRepoURL string
+	APIBaseURL     string // Defaults to notify.DiscordAPIBaseURL
+	GatewayURL     string // Defaults to notify.DiscordGatewayURL
+	Client         *http.Client
+	Allow          DiscordAllowlist
+
+	mu      sync.Mutex
+	pending []WorkItem
+	threads map[string]discordThread
+}
+
+// NewDiscordPoller creates a DiscordPoller. defaultRepoURL is used when the
+// command does not name a repository.
+func NewDiscordPoller(token, defaultRepoURL string) *DiscordPoller {
+	return &DiscordPoller{
+		Token:          token,
+		DefaultRepoURL: normalizeRepoURL(defaultRepoURL),
+		Client:         &http.Client{Timeout: 10 * time.Second},
+		threads:        make(map[string]discordThread),
+	}
+}
+
+// Listen registers the slash command and processes interactions until ctx is done.
+func (p *DiscordPoller) Listen(ctx context.Context, logger *slog.Logger) error {
+	if err := p.notifier("").RegisterSlashCommand(ctx, discordCommand); err != nil {
+		logger.Warn("[DiscordPoller] Failed to register /recac command", "error", err)
+	}
+
+	gateway := notify.NewDiscordGateway(p.Token, func(ctx context.Context, interaction notify.DiscordInteraction) {
+		p.HandleInteraction(ctx, logger, interaction)
+	})
+	if p.GatewayURL != "" {
+		gateway.URL = p.GatewayURL
+	}
+	gateway.Logger = func(format string, args ...interface{}) {
+		logger.Info("[DiscordPoller] " + fmt.Sprintf(format, args...))
+	}
+	return gateway.Run(ctx)
+}
+
+// HandleInteraction queues a work item for a /recac command and replies with its ID.
+func (p *DiscordPoller) HandleInteraction(ctx context.Context, logger *slog.Logger, interaction notify.DiscordInteraction) {
+	if !interaction.IsCommand(DiscordCommandName) {
+		return
+	}
+	discord := p.notifier(interaction.ChannelID)
+	reject := func(msg string) {
+		if _, err := discord.RespondToInteraction(ctx, interaction, msg); err != nil {
+			logger.Error("[DiscordPoller] Failed to respond to interaction", "error", err)
+		}
+	}
+
+	if reason := p.unauthorized(interaction); reason != "" {
+		logger.Warn("[DiscordPoller] Rejected command", "user", interaction.Username(), "user_id", interaction.UserID(), "guild", interaction.GuildID, "channel", interaction.ChannelID, "reason", reason)
+		reject("You are not allowed to start recac agents here.")
+		return
+	}
+
+	summary := strings.TrimSpace(interaction.Option("summary"))
+	repoURL := normalizeRepoURL(interaction.Option("repo"))
+	if repoURL == "" {
+		repoURL = p.DefaultRepoURL
+	}
+	if summary == "" || repoURL == "" {
+		reject("Usage: /recac <summary> [repo]. A repository is required when no default is configured.")
+		return
+	}
+	if !p.repoAllowed(repoURL) {
+		logger.Warn("[DiscordPoller] Rejected command for repository outside the allowlist", "user", interaction.Username(), "repo", repoURL)
+		reject(fmt.Sprintf("Repository %s is not in the allowlist.", repoURL))
+		return
+	}
+
+	id := "discord-" + interaction.ID
+	reply := fmt.Sprintf("Queued %s: %s\nRepo: %s", id, summary, repoURL)
+	messageID, err := discord.RespondToInteraction(ctx, interaction, reply)
+	if err != nil {
+		logger.Error("[DiscordPoller] Failed to respond to interaction", "id", id, "error", err)
+	}
+
+	item := WorkItem{
+		ID:          id,
+		Summary:     summary,
+		Description: fmt.Sprintf("%s\n\nRequested by %s via Discord.", summary, interaction.Username()),
+		RepoURL:     repoURL,
+		EnvVars: map[string]string{
+			"RECAC_SUMMARY":                       summary,
+			"DISCORD_CHANNEL_ID":                  interaction.ChannelID,
+			"RECAC_NOTIFICATIONS_DISCORD_ENABLED": "true",
+		},
+	}
+	if messageID != "" {
+		// Thread the agent's own notifications under the command response.
+		thread, _ := json.Marshal(notify.ThreadState{DiscordID: messageID})
+		item.EnvVars["RECAC_NOTIFY_THREAD"] = string(thread)
+	}
+
+	p.mu.Lock()
+	p.pending = append(p.pending, item)
+	p.threads[id] = discordThread{ChannelID: interaction.ChannelID, MessageID: messageID}
+	p.mu.Unlock()
+
+	logger.Info("[DiscordPoller] Queued work item", "id", id, "user", interaction.Username(), "repo", repoURL)
+}
+
+// unauthorized returns why the interaction's caller may not run the command,
+// or "" if the allowlist admits it.
+func (p *DiscordPoller) unauthorized(interaction notify.DiscordInteraction) string {
+	if len(p.Allow.Guilds) > 0 && !slices.Contains(p.Allow.Guilds, interaction.GuildID) {
+		return "guild not allowed"
+	}
+	if len(p.Allow.Channels) > 0 && !slices.Contains(p.Allow.Channels, interaction.ChannelID) {
+		return "channel not allowed"
+	}
+	if userID := interaction.UserID(); userID != "" && slices.Contains(p.Allow.Users, userID) {
+		return ""
+	}
+	for _, role := range interaction.Roles() {
+		if slices.Contains(p.Allow.Roles, role) {
+			return ""
+		}
+	}
+	return "user not allowed"
+}
+
+// repoAllowed reports whether a command may target repoURL: the default
+// repository or one listed in Allow.Repos.
+func (p *DiscordPoller) repoAllowed(repoURL string) bool {
+	if repoURL == p.DefaultRepoURL {
+		return true
+	}
+	for _, allowed := range p.Allow.Repos {
+		if normalizeRepoURL(allowed) == repoURL {
+			return true
+		}
+	}
+	return false
+}
+
+// Poll returns the work items queued since the last poll.
+func (p *DiscordPoller) Poll(ctx context.Context, logger *slog.Logger) ([]WorkItem, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	items := p.pending
+	p.pending = nil
+	return items, nil
+}
+
+// UpdateStatus posts the status update as a reply in the command's channel.
+func (p *DiscordPoller) UpdateStatus(ctx context.Context, item WorkItem, status string, comment string) error {
+	p.mu.Lock()
+	thread, ok := p.threads[item.ID]
+	p.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no discord channel recorded for work item %s", item.ID)
+	}
+
+	message := fmt.Sprintf("[%s] %s", item.ID, status)
+	if comment != "" {
+		message += ": " + comment
+	}
+	_, err := p.notifier(thread.ChannelID).Send(ctx, message, thread.MessageID)
+	return err
+}
+
+// RecordSpawnResult tells the channel that the agent session started. Failed
+// spawns are already reported through UpdateStatus.
+func (p *DiscordPoller) RecordSpawnResult(ctx context.Context, item WorkItem, spawnErr error) error {
+	if spawnErr != nil {
+		return nil
+	}
+	return p.UpdateStatus(ctx, item, "Started", "agent session is running, progress will be posted here")
+}
+
+func (p *DiscordPoller) notifier(channelID string) *notify.DiscordNotifier {
+	discord := notify.NewDiscordBotNotifier(p.Token, channelID)
+	discord.APIBaseURL = p.APIBaseURL
+	if p.Client != nil {
+		discord.Client = p.Client
+	}
+	return discord
+}
@@ -0,0 +1,241 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"recac/internal/notify"
+)
+
+type discordAPIStub struct {
+	mu       sync.Mutex
+	replies  []string
+	messages []map[string]interface{}
+}
+
+func (s *discordAPIStub) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/callback"):
+			var payload struct {
+				Data struct {
+					Content string `json:"content"`
+				} `json:"data"`
+			}
+			json.Unmarshal(body, &payload)
+			s.replies = append(s.replies, payload.Data.Content)
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/messages/@original"):
+			w.Write([]byte(`{"id":"msg-1"}`))
+		case strings.HasPrefix(r.URL.Path, "/channels/"):
+			if r.Header.Get("Authorization") != "Bot test-token" {
+				t.Errorf("missing bot authorization: %v", r.Header)
+			}
+			var msg map[string]interface{}
+			json.Unmarshal(body, &msg)
+			msg["path"] = r.URL.Path
+			s.messages = append(s.messages, msg)
+			w.Write([]byte(`{"id":"msg-2"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func recacCommand(t *testing.T, payload string) notify.DiscordInteraction {
+	t.Helper()
+	var interaction notify.DiscordInteraction
+	if err := json.Unmarshal([]byte(payload), &interaction); err != nil {
+		t.Fatal(err)
+	}
+	return interaction
+}
+
+func TestDiscordPoller_QueuesCommandAndThreadsUpdates(t *testing.T) {
+	stub := &discordAPIStub{}
+	server := httptest.NewServer(stub.handler(t))
+	defer server.Close()
+
+	p := NewDiscordPoller("test-token", "https://github.com/org/default.git")
+	p.APIBaseURL = server.URL
+	p.Allow.Users = []string{"u1"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	p.HandleInteraction(ctx, logger, recacCommand(t, `{
+		"id": "123", "application_id": "app", "type": 2, "token": "tok", "channel_id": "chan-1",
+		"data": {"name": "recac", "options": [{"name": "summary", "value": "Add a health endpoint"}]},
+		"member": {"user": {"id": "u1", "username": "alice"}}
+	}`))
+
+	items, err := p.Poll(ctx, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 queued item, got %d", len(items))
+	}
+	item := items[0]
+	if item.ID != "discord-123" || item.Summary != "Add a health endpoint" || item.RepoURL != "https://github.com/org/default" {
+		t.Errorf("unexpected work item: %+v", item)
+	}
+	if item.EnvVars["DISCORD_CHANNEL_ID"] != "chan-1" || item.EnvVars["RECAC_NOTIFY_THREAD"] != `{"discord_id":"msg-1"}` {
+		t.Errorf("expected notifications threaded under the reply, got %v", item.EnvVars)
+	}
+	if len(stub.replies) != 1 || !strings.Contains(stub.replies[0], "Queued discord-123") {
+		t.Errorf("unexpected interaction reply: %v", stub.replies)
+	}
+
+	if again, _ := p.Poll(ctx, logger); len(again) != 0 {
+		t.Errorf("expected queue to be drained, got %d items", len(again))
+	}
+
+	if err := p.RecordSpawnResult(ctx, item, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.UpdateStatus(ctx, item, "Failed", "boom"); err != nil {
+		t.Fatal(err)
+	}
+	if len(stub.messages) != 2 {
+		t.Fatalf("expected 2 channel messages, got %d", len(stub.messages))
+	}
+	last := stub.messages[1]
+	if last["path"] != "/channels/chan-1/messages" || last["content"] != "[discord-123] Failed: boom" {
+		t.Errorf("unexpected status message: %v", last)
+	}
+	if ref, _ := last["message_reference"].(map[string]interface{}); ref["message_id"] != "msg-1" {
+		t.Errorf("expected reply to the command response, got %v", last["message_reference"])
+	}
+
+	// Failed spawns are reported by the orchestrator via UpdateStatus only
+	p.RecordSpawnResult(ctx, item, errors.New("spawn failed"))
+	if len(stub.messages) != 2 {
+		t.Errorf("expected no extra message for failed spawn, got %d", len(stub.messages))
+	}
+}
+
+func TestDiscordPoller_RejectsCommandWithoutRepo(t *testing.T) {
+	stub := &discordAPIStub{}
+	server := httptest.NewServer(stub.handler(t))
+	defer server.Close()
+
+	p := NewDiscordPoller("test-token", "")
+	p.APIBaseURL = server.URL
+	p.Allow.Users = []string{"u1"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	p.HandleInteraction(context.Background(), logger, recacCommand(t, `{
+		"id": "124", "application_id": "app", "type": 2, "token": "tok", "channel_id": "chan-1",
+		"data": {"name": "recac", "options": [{"name": "summary", "value": "Fix bug"}]},
+		"user": {"id": "u1", "username": "alice"}
+	}`))
+
+	if items, _ := p.Poll(context.Background(), logger); len(items) != 0 {
+		t.Errorf("expected no queued items, got %+v", items)
+	}
+	if len(stub.replies) != 1 || !strings.Contains(stub.replies[0], "Usage: /recac") {
+		t.Errorf("expected usage reply, got %v", stub.replies)
+	}
+}
+
+func TestDiscordPoller_Allowlist(t *testing.T) {
+	tests := []struct {
+		name    string
+		allow   DiscordAllowlist
+		payload string
+		repo    string
+		queued  bool
+		reply   string
+	}{
+		{
+			name:    "no allowlist rejects everyone",
+			payload: `"guild_id": "g1", "channel_id": "chan-1", "member": {"user": {"id": "u1"}, "roles": ["r1"]}`,
+			reply:   "not allowed",
+		},
+		{
+			name:    "allowed user",
+			allow:   DiscordAllowlist{Users: []string{"u1"}},
+			payload: `"guild_id": "g1", "channel_id": "chan-1", "member": {"user": {"id": "u1"}}`,
+			queued:  true,
+		},
+		{
+			name:    "allowed role",
+			allow:   DiscordAllowlist{Roles: []string{"r1"}},
+			payload: `"guild_id": "g1", "channel_id": "chan-1", "member": {"user": {"id": "u2"}, "roles": ["r0", "r1"]}`,
+			queued:  true,
+		},
+		{
+			name:    "other guild",
+			allow:   DiscordAllowlist{Guilds: []string{"g1"}, Users: []string{"u1"}},
+			payload: `"guild_id": "g2", "channel_id": "chan-1", "member": {"user": {"id": "u1"}}`,
+			reply:   "not allowed",
+		},
+		{
+			name:    "direct message with a guild allowlist",
+			allow:   DiscordAllowlist{Guilds: []string{"g1"}, Users: []string{"u1"}},
+			payload: `"channel_id": "dm-1", "user": {"id": "u1"}`,
+			reply:   "not allowed",
+		},
+		{
+			name:    "other channel",
+			allow:   DiscordAllowlist{Channels: []string{"chan-1"}, Users: []string{"u1"}},
+			payload: `"guild_id": "g1", "channel_id": "chan-2", "member": {"user": {"id": "u1"}}`,
+			reply:   "not allowed",
+		},
+		{
+			name:    "repository outside the allowlist",
+			allow:   DiscordAllowlist{Users: []string{"u1"}},
+			payload: `"guild_id": "g1", "channel_id": "chan-1", "member": {"user": {"id": "u1"}}`,
+			repo:    "https://github.com/evil/repo",
+			reply:   "not in the allowlist",
+		},
+		{
+			name:    "allowlisted repository",
+			allow:   DiscordAllowlist{Users: []string{"u1"}, Repos: []string{"https://github.com/org/other.git"}},
+			payload: `"guild_id": "g1", "channel_id": "chan-1", "member": {"user": {"id": "u1"}}`,
+			repo:    "https://github.com/org/other",
+			queued:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &discordAPIStub{}
+			server := httptest.NewServer(stub.handler(t))
+			defer server.Close()
+
+			p := NewDiscordPoller("test-token", "https://github.com/org/default")
+			p.APIBaseURL = server.URL
+			p.Allow = tt.allow
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			options := `[{"name": "summary", "value": "Fix bug"}]`
+			if tt.repo != "" {
+				options = `[{"name": "summary", "value": "Fix bug"}, {"name": "repo", "value": "` + tt.repo + `"}]`
+			}
+			p.HandleInteraction(context.Background(), logger, recacCommand(t, `{
+				"id": "125", "application_id": "app", "type": 2, "token": "tok",
+				"data": {"name": "recac", "options": `+options+`}, `+tt.payload+`
+			}`))
+
+			items, _ := p.Poll(context.Background(), logger)
+			if tt.queued != (len(items) == 1) {
+				t.Fatalf("expected queued=%v, got %+v (replies %v)", tt.queued, items, stub.replies)
+			}
+			if tt.reply != "" && (len(stub.replies) != 1 || !strings.Contains(stub.replies[0], tt.reply)) {
+				t.Errorf("expected reply containing %q, got %v", tt.reply, stub.replies)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package orchestrator
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
@@ -37,9 +36,12 @@ func (p *FilePoller) Poll(ctx context.Context, logger *slog.Logger) ([]WorkItem,
 		return nil, fmt.Errorf("failed to read work file: %w", err)
 	}
 
-	var items []WorkItem
-	if err := json.Unmarshal(data, &items); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal work items: %w", err)
+	items, itemErrs, err := ParseWorkItems(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid work file %s: %w", p.path, err)
+	}
+	for _, itemErr := range itemErrs {
+		logger.Error("[FilePoller] Skipping invalid work item", "path", p.path, "error", itemErr)
 	}
 
 	// Filter out already claimed items
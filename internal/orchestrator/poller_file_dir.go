@@ -1,18 +1,42 @@
 package orchestrator
 
 import (
+	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 )
 
+// File lifecycles for FileDirPoller, selecting how a work file is retired once its spawn succeeds.
+const (
+	// FileLifecycleMove moves the file into processed/.
+	FileLifecycleMove = "move"
+	// FileLifecycleIndex leaves the file in place and records its name in the processed index.
+	FileLifecycleIndex = "index"
+)
+
+// processedIndexFile lists work files already handled in FileLifecycleIndex mode.
+const processedIndexFile = ".processed"
+
 // FileDirPoller reads work items from individual JSON files in a directory.
+// A file stays claimed while its agent is being spawned; afterwards it is
+// retired according to Lifecycle, and failed spawns always move to failed/.
+// Files that are not valid work items move to failed/ when first seen.
 type FileDirPoller struct {
 	watchDir     string
 	processedDir string
+	failedDir    string
+
+	// Lifecycle is FileLifecycleMove (default) or FileLifecycleIndex.
+	Lifecycle string
+
+	mu        sync.Mutex
+	inFlight  map[string]string // item ID -> file name
+	processed map[string]bool   // file names recorded in the processed index
 }
 
 func NewFileDirPoller(watchDir string) (*FileDirPoller, error) {
@@ -20,24 +44,50 @@ func NewFileDirPoller(watchDir string) (*FileDirPoller, error) {
 	if err := os.MkdirAll(processedDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create processed directory: %w", err)
 	}
+	failedDir := filepath.Join(watchDir, "failed")
+	if err := os.MkdirAll(failedDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create failed directory: %w", err)
+	}
 
 	return &FileDirPoller{
 		watchDir:     watchDir,
 		processedDir: processedDir,
+		failedDir:    failedDir,
+		Lifecycle:    FileLifecycleMove,
+		inFlight:     make(map[string]string),
 	}, nil
 }
 
 func (p *FileDirPoller) Poll(ctx context.Context, logger *slog.Logger) ([]WorkItem, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	entries, err := os.ReadDir(p.watchDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read watch directory: %w", err)
 	}
 
+	if p.Lifecycle == FileLifecycleIndex && p.processed == nil {
+		if p.processed, err = p.loadIndex(); err != nil {
+			return nil, err
+		}
+	}
+	if p.inFlight == nil {
+		p.inFlight = make(map[string]string)
+	}
+	claimed := make(map[string]bool, len(p.inFlight))
+	for _, name := range p.inFlight {
+		claimed[name] = true
+	}
+
 	var items []WorkItem
 	for _, entry := range entries {
 		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
 			continue
 		}
+		if claimed[entry.Name()] || p.processed[entry.Name()] {
+			continue
+		}
 
 		path := filepath.Join(p.watchDir, entry.Name())
 		logger.Info("[FileDirPoller] Found work file", "path", path)
@@ -48,29 +98,111 @@ func (p *FileDirPoller) Poll(ctx context.Context, logger *slog.Logger) ([]WorkIt
 			continue
 		}
 
-		var item WorkItem
-		if err := json.Unmarshal(data, &item); err != nil {
-			logger.Error("[FileDirPoller] Failed to unmarshal work item", "path", path, "error", err)
+		item, err := ParseWorkItem(data)
+		if err != nil {
+			logger.Error("[FileDirPoller] Moving invalid work item to failed/", "path", path, "error", err)
+			if err := p.reject(entry.Name(), err); err != nil {
+				logger.Error("[FileDirPoller] Failed to move invalid work item", "path", path, "error", err)
+			}
+			continue
+		}
+		if other, ok := p.inFlight[item.ID]; ok {
+			logger.Warn("[FileDirPoller] Work item already in progress, skipping", "id", item.ID, "path", path, "claimed_by", other)
 			continue
 		}
 
+		p.inFlight[item.ID] = entry.Name()
 		items = append(items, item)
-
-		// Move the file to the processed directory to prevent re-reading
-		processedPath := filepath.Join(p.processedDir, entry.Name())
-		if err := os.Rename(path, processedPath); err != nil {
-			logger.Error("[FileDirPoller] Failed to move processed file", "from", path, "to", processedPath, "error", err)
-			// If we can't move it, we can't process it, so we'll skip it for now.
-			// This could lead to retries, which is desirable.
-			items = items[:len(items)-1] // Remove the item we failed to move
-		}
 	}
 
 	return items, nil
 }
 
+// RecordSpawnResult retires the item's work file so it is not picked up again:
+// failed spawns move to failed/, successful ones are moved to processed/ or
+// added to the processed index depending on Lifecycle.
+func (p *FileDirPoller) RecordSpawnResult(ctx context.Context, item WorkItem, spawnErr error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	name, ok := p.inFlight[item.ID]
+	if !ok {
+		return fmt.Errorf("no work file claimed for item %s", item.ID)
+	}
+	delete(p.inFlight, item.ID)
+
+	path := filepath.Join(p.watchDir, name)
+	switch {
+	case spawnErr != nil:
+		return moveFile(path, filepath.Join(p.failedDir, name))
+	case p.Lifecycle == FileLifecycleIndex:
+		return p.appendIndex(name)
+	default:
+		return moveFile(path, filepath.Join(p.processedDir, name))
+	}
+}
+
+// reject moves a work file that cannot be parsed into failed/, recording why
+// next to it in <name>.error so it is not retried on every poll.
+func (p *FileDirPoller) reject(name string, reason error) error {
+	if err := moveFile(filepath.Join(p.watchDir, name), filepath.Join(p.failedDir, name)); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(p.failedDir, name+".error"), []byte(reason.Error()+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to record rejection reason: %w", err)
+	}
+	return nil
+}
+
 func (p *FileDirPoller) UpdateStatus(ctx context.Context, item WorkItem, status string, comment string) error {
 	// No-op for file poller usually, but could log
 	fmt.Printf("[FileDirPoller] Item %s status updated to %s: %s\n", item.ID, status, comment)
 	return nil
 }
+
+func (p *FileDirPoller) loadIndex() (map[string]bool, error) {
+	processed := make(map[string]bool)
+	f, err := os.Open(filepath.Join(p.watchDir, processedIndexFile))
+	if os.IsNotExist(err) {
+		return processed, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open processed index: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if name := strings.TrimSpace(scanner.Text()); name != "" {
+			processed[name] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read processed index: %w", err)
+	}
+	return processed, nil
+}
+
+func (p *FileDirPoller) appendIndex(name string) error {
+	f, err := os.OpenFile(filepath.Join(p.watchDir, processedIndexFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open processed index: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintln(f, name); err != nil {
+		return fmt.Errorf("failed to update processed index: %w", err)
+	}
+	if p.processed == nil {
+		p.processed = make(map[string]bool)
+	}
+	p.processed[name] = true
+	return nil
+}
+
+func moveFile(from, to string) error {
+	if err := os.Rename(from, to); err != nil {
+		return fmt.Errorf("failed to move %s to %s: %w", from, to, err)
+	}
+	return nil
+}
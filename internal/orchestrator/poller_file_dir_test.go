@@ -3,6 +3,7 @@ package orchestrator
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -65,6 +66,13 @@ func TestFileDirPoller_Poll(t *testing.T) {
 	assert.Equal(t, "task-1", items[0].ID)
 	assert.Equal(t, "Test Task", items[0].Summary)
 
+	// task1.json stays claimed until the spawn result is recorded
+	items, err = poller.Poll(ctx, logger)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	require.NoError(t, poller.RecordSpawnResult(ctx, WorkItem{ID: "task-1"}, nil))
+
 	// Verify file movement
 	// task1.json should be in processed
 	_, err = os.Stat(filepath.Join(processedDir, "task1.json"))
@@ -72,15 +80,71 @@ func TestFileDirPoller_Poll(t *testing.T) {
 	_, err = os.Stat(filepath.Join(tempDir, "task1.json"))
 	assert.True(t, os.IsNotExist(err))
 
-	// invalid.json should remain
+	// invalid.json is moved to failed/ with the reason
 	_, err = os.Stat(filepath.Join(tempDir, "invalid.json"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(tempDir, "failed", "invalid.json"))
+	assert.NoError(t, err)
+	reason, err := os.ReadFile(filepath.Join(tempDir, "failed", "invalid.json.error"))
 	assert.NoError(t, err)
+	assert.Contains(t, string(reason), "invalid")
 
 	// other.txt should remain
 	_, err = os.Stat(filepath.Join(tempDir, "other.txt"))
 	assert.NoError(t, err)
 }
 
+func TestFileDirPoller_FailedSpawn(t *testing.T) {
+	tempDir := t.TempDir()
+	poller, err := NewFileDirPoller(tempDir)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "task1.json"), []byte(`{"id":"task-1","summary":"Task 1"}`), 0644))
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+
+	items, err := poller.Poll(ctx, logger)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	require.NoError(t, poller.RecordSpawnResult(ctx, items[0], errors.New("spawn failed")))
+
+	_, err = os.Stat(filepath.Join(tempDir, "failed", "task1.json"))
+	assert.NoError(t, err)
+
+	items, err = poller.Poll(ctx, logger)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestFileDirPoller_IndexLifecycle(t *testing.T) {
+	tempDir := t.TempDir()
+	poller, err := NewFileDirPoller(tempDir)
+	require.NoError(t, err)
+	poller.Lifecycle = FileLifecycleIndex
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "task1.json"), []byte(`{"id":"task-1","summary":"Task 1"}`), 0644))
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+
+	items, err := poller.Poll(ctx, logger)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.NoError(t, poller.RecordSpawnResult(ctx, items[0], nil))
+
+	// The file stays in place but is recorded in the index
+	_, err = os.Stat(filepath.Join(tempDir, "task1.json"))
+	assert.NoError(t, err)
+
+	// A fresh poller (e.g. after a restart) honours the index
+	restarted, err := NewFileDirPoller(tempDir)
+	require.NoError(t, err)
+	restarted.Lifecycle = FileLifecycleIndex
+	items, err = restarted.Poll(ctx, logger)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
 func TestFileDirPoller_UpdateStatus(t *testing.T) {
 	tempDir := t.TempDir()
 	poller, _ := NewFileDirPoller(tempDir)
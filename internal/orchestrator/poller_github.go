@@ -1,13 +1,13 @@
 package orchestrator
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"recac/internal/github"
 	"regexp"
 	"strconv"
 	"strings"
@@ -30,7 +30,7 @@ type GitHubPoller struct {
 // NewGitHubPoller creates a new GitHubPoller.
 func NewGitHubPoller(token, owner, repo, label string) *GitHubPoller {
 	return &GitHubPoller{
-		BaseURL: "https://api.github.com",
+		BaseURL: github.DefaultBaseURL,
 		Token:   token,
 		Owner:   owner,
 		Repo:    repo,
@@ -88,13 +88,28 @@ func (p *GitHubPoller) Poll(ctx context.Context, logger *slog.Logger) ([]WorkIte
 
 		id := fmt.Sprintf("gh-%d", number)
 
+		var labels []string
+		if rawLabels, ok := issue["labels"].([]interface{}); ok {
+			for _, l := range rawLabels {
+				if lm, ok := l.(map[string]interface{}); ok {
+					if name, ok := lm["name"].(string); ok {
+						labels = append(labels, name)
+					}
+				}
+			}
+		}
+
 		item := WorkItem{
 			ID:          id,
 			Summary:     title,
 			Description: body,
 			RepoURL:     repoURL,
+			Model:       modelFromLabels(labels),
 			EnvVars: map[string]string{
-				"GITHUB_ISSUE": strconv.Itoa(number),
+				"GITHUB_ISSUE":      strconv.Itoa(number),
+				"GITHUB_ISSUE_REPO": p.Owner + "/" + p.Repo,
+				"RECAC_SUMMARY":     title,
+				"RECAC_DESCRIPTION": body,
 			},
 		}
 		items = append(items, item)
@@ -105,69 +120,35 @@ func (p *GitHubPoller) Poll(ctx context.Context, logger *slog.Logger) ([]WorkIte
 
 // UpdateStatus posts a comment and optionally closes the issue.
 func (p *GitHubPoller) UpdateStatus(ctx context.Context, item WorkItem, status string, comment string) error {
-	issueNumStr := strings.TrimPrefix(item.ID, "gh-")
+	issueNum, err := strconv.Atoi(strings.TrimPrefix(item.ID, "gh-"))
+	if err != nil {
+		return fmt.Errorf("invalid GitHub work item ID %q: %w", item.ID, err)
+	}
+	issues := p.issueClient()
 
 	// 1. Post Comment
 	if comment != "" {
-		if err := p.postComment(ctx, issueNumStr, comment); err != nil {
+		if err := issues.AddComment(ctx, issueNum, comment); err != nil {
 			return err
 		}
 	}
 
 	// 2. Close if Done
 	if strings.EqualFold(status, "Done") || strings.EqualFold(status, "Closed") {
-		return p.closeIssue(ctx, issueNumStr)
-	}
-
-	return nil
-}
-
-func (p *GitHubPoller) postComment(ctx context.Context, issueNum, body string) error {
-	url := fmt.Sprintf("%s/repos/%s/%s/issues/%s/comments", p.BaseURL, p.Owner, p.Repo, issueNum)
-
-	payload := map[string]string{"body": body}
-	jsonBody, _ := json.Marshal(payload)
-
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return err
-	}
-	p.setHeaders(req)
-
-	resp, err := p.Client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to post comment: %w", err)
+		return issues.CloseIssue(ctx, issueNum)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to post comment: %d", resp.StatusCode)
-	}
 	return nil
 }
 
-func (p *GitHubPoller) closeIssue(ctx context.Context, issueNum string) error {
-	url := fmt.Sprintf("%s/repos/%s/%s/issues/%s", p.BaseURL, p.Owner, p.Repo, issueNum)
-
-	payload := map[string]string{"state": "closed"}
-	jsonBody, _ := json.Marshal(payload)
-
-	req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return err
-	}
-	p.setHeaders(req)
-
-	resp, err := p.Client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to close issue: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to close issue: %d", resp.StatusCode)
+func (p *GitHubPoller) issueClient() *github.IssueClient {
+	return &github.IssueClient{
+		BaseURL:    p.BaseURL,
+		Token:      p.Token,
+		Owner:      p.Owner,
+		Repo:       p.Repo,
+		HTTPClient: p.Client,
 	}
-	return nil
 }
 
 func (p *GitHubPoller) setHeaders(req *http.Request) {
@@ -0,0 +1,406 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"recac/internal/github"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultProjectStatusField is the single-select field that holds a project item's column.
+	DefaultProjectStatusField = "Status"
+	// DefaultProjectInProgressColumn is where spawned items are moved, if the board has it.
+	DefaultProjectInProgressColumn = "In Progress"
+)
+
+const githubProjectItemsQuery = `query($org: String!, $number: Int!, $field: String!, $cursor: String) {
+  organization(login: $org) {
+    projectV2(number: $number) {
+      id
+      field(name: $field) {
+        ... on ProjectV2SingleSelectField { id options { id name } }
+      }
+      items(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          id
+          databaseId
+          fieldValueByName(name: $field) {
+            ... on ProjectV2ItemFieldSingleSelectValue { name }
+          }
+          content {
+            __typename
+            ... on DraftIssue { title body }
+            ... on Issue {
+              number title body state
+              repository { name nameWithOwner url }
+              labels(first: 20) { nodes { name } }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const githubProjectMoveMutation = `mutation($project: ID!, $item: ID!, $field: ID!, $option: String!) {
+  updateProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field, value: {singleSelectOptionId: $option}}) {
+    projectV2Item { id }
+  }
+}`
+
+// githubProjectItem is a work item's card on the project board.
+type githubProjectItem struct {
+	NodeID    string
+	IssueNum  int    // 0 for draft issues
+	IssueRepo string // "owner/repo" of a linked issue
+}
+
+// GitHubProjectPoller implements the Poller interface for a GitHub Projects (v2)
+// board. Each poll returns the items in one column (a value of the board's
+// Status field): linked issues and draft issues alike. Spawned items are moved
+// to the In Progress column, and status updates move cards and comment on
+// linked issues.
+type GitHubProjectPoller struct {
+	BaseURL          string
+	Token            string
+	Org              string
+	ProjectNumber    int
+	Column           string
+	StatusField      string // Defaults to DefaultProjectStatusField
+	InProgressColumn string // Defaults to DefaultProjectInProgressColumn; "" disables moving
+	Client           *http.Client
+
+	mu        sync.Mutex
+	projectID string
+	fieldID   string
+	options   map[string]string // lower-cased column name -> option ID
+	items     map[string]githubProjectItem
+	spawned   map[string]bool
+}
+
+// NewGitHubProjectPoller creates a poller for the column named columnName of
+// organization project number projectNumber.
+func NewGitHubProjectPoller(token, org string, projectNumber int, columnName string) *GitHubProjectPoller {
+	return &GitHubProjectPoller{
+		BaseURL:          github.DefaultBaseURL,
+		Token:            token,
+		Org:              org,
+		ProjectNumber:    projectNumber,
+		Column:           columnName,
+		StatusField:      DefaultProjectStatusField,
+		InProgressColumn: DefaultProjectInProgressColumn,
+		Client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		items:   make(map[string]githubProjectItem),
+		spawned: make(map[string]bool),
+	}
+}
+
+type githubProjectItemNode struct {
+	ID               string `json:"id"`
+	DatabaseID       int64  `json:"databaseId"`
+	FieldValueByName *struct {
+		Name string `json:"name"`
+	} `json:"fieldValueByName"`
+	Content *struct {
+		Typename   string `json:"__typename"`
+		Title      string `json:"title"`
+		Body       string `json:"body"`
+		Number     int    `json:"number"`
+		State      string `json:"state"`
+		Repository *struct {
+			Name          string `json:"name"`
+			NameWithOwner string `json:"nameWithOwner"`
+			URL           string `json:"url"`
+		} `json:"repository"`
+		Labels *struct {
+			Nodes []struct {
+				Name string `json:"name"`
+			} `json:"nodes"`
+		} `json:"labels"`
+	} `json:"content"`
+}
+
+// githubProjectField is the board's single-select Status field.
+type githubProjectField struct {
+	ID      string `json:"id"`
+	Options []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"options"`
+}
+
+type githubProjectItemsResponse struct {
+	Organization *struct {
+		ProjectV2 *struct {
+			ID    string              `json:"id"`
+			Field *githubProjectField `json:"field"`
+			Items struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []githubProjectItemNode `json:"nodes"`
+			} `json:"items"`
+		} `json:"projectV2"`
+	} `json:"organization"`
+}
+
+// Poll returns the open items in the configured column that this poller has
+// not already spawned.
+func (p *GitHubProjectPoller) Poll(ctx context.Context, logger *slog.Logger) ([]WorkItem, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	var items []WorkItem
+	cursor := ""
+	for {
+		vars := map[string]interface{}{
+			"org":    p.Org,
+			"number": p.ProjectNumber,
+			"field":  p.statusField(),
+		}
+		if cursor != "" {
+			vars["cursor"] = cursor
+		}
+		var data githubProjectItemsResponse
+		if err := p.graphQL(ctx, githubProjectItemsQuery, vars, &data); err != nil {
+			return nil, err
+		}
+		if data.Organization == nil || data.Organization.ProjectV2 == nil {
+			return nil, fmt.Errorf("github project %s/%d not found", p.Org, p.ProjectNumber)
+		}
+		project := data.Organization.ProjectV2
+		p.recordProject(project.ID, project.Field)
+
+		for _, node := range project.Items.Nodes {
+			if node.FieldValueByName == nil || !strings.EqualFold(node.FieldValueByName.Name, p.Column) {
+				continue
+			}
+			item, card, ok := p.workItem(node, logger)
+			if !ok {
+				continue
+			}
+			p.mu.Lock()
+			p.items[item.ID] = card
+			alreadySpawned := p.spawned[item.ID]
+			p.mu.Unlock()
+			if !alreadySpawned {
+				items = append(items, item)
+			}
+		}
+
+		if !project.Items.PageInfo.HasNextPage || project.Items.PageInfo.EndCursor == "" {
+			break
+		}
+		cursor = project.Items.PageInfo.EndCursor
+	}
+	return items, nil
+}
+
+// workItem maps a project item to a work item. Linked issues default to their
+// own repository; draft issues need a Repo: line in the body.
+func (p *GitHubProjectPoller) workItem(node githubProjectItemNode, logger *slog.Logger) (WorkItem, githubProjectItem, bool) {
+	content := node.Content
+	if content == nil {
+		return WorkItem{}, githubProjectItem{}, false
+	}
+	card := githubProjectItem{NodeID: node.ID}
+	repoURL := extractRepoURL(content.Body, RepoRegex)
+	env := map[string]string{
+		"RECAC_SUMMARY":     content.Title,
+		"RECAC_DESCRIPTION": content.Body,
+	}
+	var id string
+	var labels []string
+
+	switch content.Typename {
+	case "Issue":
+		if content.State != "" && !strings.EqualFold(content.State, "OPEN") {
+			return WorkItem{}, githubProjectItem{}, false
+		}
+		if content.Repository == nil {
+			return WorkItem{}, githubProjectItem{}, false
+		}
+		// Boards span organizations, so the owner keeps same-named repos apart
+		repo := content.Repository.NameWithOwner
+		if repo == "" {
+			repo = content.Repository.Name
+		}
+		id = fmt.Sprintf("gh-%s-%d", strings.ToLower(strings.ReplaceAll(repo, "/", "-")), content.Number)
+		if repoURL == "" {
+			repoURL = normalizeRepoURL(content.Repository.URL)
+		}
+		if content.Labels != nil {
+			for _, l := range content.Labels.Nodes {
+				labels = append(labels, l.Name)
+			}
+		}
+		card.IssueNum = content.Number
+		card.IssueRepo = content.Repository.NameWithOwner
+		env["GITHUB_ISSUE"] = strconv.Itoa(content.Number)
+		env["GITHUB_ISSUE_REPO"] = content.Repository.NameWithOwner
+	case "DraftIssue":
+		id = fmt.Sprintf("gh-draft-%d", node.DatabaseID)
+		if repoURL == "" {
+			logger.Warn("Skipping draft project item without a Repo: line", "id", id, "title", content.Title)
+			return WorkItem{}, githubProjectItem{}, false
+		}
+	default:
+		// Pull requests and redacted items are not work
+		return WorkItem{}, githubProjectItem{}, false
+	}
+
+	return WorkItem{
+		ID:          id,
+		Summary:     content.Title,
+		Description: content.Body,
+		RepoURL:     repoURL,
+		Model:       modelFromLabels(labels),
+		EnvVars:     env,
+	}, card, true
+}
+
+// RecordSpawnResult moves a spawned item's card to the In Progress column so
+// it is not picked up again. Failed spawns are retried on the next poll.
+func (p *GitHubProjectPoller) RecordSpawnResult(ctx context.Context, item WorkItem, spawnErr error) error {
+	if spawnErr != nil {
+		return nil
+	}
+	p.mu.Lock()
+	p.spawned[item.ID] = true
+	p.mu.Unlock()
+
+	if p.InProgressColumn == "" {
+		return nil
+	}
+	return p.moveCard(ctx, item, p.InProgressColumn)
+}
+
+// UpdateStatus comments on a linked issue (closing it when Done) and moves the
+// card to the column named status, if the board has one.
+func (p *GitHubProjectPoller) UpdateStatus(ctx context.Context, item WorkItem, status string, comment string) error {
+	p.mu.Lock()
+	card, ok := p.items[item.ID]
+	p.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("work item %s is not on github project %s/%d", item.ID, p.Org, p.ProjectNumber)
+	}
+
+	if card.IssueNum > 0 {
+		owner, repo, err := github.ParseRepo(card.IssueRepo)
+		if err != nil {
+			return err
+		}
+		issues := &github.IssueClient{BaseURL: p.BaseURL, Token: p.Token, Owner: owner, Repo: repo, HTTPClient: p.Client}
+		if comment != "" {
+			if err := issues.AddComment(ctx, card.IssueNum, comment); err != nil {
+				return err
+			}
+		}
+		if strings.EqualFold(status, "Done") || strings.EqualFold(status, "Closed") {
+			if err := issues.CloseIssue(ctx, card.IssueNum); err != nil {
+				return err
+			}
+		}
+	}
+	return p.moveCard(ctx, item, status)
+}
+
+// moveCard sets the item's Status field to column. Columns the board does not
+// have are ignored.
+func (p *GitHubProjectPoller) moveCard(ctx context.Context, item WorkItem, column string) error {
+	p.mu.Lock()
+	card, ok := p.items[item.ID]
+	optionID := p.options[strings.ToLower(column)]
+	projectID, fieldID := p.projectID, p.fieldID
+	p.mu.Unlock()
+	if !ok || optionID == "" || fieldID == "" {
+		return nil
+	}
+
+	return p.graphQL(ctx, githubProjectMoveMutation, map[string]interface{}{
+		"project": projectID,
+		"item":    card.NodeID,
+		"field":   fieldID,
+		"option":  optionID,
+	}, nil)
+}
+
+func (p *GitHubProjectPoller) recordProject(projectID string, field *githubProjectField) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.projectID = projectID
+	if field == nil {
+		return
+	}
+	p.fieldID = field.ID
+	p.options = make(map[string]string, len(field.Options))
+	for _, opt := range field.Options {
+		p.options[strings.ToLower(opt.Name)] = opt.ID
+	}
+}
+
+func (p *GitHubProjectPoller) statusField() string {
+	if p.StatusField == "" {
+		return DefaultProjectStatusField
+	}
+	return p.StatusField
+}
+
+// graphQL runs a GraphQL request and decodes its data into out (if non-nil).
+func (p *GitHubProjectPoller) graphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to marshal graphql request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", p.BaseURL+"/graphql", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "bearer "+p.Token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "recac-orchestrator")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("github graphql error: %d %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("github graphql error: %s", result.Errors[0].Message)
+	}
+	if out != nil && len(result.Data) > 0 {
+		if err := json.Unmarshal(result.Data, out); err != nil {
+			return fmt.Errorf("failed to decode graphql data: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const githubProjectItemsFixture = `{"data": {"organization": {"projectV2": {
+	"id": "PVT_1",
+	"field": {"id": "FIELD_1", "options": [
+		{"id": "OPT_TODO", "name": "Todo"},
+		{"id": "OPT_PROGRESS", "name": "In Progress"},
+		{"id": "OPT_DONE", "name": "Done"}
+	]},
+	"items": {"pageInfo": {"hasNextPage": false, "endCursor": ""}, "nodes": [
+		{"id": "ITEM_1", "databaseId": 11, "fieldValueByName": {"name": "Todo"}, "content": {
+			"__typename": "Issue", "number": 7, "title": "Add login", "body": "Users need to log in", "state": "OPEN",
+			"repository": {"name": "Web", "nameWithOwner": "acme/Web", "url": "https://github.com/acme/Web"},
+			"labels": {"nodes": [{"name": "model/gpt-4o"}]}
+		}},
+		{"id": "ITEM_2", "databaseId": 12, "fieldValueByName": {"name": "Todo"}, "content": {
+			"__typename": "DraftIssue", "title": "Spike caching", "body": "Try redis\nRepo: https://github.com/acme/api.git"
+		}},
+		{"id": "ITEM_3", "databaseId": 13, "fieldValueByName": {"name": "Todo"}, "content": {
+			"__typename": "DraftIssue", "title": "No repo", "body": "Nothing to clone"
+		}},
+		{"id": "ITEM_4", "databaseId": 14, "fieldValueByName": {"name": "Done"}, "content": {
+			"__typename": "Issue", "number": 8, "title": "Old", "body": "", "state": "OPEN",
+			"repository": {"name": "Web", "nameWithOwner": "acme/Web", "url": "https://github.com/acme/Web"}
+		}},
+		{"id": "ITEM_5", "databaseId": 15, "fieldValueByName": {"name": "Todo"}, "content": {
+			"__typename": "Issue", "number": 9, "title": "Closed", "body": "", "state": "CLOSED",
+			"repository": {"name": "Web", "nameWithOwner": "acme/Web", "url": "https://github.com/acme/Web"}
+		}},
+		{"id": "ITEM_6", "databaseId": 16, "fieldValueByName": null, "content": null}
+	]}
+}}}}`
+
+type githubProjectStub struct {
+	mu        sync.Mutex
+	moves     []map[string]interface{}
+	comments  []string
+	closedURL string
+}
+
+func (s *githubProjectStub) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch {
+		case r.URL.Path == "/graphql":
+			assert.Equal(t, "bearer test-token", r.Header.Get("Authorization"))
+			var req struct {
+				Query     string                 `json:"query"`
+				Variables map[string]interface{} `json:"variables"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			if strings.HasPrefix(req.Query, "mutation") {
+				s.moves = append(s.moves, req.Variables)
+				w.Write([]byte(`{"data": {"updateProjectV2ItemFieldValue": {"projectV2Item": {"id": "x"}}}}`))
+				return
+			}
+			assert.Equal(t, "acme", req.Variables["org"])
+			assert.Equal(t, float64(3), req.Variables["number"])
+			w.Write([]byte(githubProjectItemsFixture))
+		case r.URL.Path == "/repos/acme/Web/issues/7/comments":
+			body, _ := io.ReadAll(r.Body)
+			s.comments = append(s.comments, string(body))
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Path == "/repos/acme/Web/issues/7" && r.Method == http.MethodPatch:
+			s.closedURL = r.URL.Path
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestGitHubProjectPoller_PollColumn(t *testing.T) {
+	stub := &githubProjectStub{}
+	server := httptest.NewServer(stub.handler(t))
+	defer server.Close()
+
+	p := NewGitHubProjectPoller("test-token", "acme", 3, "todo")
+	p.BaseURL = server.URL
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	items, err := p.Poll(ctx, logger)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	issue := items[0]
+	assert.Equal(t, "gh-acme-web-7", issue.ID)
+	assert.Equal(t, "Add login", issue.Summary)
+	assert.Equal(t, "https://github.com/acme/Web", issue.RepoURL)
+	assert.Equal(t, "gpt-4o", issue.Model)
+	assert.Equal(t, "7", issue.EnvVars["GITHUB_ISSUE"])
+	assert.Equal(t, "acme/Web", issue.EnvVars["GITHUB_ISSUE_REPO"])
+
+	draft := items[1]
+	assert.Equal(t, "gh-draft-12", draft.ID)
+	assert.Equal(t, "https://github.com/acme/api", draft.RepoURL)
+	assert.NotContains(t, draft.EnvVars, "GITHUB_ISSUE")
+
+	// A spawned item moves to In Progress and is not handed out again
+	require.NoError(t, p.RecordSpawnResult(ctx, issue, nil))
+	require.Len(t, stub.moves, 1)
+	assert.Equal(t, map[string]interface{}{"project": "PVT_1", "item": "ITEM_1", "field": "FIELD_1", "option": "OPT_PROGRESS"}, stub.moves[0])
+
+	items, err = p.Poll(ctx, logger)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "gh-draft-12", items[0].ID)
+
+	// Done comments on and closes the linked issue, then moves the card
+	require.NoError(t, p.UpdateStatus(ctx, issue, "Done", "All finished"))
+	require.Len(t, stub.comments, 1)
+	assert.Contains(t, stub.comments[0], "All finished")
+	assert.Equal(t, "/repos/acme/Web/issues/7", stub.closedURL)
+	require.Len(t, stub.moves, 2)
+	assert.Equal(t, "OPT_DONE", stub.moves[1]["option"])
+
+	// Columns the board does not have leave the card alone
+	require.NoError(t, p.UpdateStatus(ctx, draft, "Failed", ""))
+	assert.Len(t, stub.moves, 2)
+}
+
+func TestGitHubProjectPoller_GraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"organization": null}, "errors": [{"message": "Could not resolve to an Organization"}]}`))
+	}))
+	defer server.Close()
+
+	p := NewGitHubProjectPoller("test-token", "nope", 1, "Todo")
+	p.BaseURL = server.URL
+
+	_, err := p.Poll(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Could not resolve to an Organization")
+}
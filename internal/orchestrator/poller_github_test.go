@@ -33,6 +33,10 @@ func TestGitHubPoller_Poll(t *testing.T) {
 					"number": 2,
 					"title":  "Test Issue 2",
 					"body":   "This is another issue without explicit repo.",
+					"labels": []map[string]interface{}{
+						{"name": "test-label"},
+						{"name": "model/gpt-4o"},
+					},
 				},
 				{
 					"number": 3,
@@ -63,6 +67,11 @@ func TestGitHubPoller_Poll(t *testing.T) {
 	assert.Equal(t, "gh-2", items[1].ID)
 	assert.Equal(t, "Test Issue 2", items[1].Summary)
 	assert.Equal(t, "https://github.com/owner/repo", items[1].RepoURL)
+	assert.Equal(t, "2", items[1].EnvVars["GITHUB_ISSUE"])
+	assert.Equal(t, "owner/repo", items[1].EnvVars["GITHUB_ISSUE_REPO"])
+	assert.Empty(t, items[0].Model)
+	assert.Equal(t, "gpt-4o", items[1].Model)
+	assert.Equal(t, "Test Issue 2", items[1].EnvVars["RECAC_SUMMARY"])
 }
 
 func TestGitHubPoller_UpdateStatus_Done(t *testing.T) {
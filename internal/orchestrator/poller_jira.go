@@ -16,6 +16,8 @@ type JiraPoller struct {
 	JQL     string
 	Label   string // Helper to construct JQL if JQL not provided
 	Project string // Helper to construct JQL
+	// MaxItems caps the number of work items returned per poll (0 = unlimited)
+	MaxItems int
 }
 
 func NewJiraPoller(client JiraClient, jql string) *JiraPoller {
@@ -25,7 +27,51 @@ func NewJiraPoller(client JiraClient, jql string) *JiraPoller {
 	}
 }
 
+// BuildJQL composes the poller query from a label and optional filters.
+// excludeTypes drops issues of the given types (e.g. "Epic"); statuses restricts
+// results to the given status names, otherwise issues that are not Done are returned.
+func BuildJQL(label string, excludeTypes, statuses []string) string {
+	var clauses []string
+	if label != "" {
+		clauses = append(clauses, fmt.Sprintf("labels = %s", quoteJQL(label)))
+	}
+	if types := nonEmpty(excludeTypes); len(types) > 0 {
+		clauses = append(clauses, fmt.Sprintf("issuetype not in (%s)", joinJQL(types)))
+	}
+	if names := nonEmpty(statuses); len(names) > 0 {
+		clauses = append(clauses, fmt.Sprintf("status in (%s)", joinJQL(names)))
+	} else {
+		clauses = append(clauses, "statusCategory != Done")
+	}
+	return strings.Join(clauses, " AND ") + " ORDER BY created ASC"
+}
+
+func nonEmpty(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func quoteJQL(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+func joinJQL(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = quoteJQL(v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
 func (p *JiraPoller) Poll(ctx context.Context, logger *slog.Logger) ([]WorkItem, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
 	// Default JQL if empty
 	if p.JQL == "" {
 		p.JQL = "statusCategory != Done ORDER BY created ASC"
@@ -84,29 +130,57 @@ func (p *JiraPoller) Poll(ctx context.Context, logger *slog.Logger) ([]WorkItem,
 		seenKeys[key] = true
 	}
 
-	// Construct WorkItems
-	for _, key := range finalKeys {
+	// Construct WorkItems. The cap is applied here, before deferred children are
+	// created for an epic, so tickets past it are left untouched until a later poll
+	for i, key := range finalKeys {
+		if p.MaxItems > 0 && len(curatedItems) >= p.MaxItems {
+			logger.Info("Work items truncated by max-items cap; remaining tickets will be picked up on a later poll",
+				"remaining", len(finalKeys)-i, "max_items", p.MaxItems)
+			break
+		}
 		issue := issueMap[key]
 		fields, _ := issue["fields"].(map[string]interface{})
 		summary, _ := fields["summary"].(string)
 		description := p.Client.ParseDescription(issue)
 
+		// Epics created incrementally carry their children; create them now that
+		// the epic is ready and pick them up on the next poll
+		if strings.Contains(description, jira.PendingChildrenHeader) {
+			if expander, ok := p.Client.(JiraPlanExpander); ok {
+				created, err := expander.ExpandPendingChildren(ctx, issue)
+				if err != nil {
+					logger.Error("[JiraPoller] Failed to create deferred child tickets", "ticket", key, "error", err)
+				} else {
+					logger.Info("[JiraPoller] Created deferred child tickets", "ticket", key, "children", created)
+				}
+				continue
+			}
+		}
+
 		// Extract Repo
 		repoURL := extractRepoURL(description, jira.RepoRegex)
 
-		// If no Repo found, we can't run agent really.
-		// Unless we allow no-repo agents?
-		// For now, require repo or skip/log.
+		// Without a repo the agent has nothing to clone, so skip the ticket
 		if repoURL == "" {
-			// Maybe log warning?
+			logger.Warn("[JiraPoller] Skipping ticket without a repo URL (add 'Repo: https://...' to the description)", "ticket", key)
 			continue
 		}
 
+		var labels []string
+		if rawLabels, ok := fields["labels"].([]interface{}); ok {
+			for _, l := range rawLabels {
+				if label, ok := l.(string); ok {
+					labels = append(labels, label)
+				}
+			}
+		}
+
 		item := WorkItem{
 			ID:          key,
 			Summary:     summary,
 			Description: description,
 			RepoURL:     repoURL,
+			Model:       modelFromLabels(labels),
 			EnvVars: map[string]string{
 				"JIRA_TICKET": key,
 			},
@@ -149,7 +223,7 @@ func extractRepoURL(text string, repoRegex *regexp.Regexp) string {
 	}
 	matches := repoRegex.FindStringSubmatch(text)
 	if len(matches) > 1 {
-		return strings.TrimSuffix(matches[1], ".git")
+		return normalizeRepoURL(matches[1])
 	}
 	return ""
 }
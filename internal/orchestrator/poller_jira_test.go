@@ -44,6 +44,19 @@ func (m *MockJiraClient) SmartTransition(ctx context.Context, issueID string, st
 	return args.Error(0)
 }
 
+// MockExpandingJiraClient also expands pending children
+type MockExpandingJiraClient struct {
+	MockJiraClient
+}
+
+func (m *MockExpandingJiraClient) ExpandPendingChildren(ctx context.Context, issue map[string]interface{}) ([]string, error) {
+	args := m.Called(ctx, issue)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func TestExtractRepoURL(t *testing.T) {
 	// This regex is a simplified version for testing purposes.
 	// The real regex is in the jira package.
@@ -58,6 +71,7 @@ func TestExtractRepoURL(t *testing.T) {
 		{"Valid HTTPS URL with .git", "some text\nRepo: https://github.com/user/repo.git\nmore text", "https://github.com/user/repo"},
 		{"Standard HTTPS", "Please fix this. Repo: https://github.com/org/repo.git", "https://github.com/org/repo"},
 		{"Standard HTTP", "Repo: http://github.com/org/repo", "http://github.com/org/repo"},
+		{"Trailing slash", "Repo: https://github.com/org/repo/", "https://github.com/org/repo"},
 		{"Case Insensitive", "repo: https://github.com/org/repo", "https://github.com/org/repo"},
 		{"In middle of text", "The repo is Repo: https://github.com/foo/bar and it is cool.", "https://github.com/foo/bar"},
 		{"No URL", "just some text without any url", ""},
@@ -226,4 +240,89 @@ func TestJiraPoller_Poll(t *testing.T) {
 		assert.Contains(t, workItems[0].EnvVars["RECAC_INJECTED_FEATURES"], "Feature B")
 		mockClient.AssertExpectations(t)
 	})
-}
\ No newline at end of file
+
+	t.Run("Max Items Caps Results", func(t *testing.T) {
+		mockClient := new(MockJiraClient)
+		poller := NewJiraPoller(mockClient, "status = 'To Do'")
+		poller.MaxItems = 1
+
+		ready := mockIssue("PROJ-5", "Task 5", "Repo: https://github.com/test/repo5")
+		mockClient.On("SearchIssues", ctx, "status = 'To Do'").Return([]map[string]interface{}{issue1, ready}, nil)
+		mockClient.On("GetBlockers", issue1).Return([]string{})
+		mockClient.On("ParseDescription", issue1).Return("Repo: https://github.com/test/repo1")
+		mockClient.On("GetBlockers", ready).Return([]string{})
+		mockClient.On("ParseDescription", ready).Return("Repo: https://github.com/test/repo5")
+
+		workItems, err := poller.Poll(ctx, silentLogger)
+
+		assert.NoError(t, err)
+		assert.Len(t, workItems, 1)
+	})
+
+	t.Run("Max Items Reached Before Expansion", func(t *testing.T) {
+		mockClient := new(MockExpandingJiraClient)
+		poller := NewJiraPoller(mockClient, "status = 'To Do'")
+		poller.MaxItems = 1
+
+		desc, err := jira.AppendPendingChildren("Repo: https://github.com/test/repo", []jira.TicketPlan{{Title: "Child"}})
+		assert.NoError(t, err)
+		epic := mockIssue("PROJ-EPIC", "Epic", desc)
+
+		mockClient.On("SearchIssues", ctx, "status = 'To Do'").Return([]map[string]interface{}{issue1, epic}, nil)
+		mockClient.On("GetBlockers", issue1).Return([]string{})
+		mockClient.On("ParseDescription", issue1).Return("Repo: https://github.com/test/repo1")
+		mockClient.On("GetBlockers", epic).Return([]string{})
+
+		workItems, err := poller.Poll(ctx, silentLogger)
+
+		assert.NoError(t, err)
+		assert.Len(t, workItems, 1)
+		mockClient.AssertNotCalled(t, "ExpandPendingChildren", ctx, epic)
+	})
+
+	t.Run("Expands Pending Children", func(t *testing.T) {
+		mockClient := new(MockExpandingJiraClient)
+		poller := NewJiraPoller(mockClient, "status = 'To Do'")
+
+		desc, err := jira.AppendPendingChildren("Repo: https://github.com/test/repo", []jira.TicketPlan{{Title: "Child"}})
+		assert.NoError(t, err)
+		epic := mockIssue("PROJ-EPIC", "Epic", desc)
+
+		mockClient.On("SearchIssues", ctx, "status = 'To Do'").Return([]map[string]interface{}{epic, issue1}, nil)
+		mockClient.On("GetBlockers", epic).Return([]string{})
+		mockClient.On("ParseDescription", epic).Return(desc)
+		mockClient.On("ExpandPendingChildren", ctx, epic).Return([]string{"PROJ-10"}, nil)
+		mockClient.On("GetBlockers", issue1).Return([]string{})
+		mockClient.On("ParseDescription", issue1).Return("Repo: https://github.com/test/repo1")
+
+		workItems, err := poller.Poll(ctx, silentLogger)
+
+		assert.NoError(t, err)
+		// The epic waits for the next poll, once its children exist
+		assert.Len(t, workItems, 1)
+		assert.Equal(t, "PROJ-1", workItems[0].ID)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestBuildJQL(t *testing.T) {
+	testCases := []struct {
+		name         string
+		label        string
+		excludeTypes []string
+		statuses     []string
+		expected     string
+	}{
+		{"Label Only", "recac-agent", nil, nil, `labels = "recac-agent" AND statusCategory != Done ORDER BY created ASC`},
+		{"No Label", "", nil, nil, `statusCategory != Done ORDER BY created ASC`},
+		{"Exclude Types", "recac-agent", []string{"Epic", " Sub-task "}, nil, `labels = "recac-agent" AND issuetype not in ("Epic", "Sub-task") AND statusCategory != Done ORDER BY created ASC`},
+		{"Statuses", "recac-agent", nil, []string{"To Do", "Ready"}, `labels = "recac-agent" AND status in ("To Do", "Ready") ORDER BY created ASC`},
+		{"Blank Entries Ignored", "", []string{""}, []string{" "}, `statusCategory != Done ORDER BY created ASC`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, BuildJQL(tc.label, tc.excludeTypes, tc.statuses))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// MockRepoURL is the repository used by generated mock work items.
+const MockRepoURL = "https://example.com/recac/mock-repo"
+
+// MockPoller serves a fixed set of work items without any external tracker,
+// for local development and tests. Each item is returned by a single Poll,
+// as if it had been claimed, and status updates are recorded.
+type MockPoller struct {
+	mu       sync.Mutex
+	pending  []WorkItem
+	statuses map[string]string
+}
+
+// NewMockPoller creates a MockPoller that hands out items on the next Poll.
+func NewMockPoller(items []WorkItem) *MockPoller {
+	return &MockPoller{
+		pending:  append([]WorkItem(nil), items...),
+		statuses: make(map[string]string),
+	}
+}
+
+// MockWorkItems generates n numbered work items (MOCK-1..MOCK-n).
+func MockWorkItems(n int) []WorkItem {
+	items := make([]WorkItem, 0, n)
+	for i := 1; i <= n; i++ {
+		items = append(items, WorkItem{
+			ID:          fmt.Sprintf("MOCK-%d", i),
+			Summary:     fmt.Sprintf("Mock task %d", i),
+			Description: "Generated by the mock poller.",
+			RepoURL:     MockRepoURL,
+		})
+	}
+	return items
+}
+
+// Add queues more items for the next Poll.
+func (p *MockPoller) Add(items ...WorkItem) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending = append(p.pending, items...)
+}
+
+func (p *MockPoller) Poll(ctx context.Context, logger *slog.Logger) ([]WorkItem, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	items := p.pending
+	p.pending = nil
+	return items, nil
+}
+
+func (p *MockPoller) UpdateStatus(ctx context.Context, item WorkItem, status string, comment string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.statuses == nil {
+		p.statuses = make(map[string]string)
+	}
+	p.statuses[item.ID] = status
+	return nil
+}
+
+// Status returns the last status recorded for an item.
+func (p *MockPoller) Status(id string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.statuses[id]
+}
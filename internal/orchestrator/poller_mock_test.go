@@ -0,0 +1,60 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestMockPollerAndSpawner_RunOnce(t *testing.T) {
+	poller := NewMockPoller(MockWorkItems(3))
+	poller.Add(WorkItem{ID: "NO-REPO", Summary: "Missing repo"})
+	spawner := NewMockSpawner()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	orch := New(poller, spawner, 0)
+	orch.Once = true
+	if err := orch.Run(context.Background(), logger); err != nil {
+		t.Fatal(err)
+	}
+
+	spawned := spawner.Spawned()
+	if len(spawned) != 3 {
+		t.Fatalf("expected 3 spawns, got %d", len(spawned))
+	}
+	for _, item := range spawned {
+		if item.RepoURL != MockRepoURL {
+			t.Errorf("expected mock repo for %s, got %q", item.ID, item.RepoURL)
+		}
+	}
+	if got := poller.Status("NO-REPO"); got != "Failed" {
+		t.Errorf("expected item without repo to be marked Failed, got %q", got)
+	}
+	if got := poller.Status("MOCK-1"); got != "" {
+		t.Errorf("expected no status for a spawned item, got %q", got)
+	}
+
+	// Items are handed out once
+	if items, _ := poller.Poll(context.Background(), logger); len(items) != 0 {
+		t.Errorf("expected drained poller, got %d items", len(items))
+	}
+}
+
+func TestMockSpawner_SpawnErr(t *testing.T) {
+	spawner := NewMockSpawner()
+	spawner.SpawnErr = errors.New("boom")
+
+	err := spawner.Spawn(context.Background(), MockWorkItems(1)[0])
+	if !errors.Is(err, spawner.SpawnErr) {
+		t.Errorf("expected configured error, got %v", err)
+	}
+	if len(spawner.Spawned()) != 0 {
+		t.Error("expected failed spawn not to be recorded")
+	}
+
+	if err := spawner.Cleanup(context.Background(), WorkItem{ID: "X"}); err != nil || len(spawner.Cleaned()) != 1 {
+		t.Errorf("expected cleanup to be recorded, got %v", spawner.Cleaned())
+	}
+}
@@ -25,6 +25,8 @@ type DockerSpawner struct {
 	Logger         *slog.Logger
 	SessionManager ISessionManager
 	GitClient      IGitClient
+	// GitHubToken, if set, mints the GitHub token each agent authenticates with.
+	GitHubToken GitHubTokenSource
 }
 
 func NewDockerSpawner(logger *slog.Logger, client DockerClient, image string, projectName string, poller Poller, provider, model string, sm ISessionManager) *DockerSpawner {
@@ -42,6 +44,14 @@ func NewDockerSpawner(logger *slog.Logger, client DockerClient, image string, pr
 }
 
 func (s *DockerSpawner) Spawn(ctx context.Context, item WorkItem) error {
+	if item.RepoURL == "" {
+		return fmt.Errorf("%w: %s", ErrNoRepoURL, item.ID)
+	}
+	githubEnv, err := githubTokenEnv(ctx, s.GitHubToken)
+	if err != nil {
+		return err
+	}
+
 	// 1. Create temporary workspace on host
 	tempDir, err := os.MkdirTemp("", fmt.Sprintf("recac-agent-%s-*", item.ID))
 	if err != nil {
@@ -81,6 +91,9 @@ func (s *DockerSpawner) Spawn(ctx context.Context, item WorkItem) error {
 		"--verbose",
 		"--repo-url", item.RepoURL, // Delegate cloning
 	}
+	if len(item.RepoURLs) > 0 {
+		agentCmd = append(agentCmd, "--repo-urls", strings.Join(item.RepoURLs, ","))
+	}
 
 	session := &runner.SessionState{
 		Name:           item.ID,
@@ -112,8 +125,8 @@ func (s *DockerSpawner) Spawn(ctx context.Context, item WorkItem) error {
 		if s.AgentProvider != "" {
 			envExports = append(envExports, fmt.Sprintf("export RECAC_PROVIDER=%s", shellquote.Join(s.AgentProvider)))
 		}
-		if s.AgentModel != "" {
-			envExports = append(envExports, fmt.Sprintf("export RECAC_MODEL=%s", shellquote.Join(s.AgentModel)))
+		if model := itemModel(item, s.AgentModel); model != "" {
+			envExports = append(envExports, fmt.Sprintf("export RECAC_MODEL=%s", shellquote.Join(model)))
 		}
 		envExports = append(envExports, "export GIT_TERMINAL_PROMPT=0")
 		envExports = append(envExports, fmt.Sprintf("export RECAC_PROJECT_ID=%s", shellquote.Join(item.ID)))
@@ -131,13 +144,19 @@ func (s *DockerSpawner) Spawn(ctx context.Context, item WorkItem) error {
 		if val := os.Getenv("RECAC_NOTIFICATIONS_SLACK_ENABLED"); val != "" {
 			envExports = append(envExports, fmt.Sprintf("export RECAC_NOTIFICATIONS_SLACK_ENABLED=%s", shellquote.Join(val)))
 		}
+		if val := os.Getenv("RECAC_GITHUB_CLOSE_ISSUES"); val != "" {
+			envExports = append(envExports, fmt.Sprintf("export RECAC_GITHUB_CLOSE_ISSUES=%s", shellquote.Join(val)))
+		}
 
 		for k, v := range item.EnvVars {
 			envExports = append(envExports, fmt.Sprintf("export %s=%s", k, shellquote.Join(v)))
 		}
 
-		secrets := []string{"JIRA_API_TOKEN", "JIRA_USERNAME", "JIRA_URL", "GITHUB_TOKEN", "GITHUB_API_KEY", "OPENAI_API_KEY", "ANTHROPIC_API_KEY", "GEMINI_API_KEY", "OPENROUTER_API_KEY", "RECAC_DB_TYPE", "RECAC_DB_URL"}
+		secrets := []string{"JIRA_API_TOKEN", "JIRA_USERNAME", "JIRA_URL", "GITHUB_TOKEN", "GITHUB_API_KEY", "GITLAB_TOKEN", "OPENAI_API_KEY", "ANTHROPIC_API_KEY", "GEMINI_API_KEY", "OPENROUTER_API_KEY", "OPENAI_API_KEYS", "ANTHROPIC_API_KEYS", "GEMINI_API_KEYS", "OPENROUTER_API_KEYS", "RECAC_DB_TYPE", "RECAC_DB_URL", "RECAC_FRESH", "RECAC_CHECKPOINT_INTERVAL", "RECAC_SAFE_MODE", "RECAC_CACHE_RESPONSES", "RECAC_RESPONSE_CACHE_DIR", "DISCORD_BOT_TOKEN"}
 		for _, secret := range secrets {
+			if _, ok := githubEnv[secret]; ok {
+				continue
+			}
 			if val := os.Getenv(secret); val != "" {
 				quotedVal := shellquote.Join(val)
 				envExports = append(envExports, fmt.Sprintf("export %s=%s", secret, quotedVal))
@@ -147,6 +166,12 @@ func (s *DockerSpawner) Spawn(ctx context.Context, item WorkItem) error {
 			}
 		}
 
+		for _, key := range []string{"GITHUB_TOKEN", "GITHUB_API_KEY", "RECAC_GITHUB_API_KEY"} {
+			if val, ok := githubEnv[key]; ok {
+				envExports = append(envExports, fmt.Sprintf("export %s=%s", key, shellquote.Join(val)))
+			}
+		}
+
 		envExports = append(envExports, fmt.Sprintf("export RECAC_HOST_WORKSPACE_PATH=%s", shellquote.Join(tempDir)))
 
 		// Propagate Agent Limits from Host (Default to 20 if unset)
@@ -164,6 +189,14 @@ func (s *DockerSpawner) Spawn(ctx context.Context, item WorkItem) error {
 			envExports = append(envExports, fmt.Sprintf("export RECAC_TASK_MAX_ITERATIONS=%s", shellquote.Join(val)))
 		}
 
+		if val := os.Getenv("RECAC_MAX_WORKSPACE_SIZE"); val != "" {
+			envExports = append(envExports, fmt.Sprintf("export RECAC_MAX_WORKSPACE_SIZE=%s", shellquote.Join(val)))
+		}
+
+		if val := os.Getenv("RECAC_MAX_QA_REJECTIONS"); val != "" {
+			envExports = append(envExports, fmt.Sprintf("export RECAC_MAX_QA_REJECTIONS=%s", shellquote.Join(val)))
+		}
+
 		cmdStr := "cd /workspace"
 		cmdStr += " && " + strings.Join(envExports, " && ")
 		cmdStr += " && " + shellquote.Join(agentCmd...) + " --allow-dirty"
@@ -113,19 +113,19 @@ func (m *MockGitClient) CreatePR(repoPath, title, body, base string) (string, er
 	return args.String(0), args.Error(1)
 }
 
-// Mock Poller (Minimal for this test)
-type MockPoller struct {
-	mock.Mock
+func (m *MockGitClient) AddWorktree(repoPath, path, branch string) error {
+	args := m.Called(repoPath, path, branch)
+	return args.Error(0)
 }
 
-func (m *MockPoller) Poll(ctx context.Context, logger *slog.Logger) ([]WorkItem, error) {
-	args := m.Called(ctx, logger)
-	return args.Get(0).([]WorkItem), args.Error(1)
+func (m *MockGitClient) RemoveWorktree(repoPath, path string) error {
+	args := m.Called(repoPath, path)
+	return args.Error(0)
 }
 
-func (m *MockPoller) UpdateStatus(ctx context.Context, item WorkItem, status string, comment string) error {
-	args := m.Called(ctx, item, status, comment)
-	return args.Error(0)
+func (m *MockGitClient) DefaultBranch(repoPath string) (string, error) {
+	args := m.Called(repoPath)
+	return args.String(0), args.Error(1)
 }
 
 func TestDockerSpawner_Spawn_Success(t *testing.T) {
@@ -2,13 +2,18 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"recac/internal/docker"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -104,8 +109,10 @@ func TestK8sSpawner_Spawn_PropagatesEnvVars(t *testing.T) {
 	// Set Environment Variables
 	os.Setenv("GITHUB_API_KEY", "test-github-key")
 	os.Setenv("OPENAI_API_KEY", "test-openai-key")
+	os.Setenv("ANTHROPIC_API_KEYS", "sk-ant-1,sk-ant-2")
 	defer os.Unsetenv("GITHUB_API_KEY")
 	defer os.Unsetenv("OPENAI_API_KEY")
+	defer os.Unsetenv("ANTHROPIC_API_KEYS")
 
 	item := WorkItem{
 		ID:      "TICKET-1",
@@ -133,10 +140,60 @@ func TestK8sSpawner_Spawn_PropagatesEnvVars(t *testing.T) {
 	assert.Equal(t, "test-github-key", envMap["GITHUB_API_KEY"], "GITHUB_API_KEY should be propagated")
 	assert.Equal(t, "test-github-key", envMap["RECAC_GITHUB_API_KEY"], "RECAC_GITHUB_API_KEY should be aliased to GITHUB_API_KEY")
 	assert.Equal(t, "test-openai-key", envMap["OPENAI_API_KEY"], "OPENAI_API_KEY should be propagated")
+	assert.Equal(t, "sk-ant-1,sk-ant-2", envMap["ANTHROPIC_API_KEYS"], "ANTHROPIC_API_KEYS should be propagated")
 	assert.Equal(t, "0", envMap["GIT_TERMINAL_PROMPT"], "GIT_TERMINAL_PROMPT should be 0")
 	assert.Equal(t, "20", envMap["RECAC_MAX_ITERATIONS"], "RECAC_MAX_ITERATIONS should be 20")
 }
 
+type fakeTokenSource struct {
+	token    string
+	err      error
+	validFor *time.Duration
+}
+
+func (f fakeTokenSource) TokenValidFor(ctx context.Context, d time.Duration) (string, error) {
+	if f.validFor != nil {
+		*f.validFor = d
+	}
+	return f.token, f.err
+}
+
+func TestK8sSpawner_Spawn_GitHubToken(t *testing.T) {
+	t.Setenv("GITHUB_API_KEY", "host-key")
+	t.Setenv("RECAC_GITHUB_APP_ID", "42")
+	t.Setenv("RECAC_GITHUB_PRIVATE_KEY_PATH", "/etc/recac/app.pem")
+
+	var validFor time.Duration
+	fakeClient := fake.NewSimpleClientset()
+	spawner := &K8sSpawner{
+		Client:      fakeClient,
+		Namespace:   "default",
+		Image:       "test-image",
+		Logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		GitHubToken: fakeTokenSource{token: "ghs_minted", validFor: &validFor},
+	}
+	require.NoError(t, spawner.Spawn(context.Background(), WorkItem{ID: "TICKET-1", RepoURL: "https://github.com/test/repo"}))
+
+	job, err := fakeClient.BatchV1().Jobs("default").Get(context.Background(), "recac-agent-ticket-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	envMap := make(map[string]string)
+	for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+		envMap[e.Name] = e.Value
+	}
+	assert.Equal(t, "ghs_minted", envMap["GITHUB_TOKEN"])
+	assert.Equal(t, "ghs_minted", envMap["GITHUB_API_KEY"], "the minted token replaces the host's")
+	assert.Equal(t, "ghs_minted", envMap["RECAC_GITHUB_API_KEY"])
+	assert.NotContains(t, envMap, "RECAC_GITHUB_APP_ID")
+	assert.NotContains(t, envMap, "RECAC_GITHUB_PRIVATE_KEY_PATH", "the App's key is not handed to agents")
+	assert.GreaterOrEqual(t, validFor, 55*time.Minute, "agents get a token with close to its full lifetime")
+
+	spawner.GitHubToken = fakeTokenSource{err: errors.New("boom")}
+	err = spawner.Spawn(context.Background(), WorkItem{ID: "TICKET-2", RepoURL: "https://github.com/test/repo"})
+	assert.ErrorContains(t, err, "failed to mint GitHub token")
+	_, err = fakeClient.BatchV1().Jobs("default").Get(context.Background(), "recac-agent-ticket-2", metav1.GetOptions{})
+	assert.Error(t, err, "no job is created without a token")
+}
+
 func TestK8sSpawner_Spawn_Lifecycle(t *testing.T) {
 	clientset := fake.NewSimpleClientset()
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -198,6 +255,51 @@ func TestK8sSpawner_Spawn_Lifecycle(t *testing.T) {
 	})
 }
 
+func TestK8sSpawner_Spawn_ModelOverride(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	spawner := &K8sSpawner{
+		Client:     clientset,
+		Namespace:  "test-ns",
+		Image:      "recac-agent:latest",
+		AgentModel: "gemini-pro",
+		Logger:     slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	item := WorkItem{ID: "TASK-9", RepoURL: "https://github.com/example/repo", Model: "gemini-ultra"}
+	assert.NoError(t, spawner.Spawn(context.Background(), item))
+
+	job, err := clientset.BatchV1().Jobs("test-ns").Get(context.Background(), "recac-agent-task-9", metav1.GetOptions{})
+	assert.NoError(t, err)
+	envMap := make(map[string]string)
+	for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+		envMap[e.Name] = e.Value
+	}
+	assert.Equal(t, "gemini-ultra", envMap["RECAC_MODEL"])
+}
+
+func TestSpawners_RequireRepoURL(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	item := WorkItem{ID: "TASK-NOREPO", Summary: "No repo"}
+
+	clientset := fake.NewSimpleClientset()
+	k8s := &K8sSpawner{Client: clientset, Namespace: "test-ns", Image: "recac-agent:latest", Logger: logger}
+	assert.ErrorIs(t, k8s.Spawn(context.Background(), item), ErrNoRepoURL)
+	jobs, err := clientset.BatchV1().Jobs("test-ns").List(context.Background(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, jobs.Items)
+
+	client := new(MockDockerClient)
+	docker := NewDockerSpawner(logger, client, "recac-agent:latest", "test-project", nil, "", "", new(MockSessionManager))
+	assert.ErrorIs(t, docker.Spawn(context.Background(), item), ErrNoRepoURL)
+	client.AssertNotCalled(t, "RunContainer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModelFromLabels(t *testing.T) {
+	assert.Equal(t, "", modelFromLabels(nil))
+	assert.Equal(t, "", modelFromLabels([]string{"agent", "model/"}))
+	assert.Equal(t, "claude-opus", modelFromLabels([]string{"agent", "model/claude-opus", "model/other"}))
+}
+
 func TestSanitizeK8sName(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -213,4 +315,39 @@ func TestSanitizeK8sName(t *testing.T) {
 	for _, tc := range tests {
 		assert.Equal(t, tc.expected, sanitizeK8sName(tc.input))
 	}
-}
\ No newline at end of file
+}
+func TestK8sSpawner_Spawn_RegistryAuth(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	spawner := &K8sSpawner{
+		Client:       clientset,
+		Namespace:    "test-ns",
+		Image:        "registry.example.com/recac-agent:latest",
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		RegistryAuth: docker.RegistryAuth{URL: "registry.example.com", Username: "bot", Password: "secret"},
+	}
+
+	item := WorkItem{ID: "TASK-10", RepoURL: "https://github.com/example/repo"}
+	assert.NoError(t, spawner.Spawn(context.Background(), item))
+
+	secret, err := clientset.CoreV1().Secrets("test-ns").Get(context.Background(), "recac-registry-auth", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+	assert.Contains(t, string(secret.Data[corev1.DockerConfigJsonKey]), "registry.example.com")
+
+	job, err := clientset.BatchV1().Jobs("test-ns").Get(context.Background(), "recac-agent-task-10", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "recac-registry-auth"}}, job.Spec.Template.Spec.ImagePullSecrets)
+
+	// A second spawn updates the existing secret
+	assert.NoError(t, spawner.Spawn(context.Background(), WorkItem{ID: "TASK-11", RepoURL: "https://github.com/example/repo"}))
+
+	// Without credentials, an existing secret is only referenced
+	spawner.RegistryAuth = docker.RegistryAuth{}
+	spawner.ImagePullSecret = "team-registry"
+	assert.NoError(t, spawner.Spawn(context.Background(), WorkItem{ID: "TASK-12", RepoURL: "https://github.com/example/repo"}))
+	job, err = clientset.BatchV1().Jobs("test-ns").Get(context.Background(), "recac-agent-task-12", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "team-registry"}}, job.Spec.Template.Spec.ImagePullSecrets)
+	_, err = clientset.CoreV1().Secrets("test-ns").Get(context.Background(), "team-registry", metav1.GetOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// MockSpawner records spawns instead of launching agents, for local
+// development and tests. Like the real spawners it rejects items without a
+// repository URL.
+type MockSpawner struct {
+	// SpawnErr, if set, is returned by every Spawn.
+	SpawnErr error
+	Logger   *slog.Logger
+
+	mu      sync.Mutex
+	spawned []WorkItem
+	cleaned []WorkItem
+}
+
+// NewMockSpawner creates a MockSpawner.
+func NewMockSpawner() *MockSpawner {
+	return &MockSpawner{}
+}
+
+func (s *MockSpawner) Spawn(ctx context.Context, item WorkItem) error {
+	if item.RepoURL == "" {
+		return fmt.Errorf("%w: %s", ErrNoRepoURL, item.ID)
+	}
+	if s.SpawnErr != nil {
+		return s.SpawnErr
+	}
+
+	s.mu.Lock()
+	s.spawned = append(s.spawned, item)
+	s.mu.Unlock()
+
+	if s.Logger != nil {
+		s.Logger.Info("[MockSpawner] Recorded spawn", "id", item.ID, "summary", item.Summary, "repo", item.RepoURL)
+	}
+	return nil
+}
+
+func (s *MockSpawner) Cleanup(ctx context.Context, item WorkItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cleaned = append(s.cleaned, item)
+	return nil
+}
+
+// Spawned returns the items spawned so far, in order.
+func (s *MockSpawner) Spawned() []WorkItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]WorkItem(nil), s.spawned...)
+}
+
+// Cleaned returns the items cleaned up so far, in order.
+func (s *MockSpawner) Cleaned() []WorkItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]WorkItem(nil), s.cleaned...)
+}
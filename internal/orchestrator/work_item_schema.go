@@ -0,0 +1,108 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// workItemSchema maps the properties accepted in work item files to their
+// canonical names. Keys are normalized so "repo_url", "repoUrl" and "RepoURL"
+// are all accepted. id and summary are required.
+var workItemSchema = map[string]string{
+	"id":          "id",
+	"summary":     "summary",
+	"description": "description",
+	"priority":    "priority",
+	"repourl":     "repo_url",
+	"repourls":    "repo_urls",
+	"model":       "model",
+	"envvars":     "env_vars",
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// ParseWorkItem validates a single work item object against the work item
+// schema and decodes it. The error lists every schema violation found.
+func ParseWorkItem(data []byte) (WorkItem, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return WorkItem{}, fmt.Errorf("invalid JSON object: %w", err)
+	}
+	if raw == nil {
+		return WorkItem{}, fmt.Errorf("expected a JSON object, got null")
+	}
+
+	var problems []string
+	values := make(map[string]json.RawMessage, len(raw))
+	for key, value := range raw {
+		name, ok := workItemSchema[normalizeFieldName(key)]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown field %q", key))
+			continue
+		}
+		values[name] = value
+	}
+
+	var item WorkItem
+	mistyped := make(map[string]bool)
+	strField := func(name string, dst *string) {
+		if value, ok := values[name]; ok && json.Unmarshal(value, dst) != nil {
+			mistyped[name] = true
+			problems = append(problems, fmt.Sprintf("field %q must be a string", name))
+		}
+	}
+	strField("id", &item.ID)
+	strField("summary", &item.Summary)
+	strField("description", &item.Description)
+	strField("priority", &item.Priority)
+	strField("repo_url", &item.RepoURL)
+	strField("model", &item.Model)
+	if value, ok := values["repo_urls"]; ok && json.Unmarshal(value, &item.RepoURLs) != nil {
+		problems = append(problems, `field "repo_urls" must be an array of strings`)
+	}
+	if value, ok := values["env_vars"]; ok && json.Unmarshal(value, &item.EnvVars) != nil {
+		problems = append(problems, `field "env_vars" must be an object of strings`)
+	}
+
+	for name, value := range map[string]string{"id": item.ID, "summary": item.Summary} {
+		if !mistyped[name] && strings.TrimSpace(value) == "" {
+			problems = append(problems, fmt.Sprintf("missing required field %q", name))
+		}
+	}
+
+	item.RepoURL = normalizeRepoURL(item.RepoURL)
+	for i, repoURL := range item.RepoURLs {
+		item.RepoURLs[i] = normalizeRepoURL(repoURL)
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return WorkItem{}, errors.New(strings.Join(problems, "; "))
+	}
+	return item, nil
+}
+
+// ParseWorkItems decodes a JSON array of work items. Entries that fail schema
+// validation are skipped and reported in itemErrs; err is only set when the
+// document itself is not a JSON array.
+func ParseWorkItems(data []byte) (items []WorkItem, itemErrs []error, err error) {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, nil, fmt.Errorf("work file must be a JSON array of work items: %w", err)
+	}
+
+	for i, entry := range entries {
+		item, err := ParseWorkItem(entry)
+		if err != nil {
+			itemErrs = append(itemErrs, fmt.Errorf("work item %d: %w", i, err))
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, itemErrs, nil
+}
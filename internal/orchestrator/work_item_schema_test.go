@@ -0,0 +1,64 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWorkItem(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    WorkItem
+		wantErr string
+	}{
+		{
+			name:  "all fields",
+			input: `{"id":"TASK-1","summary":"Login","description":"Add login","priority":"high","repo_url":"https://github.com/o/r","model":"gpt-4o","env_vars":{"A":"1"}}`,
+			want:  WorkItem{ID: "TASK-1", Summary: "Login", Description: "Add login", Priority: "high", RepoURL: "https://github.com/o/r", Model: "gpt-4o", EnvVars: map[string]string{"A": "1"}},
+		},
+		{
+			name:  "Go field names",
+			input: `{"ID":"TASK-2","Summary":"Logout","RepoURL":"https://github.com/o/r.git"}`,
+			want:  WorkItem{ID: "TASK-2", Summary: "Logout", RepoURL: "https://github.com/o/r"},
+		},
+		{
+			name:  "additional repos",
+			input: `{"id":"TASK-3","summary":"Cross-repo","repo_url":"https://github.com/o/svc","repo_urls":["https://github.com/o/lib.git/"]}`,
+			want:  WorkItem{ID: "TASK-3", Summary: "Cross-repo", RepoURL: "https://github.com/o/svc", RepoURLs: []string{"https://github.com/o/lib"}},
+		},
+		{name: "repo_urls not a list", input: `{"id":"T","summary":"x","repo_urls":"https://github.com/o/lib"}`, wantErr: `field "repo_urls" must be an array of strings`},
+		{name: "missing required", input: `{"description":"no id"}`, wantErr: `missing required field "id"; missing required field "summary"`},
+		{name: "wrong type", input: `{"id":42,"summary":"x"}`, wantErr: `field "id" must be a string`},
+		{name: "unknown field", input: `{"id":"T","summary":"x","sumary":"typo"}`, wantErr: `unknown field "sumary"`},
+		{name: "not an object", input: `["T"]`, wantErr: "invalid JSON object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWorkItem([]byte(tt.input))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseWorkItems_SkipsInvalidEntries(t *testing.T) {
+	items, itemErrs, err := ParseWorkItems([]byte(`[{"id":"T-1","summary":"ok"},{"id":"T-2"},{"id":"T-3","summary":"ok"}]`))
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "T-1", items[0].ID)
+	assert.Equal(t, "T-3", items[1].ID)
+	require.Len(t, itemErrs, 1)
+	assert.Contains(t, itemErrs[0].Error(), `work item 1: missing required field "summary"`)
+
+	_, _, err = ParseWorkItems([]byte(`{"id":"T-1"}`))
+	assert.ErrorContains(t, err, "must be a JSON array")
+}
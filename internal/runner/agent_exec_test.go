@@ -135,3 +135,29 @@ func TestSession_ProcessResponse_Timeout(t *testing.T) {
 		t.Errorf("Expected command to be marked as failed")
 	}
 }
+
+func TestCommandTimeout(t *testing.T) {
+	defer viper.Set("command_timeout", nil)
+	defer viper.Set("bash_timeout", 600)
+
+	viper.Set("bash_timeout", 0)
+	viper.Set("command_timeout", nil)
+	if got := commandTimeout(viper.GetViper()); got != DefaultCommandTimeout {
+		t.Errorf("Expected default %v, got %v", DefaultCommandTimeout, got)
+	}
+
+	viper.Set("bash_timeout", 30)
+	if got := commandTimeout(viper.GetViper()); got != 30*time.Second {
+		t.Errorf("Expected legacy bash_timeout 30s, got %v", got)
+	}
+
+	viper.Set("command_timeout", "2m")
+	if got := commandTimeout(viper.GetViper()); got != 2*time.Minute {
+		t.Errorf("Expected 2m, got %v", got)
+	}
+
+	viper.Set("command_timeout", 45)
+	if got := commandTimeout(viper.GetViper()); got != 45*time.Second {
+		t.Errorf("Expected 45s, got %v", got)
+	}
+}
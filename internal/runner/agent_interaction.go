@@ -8,10 +8,21 @@ import (
 	"recac/internal/agent/prompts"
 	"recac/internal/db"
 	"strings"
-
-	"github.com/spf13/viper"
 )
 
+// printPrompt writes the full prompt about to be sent to the agent, including
+// the history assembled into it, when PrintPrompt is set.
+func (s *Session) printPrompt(name, prompt string) {
+	if !s.PrintPrompt {
+		return
+	}
+	out := s.PromptOutput
+	if out == nil {
+		out = os.Stdout
+	}
+	fmt.Fprintf(out, "\n===== PROMPT: %s (iteration %d, %d chars) =====\n%s\n===== END PROMPT: %s =====\n\n", name, s.GetIteration(), len(prompt), prompt, name)
+}
+
 // SelectPrompt determines which prompt to send based on current state.
 func (s *Session) SelectPrompt() (string, string, bool, error) {
 	// 1. Initializer (Session 1)
@@ -26,7 +37,7 @@ func (s *Session) SelectPrompt() (string, string, bool, error) {
 			// Manager First: Skip Initializer, go straight to Manager prompt
 			// ... (existing logic for ManagerFirst)
 			qaReport := "Initial Planning Phase. No code implemented yet."
-			prompt, err := prompts.GetPrompt(prompts.ManagerReview, map[string]string{
+			prompt, err := s.getRolePrompt(prompts.ManagerReview, map[string]string{
 				"qa_report": qaReport,
 			})
 			return prompt, prompts.ManagerReview, true, err
@@ -71,7 +82,7 @@ func (s *Session) SelectPrompt() (string, string, bool, error) {
 			vars["stall_warning"] = fmt.Sprintf("CRITICAL WARNING: The Coding Agent has stalled for %d iterations. You must intervene. Review their recent history and provide specific redirection instructions or STOP the project.", s.StalledCount)
 		}
 
-		prompt, err := prompts.GetPrompt(prompts.ManagerReview, vars)
+		prompt, err := s.getRolePrompt(prompts.ManagerReview, vars)
 		return prompt, prompts.ManagerReview, true, err
 	}
 
@@ -176,72 +187,98 @@ func (s *Session) SelectPrompt() (string, string, bool, error) {
 		vars["read_only_paths"] = "All available files"
 	}
 
-	prompt, err := prompts.GetPrompt(prompts.CodingAgent, vars)
+	prompt, err := s.getRolePrompt(prompts.CodingAgent, vars)
+	if warning := s.repetitionWarning(); warning != "" && err == nil {
+		prompt += "\n\n" + warning
+	}
+	if warning := s.responseSizeWarning(); warning != "" && err == nil {
+		prompt += "\n\n" + warning
+	}
 	return prompt, prompts.CodingAgent, false, err
 }
 
+// resolveRoleAgent determines the provider, model and API key for a supporting agent role ("qa" or "manager").
+// Precedence: explicit session override, agents.<role>.* config, the session's coding provider/model, global config.
+// The API key is agents.<role>.api_key, then the provider's own key variable, then the global api_key.
+func (s *Session) resolveRoleAgent(role, provider, model, defaultModel string) (string, string, string) {
+	if provider == "" {
+		provider = s.config().GetString("agents." + role + ".provider")
+	}
+	if provider == "" {
+		provider = s.AgentProvider
+	}
+	if provider == "" {
+		provider = s.config().GetString("provider")
+	}
+	if provider == "" {
+		provider = "gemini"
+	}
+
+	if model == "" {
+		model = s.config().GetString("agents." + role + ".model")
+	}
+	if model == "" {
+		model = s.AgentModel
+	}
+	if model == "" {
+		model = s.config().GetString("model")
+	}
+	if model == "" {
+		model = defaultModel
+	}
+
+	// The global api_key belongs to the coding agent's provider, so a role on
+	// another provider uses that provider's key first
+	apiKey := s.config().GetString("agents." + role + ".api_key")
+	if apiKey == "" {
+		switch provider {
+		case "openrouter":
+			apiKey = os.Getenv("OPENROUTER_API_KEY")
+		case "gemini", "gemini-cli":
+			apiKey = os.Getenv("GEMINI_API_KEY")
+		case "openai":
+			apiKey = os.Getenv("OPENAI_API_KEY")
+		case "anthropic":
+			apiKey = os.Getenv("ANTHROPIC_API_KEY")
+		}
+	}
+	if apiKey == "" {
+		apiKey = s.config().GetString("api_key")
+	}
+
+	return provider, model, apiKey
+}
+
 // runQAAgent runs quality assurance checks on the feature list.
 // Returns error if QA fails, nil if QA passes.
 func (s *Session) runQAAgent(ctx context.Context) error {
+	// The completion policy gates QA: no point asking the agent while it is unmet
+	if err := s.checkCompletionPolicy(ctx); err != nil {
+		return err
+	}
+
 	s.Logger.Info("QA agent running quality checks")
 
+	provider, model, apiKey := s.resolveRoleAgent("qa", s.QAProvider, s.QAModel, "gemini-1.5-flash-latest")
 	var qaAgent agent.Agent
 	if s.QAAgent != nil {
 		qaAgent = s.QAAgent
 	} else {
 		var err error
-		// Resolve Config
-		provider := s.AgentProvider
-		if provider == "" {
-			provider = viper.GetString("agents.qa.provider")
-			if provider == "" {
-				provider = viper.GetString("provider") // Fallback to global setting
-				if provider == "" {
-					provider = "gemini"
-				}
-			}
-		}
-
-		model := s.AgentModel
-		if model == "" {
-			model = viper.GetString("agents.qa.model")
-			if model == "" {
-				model = viper.GetString("model") // Fallback to global setting
-				if model == "" {
-					model = "gemini-1.5-flash-latest" // Ultimate fallback
-				}
-			}
-		}
-		apiKey := viper.GetString("agents.qa.api_key")
-		if apiKey == "" {
-			// Fallback to global API key
-			apiKey = viper.GetString("api_key")
-			if apiKey == "" {
-				// Try provider-specific env vars
-				if provider == "openrouter" {
-					apiKey = os.Getenv("OPENROUTER_API_KEY")
-				} else if provider == "gemini" || provider == "gemini-cli" {
-					apiKey = os.Getenv("GEMINI_API_KEY")
-				} else if provider == "openai" {
-					apiKey = os.Getenv("OPENAI_API_KEY")
-				}
-
-				// Final catch-all if still empty (legacy support)
-				if apiKey == "" {
-					apiKey = os.Getenv("GEMINI_API_KEY")
-				}
-			}
-		}
-
 		s.Logger.Info("initializing QA agent", "provider", provider, "model", model)
 		qaAgent, err = agent.NewAgent(provider, apiKey, model, s.Workspace, s.Project)
 		if err != nil {
 			return fmt.Errorf("failed to create QA agent: %w", err)
 		}
+		qaAgent = agent.WithResponseCache(qaAgent, s.config().GetBool("cache_responses"), s.config().GetString("response_cache_dir"), provider, model)
+		// Count QA tokens in the session's state alongside the coding agent
+		if s.StateManager != nil {
+			agent.UseStateManager(qaAgent, s.StateManager)
+		}
 	}
 
 	// 1. Get Prompt
-	prompt, err := prompts.GetPrompt(prompts.QAAgent, nil)
+	prompt, err := s.getRolePrompt(prompts.QAAgent, nil)
 	if err != nil {
 		return fmt.Errorf("failed to load QA prompt: %w", err)
 	}
@@ -250,8 +287,9 @@ func (s *Session) runQAAgent(ctx context.Context) error {
 	s.clearSignal("QA_PASSED")
 
 	// 2. Send to Agent
+	s.printPrompt(prompts.QAAgent, prompt)
 	s.Logger.Info("sending verification instructions to QA agent")
-	response, err := qaAgent.Send(ctx, prompt) // Use qaAgent
+	response, err := qaAgent.Send(agent.WithUsageLabel(ctx, agent.RoleQA, model, s.GetIteration()), prompt) // Use qaAgent
 	if err != nil {
 		return fmt.Errorf("QA Agent failed to respond: %w", err)
 	}
@@ -289,72 +327,41 @@ func (s *Session) runQAAgent(ctx context.Context) error {
 func (s *Session) runManagerAgent(ctx context.Context) error {
 	s.Logger.Info("manager agent reviewing QA report")
 
+	provider, model, apiKey := s.resolveRoleAgent("manager", s.ManagerProvider, s.ManagerModel, "gemini-1.5-pro-latest")
 	var managerAgent agent.Agent
 	if s.ManagerAgent != nil {
 		managerAgent = s.ManagerAgent
 	} else {
 		var err error
-		// Resolve Config
-		provider := s.AgentProvider
-		if provider == "" {
-			provider = viper.GetString("agents.manager.provider")
-			if provider == "" {
-				provider = viper.GetString("provider") // Fallback to global setting
-				if provider == "" {
-					provider = "gemini-cli"
-				}
-			}
-		}
-		model := s.AgentModel
-		if model == "" {
-			model = viper.GetString("agents.manager.model")
-			if model == "" {
-				model = viper.GetString("model")
-				if model == "" {
-					model = "gemini-1.5-pro-latest"
-				}
-			}
-		}
-		apiKey := viper.GetString("agents.manager.api_key")
-		if apiKey == "" {
-			apiKey = viper.GetString("api_key")
-			if apiKey == "" {
-				// Try provider-specific env vars
-				if provider == "openrouter" {
-					apiKey = os.Getenv("OPENROUTER_API_KEY")
-				} else if provider == "gemini" || provider == "gemini-cli" {
-					apiKey = os.Getenv("GEMINI_API_KEY")
-				} else if provider == "openai" {
-					apiKey = os.Getenv("OPENAI_API_KEY")
-				}
-
-				if apiKey == "" {
-					apiKey = os.Getenv("GEMINI_API_KEY")
-				}
-			}
-		}
-
 		fmt.Printf("Initialising Manager Agent with provider: %s, model: %s\n", provider, model)
 		managerAgent, err = agent.NewAgent(provider, apiKey, model, s.Workspace, s.Project)
 		if err != nil {
 			return fmt.Errorf("failed to create manager agent: %w", err)
 		}
+		managerAgent = agent.WithResponseCache(managerAgent, s.config().GetBool("cache_responses"), s.config().GetString("response_cache_dir"), provider, model)
+		// Count Manager tokens in the session's state alongside the coding agent
+		if s.StateManager != nil {
+			agent.UseStateManager(managerAgent, s.StateManager)
+		}
 	}
 
 	features := s.loadFeatures()
 	qaReport := RunQA(features)
+	plan := s.loadManagerPlan()
 
-	// Create manager review prompt
-	prompt, err := prompts.GetPrompt(prompts.ManagerReview, map[string]string{
-		"qa_report": qaReport.String(),
+	// Create manager review prompt, with the plan of earlier reviews as context
+	prompt, err := s.getRolePrompt(prompts.ManagerReview, map[string]string{
+		"qa_report":    qaReport.String(),
+		"manager_plan": plan.String(),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to load manager review prompt: %w", err)
 	}
 
 	// Send to agent for review
+	s.printPrompt(prompts.ManagerReview, prompt)
 	s.Logger.Info("sending QA report to manager agent")
-	response, err := managerAgent.Send(ctx, prompt) // Use managerAgent
+	response, err := managerAgent.Send(agent.WithUsageLabel(ctx, agent.RoleManager, model, s.GetIteration()), prompt) // Use managerAgent
 	if err != nil {
 		return fmt.Errorf("manager review request failed: %w", err)
 	}
@@ -369,18 +376,21 @@ func (s *Session) runManagerAgent(ctx context.Context) error {
 	// Check for PROJECT_SIGNED_OFF signal
 	if s.hasSignal("PROJECT_SIGNED_OFF") {
 		s.Logger.Info("manager approved, project signed off via signal")
+		s.recordManagerReview(plan, response, true, qaReport.CompletionRatio)
 		return nil
 	}
 
-	// Fallback to legacy ratio check if no explicit signal was given
-	if qaReport.CompletionRatio >= 1.0 {
-		s.Logger.Info("manager approved (legacy/fallback), all features passing")
+	// Fallback to the completion policy if no explicit signal was given
+	if len(features) > 0 && LoadCompletionPolicy(s.config()).Unmet(features) == "" {
+		s.Logger.Info("manager approved (legacy/fallback), completion policy met")
+		s.recordManagerReview(plan, response, true, qaReport.CompletionRatio)
 		return nil
 	}
 
 	// Manager rejected or didn't explicitly sign off
 	// Manager rejected or didn't explicitly sign off
 	s.Logger.Info("manager rejected or pending, project not signed off")
+	s.recordManagerReview(plan, response, false, qaReport.CompletionRatio)
 	s.clearSignal("QA_PASSED")
 	s.clearSignal("COMPLETED")
 	return fmt.Errorf("manager review did not result in sign-off (ratio: %.2f)", qaReport.CompletionRatio)
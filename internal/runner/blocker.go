@@ -0,0 +1,29 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"recac/internal/db"
+	"recac/internal/notify"
+)
+
+// reportBlocker announces the blocker the agent raised, with instructions for
+// resolving it. Humans are notified once per blocker, not on every iteration
+// the session stays blocked.
+func (s *Session) reportBlocker(ctx context.Context, b db.Blocker) {
+	fmt.Printf("\n!!! AGENT BLOCKED: %s !!!\n", b)
+	s.emitEvent(EventBlocker, map[string]interface{}{
+		"source":           "signal",
+		"type":             b.Type,
+		"message":          b.Message,
+		"suggested_action": b.SuggestedAction,
+		"related_feature":  b.RelatedFeature,
+	})
+
+	if b == s.notifiedBlocker {
+		return
+	}
+	s.notifiedBlocker = b
+	s.forceNotify(ctx, notify.EventUserInteraction, fmt.Sprintf("Project %s Blocked: %s", s.Project, b))
+}
@@ -3,6 +3,8 @@ package runner
 import (
 	"context"
 	"log/slog"
+	"path/filepath"
+	"recac/internal/db"
 	"recac/internal/notify"
 	"strings"
 	"testing"
@@ -87,3 +89,47 @@ func TestProcessResponse_BlockerFalsePositives(t *testing.T) {
 		delete(mockDocker.Files, tc.filename)
 	}
 }
+
+func TestProcessResponse_StructuredBlocker(t *testing.T) {
+	store, err := db.NewSQLiteStore(filepath.Join(t.TempDir(), ".recac.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	spy := &SpyNotifier{}
+	s := &Session{
+		DBStore:  store,
+		Logger:   slog.Default(),
+		Notifier: spy,
+		Project:  "test-project",
+	}
+	blocker := db.Blocker{Type: db.BlockerCredentials, Message: "Stripe key missing", RelatedFeature: "F-2"}
+	if err := db.SetBlocker(store, "test-project", blocker); err != nil {
+		t.Fatal(err)
+	}
+
+	// Blocked on every iteration, but humans hear about it once
+	for i := 0; i < 2; i++ {
+		if _, err := s.ProcessResponse(context.Background(), "no commands"); err != ErrBlocker {
+			t.Fatalf("Expected ErrBlocker, got %v", err)
+		}
+	}
+	if len(spy.Messages) != 1 {
+		t.Fatalf("Expected one notification, got %d", len(spy.Messages))
+	}
+	msg := spy.Messages[0].Message
+	if !strings.Contains(msg, "[credentials] Stripe key missing") || !strings.Contains(msg, "Feature: F-2") || !strings.Contains(msg, "Provide the missing credentials") {
+		t.Errorf("Expected the blocker with its resolution instructions, got %q", msg)
+	}
+
+	// A new blocker is announced again
+	blocker.Type = db.BlockerAmbiguousSpec
+	if err := db.SetBlocker(store, "test-project", blocker); err != nil {
+		t.Fatal(err)
+	}
+	s.ProcessResponse(context.Background(), "no commands")
+	if len(spy.Messages) != 2 || !strings.Contains(spy.Messages[1].Message, "Clarify the specification") {
+		t.Errorf("Expected a notification for the new blocker, got %+v", spy.Messages)
+	}
+}
@@ -0,0 +1,185 @@
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"recac/internal/docker"
+)
+
+// CheckpointFile records the latest container checkpoint in the workspace,
+// so a rerun after a crash can start from it.
+const CheckpointFile = ".recac_checkpoint.json"
+
+// checkpointRepo is the image repository container checkpoints are committed to.
+const checkpointRepo = "recac-checkpoint"
+
+var checkpointTagInvalid = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// Checkpoint describes a snapshot of the agent container's filesystem.
+type Checkpoint struct {
+	Image     string    `json:"image"`
+	ImageID   string    `json:"image_id"`
+	Iteration int       `json:"iteration"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CheckpointImage returns the image the containers of project running in
+// workspace are checkpointed to. The tag includes a hash of the workspace path
+// so two workspaces of one project never share it; each checkpoint replaces
+// the previous one.
+func CheckpointImage(project, workspace string) string {
+	tag := strings.Trim(checkpointTagInvalid.ReplaceAllString(strings.ToLower(project), "-"), "-.")
+	if tag == "" {
+		tag = "default"
+	}
+	if len(tag) > 115 {
+		tag = tag[:115]
+	}
+	if abs, err := filepath.Abs(workspace); err == nil {
+		workspace = abs
+	}
+	sum := sha256.Sum256([]byte(workspace))
+	return checkpointRepo + ":" + tag + "-" + hex.EncodeToString(sum[:6])
+}
+
+// checkpointContainer commits the agent container to CheckpointImage once
+// CheckpointInterval has passed since the last checkpoint. The workspace is
+// a bind mount and already lives on the host, so the image captures what the
+// agent changed elsewhere, such as installed packages and toolchains.
+func (s *Session) checkpointContainer(ctx context.Context) {
+	if s.CheckpointInterval <= 0 || s.UseLocalAgent || s.Docker == nil {
+		return
+	}
+	containerID := s.GetContainerID()
+	if containerID == "" || containerID == "local" {
+		return
+	}
+	// The first call starts the clock rather than committing right away
+	if s.lastCheckpoint.IsZero() {
+		s.lastCheckpoint = time.Now()
+		return
+	}
+	if time.Since(s.lastCheckpoint) < s.CheckpointInterval {
+		return
+	}
+
+	committer, ok := s.Docker.(ContainerCommitter)
+	if !ok {
+		s.Logger.Warn("docker client cannot commit containers, checkpoints disabled")
+		s.CheckpointInterval = 0
+		return
+	}
+
+	s.lastCheckpoint = time.Now()
+	image := CheckpointImage(s.Project, s.Workspace)
+	id, err := committer.CommitContainer(ctx, containerID, image)
+	if err != nil {
+		s.Logger.Warn("failed to checkpoint container", "container", containerID, "error", err)
+		return
+	}
+	cp := Checkpoint{Image: image, ImageID: id, Iteration: s.GetIteration(), CreatedAt: s.lastCheckpoint}
+	if err := writeCheckpoint(s.Workspace, cp); err != nil {
+		s.Logger.Warn("failed to record checkpoint", "error", err)
+		return
+	}
+	s.Logger.Info("checkpointed container", "image", image, "iteration", cp.Iteration)
+}
+
+// restoreCheckpoint switches the session to the image of the workspace's
+// last checkpoint, if checkpoints are enabled and it still exists locally. It
+// reports whether it did, in which case the image needs no pull or build.
+//
+// The checkpoint record lives in the workspace, where the agent can write, so
+// it is only trusted to name an image ID that this workspace's checkpoint tag
+// still points at. The session then runs that ID, never a tag.
+func (s *Session) restoreCheckpoint(ctx context.Context) bool {
+	if s.CheckpointInterval <= 0 {
+		return false
+	}
+	cp, err := LoadCheckpoint(s.Workspace)
+	if err != nil {
+		fmt.Printf("Warning: Failed to read checkpoint: %v\n", err)
+		return false
+	}
+	if cp == nil {
+		return false
+	}
+	// A checkpoint is not the pinned image, so it can never pass its digest check
+	if _, digest := docker.SplitDigest(s.Image); digest != "" || s.ImageDigest != "" {
+		fmt.Printf("Warning: Not resuming from checkpoint, %s is pinned to a digest.\n", s.Image)
+		return false
+	}
+
+	expected := CheckpointImage(s.Project, s.Workspace)
+	if cp.Image != expected || cp.ImageID == "" {
+		fmt.Printf("Warning: Ignoring checkpoint %q, expected an image ID tagged %s.\n", cp.Image, expected)
+		return false
+	}
+	digester, ok := s.Docker.(ImageDigester)
+	if !ok {
+		return false
+	}
+	ids, err := digester.ImageDigests(ctx, expected)
+	if err != nil || !slices.Contains(ids, cp.ImageID) {
+		fmt.Printf("Warning: Checkpoint image %s (%s) is missing, starting from %s.\n", cp.Image, cp.ImageID, s.Image)
+		return false
+	}
+
+	fmt.Printf("Resuming from checkpoint %s (iteration %d, %s).\n", cp.Image, cp.Iteration, cp.CreatedAt.Format(time.RFC3339))
+	s.Image = cp.ImageID
+	return true
+}
+
+// isCheckpointImage reports whether image is a restored checkpoint, which
+// only exists locally.
+func isCheckpointImage(image string) bool {
+	return strings.HasPrefix(image, checkpointRepo+":") || strings.HasPrefix(image, "sha256:")
+}
+
+// clearCheckpoint forgets the workspace's checkpoint once the session has
+// finished, so the next session starts from a clean image. The image itself
+// is kept until the next checkpoint replaces it.
+func (s *Session) clearCheckpoint() {
+	if err := os.Remove(filepath.Join(s.Workspace, CheckpointFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		s.Logger.Warn("failed to remove checkpoint record", "error", err)
+	}
+}
+
+// LoadCheckpoint reads the checkpoint recorded in workspace. It returns nil
+// when there is none.
+func LoadCheckpoint(workspace string) (*Checkpoint, error) {
+	data, err := os.ReadFile(filepath.Join(workspace, CheckpointFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", CheckpointFile, err)
+	}
+	if cp.Image == "" {
+		return nil, nil
+	}
+	return &cp, nil
+}
+
+func writeCheckpoint(workspace string, cp Checkpoint) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(workspace, CheckpointFile), data, 0644)
+}
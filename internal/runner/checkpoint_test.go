@@ -0,0 +1,147 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointImage(t *testing.T) {
+	assert.Regexp(t, `^recac-checkpoint:proj-123-[0-9a-f]{12}$`, CheckpointImage("PROJ 123", "/ws/a"))
+	assert.Regexp(t, `^recac-checkpoint:default-[0-9a-f]{12}$`, CheckpointImage("", "/ws/a"))
+	assert.Equal(t, CheckpointImage("proj", "/ws/a"), CheckpointImage("proj", "/ws/a/"))
+	assert.NotEqual(t, CheckpointImage("proj", "/ws/a"), CheckpointImage("proj", "/ws/b"), "workspaces of one project must not share a checkpoint")
+}
+
+func TestSession_CheckpointContainer(t *testing.T) {
+	workspace := t.TempDir()
+	var committed []string
+	s := &Session{
+		Workspace:          workspace,
+		Project:            "proj",
+		ContainerID:        "container-1",
+		CheckpointInterval: time.Minute,
+		Logger:             slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Docker: &MockDockerClient{CommitFunc: func(ctx context.Context, containerID, ref string) (string, error) {
+			committed = append(committed, containerID+"->"+ref)
+			return "sha256:abc", nil
+		}},
+	}
+	ctx := context.Background()
+
+	// The first call only starts the clock, and nothing is due before the interval
+	s.checkpointContainer(ctx)
+	s.checkpointContainer(ctx)
+	assert.Empty(t, committed)
+
+	s.lastCheckpoint = time.Now().Add(-2 * time.Minute)
+	s.Iteration = 7
+	s.checkpointContainer(ctx)
+	image := CheckpointImage("proj", workspace)
+	assert.Equal(t, []string{"container-1->" + image}, committed)
+
+	cp, err := LoadCheckpoint(workspace)
+	require.NoError(t, err)
+	require.NotNil(t, cp)
+	assert.Equal(t, image, cp.Image)
+	assert.Equal(t, "sha256:abc", cp.ImageID)
+	assert.Equal(t, 7, cp.Iteration)
+
+	s.clearCheckpoint()
+	cp, err = LoadCheckpoint(workspace)
+	require.NoError(t, err)
+	assert.Nil(t, cp)
+}
+
+func TestSession_RestoreCheckpoint(t *testing.T) {
+	workspace := t.TempDir()
+	image := CheckpointImage("proj", workspace)
+	tagged := map[string][]string{image: {"sha256:abc"}}
+	s := &Session{
+		Workspace:          workspace,
+		Project:            "proj",
+		Image:              "recac-agent:latest",
+		CheckpointInterval: time.Minute,
+		Docker: &MockDockerClient{ImageDigestsFunc: func(ctx context.Context, ref string) ([]string, error) {
+			if ids, ok := tagged[ref]; ok {
+				return ids, nil
+			}
+			return nil, errors.New("image not found")
+		}},
+	}
+	ctx := context.Background()
+
+	assert.False(t, s.restoreCheckpoint(ctx), "no checkpoint recorded")
+
+	require.NoError(t, writeCheckpoint(workspace, Checkpoint{Image: image, ImageID: "sha256:abc", Iteration: 3, CreatedAt: time.Now()}))
+	delete(tagged, image)
+	assert.False(t, s.restoreCheckpoint(ctx), "checkpoint image is gone")
+	assert.Equal(t, "recac-agent:latest", s.Image)
+
+	// The tag was moved to a newer checkpoint than the recorded one
+	tagged[image] = []string{"sha256:def"}
+	assert.False(t, s.restoreCheckpoint(ctx))
+
+	tagged[image] = []string{"sha256:abc"}
+	assert.True(t, s.restoreCheckpoint(ctx))
+	assert.Equal(t, "sha256:abc", s.Image, "the checkpoint is run by image ID, not by tag")
+
+	// --fresh discards the checkpoint along with the rest of the session state
+	_, err := WipeWorkspaceState(workspace)
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(workspace, CheckpointFile))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSession_RestoreCheckpoint_Untrusted(t *testing.T) {
+	ctx := context.Background()
+	newSession := func(t *testing.T, cp Checkpoint) *Session {
+		workspace := t.TempDir()
+		require.NoError(t, writeCheckpoint(workspace, cp))
+		return &Session{
+			Workspace:          workspace,
+			Project:            "proj",
+			Image:              "recac-agent:latest",
+			CheckpointInterval: time.Minute,
+			Docker: &MockDockerClient{ImageDigestsFunc: func(ctx context.Context, ref string) ([]string, error) {
+				return []string{"sha256:abc"}, nil
+			}},
+		}
+	}
+
+	t.Run("image outside the checkpoint repository", func(t *testing.T) {
+		s := newSession(t, Checkpoint{Image: "evil/image:latest", ImageID: "sha256:abc"})
+		assert.False(t, s.restoreCheckpoint(ctx))
+		assert.Equal(t, "recac-agent:latest", s.Image)
+	})
+
+	t.Run("another workspace's checkpoint", func(t *testing.T) {
+		s := newSession(t, Checkpoint{Image: CheckpointImage("proj", "/elsewhere"), ImageID: "sha256:abc"})
+		assert.False(t, s.restoreCheckpoint(ctx))
+	})
+
+	t.Run("checkpoints disabled", func(t *testing.T) {
+		s := newSession(t, Checkpoint{ImageID: "sha256:abc"})
+		require.NoError(t, writeCheckpoint(s.Workspace, Checkpoint{Image: CheckpointImage("proj", s.Workspace), ImageID: "sha256:abc"}))
+		s.CheckpointInterval = 0
+		assert.False(t, s.restoreCheckpoint(ctx))
+		assert.Equal(t, "recac-agent:latest", s.Image)
+	})
+
+	t.Run("digest-pinned image", func(t *testing.T) {
+		s := newSession(t, Checkpoint{ImageID: "sha256:abc"})
+		require.NoError(t, writeCheckpoint(s.Workspace, Checkpoint{Image: CheckpointImage("proj", s.Workspace), ImageID: "sha256:abc"}))
+		s.ImageDigest = "sha256:" + strings.Repeat("ab", 32)
+		assert.False(t, s.restoreCheckpoint(ctx))
+		assert.Equal(t, "recac-agent:latest", s.Image)
+	})
+}
@@ -0,0 +1,156 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"recac/internal/git"
+	"recac/internal/github"
+	"recac/internal/gitlab"
+)
+
+// CommitChecksClient reports CI results for a commit, keyed by check name.
+type CommitChecksClient interface {
+	CommitChecks(ctx context.Context, ref string) (map[string]string, error)
+}
+
+// checksPollInterval is how often pending required checks are re-queried.
+var checksPollInterval = 30 * time.Second
+
+// defaultChecksTimeout bounds the wait for required checks when ChecksTimeout is unset.
+const defaultChecksTimeout = 30 * time.Minute
+
+// errChecksFailed is returned when a required check completed unsuccessfully,
+// as opposed to checks that could not be read or did not finish in time.
+var errChecksFailed = errors.New("required checks failed")
+
+// newChecksClient picks the checks provider for a remote URL from its host.
+// GitHub checks are read as app when it is not nil.
+func newChecksClient(remoteURL string, app *github.App) (CommitChecksClient, error) {
+	host, path, err := git.ParseRemoteURL(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case host == "github.com":
+		owner, repo, err := github.ParseRepo(path)
+		if err != nil {
+			return nil, err
+		}
+		if app != nil {
+			client := github.NewChecksClient("", owner, repo)
+			app.Authorize(client.HTTPClient)
+			return client, nil
+		}
+		token := os.Getenv("GITHUB_TOKEN")
+		if token == "" {
+			token = os.Getenv("GITHUB_API_KEY")
+		}
+		if token == "" {
+			return nil, fmt.Errorf("GITHUB_TOKEN or GITHUB_API_KEY environment variable is required")
+		}
+		return github.NewChecksClient(token, owner, repo), nil
+	case strings.Contains(host, "gitlab"):
+		token := os.Getenv("GITLAB_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("GITLAB_TOKEN environment variable is required")
+		}
+		client := gitlab.NewStatusClient(token, path)
+		if host != "gitlab.com" {
+			client.BaseURL = fmt.Sprintf("https://%s/api/v4", host)
+		}
+		return client, nil
+	default:
+		return nil, fmt.Errorf("unsupported git host %q for status checks", host)
+	}
+}
+
+// awaitRequiredChecks pushes branch and waits until every check in
+// RequiredChecks has passed on its head commit. It returns errChecksFailed
+// (wrapped) as soon as a required check fails.
+func (s *Session) awaitRequiredChecks(ctx context.Context, gitClient git.IClient, branch string) error {
+	return s.awaitRequiredChecksIn(ctx, gitClient, s.Workspace, branch)
+}
+
+// awaitRequiredChecksIn is awaitRequiredChecks for the repository in dir.
+func (s *Session) awaitRequiredChecksIn(ctx context.Context, gitClient git.IClient, dir, branch string) error {
+	s.refreshGitAuthIn(ctx, gitClient, dir)
+	if err := gitClient.Push(dir, branch); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+	sha, err := gitClient.CurrentCommitSHA(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve head commit: %w", err)
+	}
+
+	client := s.ChecksClient
+	if client == nil {
+		remote, err := gitClient.Run(dir, "remote", "get-url", "origin")
+		if err != nil {
+			return fmt.Errorf("failed to read origin remote: %w", err)
+		}
+		if client, err = newChecksClient(remote, s.GitHubApp); err != nil {
+			return err
+		}
+	}
+
+	timeout := s.ChecksTimeout
+	if timeout <= 0 {
+		timeout = defaultChecksTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		checks, err := client.CommitChecks(ctx, sha)
+		if err != nil {
+			return err
+		}
+
+		var failed, pending []string
+		for _, name := range s.RequiredChecks {
+			switch checks[name] {
+			case git.CheckSuccess:
+			case git.CheckFailure:
+				failed = append(failed, name)
+			default:
+				// Checks that have not been reported yet are still pending
+				pending = append(pending, name)
+			}
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("%w: %s", errChecksFailed, strings.Join(failed, ", "))
+		}
+		if len(pending) == 0 {
+			s.Logger.Info("required checks passed", "sha", sha, "checks", s.RequiredChecks)
+			return nil
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("timed out after %s waiting for checks: %s", timeout, strings.Join(pending, ", "))
+		}
+
+		s.Logger.Info("waiting for required checks", "sha", sha, "pending", pending)
+		wait := checksPollInterval
+		if remaining < wait {
+			wait = remaining
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// ensureChecksTask adds (or reopens) a guardrail feature asking the agent to
+// fix the required checks that failed on the branch.
+func (s *Session) ensureChecksTask(failure error) {
+	s.ensureGuardrailTask("CI_CHECKS", fmt.Sprintf("Fix the failing CI checks before merging into %s (%v). Reproduce the failures locally, fix them and commit.", s.BaseBranch, failure))
+}
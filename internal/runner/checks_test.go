@@ -0,0 +1,147 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"recac/internal/git"
+	"recac/internal/github"
+	"recac/internal/gitlab"
+	"recac/internal/telemetry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequenceChecksClient returns each result in turn, repeating the last one.
+type sequenceChecksClient struct {
+	results []map[string]string
+	calls   int
+}
+
+func (c *sequenceChecksClient) CommitChecks(ctx context.Context, ref string) (map[string]string, error) {
+	result := c.results[min(c.calls, len(c.results)-1)]
+	c.calls++
+	return result, nil
+}
+
+func newChecksTestSession(t *testing.T, client CommitChecksClient) (*Session, *MockGitClient) {
+	orig := checksPollInterval
+	checksPollInterval = time.Millisecond
+	t.Cleanup(func() { checksPollInterval = orig })
+
+	mockGit := new(MockGitClient)
+	mockGit.On("Push", "/ws", "feature").Return(nil)
+	mockGit.On("CurrentCommitSHA", "/ws").Return("abc123", nil)
+
+	s := &Session{
+		Workspace:      "/ws",
+		RequiredChecks: []string{"build", "test"},
+		ChecksTimeout:  time.Minute,
+		ChecksClient:   client,
+		Logger:         telemetry.NewLogger(true, "", false),
+	}
+	return s, mockGit
+}
+
+func TestSession_AwaitRequiredChecks(t *testing.T) {
+	t.Run("waits for pending checks to pass", func(t *testing.T) {
+		client := &sequenceChecksClient{results: []map[string]string{
+			{"build": git.CheckSuccess},
+			{"build": git.CheckSuccess, "test": git.CheckPending},
+			{"build": git.CheckSuccess, "test": git.CheckSuccess, "lint": git.CheckFailure},
+		}}
+		s, mockGit := newChecksTestSession(t, client)
+
+		require.NoError(t, s.awaitRequiredChecks(context.Background(), mockGit, "feature"))
+		assert.Equal(t, 3, client.calls)
+		mockGit.AssertCalled(t, "Push", "/ws", "feature")
+	})
+
+	t.Run("fails fast on a failed check", func(t *testing.T) {
+		client := &sequenceChecksClient{results: []map[string]string{
+			{"build": git.CheckFailure, "test": git.CheckPending},
+		}}
+		s, mockGit := newChecksTestSession(t, client)
+
+		err := s.awaitRequiredChecks(context.Background(), mockGit, "feature")
+		require.ErrorIs(t, err, errChecksFailed)
+		assert.Contains(t, err.Error(), "build")
+		assert.Equal(t, 1, client.calls)
+	})
+
+	t.Run("times out on checks that never finish", func(t *testing.T) {
+		client := &sequenceChecksClient{results: []map[string]string{{"build": git.CheckSuccess}}}
+		s, mockGit := newChecksTestSession(t, client)
+		s.ChecksTimeout = 5 * time.Millisecond
+
+		err := s.awaitRequiredChecks(context.Background(), mockGit, "feature")
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, errChecksFailed))
+		assert.Contains(t, err.Error(), "timed out")
+	})
+
+	t.Run("unset timeout uses the default", func(t *testing.T) {
+		client := &sequenceChecksClient{results: []map[string]string{
+			{"build": git.CheckSuccess},
+			{"build": git.CheckSuccess, "test": git.CheckSuccess},
+		}}
+		s, mockGit := newChecksTestSession(t, client)
+		s.ChecksTimeout = 0
+
+		require.NoError(t, s.awaitRequiredChecks(context.Background(), mockGit, "feature"))
+		assert.Equal(t, 2, client.calls)
+	})
+
+	t.Run("stops waiting when the context is canceled", func(t *testing.T) {
+		client := &sequenceChecksClient{results: []map[string]string{{"build": git.CheckSuccess}}}
+		s, mockGit := newChecksTestSession(t, client)
+		checksPollInterval = time.Hour
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := s.awaitRequiredChecks(ctx, mockGit, "feature")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, client.calls)
+	})
+}
+
+func TestNewChecksClient(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "gh-token")
+	t.Setenv("GITLAB_TOKEN", "gl-token")
+
+	client, err := newChecksClient("https://github.com/owner/repo.git", nil)
+	require.NoError(t, err)
+	gh := client.(*github.ChecksClient)
+	assert.Equal(t, "owner", gh.Owner)
+	assert.Equal(t, "repo", gh.Repo)
+
+	client, err = newChecksClient("git@gitlab.example.com:group/repo.git", nil)
+	require.NoError(t, err)
+	gl := client.(*gitlab.StatusClient)
+	assert.Equal(t, "group/repo", gl.Project)
+	assert.Equal(t, "https://gitlab.example.com/api/v4", gl.BaseURL)
+
+	_, err = newChecksClient("https://bitbucket.org/owner/repo", nil)
+	assert.Error(t, err)
+
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_API_KEY", "")
+	_, err = newChecksClient("https://github.com/owner/repo", nil)
+	assert.Error(t, err)
+}
+
+func TestSession_EnsureChecksTask(t *testing.T) {
+	mockDB := &FaultToleranceMockDB{}
+	s := &Session{Workspace: t.TempDir(), DBStore: mockDB, Project: "proj", BaseBranch: "main", Logger: telemetry.NewLogger(true, "", false)}
+
+	s.ensureChecksTask(errors.New("required checks failed: test"))
+
+	require.Len(t, mockDB.FeatureList.Features, 1)
+	feature := mockDB.FeatureList.Features[0]
+	assert.Equal(t, "CI_CHECKS", feature.ID)
+	assert.Equal(t, "todo", feature.Status)
+	assert.Contains(t, feature.Description, "required checks failed: test")
+}
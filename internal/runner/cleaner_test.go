@@ -95,3 +95,23 @@ func TestRunCleanerAgent_AbsolutePath(t *testing.T) {
 	}
     assert.FileExists(t, targetFile, "Target file should not be deleted")
 }
+
+func TestRunCleanerAgent_RecacIgnore(t *testing.T) {
+	workspace := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workspace, "fixtures"), 0755))
+	for _, f := range []string{"scratch.txt", "fixtures/users.json", "config.yaml"} {
+		require.NoError(t, os.WriteFile(filepath.Join(workspace, f), []byte("data"), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, ".recacignore"), []byte("fixtures/\nconfig.yaml\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "temp_files.txt"), []byte("scratch.txt\nfixtures/users.json\nconfig.yaml\n"), 0644))
+
+	session := &Session{
+		Workspace: workspace,
+		Logger:    telemetry.NewLogger(true, "", false),
+	}
+	require.NoError(t, session.runCleanerAgent(context.Background()))
+
+	assert.NoFileExists(t, filepath.Join(workspace, "scratch.txt"))
+	assert.FileExists(t, filepath.Join(workspace, "fixtures/users.json"))
+	assert.FileExists(t, filepath.Join(workspace, "config.yaml"))
+}
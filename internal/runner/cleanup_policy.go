@@ -0,0 +1,66 @@
+package runner
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// CleanupPolicy decides whether a session's temporary workspace is removed
+// when the session ends.
+type CleanupPolicy string
+
+const (
+	// CleanupAlways removes the workspace regardless of the outcome.
+	CleanupAlways CleanupPolicy = "always"
+	// CleanupOnSuccess removes the workspace only when the session succeeded,
+	// keeping failed workspaces around for post-mortem debugging.
+	CleanupOnSuccess CleanupPolicy = "on-success"
+	// CleanupNever keeps the workspace.
+	CleanupNever CleanupPolicy = "never"
+)
+
+// ResolveCleanupPolicy returns the configured cleanup_policy. When no policy
+// is set, the legacy cleanup flag maps to CleanupAlways or CleanupNever.
+func ResolveCleanupPolicy(policy string, cleanup bool) (CleanupPolicy, error) {
+	switch p := CleanupPolicy(strings.ToLower(strings.TrimSpace(policy))); p {
+	case "":
+		if cleanup {
+			return CleanupAlways, nil
+		}
+		return CleanupNever, nil
+	case CleanupAlways, CleanupOnSuccess, CleanupNever:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid cleanup_policy %q: must be always, on-success or never", policy)
+	}
+}
+
+// ShouldCleanup reports whether the workspace should be removed for a session
+// that ended with sessionErr.
+func (p CleanupPolicy) ShouldCleanup(sessionErr error) bool {
+	switch p {
+	case CleanupAlways:
+		return true
+	case CleanupOnSuccess:
+		return sessionErr == nil
+	default:
+		return false
+	}
+}
+
+// CleanupWorkspace removes workspace if the policy allows it for the session
+// outcome, otherwise it logs where the workspace was kept.
+func (p CleanupPolicy) CleanupWorkspace(logger *slog.Logger, workspace string, sessionErr error) {
+	if !p.ShouldCleanup(sessionErr) {
+		if sessionErr != nil && p == CleanupOnSuccess {
+			logger.Info("Keeping workspace of failed session for debugging", "path", workspace)
+		}
+		return
+	}
+	logger.Info("Cleaning up workspace", "path", workspace)
+	if err := os.RemoveAll(workspace); err != nil {
+		logger.Error("Failed to cleanup workspace", "path", workspace, "error", err)
+	}
+}
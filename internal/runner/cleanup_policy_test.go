@@ -0,0 +1,57 @@
+package runner
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestResolveCleanupPolicy(t *testing.T) {
+	tests := []struct {
+		policy  string
+		cleanup bool
+		want    CleanupPolicy
+		wantErr bool
+	}{
+		{policy: "", cleanup: true, want: CleanupAlways},
+		{policy: "", cleanup: false, want: CleanupNever},
+		{policy: "on-success", cleanup: false, want: CleanupOnSuccess},
+		{policy: "Never", cleanup: true, want: CleanupNever},
+		{policy: "sometimes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolveCleanupPolicy(tt.policy, tt.cleanup)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ResolveCleanupPolicy(%q, %v) error = %v, wantErr %v", tt.policy, tt.cleanup, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ResolveCleanupPolicy(%q, %v) = %q, want %q", tt.policy, tt.cleanup, got, tt.want)
+		}
+	}
+}
+
+func TestCleanupPolicy_CleanupWorkspace(t *testing.T) {
+	failed := errors.New("QA failed")
+	tests := []struct {
+		policy     CleanupPolicy
+		sessionErr error
+		wantKept   bool
+	}{
+		{CleanupAlways, failed, false},
+		{CleanupOnSuccess, nil, false},
+		{CleanupOnSuccess, failed, true},
+		{CleanupNever, nil, true},
+	}
+
+	for _, tt := range tests {
+		workspace := t.TempDir()
+		tt.policy.CleanupWorkspace(slog.Default(), workspace, tt.sessionErr)
+
+		_, err := os.Stat(workspace)
+		if kept := err == nil; kept != tt.wantKept {
+			t.Errorf("policy %q with error %v: kept = %v, want %v", tt.policy, tt.sessionErr, kept, tt.wantKept)
+		}
+	}
+}
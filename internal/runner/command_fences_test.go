@@ -0,0 +1,44 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     []string
+	}{
+		{"bash", "Run:\n```bash\nls -la\n```", []string{"ls -la"}},
+		{"sh", "```sh\nmake build\n```", []string{"make build"}},
+		{"shell", "```shell\ngo test ./...\n```", []string{"go test ./..."}},
+		{"upper case", "```Bash\necho hi\n```", []string{"echo hi"}},
+		{"closing fence on content line", "```bash\necho 2```", []string{"echo 2"}},
+		{"trailing space", "```bash \necho 1\n```", []string{"echo 1"}},
+		{"attributes", "```bash title=\"setup\"\nnpm ci\n```", []string{"npm ci"}},
+		{"brace attributes", "```bash {linenos=true}\nnpm ci\n```", []string{"npm ci"}},
+		{"pandoc attributes", "```{.sh}\nnpm ci\n```", []string{"npm ci"}},
+		{"indented", "1. Build it:\n\n    ```bash\n    cat > a.txt <<EOF\n    hello\n    EOF\n    ```", []string{"cat > a.txt <<EOF\nhello\nEOF"}},
+		{"console transcript", "```console\n$ go build ./...\n$ ./app --version\napp 1.0\n```", []string{"go build ./...\n./app --version"}},
+		{"heredoc with prompts", "```bash\ncat > README.md <<EOF\n$ make\nEOF\n```", []string{"cat > README.md <<EOF\n$ make\nEOF"}},
+		{"other languages", "```go\nfmt.Println()\n```\n```bash-session\nx\n```\n```\nplain\n```", nil},
+		{"several", "```bash\none\n```\ntext\n```sh\ntwo\n```", []string{"one", "two"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, extractCommands(tt.response))
+		})
+	}
+}
+
+func TestExtractCommands_ConfiguredFences(t *testing.T) {
+	viper.Set("command_fences", []string{"zsh"})
+	defer viper.Set("command_fences", nil)
+
+	assert.Equal(t, []string{"ls"}, extractCommands("```zsh\nls\n```\n```bash\npwd\n```"))
+}
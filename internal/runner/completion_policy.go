@@ -0,0 +1,166 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"strings"
+	"time"
+
+	"recac/internal/db"
+	"recac/internal/telemetry"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultCompletionTestTimeout bounds completion.test_command when no timeout is configured.
+const DefaultCompletionTestTimeout = 10 * time.Minute
+
+// CompletionPolicy is the project's definition of done, configured under
+// completion.*. The default, every feature passing, is the historical rule.
+type CompletionPolicy struct {
+	MinPassRatio     float64       // Share of features that must pass, in (0, 1]
+	RequiredFeatures []string      // Feature IDs that must pass whatever the ratio
+	TestCommand      string        // Must exit cleanly in the workspace before QA (empty = none)
+	TestTimeout      time.Duration // Bounds TestCommand
+}
+
+// LoadCompletionPolicy returns the configured completion policy. A ratio
+// outside (0, 1] means all features, and a non-positive timeout falls back to
+// DefaultCompletionTestTimeout.
+func LoadCompletionPolicy(cfg *viper.Viper) CompletionPolicy {
+	policy := CompletionPolicy{
+		MinPassRatio:     cfg.GetFloat64("completion.min_pass_ratio"),
+		RequiredFeatures: cfg.GetStringSlice("completion.required_features"),
+		TestCommand:      strings.TrimSpace(cfg.GetString("completion.test_command")),
+		TestTimeout:      cfg.GetDuration("completion.test_timeout"),
+	}
+	if policy.MinPassRatio <= 0 || policy.MinPassRatio > 1 {
+		policy.MinPassRatio = 1
+	}
+	if policy.TestTimeout <= 0 {
+		policy.TestTimeout = DefaultCompletionTestTimeout
+	}
+	return policy
+}
+
+// featurePasses reports whether a feature counts as passing, as in RunQA.
+func featurePasses(f db.Feature) bool {
+	return f.Passes || f.Status == "done" || f.Status == "implemented"
+}
+
+// Unmet returns why the features fall short of the policy, or "" when they
+// meet it. An empty feature list never meets it.
+func (p CompletionPolicy) Unmet(features []db.Feature) string {
+	if len(features) == 0 {
+		return "no features"
+	}
+
+	passing := make(map[string]bool, len(features))
+	passed := 0
+	for _, f := range features {
+		if featurePasses(f) {
+			passing[f.ID] = true
+			passed++
+		}
+	}
+
+	var missing []string
+	for _, id := range p.RequiredFeatures {
+		if !passing[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Sprintf("required features not passing: %s", strings.Join(missing, ", "))
+	}
+
+	// Round up, tolerating float error, so a ratio of 1 means every feature
+	needed := int(math.Ceil(p.MinPassRatio*float64(len(features)) - 1e-9))
+	if passed < needed {
+		return fmt.Sprintf("%d/%d features passing, %d needed (min_pass_ratio %.2f)", passed, len(features), needed, p.MinPassRatio)
+	}
+	return ""
+}
+
+// checkCompletionPolicy verifies the completion policy before QA: the
+// feature list must meet it and the test command, if any, must run clean.
+// The test output is stored as a System observation so a failure is visible
+// to the coding agent when the session returns to coding.
+func (s *Session) checkCompletionPolicy(ctx context.Context) error {
+	policy := LoadCompletionPolicy(s.config())
+	if features := s.loadFeatures(); len(features) > 0 {
+		if reason := policy.Unmet(features); reason != "" {
+			return fmt.Errorf("completion policy not met: %s", reason)
+		}
+	}
+	if policy.TestCommand == "" {
+		return nil
+	}
+
+	fmt.Printf("Running completion test command: %s\n", policy.TestCommand)
+	output, err := s.execCompletionTest(ctx, policy)
+	s.saveCompletionTestOutput(policy.TestCommand, output, err)
+	if err != nil {
+		return fmt.Errorf("completion test command failed: %w", err)
+	}
+	return nil
+}
+
+// execCompletionTest runs the test command in the workspace, locally or in
+// the container, returning its combined output.
+func (s *Session) execCompletionTest(ctx context.Context, policy CompletionPolicy) (string, error) {
+	runCtx, cancel := context.WithTimeout(ctx, policy.TestTimeout)
+	defer cancel()
+
+	var output string
+	var err error
+	if s.UseLocalAgent {
+		cmd := exec.CommandContext(runCtx, "/bin/sh", "-c", policy.TestCommand)
+		cmd.Dir = s.Workspace
+		cmd.WaitDelay = initScriptWaitDelay
+		var outBuf bytes.Buffer
+		cmd.Stdout = &outBuf
+		cmd.Stderr = &outBuf
+		err = cmd.Run()
+		output = outBuf.String()
+	} else if s.Docker != nil {
+		output, err = s.Docker.Exec(runCtx, s.GetContainerID(), []string{"/bin/sh", "-c", policy.TestCommand})
+	} else {
+		return "", fmt.Errorf("no container to run %q in", policy.TestCommand)
+	}
+
+	if errors.Is(err, exec.ErrWaitDelay) {
+		err = nil
+	}
+	if err != nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", policy.TestTimeout)
+	}
+	return output, err
+}
+
+// saveCompletionTestOutput records the test command's result as a System observation.
+func (s *Session) saveCompletionTestOutput(command, output string, runErr error) {
+	if s.DBStore == nil {
+		return
+	}
+	observation := fmt.Sprintf("Completion test command %q passed.", command)
+	if runErr != nil {
+		observation = fmt.Sprintf("Completion test command %q failed: %v. The project is not done until it passes.", command, runErr)
+	}
+	if output = strings.TrimSpace(output); output != "" {
+		const maxOutputChars = 20000
+		if len(output) > maxOutputChars {
+			output = "..." + output[len(output)-maxOutputChars:]
+		}
+		observation += "\nOutput:\n" + output
+	}
+
+	telemetry.TrackDBOp(s.Project)
+	if err := s.DBStore.SaveObservation(s.Project, "System", observation); err != nil {
+		s.Logger.Error("failed to save completion test output to DB", "error", err)
+	}
+}
@@ -0,0 +1,147 @@
+package runner
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"recac/internal/db"
+	"recac/internal/notify"
+	"recac/internal/telemetry"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionPolicy_Unmet(t *testing.T) {
+	features := []db.Feature{
+		{ID: "core-1", Status: "done"},
+		{ID: "core-2", Passes: true},
+		{ID: "nice-1", Status: "todo"},
+		{ID: "nice-2", Status: "implemented"},
+	}
+	tests := []struct {
+		name   string
+		policy CompletionPolicy
+		want   string
+	}{
+		{"all features by default", CompletionPolicy{MinPassRatio: 1}, "3/4 features passing, 4 needed (min_pass_ratio 1.00)"},
+		{"ratio met", CompletionPolicy{MinPassRatio: 0.75}, ""},
+		{"ratio rounds up", CompletionPolicy{MinPassRatio: 0.8}, "3/4 features passing, 4 needed (min_pass_ratio 0.80)"},
+		{"must-haves pass", CompletionPolicy{MinPassRatio: 0.5, RequiredFeatures: []string{"core-1", "core-2"}}, ""},
+		{"must-have failing", CompletionPolicy{MinPassRatio: 0.5, RequiredFeatures: []string{"core-1", "nice-1", "missing"}}, "required features not passing: nice-1, missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Unmet(features))
+		})
+	}
+
+	assert.Equal(t, "no features", CompletionPolicy{MinPassRatio: 0.5}.Unmet(nil))
+}
+
+func TestLoadCompletionPolicy(t *testing.T) {
+	policy := LoadCompletionPolicy(viper.GetViper())
+	assert.Equal(t, 1.0, policy.MinPassRatio, "unset ratio requires every feature")
+	assert.Equal(t, DefaultCompletionTestTimeout, policy.TestTimeout)
+
+	viper.Set("completion.min_pass_ratio", 0.9)
+	viper.Set("completion.required_features", []string{"core-1"})
+	viper.Set("completion.test_command", " make test ")
+	viper.Set("completion.test_timeout", "2m")
+	defer viper.Set("completion.min_pass_ratio", nil)
+	defer viper.Set("completion.required_features", nil)
+	defer viper.Set("completion.test_command", nil)
+	defer viper.Set("completion.test_timeout", nil)
+
+	assert.Equal(t, CompletionPolicy{MinPassRatio: 0.9, RequiredFeatures: []string{"core-1"}, TestCommand: "make test", TestTimeout: 2 * time.Minute}, LoadCompletionPolicy(viper.GetViper()))
+}
+
+func TestSession_CheckAutoQA_CompletionPolicy(t *testing.T) {
+	workspace := t.TempDir()
+	store, err := db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	s := &Session{
+		Workspace: workspace,
+		Project:   "policy-project",
+		DBStore:   store,
+		Logger:    telemetry.NewLogger(true, "", false),
+		Notifier:  notify.NewManager(func(string, ...interface{}) {}),
+	}
+	writeFeaturesForAutoQAWithSession(t, s, []db.Feature{
+		{ID: "core", Status: "done"},
+		{ID: "nice", Status: "todo"},
+	})
+	assert.False(t, s.checkAutoQA(), "all features must pass by default")
+
+	viper.Set("completion.min_pass_ratio", 0.5)
+	viper.Set("completion.required_features", []string{"nice"})
+	defer viper.Set("completion.min_pass_ratio", nil)
+	defer viper.Set("completion.required_features", nil)
+	assert.False(t, s.checkAutoQA(), "a failing must-have blocks completion")
+
+	viper.Set("completion.required_features", []string{"core"})
+	assert.True(t, s.checkAutoQA(), "core features passing meet the policy")
+	assert.True(t, s.hasSignal("COMPLETED"))
+}
+
+func TestSession_CheckCompletionPolicy_TestCommand(t *testing.T) {
+	workspace := t.TempDir()
+	store, err := db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	s := &Session{
+		Workspace:     workspace,
+		Project:       "policy-project",
+		DBStore:       store,
+		UseLocalAgent: true,
+		Logger:        telemetry.NewLogger(true, "", false),
+	}
+	writeFeaturesForAutoQAWithSession(t, s, []db.Feature{{ID: "core", Status: "done"}})
+	defer viper.Set("completion.test_command", nil)
+
+	viper.Set("completion.test_command", "echo all green")
+	require.NoError(t, s.checkCompletionPolicy(context.Background()))
+
+	viper.Set("completion.test_command", "echo 1 test failed; exit 1")
+	err = s.checkCompletionPolicy(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "completion test command failed")
+
+	history, err := store.QueryHistory(s.Project, 1)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "System", history[0].AgentID)
+	assert.Contains(t, history[0].Content, "1 test failed")
+}
+
+func TestSession_RunQAAgent_CompletionPolicyNotMet(t *testing.T) {
+	workspace := t.TempDir()
+	store, err := db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	qaAgent := &PromptRecordingAgent{Responses: []string{"```bash\nagent-bridge qa\n```"}}
+	s := &Session{
+		Workspace:     workspace,
+		Project:       "policy-project",
+		DBStore:       store,
+		QAAgent:       qaAgent,
+		UseLocalAgent: true,
+		Logger:        telemetry.NewLogger(true, "", false),
+	}
+	writeFeaturesForAutoQAWithSession(t, s, []db.Feature{
+		{ID: "core", Status: "done"},
+		{ID: "nice", Status: "todo"},
+	})
+
+	err = s.runQAAgent(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "completion policy not met: 1/2 features passing")
+	assert.Empty(t, qaAgent.Prompts, "the QA agent is not asked while the policy is unmet")
+}
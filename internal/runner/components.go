@@ -0,0 +1,173 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"recac/internal/git"
+)
+
+// componentBranch is a repository from ComponentRepos whose work has been
+// committed and is ready to be pushed or merged.
+type componentBranch struct {
+	Name          string
+	Dir           string
+	FeatureBranch string
+	// BaseBranch is empty when the component is only pushed, never merged.
+	BaseBranch string
+}
+
+// mergeable reports whether the component's feature branch is merged into a
+// separate base branch on sign-off.
+func (c componentBranch) mergeable() bool {
+	return c.BaseBranch != "" && c.BaseBranch != c.FeatureBranch
+}
+
+// prepareComponents commits the pending work in each repository of
+// ComponentRepos and resolves its feature and base branches. A repository
+// that cannot be prepared is logged and left out, so it does not stop the others.
+func (s *Session) prepareComponents(gitClient git.IClient) []componentBranch {
+	if len(s.ComponentRepos) == 0 {
+		return nil
+	}
+	commitMsg := git.CommitMessage(git.CommitVars{
+		Project:  s.Project,
+		TicketID: s.JiraTicketID,
+		Summary:  s.specSummary(),
+	})
+
+	var components []componentBranch
+	for _, name := range s.ComponentRepos {
+		c, err := s.prepareComponent(gitClient, name, commitMsg)
+		if err != nil {
+			s.Logger.Warn("failed to sign off component repository", "repo", name, "error", err)
+			continue
+		}
+		components = append(components, c)
+	}
+	return components
+}
+
+// prepareComponent commits the repository called name and resolves its branches.
+func (s *Session) prepareComponent(gitClient git.IClient, name, commitMsg string) (componentBranch, error) {
+	c := componentBranch{Name: name, Dir: filepath.Join(s.Workspace, name)}
+	if !gitClient.RepoExists(c.Dir) {
+		return c, fmt.Errorf("%s is not a git repository", c.Dir)
+	}
+
+	if status, err := gitClient.Run(c.Dir, "status", "--porcelain"); err != nil {
+		return c, fmt.Errorf("failed to read status: %w", err)
+	} else if strings.TrimSpace(status) != "" {
+		if err := gitClient.Commit(c.Dir, commitMsg); err != nil {
+			return c, fmt.Errorf("failed to commit: %w", err)
+		}
+	}
+
+	var err error
+	if c.FeatureBranch, err = gitClient.CurrentBranch(c.Dir); err != nil {
+		return c, fmt.Errorf("failed to get current branch: %w", err)
+	}
+	if s.AutoMerge {
+		if c.BaseBranch, err = gitClient.DefaultBranch(c.Dir); err != nil {
+			s.Logger.Warn("could not detect base branch, pushing without merging", "dir", c.Dir, "error", err)
+		}
+	}
+	return c, nil
+}
+
+// awaitComponentChecks waits for RequiredChecks on the feature branch of every
+// component that will be merged. It stops at the first component whose checks
+// failed or could not be verified, returning errChecksFailed (wrapped) for the former.
+func (s *Session) awaitComponentChecks(ctx context.Context, gitClient git.IClient, components []componentBranch) error {
+	if len(s.RequiredChecks) == 0 {
+		return nil
+	}
+	for _, c := range components {
+		if !c.mergeable() {
+			continue
+		}
+		if err := s.awaitRequiredChecksIn(ctx, gitClient, c.Dir, c.FeatureBranch); err != nil {
+			return fmt.Errorf("%s: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// pushComponents pushes the feature branch of each component without merging it.
+func (s *Session) pushComponents(ctx context.Context, gitClient git.IClient, components []componentBranch) {
+	for _, c := range components {
+		s.refreshGitAuthIn(ctx, gitClient, c.Dir)
+		if err := gitClient.Push(c.Dir, c.FeatureBranch); err != nil {
+			s.Logger.Warn("failed to push component repository", "repo", c.Name, "branch", c.FeatureBranch, "error", err)
+			continue
+		}
+		fmt.Printf("Pushed %s in %s.\n", c.FeatureBranch, c.Dir)
+	}
+}
+
+// mergeComponents merges each mergeable component into its base branch and
+// pushes the rest. Required checks must already have passed; a failure in one
+// repository is logged and does not stop the others.
+func (s *Session) mergeComponents(ctx context.Context, gitClient git.IClient, components []componentBranch) {
+	for _, c := range components {
+		if !c.mergeable() {
+			s.pushComponents(ctx, gitClient, []componentBranch{c})
+			continue
+		}
+		if err := s.mergeComponent(ctx, gitClient, c); err != nil {
+			s.Logger.Warn("failed to sign off component repository", "repo", c.Name, "error", err)
+		}
+	}
+}
+
+// mergeComponent merges the component's feature branch into its base branch,
+// pushes it and removes the remote feature branch.
+func (s *Session) mergeComponent(ctx context.Context, gitClient git.IClient, c componentBranch) error {
+	if err := gitClient.Checkout(c.Dir, c.BaseBranch); err != nil {
+		return fmt.Errorf("auto-merge failed (checkout base): %w", err)
+	}
+	defer gitClient.Checkout(c.Dir, c.FeatureBranch)
+
+	if err := gitClient.Merge(c.Dir, c.FeatureBranch); err != nil {
+		_ = gitClient.AbortMerge(c.Dir)
+		_ = gitClient.Recover(c.Dir)
+		return fmt.Errorf("auto-merge failed (merge): %w", err)
+	}
+	s.refreshGitAuthIn(ctx, gitClient, c.Dir)
+	if err := gitClient.Push(c.Dir, c.BaseBranch); err != nil {
+		_ = gitClient.AbortMerge(c.Dir)
+		return fmt.Errorf("auto-merge failed (push): %w", err)
+	}
+	fmt.Printf("Successfully auto-merged %s into %s in %s and pushed.\n", c.FeatureBranch, c.BaseBranch, c.Dir)
+
+	if s.JiraTicketID == "" || git.IsAgentBranch(c.FeatureBranch, s.JiraTicketID) {
+		if err := gitClient.DeleteRemoteBranch(c.Dir, "origin", c.FeatureBranch); err != nil {
+			fmt.Printf("[%s] Warning: Failed to delete remote branch %s in %s: %v\n", s.Project, c.FeatureBranch, c.Dir, err)
+		}
+	}
+	return nil
+}
+
+// signOffComponents signs off ComponentRepos when the main repository is not
+// being merged: it waits for the required checks of every component first and
+// merges (or pushes) them only if none failed. It returns errChecksFailed
+// (wrapped) if the required checks failed in any of them, and nothing is merged.
+func (s *Session) signOffComponents(ctx context.Context, gitClient git.IClient, components []componentBranch) error {
+	if len(components) == 0 {
+		return nil
+	}
+	if err := s.awaitComponentChecks(ctx, gitClient, components); err != nil {
+		if errors.Is(err, errChecksFailed) {
+			return err
+		}
+		// Never merge unverified work; the branches stay pushed for a human to merge
+		fmt.Printf("Warning: Component auto-merge skipped, required checks could not be verified: %v\n", err)
+		s.pushComponents(ctx, gitClient, components)
+		return nil
+	}
+	s.mergeComponents(ctx, gitClient, components)
+	return nil
+}
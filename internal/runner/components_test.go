@@ -0,0 +1,181 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"recac/internal/git"
+	"recac/internal/notify"
+	"recac/internal/telemetry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestSession_SignOffComponents(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := t.TempDir()
+
+	// A remote with one commit on main
+	remote := filepath.Join(root, "lib.git")
+	seed := filepath.Join(root, "seed")
+	runGit(t, root, "init", "--bare", "-b", "main", remote)
+	runGit(t, root, "clone", remote, seed)
+	if err := os.WriteFile(filepath.Join(seed, "README.md"), []byte("lib\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, seed, "add", ".")
+	runGit(t, seed, "commit", "-m", "init")
+	runGit(t, seed, "push", "origin", "main")
+
+	// The session's component clone, with uncommitted work on a feature branch
+	workspace := filepath.Join(root, "workspace")
+	runGit(t, root, "clone", remote, filepath.Join(workspace, "lib"))
+	lib := filepath.Join(workspace, "lib")
+	runGit(t, lib, "config", "user.email", "t@example.com")
+	runGit(t, lib, "config", "user.name", "t")
+	runGit(t, lib, "checkout", "-b", "agent/TASK-1")
+	if err := os.WriteFile(filepath.Join(lib, "shared.go"), []byte("package lib\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSession(nil, &MockAgent{}, workspace, "alpine", "test-project", "gemini", "gemini-pro", 1)
+	s.ComponentRepos = []string{"lib"}
+	s.JiraTicketID = "TASK-1"
+
+	// Without auto-merge the feature branch is committed and pushed
+	gitClient := git.NewClient()
+	if err := s.signOffComponents(context.Background(), gitClient, s.prepareComponents(gitClient)); err != nil {
+		t.Fatalf("signOffComponents failed: %v", err)
+	}
+	if files := runGit(t, remote, "ls-tree", "--name-only", "agent/TASK-1"); !strings.Contains(files, "shared.go") {
+		t.Fatalf("expected shared.go pushed on the feature branch, got %q", files)
+	}
+
+	// With auto-merge it lands on the component's default branch and the feature branch is deleted
+	s.AutoMerge = true
+	if err := s.signOffComponents(context.Background(), gitClient, s.prepareComponents(gitClient)); err != nil {
+		t.Fatalf("signOffComponents failed: %v", err)
+	}
+	if files := runGit(t, remote, "ls-tree", "--name-only", "main"); !strings.Contains(files, "shared.go") {
+		t.Errorf("expected shared.go merged into main, got %q", files)
+	}
+	if branches := runGit(t, remote, "branch", "--list", "agent/TASK-1"); branches != "" {
+		t.Errorf("expected the remote feature branch to be deleted, got %q", branches)
+	}
+	if branch := runGit(t, lib, "rev-parse", "--abbrev-ref", "HEAD"); branch != "agent/TASK-1" {
+		t.Errorf("expected the component to be back on its feature branch, got %q", branch)
+	}
+}
+
+// shaChecksClient reports the checks configured for each commit SHA.
+type shaChecksClient map[string]map[string]string
+
+func (c shaChecksClient) CommitChecks(ctx context.Context, ref string) (map[string]string, error) {
+	return c[ref], nil
+}
+
+func TestRunLoop_AutoMerge_ComponentChecksFailed(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "app_spec.txt"), []byte("Spec"), 0644)
+
+	// Initialize real git repo for direct exec calls in RunLoop
+	exec.Command("git", "-C", tmpDir, "init").Run()
+	exec.Command("git", "-C", tmpDir, "config", "user.email", "test@example.com").Run()
+	exec.Command("git", "-C", tmpDir, "config", "user.name", "Test").Run()
+	exec.Command("git", "-C", tmpDir, "commit", "--allow-empty", "-m", "init").Run()
+	exec.Command("git", "-C", tmpDir, "checkout", "-b", "feature/foo").Run()
+	lib := filepath.Join(tmpDir, "lib")
+
+	signals := map[string]string{"PROJECT_SIGNED_OFF": "true"}
+	mockDB := &MockRunLoopDBStore{
+		GetSignalFunc: func(projectID, key string) (string, error) {
+			return signals[key], nil
+		},
+		DeleteSignalFunc: func(projectID, key string) error {
+			delete(signals, key)
+			return nil
+		},
+		GetFeaturesFunc: func(projectID string) (string, error) {
+			return `{"features": []}`, nil
+		},
+		SaveFeaturesFunc: func(projectID, features string) error {
+			return nil
+		},
+	}
+
+	mockGit := new(MockGitClient)
+	mockGit.On("Fetch", mock.Anything, "origin", "main").Return(nil)
+	mockGit.On("Stash", mock.Anything).Return(nil)
+	mockGit.On("Merge", mock.Anything, "origin/main").Return(nil)
+	mockGit.On("StashPop", mock.Anything).Return(nil)
+	mockGit.On("Commit", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockGit.On("Run", mock.Anything, mock.Anything).Return("", nil).Maybe()
+	mockGit.On("DiffStat", mock.Anything, mock.Anything, mock.Anything).Return("", nil).Maybe()
+
+	// The component is on its own feature branch and merges into main
+	mockGit.On("RepoExists", lib).Return(true)
+	mockGit.On("CurrentBranch", lib).Return("feature/foo", nil)
+	mockGit.On("DefaultBranch", lib).Return("main", nil)
+
+	// Both feature branches are pushed for the checks; the main repository passes, the component fails
+	mockGit.On("Push", mock.Anything, "feature/foo").Return(nil)
+	mockGit.On("CurrentCommitSHA", tmpDir).Return("sha-main", nil)
+	mockGit.On("CurrentCommitSHA", lib).Return("sha-lib", nil)
+
+	originalNewClient := git.NewClient
+	git.NewClient = func() git.IClient {
+		return mockGit
+	}
+	defer func() { git.NewClient = originalNewClient }()
+
+	s := &Session{
+		Workspace:      tmpDir,
+		DBStore:        mockDB,
+		Notifier:       notify.NewManager(func(string, ...interface{}) {}),
+		Logger:         telemetry.NewLogger(true, "", false),
+		BaseBranch:     "main",
+		AutoMerge:      true,
+		ComponentRepos: []string{"lib"},
+		RequiredChecks: []string{"build"},
+		ChecksTimeout:  time.Minute,
+		ChecksClient: shaChecksClient{
+			"sha-main": {"build": git.CheckSuccess},
+			"sha-lib":  {"build": git.CheckFailure},
+		},
+		RepoURL:       "http://github.com/org/repo",
+		Project:       "test-proj",
+		MaxIterations: 1,
+		Agent:         &MockAgent{Response: "Working on it"},
+	}
+
+	_ = s.RunLoop(context.Background())
+
+	mockGit.AssertCalled(t, "CurrentCommitSHA", lib)
+
+	// Nothing is merged into main, in either repository
+	mockGit.AssertNotCalled(t, "Checkout", mock.Anything, "main")
+	mockGit.AssertNotCalled(t, "Merge", mock.Anything, "feature/foo")
+	mockGit.AssertNotCalled(t, "Push", mock.Anything, "main")
+	mockGit.AssertNotCalled(t, "DeleteRemoteBranch", mock.Anything, mock.Anything, mock.Anything)
+	assert.Empty(t, signals["PROJECT_SIGNED_OFF"], "sign-off should be revoked")
+}
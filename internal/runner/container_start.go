@@ -0,0 +1,59 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"recac/internal/docker"
+)
+
+// Retries of a container start that failed with a transient daemon error. The
+// backoff grows linearly with each attempt.
+var (
+	containerStartRetries = 3
+	containerStartBackoff = 2 * time.Second
+)
+
+// startContainer runs the agent container, recovering from failures a retry
+// can fix. Transient daemon errors are retried after a backoff; any other
+// failure, typically a missing or corrupt image, re-pulls or rebuilds the
+// image once and tries again. Configuration errors fail immediately.
+func (s *Session) startContainer(ctx context.Context, extraBinds, env []string, user string) (string, error) {
+	sleep := s.SleepFunc
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+
+	retries, refreshed := 0, false
+	for {
+		id, err := s.runContainer(ctx, extraBinds, env, user)
+		if err == nil {
+			return id, nil
+		}
+		if ctx.Err() != nil {
+			return "", err
+		}
+
+		switch docker.ClassifyRunError(err) {
+		case docker.RunFailureConfig:
+			return "", err
+		case docker.RunFailureTransient:
+			if retries >= containerStartRetries {
+				return "", err
+			}
+			retries++
+			fmt.Printf("Warning: Failed to start container: %v. Retrying (%d/%d)...\n", err, retries, containerStartRetries)
+			sleep(time.Duration(retries) * containerStartBackoff)
+		default:
+			if refreshed {
+				return "", err
+			}
+			refreshed = true
+			fmt.Printf("Warning: Failed to start container from %s: %v. Refreshing the image and retrying...\n", s.Image, err)
+			if rerr := s.prepareImage(ctx, true); rerr != nil {
+				return "", fmt.Errorf("%w (refreshing the image failed: %v)", err, rerr)
+			}
+		}
+	}
+}
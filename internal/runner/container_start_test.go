@@ -0,0 +1,149 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"recac/internal/docker"
+)
+
+func TestStartContainer(t *testing.T) {
+	tests := []struct {
+		name     string
+		errs     []error // RunContainer results before it succeeds
+		image    string
+		wantErr  bool
+		wantRuns int
+		wantPull int
+	}{
+		{
+			name:     "corrupt image is pulled again",
+			errs:     []error{errors.New("failed to create container: layer does not exist")},
+			image:    "ghcr.io/process-failed-successfully/recac-agent:latest",
+			wantRuns: 2,
+			wantPull: 1,
+		},
+		{
+			name: "image is refreshed only once",
+			errs: []error{
+				errors.New("failed to start container: unknown layer"),
+				errors.New("failed to start container: unknown layer"),
+			},
+			image:    "my-agent:1.0",
+			wantErr:  true,
+			wantRuns: 2,
+			wantPull: 1,
+		},
+		{
+			name: "transient daemon errors are retried",
+			errs: []error{
+				errors.New("Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?"),
+				errors.New("failed to create container: connection reset by peer"),
+			},
+			image:    "my-agent:1.0",
+			wantRuns: 3,
+		},
+		{
+			name:     "configuration errors fail fast",
+			errs:     []error{errors.New("failed to create container: invalid mount config for type \"bind\"")},
+			image:    "my-agent:1.0",
+			wantErr:  true,
+			wantRuns: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs, pulls := 0, 0
+			s := &Session{
+				Image:     tt.image,
+				Workspace: t.TempDir(),
+				SleepFunc: func(time.Duration) {},
+				Docker: &MockDockerClient{
+					RunContainerFunc: func(ctx context.Context, image, workspace string, extraBinds, env []string, user string) (string, error) {
+						runs++
+						if runs <= len(tt.errs) {
+							return "", tt.errs[runs-1]
+						}
+						return "container-id", nil
+					},
+					PullImageFunc: func(ctx context.Context, image string) error {
+						pulls++
+						return nil
+					},
+				},
+			}
+
+			id, err := s.startContainer(context.Background(), nil, nil, "")
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("startContainer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && id != "container-id" {
+				t.Errorf("Expected container-id, got %q", id)
+			}
+			if runs != tt.wantRuns {
+				t.Errorf("Expected %d run attempts, got %d", tt.wantRuns, runs)
+			}
+			if pulls != tt.wantPull {
+				t.Errorf("Expected %d pulls, got %d", tt.wantPull, pulls)
+			}
+		})
+	}
+}
+
+func TestStartContainer_RebuildsLocalImage(t *testing.T) {
+	var builds []docker.ImageBuildOptions
+	runs := 0
+	s := &Session{
+		Image:     "recac-agent:0123456789ab",
+		Workspace: t.TempDir(),
+		SleepFunc: func(time.Duration) {},
+		Docker: &MockDockerClient{
+			RunContainerFunc: func(ctx context.Context, image, workspace string, extraBinds, env []string, user string) (string, error) {
+				runs++
+				if runs == 1 {
+					return "", errors.New("failed to create container: No such image: recac-agent:0123456789ab")
+				}
+				return "container-id", nil
+			},
+			ImageExistsFunc: func(ctx context.Context, image string) (bool, error) {
+				t.Error("Expected the existing image not to be reused")
+				return true, nil
+			},
+			ImageBuildFunc: func(ctx context.Context, opts docker.ImageBuildOptions) (string, error) {
+				builds = append(builds, opts)
+				return "sha256:new", nil
+			},
+		},
+	}
+
+	if _, err := s.startContainer(context.Background(), nil, nil, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(builds) != 1 || !builds[0].NoCache {
+		t.Errorf("Expected one uncached rebuild, got %+v", builds)
+	}
+}
+
+func TestStartContainer_TransientRetriesExhausted(t *testing.T) {
+	runs := 0
+	s := &Session{
+		Image:     "my-agent:1.0",
+		SleepFunc: func(time.Duration) {},
+		Docker: &MockDockerClient{
+			RunContainerFunc: func(ctx context.Context, image, workspace string, extraBinds, env []string, user string) (string, error) {
+				runs++
+				return "", errors.New("dial unix /var/run/docker.sock: connect: connection refused")
+			},
+		},
+	}
+
+	if _, err := s.startContainer(context.Background(), nil, nil, ""); err == nil {
+		t.Fatal("Expected an error once retries are exhausted")
+	}
+	if runs != containerStartRetries+1 {
+		t.Errorf("Expected %d attempts, got %d", containerStartRetries+1, runs)
+	}
+}
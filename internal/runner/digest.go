@@ -0,0 +1,75 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"recac/internal/agent"
+	"recac/internal/notify"
+)
+
+// digestTimeout bounds posting the end-of-session digest, which is sent
+// after the session's own context may already be cancelled.
+const digestTimeout = 10 * time.Second
+
+// sendDigest posts a single wrap-up of the session in its notification
+// thread when RunLoop returns with err.
+func (s *Session) sendDigest(err error, interrupted bool) {
+	if s.Notifier == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), digestTimeout)
+	defer cancel()
+
+	d := s.digest(err, interrupted)
+	if dn, ok := s.Notifier.(notify.DigestNotifier); ok {
+		_, _ = dn.NotifyDigest(ctx, d, s.GetSlackThreadTS())
+		return
+	}
+	_, _ = s.Notifier.Notify(ctx, notify.EventDigest, d.String(), s.GetSlackThreadTS())
+}
+
+// digest assembles the end-of-session summary from the session state.
+func (s *Session) digest(err error, interrupted bool) notify.Digest {
+	d := notify.Digest{
+		Project:    s.Project,
+		Status:     digestStatus(err, interrupted),
+		Iterations: s.GetIteration(),
+	}
+	if !s.runStarted.IsZero() {
+		d.Duration = time.Since(s.runStarted)
+	}
+
+	features := s.loadFeatures()
+	d.Total = len(features)
+	for _, f := range features {
+		if f.Passes {
+			d.Passing++
+		}
+	}
+
+	if s.StateManager != nil {
+		if state, err := s.StateManager.Load(); err == nil {
+			d.Tokens = state.TokenUsage.TotalTokens
+			d.Cost = agent.CalculateProviderCost(state.Provider, state.Model, state.TokenUsage)
+		}
+	}
+	return d
+}
+
+func digestStatus(err error, interrupted bool) string {
+	switch {
+	case err == nil:
+		return "completed"
+	case interrupted:
+		return "interrupted"
+	case errors.Is(err, ErrMaxIterations):
+		return "stopped at max iterations"
+	case errors.Is(err, ErrBlocker):
+		return "blocked"
+	default:
+		return fmt.Sprintf("failed: %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"recac/internal/agent"
+	"recac/internal/notify"
+)
+
+type digestRecorder struct {
+	MockNotifier
+	digests []notify.Digest
+}
+
+func (r *digestRecorder) NotifyDigest(ctx context.Context, d notify.Digest, threadTS string) (string, error) {
+	r.digests = append(r.digests, d)
+	return "", nil
+}
+
+func TestSession_Digest(t *testing.T) {
+	tmpDir := t.TempDir()
+	features := `{"project_name":"p","features":[{"id":"1","description":"a","passes":true},{"id":"2","description":"b","passes":false}]}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "feature_list.json"), []byte(features), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	session := NewSession(&MockDockerClient{}, &MockAgent{}, tmpDir, "alpine", "test-project", "openai", "gpt-4o", 1)
+	if err := session.StateManager.Save(agent.State{Provider: "openai", Model: "gpt-4o", TokenUsage: agent.TokenUsage{TotalPromptTokens: 1000, TotalResponseTokens: 500, TotalTokens: 1500}}); err != nil {
+		t.Fatal(err)
+	}
+	session.IncrementIteration()
+	session.IncrementIteration()
+
+	d := session.digest(ErrMaxIterations, false)
+	if d.Project != "test-project" || d.Iterations != 2 || d.Passing != 1 || d.Total != 2 {
+		t.Errorf("unexpected digest: %+v", d)
+	}
+	if d.Status != "stopped at max iterations" {
+		t.Errorf("unexpected status %q", d.Status)
+	}
+	if d.Tokens != 1500 || d.Cost <= 0 {
+		t.Errorf("expected usage and cost from the agent state, got %d tokens, $%f", d.Tokens, d.Cost)
+	}
+}
+
+func TestDigestStatus(t *testing.T) {
+	tests := []struct {
+		err         error
+		interrupted bool
+		want        string
+	}{
+		{nil, false, "completed"},
+		{context.Canceled, true, "interrupted"},
+		{ErrBlocker, false, "blocked"},
+		{fmt.Errorf("%w: timeout", ErrAgentCall), false, "failed: agent call failed: timeout"},
+	}
+	for _, tt := range tests {
+		if got := digestStatus(tt.err, tt.interrupted); got != tt.want {
+			t.Errorf("digestStatus(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRunLoop_SendsDigest(t *testing.T) {
+	session := NewSession(&MockDockerClient{}, &MockAgent{}, t.TempDir(), "alpine", "test-project", "gemini", "gemini-pro", 1)
+	recorder := &digestRecorder{}
+	session.Notifier = recorder
+	session.SlackThreadTS = "thread"
+
+	// No app_spec.txt: the loop fails right away, but still wraps up
+	if err := session.RunLoop(context.Background()); err == nil {
+		t.Fatal("expected RunLoop to fail without app_spec.txt")
+	}
+	if len(recorder.digests) != 1 {
+		t.Fatalf("expected one digest, got %d", len(recorder.digests))
+	}
+	if !strings.HasPrefix(recorder.digests[0].Status, "failed: CRITICAL ERROR") {
+		t.Errorf("unexpected digest status %q", recorder.digests[0].Status)
+	}
+}
@@ -17,3 +17,27 @@ type DockerClient interface {
 	ImageBuild(ctx context.Context, opts docker.ImageBuildOptions) (string, error)
 	PullImage(ctx context.Context, imageRef string) error
 }
+
+// ImageDigester is implemented by Docker clients that can report the digests
+// of a local image. It is required for image digest verification.
+type ImageDigester interface {
+	ImageDigests(ctx context.Context, imageRef string) ([]string, error)
+}
+
+// StreamingExecer is implemented by Docker clients that can forward command
+// output line by line while the command is still running.
+type StreamingExecer interface {
+	ExecStream(ctx context.Context, containerID string, cmd []string, onLine func(string)) (string, error)
+}
+
+// ContainerOptionsRunner is implemented by Docker clients that can start a
+// container with a custom entrypoint and command.
+type ContainerOptionsRunner interface {
+	RunContainerWithOptions(ctx context.Context, imageRef string, workspace string, extraBinds []string, env []string, user string, opts docker.ContainerOptions) (string, error)
+}
+
+// ContainerCommitter is implemented by Docker clients that can snapshot a
+// container into an image. It is required for container checkpoints.
+type ContainerCommitter interface {
+	CommitContainer(ctx context.Context, containerID, ref string) (string, error)
+}
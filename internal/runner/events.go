@@ -0,0 +1,99 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// EventType identifies an entry in the session event log.
+type EventType string
+
+const (
+	EventIterationStart    EventType = "iteration_start"
+	EventAgentResponse     EventType = "agent_response"
+	EventResponseTruncated EventType = "response_truncated"
+	EventCommandExecuted   EventType = "command_executed"
+	EventBlocker           EventType = "blocker"
+	EventQAResult          EventType = "qa_result"
+	EventSignOff           EventType = "signoff"
+	EventComplete          EventType = "complete"
+	EventFailure           EventType = "failure"
+)
+
+// EventLogFile is the workspace-relative path of the JSONL event log.
+var EventLogFile = filepath.Join(".recac", "events.jsonl")
+
+// Event is a single timestamped record in the session timeline.
+type Event struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Type      EventType              `json:"type"`
+	Project   string                 `json:"project"`
+	TicketID  string                 `json:"ticket_id,omitempty"`
+	Iteration int                    `json:"iteration"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
+// EventLog appends events as JSON lines to a file. It is safe for concurrent use.
+type EventLog struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewEventLog opens (or creates) the event log at path in append mode.
+func NewEventLog(path string) (*EventLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create event log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+	return &EventLog{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Write appends a single event to the log.
+func (l *EventLog) Write(e Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return fmt.Errorf("event log is closed")
+	}
+	return l.enc.Encode(e)
+}
+
+// Close flushes and closes the underlying file.
+func (l *EventLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// emitEvent records an event in the session event log, if one is configured,
+// and runs the lifecycle hooks registered for it.
+func (s *Session) emitEvent(t EventType, data map[string]interface{}) {
+	s.runLifecycleHooks(t, data)
+	if s.EventLog == nil {
+		return
+	}
+	e := Event{
+		Timestamp: time.Now().UTC(),
+		Type:      t,
+		Project:   s.Project,
+		TicketID:  s.JiraTicketID,
+		Iteration: s.GetIteration(),
+		Data:      data,
+	}
+	if err := s.EventLog.Write(e); err != nil && s.Logger != nil {
+		s.Logger.Warn("failed to write event log entry", "type", t, "error", err)
+	}
+}
@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEventLog_WritesJSONLines(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, EventLogFile)
+
+	eventLog, err := NewEventLog(path)
+	if err != nil {
+		t.Fatalf("NewEventLog failed: %v", err)
+	}
+
+	session := &Session{Project: "proj", JiraTicketID: "RECAC-1", Iteration: 3, EventLog: eventLog}
+	session.emitEvent(EventIterationStart, nil)
+	session.emitEvent(EventCommandExecuted, map[string]interface{}{"script": "ls", "success": true})
+
+	if err := eventLog.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open event log: %v", err)
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		events = append(events, e)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Type != EventIterationStart || events[0].Project != "proj" || events[0].TicketID != "RECAC-1" || events[0].Iteration != 3 {
+		t.Errorf("Unexpected first event: %+v", events[0])
+	}
+	if events[1].Type != EventCommandExecuted || events[1].Data["script"] != "ls" {
+		t.Errorf("Unexpected second event: %+v", events[1])
+	}
+	if events[0].Timestamp.IsZero() {
+		t.Error("Expected timestamp to be set")
+	}
+}
+
+func TestEventLog_DisabledIsNoOp(t *testing.T) {
+	session := &Session{Project: "proj"}
+	// Must not panic without an event log configured
+	session.emitEvent(EventBlocker, map[string]interface{}{"message": "stuck"})
+}
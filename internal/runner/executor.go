@@ -9,41 +9,118 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"recac/internal/db"
 	"recac/internal/telemetry"
+	"recac/internal/utils"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
-var bashBlockRegex = regexp.MustCompile("(?s)```bash\\s*(.*?)\\s*```")
+// DefaultCommandFences are the code fence languages run as commands when
+// command_fences is not configured.
+var DefaultCommandFences = []string{"bash", "sh", "shell", "console"}
+
+// commandBlockRegex matches fenced blocks labelled with one of langs. The
+// fence may be indented and carry attributes ("```bash title=x",
+// "```{.sh}"); group 1 is its indentation and group 2 the block's content.
+func commandBlockRegex(langs []string) *regexp.Regexp {
+	quoted := make([]string, len(langs))
+	for i, lang := range langs {
+		quoted[i] = regexp.QuoteMeta(lang)
+	}
+	return regexp.MustCompile("(?sm)(^[ \t]*)?```[ \t]*\\{?[ \t]*\\.?(?i:" + strings.Join(quoted, "|") + ")" +
+		"(?:[ \t]*\\{[^}\n]*\\}|[ \t]+[\\w.-]+=[^\n]*|\\})?(?:[ \t]*\n|[ \t]+)(.*?)\\s*```")
+}
+
+// extractCommands returns the scripts of the command blocks in response,
+// normalized for execution by normalizeCommandBlock.
+func extractCommands(response string) []string {
+	langs := viper.GetStringSlice("command_fences")
+	if len(langs) == 0 {
+		langs = DefaultCommandFences
+	}
+
+	var scripts []string
+	for _, match := range commandBlockRegex(langs).FindAllStringSubmatch(response, -1) {
+		scripts = append(scripts, normalizeCommandBlock(match[2], match[1]))
+	}
+	return scripts
+}
+
+// normalizeCommandBlock strips the fence's indentation from every line of
+// script. A console transcript (first line "$ go test") is reduced to its
+// prompted commands, dropping their sample output.
+func normalizeCommandBlock(script, indent string) string {
+	lines := strings.Split(script, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, indent)
+	}
+	if !strings.HasPrefix(lines[0], "$ ") {
+		return strings.Join(lines, "\n")
+	}
+
+	var prompted []string
+	for _, line := range lines {
+		if rest, ok := strings.CutPrefix(line, "$ "); ok {
+			prompted = append(prompted, rest)
+		}
+	}
+	return strings.Join(prompted, "\n")
+}
+
+// CommandPolicyFile is the workspace-relative path of the optional command policy.
+var CommandPolicyFile = filepath.Join(".recac", "command_policy.yaml")
+
+// DefaultCommandTimeout bounds a single bash block when no timeout is configured.
+const DefaultCommandTimeout = 10 * time.Minute
+
+// commandTimeout returns the per-command timeout. command_timeout accepts a
+// duration ("10m") or seconds; the legacy bash_timeout (seconds) is the fallback.
+func commandTimeout(cfg *viper.Viper) time.Duration {
+	if raw := cfg.GetString("command_timeout"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			return d
+		}
+		if secs, err := strconv.Atoi(raw); err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	if secs := cfg.GetInt("bash_timeout"); secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return DefaultCommandTimeout
+}
 
 // ProcessResponse parses the agent response for commands, executes them, and handles blockers.
 func (s *Session) ProcessResponse(ctx context.Context, response string) (string, error) {
-	// 1. Extract Bash Blocks (More robust regex to handle variations in LLM output)
-	matches := bashBlockRegex.FindAllStringSubmatch(response, -1)
+	// 1. Extract Bash Blocks (any configured fence language, see extractCommands)
+	return s.processCommands(ctx, response, extractCommands(response))
+}
 
+// processCommands executes scripts, the commands found in response, and handles blockers.
+func (s *Session) processCommands(ctx context.Context, response string, scripts []string) (string, error) {
 	// Safety valve: Prevent LLM loops from flooding the execution
 	const maxCommandBlocks = 100
-	if len(matches) > maxCommandBlocks {
-		s.Logger.Warn("Safety valve tripped: truncated too many command blocks", "total", len(matches), "limit", maxCommandBlocks)
-		matches = matches[:maxCommandBlocks]
+	if len(scripts) > maxCommandBlocks {
+		s.Logger.Warn("Safety valve tripped: truncated too many command blocks", "total", len(scripts), "limit", maxCommandBlocks)
+		scripts = scripts[:maxCommandBlocks]
 	}
 
 	var parsedOutput strings.Builder
 	// Get timeout from config
-	timeoutSeconds := viper.GetInt("bash_timeout")
-	if timeoutSeconds == 0 {
-		timeoutSeconds = 600 // Default 10 minutes
-	}
+	timeout := commandTimeout(s.config())
+	timeoutSeconds := int(timeout.Seconds())
 
-	for i, match := range matches {
-		cmdScript := strings.TrimSpace(match[1])
+	for i, script := range scripts {
+		cmdScript := strings.TrimSpace(script)
 		if cmdScript == "" {
 			continue
 		}
-		s.Logger.Info("executing command block", "index", i+1, "total", len(matches), "script", cmdScript)
+		s.Logger.Info("executing command block", "index", i+1, "total", len(scripts), "script", cmdScript)
 
 		// Heuristic: If block starts with '{' or '[' and parses as JSON, it's likely data mislabeled as bash.
 		if (strings.HasPrefix(cmdScript, "{") || strings.HasPrefix(cmdScript, "[")) && json.Valid([]byte(cmdScript)) {
@@ -52,18 +129,52 @@ func (s *Session) ProcessResponse(ctx context.Context, response string) (string,
 			continue
 		}
 
+		// Command Policy: reject before anything reaches the container
+		if err := s.CommandPolicy.Check(cmdScript); err != nil {
+			s.Logger.Warn("command blocked by policy", "script", cmdScript, "reason", err)
+			s.emitEvent(EventCommandExecuted, map[string]interface{}{
+				"script":  telemetry.Redact(cmdScript),
+				"success": false,
+				"blocked": true,
+			})
+			parsedOutput.WriteString(fmt.Sprintf("Command Blocked: %s\nReason: %s\nThis command was rejected by the project's command policy (%s) and was not executed. Use a different approach.\n", cmdScript, err, CommandPolicyFile))
+			break
+		}
+
+		// Safe Mode: risky commands wait for a human to confirm them
+		if s.safeMode() {
+			if line := s.CommandPolicy.NeedsConfirmation(cmdScript); line != "" {
+				reason, err := s.confirmCommand(ctx, cmdScript, line)
+				if err != nil {
+					return parsedOutput.String(), err
+				}
+				if reason != "" {
+					s.emitEvent(EventCommandExecuted, map[string]interface{}{
+						"script":  telemetry.Redact(cmdScript),
+						"success": false,
+						"blocked": true,
+					})
+					parsedOutput.WriteString(fmt.Sprintf("Command Denied: %s\nReason: %s\nSafe mode requires a human to confirm %q and the command was not executed. Use a different approach.\n", cmdScript, reason, line))
+					break
+				}
+			}
+		}
+
 		// Create timeout context for this specific command
-		cmdCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+		cmdCtx, cancel := context.WithTimeout(ctx, timeout)
+		cmdStart := time.Now()
 
 		// Execute via Docker or Local
 		var output string
 		var err error
+		var streamed bool
 
 		if s.UseLocalAgent {
 			// Execute Locally
 			cmd := exec.CommandContext(cmdCtx, "/bin/bash", "-c", cmdScript)
 			// Propagate Environment + Inject Project ID
 			cmd.Env = append(os.Environ(), fmt.Sprintf("RECAC_PROJECT_ID=%s", s.Project))
+			cmd.Env = append(cmd.Env, s.ExtraEnv...)
 			// Debug: Log key env vars for troubleshooting
 			s.Logger.Info("[DEBUG] Local exec env vars",
 				"RECAC_PROJECT_ID", s.Project,
@@ -74,8 +185,16 @@ func (s *Session) ProcessResponse(ctx context.Context, response string) (string,
 			var outBuf bytes.Buffer
 			cmd.Stdout = &outBuf
 			cmd.Stderr = &outBuf
+			// Don't wait on background children still holding the output pipe after a kill
+			cmd.WaitDelay = 5 * time.Second
 			err = cmd.Run()
 			output = outBuf.String()
+		} else if se, ok := s.Docker.(StreamingExecer); ok && s.StreamOutput {
+			// Execute via Docker, logging output as it arrives so long commands don't look hung
+			streamed = true
+			output, err = se.ExecStream(cmdCtx, s.GetContainerID(), []string{"/bin/bash", "-c", cmdScript}, func(line string) {
+				s.Logger.Info("command output", "index", i+1, "line", line)
+			})
 		} else {
 			// Execute via Docker
 			output, err = s.Docker.Exec(cmdCtx, s.GetContainerID(), []string{"/bin/bash", "-c", cmdScript})
@@ -83,6 +202,13 @@ func (s *Session) ProcessResponse(ctx context.Context, response string) (string,
 
 		cancel() // Ensure we release resources
 
+		s.emitEvent(EventCommandExecuted, map[string]interface{}{
+			"script":      telemetry.Redact(cmdScript),
+			"success":     err == nil,
+			"duration_ms": time.Since(cmdStart).Milliseconds(),
+			"output_len":  len(output),
+		})
+
 		if err != nil {
 			var errMsg string
 			if cmdCtx.Err() == context.DeadlineExceeded {
@@ -114,7 +240,7 @@ func (s *Session) ProcessResponse(ctx context.Context, response string) (string,
 				s.Logger.Info("command output truncated", "truncated_output", truncatedOutput)
 			} else {
 				// result := fmt.Sprintf("Command Output:\n%s\n", output)
-				if len(output) > 0 {
+				if len(output) > 0 && !streamed {
 					s.Logger.Info("command output", "output", output)
 				}
 			}
@@ -140,20 +266,19 @@ func (s *Session) ProcessResponse(ctx context.Context, response string) (string,
 
 	// Check for Blocker Signal (DB)
 	if s.DBStore != nil {
-		blockerMsg, err := s.DBStore.GetSignal(s.Project, "BLOCKER")
-		if err == nil && blockerMsg != "" {
-			fmt.Printf("\n!!! AGENT BLOCKED: %s !!!\n", blockerMsg)
+		blocker, err := db.GetBlocker(s.DBStore, s.Project)
+		if err == nil && blocker != nil {
+			s.reportBlocker(ctx, *blocker)
 			fmt.Println("Waiting for blocker to be resolved...")
 			return "", ErrBlocker
 		}
 	}
 
 	// Legacy File Check (Deprecating, but keeping for compatibility)
-	if s.Docker != nil {
+	if s.Docker != nil || s.UseLocalAgent {
 		blockerFiles := []string{"recac_blockers.txt", "blockers.txt"}
 		for _, bf := range blockerFiles {
-			checkCmd := []string{"/bin/sh", "-c", fmt.Sprintf("test -f %s && cat %s", bf, bf)}
-			blockerContent, err := s.Docker.Exec(ctx, s.GetContainerID(), checkCmd)
+			blockerContent, err := s.readBlockerFile(ctx, bf)
 			trimmed := strings.TrimSpace(blockerContent)
 			if err == nil && len(trimmed) > 0 {
 				// Check for false positives (status messages instead of blockers)
@@ -176,13 +301,14 @@ func (s *Session) ProcessResponse(ctx context.Context, response string) (string,
 				if isFalsePositive {
 					s.Logger.Info("ignoring false positive blocker", "file", bf, "content", trimmed)
 					// Cleanup the file so it doesn't re-trigger
-					s.Docker.Exec(ctx, s.GetContainerID(), []string{"rm", bf})
+					s.removeBlockerFile(ctx, bf)
 					continue
 				}
 
 				// Real Blocker found!
 				s.Logger.Warn("agent reported blocker file", "file", bf)
 				s.Logger.Warn("blocker content", "content", blockerContent)
+				s.emitEvent(EventBlocker, map[string]interface{}{"source": bf, "message": trimmed})
 				s.Logger.Info("session stopping to allow human resolution")
 				return "", ErrBlocker
 			}
@@ -195,12 +321,11 @@ func (s *Session) ProcessResponse(ctx context.Context, response string) (string,
 		FilesModified int
 		OutputLines   int
 	}{
-		Commands: len(matches),
+		Commands: len(scripts),
 	}
 
 	// Heuristic for files modified (counting write operations)
-	for _, match := range matches {
-		script := match[1]
+	for _, script := range scripts {
 		if strings.Contains(script, " > ") || strings.Contains(script, " >> ") || strings.Contains(script, "touch ") {
 			metrics.FilesModified++
 		}
@@ -234,9 +359,17 @@ func (s *Session) runCleanerAgent(ctx context.Context) error {
 		return fmt.Errorf("failed to read temp_files.txt: %w", err)
 	}
 
+	// Files matching .recacignore are never removed, even when listed
+	ignore, err := utils.LoadIgnore(s.Workspace)
+	if err != nil {
+		s.Logger.Warn("failed to read .recacignore", "error", err)
+		ignore = &utils.IgnoreMatcher{}
+	}
+
 	// Parse temp files (one per line)
 	lines := strings.Split(string(data), "\n")
 	cleaned := 0
+	kept := 0
 	errors := 0
 
 	for _, line := range lines {
@@ -268,6 +401,13 @@ func (s *Session) runCleanerAgent(ctx context.Context) error {
 			continue
 		}
 
+		info, statErr := os.Lstat(filePath)
+		if ignore.Match(rel, statErr == nil && info.IsDir()) {
+			s.Logger.Info("kept temp file protected by .recacignore", "file", line)
+			kept++
+			continue
+		}
+
 		if err := os.Remove(filePath); err != nil {
 			if !os.IsNotExist(err) {
 				s.Logger.Warn("failed to remove temp file", "file", line, "error", err)
@@ -279,7 +419,7 @@ func (s *Session) runCleanerAgent(ctx context.Context) error {
 		}
 	}
 
-	s.Logger.Info("cleaner agent complete", "removed", cleaned, "errors", errors)
+	s.Logger.Info("cleaner agent complete", "removed", cleaned, "kept", kept, "errors", errors)
 
 	// Clear the temp_files.txt itself
 	os.Remove(tempFilesPath)
@@ -0,0 +1,107 @@
+package runner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"recac/internal/db"
+)
+
+// minShrinkBaseline is the smallest feature list for which losing more than
+// half of its features counts as a destructive overwrite. Smaller lists are
+// only guarded against being emptied.
+const minShrinkBaseline = 4
+
+// isDestructiveShrink reports whether going from prev to curr features looks
+// like an accidental overwrite rather than normal planning churn.
+func isDestructiveShrink(prev, curr int) bool {
+	if prev == 0 || curr >= prev {
+		return false
+	}
+	if curr == 0 {
+		return true
+	}
+	return prev >= minShrinkBaseline && curr*2 < prev
+}
+
+// guardFeatureList protects against feature_list.json (or the DB copy) being
+// overwritten with an empty or drastically shorter list between iterations.
+// When that happens the larger of the DB GetFeatures snapshot and the last
+// list seen by this session is restored to both the DB and the workspace file.
+func (s *Session) guardFeatureList(features []db.Feature) []db.Feature {
+	if isDestructiveShrink(len(s.lastFeatures), len(features)) {
+		restored := s.lastFeatures
+		if snapshot := s.dbFeatureSnapshot(); len(snapshot) > len(restored) {
+			restored = snapshot
+		}
+		s.Logger.Warn("GUARDRAIL: feature list shrank unexpectedly, restoring previous snapshot",
+			"previous", len(s.lastFeatures), "loaded", len(features), "restored", len(restored))
+		s.restoreFeatures(restored)
+		features = restored
+	} else if fileCount, ok := s.featureFileCount(); ok && isDestructiveShrink(len(features), fileCount) {
+		// The DB is authoritative, but agents read the workspace file.
+		s.Logger.Warn("GUARDRAIL: feature_list.json shrank unexpectedly, restoring from DB",
+			"expected", len(features), "file", fileCount)
+		s.writeFeatureFile(features)
+	}
+
+	if len(features) > 0 {
+		s.lastFeatures = features
+	}
+	return features
+}
+
+// dbFeatureSnapshot returns the feature list currently stored in the DB.
+func (s *Session) dbFeatureSnapshot() []db.Feature {
+	if s.DBStore == nil {
+		return nil
+	}
+	content, err := s.DBStore.GetFeatures(s.Project)
+	if err != nil || content == "" {
+		return nil
+	}
+	var fl db.FeatureList
+	if err := json.Unmarshal([]byte(content), &fl); err != nil {
+		return nil
+	}
+	return fl.Features
+}
+
+// featureFileCount returns the number of features in the workspace
+// feature_list.json, and false if the file is missing or invalid.
+func (s *Session) featureFileCount() (int, bool) {
+	data, err := os.ReadFile(filepath.Join(s.Workspace, "feature_list.json"))
+	if err != nil {
+		return 0, false
+	}
+	var fl db.FeatureList
+	if err := json.Unmarshal(data, &fl); err != nil {
+		return 0, false
+	}
+	return len(fl.Features), true
+}
+
+func (s *Session) restoreFeatures(features []db.Feature) {
+	if s.DBStore != nil {
+		fl := db.FeatureList{ProjectName: s.Project, Features: features}
+		if data, err := json.Marshal(fl); err == nil {
+			if err := s.DBStore.SaveFeatures(s.Project, string(data)); err != nil {
+				s.Logger.Error("failed to restore features to DB", "error", err)
+			}
+		}
+	}
+	s.writeFeatureFile(features)
+}
+
+func (s *Session) writeFeatureFile(features []db.Feature) {
+	fl := db.FeatureList{ProjectName: s.Project, Features: features}
+	data, err := json.MarshalIndent(fl, "", "  ")
+	if err != nil {
+		return
+	}
+	listPath := filepath.Join(s.Workspace, "feature_list.json")
+	if err := os.WriteFile(listPath, data, 0644); err != nil {
+		s.Logger.Error("failed to restore feature_list.json", "path", listPath, "error", err)
+	}
+}
@@ -0,0 +1,107 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"recac/internal/db"
+)
+
+func featureListJSON(n int) string {
+	fl := db.FeatureList{ProjectName: "test-project"}
+	for i := 0; i < n; i++ {
+		fl.Features = append(fl.Features, db.Feature{ID: fmt.Sprint(i), Description: fmt.Sprintf("feat %d", i), Status: "todo"})
+	}
+	data, _ := json.Marshal(fl)
+	return string(data)
+}
+
+func TestIsDestructiveShrink(t *testing.T) {
+	tests := []struct {
+		prev, curr int
+		want       bool
+	}{
+		{0, 0, false},
+		{5, 5, false},
+		{5, 7, false},
+		{5, 0, true},
+		{1, 0, true},
+		{3, 1, false},
+		{10, 6, false},
+		{10, 4, true},
+	}
+	for _, tt := range tests {
+		if got := isDestructiveShrink(tt.prev, tt.curr); got != tt.want {
+			t.Errorf("isDestructiveShrink(%d, %d) = %v, want %v", tt.prev, tt.curr, got, tt.want)
+		}
+	}
+}
+
+func TestLoadFeatures_RestoresEmptiedFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	session := NewSession(nil, &MockAgent{}, tmpDir, "alpine", "test-project", "gemini", "gemini-pro", 1)
+	if session.DBStore == nil {
+		t.Fatal("DBStore not initialized")
+	}
+	if err := session.DBStore.SaveFeatures(session.Project, featureListJSON(5)); err != nil {
+		t.Fatal(err)
+	}
+
+	listPath := filepath.Join(tmpDir, "feature_list.json")
+	os.WriteFile(listPath, []byte(`{"project_name": "test-project", "features": []}`), 0644)
+
+	if features := session.loadFeatures(); len(features) != 5 {
+		t.Fatalf("Expected 5 features from DB, got %d", len(features))
+	}
+
+	data, _ := os.ReadFile(listPath)
+	var fl db.FeatureList
+	if err := json.Unmarshal(data, &fl); err != nil {
+		t.Fatal(err)
+	}
+	if len(fl.Features) != 5 {
+		t.Errorf("Expected feature_list.json restored to 5 features, got %d", len(fl.Features))
+	}
+}
+
+func TestLoadFeatures_RestoresDrasticShrinkBetweenIterations(t *testing.T) {
+	tmpDir := t.TempDir()
+	session := NewSession(nil, &MockAgent{}, tmpDir, "alpine", "test-project", "gemini", "gemini-pro", 1)
+	if session.DBStore == nil {
+		t.Fatal("DBStore not initialized")
+	}
+	session.DBStore.SaveFeatures(session.Project, featureListJSON(8))
+
+	if features := session.loadFeatures(); len(features) != 8 {
+		t.Fatalf("Expected 8 features, got %d", len(features))
+	}
+
+	// Something overwrites the DB copy with a much shorter list
+	session.DBStore.SaveFeatures(session.Project, featureListJSON(2))
+
+	if features := session.loadFeatures(); len(features) != 8 {
+		t.Fatalf("Expected guard to restore 8 features, got %d", len(features))
+	}
+
+	content, _ := session.DBStore.GetFeatures(session.Project)
+	var fl db.FeatureList
+	json.Unmarshal([]byte(content), &fl)
+	if len(fl.Features) != 8 {
+		t.Errorf("Expected DB snapshot restored to 8 features, got %d", len(fl.Features))
+	}
+}
+
+func TestLoadFeatures_AllowsGrowth(t *testing.T) {
+	tmpDir := t.TempDir()
+	session := NewSession(nil, &MockAgent{}, tmpDir, "alpine", "test-project", "gemini", "gemini-pro", 1)
+	session.DBStore.SaveFeatures(session.Project, featureListJSON(2))
+	session.loadFeatures()
+
+	session.DBStore.SaveFeatures(session.Project, featureListJSON(6))
+	if features := session.loadFeatures(); len(features) != 6 {
+		t.Errorf("Expected 6 features, got %d", len(features))
+	}
+}
@@ -0,0 +1,49 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// freshStatePatterns match the workspace files that carry a session over to
+// the next run: the SQLite database (with its journal files), the agent
+// state of the session and its per-task agents, and the container checkpoint.
+var freshStatePatterns = []string{
+	".recac.db",
+	".recac.db-*",
+	".agent_state.json",
+	".agent_state_*.json",
+	CheckpointFile,
+}
+
+// WipeWorkspaceState deletes the session database and agent state files
+// from workspace and returns the paths it removed. It must run before the
+// session is created, since creating it opens the database.
+func WipeWorkspaceState(workspace string) ([]string, error) {
+	var removed []string
+	for _, pattern := range freshStatePatterns {
+		matches, err := filepath.Glob(filepath.Join(workspace, pattern))
+		if err != nil {
+			return removed, err
+		}
+		for _, path := range matches {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return removed, fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+			removed = append(removed, path)
+		}
+	}
+	return removed, nil
+}
+
+// ResetProjectState deletes the project's observations, signals, features
+// and locks from the session database. Shared databases such as Postgres
+// outlive the workspace, so wiping the files alone does not clear them.
+func (s *Session) ResetProjectState() error {
+	if s.DBStore == nil {
+		return nil
+	}
+	return s.DBStore.ResetProject(s.Project)
+}
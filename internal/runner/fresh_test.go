@@ -0,0 +1,56 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"recac/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWipeWorkspaceState(t *testing.T) {
+	workspace := t.TempDir()
+	for _, name := range []string{".recac.db", ".recac.db-wal", ".agent_state.json", ".agent_state_task-1.json", "app_spec.txt", "feature_list.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(workspace, name), []byte("x"), 0644))
+	}
+
+	removed, err := WipeWorkspaceState(workspace)
+	require.NoError(t, err)
+	assert.Len(t, removed, 4)
+
+	for _, name := range []string{".recac.db", ".recac.db-wal", ".agent_state.json", ".agent_state_task-1.json"} {
+		assert.NoFileExists(t, filepath.Join(workspace, name))
+	}
+	// Project files are kept
+	assert.FileExists(t, filepath.Join(workspace, "app_spec.txt"))
+	assert.FileExists(t, filepath.Join(workspace, "feature_list.json"))
+
+	// Nothing to do on a clean workspace
+	removed, err = WipeWorkspaceState(workspace)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+}
+
+func TestSession_ResetProjectState(t *testing.T) {
+	store, err := db.NewSQLiteStore(filepath.Join(t.TempDir(), "shared.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.NoError(t, store.SetSignal("proj", "PROJECT_SIGNED_OFF", "true"))
+	require.NoError(t, store.SaveFeatures("proj", `{"features": []}`))
+
+	s := &Session{Project: "proj", DBStore: store}
+	require.NoError(t, s.ResetProjectState())
+
+	val, err := store.GetSignal("proj", "PROJECT_SIGNED_OFF")
+	require.NoError(t, err)
+	assert.Empty(t, val)
+	features, err := store.GetFeatures("proj")
+	require.NoError(t, err)
+	assert.Empty(t, features)
+
+	assert.NoError(t, (&Session{}).ResetProjectState())
+}
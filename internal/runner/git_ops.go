@@ -7,15 +7,99 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"recac/internal/agent/prompts"
 	"recac/internal/db"
 	"recac/internal/git"
 	"recac/internal/notify"
 	"reflect"
 	"strings"
+)
 
-	"github.com/spf13/viper"
+// Merge conflict strategies for the sign-off merge guardrail (conflict_strategy).
+const (
+	// ConflictStrategyReset aborts the merge and, if recovery fails, discards
+	// the feature branch so the agent starts clean from the base branch.
+	ConflictStrategyReset = "reset"
+	// ConflictStrategyResolve leaves the conflict markers in the workspace and
+	// asks the agent to resolve them before the merge is re-attempted.
+	ConflictStrategyResolve = "resolve"
 )
 
+// resolveMergeConflicts runs the conflict resolution prompt against an
+// in-progress merge with origin/BaseBranch and commits the result. It reports
+// whether the merge was completed; on false the merge is still in progress.
+func (s *Session) resolveMergeConflicts(ctx context.Context, gitClient git.IClient) bool {
+	out, err := gitClient.Run(s.Workspace, "diff", "--name-only", "--diff-filter=U")
+	if err != nil || out == "" {
+		return false
+	}
+	files := strings.Split(out, "\n")
+
+	prompt, err := prompts.GetPrompt(prompts.ConflictResolver, map[string]string{
+		"branch": s.BaseBranch,
+		"files":  "- " + strings.Join(files, "\n- "),
+	})
+	if err != nil {
+		s.Logger.Warn("failed to load conflict resolution prompt", "error", err)
+		return false
+	}
+
+	s.Logger.Info("asking agent to resolve merge conflicts", "branch", s.BaseBranch, "files", files)
+	if _, err := s.RunIteration(ctx, prompt, false); err != nil {
+		s.Logger.Warn("conflict resolution iteration failed", "error", err)
+		return false
+	}
+
+	if err := s.fixPermissions(ctx); err != nil {
+		s.Logger.Warn("failed to fix permissions after conflict resolution", "error", err)
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(s.Workspace, file))
+		if err == nil && hasConflictMarkers(string(content)) {
+			s.Logger.Warn("conflict markers remain after resolution", "file", file)
+			return false
+		}
+	}
+
+	if err := gitClient.Commit(s.Workspace, fmt.Sprintf("Merge origin/%s (conflicts resolved by agent)", s.BaseBranch)); err != nil {
+		// The agent may have committed the merge itself
+		if _, mergeInProgress := gitClient.Run(s.Workspace, "rev-parse", "-q", "--verify", "MERGE_HEAD"); mergeInProgress == nil {
+			s.Logger.Warn("failed to commit resolved merge", "error", err)
+			return false
+		}
+	}
+	s.Logger.Info("merge conflicts resolved by agent", "branch", s.BaseBranch)
+	return true
+}
+
+// hasConflictMarkers reports whether content still contains git conflict markers.
+func hasConflictMarkers(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "<<<<<<< ") || strings.HasPrefix(line, ">>>>>>> ") {
+			return true
+		}
+	}
+	return false
+}
+
+// detectBaseBranch sets BaseBranch to the default branch of the workspace's
+// origin. It leaves BaseBranch empty when the workspace has no origin/HEAD.
+func (s *Session) detectBaseBranch() {
+	gitClient := git.NewClient()
+	if s.Workspace == "" || !gitClient.RepoExists(s.Workspace) {
+		return
+	}
+
+	branch, err := gitClient.DefaultBranch(s.Workspace)
+	if err != nil {
+		s.Logger.Debug("could not detect base branch", "error", err)
+		return
+	}
+	s.BaseBranch = branch
+	s.Logger.Info("detected base branch", "branch", branch)
+}
+
 // bootstrapGit sets up default git configuration in the container.
 func (s *Session) bootstrapGit(ctx context.Context) error {
 	containerID := s.GetContainerID()
@@ -23,8 +107,8 @@ func (s *Session) bootstrapGit(ctx context.Context) error {
 		return fmt.Errorf("container not started")
 	}
 
-	email := viper.GetString("git_user_email")
-	name := viper.GetString("git_user_name")
+	email := s.config().GetString("git_user_email")
+	name := s.config().GetString("git_user_name")
 
 	if email == "" {
 		email = "recac-agent@example.com"
@@ -38,8 +122,10 @@ func (s *Session) bootstrapGit(ctx context.Context) error {
 # RECAC Agent Artifacts
 .recac.db
 .recac.db-wal
+.recac/events.jsonl
 .agent_state.json
 .agent_state_*.json
+.recac_checkpoint.json
 .qa_result
 manager_directives.txt
 successes.txt
@@ -192,25 +278,74 @@ func (s *Session) pushProgress(ctx context.Context) {
 	}
 
 	// Push progress
+	s.refreshGitAuth(ctx, gitClient)
 	if err := gitClient.Push(s.Workspace, branch); err != nil {
 		s.Logger.Warn("failed to push progress", "error", err)
 	}
 }
 
+// refreshGitAuth renews the GitHub App installation token in the origin
+// remote before a push, as the one used for the clone expires after an hour.
+func (s *Session) refreshGitAuth(ctx context.Context, gitClient git.IClient) {
+	s.refreshGitAuthIn(ctx, gitClient, s.Workspace)
+}
+
+// refreshGitAuthIn is refreshGitAuth for the repository in dir. It does
+// nothing unless the session authenticates as a GitHub App.
+func (s *Session) refreshGitAuthIn(ctx context.Context, gitClient git.IClient, dir string) {
+	if s.GitHubApp == nil {
+		return
+	}
+	if err := s.GitHubApp.RefreshRemote(ctx, gitClient, dir); err != nil {
+		s.Logger.Warn("failed to refresh GitHub App credentials", "error", err)
+	}
+}
+
+// specSummary returns the task summary recorded in the spec header (e.g. "# Summary: ..."),
+// falling back to the first non-empty line of the spec.
+func (s *Session) specSummary() string {
+	spec := s.SpecContent
+	if spec == "" {
+		if data, err := os.ReadFile(filepath.Join(s.Workspace, s.SpecFile)); err == nil {
+			spec = string(data)
+		}
+	}
+
+	first := ""
+	for _, line := range strings.Split(spec, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(line, "# "))
+		if line == "" {
+			continue
+		}
+		if _, summary, ok := strings.Cut(line, "Summary:"); ok {
+			return strings.TrimSpace(summary)
+		}
+		if first == "" {
+			first = line
+		}
+	}
+	return first
+}
+
 // EnsureConflictTask checks if "Resolve Merge Conflicts" task exists, otherwise adds it.
 func (s *Session) EnsureConflictTask() {
+	s.ensureGuardrailTask("CONFLICT_RES", fmt.Sprintf("Resolve git merge conflicts with branch %s. Files contain conflict markers (<<<< HEAD). Fix them and commit.", s.BaseBranch))
+}
+
+// ensureGuardrailTask adds a critical guardrail feature with the given ID, or
+// reopens it if an earlier occurrence was already completed.
+func (s *Session) ensureGuardrailTask(taskID, description string) {
 	if s.DBStore == nil {
 		return
 	}
 	features := s.loadFeatures()
-	conflictTaskID := "CONFLICT_RES"
 	needsUpdate := false
 
 	// Check if already exists/pending
 	for idx, f := range features {
-		if f.ID == conflictTaskID {
+		if f.ID == taskID {
 			if f.Status == "done" || f.Status == "implemented" || f.Passes {
-				// Reset it to todo since we have a NEW conflict
+				// Reset it to todo since we have a NEW failure
 				features[idx].Status = "todo"
 				features[idx].Passes = false
 				needsUpdate = true
@@ -222,7 +357,7 @@ func (s *Session) EnsureConflictTask() {
 	// Add new if not found (needsUpdate loop below handles the save)
 	found := false
 	for _, f := range features {
-		if f.ID == conflictTaskID {
+		if f.ID == taskID {
 			found = true
 			break
 		}
@@ -230,10 +365,10 @@ func (s *Session) EnsureConflictTask() {
 
 	if !found {
 		newFeature := db.Feature{
-			ID:          conflictTaskID,
+			ID:          taskID,
 			Category:    "Guardrail",
 			Priority:    "Critical",
-			Description: fmt.Sprintf("Resolve git merge conflicts with branch %s. Files contain conflict markers (<<<< HEAD). Fix them and commit.", s.BaseBranch),
+			Description: description,
 			Status:      "todo",
 			Passes:      false,
 		}
@@ -250,6 +385,42 @@ func (s *Session) EnsureConflictTask() {
 	}
 }
 
+// completeTicket reports completion back to the work item's source (GitHub issue or Jira ticket).
+func (s *Session) completeTicket(ctx context.Context, gitLink string) {
+	if s.GitHubClient != nil && s.GitHubIssue > 0 {
+		s.completeGitHubIssue(ctx, gitLink)
+		return
+	}
+	s.completeJiraTicket(ctx, gitLink)
+}
+
+// completeGitHubIssue comments on the source GitHub issue with the link, optionally closes it, and sends a notification.
+func (s *Session) completeGitHubIssue(ctx context.Context, gitLink string) {
+	fmt.Printf("[%s] Finalizing GitHub issue #%d...\n", s.Project, s.GitHubIssue)
+
+	// 1. Add Comment with Link
+	comment := fmt.Sprintf("RECAC session completed successfully.\n\nGit Link: %s", gitLink)
+	if err := s.GitHubClient.AddComment(ctx, s.GitHubIssue, comment); err != nil {
+		fmt.Printf("[%s] Warning: Failed to comment on GitHub issue #%d: %v\n", s.Project, s.GitHubIssue, err)
+	} else {
+		fmt.Printf("[%s] GitHub issue #%d commented with Git link.\n", s.Project, s.GitHubIssue)
+	}
+
+	// 2. Close (opt-in, the PR/branch may still need review)
+	if s.config().GetBool("github.close_issues") {
+		if err := s.GitHubClient.CloseIssue(ctx, s.GitHubIssue); err != nil {
+			fmt.Printf("[%s] Warning: Failed to close GitHub issue #%d: %v\n", s.Project, s.GitHubIssue, err)
+		} else {
+			fmt.Printf("[%s] GitHub issue #%d closed.\n", s.Project, s.GitHubIssue)
+		}
+	}
+
+	// 3. Send Notification with Links
+	notificationMsg := fmt.Sprintf("Project %s is COMPLETE!\n\nIssue: %s\nGit: %s", s.Project, s.GitHubClient.IssueURL(s.GitHubIssue), gitLink)
+	s.Notifier.Notify(ctx, notify.EventProjectComplete, notificationMsg, s.GetSlackThreadTS())
+	s.Notifier.AddReaction(ctx, s.GetSlackThreadTS(), "white_check_mark")
+}
+
 // completeJiraTicket performs the final Jira transition, adds a comment with the link, and sends a notification.
 func (s *Session) completeJiraTicket(ctx context.Context, gitLink string) {
 	if s.JiraClient == nil || (reflect.ValueOf(s.JiraClient).Kind() == reflect.Ptr && reflect.ValueOf(s.JiraClient).IsNil()) || s.JiraTicketID == "" {
@@ -268,9 +439,12 @@ func (s *Session) completeJiraTicket(ctx context.Context, gitLink string) {
 		fmt.Printf("[%s] Jira comment added with Git link.\n", s.JiraTicketID)
 	}
 
-	// 2. Transition to Done
+	// 2. Attach configured artifacts (jira.attachments)
+	s.attachJiraArtifacts(ctx)
+
+	// 3. Transition to Done
 	// We use "Done" as the default target status, but it could be configurable
-	targetStatus := viper.GetString("jira.done_status")
+	targetStatus := s.config().GetString("jira.done_status")
 	if targetStatus == "" {
 		targetStatus = "Done"
 	}
@@ -282,8 +456,8 @@ func (s *Session) completeJiraTicket(ctx context.Context, gitLink string) {
 		fmt.Printf("[%s] Jira ticket transitioned to %s.\n", s.JiraTicketID, targetStatus)
 	}
 
-	// 3. Send Notification with Links
-	jiraURL := viper.GetString("jira.url")
+	// 4. Send Notification with Links
+	jiraURL := s.config().GetString("jira.url")
 	if jiraURL == "" {
 		jiraURL = os.Getenv("JIRA_URL")
 	}
@@ -0,0 +1,127 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"recac/internal/agent"
+	"recac/internal/git"
+	"recac/internal/telemetry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_DetectBaseBranch(t *testing.T) {
+	originalNewClient := git.NewClient
+	defer func() { git.NewClient = originalNewClient }()
+
+	t.Run("uses the remote default branch", func(t *testing.T) {
+		mockGit := new(MockGitClient)
+		mockGit.On("RepoExists", "/ws").Return(true)
+		mockGit.On("DefaultBranch", "/ws").Return("master", nil)
+		git.NewClient = func() git.IClient { return mockGit }
+
+		s := &Session{Workspace: "/ws", Logger: telemetry.NewLogger(true, "", false)}
+		s.detectBaseBranch()
+		assert.Equal(t, "master", s.BaseBranch)
+	})
+
+	t.Run("leaves it empty without origin/HEAD", func(t *testing.T) {
+		mockGit := new(MockGitClient)
+		mockGit.On("RepoExists", "/ws").Return(true)
+		mockGit.On("DefaultBranch", "/ws").Return("", errors.New("not a symbolic ref"))
+		git.NewClient = func() git.IClient { return mockGit }
+
+		s := &Session{Workspace: "/ws", Logger: telemetry.NewLogger(true, "", false)}
+		s.detectBaseBranch()
+		assert.Empty(t, s.BaseBranch)
+	})
+
+	t.Run("skips non-repositories", func(t *testing.T) {
+		mockGit := new(MockGitClient)
+		mockGit.On("RepoExists", "/ws").Return(false)
+		git.NewClient = func() git.IClient { return mockGit }
+
+		s := &Session{Workspace: "/ws", Logger: telemetry.NewLogger(true, "", false)}
+		s.detectBaseBranch()
+		assert.Empty(t, s.BaseBranch)
+		mockGit.AssertNotCalled(t, "DefaultBranch", "/ws")
+	})
+}
+
+func newConflictedWorkspace(t *testing.T) string {
+	t.Helper()
+	t.Setenv("GIT_AUTHOR_NAME", "RECAC Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "RECAC Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	workspace := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = workspace
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(workspace, "shared.txt"), []byte(content), 0644))
+	}
+
+	write("base\n")
+	run("init", "-b", "main")
+	run("add", ".")
+	run("commit", "-m", "initial")
+	run("checkout", "-b", "feature")
+	write("feature\n")
+	run("commit", "-am", "feature change")
+	run("checkout", "main")
+	write("upstream\n")
+	run("commit", "-am", "upstream change")
+	run("checkout", "feature")
+
+	cmd := exec.Command("git", "merge", "main")
+	cmd.Dir = workspace
+	require.Error(t, cmd.Run(), "merge should conflict")
+	return workspace
+}
+
+func TestSession_ResolveMergeConflicts(t *testing.T) {
+	t.Run("commits the merge once markers are gone", func(t *testing.T) {
+		workspace := newConflictedWorkspace(t)
+		mockAgent := agent.NewMockAgent()
+		mockAgent.SetResponse("```bash\nprintf 'feature\\nupstream\\n' > shared.txt && git add shared.txt\n```")
+
+		s := &Session{Workspace: workspace, BaseBranch: "main", Agent: mockAgent, UseLocalAgent: true, Logger: telemetry.NewLogger(true, "", false)}
+		gitClient := git.NewClient()
+		require.True(t, s.resolveMergeConflicts(context.Background(), gitClient))
+
+		content, err := os.ReadFile(filepath.Join(workspace, "shared.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "feature\nupstream\n", string(content))
+		_, err = gitClient.Run(workspace, "rev-parse", "-q", "--verify", "MERGE_HEAD")
+		assert.Error(t, err, "merge should be concluded")
+	})
+
+	t.Run("leaves the merge in progress when markers remain", func(t *testing.T) {
+		workspace := newConflictedWorkspace(t)
+		mockAgent := agent.NewMockAgent()
+		mockAgent.SetResponse("I could not decide which side to keep.")
+
+		s := &Session{Workspace: workspace, BaseBranch: "main", Agent: mockAgent, UseLocalAgent: true, Logger: telemetry.NewLogger(true, "", false)}
+		gitClient := git.NewClient()
+		assert.False(t, s.resolveMergeConflicts(context.Background(), gitClient))
+
+		_, err := gitClient.Run(workspace, "rev-parse", "-q", "--verify", "MERGE_HEAD")
+		assert.NoError(t, err, "merge should still be in progress")
+	})
+}
+
+func TestHasConflictMarkers(t *testing.T) {
+	assert.True(t, hasConflictMarkers("a\n<<<<<<< HEAD\nb\n=======\nc\n>>>>>>> main\n"))
+	assert.False(t, hasConflictMarkers("a\nb\n"))
+}
@@ -81,6 +81,11 @@ func TestSession_GitIgnoreFunctional(t *testing.T) {
 	srcPath := filepath.Join(workspace, "main.go")
 	os.WriteFile(srcPath, []byte("package main"), 0644)
 
+	// The event log is ignored, but project settings under .recac/ are not
+	os.MkdirAll(filepath.Join(workspace, ".recac"), 0755)
+	os.WriteFile(filepath.Join(workspace, EventLogFile), []byte("{}\n"), 0644)
+	os.WriteFile(filepath.Join(workspace, ".recac", "config.yaml"), []byte("provider: openai\n"), 0644)
+
 	// git add .
 	exec.Command("git", "-C", workspace, "add", ".").Run()
 
@@ -94,6 +99,12 @@ func TestSession_GitIgnoreFunctional(t *testing.T) {
 	if strings.Contains(files, ".recac.db") {
 		t.Errorf(".recac.db should NOT be tracked, but found in ls-files:\n%s", files)
 	}
+	if !strings.Contains(files, ".recac/config.yaml") {
+		t.Errorf(".recac/config.yaml should be tracked, but not found in ls-files:\n%s", files)
+	}
+	if strings.Contains(files, "events.jsonl") {
+		t.Errorf("events.jsonl should NOT be tracked, but found in ls-files:\n%s", files)
+	}
 }
 
 // Minimal mock for testing
@@ -12,6 +12,8 @@ import (
 	"recac/internal/db"
 	"recac/internal/notify"
 	"recac/internal/telemetry"
+
+	"github.com/spf13/viper"
 )
 
 // MockDockerForGuardrail is a simple mock that returns success for everything
@@ -107,3 +109,46 @@ func TestSession_Guardrail_PrematureSignoff(t *testing.T) {
 		t.Errorf("Guardrail failed: DB still has signal: %s", val)
 	}
 }
+
+func TestSession_Guardrail_SignoffMeetsCompletionPolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Half the features pass, which meets a min_pass_ratio of 0.5
+	features := `{"features":[{"id":"1","description":"feat","passes":true},{"id":"2","description":"feat","passes":false}]}`
+	os.WriteFile(filepath.Join(tmpDir, "app_spec.txt"), []byte("Spec"), 0644)
+
+	dbStore, err := db.NewSQLiteStore(filepath.Join(tmpDir, "recac.db"))
+	if err != nil {
+		t.Fatalf("Failed to init db: %v", err)
+	}
+	if err := dbStore.SaveFeatures("test-project", features); err != nil {
+		t.Fatalf("Failed to seed features: %v", err)
+	}
+	if err := dbStore.SetSignal("test-project", "PROJECT_SIGNED_OFF", "true"); err != nil {
+		t.Fatalf("Failed to seed signal: %v", err)
+	}
+
+	cfg := viper.New()
+	cfg.Set("completion.min_pass_ratio", 0.5)
+
+	s := &Session{
+		Docker:           &MockDockerForGuardrail{},
+		Agent:            agent.NewMockAgent(),
+		Workspace:        tmpDir,
+		Project:          "test-project",
+		MaxIterations:    2,
+		ManagerFrequency: 5,
+		DBStore:          dbStore,
+		Config:           cfg,
+		Notifier:         notify.NewManager(func(string, ...interface{}) {}),
+		Logger:           telemetry.NewLogger(true, "", false),
+	}
+
+	if err := s.RunLoop(context.Background()); err != nil {
+		t.Fatalf("RunLoop failed: %v", err)
+	}
+
+	if !s.hasSignal("PROJECT_SIGNED_OFF") {
+		t.Error("PROJECT_SIGNED_OFF should be kept when the completion policy is met")
+	}
+}
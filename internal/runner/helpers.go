@@ -1,14 +1,11 @@
 package runner
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
-	"time"
 )
 
 // fixPasswdDatabase ensures the host user exists in the container's /etc/passwd.
@@ -94,63 +91,3 @@ func (s *Session) findAgentBridgeBinary() (string, error) {
 	}
 	return srcPath, nil
 }
-
-// runInitScript checks for init.sh in the workspace and executes it if present.
-// Failures are logged as warnings but do not stop the session.
-func (s *Session) runInitScript(ctx context.Context) {
-	initPath := filepath.Join(s.Workspace, "init.sh")
-	if _, err := os.Stat(initPath); os.IsNotExist(err) {
-		return
-	}
-
-	fmt.Println("Found init.sh. Executing in container...")
-
-	// 1. Ensure executable
-	if s.UseLocalAgent {
-		if err := os.Chmod(initPath, 0755); err != nil {
-			fmt.Printf("Warning: Failed to make init.sh executable: %v\n", err)
-			return
-		}
-	} else {
-		if _, err := s.Docker.ExecAsUser(ctx, s.GetContainerID(), "root", []string{"chmod", "+x", "init.sh"}); err != nil {
-			fmt.Printf("Warning: Failed to make init.sh executable: %v\n", err)
-			return
-		}
-	}
-
-	// 2. Execute Async
-	fmt.Println("Found init.sh. Launching in background (10m timeout)...")
-	go func() {
-		asyncCtx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-		defer cancel()
-
-		var output string
-		var err error
-
-		if s.UseLocalAgent {
-			// Local Execution
-			cmd := exec.CommandContext(asyncCtx, "/bin/sh", "-c", "./init.sh")
-			cmd.Dir = s.Workspace
-			var outBuf bytes.Buffer
-			cmd.Stdout = &outBuf
-			cmd.Stderr = &outBuf
-			err = cmd.Run()
-			output = outBuf.String()
-		} else {
-			// Docker Execution
-			output, err = s.Docker.ExecAsUser(asyncCtx, s.GetContainerID(), "root", []string{"/bin/sh", "-c", "./init.sh"})
-		}
-
-		if err != nil {
-			if asyncCtx.Err() == context.DeadlineExceeded {
-				fmt.Printf("Warning: init.sh execution timed out after 10 minutes.\n")
-			} else {
-				fmt.Printf("Warning: init.sh execution failed: %v\n", err)
-			}
-		} else if len(output) > 0 {
-			fmt.Printf("async init.sh finished. Output:\n%s\n", output)
-		} else {
-			fmt.Println("async init.sh finished successfully.")
-		}
-	}()
-}
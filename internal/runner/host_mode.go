@@ -0,0 +1,50 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+// hostMode reports whether host_mode is enabled: commands run directly in the
+// workspace on this machine instead of in an agent container. It can only be
+// set globally (flag, environment or global config), never by a repository.
+func hostMode() bool {
+	return viper.GetBool("host_mode")
+}
+
+// warnHostMode reminds the user that commands are not isolated.
+func (s *Session) warnHostMode() {
+	msg := "Host mode: agent commands run directly on this machine, without container isolation"
+	if !s.safeMode() {
+		msg += "; consider safe_mode and a command policy (" + CommandPolicyFile + ")"
+	}
+	if s.Logger != nil {
+		s.Logger.Warn(msg, "workspace", s.Workspace)
+	} else {
+		fmt.Println("WARNING: " + msg)
+	}
+}
+
+// readBlockerFile returns the content of a legacy blocker file in the
+// workspace, from the host in local mode and from the container otherwise.
+func (s *Session) readBlockerFile(ctx context.Context, name string) (string, error) {
+	if s.UseLocalAgent {
+		data, err := os.ReadFile(filepath.Join(s.Workspace, name))
+		return string(data), err
+	}
+	checkCmd := []string{"/bin/sh", "-c", fmt.Sprintf("test -f %s && cat %s", name, name)}
+	return s.Docker.Exec(ctx, s.GetContainerID(), checkCmd)
+}
+
+// removeBlockerFile deletes a legacy blocker file so it doesn't re-trigger.
+func (s *Session) removeBlockerFile(ctx context.Context, name string) {
+	if s.UseLocalAgent {
+		os.Remove(filepath.Join(s.Workspace, name))
+		return
+	}
+	s.Docker.Exec(ctx, s.GetContainerID(), []string{"rm", name})
+}
@@ -0,0 +1,54 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"recac/internal/telemetry"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSession_HostMode(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	s := NewSession(&MockDockerClient{}, &MockAgent{}, t.TempDir(), "alpine", "host-project", "gemini", "gemini-pro", 1)
+	assert.False(t, s.UseLocalAgent)
+
+	viper.Set("host_mode", true)
+	defer viper.Set("host_mode", nil)
+	s = NewSession(&MockDockerClient{}, &MockAgent{}, t.TempDir(), "alpine", "host-project", "gemini", "gemini-pro", 1)
+	assert.True(t, s.UseLocalAgent)
+}
+
+func TestProcessResponse_HostMode(t *testing.T) {
+	workspace := t.TempDir()
+	s := &Session{Workspace: workspace, Project: "host-project", UseLocalAgent: true, Logger: telemetry.NewLogger(true, "", false)}
+
+	t.Run("runs commands in the workspace", func(t *testing.T) {
+		output, err := s.ProcessResponse(context.Background(), "```bash\npwd\necho hi > out.txt\n```")
+		require.NoError(t, err)
+		assert.Contains(t, output, workspace)
+		assert.FileExists(t, filepath.Join(workspace, "out.txt"))
+	})
+
+	t.Run("ignores status messages in blocker files", func(t *testing.T) {
+		path := filepath.Join(workspace, "blockers.txt")
+		require.NoError(t, os.WriteFile(path, []byte("No blockers"), 0644))
+
+		_, err := s.ProcessResponse(context.Background(), "")
+		require.NoError(t, err)
+		assert.NoFileExists(t, path)
+	})
+
+	t.Run("stops on blocker files", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(workspace, "recac_blockers.txt"), []byte("Need database credentials"), 0644))
+
+		_, err := s.ProcessResponse(context.Background(), "")
+		assert.ErrorIs(t, err, ErrBlocker)
+	})
+}
@@ -0,0 +1,83 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"recac/internal/notify"
+)
+
+// Human sign-off gate states, stored as the value of the PENDING_HUMAN_SIGNOFF signal.
+// The agent-bridge approve/reject commands move the gate out of the pending state.
+const (
+	HumanSignoffSignal   = "PENDING_HUMAN_SIGNOFF"
+	HumanSignoffPending  = "pending"
+	HumanSignoffApproved = "approved"
+	HumanSignoffRejected = "rejected"
+)
+
+// humanSignoffPollInterval is how often the run loop re-checks a pending sign-off.
+var humanSignoffPollInterval = 10 * time.Second
+
+// requestHumanSignoff parks the session at the human approval gate after the Manager approved.
+func (s *Session) requestHumanSignoff(ctx context.Context) error {
+	if s.DBStore == nil {
+		return fmt.Errorf("db store not initialized")
+	}
+	if err := s.DBStore.SetSignal(s.Project, HumanSignoffSignal, HumanSignoffPending); err != nil {
+		return err
+	}
+	s.Logger.Info("manager approved, waiting for human sign-off", "project", s.Project)
+	fmt.Printf("Awaiting human sign-off. Run 'agent-bridge approve %s' or 'agent-bridge reject %s <reason>'.\n", s.Project, s.Project)
+	s.Notifier.Notify(ctx, notify.EventUserInteraction, fmt.Sprintf("Project %s is awaiting human sign-off (agent-bridge approve/reject %s)", s.Project, s.Project), s.GetSlackThreadTS())
+	return nil
+}
+
+// awaitHumanSignoff resolves the human approval gate. It returns true while the gate is
+// still pending (the caller should wait and re-check), and false once there is nothing to wait for.
+func (s *Session) awaitHumanSignoff(ctx context.Context) bool {
+	if s.DBStore == nil {
+		return false
+	}
+	state, err := s.DBStore.GetSignal(s.Project, HumanSignoffSignal)
+	if err != nil || state == "" {
+		return false
+	}
+
+	switch state {
+	case HumanSignoffApproved:
+		s.clearSignal(HumanSignoffSignal)
+		if err := s.createSignal("PROJECT_SIGNED_OFF"); err != nil {
+			fmt.Printf("Warning: Failed to create PROJECT_SIGNED_OFF: %v\n", err)
+		}
+		s.emitEvent(EventSignOff, map[string]interface{}{"by": "human"})
+		fmt.Println("Human sign-off received. Project signed off.")
+		s.Notifier.Notify(ctx, notify.EventSuccess, fmt.Sprintf("Project %s Signed Off by human reviewer!", s.Project), s.GetSlackThreadTS())
+		return false
+
+	case HumanSignoffRejected:
+		s.clearSignal(HumanSignoffSignal)
+		s.clearSignal("QA_PASSED")
+		s.clearSignal("COMPLETED")
+
+		// Surface the reviewer's feedback to the coding agent via history
+		reason, _ := s.DBStore.GetSignal(s.Project, HumanSignoffSignal+"_REASON")
+		s.DBStore.DeleteSignal(s.Project, HumanSignoffSignal+"_REASON")
+		feedback := "Human reviewer rejected the sign-off. Continue working on the project."
+		if reason != "" {
+			feedback = fmt.Sprintf("Human reviewer rejected the sign-off: %s", reason)
+		}
+		if err := s.DBStore.SaveObservation(s.Project, "Human", feedback); err != nil {
+			s.Logger.Warn("failed to save sign-off rejection to history", "error", err)
+		}
+		fmt.Println("Human sign-off rejected. Returning to coding phase.")
+		return false
+
+	default:
+		// Still pending: block like a blocker until a human acts
+		s.Logger.Debug("waiting for human sign-off", "state", state)
+		s.SleepFunc(humanSignoffPollInterval)
+		return true
+	}
+}
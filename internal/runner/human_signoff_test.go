@@ -0,0 +1,109 @@
+package runner
+
+import (
+	"context"
+	"path/filepath"
+	"recac/internal/db"
+	"recac/internal/notify"
+	"recac/internal/telemetry"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func newHumanSignoffSession(t *testing.T) *Session {
+	t.Helper()
+	workspace := t.TempDir()
+	store, err := db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	return &Session{
+		Workspace: workspace,
+		Project:   "test-project",
+		DBStore:   store,
+		Notifier:  notify.NewManager(func(string, ...interface{}) {}),
+		Logger:    telemetry.NewLogger(true, "", false),
+		SleepFunc: func(time.Duration) {},
+	}
+}
+
+func TestHumanSignoff_Pending(t *testing.T) {
+	session := newHumanSignoffSession(t)
+
+	if session.awaitHumanSignoff(context.Background()) {
+		t.Error("Expected no wait when no sign-off was requested")
+	}
+
+	if err := session.requestHumanSignoff(context.Background()); err != nil {
+		t.Fatalf("requestHumanSignoff failed: %v", err)
+	}
+	if !session.awaitHumanSignoff(context.Background()) {
+		t.Error("Expected to wait while sign-off is pending")
+	}
+	if session.hasSignal("PROJECT_SIGNED_OFF") {
+		t.Error("PROJECT_SIGNED_OFF must not be set before a human approves")
+	}
+}
+
+func TestHumanSignoff_Approved(t *testing.T) {
+	session := newHumanSignoffSession(t)
+	session.DBStore.SetSignal(session.Project, HumanSignoffSignal, HumanSignoffApproved)
+
+	if session.awaitHumanSignoff(context.Background()) {
+		t.Error("Expected no wait after approval")
+	}
+	if !session.hasSignal("PROJECT_SIGNED_OFF") {
+		t.Error("Expected PROJECT_SIGNED_OFF after approval")
+	}
+	if val, _ := session.DBStore.GetSignal(session.Project, HumanSignoffSignal); val != "" {
+		t.Errorf("Expected gate signal to be cleared, got %q", val)
+	}
+}
+
+func TestHumanSignoff_Rejected(t *testing.T) {
+	session := newHumanSignoffSession(t)
+	session.createSignal("QA_PASSED")
+	session.createSignal("COMPLETED")
+	session.DBStore.SetSignal(session.Project, HumanSignoffSignal, HumanSignoffRejected)
+	session.DBStore.SetSignal(session.Project, HumanSignoffSignal+"_REASON", "login page is broken")
+
+	if session.awaitHumanSignoff(context.Background()) {
+		t.Error("Expected no wait after rejection")
+	}
+	if session.hasSignal("PROJECT_SIGNED_OFF") || session.hasSignal("QA_PASSED") || session.hasSignal("COMPLETED") {
+		t.Error("Expected lifecycle signals to be cleared after rejection")
+	}
+
+	history, err := session.DBStore.QueryHistory(session.Project, 10)
+	if err != nil {
+		t.Fatalf("QueryHistory failed: %v", err)
+	}
+	found := false
+	for _, obs := range history {
+		if strings.Contains(obs.Content, "login page is broken") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected rejection reason to be saved to history")
+	}
+}
+
+func TestNewSession_RequireHumanSignoff(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	if NewSession(nil, &MockAgent{}, t.TempDir(), "alpine", "test-project", "gemini", "gemini-pro", 1).RequireHumanSignoff {
+		t.Error("Expected sign-off not to be required by default")
+	}
+
+	viper.Set("require_human_signoff", true)
+	if !NewSession(nil, &MockAgent{}, t.TempDir(), "alpine", "test-project", "gemini", "gemini-pro", 1).RequireHumanSignoff {
+		t.Error("Expected require_human_signoff to be read from the config")
+	}
+}
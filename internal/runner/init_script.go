@@ -0,0 +1,168 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"recac/internal/telemetry"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultInitScript is the workspace-relative bootstrap script run before the loop.
+const DefaultInitScript = "init.sh"
+
+// DefaultInitScriptTimeout bounds the init script when no timeout is configured.
+const DefaultInitScriptTimeout = 10 * time.Minute
+
+// initScriptWaitDelay bounds how long a local init script's output is read
+// after it exits, so servers it leaves running in the background don't hang
+// the session.
+const initScriptWaitDelay = 5 * time.Second
+
+// initScriptPath returns the configured init script, relative to the workspace.
+// The script must live inside the workspace so it resolves the same way on the
+// host and in the container.
+func initScriptPath(cfg *viper.Viper) (string, error) {
+	name := strings.TrimSpace(cfg.GetString("init_script"))
+	if name == "" {
+		name = DefaultInitScript
+	}
+	name = path.Clean(filepath.ToSlash(name))
+	if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return "", fmt.Errorf("init_script %q must be a path inside the workspace", cfg.GetString("init_script"))
+	}
+	return name, nil
+}
+
+// initScriptEnv returns the KEY=VALUE pairs of init_script_env.
+func initScriptEnv(cfg *viper.Viper) ([]string, error) {
+	var env []string
+	for _, kv := range cfg.GetStringSlice("init_script_env") {
+		if key, _, ok := strings.Cut(kv, "="); !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("init_script_env entry %q must be KEY=VALUE", kv)
+		}
+		env = append(env, kv)
+	}
+	return env, nil
+}
+
+// initScriptTimeout returns init_script_timeout, falling back to
+// DefaultInitScriptTimeout when it is unset or not a positive duration.
+func initScriptTimeout(cfg *viper.Viper) time.Duration {
+	if d := cfg.GetDuration("init_script_timeout"); d > 0 {
+		return d
+	}
+	return DefaultInitScriptTimeout
+}
+
+// runInitScript runs the workspace's init script (init_script, init.sh by
+// default) before the loop starts, with init_script_env added to its
+// environment. Its output is stored as a System observation. A failing script
+// fails the session unless init_script_fail_on_error is false, in which case
+// it is logged as a warning.
+func (s *Session) runInitScript(ctx context.Context) error {
+	name, err := initScriptPath(s.config())
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(s.Workspace, filepath.FromSlash(name))); os.IsNotExist(err) {
+		return nil
+	}
+
+	output, err := s.execInitScript(ctx, name)
+	s.saveInitScriptOutput(name, output, err)
+	if err == nil {
+		fmt.Printf("%s finished successfully.\n", name)
+		return nil
+	}
+
+	err = fmt.Errorf("init script %s failed: %w", name, err)
+	if s.config().GetBool("init_script_fail_on_error") {
+		return err
+	}
+	fmt.Printf("Warning: %v\n", err)
+	return nil
+}
+
+// execInitScript makes the script executable and runs it in the workspace,
+// locally or in the container as root, returning its combined output.
+func (s *Session) execInitScript(ctx context.Context, name string) (string, error) {
+	env, err := initScriptEnv(s.config())
+	if err != nil {
+		return "", err
+	}
+	timeout := initScriptTimeout(s.config())
+	fmt.Printf("Found %s. Executing (%s timeout)...\n", name, timeout)
+
+	// 1. Ensure executable
+	if s.UseLocalAgent {
+		if err := os.Chmod(filepath.Join(s.Workspace, filepath.FromSlash(name)), 0755); err != nil {
+			return "", fmt.Errorf("failed to make %s executable: %w", name, err)
+		}
+	} else {
+		if _, err := s.Docker.ExecAsUser(ctx, s.GetContainerID(), "root", []string{"chmod", "+x", name}); err != nil {
+			return "", fmt.Errorf("failed to make %s executable: %w", name, err)
+		}
+	}
+
+	// 2. Execute
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var output string
+	if s.UseLocalAgent {
+		cmd := exec.CommandContext(runCtx, "/bin/sh", "-c", "./"+name)
+		cmd.Dir = s.Workspace
+		cmd.Env = append(os.Environ(), env...)
+		cmd.WaitDelay = initScriptWaitDelay
+		var outBuf bytes.Buffer
+		cmd.Stdout = &outBuf
+		cmd.Stderr = &outBuf
+		err = cmd.Run()
+		output = outBuf.String()
+	} else {
+		command := []string{"/bin/sh", "-c", "./" + name}
+		if len(env) > 0 {
+			command = append(append([]string{"env"}, env...), command...)
+		}
+		output, err = s.Docker.ExecAsUser(runCtx, s.GetContainerID(), "root", command)
+	}
+
+	// Output still pending after exit is dropped, not a failure
+	if errors.Is(err, exec.ErrWaitDelay) {
+		err = nil
+	}
+	if err != nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	return output, err
+}
+
+// saveInitScriptOutput records the init script's output as a System observation,
+// so the agent sees how the environment was bootstrapped.
+func (s *Session) saveInitScriptOutput(name, output string, runErr error) {
+	if s.DBStore == nil {
+		return
+	}
+	observation := fmt.Sprintf("Init script %s succeeded.", name)
+	if runErr != nil {
+		observation = fmt.Sprintf("Init script %s failed: %v", name, runErr)
+	}
+	if output = strings.TrimSpace(output); output != "" {
+		observation += "\nOutput:\n" + output
+	}
+
+	telemetry.TrackDBOp(s.Project)
+	if err := s.DBStore.SaveObservation(s.Project, "System", observation); err != nil {
+		s.Logger.Error("failed to save init script output to DB", "error", err)
+	}
+}
@@ -0,0 +1,142 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"recac/internal/db"
+	"recac/internal/telemetry"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInitScriptSession(t *testing.T) *Session {
+	t.Helper()
+	store, err := db.NewSQLiteStore(filepath.Join(t.TempDir(), ".recac.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return &Session{
+		Workspace:     t.TempDir(),
+		Project:       "init-project",
+		UseLocalAgent: true,
+		DBStore:       store,
+		Logger:        telemetry.NewLogger(true, "", false),
+	}
+}
+
+func TestRunInitScript_ConfiguredScriptAndEnv(t *testing.T) {
+	viper.Set("init_script", "scripts/bootstrap.sh")
+	viper.Set("init_script_env", []string{"FIXTURES=small", "GREETING=hello world"})
+	defer viper.Set("init_script", nil)
+	defer viper.Set("init_script_env", nil)
+
+	s := newInitScriptSession(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(s.Workspace, "scripts"), 0755))
+	script := "#!/bin/sh\necho \"$GREETING\"\necho \"$FIXTURES\" > fixtures.txt\n"
+	require.NoError(t, os.WriteFile(filepath.Join(s.Workspace, "scripts", "bootstrap.sh"), []byte(script), 0644))
+
+	require.NoError(t, s.runInitScript(context.Background()))
+	assert.FileExists(t, filepath.Join(s.Workspace, "fixtures.txt"))
+
+	history, err := s.DBStore.QueryHistory(s.Project, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "System", history[0].AgentID)
+	assert.Contains(t, history[0].Content, "Init script scripts/bootstrap.sh succeeded.")
+	assert.Contains(t, history[0].Content, "hello world")
+}
+
+func TestRunInitScript_Failure(t *testing.T) {
+	s := newInitScriptSession(t)
+	require.NoError(t, os.WriteFile(filepath.Join(s.Workspace, "init.sh"), []byte("#!/bin/sh\necho missing dependency\nexit 3\n"), 0644))
+
+	t.Run("fails the session", func(t *testing.T) {
+		viper.Set("init_script_fail_on_error", true)
+		defer viper.Set("init_script_fail_on_error", nil)
+
+		err := s.runInitScript(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "init script init.sh failed")
+	})
+
+	t.Run("warns when not required", func(t *testing.T) {
+		viper.Set("init_script_fail_on_error", false)
+		defer viper.Set("init_script_fail_on_error", nil)
+
+		assert.NoError(t, s.runInitScript(context.Background()))
+	})
+
+	history, err := s.DBStore.QueryHistory(s.Project, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	for _, obs := range history {
+		assert.True(t, strings.HasPrefix(obs.Content, "Init script init.sh failed: exit status 3"), obs.Content)
+		assert.Contains(t, obs.Content, "missing dependency")
+	}
+}
+
+func TestRunInitScript_Timeout(t *testing.T) {
+	viper.Set("init_script_timeout", "100ms")
+	viper.Set("init_script_fail_on_error", true)
+	defer viper.Set("init_script_timeout", nil)
+	defer viper.Set("init_script_fail_on_error", nil)
+
+	s := newInitScriptSession(t)
+	require.NoError(t, os.WriteFile(filepath.Join(s.Workspace, "init.sh"), []byte("#!/bin/sh\nsleep 1\n"), 0644))
+
+	err := s.runInitScript(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out after 100ms")
+}
+
+func TestRunInitScript_Config(t *testing.T) {
+	s := newInitScriptSession(t)
+
+	t.Run("missing script is skipped", func(t *testing.T) {
+		viper.Set("init_script_fail_on_error", true)
+		defer viper.Set("init_script_fail_on_error", nil)
+		assert.NoError(t, s.runInitScript(context.Background()))
+	})
+
+	t.Run("script outside the workspace", func(t *testing.T) {
+		viper.Set("init_script", "../init.sh")
+		defer viper.Set("init_script", nil)
+		assert.Error(t, s.runInitScript(context.Background()))
+	})
+
+	t.Run("malformed env", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(s.Workspace, "init.sh"), []byte("#!/bin/sh\n"), 0644))
+		viper.Set("init_script_env", []string{"NOVALUE"})
+		viper.Set("init_script_fail_on_error", true)
+		defer viper.Set("init_script_env", nil)
+		defer viper.Set("init_script_fail_on_error", nil)
+
+		err := s.runInitScript(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be KEY=VALUE")
+	})
+}
+
+func TestRunInitScript_DockerEnv(t *testing.T) {
+	viper.Set("init_script_env", []string{"CI=1"})
+	defer viper.Set("init_script_env", nil)
+
+	workspace := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "init.sh"), []byte("#!/bin/sh\n"), 0644))
+
+	var cmds []string
+	d := &MockDockerClient{}
+	d.ExecAsUserFunc = func(ctx context.Context, containerID, user string, cmd []string) (string, error) {
+		cmds = append(cmds, user+": "+strings.Join(cmd, " "))
+		return "", nil
+	}
+	s := &Session{Workspace: workspace, Docker: d, ContainerID: "test-container", Logger: telemetry.NewLogger(true, "", false)}
+
+	require.NoError(t, s.runInitScript(context.Background()))
+	assert.Equal(t, []string{"root: chmod +x init.sh", "root: env CI=1 /bin/sh -c ./init.sh"}, cmds)
+}
@@ -0,0 +1,61 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"recac/internal/notify"
+)
+
+// interruptTimeout bounds the wrap-up after the session's context is
+// cancelled. It stays under Kubernetes' default 30s termination grace period.
+const interruptTimeout = 20 * time.Second
+
+// handleInterrupt runs when RunLoop is stopped by its context (SIGTERM or
+// SIGINT, e.g. a pod eviction). It saves the agent state, pushes committed
+// work, reports the interruption and moves the Jira ticket back out of
+// "In Progress" so the session can be picked up again.
+func (s *Session) handleInterrupt(cause error) {
+	ctx, cancel := context.WithTimeout(context.Background(), interruptTimeout)
+	defer cancel()
+
+	s.Logger.Warn("session interrupted, saving progress", "iteration", s.GetIteration(), "cause", cause)
+
+	if err := s.SaveAgentState(); err != nil {
+		s.Logger.Warn("failed to save agent state", "error", err)
+	}
+
+	s.pushProgress(ctx)
+
+	// Not a failure: an eviction or restart should not page anyone
+	s.forceNotify(ctx, notify.EventUserInteraction, fmt.Sprintf("Project %s interrupted at iteration %d; progress was saved and the session can be resumed.", s.Project, s.GetIteration()))
+
+	s.releaseJiraTicket(ctx)
+}
+
+// releaseJiraTicket comments on the session's Jira ticket and transitions it
+// to jira.interrupted_status (default "To Do").
+func (s *Session) releaseJiraTicket(ctx context.Context) {
+	if s.JiraClient == nil || (reflect.ValueOf(s.JiraClient).Kind() == reflect.Ptr && reflect.ValueOf(s.JiraClient).IsNil()) || s.JiraTicketID == "" {
+		return
+	}
+
+	comment := fmt.Sprintf("RECAC session was interrupted at iteration %d. Committed work has been pushed; the ticket can be picked up again.", s.GetIteration())
+	if err := s.JiraClient.AddComment(ctx, s.JiraTicketID, comment); err != nil {
+		fmt.Printf("[%s] Warning: Failed to add Jira comment: %v\n", s.JiraTicketID, err)
+	}
+
+	targetStatus := s.config().GetString("jira.interrupted_status")
+	if targetStatus == "" {
+		targetStatus = "To Do"
+	}
+
+	fmt.Printf("[%s] Transitioning ticket to '%s'...\n", s.JiraTicketID, targetStatus)
+	if err := s.JiraClient.SmartTransition(ctx, s.JiraTicketID, targetStatus); err != nil {
+		fmt.Printf("[%s] Warning: Failed to transition Jira ticket to %s: %v\n", s.JiraTicketID, targetStatus, err)
+	} else {
+		fmt.Printf("[%s] Jira ticket transitioned to %s.\n", s.JiraTicketID, targetStatus)
+	}
+}
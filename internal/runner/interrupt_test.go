@@ -0,0 +1,114 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"recac/internal/notify"
+	"recac/internal/telemetry"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RecordingJiraClient records comments and transitions
+type RecordingJiraClient struct {
+	Comments    []string
+	Transitions []string
+}
+
+func (m *RecordingJiraClient) AddComment(ctx context.Context, ticketID, comment string) error {
+	m.Comments = append(m.Comments, comment)
+	return nil
+}
+
+func (m *RecordingJiraClient) SmartTransition(ctx context.Context, ticketID, target string) error {
+	m.Transitions = append(m.Transitions, target)
+	return nil
+}
+
+func TestSession_RunLoop_Interrupted(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "RECAC Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "RECAC Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	remote := t.TempDir()
+	workspace := t.TempDir()
+	run := func(dir string, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	run(remote, "init", "--bare", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "app_spec.txt"), []byte("spec"), 0644))
+	run(workspace, "init", "-b", "main")
+	run(workspace, "remote", "add", "origin", remote)
+	run(workspace, "add", ".")
+	run(workspace, "commit", "-m", "initial")
+	run(workspace, "push", "origin", "main")
+	run(workspace, "checkout", "-b", "feature/interrupted")
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "work.txt"), []byte("work"), 0644))
+	run(workspace, "add", "work.txt")
+	run(workspace, "commit", "-m", "work in progress")
+
+	spy := &SpyNotifier{}
+	jira := &RecordingJiraClient{}
+	s := &Session{
+		Workspace:     workspace,
+		Project:       "interrupted",
+		BaseBranch:    "main",
+		UseLocalAgent: true,
+		Notifier:      spy,
+		JiraClient:    jira,
+		JiraTicketID:  "PROJ-1",
+		SlackThreadTS: "thread-ts",
+		Logger:        telemetry.NewLogger(true, "", false),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := s.RunLoop(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Committed work reached the remote
+	assert.Equal(t, run(workspace, "rev-parse", "HEAD"), run(remote, "rev-parse", "feature/interrupted"))
+
+	// The interruption is reported, without paging: PagerDuty triggers on failures
+	found := false
+	for _, m := range spy.Messages {
+		assert.NotEqual(t, notify.EventFailure, m.EventType)
+		if m.EventType == notify.EventUserInteraction && strings.Contains(m.Message, "interrupted") {
+			found = true
+		}
+	}
+	assert.True(t, found, "expected an interruption notification")
+
+	// The ticket is released
+	require.Len(t, jira.Comments, 1)
+	assert.Contains(t, jira.Comments[0], "interrupted")
+	assert.Equal(t, []string{"To Do"}, jira.Transitions)
+}
+
+func TestSession_ReleaseJiraTicket(t *testing.T) {
+	viper.Set("jira.interrupted_status", "Backlog")
+	defer viper.Set("jira.interrupted_status", "")
+
+	jira := &RecordingJiraClient{}
+	s := &Session{JiraClient: jira, JiraTicketID: "PROJ-1"}
+	s.releaseJiraTicket(context.Background())
+	assert.Equal(t, []string{"Backlog"}, jira.Transitions)
+
+	// Sessions without a ticket are left alone
+	jira = &RecordingJiraClient{}
+	s = &Session{JiraClient: jira}
+	s.releaseJiraTicket(context.Background())
+	assert.Empty(t, jira.Transitions)
+}
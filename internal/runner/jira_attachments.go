@@ -0,0 +1,61 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// JiraAttachmentClient is implemented by Jira clients that can upload files to
+// a ticket. Sessions whose client doesn't implement it skip artifact uploads.
+type JiraAttachmentClient interface {
+	AddAttachment(ctx context.Context, issueKey, filePath string) error
+}
+
+// jiraArtifacts returns the workspace files matching the jira.attachments
+// globs, in pattern order and without duplicates. Patterns are relative to the
+// workspace; matches outside it and directories are skipped.
+func (s *Session) jiraArtifacts() []string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, pattern := range s.config().GetStringSlice("jira.attachments") {
+		matches, err := filepath.Glob(filepath.Join(s.Workspace, pattern))
+		if err != nil {
+			fmt.Printf("[%s] Warning: Invalid attachment pattern %q: %v\n", s.JiraTicketID, pattern, err)
+			continue
+		}
+		for _, match := range matches {
+			rel, err := filepath.Rel(s.Workspace, match)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || seen[match] {
+				continue
+			}
+			if info, err := os.Stat(match); err != nil || info.IsDir() {
+				continue
+			}
+			seen[match] = true
+			files = append(files, match)
+		}
+	}
+	return files
+}
+
+// attachJiraArtifacts uploads the jira.attachments artifacts (coverage
+// reports, build logs) to the session's ticket, so the agent's evidence stays
+// with the work. Failed uploads are logged and don't stop the others.
+func (s *Session) attachJiraArtifacts(ctx context.Context) {
+	client, ok := s.JiraClient.(JiraAttachmentClient)
+	if !ok {
+		return
+	}
+
+	for _, file := range s.jiraArtifacts() {
+		rel, _ := filepath.Rel(s.Workspace, file)
+		if err := client.AddAttachment(ctx, s.JiraTicketID, file); err != nil {
+			fmt.Printf("[%s] Warning: Failed to attach %s: %v\n", s.JiraTicketID, rel, err)
+			continue
+		}
+		fmt.Printf("[%s] Attached %s.\n", s.JiraTicketID, rel)
+	}
+}
@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"recac/internal/telemetry"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// AttachingJiraClient records comments, transitions and uploaded files
+type AttachingJiraClient struct {
+	RecordingJiraClient
+	Attachments []string
+	FailOn      string
+}
+
+func (m *AttachingJiraClient) AddAttachment(ctx context.Context, issueKey, filePath string) error {
+	if filepath.Base(filePath) == m.FailOn {
+		return errors.New("upload rejected")
+	}
+	m.Attachments = append(m.Attachments, filePath)
+	return nil
+}
+
+func TestCompleteJiraTicket_AttachesArtifacts(t *testing.T) {
+	workspace := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workspace, "reports", "nested.log"), 0755))
+	for _, name := range []string{"coverage.out", "reports/build.log", "reports/test.log", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(workspace, name), []byte(name), 0644))
+	}
+
+	viper.Set("jira.attachments", []string{"coverage.out", "reports/*.log", "coverage.*", "../*", "missing.xml"})
+	defer viper.Set("jira.attachments", nil)
+
+	client := &AttachingJiraClient{FailOn: "build.log"}
+	s := &Session{
+		Project:      "attach-project",
+		Workspace:    workspace,
+		Notifier:     &SpyNotifier{},
+		JiraClient:   client,
+		JiraTicketID: "PROJ-1",
+		Logger:       telemetry.NewLogger(true, "", false),
+	}
+
+	s.completeJiraTicket(context.Background(), "https://example.com/commit/sha")
+
+	// A failed upload doesn't stop the others or the transition
+	assert.Equal(t, []string{
+		filepath.Join(workspace, "coverage.out"),
+		filepath.Join(workspace, "reports", "test.log"),
+	}, client.Attachments)
+	assert.Equal(t, []string{"Done"}, client.Transitions)
+}
+
+func TestAttachJiraArtifacts_UnsupportedClient(t *testing.T) {
+	workspace := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "coverage.out"), []byte("x"), 0644))
+
+	viper.Set("jira.attachments", []string{"coverage.out"})
+	defer viper.Set("jira.attachments", nil)
+
+	s := &Session{Workspace: workspace, JiraClient: &RecordingJiraClient{}, JiraTicketID: "PROJ-1"}
+	assert.Equal(t, []string{filepath.Join(workspace, "coverage.out")}, s.jiraArtifacts())
+	assert.NotPanics(t, func() { s.attachJiraArtifacts(context.Background()) })
+}
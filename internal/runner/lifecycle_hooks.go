@@ -0,0 +1,135 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"recac/internal/git"
+
+	"github.com/spf13/viper"
+)
+
+// hookTimeout bounds how long a single lifecycle hook may run.
+var hookTimeout = 30 * time.Second
+
+// LifecycleHooks maps hook names (on_<event type>, e.g. on_signoff) to the
+// shell commands or http(s) URLs invoked when the session reaches that event.
+type LifecycleHooks map[string][]string
+
+// HookPayload is the JSON document POSTed to URL hooks and written to the
+// stdin of command hooks.
+type HookPayload struct {
+	Event     string                 `json:"event"`
+	Project   string                 `json:"project"`
+	TicketID  string                 `json:"ticket_id,omitempty"`
+	Branch    string                 `json:"branch,omitempty"`
+	Workspace string                 `json:"workspace"`
+	Iteration int                    `json:"iteration"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// LoadLifecycleHooks reads the hooks.* config section. Each hook accepts a
+// single command/URL or a list of them.
+func LoadLifecycleHooks() LifecycleHooks {
+	hooks := LifecycleHooks{}
+	for name := range viper.GetStringMap("hooks") {
+		var targets []string
+		for _, target := range viper.GetStringSlice("hooks." + name) {
+			if target = strings.TrimSpace(target); target != "" {
+				targets = append(targets, target)
+			}
+		}
+		if len(targets) > 0 {
+			hooks[name] = targets
+		}
+	}
+	return hooks
+}
+
+// runLifecycleHooks invokes the hooks configured for event. Hook failures are
+// logged and never interrupt the session.
+func (s *Session) runLifecycleHooks(event EventType, data map[string]interface{}) {
+	targets := s.Hooks["on_"+string(event)]
+	if len(targets) == 0 {
+		return
+	}
+
+	payload := HookPayload{
+		Event:     string(event),
+		Project:   s.Project,
+		TicketID:  s.JiraTicketID,
+		Workspace: s.Workspace,
+		Iteration: s.GetIteration(),
+		Data:      data,
+		Timestamp: time.Now().UTC(),
+	}
+	if branch, err := git.NewClient().CurrentBranch(s.Workspace); err == nil {
+		payload.Branch = branch
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	for _, target := range targets {
+		ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+		if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+			err = postHook(ctx, target, body)
+		} else {
+			err = execHook(ctx, target, payload, body)
+		}
+		cancel()
+		if s.Logger == nil {
+			continue
+		}
+		if err != nil {
+			s.Logger.Warn("lifecycle hook failed", "event", event, "hook", target, "error", err)
+		} else {
+			s.Logger.Info("lifecycle hook completed", "event", event, "hook", target)
+		}
+	}
+}
+
+func postHook(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// execHook runs command through sh with the project, ticket and branch as
+// positional arguments ($1..$3), the payload on stdin and RECAC_* variables set.
+func execHook(ctx context.Context, command string, payload HookPayload, body []byte) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command, "recac-hook", payload.Project, payload.TicketID, payload.Branch)
+	cmd.Dir = payload.Workspace
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"RECAC_HOOK_EVENT="+payload.Event,
+		"RECAC_PROJECT="+payload.Project,
+		"RECAC_TICKET_ID="+payload.TicketID,
+		"RECAC_BRANCH="+payload.Branch,
+		"RECAC_WORKSPACE="+payload.Workspace,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
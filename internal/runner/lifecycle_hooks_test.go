@@ -0,0 +1,94 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"recac/internal/telemetry"
+
+	"github.com/spf13/viper"
+)
+
+func TestLoadLifecycleHooks(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("hooks.on_signoff", "./deploy-preview.sh")
+	viper.Set("hooks.on_failure", []string{"https://example.com/hook", "  ", "echo failed"})
+	viper.Set("hooks.on_blocker", []string{})
+
+	hooks := LoadLifecycleHooks()
+	if got := hooks["on_signoff"]; len(got) != 1 || got[0] != "./deploy-preview.sh" {
+		t.Errorf("unexpected on_signoff hooks: %v", got)
+	}
+	if got := hooks["on_failure"]; len(got) != 2 || got[1] != "echo failed" {
+		t.Errorf("unexpected on_failure hooks: %v", got)
+	}
+	if _, ok := hooks["on_blocker"]; ok {
+		t.Error("expected empty hook list to be dropped")
+	}
+}
+
+func TestRunLifecycleHooks_Command(t *testing.T) {
+	workspace := t.TempDir()
+	out := filepath.Join(workspace, "hook.out")
+	s := &Session{
+		Workspace:    workspace,
+		Project:      "proj",
+		JiraTicketID: "PROJ-1",
+		Logger:       telemetry.NewLogger(true, "", false),
+		Hooks: LifecycleHooks{
+			"on_signoff": {`echo "$1 $2 $RECAC_HOOK_EVENT" > hook.out; cat >> hook.out`},
+		},
+	}
+
+	s.emitEvent(EventSignOff, map[string]interface{}{"by": "manager"})
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	lines := strings.SplitN(string(data), "\n", 2)
+	if lines[0] != "proj PROJ-1 signoff" {
+		t.Errorf("unexpected hook arguments: %q", lines[0])
+	}
+	var payload HookPayload
+	if err := json.Unmarshal([]byte(lines[1]), &payload); err != nil {
+		t.Fatalf("expected JSON payload on stdin: %v", err)
+	}
+	if payload.Event != "signoff" || payload.Data["by"] != "manager" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestRunLifecycleHooks_URL(t *testing.T) {
+	var received HookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	s := &Session{
+		Workspace: t.TempDir(),
+		Project:   "proj",
+		Logger:    telemetry.NewLogger(true, "", false),
+		Hooks:     LifecycleHooks{"on_failure": {server.URL}},
+	}
+
+	// RunLoop fails immediately without app_spec.txt
+	if err := s.RunLoop(context.Background()); err == nil {
+		t.Fatal("expected RunLoop to fail without app_spec.txt")
+	}
+
+	if received.Event != "failure" || received.Project != "proj" {
+		t.Errorf("unexpected payload: %+v", received)
+	}
+	if msg, _ := received.Data["error"].(string); !strings.Contains(msg, "app_spec.txt") {
+		t.Errorf("expected failure error in payload, got %v", received.Data)
+	}
+}
@@ -2,10 +2,12 @@ package runner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"recac/internal/agent"
 	"recac/internal/agent/prompts"
 	"recac/internal/git"
 	"recac/internal/notify"
@@ -15,7 +17,21 @@ import (
 )
 
 // RunLoop executes the autonomous agent loop.
-func (s *Session) RunLoop(ctx context.Context) error {
+func (s *Session) RunLoop(ctx context.Context) (err error) {
+	defer func() {
+		// Stopped by SIGTERM/SIGINT: leave the session resumable
+		if err != nil && ctx.Err() != nil {
+			s.handleInterrupt(err)
+		}
+		if err != nil {
+			s.emitEvent(EventFailure, map[string]interface{}{"error": err.Error()})
+		} else {
+			s.emitEvent(EventComplete, nil)
+		}
+		s.sendDigest(err, ctx.Err() != nil)
+	}()
+	s.runStarted = time.Now()
+
 	// Guard: Ensure Notifier is initialized (mostly for tests using manual struct initialization)
 	if s.Notifier == nil {
 		s.Notifier = notify.NewManager(func(string, ...interface{}) {})
@@ -26,6 +42,12 @@ func (s *Session) RunLoop(ctx context.Context) error {
 		s.SleepFunc = time.Sleep
 	}
 
+	if s.StatusAddr != "" {
+		statusCtx, stopStatus := context.WithCancel(ctx)
+		defer stopStatus()
+		s.serveStatus(statusCtx)
+	}
+
 	s.Logger.Info("entering autonomous run loop")
 	// Note: We use the stored SlackThreadTS if available (from startup), otherwise we start a new thread here if needed?
 	// But Start() is called before RunLoop(), so s.SlackThreadTS should be set if notifications are enabled.
@@ -49,6 +71,11 @@ func (s *Session) RunLoop(ctx context.Context) error {
 		return fmt.Errorf("CRITICAL ERROR: app_spec.txt not found in workspace (%s). This file is required as the source of truth for the project.", s.Workspace)
 	}
 
+	// Merge guardrail and auto-merge need a base branch; default to the remote's
+	if s.BaseBranch == "" && s.SelectedTaskID == "" {
+		s.detectBaseBranch()
+	}
+
 	// Load agent state if it exists (for session restoration)
 	if err := s.LoadAgentState(); err != nil {
 		fmt.Printf("Warning: Failed to load agent state: %v\n", err)
@@ -63,18 +90,11 @@ func (s *Session) RunLoop(ctx context.Context) error {
 		}
 	}
 
-	// Startup Check: If feature list exists and all passed, mark COMPLETED
+	// Startup Check: If feature list exists and meets the completion policy, mark COMPLETED
 	features := s.loadFeatures()
 	if len(features) > 0 {
-		allPassed := true
-		for _, f := range features {
-			if !(f.Passes || f.Status == "done" || f.Status == "implemented") {
-				allPassed = false
-				break
-			}
-		}
-		if allPassed {
-			fmt.Println("All features passed! Triggering Project Complete flow.")
+		if LoadCompletionPolicy(s.config()).Unmet(features) == "" {
+			fmt.Println("Completion policy met! Triggering Project Complete flow.")
 			if err := s.createSignal("COMPLETED"); err != nil {
 				fmt.Printf("Warning: Failed to create COMPLETED signal: %v\n", err)
 			}
@@ -101,6 +121,9 @@ func (s *Session) RunLoop(ctx context.Context) error {
 		}
 	}()
 
+	// Baseline for the no-op breaker: changes made before the first iteration are not progress
+	s.workspaceChanged()
+
 	for {
 		// Check for cancellation
 		select {
@@ -109,6 +132,11 @@ func (s *Session) RunLoop(ctx context.Context) error {
 		default:
 		}
 
+		// Human Sign-off Gate: wait for agent-bridge approve/reject without consuming iterations
+		if s.awaitHumanSignoff(ctx) {
+			continue
+		}
+
 		// Check Max Iterations
 		currentIteration := s.GetIteration()
 		if s.MaxIterations > 0 && currentIteration >= s.MaxIterations {
@@ -118,6 +146,7 @@ func (s *Session) RunLoop(ctx context.Context) error {
 
 		newIteration := s.IncrementIteration()
 		s.Logger.Info("starting iteration", "iteration", newIteration, "task_id", s.SelectedTaskID, "agent_provider", s.AgentProvider, "agent_model", s.AgentModel)
+		s.emitEvent(EventIterationStart, map[string]interface{}{"task_id": s.SelectedTaskID})
 		if s.SelectedTaskID != "" {
 			// Log task description snippet for debugging context
 			descSnippet := ""
@@ -166,8 +195,15 @@ func (s *Session) RunLoop(ctx context.Context) error {
 						_ = gitClient.Stash(s.Workspace)
 
 						// 3. Attempt Merge
-						if err := gitClient.Merge(s.Workspace, "origin/"+s.BaseBranch); err != nil {
-							s.Logger.Warn("merge failed", "attempt", i+1, "max", maxRetries, "error", err)
+						mergeErr := gitClient.Merge(s.Workspace, "origin/"+s.BaseBranch)
+						if mergeErr != nil && s.ConflictStrategy == ConflictStrategyResolve {
+							s.Logger.Warn("merge conflicted, handing off to agent", "attempt", i+1, "max", maxRetries, "error", mergeErr)
+							if s.resolveMergeConflicts(ctx, gitClient) {
+								mergeErr = nil
+							}
+						}
+						if mergeErr != nil {
+							s.Logger.Warn("merge failed", "attempt", i+1, "max", maxRetries, "error", mergeErr)
 
 							// ENSURE WE ABORT to clear unmerged files
 							_ = gitClient.AbortMerge(s.Workspace)
@@ -181,18 +217,21 @@ func (s *Session) RunLoop(ctx context.Context) error {
 									s.Logger.Warn("recover failed", "error", err)
 								}
 
-								// Recovery Step 2: Clean aggressively
-								if err := gitClient.Clean(s.Workspace); err != nil {
-									s.Logger.Warn("clean failed", "error", err)
-								}
-
-								// Recovery Step 3: Hard Reset to origin/current_feature_branch
-								// This is safer than just 'reset --hard' without target
-								cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
-								cmd.Dir = s.Workspace
-								if out, err := cmd.Output(); err == nil {
-									currBranch := strings.TrimSpace(string(out))
-									_ = gitClient.ResetHard(s.Workspace, "origin", currBranch)
+								// The resolve strategy keeps the branch so the agent can retry on top of it
+								if s.ConflictStrategy != ConflictStrategyResolve {
+									// Recovery Step 2: Clean aggressively
+									if err := gitClient.Clean(s.Workspace); err != nil {
+										s.Logger.Warn("clean failed", "error", err)
+									}
+
+									// Recovery Step 3: Hard Reset to origin/current_feature_branch
+									// This is safer than just 'reset --hard' without target
+									cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+									cmd.Dir = s.Workspace
+									if out, err := cmd.Output(); err == nil {
+										currBranch := strings.TrimSpace(string(out))
+										_ = gitClient.ResetHard(s.Workspace, "origin", currBranch)
+									}
 								}
 							} else {
 								// Final Failure
@@ -219,12 +258,12 @@ func (s *Session) RunLoop(ctx context.Context) error {
 
 					// BRUTAL RECOVERY: If standard recovery fails, delete remote feature branch
 					// and let the agent start clean on next iteration.
-					if s.JiraTicketID != "" {
+					if s.JiraTicketID != "" && s.ConflictStrategy != ConflictStrategyResolve {
 						cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
 						cmd.Dir = s.Workspace
 						if out, err := cmd.Output(); err == nil {
 							featureBranch := strings.TrimSpace(string(out))
-							if featureBranch != s.BaseBranch && !strings.Contains(featureBranch, "HEAD") {
+							if featureBranch != s.BaseBranch && git.IsAgentBranch(featureBranch, s.JiraTicketID) {
 								fmt.Printf("[%s] BRUTAL RECOVERY: Deleting remote branch %s to clear conflict.\n", s.JiraTicketID, featureBranch)
 								_ = gitClient.DeleteRemoteBranch(s.Workspace, "origin", featureBranch)
 							}
@@ -243,18 +282,10 @@ func (s *Session) RunLoop(ctx context.Context) error {
 			}
 
 			// CRITICAL: Guardrail against premature sign-off.
-			// Validate that ALL features are actually passing before accepting the sign-off.
+			// Validate that the features meet the completion policy before accepting the sign-off.
 			features := s.loadFeatures()
-			incompleteFeatures := []string{}
-			for _, f := range features {
-				if !(f.Passes || f.Status == "done" || f.Status == "implemented") {
-					incompleteFeatures = append(incompleteFeatures, f.ID)
-				}
-			}
-
-			if len(incompleteFeatures) > 0 {
-
-				s.Logger.Warn("premature project sign-off detected", "incomplete_features", incompleteFeatures)
+			if reason := LoadCompletionPolicy(s.config()).Unmet(features); len(features) > 0 && reason != "" {
+				s.Logger.Warn("premature project sign-off detected", "reason", reason)
 
 				// Revoke signal
 				s.clearSignal("PROJECT_SIGNED_OFF")
@@ -278,8 +309,15 @@ func (s *Session) RunLoop(ctx context.Context) error {
 
 				// 0. COMMIT WORK: Ensure any pending changes are committed before merging
 				// We use a more careful commit strategy to avoid re-adding ignored files
-				commitCmd := exec.Command("sh", "-c", "git add . && git commit -m 'feat: implemented features for "+s.Project+"' || echo 'Nothing to commit'")
+				commitMsg := git.CommitMessage(git.CommitVars{
+					Project:  s.Project,
+					TicketID: s.JiraTicketID,
+					Summary:  s.specSummary(),
+				})
+				// Message is passed on stdin so templated values never reach the shell
+				commitCmd := exec.Command("sh", "-c", "git add . && git commit -F - || echo 'Nothing to commit'")
 				commitCmd.Dir = s.Workspace
+				commitCmd.Stdin = strings.NewReader(commitMsg)
 				if out, err := commitCmd.CombinedOutput(); err != nil {
 					fmt.Printf("Warning: Failed to auto-commit work: %v\nOutput: %s\n", err, out)
 				} else {
@@ -299,9 +337,31 @@ func (s *Session) RunLoop(ctx context.Context) error {
 					fmt.Printf("Warning: Failed to get current branch for auto-merge: %v\n", err)
 				} else {
 					featureBranch := strings.TrimSpace(string(out))
-
-					// 2. Checkout Base Branch
-					if err := gitClient.Checkout(s.Workspace, s.BaseBranch); err != nil {
+					components := s.prepareComponents(gitClient)
+
+					// 1b. Wait for required CI checks on the pushed feature branch of
+					// this repository and every component before merging any of them
+					var checksErr error
+					if len(s.RequiredChecks) > 0 {
+						checksErr = s.awaitRequiredChecks(ctx, gitClient, featureBranch)
+						if checksErr == nil {
+							checksErr = s.awaitComponentChecks(ctx, gitClient, components)
+						}
+					}
+					mergedLink := ""
+
+					// 2. Checkout Base Branch, unless the checks failed or could not be verified
+					if errors.Is(checksErr, errChecksFailed) {
+						s.Logger.Warn("required checks failed, revoking sign-off", "branch", featureBranch, "error", checksErr)
+						s.clearSignal("PROJECT_SIGNED_OFF")
+						s.ensureChecksTask(checksErr)
+						s.clearSignal("QA_PASSED")
+						s.clearSignal("COMPLETED")
+						continue
+					} else if checksErr != nil {
+						// Never merge unverified work; the branch stays pushed for a human to merge
+						fmt.Printf("Warning: Auto-merge skipped, required checks could not be verified: %v\n", checksErr)
+					} else if err := gitClient.Checkout(s.Workspace, s.BaseBranch); err != nil {
 						fmt.Printf("Warning: Auto-merge failed (checkout base): %v\n", err)
 					} else {
 						// 3. Merge Feature Branch
@@ -312,6 +372,7 @@ func (s *Session) RunLoop(ctx context.Context) error {
 							_ = gitClient.Recover(s.Workspace)
 						} else {
 							// 4. Push Base Branch
+							s.refreshGitAuth(ctx, gitClient)
 							if err := gitClient.Push(s.Workspace, s.BaseBranch); err != nil {
 								fmt.Printf("Warning: Auto-merge failed (push): %v\n", err)
 								// If push fails (likely race), abort the merge locally too so we can retry from clean state
@@ -320,10 +381,13 @@ func (s *Session) RunLoop(ctx context.Context) error {
 								fmt.Printf("Successfully auto-merged %s into %s and pushed.\n", featureBranch, s.BaseBranch)
 
 								// DELETE REMOTE FEATURE BRANCH (Cleanup)
-								// This keeps the repo clean and prevents branch accumulation
-								fmt.Printf("[%s] Deleting remote feature branch %s...\n", s.Project, featureBranch)
-								if err := gitClient.DeleteRemoteBranch(s.Workspace, "origin", featureBranch); err != nil {
-									fmt.Printf("[%s] Warning: Failed to delete remote branch: %v\n", s.Project, err)
+								// This keeps the repo clean and prevents branch accumulation.
+								// Only branches created by the agent for this ticket are removed.
+								if s.JiraTicketID == "" || git.IsAgentBranch(featureBranch, s.JiraTicketID) {
+									fmt.Printf("[%s] Deleting remote feature branch %s...\n", s.Project, featureBranch)
+									if err := gitClient.DeleteRemoteBranch(s.Workspace, "origin", featureBranch); err != nil {
+										fmt.Printf("[%s] Warning: Failed to delete remote branch: %v\n", s.Project, err)
+									}
 								}
 
 								// 6. Capture Commit SHA for links
@@ -334,29 +398,47 @@ func (s *Session) RunLoop(ctx context.Context) error {
 									commitSHA = strings.TrimSpace(string(shaOut))
 								}
 
-								// 7. Transition Jira and notify with commit link
-								gitLink := s.RepoURL
+								mergedLink = s.RepoURL
 								if commitSHA != "" {
-									gitLink = fmt.Sprintf("%s/commit/%s", s.RepoURL, commitSHA)
+									mergedLink = fmt.Sprintf("%s/commit/%s", s.RepoURL, commitSHA)
 								}
-								s.completeJiraTicket(ctx, gitLink)
 							}
 						}
 						// 5. Checkout back to feature branch (nice to have)
 						_ = gitClient.Checkout(s.Workspace, featureBranch)
 					}
+
+					// 7. Merge the components only once this repository is merged, then
+					// transition Jira and notify with commit link
+					if mergedLink != "" {
+						s.mergeComponents(ctx, gitClient, components)
+						s.completeTicket(ctx, mergedLink)
+					} else {
+						s.pushComponents(ctx, gitClient, components)
+					}
 				}
 			} else {
 				// No auto-merge or no base branch. Just push the feature branch and complete.
+				gitClient := git.NewClient()
+				// Additional repositories get their own feature branch, pushed and merged separately
+				if err := s.signOffComponents(ctx, gitClient, s.prepareComponents(gitClient)); errors.Is(err, errChecksFailed) {
+					s.Logger.Warn("required checks failed in a component repository, revoking sign-off", "error", err)
+					s.clearSignal("PROJECT_SIGNED_OFF")
+					s.ensureChecksTask(err)
+					s.clearSignal("QA_PASSED")
+					s.clearSignal("COMPLETED")
+					continue
+				}
+
 				cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
 				cmd.Dir = s.Workspace
 				if out, err := cmd.Output(); err == nil {
 					featureBranch := strings.TrimSpace(string(out))
 					// Push current branch
-					gitClient := git.NewClient()
+					s.refreshGitAuth(ctx, gitClient)
 					if err := gitClient.Push(s.Workspace, featureBranch); err == nil {
 						gitLink := fmt.Sprintf("%s/tree/%s", s.RepoURL, featureBranch)
-						s.completeJiraTicket(ctx, gitLink)
+						s.completeTicket(ctx, gitLink)
 					}
 				}
 			}
@@ -366,6 +448,7 @@ func (s *Session) RunLoop(ctx context.Context) error {
 				s.Logger.Error("cleaner agent error", "error", err)
 			}
 			s.Logger.Info("cleaner agent complete, session finished")
+			s.clearCheckpoint()
 			return nil
 		}
 
@@ -376,11 +459,23 @@ func (s *Session) RunLoop(ctx context.Context) error {
 				if err := s.runManagerAgent(ctx); err != nil {
 					fmt.Printf("Manager agent error: %v\n", err)
 					fmt.Println("Manager review failed. Returning to coding phase.")
+					if err := s.recordQARejection(ctx, "Manager review", err); err != nil {
+						fmt.Println(err)
+						s.Notifier.AddReaction(ctx, s.GetSlackThreadTS(), "x")
+						return err
+					}
+				} else if s.RequireHumanSignoff {
+					// Manager approved - park at the human approval gate
+					if err := s.requestHumanSignoff(ctx); err != nil {
+						fmt.Printf("Warning: Failed to request human sign-off: %v\n", err)
+					}
+					continue
 				} else {
 					// Manager approved - create PROJECT_SIGNED_OFF
 					if err := s.createSignal("PROJECT_SIGNED_OFF"); err != nil {
 						fmt.Printf("Warning: Failed to create PROJECT_SIGNED_OFF: %v\n", err)
 					}
+					s.emitEvent(EventSignOff, map[string]interface{}{"by": "manager"})
 					fmt.Println("Manager approved. Project signed off.")
 					s.Notifier.Notify(ctx, notify.EventSuccess, fmt.Sprintf("Project %s Signed Off by Manager!", s.Project), s.GetSlackThreadTS())
 					continue // Next iteration will run Cleaner
@@ -392,17 +487,25 @@ func (s *Session) RunLoop(ctx context.Context) error {
 				if s.SkipQA {
 					fmt.Println("SkipQA enabled. Bypassing QA agent and Manager review.")
 					s.createSignal("PROJECT_SIGNED_OFF")
+					s.emitEvent(EventSignOff, map[string]interface{}{"by": "skip_qa"})
 					s.clearSignal("COMPLETED")
 					continue
 				}
 
 				fmt.Println("Project marked as COMPLETED. Running QA agent...")
 				if err := s.runQAAgent(ctx); err != nil {
+					s.emitEvent(EventQAResult, map[string]interface{}{"passed": false, "error": err.Error()})
 					fmt.Printf("QA agent error: %v\n", err)
 					// QA failed - clear COMPLETED and continue coding
 					s.clearSignal("COMPLETED")
 					fmt.Println("QA checks failed. Returning to coding phase.")
+					if err := s.recordQARejection(ctx, "QA", err); err != nil {
+						fmt.Println(err)
+						s.Notifier.AddReaction(ctx, s.GetSlackThreadTS(), "x")
+						return err
+					}
 				} else {
+					s.emitEvent(EventQAResult, map[string]interface{}{"passed": true})
 					// QA passed - create QA_PASSED
 					if err := s.createSignal("QA_PASSED"); err != nil {
 						fmt.Printf("Warning: Failed to create QA_PASSED signal: %v\n", err)
@@ -419,11 +522,13 @@ func (s *Session) RunLoop(ctx context.Context) error {
 			fmt.Printf("Error selecting prompt: %v\n", err)
 			break
 		}
+		s.setRole(role)
 
 		// Multi-Agent Coding Sprint Delegation
 		if role == prompts.CodingAgent && s.MaxAgents > 1 {
 			fmt.Printf("Delegating to Multi-Agent Orchestrator (role: %s, max-agents: %d)\n", role, s.MaxAgents)
 			orchestrator := NewOrchestrator(s.DBStore, s.Docker, s.Workspace, s.Image, s.Agent, s.Project, s.AgentProvider, s.AgentModel, s.MaxAgents, s.GetSlackThreadTS())
+			orchestrator.IsolateWorktrees = s.IsolateWorktrees
 			if err := orchestrator.Run(ctx); err != nil {
 				fmt.Printf("Orchestrator sprint failed: %v\n", err)
 			}
@@ -431,23 +536,40 @@ func (s *Session) RunLoop(ctx context.Context) error {
 			if s.checkAutoQA() {
 				fmt.Println("Project automatically marked as completed after multi-agent sprint.")
 			}
+			s.reportUsageMetrics()
 			continue
 		}
 
 		// Run iteration using determined prompt
+		s.printPrompt(role, prompt)
 		executionOutput, err := s.RunIteration(ctx, prompt, isManager)
 
 		// Check for Agent/API Error (e.g. 413, Network, etc)
 		if err != nil {
 			s.Logger.Error("iteration failed", "error", err)
-			s.SleepFunc(5 * time.Second) // Backoff
-			continue                     // Retry loop without tripping no-op breaker
+			if !errors.Is(err, ErrAgentCall) {
+				s.SleepFunc(5 * time.Second) // Backoff
+				continue                     // Retry loop without tripping no-op breaker
+			}
+
+			// Agent/API errors: exponential backoff, abort on fatal errors or too many failures
+			backoff, breakerErr := s.checkAgentErrorBreaker(err)
+			if breakerErr != nil {
+				fmt.Println(breakerErr)
+				s.forceNotify(ctx, notify.EventFailure, fmt.Sprintf("Project %s Failed: %v", s.Project, breakerErr))
+				s.Notifier.AddReaction(ctx, s.GetSlackThreadTS(), "x")
+				return breakerErr
+			}
+			s.Logger.Info("backing off before retrying agent call", "wait", backoff, "consecutive_errors", s.AgentErrorCount)
+			s.SleepFunc(backoff)
+			continue
 		}
+		s.AgentErrorCount = 0
 
 		// Circuit Breaker: No-Op Check
 		if err := s.checkNoOpBreaker(executionOutput); err != nil {
 			fmt.Println(err)
-			s.Notifier.Notify(ctx, notify.EventFailure, fmt.Sprintf("Project %s Failed: %v", s.Project, err), s.GetSlackThreadTS())
+			s.forceNotify(ctx, notify.EventFailure, fmt.Sprintf("Project %s Failed: %v", s.Project, err))
 			s.Notifier.AddReaction(ctx, s.GetSlackThreadTS(), "x")
 			return ErrNoOp // Exit loop with error
 		}
@@ -457,15 +579,29 @@ func (s *Session) RunLoop(ctx context.Context) error {
 		if err := s.checkStalledBreaker(role, passingCount); err != nil {
 			telemetry.TrackAgentStall(s.Project)
 			fmt.Println(err)
-			s.Notifier.Notify(ctx, notify.EventFailure, fmt.Sprintf("Project %s Stalled: %v", s.Project, err), s.GetSlackThreadTS())
+			s.forceNotify(ctx, notify.EventFailure, fmt.Sprintf("Project %s Stalled: %v", s.Project, err))
 			s.Notifier.AddReaction(ctx, s.GetSlackThreadTS(), "x")
 			return ErrStalled // Exit loop with error
 		}
 
+		// Periodic feature-progress update in the session thread
+		s.reportProgress(ctx, role, passingCount)
+
+		// Disk Guard: stop before agent artifacts exhaust the host disk
+		if err := s.checkWorkspaceSize(ctx); err != nil {
+			fmt.Println(err)
+			s.Notifier.AddReaction(ctx, s.GetSlackThreadTS(), "x")
+			return err
+		}
+
 		// Save agent state periodically (every iteration)
 		if err := s.SaveAgentState(); err != nil {
 			fmt.Printf("Warning: Failed to save agent state: %v\n", err)
 		}
+		s.reportUsageMetrics()
+
+		// Snapshot the container filesystem every CheckpointInterval
+		s.checkpointContainer(ctx)
 
 		// Push progress to remote periodically (to ensure visibility in Jira/Git)
 		s.pushProgress(ctx)
@@ -491,27 +627,43 @@ func (s *Session) RunIteration(ctx context.Context, prompt string, isManager boo
 	}
 	s.Logger.Info("agent role selected", "role", role)
 
+	usageRole := agent.RoleCoding
+	if isManager {
+		usageRole = agent.RoleManager
+	}
+	agentCtx := agent.WithUsageLabel(ctx, usageRole, s.AgentModel, s.GetIteration())
+
 	// Send to Agent
 	s.Logger.Info("sending prompt to agent")
 	var response string
+	var commands []string // From structured tool calls; nil means parse the response
 	var err error
 
-	if s.StreamOutput {
+	if ta, ok := s.Agent.(agent.ToolAgent); ok && s.config().GetBool("tool_calling") {
+		var toolResp agent.ToolResponse
+		toolResp, err = ta.SendWithTools(agentCtx, prompt, []agent.Tool{agent.RunShellTool})
+		response = toolResp.String()
+		commands = toolResp.ShellCommands()
+		if s.StreamOutput && err == nil {
+			fmt.Printf("Agent Response: %s\n", response)
+		}
+	} else if s.StreamOutput {
 		fmt.Print("Agent Response: ")
-		response, err = s.Agent.SendStream(ctx, prompt, func(chunk string) {
+		response, err = s.Agent.SendStream(agentCtx, prompt, func(chunk string) {
 			fmt.Print(chunk)
 		})
 		fmt.Println() // Newline after stream
 	} else {
-		response, err = s.Agent.Send(ctx, prompt)
+		response, err = s.Agent.Send(agentCtx, prompt)
 	}
 
 	if err != nil {
 		s.Logger.Error("agent error, retrying", "error", err)
-		return "", err
+		return "", fmt.Errorf("%w: %w", ErrAgentCall, err)
 	}
 
-	s.Logger.Info("agent response received", "role", role, "chars", len(response))
+	s.Logger.Info("agent response received", "role", role, "chars", len(response), "tool_calls", len(commands))
+	s.emitEvent(EventAgentResponse, map[string]interface{}{"role": role, "chars": len(response)})
 
 	// Repetition Mitigation
 	truncated, wasTruncated := TruncateRepetitiveResponse(response)
@@ -520,6 +672,9 @@ func (s *Session) RunIteration(ctx context.Context, prompt string, isManager boo
 		response = truncated + "\n\n[RESPONSE TRUNCATED DUE TO REPETITION DETECTED]"
 	}
 
+	// Size Limit (max_response_size)
+	response = s.limitResponseSize(role, response)
+
 	// Security Scan
 	if s.Scanner != nil {
 		findings, err := s.Scanner.Scan(response)
@@ -547,7 +702,14 @@ func (s *Session) RunIteration(ctx context.Context, prompt string, isManager boo
 	}
 
 	// Process Response (Execute Commands & Check Blockers)
-	executionOutput, execErr := s.ProcessResponse(ctx, response)
+	// Tool calls are preferred; without any, commands are parsed from ```bash blocks
+	var executionOutput string
+	var execErr error
+	if len(commands) > 0 {
+		executionOutput, execErr = s.processCommands(ctx, response, commands)
+	} else {
+		executionOutput, execErr = s.ProcessResponse(ctx, response)
+	}
 
 	// Save System Output to DB (Feedback Loop)
 	if s.DBStore != nil && executionOutput != "" {
@@ -563,26 +725,14 @@ func (s *Session) RunIteration(ctx context.Context, prompt string, isManager boo
 	return executionOutput, execErr
 }
 
-// checkAutoQA checks if all features pass and we haven't already passed QA/Completed
+// checkAutoQA checks if the features meet the completion policy (by default,
+// all of them pass) and we haven't already passed QA/Completed
 func (s *Session) checkAutoQA() bool {
 	if s.hasSignal("QA_PASSED") || s.hasSignal("COMPLETED") || s.hasSignal("PROJECT_SIGNED_OFF") {
 		return false
 	}
 
-	features := s.loadFeatures()
-	if len(features) == 0 {
-		return false
-	}
-
-	allPass := true
-	for _, f := range features {
-		if !(f.Passes || f.Status == "done" || f.Status == "implemented") {
-			allPass = false
-			break
-		}
-	}
-
-	if allPass {
+	if LoadCompletionPolicy(s.config()).Unmet(s.loadFeatures()) == "" {
 		if err := s.createSignal("COMPLETED"); err != nil {
 			fmt.Printf("Warning: Failed to create COMPLETED signal: %v\n", err)
 		}
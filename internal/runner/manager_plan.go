@@ -0,0 +1,160 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"recac/internal/db"
+)
+
+// ManagerPlanSignal is the signal holding the Manager's plan, as JSON.
+const ManagerPlanSignal = "MANAGER_PLAN"
+
+// maxManagerDecisions caps the reviews kept in the plan, and so in the prompt.
+const maxManagerDecisions = 10
+
+// managerPlanBlockRegex matches the ```json block the Manager updates its plan with.
+var managerPlanBlockRegex = regexp.MustCompile("(?s)```json[^\\n]*\\n(.*?)```")
+
+// ManagerPlan is the Manager's running plan, carried from one review to the
+// next so reviews build on earlier decisions instead of starting over.
+type ManagerPlan struct {
+	Steps     []string          `json:"steps,omitempty"`     // Remaining work, in order
+	Notes     string            `json:"notes,omitempty"`     // Standing guidance for the team
+	Decisions []ManagerDecision `json:"decisions,omitempty"` // Most recent reviews, oldest first
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// ManagerDecision is the outcome of one Manager review.
+type ManagerDecision struct {
+	Iteration       int       `json:"iteration"`
+	Time            time.Time `json:"time"`
+	Approved        bool      `json:"approved"`
+	CompletionRatio float64   `json:"completion_ratio"`
+	Notes           string    `json:"notes,omitempty"`
+}
+
+// managerPlanUpdate is the part of the plan the Manager writes itself.
+type managerPlanUpdate struct {
+	Steps []string `json:"steps"`
+	Notes string   `json:"notes"`
+}
+
+// LoadManagerPlan returns the project's Manager plan, or nil if no review has
+// recorded one yet.
+func LoadManagerPlan(store db.Store, project string) (*ManagerPlan, error) {
+	raw, err := store.GetSignal(project, ManagerPlanSignal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manager plan: %w", err)
+	}
+	if raw == "" {
+		return nil, nil
+	}
+	var plan ManagerPlan
+	if err := json.Unmarshal([]byte(raw), &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse manager plan: %w", err)
+	}
+	return &plan, nil
+}
+
+// String renders the plan as the prior context of a Manager prompt, and for
+// `recac plan show`.
+func (p *ManagerPlan) String() string {
+	if p == nil {
+		return "No prior plan. This is the first review."
+	}
+
+	var b strings.Builder
+	b.WriteString("Steps:\n")
+	if len(p.Steps) == 0 {
+		b.WriteString("(none)\n")
+	}
+	for i, step := range p.Steps {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, step)
+	}
+	if p.Notes != "" {
+		fmt.Fprintf(&b, "\nNotes:\n%s\n", p.Notes)
+	}
+	if len(p.Decisions) > 0 {
+		b.WriteString("\nPrevious reviews:\n")
+	}
+	for _, d := range p.Decisions {
+		verdict := "rejected"
+		if d.Approved {
+			verdict = "approved"
+		}
+		fmt.Fprintf(&b, "- Iteration %d (%s): %s at %.0f%% complete", d.Iteration, d.Time.Format(time.RFC3339), verdict, d.CompletionRatio*100)
+		if d.Notes != "" {
+			b.WriteString(": " + d.Notes)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// parseManagerPlanUpdate returns the plan update in the Manager's response,
+// the last ```json block that decodes as one.
+func parseManagerPlanUpdate(response string) (managerPlanUpdate, bool) {
+	matches := managerPlanBlockRegex.FindAllStringSubmatch(response, -1)
+	for i := len(matches) - 1; i >= 0; i-- {
+		var update managerPlanUpdate
+		if err := json.Unmarshal([]byte(strings.TrimSpace(matches[i][1])), &update); err == nil && (len(update.Steps) > 0 || update.Notes != "") {
+			return update, true
+		}
+	}
+	return managerPlanUpdate{}, false
+}
+
+// loadManagerPlan returns the session's Manager plan, or nil if there is none
+// or it can't be read.
+func (s *Session) loadManagerPlan() *ManagerPlan {
+	if s.DBStore == nil {
+		return nil
+	}
+	plan, err := LoadManagerPlan(s.DBStore, s.Project)
+	if err != nil {
+		s.Logger.Warn("failed to load manager plan", "error", err)
+		return nil
+	}
+	return plan
+}
+
+// recordManagerReview updates the Manager plan with the steps and notes of
+// its response, if it gave any, and appends the review's decision.
+func (s *Session) recordManagerReview(plan *ManagerPlan, response string, approved bool, ratio float64) {
+	if s.DBStore == nil {
+		return
+	}
+	if plan == nil {
+		plan = &ManagerPlan{}
+	}
+
+	update, ok := parseManagerPlanUpdate(response)
+	if ok {
+		plan.Steps = update.Steps
+		plan.Notes = update.Notes
+	}
+	plan.Decisions = append(plan.Decisions, ManagerDecision{
+		Iteration:       s.GetIteration(),
+		Time:            time.Now(),
+		Approved:        approved,
+		CompletionRatio: ratio,
+		Notes:           update.Notes,
+	})
+	if len(plan.Decisions) > maxManagerDecisions {
+		plan.Decisions = plan.Decisions[len(plan.Decisions)-maxManagerDecisions:]
+	}
+	plan.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(plan)
+	if err != nil {
+		s.Logger.Warn("failed to encode manager plan", "error", err)
+		return
+	}
+	if err := s.DBStore.SetSignal(s.Project, ManagerPlanSignal, string(data)); err != nil {
+		s.Logger.Warn("failed to save manager plan", "error", err)
+	}
+}
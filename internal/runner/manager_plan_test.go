@@ -0,0 +1,122 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"recac/internal/db"
+	"recac/internal/notify"
+	"recac/internal/telemetry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PromptRecordingAgent answers with Responses in turn, recording every prompt
+type PromptRecordingAgent struct {
+	Responses []string
+	Prompts   []string
+}
+
+func (a *PromptRecordingAgent) Send(ctx context.Context, prompt string) (string, error) {
+	a.Prompts = append(a.Prompts, prompt)
+	return a.Responses[(len(a.Prompts)-1)%len(a.Responses)], nil
+}
+
+func (a *PromptRecordingAgent) SendStream(ctx context.Context, prompt string, onChunk func(string)) (string, error) {
+	return a.Send(ctx, prompt)
+}
+
+func TestRunManagerAgent_KeepsPlanAcrossReviews(t *testing.T) {
+	workspace := t.TempDir()
+	store, err := db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	project := "plan-project"
+	require.NoError(t, store.SaveFeatures(project, `{"project_name": "Test", "features": [{"id": "1", "description": "Login", "status": "failed", "passes": false}]}`))
+
+	manager := &PromptRecordingAgent{Responses: []string{
+		"```bash\nagent-bridge signal COMPLETED false\n```\n```json\n{\"steps\": [\"Fix the login form\", \"Add login tests\"], \"notes\": \"Keep the session cookie.\"}\n```",
+		"```bash\nagent-bridge signal COMPLETED false\n```",
+	}}
+	s := &Session{
+		Workspace:     workspace,
+		Project:       project,
+		DBStore:       store,
+		ManagerAgent:  manager,
+		UseLocalAgent: true,
+		Notifier:      notify.NewManager(func(string, ...interface{}) {}),
+		Logger:        telemetry.NewLogger(true, "", false),
+	}
+
+	s.Iteration = 3
+	require.Error(t, s.runManagerAgent(context.Background()))
+	assert.Contains(t, manager.Prompts[0], "No prior plan. This is the first review.")
+
+	s.Iteration = 6
+	require.Error(t, s.runManagerAgent(context.Background()))
+	assert.Contains(t, manager.Prompts[1], "1. Fix the login form\n2. Add login tests")
+	assert.Contains(t, manager.Prompts[1], "Keep the session cookie.")
+	assert.Contains(t, manager.Prompts[1], "- Iteration 3 (")
+
+	// A response without a plan block keeps the steps and still records the review
+	plan, err := LoadManagerPlan(store, project)
+	require.NoError(t, err)
+	require.NotNil(t, plan)
+	assert.Equal(t, []string{"Fix the login form", "Add login tests"}, plan.Steps)
+	require.Len(t, plan.Decisions, 2)
+	assert.Equal(t, 3, plan.Decisions[0].Iteration)
+	assert.Equal(t, "Keep the session cookie.", plan.Decisions[0].Notes)
+	assert.Equal(t, 6, plan.Decisions[1].Iteration)
+	assert.False(t, plan.Decisions[1].Approved)
+}
+
+func TestRecordManagerReview_CapsDecisions(t *testing.T) {
+	store, err := db.NewSQLiteStore(filepath.Join(t.TempDir(), ".recac.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	s := &Session{Project: "plan-project", DBStore: store, Logger: telemetry.NewLogger(true, "", false)}
+	for i := 1; i <= maxManagerDecisions+2; i++ {
+		s.Iteration = i
+		s.recordManagerReview(s.loadManagerPlan(), "", i%2 == 0, float64(i)/100)
+	}
+
+	plan, err := LoadManagerPlan(store, s.Project)
+	require.NoError(t, err)
+	require.Len(t, plan.Decisions, maxManagerDecisions)
+	assert.Equal(t, 3, plan.Decisions[0].Iteration)
+	assert.Equal(t, maxManagerDecisions+2, plan.Decisions[maxManagerDecisions-1].Iteration)
+}
+
+func TestParseManagerPlanUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     managerPlanUpdate
+		ok       bool
+	}{
+		{"plan block", "```json\n{\"steps\": [\"a\"], \"notes\": \"n\"}\n```", managerPlanUpdate{Steps: []string{"a"}, Notes: "n"}, true},
+		{"last valid block wins", "```json\n{\"steps\": [\"old\"]}\n```\n```json\n{\"steps\": [\"new\"]}\n```\n```json\nnot json\n```", managerPlanUpdate{Steps: []string{"new"}}, true},
+		{"empty plan", "```json\n{}\n```", managerPlanUpdate{}, false},
+		{"no block", "```bash\nls\n```", managerPlanUpdate{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseManagerPlanUpdate(tt.response)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManagerPlan_String(t *testing.T) {
+	var none *ManagerPlan
+	assert.Equal(t, "No prior plan. This is the first review.", none.String())
+
+	plan := &ManagerPlan{Decisions: []ManagerDecision{{Iteration: 2, Approved: true, CompletionRatio: 1}}}
+	assert.Equal(t, fmt.Sprintf("Steps:\n(none)\n\nPrevious reviews:\n- Iteration 2 (%s): approved at 100%% complete", plan.Decisions[0].Time.Format("2006-01-02T15:04:05Z07:00")), plan.String())
+}
@@ -14,6 +14,11 @@ type MockDockerClient struct {
 	PullImageFunc     func(ctx context.Context, image string) error
 	ImageExistsFunc   func(ctx context.Context, image string) (bool, error)
 	ImageBuildFunc    func(ctx context.Context, options docker.ImageBuildOptions) (string, error)
+	ImageDigestsFunc  func(ctx context.Context, image string) ([]string, error)
+	ExecStreamFunc    func(ctx context.Context, containerID string, cmd []string, onLine func(string)) (string, error)
+	CommitFunc        func(ctx context.Context, containerID, ref string) (string, error)
+
+	RunContainerWithOptionsFunc func(ctx context.Context, image, workspace string, extraBinds, env []string, user string, opts docker.ContainerOptions) (string, error)
 }
 
 func (m *MockDockerClient) CheckDaemon(ctx context.Context) error {
@@ -71,3 +76,31 @@ func (m *MockDockerClient) ImageBuild(ctx context.Context, options docker.ImageB
 	}
 	return "mock-image-id", nil
 }
+
+func (m *MockDockerClient) ImageDigests(ctx context.Context, image string) ([]string, error) {
+	if m.ImageDigestsFunc != nil {
+		return m.ImageDigestsFunc(ctx, image)
+	}
+	return nil, nil
+}
+
+func (m *MockDockerClient) ExecStream(ctx context.Context, containerID string, cmd []string, onLine func(string)) (string, error) {
+	if m.ExecStreamFunc != nil {
+		return m.ExecStreamFunc(ctx, containerID, cmd, onLine)
+	}
+	return m.Exec(ctx, containerID, cmd)
+}
+
+func (m *MockDockerClient) RunContainerWithOptions(ctx context.Context, image, workspace string, extraBinds, env []string, user string, opts docker.ContainerOptions) (string, error) {
+	if m.RunContainerWithOptionsFunc != nil {
+		return m.RunContainerWithOptionsFunc(ctx, image, workspace, extraBinds, env, user, opts)
+	}
+	return m.RunContainer(ctx, image, workspace, extraBinds, env, user)
+}
+
+func (m *MockDockerClient) CommitContainer(ctx context.Context, containerID, ref string) (string, error) {
+	if m.CommitFunc != nil {
+		return m.CommitFunc(ctx, containerID, ref)
+	}
+	return "sha256:mockcommit", nil
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"recac/internal/agent"
 	"recac/internal/db"
+	"recac/internal/git"
 	"sync"
 	"time"
 
@@ -30,7 +31,10 @@ type Orchestrator struct {
 	TaskMaxIterations int         // Max iterations for each task
 	TaskMaxRetries    int         // Max retries for failed tasks (default 3)
 	TickInterval      time.Duration
-	ParentThreadTS    string // Parent Slack Thread TS
+	ParentThreadTS    string            // Parent Slack Thread TS
+	IsolateWorktrees  bool              // Run each task in its own git worktree, merged back at the barrier
+	Git               git.IClient       // Git client for task worktrees
+	worktrees         map[string]string // Task ID -> worktree path (guarded by mu)
 	mu                sync.Mutex
 }
 
@@ -52,6 +56,7 @@ func NewOrchestrator(dbStore db.Store, dockerCli DockerClient, workspace, image
 		TaskMaxRetries:    3,  // Default retries
 		TickInterval:      1 * time.Second,
 		ParentThreadTS:    parentThreadTS,
+		Git:               git.NewClient(),
 	}
 }
 
@@ -107,6 +112,11 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 		o.Pool.SetNumWorkers(newMax)
 	}
 
+	// Deferred first so it runs after the pool has drained
+	if o.IsolateWorktrees {
+		defer o.mergeWorktrees()
+	}
+
 	o.Pool.Start()
 	defer o.Pool.Stop()
 
@@ -273,7 +283,19 @@ func (o *Orchestrator) ExecuteTask(ctx context.Context, taskID string, node *Tas
 		defer o.DB.ReleaseLock(o.Project, path, agentID)
 	}
 
-	session := NewSession(o.Docker, o.Agent, o.Workspace, o.BaseImage, o.Project, o.AgentProvider, o.AgentModel, 1)
+	workspace := o.Workspace
+	if o.IsolateWorktrees {
+		if path, err := o.taskWorktree(taskID); err != nil {
+			fmt.Printf("Warning: Failed to create worktree for task %s, using the shared workspace: %v\n", taskID, err)
+		} else {
+			workspace = path
+		}
+	}
+
+	session := NewSession(o.Docker, o.Agent, workspace, o.BaseImage, o.Project, o.AgentProvider, o.AgentModel, 1)
+	if workspace != o.Workspace {
+		o.useWorktree(session)
+	}
 	session.SelectedTaskID = taskID
 	session.SetSlackThreadTS(o.ParentThreadTS)
 	session.SuppressStartNotification = true
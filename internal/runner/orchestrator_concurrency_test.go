@@ -98,6 +98,9 @@ func (m *MockDBStoreForOrchestrator) SaveObservation(projectID, role, content st
 func (m *MockDBStoreForOrchestrator) QueryHistory(projectID string, limit int) ([]db.Observation, error) {
 	return nil, nil
 }
+func (m *MockDBStoreForOrchestrator) QueryHistoryPage(projectID string, q db.HistoryQuery) ([]db.Observation, error) {
+	return nil, nil
+}
 func (m *MockDBStoreForOrchestrator) DeleteSignal(projectID, name string) error       { return nil }
 func (m *MockDBStoreForOrchestrator) SaveFeatures(projectID, features string) error   { return nil }
 func (m *MockDBStoreForOrchestrator) ReleaseAllLocks(projectID, agentID string) error { return nil }
@@ -106,6 +109,7 @@ func (m *MockDBStoreForOrchestrator) AcquireLock(projectID, path, agentID string
 }
 func (m *MockDBStoreForOrchestrator) ReleaseLock(projectID, path, agentID string) error { return nil }
 func (m *MockDBStoreForOrchestrator) Cleanup() error                                    { return nil }
+func (m *MockDBStoreForOrchestrator) ResetProject(projectID string) error               { return nil }
 func (m *MockDBStoreForOrchestrator) UpdateFeatureStatus(projectID, id, status string, passes bool) error {
 	return nil
 }
@@ -27,6 +27,9 @@ func (m *FaultToleranceMockDB) SaveObservation(projectID, agentID, content strin
 func (m *FaultToleranceMockDB) QueryHistory(projectID string, limit int) ([]db.Observation, error) {
 	return nil, nil
 }
+func (m *FaultToleranceMockDB) QueryHistoryPage(projectID string, q db.HistoryQuery) ([]db.Observation, error) {
+	return nil, nil
+}
 func (m *FaultToleranceMockDB) DeleteSignal(projectID, key string) error { return nil }
 func (m *FaultToleranceMockDB) SaveFeatures(projectID, features string) error {
 	m.mu.Lock()
@@ -87,6 +90,7 @@ func (m *FaultToleranceMockDB) AcquireLock(projectID, path, agentID string, time
 func (m *FaultToleranceMockDB) ReleaseLock(projectID, path, agentID string) error  { return nil }
 func (m *FaultToleranceMockDB) GetActiveLocks(projectID string) ([]db.Lock, error) { return nil, nil }
 func (m *FaultToleranceMockDB) Cleanup() error                                     { return nil }
+func (m *FaultToleranceMockDB) ResetProject(projectID string) error                { return nil }
 
 func TestOrchestrator_FaultTolerance_HighFailureRate(t *testing.T) {
 	// Setup workspace
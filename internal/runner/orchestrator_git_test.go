@@ -24,6 +24,9 @@ func (m *MockDBStore) SaveObservation(projectID, agentID, content string) error
 func (m *MockDBStore) QueryHistory(projectID string, limit int) ([]db.Observation, error) {
 	return nil, nil
 }
+func (m *MockDBStore) QueryHistoryPage(projectID string, q db.HistoryQuery) ([]db.Observation, error) {
+	return nil, nil
+}
 func (m *MockDBStore) SetSignal(projectID, key, value string) error    { return nil }
 func (m *MockDBStore) GetSignal(projectID, key string) (string, error) { return "", nil }
 func (m *MockDBStore) DeleteSignal(projectID, key string) error        { return nil }
@@ -46,6 +49,7 @@ func (m *MockDBStore) ReleaseLock(projectID, path, agentID string) error  { retu
 func (m *MockDBStore) ReleaseAllLocks(projectID, agentID string) error    { return nil }
 func (m *MockDBStore) GetActiveLocks(projectID string) ([]db.Lock, error) { return nil, nil }
 func (m *MockDBStore) Cleanup() error                                     { return nil }
+func (m *MockDBStore) ResetProject(projectID string) error                { return nil }
 
 func TestOrchestrator_EnsureGitRepo(t *testing.T) {
 	// Setup temporary workspace
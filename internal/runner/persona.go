@@ -0,0 +1,40 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"recac/internal/agent/prompts"
+)
+
+// PersonaFile holds house rules, relative to the workspace, prepended to the
+// coding, manager and QA prompts when system_prefix is not configured.
+const PersonaFile = ".recac/PERSONA.md"
+
+// systemPrefix returns the house rules prepended to agent prompts: the
+// system_prefix config, or else the workspace's PersonaFile.
+func (s *Session) systemPrefix() string {
+	if prefix := strings.TrimSpace(s.config().GetString("system_prefix")); prefix != "" {
+		return prefix
+	}
+	data, err := os.ReadFile(filepath.Join(s.Workspace, PersonaFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// getRolePrompt loads the named prompt template and prepends the system prefix.
+// The prefix is added after the template variables are filled in, so the
+// history size limit never trims it and braces in it are left as is.
+func (s *Session) getRolePrompt(name string, vars map[string]string) (string, error) {
+	prompt, err := prompts.GetPrompt(name, vars)
+	if err != nil {
+		return "", err
+	}
+	if prefix := s.systemPrefix(); prefix != "" {
+		prompt = prefix + "\n\n" + prompt
+	}
+	return prompt, nil
+}
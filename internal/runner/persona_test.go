@@ -0,0 +1,59 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"recac/internal/agent/prompts"
+
+	"github.com/spf13/viper"
+)
+
+func TestSession_GetRolePrompt_PersonaFile(t *testing.T) {
+	workspace := t.TempDir()
+	s := &Session{Workspace: workspace}
+
+	plain, err := s.getRolePrompt(prompts.QAAgent, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(workspace, ".recac"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	persona := "House rules: never use {lodash}.\n"
+	if err := os.WriteFile(filepath.Join(workspace, PersonaFile), []byte(persona), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	prompt, err := s.getRolePrompt(prompts.QAAgent, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt != "House rules: never use {lodash}.\n\n"+plain {
+		t.Errorf("expected persona prepended to the QA prompt, got:\n%s", prompt[:min(len(prompt), 200)])
+	}
+}
+
+func TestSession_GetRolePrompt_ConfigOverridesFile(t *testing.T) {
+	workspace := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(workspace, ".recac"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workspace, PersonaFile), []byte("from file"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("system_prefix", "from config")
+	defer viper.Set("system_prefix", nil)
+
+	s := &Session{Workspace: workspace}
+	prompt, err := s.getRolePrompt(prompts.CodingAgent, map[string]string{"history": strings.Repeat("x", 30000)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(prompt, "from config\n\n") || strings.Contains(prompt, "from file") {
+		t.Errorf("expected the system_prefix config to be prepended instead of the persona file, got:\n%s", prompt[:min(len(prompt), 200)])
+	}
+}
@@ -0,0 +1,22 @@
+package runner
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSession_PrintPrompt(t *testing.T) {
+	var out bytes.Buffer
+	s := &Session{PromptOutput: &out}
+
+	// Off by default
+	s.printPrompt("coding_agent", "do the work")
+	assert.Empty(t, out.String())
+
+	s.PrintPrompt = true
+	s.printPrompt("coding_agent", "do the work\n\nHISTORY: ran tests")
+	assert.Contains(t, out.String(), "===== PROMPT: coding_agent (iteration 0, 31 chars) =====")
+	assert.Contains(t, out.String(), "do the work\n\nHISTORY: ran tests\n===== END PROMPT: coding_agent =====")
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"recac/internal/db"
 	"recac/internal/notify"
+	"recac/internal/security"
 	"strings"
 	"testing"
 )
@@ -101,3 +102,76 @@ func TestSession_ProcessResponse_Blocker(t *testing.T) {
 		t.Errorf("Expected ErrBlocker, got %v", err)
 	}
 }
+
+func TestSession_ProcessResponse_CommandPolicy(t *testing.T) {
+	var executed []string
+	mockDocker := &MockDockerClient{
+		ExecFunc: func(ctx context.Context, containerID string, cmd []string) (string, error) {
+			executed = append(executed, cmd[len(cmd)-1])
+			return "", nil
+		},
+	}
+
+	policy, err := security.ParseCommandPolicy([]byte("protected_branches: [main]"))
+	if err != nil {
+		t.Fatalf("ParseCommandPolicy failed: %v", err)
+	}
+
+	s := &Session{
+		Docker:        mockDocker,
+		Workspace:     t.TempDir(),
+		Logger:        slog.Default(),
+		Notifier:      notify.NewManager(func(string, ...interface{}) {}),
+		Project:       "test-project",
+		CommandPolicy: policy,
+	}
+
+	response := "```bash\ngit push origin main\n```\n```bash\necho after\n```"
+	output, err := s.ProcessResponse(context.Background(), response)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(output, "Command Blocked: git push origin main") {
+		t.Errorf("Expected blocked command in output, got %s", output)
+	}
+	for _, cmd := range executed {
+		if strings.Contains(cmd, "git push") || strings.Contains(cmd, "echo after") {
+			t.Errorf("Expected no commands to run after the policy rejection, got %q", cmd)
+		}
+	}
+}
+
+func TestSession_ProcessResponse_StreamOutput(t *testing.T) {
+	var streamed []string
+	mockDocker := &MockDockerClient{
+		ExecFunc: func(ctx context.Context, containerID string, cmd []string) (string, error) {
+			return "", nil
+		},
+		ExecStreamFunc: func(ctx context.Context, containerID string, cmd []string, onLine func(string)) (string, error) {
+			streamed = append(streamed, cmd[len(cmd)-1])
+			onLine("compiling")
+			return "compiling\n", nil
+		},
+	}
+
+	s := &Session{
+		Docker:       mockDocker,
+		Workspace:    t.TempDir(),
+		Logger:       slog.Default(),
+		Notifier:     notify.NewManager(func(string, ...interface{}) {}),
+		Project:      "test-project",
+		StreamOutput: true,
+	}
+
+	output, err := s.ProcessResponse(context.Background(), "```bash\nmake build\n```")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(streamed) != 1 || streamed[0] != "make build" {
+		t.Errorf("Expected command to run via ExecStream, got %v", streamed)
+	}
+	if output != "Command Output:\ncompiling\n\n" {
+		t.Errorf("Expected full output to be returned, got %q", output)
+	}
+}
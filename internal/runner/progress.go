@@ -0,0 +1,41 @@
+package runner
+
+import (
+	"context"
+
+	"recac/internal/notify"
+)
+
+// progressInterval returns the number of iterations between progress notifications,
+// falling back to the manager frequency when progress_interval is unset.
+func (s *Session) progressInterval() int {
+	if interval := s.config().GetInt("progress_interval"); interval > 0 {
+		return interval
+	}
+	return s.ManagerFrequency
+}
+
+// reportProgress posts a passing/total feature status line every progressInterval iterations.
+func (s *Session) reportProgress(ctx context.Context, role string, passing int) {
+	interval := s.progressInterval()
+	if interval <= 0 || s.GetIteration()%interval != 0 {
+		return
+	}
+
+	total := len(s.loadFeatures())
+	if total == 0 {
+		return
+	}
+
+	p := notify.Progress{
+		Project: s.Project,
+		Role:    role,
+		Passing: passing,
+		Total:   total,
+	}
+	if pn, ok := s.Notifier.(notify.ProgressNotifier); ok {
+		_, _ = pn.NotifyProgress(ctx, p, s.GetSlackThreadTS())
+		return
+	}
+	_, _ = s.Notifier.Notify(ctx, notify.EventProgress, p.String(), s.GetSlackThreadTS())
+}
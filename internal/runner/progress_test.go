@@ -0,0 +1,59 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"recac/internal/notify"
+
+	"github.com/spf13/viper"
+)
+
+type progressRecorder struct {
+	MockNotifier
+	updates []notify.Progress
+}
+
+func (r *progressRecorder) NotifyProgress(ctx context.Context, p notify.Progress, threadTS string) (string, error) {
+	r.updates = append(r.updates, p)
+	return "", nil
+}
+
+func TestSession_ReportProgress(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	tmpDir := t.TempDir()
+	features := `{"project_name":"p","features":[{"id":"1","description":"a","passes":true},{"id":"2","description":"b","passes":false}]}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "feature_list.json"), []byte(features), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := &progressRecorder{}
+	session := NewSession(&MockDockerClient{}, &MockAgent{}, tmpDir, "alpine", "test-project", "gemini", "gemini-pro", 1)
+	session.Notifier = recorder
+	session.ManagerFrequency = 3
+
+	for i := 1; i <= 6; i++ {
+		session.IncrementIteration()
+		session.reportProgress(context.Background(), "coding_agent", 1)
+	}
+	if len(recorder.updates) != 2 {
+		t.Fatalf("expected progress every manager-frequency iterations (2 updates), got %d", len(recorder.updates))
+	}
+	got := recorder.updates[0]
+	if got.Passing != 1 || got.Total != 2 || got.Role != "coding_agent" || got.Project != "test-project" {
+		t.Errorf("unexpected progress update: %+v", got)
+	}
+
+	// progress_interval overrides the manager frequency
+	viper.Set("progress_interval", 1)
+	recorder.updates = nil
+	session.IncrementIteration()
+	session.reportProgress(context.Background(), "coding_agent", 1)
+	if len(recorder.updates) != 1 {
+		t.Errorf("expected an update every iteration with progress_interval=1, got %d", len(recorder.updates))
+	}
+}
@@ -23,7 +23,7 @@ func RunQA(features []db.Feature) QAReport {
 	}
 
 	for _, f := range features {
-		if f.Passes || f.Status == "done" || f.Status == "implemented" {
+		if featurePasses(f) {
 			report.PassedFeatures++
 		} else {
 			report.FailedFeatures++
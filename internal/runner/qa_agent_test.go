@@ -9,6 +9,8 @@ import (
 	"recac/internal/telemetry"
 	"strings"
 	"testing"
+
+	"github.com/spf13/viper"
 )
 
 // MockAgentForQA simulates the agent interaction for QA
@@ -137,3 +139,43 @@ func TestRunQAAgent_Fail(t *testing.T) {
 		t.Errorf("Expected QA_PASSED signal 'false', got '%s'", val)
 	}
 }
+
+func TestSession_ResolveRoleAgent(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	s := &Session{AgentProvider: "openrouter", AgentModel: "deepseek/deepseek-v3.2"}
+
+	// Defaults to the session's coding provider/model
+	provider, model, _ := s.resolveRoleAgent("qa", "", "", "gemini-1.5-flash-latest")
+	if provider != "openrouter" || model != "deepseek/deepseek-v3.2" {
+		t.Errorf("Expected session provider/model, got %s/%s", provider, model)
+	}
+
+	// Role config overrides the session defaults
+	viper.Set("agents.manager.model", "anthropic/claude-3.5-sonnet")
+	provider, model, _ = s.resolveRoleAgent("manager", "", "", "gemini-1.5-pro-latest")
+	if provider != "openrouter" || model != "anthropic/claude-3.5-sonnet" {
+		t.Errorf("Expected role config model, got %s/%s", provider, model)
+	}
+
+	// Explicit session overrides win over config
+	provider, model, _ = s.resolveRoleAgent("manager", "openai", "gpt-4o", "gemini-1.5-pro-latest")
+	if provider != "openai" || model != "gpt-4o" {
+		t.Errorf("Expected explicit override, got %s/%s", provider, model)
+	}
+
+	// Provider-specific API key is used instead of the Gemini key
+	t.Setenv("OPENROUTER_API_KEY", "or-key")
+	t.Setenv("GEMINI_API_KEY", "gemini-key")
+	if _, _, apiKey := s.resolveRoleAgent("qa", "", "", ""); apiKey != "or-key" {
+		t.Errorf("Expected OpenRouter API key, got %q", apiKey)
+	}
+
+	// The provider's own key wins over the global api_key
+	viper.Set("api_key", "global-key")
+	t.Setenv("OPENAI_API_KEY", "openai-key")
+	if _, _, apiKey := s.resolveRoleAgent("manager", "openai", "gpt-4o", ""); apiKey != "openai-key" {
+		t.Errorf("Expected OpenAI API key, got %q", apiKey)
+	}
+}
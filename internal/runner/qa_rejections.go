@@ -0,0 +1,39 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"recac/internal/notify"
+)
+
+// ErrQARejectionLimit is returned when QA or the Manager review rejects the
+// project more than MaxQARejections times.
+var ErrQARejectionLimit = errors.New("QA failing repeatedly")
+
+// recordQARejection counts a failed QA run or Manager review. Once the count
+// exceeds MaxQARejections the session is blocked and escalated instead of
+// returning to the coding phase again.
+func (s *Session) recordQARejection(ctx context.Context, stage string, reason error) error {
+	s.QARejections++
+	s.Logger.Info("QA cycle rejected", "stage", stage, "rejections", s.QARejections, "max", s.MaxQARejections)
+
+	if s.MaxQARejections <= 0 || s.QARejections <= s.MaxQARejections {
+		return nil
+	}
+
+	message := fmt.Sprintf("QA failing repeatedly: rejected %d times (max_qa_rejections=%d). Last rejection by %s: %v. Review the QA feedback, then resolve this blocker.",
+		s.QARejections, s.MaxQARejections, stage, reason)
+	s.Logger.Error("QA rejection limit exceeded", "rejections", s.QARejections, "max", s.MaxQARejections)
+
+	if s.DBStore != nil {
+		if err := s.DBStore.SetSignal(s.Project, "BLOCKER", message); err != nil {
+			s.Logger.Warn("failed to set blocker signal", "error", err)
+		}
+	}
+	s.emitEvent(EventBlocker, map[string]interface{}{"source": "qa_rejections", "message": message})
+	s.forceNotify(ctx, notify.EventFailure, fmt.Sprintf("Project %s Blocked: %s", s.Project, message))
+
+	return fmt.Errorf("%w: %s", ErrQARejectionLimit, message)
+}
@@ -0,0 +1,70 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"recac/internal/db"
+	"recac/internal/notify"
+)
+
+func TestSession_RecordQARejection(t *testing.T) {
+	store, err := db.NewSQLiteStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	spy := &SpyNotifier{}
+	s := &Session{
+		Project:         "test-project",
+		DBStore:         store,
+		Logger:          slog.Default(),
+		Notifier:        spy,
+		MaxQARejections: 2,
+	}
+
+	qaErr := errors.New("QA Agent did not signal success")
+	for i := 0; i < 2; i++ {
+		if err := s.recordQARejection(context.Background(), "QA", qaErr); err != nil {
+			t.Fatalf("Rejection %d: expected no escalation yet, got %v", i+1, err)
+		}
+	}
+
+	err = s.recordQARejection(context.Background(), "Manager review", qaErr)
+	if !errors.Is(err, ErrQARejectionLimit) {
+		t.Fatalf("Expected ErrQARejectionLimit after exceeding the cap, got %v", err)
+	}
+	if s.QARejections != 3 {
+		t.Errorf("Expected 3 rejections tracked, got %d", s.QARejections)
+	}
+
+	blocker, _ := store.GetSignal("test-project", "BLOCKER")
+	if !strings.Contains(blocker, "QA failing repeatedly") || !strings.Contains(blocker, "Manager review") {
+		t.Errorf("Expected blocker describing repeated QA failures, got %q", blocker)
+	}
+	if len(spy.Messages) != 1 || spy.Messages[0].EventType != notify.EventFailure {
+		t.Errorf("Expected a single failure notification, got %+v", spy.Messages)
+	}
+}
+
+func TestSession_RecordQARejection_Unlimited(t *testing.T) {
+	s := &Session{
+		Project:  "test-project",
+		Logger:   slog.Default(),
+		Notifier: &MockNotifier{},
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := s.recordQARejection(context.Background(), "QA", errors.New("failed")); err != nil {
+			t.Fatalf("Expected no cap when MaxQARejections is 0, got %v", err)
+		}
+	}
+	if s.QARejections != 10 {
+		t.Errorf("Expected 10 rejections tracked, got %d", s.QARejections)
+	}
+}
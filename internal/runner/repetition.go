@@ -1,7 +1,10 @@
 package runner
 
 import (
+	"fmt"
 	"strings"
+
+	"github.com/kballard/go-shellquote"
 )
 
 // DetectRepetitiveLine checks if any single non-empty line repeats consecutively more than threshold times.
@@ -94,3 +97,144 @@ func TruncateRepetitiveResponse(response string) (string, bool) {
 
 	return response, false
 }
+
+// Defaults for detecting commands an agent keeps re-running across iterations.
+const (
+	defaultRepetitionWindow    = 5
+	defaultRepetitionThreshold = 3
+)
+
+// maxWarnedCommandLen caps each command quoted in a repetition warning.
+const maxWarnedCommandLen = 200
+
+// NormalizeCommand reduces a bash block to a canonical form, so that blocks
+// differing only in whitespace, quoting, comments or command separators
+// compare equal. Each command is tokenized like the shell does and the
+// commands are joined with " && ".
+func NormalizeCommand(script string) string {
+	var commands []string
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, part := range splitCommands(line) {
+			words, err := shellquote.Split(part)
+			if err != nil {
+				words = strings.Fields(part)
+			}
+			if len(words) > 0 {
+				commands = append(commands, strings.Join(words, " "))
+			}
+		}
+	}
+	return strings.Join(commands, " && ")
+}
+
+// splitCommands splits a line on the ;, && and || separators outside quotes.
+func splitCommands(line string) []string {
+	var parts []string
+	var quote rune
+	start := 0
+	for i := 0; i < len(line); i++ {
+		c := rune(line[i])
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '\\':
+			i++
+		case c == ';':
+			parts = append(parts, line[start:i])
+			start = i + 1
+		case (c == '&' || c == '|') && i+1 < len(line) && rune(line[i+1]) == c:
+			parts = append(parts, line[start:i])
+			start = i + 2
+			i++
+		}
+	}
+	return append(parts, line[start:])
+}
+
+// RepeatedCommands returns the normalized bash blocks that appear in at least
+// threshold of the given agent responses, in order of first appearance.
+func RepeatedCommands(responses []string, threshold int) []string {
+	counts := make(map[string]int)
+	var order []string
+	for _, response := range responses {
+		seen := make(map[string]bool)
+		for _, script := range extractCommands(response) {
+			cmd := NormalizeCommand(script)
+			if cmd == "" || seen[cmd] {
+				continue
+			}
+			seen[cmd] = true
+			if counts[cmd] == 0 {
+				order = append(order, cmd)
+			}
+			counts[cmd]++
+		}
+	}
+
+	var repeated []string
+	for _, cmd := range order {
+		if counts[cmd] >= threshold {
+			repeated = append(repeated, cmd)
+		}
+	}
+	return repeated
+}
+
+// repetitionWarning returns a warning for the next coding prompt when the
+// agent ran the same command in repetition_threshold of its last
+// repetition_window responses, or "" if it did not.
+func (s *Session) repetitionWarning() string {
+	if s.DBStore == nil {
+		return ""
+	}
+	window := s.config().GetInt("repetition_window")
+	if window <= 0 {
+		window = defaultRepetitionWindow
+	}
+	threshold := s.config().GetInt("repetition_threshold")
+	if threshold <= 0 {
+		threshold = defaultRepetitionThreshold
+	}
+
+	// Agent responses are interleaved with System command output and other roles
+	history, err := s.DBStore.QueryHistory(s.Project, window*4)
+	if err != nil {
+		return ""
+	}
+	var responses []string
+	for _, obs := range history {
+		if obs.AgentID == "Agent" {
+			responses = append(responses, obs.Content)
+			if len(responses) == window {
+				break
+			}
+		}
+	}
+
+	repeated := RepeatedCommands(responses, threshold)
+	if len(repeated) == 0 {
+		return ""
+	}
+	s.Logger.Warn("agent is repeating commands", "commands", len(repeated), "window", window, "threshold", threshold)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "WARNING: You've run the following command(s) repeatedly without progress (in at least %d of your last %d responses):\n", threshold, len(responses))
+	for _, cmd := range repeated {
+		if len(cmd) > maxWarnedCommandLen {
+			cmd = cmd[:maxWarnedCommandLen] + "..."
+		}
+		fmt.Fprintf(&sb, "- %s\n", cmd)
+	}
+	sb.WriteString("Do not run them again unless something has changed. Read their previous output in the history above and try a different approach.")
+	return sb.String()
+}
@@ -1,7 +1,10 @@
 package runner
 
 import (
+	"strings"
 	"testing"
+
+	"recac/internal/agent/prompts"
 )
 
 func TestTruncateRepetitiveResponse(t *testing.T) {
@@ -62,3 +65,66 @@ func TestTruncateRepetitiveResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeCommand(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"go test ./...", "  go   test   ./...  "},
+		{`grep -r "TODO" .`, "grep -r 'TODO' ."},
+		{"cd app && npm test", "cd app\n# run the tests\nnpm test"},
+		{"make build; make test", "make build && make test"},
+	}
+	for _, tt := range tests {
+		if got, want := NormalizeCommand(tt.a), NormalizeCommand(tt.b); got != want {
+			t.Errorf("NormalizeCommand(%q) = %q, want %q (from %q)", tt.a, got, want, tt.b)
+		}
+	}
+
+	if NormalizeCommand(`echo "a && b"`) != `echo a && b` {
+		t.Errorf("separators inside quotes must not split the command, got %q", NormalizeCommand(`echo "a && b"`))
+	}
+	if NormalizeCommand("go test ./...") == NormalizeCommand("go test ./internal/...") {
+		t.Error("different arguments must not normalize to the same command")
+	}
+}
+
+func TestRepeatedCommands(t *testing.T) {
+	block := func(script string) string { return "Trying again.\n```bash\n" + script + "\n```\n" }
+	responses := []string{
+		block("npm test") + block("cat package.json"),
+		block("npm   test"),
+		block("npm test ; ") + block("npm test"),
+		block("ls -la"),
+	}
+
+	if got := RepeatedCommands(responses, 3); len(got) != 1 || got[0] != "npm test" {
+		t.Errorf("expected npm test to repeat 3 times, got %v", got)
+	}
+	if got := RepeatedCommands(responses, 4); len(got) != 0 {
+		t.Errorf("expected no command to repeat 4 times, got %v", got)
+	}
+}
+
+func TestSession_RepetitionWarning(t *testing.T) {
+	session := NewSession(nil, &MockAgent{}, t.TempDir(), "alpine", "test-project", "gemini", "gemini-pro", 1)
+	session.DBStore.SaveFeatures(session.Project, `{"features":[{"id":"1","description":"build","passes":false}]}`)
+	session.IncrementIteration()
+	for i := 0; i < 3; i++ {
+		session.DBStore.SaveObservation(session.Project, "Agent", "```bash\ngo build  ./...\n```")
+		session.DBStore.SaveObservation(session.Project, "System", "build failed")
+	}
+
+	warning := session.repetitionWarning()
+	if !strings.Contains(warning, "go build ./...") || !strings.Contains(warning, "without progress") {
+		t.Errorf("expected a warning about the repeated build, got %q", warning)
+	}
+
+	prompt, role, _, err := session.SelectPrompt()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role != prompts.CodingAgent || !strings.HasSuffix(prompt, warning) {
+		t.Errorf("expected the warning at the end of the coding prompt (role %s)", role)
+	}
+}
@@ -0,0 +1,79 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/viper"
+)
+
+// ResponseTooLargeSignal is set when a response was cut to max_response_size,
+// and consumed by the next coding prompt to ask the agent to be concise.
+const ResponseTooLargeSignal = "RESPONSE_TOO_LARGE"
+
+// maxResponseSize returns the configured agent response size limit in bytes (0 = unlimited).
+func maxResponseSize(cfg *viper.Viper) (uint64, error) {
+	raw := strings.TrimSpace(cfg.GetString("max_response_size"))
+	if raw == "" || raw == "0" {
+		return 0, nil
+	}
+	limit, err := humanize.ParseBytes(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid max_response_size %q: %w", raw, err)
+	}
+	return limit, nil
+}
+
+// TruncateResponse cuts response to at most limit bytes, at the last line
+// break within the limit when there is one, so commands are not split
+// mid-line. An unterminated ```bash block left at the end is not executed.
+func TruncateResponse(response string, limit int) (string, bool) {
+	if limit <= 0 || len(response) <= limit {
+		return response, false
+	}
+	cut := response[:limit]
+	if i := strings.LastIndexByte(cut, '\n'); i > 0 {
+		return cut[:i], true
+	}
+	// No line break: don't leave half of a multi-byte character behind
+	for len(cut) > 0 && !utf8.RuneStart(response[len(cut)]) {
+		cut = cut[:len(cut)-1]
+	}
+	return cut, true
+}
+
+// limitResponseSize truncates a response larger than max_response_size,
+// records the event and flags the next coding prompt to ask for concision.
+func (s *Session) limitResponseSize(role, response string) string {
+	limit, err := maxResponseSize(s.config())
+	if err != nil {
+		s.Logger.Warn("response size limit disabled", "error", err)
+		return response
+	}
+	if limit == 0 || uint64(len(response)) <= limit {
+		return response
+	}
+	truncated, _ := TruncateResponse(response, int(limit))
+
+	s.Logger.Warn("agent response truncated due to size", "role", role, "bytes", len(response), "limit", limit)
+	s.emitEvent(EventResponseTruncated, map[string]interface{}{"role": role, "bytes": len(response), "limit": limit})
+	if err := s.createSignal(ResponseTooLargeSignal); err != nil {
+		s.Logger.Warn("failed to flag oversized response", "error", err)
+	}
+	return truncated + fmt.Sprintf("\n\n[RESPONSE TRUNCATED: %s exceeded the %s limit (max_response_size)]",
+		humanize.Bytes(uint64(len(response))), humanize.Bytes(limit))
+}
+
+// responseSizeWarning returns a note for the next coding prompt when the
+// previous response was truncated, or "" if it was not. The flag is cleared.
+func (s *Session) responseSizeWarning() string {
+	if !s.hasSignal(ResponseTooLargeSignal) {
+		return ""
+	}
+	s.clearSignal(ResponseTooLargeSignal)
+
+	limit, _ := maxResponseSize(s.config())
+	return fmt.Sprintf("WARNING: Your previous response was longer than the %s limit and was truncated; anything after the cut, including commands, was dropped. Be concise: don't echo file contents or long logs, and write large files in several smaller steps.", humanize.Bytes(limit))
+}
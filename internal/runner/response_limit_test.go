@@ -0,0 +1,96 @@
+package runner
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"recac/internal/db"
+	"recac/internal/telemetry"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncateResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		limit    int
+		want     string
+		cut      bool
+	}{
+		{"under the limit", "short", 10, "short", false},
+		{"no limit", "short", 0, "short", false},
+		{"cut at a line break", "line one\nline two\nline three", 20, "line one\nline two", true},
+		{"no line break", "abcdefghij", 4, "abcd", true},
+		{"keeps whole characters", "aé€", 4, "aé", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cut := TruncateResponse(tt.response, tt.limit)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.cut, cut)
+		})
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	defer viper.Set("max_response_size", nil)
+
+	viper.Set("max_response_size", "1KB")
+	limit, err := maxResponseSize(viper.GetViper())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), limit)
+
+	viper.Set("max_response_size", "0")
+	limit, err = maxResponseSize(viper.GetViper())
+	require.NoError(t, err)
+	assert.Zero(t, limit)
+
+	viper.Set("max_response_size", "lots")
+	_, err = maxResponseSize(viper.GetViper())
+	assert.Error(t, err)
+}
+
+func TestRunIteration_TruncatesOversizedResponse(t *testing.T) {
+	viper.Set("max_response_size", "100B")
+	defer viper.Set("max_response_size", nil)
+
+	workspace := t.TempDir()
+	store, err := db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	// The command block straddles the limit, so it must not run
+	response := "Working on it.\n```bash\ntouch ran\n" + strings.Repeat("x", 90) + "\n```\n"
+	s := &Session{
+		Workspace:     workspace,
+		Project:       "size-project",
+		DBStore:       store,
+		Agent:         &PromptRecordingAgent{Responses: []string{response}},
+		UseLocalAgent: true,
+		Logger:        telemetry.NewLogger(true, "", false),
+	}
+
+	_, err = s.RunIteration(context.Background(), "prompt", false)
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(workspace, "ran"))
+
+	history, err := store.QueryHistory(s.Project, 10)
+	require.NoError(t, err)
+	var saved string
+	for _, obs := range history {
+		if obs.AgentID == "Agent" {
+			saved = obs.Content
+		}
+	}
+	assert.True(t, strings.HasPrefix(saved, "Working on it.\n```bash\ntouch ran\n\n[RESPONSE TRUNCATED: 128 B exceeded the 100 B limit"), saved)
+	assert.NotContains(t, saved, "xxx")
+
+	// The next coding prompt asks for concision, once
+	assert.Contains(t, s.responseSizeWarning(), "Be concise")
+	assert.Empty(t, s.responseSizeWarning())
+}
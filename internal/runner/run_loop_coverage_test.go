@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"recac/internal/agent"
 	"recac/internal/notify"
 	"recac/internal/security"
 	"recac/internal/telemetry"
@@ -128,6 +129,29 @@ func TestRunLoop_SecurityViolation(t *testing.T) {
 	assert.True(t, sleepCalled, "Should have slept for backoff")
 }
 
+func TestRunLoop_FatalAgentError(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "app_spec.txt"), []byte("Spec"), 0644)
+
+	mockAgent := new(MockTestifyAgent)
+	mockAgent.On("Send", mock.Anything, mock.Anything).Return("", &agent.AuthError{StatusCode: 401, Err: errors.New("invalid api key")})
+
+	s := &Session{
+		Workspace:     tmpDir,
+		Agent:         mockAgent,
+		Notifier:      notify.NewManager(func(string, ...interface{}) {}),
+		Logger:        telemetry.NewLogger(true, "", false),
+		MaxIterations: 10,
+		SleepFunc:     func(d time.Duration) {},
+	}
+
+	err := s.RunLoop(context.Background())
+
+	var authErr *agent.AuthError
+	assert.ErrorAs(t, err, &authErr, "Fatal auth errors should abort the session")
+	assert.Equal(t, 1, s.GetIteration(), "Session should not keep retrying a bad API key")
+}
+
 func TestRunManagerAgent_Coverage(t *testing.T) {
 	tmpDir := t.TempDir()
 
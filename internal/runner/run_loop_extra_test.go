@@ -50,6 +50,9 @@ func (m *MockRunLoopDBStore) QueryHistory(projectID string, limit int) ([]db.Obs
 	}
 	return nil, nil
 }
+func (m *MockRunLoopDBStore) QueryHistoryPage(projectID string, q db.HistoryQuery) ([]db.Observation, error) {
+	return nil, nil
+}
 func (m *MockRunLoopDBStore) SetSignal(projectID, key, value string) error {
 	if m.SetSignalFunc != nil {
 		return m.SetSignalFunc(projectID, key, value)
@@ -113,6 +116,7 @@ func (m *MockRunLoopDBStore) ReleaseLock(projectID, path, agentID string) error
 func (m *MockRunLoopDBStore) ReleaseAllLocks(projectID, agentID string) error   { return nil }
 func (m *MockRunLoopDBStore) GetActiveLocks(projectID string) ([]db.Lock, error) { return nil, nil }
 func (m *MockRunLoopDBStore) Cleanup() error                                    { return nil }
+func (m *MockRunLoopDBStore) ResetProject(projectID string) error               { return nil }
 
 // MockAgent implements agent.Agent with testify/mock for better control
 type MockTestifyAgent struct {
@@ -308,6 +312,8 @@ func TestRunLoop_AutoMerge(t *testing.T) {
 	mockGit.On("Checkout", mock.Anything, "feature/foo").Return(nil)
 
 	mockGit.On("Commit", mock.Anything, mock.Anything).Return(nil).Maybe()
+	// No-op breaker workspace checks
+	mockGit.On("CurrentCommitSHA", mock.Anything).Return("", errors.New("no commits")).Maybe()
 
 	// Override git.NewClient
 	originalNewClient := git.NewClient
@@ -381,6 +387,8 @@ func TestRunLoop_GitSafeguard_MergeConflict(t *testing.T) {
 	// If brutal recovery kicks in (after 3 retries):
 	mockGit.On("DeleteRemoteBranch", mock.Anything, "origin", mock.Anything).Return(nil).Maybe()
 	mockGit.On("ResetHard", mock.Anything, "origin", "main").Return(nil).Maybe()
+	// No-op breaker workspace checks
+	mockGit.On("CurrentCommitSHA", mock.Anything).Return("", errors.New("no commits")).Maybe()
 
 	// Override git.NewClient
 	originalNewClient := git.NewClient
@@ -535,17 +543,8 @@ func TestSession_RunInitScript_Local(t *testing.T) {
 	}
 
 	// Execution
-	// runInitScript runs async in background. We need to wait for it.
-	// But runInitScript method returns immediately.
-	// It uses a goroutine.
-	// We can't wait for goroutine easily without synchronization primitives not exposed.
-	// However, it runs async but we can wait for file creation with timeout.
-
-	s.runInitScript(context.Background())
-
-	// Wait for marker file
-	assert.Eventually(t, func() bool {
-		_, err := os.Stat(markerFile)
-		return err == nil
-	}, 2*time.Second, 100*time.Millisecond, "init.sh should have run and created marker file")
+	assert.NoError(t, s.runInitScript(context.Background()))
+
+	// runInitScript waits for the script to finish
+	assert.FileExists(t, markerFile, "init.sh should have run and created marker file")
 }
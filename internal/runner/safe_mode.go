@@ -0,0 +1,142 @@
+package runner
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"recac/internal/notify"
+)
+
+// Safe mode confirmation states, stored as the value of the PENDING_COMMAND signal.
+// The command waiting for confirmation and its token are stored alongside it; the
+// agent-bridge confirm/deny commands (or a Slack reply) move it out of the pending state.
+const (
+	PendingCommandSignal   = "PENDING_COMMAND"
+	PendingCommandPending  = "pending"
+	PendingCommandApproved = "approved"
+	PendingCommandRejected = "rejected"
+)
+
+// pendingCommandPollInterval is how often a command awaiting confirmation is re-checked.
+var pendingCommandPollInterval = 5 * time.Second
+
+// safeMode reports whether risky commands need a human's confirmation.
+func (s *Session) safeMode() bool {
+	return s.SafeMode || (s.CommandPolicy != nil && s.CommandPolicy.SafeMode)
+}
+
+// confirmCommand parks the session until a human confirms or denies script,
+// whose line matched a confirm pattern. It returns the reason when the command
+// must not run, and an error only if the context ends while waiting.
+func (s *Session) confirmCommand(ctx context.Context, script, line string) (string, error) {
+	if s.DBStore == nil {
+		return "safe mode cannot ask for confirmation without a database", nil
+	}
+
+	token, err := newConfirmToken()
+	if err != nil {
+		return "", err
+	}
+	// The state goes last so the token is in place once the command shows as pending
+	for _, kv := range [][2]string{
+		{PendingCommandSignal + "_TOKEN", token},
+		{PendingCommandSignal + "_SCRIPT", script},
+		{PendingCommandSignal, PendingCommandPending},
+	} {
+		if err := s.DBStore.SetSignal(s.Project, kv[0], kv[1]); err != nil {
+			return fmt.Sprintf("failed to record the command awaiting confirmation: %v", err), nil
+		}
+	}
+	defer s.clearPendingCommand()
+
+	s.Logger.Warn("risky command awaiting confirmation", "project", s.Project, "command", line, "token", token)
+	fmt.Printf("\nSafe mode: the agent wants to run\n\n%s\n\nRun 'agent-bridge confirm %s %s' to allow it or 'agent-bridge deny %s %s <reason>' to refuse.\n", script, token, s.Project, token, s.Project)
+	s.forceNotify(ctx, notify.EventUserInteraction, fmt.Sprintf("Project %s wants to run a risky command: `%s`. Reply `confirm %s` or `deny %s <reason>`, or run agent-bridge confirm/deny %s.", s.Project, line, token, token, token))
+
+	for {
+		state, err := s.DBStore.GetSignal(s.Project, PendingCommandSignal)
+		if err != nil {
+			return fmt.Sprintf("failed to read confirmation state: %v", err), nil
+		}
+		switch state {
+		case PendingCommandApproved:
+			s.Logger.Info("risky command confirmed", "token", token)
+			return "", nil
+		case PendingCommandRejected:
+			reason, _ := s.DBStore.GetSignal(s.Project, PendingCommandSignal+"_REASON")
+			if reason == "" {
+				reason = "denied by a human reviewer"
+			}
+			s.Logger.Info("risky command denied", "token", token, "reason", reason)
+			return reason, nil
+		case PendingCommandPending:
+		default:
+			// The signal was cleared out from under us; nobody confirmed it
+			return "confirmation was withdrawn", nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		s.SleepFunc(pendingCommandPollInterval)
+	}
+}
+
+func (s *Session) clearPendingCommand() {
+	for _, key := range []string{PendingCommandSignal, PendingCommandSignal + "_TOKEN", PendingCommandSignal + "_SCRIPT", PendingCommandSignal + "_REASON"} {
+		s.DBStore.DeleteSignal(s.Project, key)
+	}
+}
+
+func newConfirmToken() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// confirmReplyRegex matches "confirm <token>" and "deny <token> [reason]"
+// replies, optionally after a mention of the bot.
+var confirmReplyRegex = regexp.MustCompile(`(?is)^(?:<@[^>]+>\s*)?(confirm|deny)\s+([0-9a-f]+)\b\s*(.*)$`)
+
+// handleConfirmReply resolves a pending command from a notification reply. It
+// returns the answer to post back, or "" if text is not a confirmation reply.
+// Only users listed in notifications.slack.approvers may answer.
+func (s *Session) handleConfirmReply(ctx context.Context, user, text string) string {
+	m := confirmReplyRegex.FindStringSubmatch(strings.TrimSpace(text))
+	if m == nil || s.DBStore == nil {
+		return ""
+	}
+	if user == "" || !slices.Contains(s.config().GetStringSlice("notifications.slack.approvers"), user) {
+		s.Logger.Warn("ignored confirmation reply from a user who is not an approver", "user", user)
+		return "Only the approvers in notifications.slack.approvers can confirm or deny commands."
+	}
+	action, token, reason := strings.ToLower(m[1]), m[2], strings.TrimSpace(m[3])
+
+	state, _ := s.DBStore.GetSignal(s.Project, PendingCommandSignal)
+	current, _ := s.DBStore.GetSignal(s.Project, PendingCommandSignal+"_TOKEN")
+	if state != PendingCommandPending || current != token {
+		return fmt.Sprintf("No command is awaiting confirmation with token %s.", token)
+	}
+
+	if action == "confirm" {
+		if err := s.DBStore.SetSignal(s.Project, PendingCommandSignal, PendingCommandApproved); err != nil {
+			return fmt.Sprintf("Failed to confirm: %v", err)
+		}
+		return fmt.Sprintf("Confirmed. Project %s will run the command.", s.Project)
+	}
+	if reason != "" {
+		s.DBStore.SetSignal(s.Project, PendingCommandSignal+"_REASON", reason)
+	}
+	if err := s.DBStore.SetSignal(s.Project, PendingCommandSignal, PendingCommandRejected); err != nil {
+		return fmt.Sprintf("Failed to deny: %v", err)
+	}
+	return fmt.Sprintf("Denied. Project %s will not run the command.", s.Project)
+}
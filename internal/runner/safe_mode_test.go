@@ -0,0 +1,153 @@
+package runner
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"recac/internal/db"
+	"recac/internal/notify"
+	"recac/internal/telemetry"
+
+	"github.com/spf13/viper"
+)
+
+func newSafeModeSession(t *testing.T, executed *[]string) *Session {
+	t.Helper()
+	workspace := t.TempDir()
+	store, err := db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	cfg := viper.New()
+	cfg.Set("notifications.slack.approvers", []string{"U123"})
+
+	return &Session{
+		Config: cfg,
+		Docker: &MockDockerClient{
+			ExecFunc: func(ctx context.Context, containerID string, cmd []string) (string, error) {
+				if cmd[0] == "/bin/bash" { // skip the blocker file checks
+					*executed = append(*executed, cmd[len(cmd)-1])
+				}
+				return "", nil
+			},
+		},
+		Workspace: workspace,
+		Project:   "test-project",
+		DBStore:   store,
+		SafeMode:  true,
+		Notifier:  notify.NewManager(func(string, ...interface{}) {}),
+		Logger:    telemetry.NewLogger(true, "", false),
+	}
+}
+
+// replyWhenPending answers the pending command with reply (formatted with its
+// token) the first time the session waits.
+func replyWhenPending(t *testing.T, s *Session, reply string) func(time.Duration) {
+	return func(time.Duration) {
+		token, _ := s.DBStore.GetSignal(s.Project, PendingCommandSignal+"_TOKEN")
+		if token == "" {
+			t.Fatal("Expected a token while the command is pending")
+		}
+		if answer := s.handleConfirmReply(context.Background(), "U123", strings.ReplaceAll(reply, "TOKEN", token)); answer == "" {
+			t.Fatalf("Expected %q to be handled as a confirmation reply", reply)
+		}
+	}
+}
+
+func TestSafeMode_Confirmed(t *testing.T) {
+	var executed []string
+	s := newSafeModeSession(t, &executed)
+	s.SleepFunc = replyWhenPending(t, s, "<@U123> confirm TOKEN")
+
+	output, err := s.ProcessResponse(context.Background(), "```bash\nrm -rf build\n```")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(executed) != 1 || executed[0] != "rm -rf build" {
+		t.Errorf("Expected the confirmed command to run, got %v (output %s)", executed, output)
+	}
+	if state, _ := s.DBStore.GetSignal(s.Project, PendingCommandSignal); state != "" {
+		t.Errorf("Expected the pending command to be cleared, got %q", state)
+	}
+}
+
+func TestSafeMode_Denied(t *testing.T) {
+	var executed []string
+	s := newSafeModeSession(t, &executed)
+	s.SleepFunc = replyWhenPending(t, s, "deny TOKEN keep the build cache")
+
+	output, err := s.ProcessResponse(context.Background(), "```bash\nrm -rf build\n```\n```bash\necho after\n```")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(executed) != 0 {
+		t.Errorf("Expected no commands to run after a denial, got %v", executed)
+	}
+	if !strings.Contains(output, "Command Denied: rm -rf build") || !strings.Contains(output, "keep the build cache") {
+		t.Errorf("Expected the denial and its reason in the output, got %s", output)
+	}
+}
+
+func TestSafeMode_SafeCommandsRunImmediately(t *testing.T) {
+	var executed []string
+	s := newSafeModeSession(t, &executed)
+	s.SleepFunc = func(time.Duration) { t.Fatal("Expected no wait for a safe command") }
+
+	if _, err := s.ProcessResponse(context.Background(), "```bash\ngo test ./...\n```"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(executed) != 1 {
+		t.Errorf("Expected the command to run, got %v", executed)
+	}
+
+	// Without safe mode risky commands are not held either
+	s.SafeMode = false
+	if _, err := s.ProcessResponse(context.Background(), "```bash\nrm -rf build\n```"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(executed) != 2 {
+		t.Errorf("Expected the command to run without safe mode, got %v", executed)
+	}
+}
+
+func TestSafeMode_ContextCanceled(t *testing.T) {
+	var executed []string
+	s := newSafeModeSession(t, &executed)
+	ctx, cancel := context.WithCancel(context.Background())
+	s.SleepFunc = func(time.Duration) { cancel() }
+
+	if _, err := s.ProcessResponse(ctx, "```bash\nrm -rf build\n```"); err == nil {
+		t.Error("Expected an error when the session is canceled while waiting")
+	}
+	if len(executed) != 0 {
+		t.Errorf("Expected nothing to run, got %v", executed)
+	}
+}
+
+func TestSafeMode_ReplyFromNonApprover(t *testing.T) {
+	var executed []string
+	s := newSafeModeSession(t, &executed)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.SleepFunc = func(time.Duration) {
+		token, _ := s.DBStore.GetSignal(s.Project, PendingCommandSignal+"_TOKEN")
+		answer := s.handleConfirmReply(ctx, "U999", "confirm "+token)
+		if !strings.Contains(answer, "approvers") {
+			t.Errorf("Expected the reply to be refused, got %q", answer)
+		}
+		cancel()
+	}
+
+	s.ProcessResponse(ctx, "```bash\nrm -rf build\n```")
+	if len(executed) != 0 {
+		t.Errorf("Expected the command not to run, got %v", executed)
+	}
+	if state, _ := s.DBStore.GetSignal(s.Project, PendingCommandSignal); state == PendingCommandApproved {
+		t.Error("Expected a non-approver not to confirm the command")
+	}
+}
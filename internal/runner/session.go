@@ -4,16 +4,21 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"os/user"
 	"path/filepath"
 	"recac/internal/agent"
+	"recac/internal/config"
 	"recac/internal/db"
 	"recac/internal/docker"
+	"recac/internal/github"
 	"recac/internal/security"
 	"strings"
 	"sync"
@@ -29,6 +34,9 @@ var ErrBlocker = errors.New("blocker detected")
 var ErrMaxIterations = errors.New("maximum iterations reached")
 var ErrNoOp = errors.New("circuit breaker: no-op loop")
 var ErrStalled = errors.New("circuit breaker: stalled progress")
+var ErrAgentUnavailable = errors.New("circuit breaker: agent unavailable")
+var ErrAgentCall = errors.New("agent call failed")
+var ErrImageDigest = errors.New("image digest verification failed")
 
 type Session struct {
 	Docker           DockerClient
@@ -42,11 +50,12 @@ type Session struct {
 	ManagerFirst     bool
 	StreamOutput     bool
 	Model            string
-	AgentStateFile   string              // Path to agent state file (.agent_state.json)
-	StateManager     *agent.StateManager // State manager for agent state persistence
-	DBStore          db.Store            // Persistent database store
-	Scanner          security.Scanner    // Security scanner
-	ContainerID      string              // Container ID for cleanup
+	AgentStateFile   string                  // Path to agent state file (.agent_state.json)
+	StateManager     *agent.StateManager     // State manager for agent state persistence
+	DBStore          db.Store                // Persistent database store
+	Scanner          security.Scanner        // Security scanner
+	CommandPolicy    *security.CommandPolicy // Optional allow/deny policy for agent commands (.recac/command_policy.yaml)
+	ContainerID      string                  // Container ID for cleanup
 
 	// Dependency Injection for Testing (optional)
 	// Agent Clients
@@ -57,10 +66,17 @@ type Session struct {
 	AgentProvider string // Specific provider for this session
 	AgentModel    string // Specific model for this session
 
+	// Supporting agent overrides (fall back to agents.<role>.* config, then AgentProvider/AgentModel)
+	QAProvider      string
+	QAModel         string
+	ManagerProvider string
+	ManagerModel    string
+
 	// Circuit Breaker State
 	LastFeatureCount int // Number of passing features last time we checked
 	StalledCount     int // Number of iterations without feature progress
-	NoOpCount        int // Number of iterations without executed commands
+	NoOpCount        int // Number of iterations without executed commands or workspace changes
+	AgentErrorCount  int // Number of consecutive failed agent calls
 
 	// Multi-Agent support
 	SelectedTaskID            string // If set, the agent should focus ONLY on this task
@@ -71,10 +87,18 @@ type Session struct {
 	Notifier                  notify.Notifier
 	BaseBranch                string // Base Branch for merge guardrails
 	SkipQA                    bool   // Skip QA phase and auto-complete
+	RequireHumanSignoff       bool   // Wait for agent-bridge approve/reject after Manager approval
 	AutoMerge                 bool   // Automatically merge PRs
+	RequiredChecks            []string      // CI checks that must pass on the feature branch before auto-merge
+	ChecksTimeout             time.Duration // How long auto-merge waits for RequiredChecks to complete
+	ChecksClient              CommitChecksClient
 	JiraClient                JiraClient
 	JiraTicketID              string
+	GitHubClient              GitHubIssueClient
+	GitHubApp                 *github.App // Authenticates pushes and CI checks as a GitHub App; nil uses GITHUB_TOKEN
+	GitHubIssue               int                 // Source GitHub issue number (GitHub poller work items)
 	RepoURL                   string       // Repository URL for links
+	ComponentRepos            []string     // Workspace subdirectories holding additional repositories, pushed and merged at sign-off
 	SlackThreadTS             string       // Thread Timestamp for Slack conversations
 	SuppressStartNotification bool         // Suppress "Session Started" notification (for sub-tasks)
 	UseLocalAgent             bool         // Execute commands locally (e.g. inside K8s pod) instead of spawning Docker container
@@ -82,8 +106,35 @@ type Session struct {
 	FeatureContent            string       // Explicit feature list JSON content (authoritative)
 	Logger                    *slog.Logger // Structured logger for this session
 	SleepFunc                 func(time.Duration) // Function for sleeping (mockable)
-
-	mu sync.RWMutex // Protects concurrent access to Iteration, SlackThreadTS, ContainerID
+	EventLog                  *EventLog           // Optional JSONL timeline of session events (.recac/events.jsonl)
+	Hooks                     LifecycleHooks      // Commands/URLs run on lifecycle events (hooks.on_<event>)
+	ImageDigest               string              // Expected image digest (sha256:...); verified before the container runs
+	ContainerEntrypoint       []string            // Overrides the agent image entrypoint (e.g. to wrap with a profiler)
+	ContainerCommand          []string            // Overrides the agent container command (default /bin/sh)
+	ContainerNetwork          string              // Network of the agent container: bridge (default), none, host or a named network
+	ContainerProxy            docker.ProxyConfig  // Egress proxy for outbound HTTP(S) traffic from the agent container
+	MaxQARejections           int                 // QA/Manager rejections tolerated before the session is blocked (0 = unlimited)
+	QARejections              int                 // QA/Manager rejections so far in this session
+	StatusAddr                string              // Serve a live status page on this address during RunLoop (empty = disabled)
+	IsolateWorktrees          bool                // Give each multi-agent sprint task its own git worktree
+	ConflictStrategy          string              // How sign-off merge conflicts are handled: reset (default) or resolve
+	ExtraBinds                []string            // Additional host:container bind mounts for the agent container
+	ExtraEnv                  []string            // Additional KEY=VALUE environment for agent commands
+	PrintPrompt               bool                // Print the full prompt sent to each agent role (--print-prompt)
+	PromptOutput              io.Writer           // Where PrintPrompt writes prompts (default stdout)
+	CheckpointInterval        time.Duration       // Commit the agent container to an image this often so a crashed session can resume (0 = disabled)
+	SafeMode                  bool                // Wait for a human to confirm risky commands before running them (also safe_mode in the command policy)
+	Config                    *viper.Viper        // Settings of this session: the global config with the workspace's .recac/config.yaml merged over it (nil = global)
+
+	lastFeatures       []db.Feature // Last non-empty feature list loaded, used by guardFeatureList
+	role               string       // Role of the current iteration, shown on the status page
+	lastCheckpoint     time.Time    // When the container was last checkpointed, or the session started
+	runStarted         time.Time    // When RunLoop started, for the end-of-session digest
+	lastWorkspaceState string       // Git state of the workspace at the last no-op check
+	notifiedBlocker    db.Blocker   // Last blocker humans were notified about
+	reportedCost       float64      // Session cost already added to the cost counter
+
+	mu sync.RWMutex // Protects concurrent access to Iteration, SlackThreadTS, ContainerID, role
 }
 
 // JiraClient defines the interface for Jira operations needed by the session
@@ -92,12 +143,20 @@ type JiraClient interface {
 	SmartTransition(ctx context.Context, ticketID, targetNameOrID string) error
 }
 
+// GitHubIssueClient defines the interface for GitHub issue operations needed by the session
+type GitHubIssueClient interface {
+	AddComment(ctx context.Context, number int, body string) error
+	CloseIssue(ctx context.Context, number int) error
+	IssueURL(number int) string
+}
+
 // NewSession creates a new worker session
 func NewSession(d DockerClient, a agent.Agent, workspace, image, project, provider, model string, maxAgents int) *Session {
 	// Default to "unknown" if project is empty
 	if project == "" {
 		project = "unknown"
 	}
+	cfg := projectSettings(workspace)
 
 	// Default agent state file path in workspace
 	stateFile := ".agent_state.json"
@@ -182,26 +241,36 @@ func NewSession(d DockerClient, a agent.Agent, workspace, image, project, provid
 	}
 
 	return &Session{
-		Docker:           d,
-		Agent:            a,
-		Workspace:        workspace,
-		Image:            image,
-		Project:          project,
-		AgentProvider:    provider,
-		AgentModel:       model,
-		SpecFile:         "app_spec.txt",
-		MaxIterations:    20, // Default
-		ManagerFrequency: 5,  // Default
-		AgentStateFile:   agentStateFile,
-		StateManager:     stateManager,
-		DBStore:          dbStore,
-		OwnsDB:           true,
-		Scanner:          scanner,
-		MaxAgents:        maxAgents,
-		Notifier:         notify.NewManager(telemetry.LogInfof),
-		UseLocalAgent:    os.Getenv("KUBERNETES_SERVICE_HOST") != "",
-		Logger:           logger,
-		SleepFunc:        time.Sleep,
+		Docker:              d,
+		Agent:               a,
+		Workspace:           workspace,
+		Image:               image,
+		Project:             project,
+		AgentProvider:       provider,
+		AgentModel:          model,
+		SpecFile:            "app_spec.txt",
+		MaxIterations:       20, // Default
+		ManagerFrequency:    5,  // Default
+		MaxQARejections:     cfg.GetInt("max_qa_rejections"),
+		Hooks:               LoadLifecycleHooks(),
+		AgentStateFile:      agentStateFile,
+		StateManager:        stateManager,
+		DBStore:             dbStore,
+		OwnsDB:              true,
+		Scanner:             scanner,
+		MaxAgents:           maxAgents,
+		IsolateWorktrees:    cfg.GetBool("isolate_worktrees"),
+		ConflictStrategy:    cfg.GetString("conflict_strategy"),
+		RequiredChecks:      cfg.GetStringSlice("auto_merge_required_checks"),
+		ChecksTimeout:       cfg.GetDuration("auto_merge_checks_timeout"),
+		CheckpointInterval:  cfg.GetDuration("checkpoint_interval"),
+		SafeMode:            cfg.GetBool("safe_mode"),
+		RequireHumanSignoff: cfg.GetBool("require_human_signoff"),
+		Notifier:            newNotifier(project),
+		UseLocalAgent:       hostMode() || os.Getenv("KUBERNETES_SERVICE_HOST") != "",
+		Logger:              logger,
+		SleepFunc:           time.Sleep,
+		Config:              cfg,
 	}
 }
 
@@ -210,6 +279,7 @@ func NewSessionWithStateFile(d DockerClient, a agent.Agent, workspace, image, pr
 	if project == "" {
 		project = "unknown"
 	}
+	cfg := projectSettings(workspace)
 	stateManager := agent.NewStateManager(agentStateFile)
 
 	// Initialize DB Store
@@ -264,25 +334,36 @@ func NewSessionWithStateFile(d DockerClient, a agent.Agent, workspace, image, pr
 	}
 
 	return &Session{
-		Docker:           d,
-		Agent:            a,
-		Workspace:        workspace,
-		Image:            image,
-		Project:          project,
-		AgentProvider:    provider,
-		AgentModel:       model,
-		SpecFile:         "app_spec.txt",
-		MaxIterations:    20, // Default
-		ManagerFrequency: 5,  // Default
-		AgentStateFile:   agentStateFile,
-		StateManager:     stateManager,
-		DBStore:          dbStore,
-		OwnsDB:           true,
-		Scanner:          scanner,
-		MaxAgents:        maxAgents,
-		Notifier:         notify.NewManager(telemetry.LogInfof),
-		Logger:           logger,
-		SleepFunc:        time.Sleep,
+		Docker:              d,
+		Agent:               a,
+		Workspace:           workspace,
+		Image:               image,
+		Project:             project,
+		AgentProvider:       provider,
+		AgentModel:          model,
+		SpecFile:            "app_spec.txt",
+		MaxIterations:       20, // Default
+		ManagerFrequency:    5,  // Default
+		MaxQARejections:     cfg.GetInt("max_qa_rejections"),
+		Hooks:               LoadLifecycleHooks(),
+		AgentStateFile:      agentStateFile,
+		StateManager:        stateManager,
+		DBStore:             dbStore,
+		OwnsDB:              true,
+		Scanner:             scanner,
+		MaxAgents:           maxAgents,
+		IsolateWorktrees:    cfg.GetBool("isolate_worktrees"),
+		ConflictStrategy:    cfg.GetString("conflict_strategy"),
+		RequiredChecks:      cfg.GetStringSlice("auto_merge_required_checks"),
+		ChecksTimeout:       cfg.GetDuration("auto_merge_checks_timeout"),
+		CheckpointInterval:  cfg.GetDuration("checkpoint_interval"),
+		SafeMode:            cfg.GetBool("safe_mode"),
+		RequireHumanSignoff: cfg.GetBool("require_human_signoff"),
+		Notifier:            newNotifier(project),
+		UseLocalAgent:       hostMode(),
+		Logger:              logger,
+		SleepFunc:           time.Sleep,
+		Config:              cfg,
 	}
 }
 
@@ -293,6 +374,7 @@ func NewSessionWithConfig(workspace, project, provider, model string, dbStore db
 	if project == "" {
 		project = "unknown"
 	}
+	cfg := projectSettings(workspace)
 
 	// Default agent state file path in workspace
 	stateFile := ".agent_state.json"
@@ -332,13 +414,56 @@ func NewSessionWithConfig(workspace, project, provider, model string, dbStore db
 		SpecFile:         "app_spec.txt",
 		MaxIterations:    20, // Default
 		ManagerFrequency: 5,  // Default
+		MaxQARejections:  cfg.GetInt("max_qa_rejections"),
+		Hooks:            LoadLifecycleHooks(),
 		AgentStateFile:   agentStateFile,
 		StateManager:     stateManager,
 		OwnsDB:           false, // This session does not own the DB, it's passed in
 		Scanner:          scanner,
-		Notifier:         notify.NewManager(telemetry.LogInfof),
+		Notifier:         newNotifier(project),
 		Logger:           logger,
+		Config:           cfg,
+	}
+}
+
+// projectSettings returns the settings for a session in workspace: the global
+// configuration with the workspace's .recac/config.yaml merged over it. The
+// global configuration is not modified, so the project's settings stay with
+// this session.
+func projectSettings(workspace string) *viper.Viper {
+	cfg, path, err := config.ProjectSettings(workspace)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	} else if path != "" {
+		fmt.Printf("Using project config file: %s\n", path)
+	}
+	return cfg
+}
+
+// config returns the session's settings, falling back to the global
+// configuration for sessions built without a constructor.
+func (s *Session) config() *viper.Viper {
+	if s.Config != nil {
+		return s.Config
+	}
+	return viper.GetViper()
+}
+
+// newNotifier creates the session's notification manager, tagged with the project.
+func newNotifier(project string) *notify.Manager {
+	m := notify.NewManager(telemetry.LogInfof)
+	m.SetProject(project)
+	return m
+}
+
+// forceNotify sends a critical notification in the session thread, bypassing
+// deduplication when the notifier supports it.
+func (s *Session) forceNotify(ctx context.Context, eventType, message string) {
+	if f, ok := s.Notifier.(notify.ForceNotifier); ok {
+		_, _ = f.ForceNotify(ctx, eventType, message, s.GetSlackThreadTS())
+		return
 	}
+	_, _ = s.Notifier.Notify(ctx, eventType, message, s.GetSlackThreadTS())
 }
 
 // LoadAgentState loads agent state from disk if it exists
@@ -402,8 +527,8 @@ func (s *Session) InitializeAgentState(maxTokens int) error {
 		return nil // No state manager configured
 	}
 
-	// Also persist the model name
-	return s.StateManager.InitializeState(maxTokens, s.AgentModel)
+	// Also persist the provider and model name, which together determine pricing
+	return s.StateManager.InitializeState(maxTokens, s.AgentProvider, s.AgentModel)
 }
 
 // SaveAgentState saves the current agent state to disk
@@ -486,6 +611,14 @@ func (s *Session) Start(ctx context.Context) error {
 		}
 	}
 
+	// Provider preflight (opt-in): fail fast on bad credentials or an unknown model
+	// instead of after the container is up and the first Send fails.
+	if s.config().GetBool("provider_health") && s.Agent != nil {
+		if err := agent.Ping(ctx, s.Agent); err != nil {
+			return err
+		}
+	}
+
 	fmt.Printf("Initializing session with image: %s\n", s.Image)
 
 	// Check Docker Daemon
@@ -506,9 +639,13 @@ func (s *Session) Start(ctx context.Context) error {
 		fmt.Printf("Loaded spec: %d bytes\n", len(spec))
 	}
 
-	// Ensure Image is ready (only if Docker is available)
-	if s.Docker != nil {
-		if err := s.ensureImage(ctx); err != nil {
+	// Ensure Image is ready (only if a container will run), unless a checkpoint
+	// of the previous container is restored instead
+	if s.Docker != nil && !s.UseLocalAgent && !s.restoreCheckpoint(ctx) {
+		if err := s.ensureImage(ctx); errors.Is(err, ErrImageDigest) {
+			// A pinned image that cannot be verified must never run
+			return err
+		} else if err != nil {
 			fmt.Printf("Warning: Failed to ensure image %s: %v. Attempting to proceed anyway...\n", s.Image, err)
 		}
 	}
@@ -574,9 +711,14 @@ func (s *Session) Start(ctx context.Context) error {
 	if s.Project != "" {
 		env = append(env, fmt.Sprintf("RECAC_PROJECT_ID=%s", s.Project))
 	}
+	env = append(env, s.ExtraEnv...)
+	extraBinds = append(extraBinds, s.ExtraBinds...)
 
 	// Run Container (or Skip if Local/Restricted)
 	if s.UseLocalAgent || s.Docker == nil {
+		if hostMode() {
+			s.warnHostMode()
+		}
 		if s.Logger != nil {
 			s.Logger.Info("Running in Local Agent Mode (K8s detected or restricted). Skipping container spawn.")
 		} else {
@@ -585,7 +727,7 @@ func (s *Session) Start(ctx context.Context) error {
 		s.ContainerID = "local"
 		s.UseLocalAgent = true
 	} else {
-		id, err := s.Docker.RunContainer(ctx, s.Image, s.Workspace, extraBinds, env, containerUser)
+		id, err := s.startContainer(ctx, extraBinds, env, containerUser)
 		if err != nil {
 			return err
 		}
@@ -599,16 +741,41 @@ func (s *Session) Start(ctx context.Context) error {
 		}
 	}
 
+	// Open Event Log (opt-in)
+	if s.EventLog == nil && s.config().GetBool("event_log") {
+		eventLog, err := NewEventLog(filepath.Join(s.Workspace, EventLogFile))
+		if err != nil {
+			fmt.Printf("Warning: Failed to open event log: %v\n", err)
+		} else {
+			s.EventLog = eventLog
+		}
+	}
+
+	// Load Command Policy (optional)
+	if s.CommandPolicy == nil {
+		policy, err := security.LoadCommandPolicy(filepath.Join(s.Workspace, CommandPolicyFile))
+		if err != nil {
+			return fmt.Errorf("failed to load command policy: %w", err)
+		}
+		s.CommandPolicy = policy
+	}
+
 	// Bootstrap Git Config
 	if err := s.bootstrapGit(ctx); err != nil {
 		fmt.Printf("Warning: Git bootstrapping failed: %v\n", err)
 	}
 
-	// Run init.sh if it exists
-	s.runInitScript(ctx)
+	// Run the init script (init.sh by default) if it exists
+	if err := s.runInitScript(ctx); err != nil {
+		return err
+	}
 
 	// Start Notifier (Socket Mode)
 	s.Notifier.Start(ctx)
+	if r, ok := s.Notifier.(notify.ReplyReceiver); ok && s.safeMode() {
+		// Let replies to the bot confirm or deny risky commands
+		r.OnReply(s.handleConfirmReply)
+	}
 
 	// Restore Slack Thread TS from DB if available (for session resumption)
 	if s.SlackThreadTS == "" && s.DBStore != nil {
@@ -617,6 +784,10 @@ func (s *Session) Start(ctx context.Context) error {
 			s.Logger.Info("restored slack thread ts from db", "ts", ts)
 		}
 	}
+	// Thread under the message that requested this session (e.g. a Discord /recac command)
+	if s.SlackThreadTS == "" {
+		s.SlackThreadTS = os.Getenv("RECAC_NOTIFY_THREAD")
+	}
 	// Notify Start
 	if !s.SuppressStartNotification {
 		msg := fmt.Sprintf("Project %s: Session Started", s.Project)
@@ -640,54 +811,130 @@ func (s *Session) Start(ctx context.Context) error {
 	return nil
 }
 
-// ensureImage ensures the agent image exists locally, pulling or building if needed.
+// ensureImage ensures the agent image exists locally, pulling or building if needed,
+// and verifies its digest when one is pinned.
 func (s *Session) ensureImage(ctx context.Context) error {
 	if s.Docker == nil {
 		fmt.Println("Docker not available available. Skipping image check (assuming local execution or pre-pulled).")
 		return nil
 	}
 
+	if err := s.prepareImage(ctx, false); err != nil {
+		if _, digest := docker.SplitDigest(s.Image); digest != "" || s.ImageDigest != "" {
+			// The pinned image could not be prepared, so it cannot be verified either
+			return fmt.Errorf("%w: %v", ErrImageDigest, err)
+		}
+		return err
+	}
+	return s.verifyImageDigest(ctx)
+}
+
+// runContainer starts the agent container, applying ContainerEntrypoint,
+// ContainerCommand, ContainerNetwork and ContainerProxy when set.
+func (s *Session) runContainer(ctx context.Context, extraBinds, env []string, user string) (string, error) {
+	// bridge is Docker's default network, so it needs no option
+	network := s.ContainerNetwork
+	if network == "bridge" {
+		network = ""
+	}
+	proxyEnv, err := s.ContainerProxy.Env()
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", docker.ErrInvalidContainerConfig, err)
+	}
+	if len(s.ContainerEntrypoint) == 0 && len(s.ContainerCommand) == 0 && network == "" && len(proxyEnv) == 0 {
+		return s.Docker.RunContainer(ctx, s.Image, s.Workspace, extraBinds, env, user)
+	}
+
+	runner, ok := s.Docker.(ContainerOptionsRunner)
+	if !ok {
+		return "", fmt.Errorf("%w: docker client does not support a custom container entrypoint, command, network or proxy", docker.ErrInvalidContainerConfig)
+	}
+	opts := docker.ContainerOptions{
+		Entrypoint: s.ContainerEntrypoint,
+		Cmd:        s.ContainerCommand,
+		Network:    network,
+		Env:        proxyEnv,
+	}
+	return runner.RunContainerWithOptions(ctx, s.Image, s.Workspace, extraBinds, env, user, opts)
+}
+
+// verifyImageDigest checks the local image against ImageDigest, or the digest
+// pinned in the image reference (image@sha256:...), if either is set.
+func (s *Session) verifyImageDigest(ctx context.Context) error {
+	_, digest := docker.SplitDigest(s.Image)
+	if s.ImageDigest != "" {
+		if digest != "" && digest != s.ImageDigest {
+			return fmt.Errorf("%w: image %s does not match the configured digest %s", ErrImageDigest, s.Image, s.ImageDigest)
+		}
+		digest = s.ImageDigest
+	}
+	if digest == "" {
+		return nil
+	}
+	if err := docker.ValidateDigest(digest); err != nil {
+		return fmt.Errorf("%w: %v", ErrImageDigest, err)
+	}
+
+	digester, ok := s.Docker.(ImageDigester)
+	if !ok {
+		return fmt.Errorf("%w: docker client does not report image digests", ErrImageDigest)
+	}
+	digests, err := digester.ImageDigests(ctx, s.Image)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrImageDigest, err)
+	}
+	if !docker.DigestMatches(digests, digest) {
+		return fmt.Errorf("%w: image digest mismatch for %s: expected %s, got %s", ErrImageDigest, s.Image, digest, strings.Join(digests, ", "))
+	}
+	fmt.Printf("Verified image digest: %s\n", digest)
+	return nil
+}
+
+// prepareImage pulls or builds the agent image if it is not available locally.
+// With refresh, it pulls or rebuilds the image even if it exists, to replace a
+// broken local copy.
+func (s *Session) prepareImage(ctx context.Context, refresh bool) error {
+	if refresh && isCheckpointImage(s.Image) {
+		return fmt.Errorf("checkpoint image %s cannot be pulled or rebuilt", s.Image)
+	}
+
 	// 1. Check for custom Dockerfile in workspace
 	// 1. Check if workspace has a Dockerfile. If so, building is mandatory to allow customization.
 	workspaceDockerfile := filepath.Join(s.Workspace, "Dockerfile")
 	if _, err := os.Stat(workspaceDockerfile); err == nil {
-		fmt.Printf("Custom Dockerfile found at %s. Building image...\n", workspaceDockerfile)
+		fmt.Printf("Custom Dockerfile found at %s.\n", workspaceDockerfile)
 		data, err := os.ReadFile(workspaceDockerfile)
 		if err != nil {
 			return fmt.Errorf("failed to read workspace Dockerfile: %w", err)
 		}
 
-		// Use the workspace path as tag if it's a custom build, or a specific name
-		tag := "recac-custom-" + filepath.Base(s.Workspace) + ":latest"
-
-		var buf bytes.Buffer
-		tw := tar.NewWriter(&buf)
-		_ = tw.WriteHeader(&tar.Header{Name: "Dockerfile", Size: int64(len(data)), Mode: 0644})
-		_, _ = tw.Write(data)
-		_ = tw.Close()
-
-		newID, err := s.Docker.ImageBuild(ctx, docker.ImageBuildOptions{
-			BuildContext: &buf,
-			Tag:          tag,
-			Dockerfile:   "Dockerfile",
-		})
+		// Tag by content hash so identical Dockerfiles share one image across workspaces
+		tag, err := s.buildCachedImage(ctx, "recac-custom", data, refresh)
 		if err != nil {
 			return fmt.Errorf("failed to build custom image: %w", err)
 		}
-		fmt.Printf("Custom image built successfully: %s\n", newID)
 		s.Image = tag
 		return nil
 	}
 
-	// 2. If using default GHCR image, ensure it is pulled if missing
-	if strings.HasPrefix(s.Image, "ghcr.io/process-failed-successfully/recac-agent") {
-		exists, err := s.Docker.ImageExists(ctx, s.Image)
-		if err != nil {
-			return fmt.Errorf("failed to check image existence: %w", err)
+	// 2. If using default GHCR image or a digest-pinned image, ensure it is pulled if missing.
+	// When refreshing, any image that is not built locally is pulled again.
+	_, digest := docker.SplitDigest(s.Image)
+	if digest != "" || strings.HasPrefix(s.Image, "ghcr.io/process-failed-successfully/recac-agent") || (refresh && !strings.HasPrefix(s.Image, "recac-agent:")) {
+		exists := false
+		if !refresh {
+			var err error
+			if exists, err = s.Docker.ImageExists(ctx, s.Image); err != nil {
+				return fmt.Errorf("failed to check image existence: %w", err)
+			}
 		}
 
 		if !exists {
-			fmt.Printf("Default agent image '%s' not found locally. Pulling...\n", s.Image)
+			if refresh {
+				fmt.Printf("Pulling agent image '%s' again...\n", s.Image)
+			} else {
+				fmt.Printf("Agent image '%s' not found locally. Pulling...\n", s.Image)
+			}
 			if err := s.Docker.PullImage(ctx, s.Image); err != nil {
 				return fmt.Errorf("failed to pull agent image: %w", err)
 			}
@@ -696,38 +943,66 @@ func (s *Session) ensureImage(ctx context.Context) error {
 		return nil
 	}
 
-	// 3. Fallback: If using legacy default image name, ensure it's built from our embedded template
-	if s.Image == "recac-agent:latest" {
+	// 3. Fallback: If using legacy default image name, ensure it's built from our embedded template.
+	// An existing recac-agent:latest (e.g. built by hand) is used as is; otherwise the build
+	// is tagged by template hash so it is rebuilt only when the template changes.
+	if s.Image == "recac-agent:latest" && !refresh {
 		exists, err := s.Docker.ImageExists(ctx, s.Image)
 		if err != nil {
 			return fmt.Errorf("failed to check image existence: %w", err)
 		}
-
-		if !exists {
-			fmt.Println("Legacy agent image 'recac-agent:latest' not found. Building from template...")
-
-			var buf bytes.Buffer
-			tw := tar.NewWriter(&buf)
-			content := docker.DefaultAgentDockerfile
-			_ = tw.WriteHeader(&tar.Header{Name: "Dockerfile", Size: int64(len(content)), Mode: 0644})
-			_, _ = tw.Write([]byte(content))
-			_ = tw.Close()
-
-			newID, err := s.Docker.ImageBuild(ctx, docker.ImageBuildOptions{
-				BuildContext: &buf,
-				Tag:          s.Image,
-				Dockerfile:   "Dockerfile",
-			})
-			if err != nil {
-				return fmt.Errorf("failed to build legacy agent image: %w", err)
-			}
-			fmt.Printf("Legacy agent image built successfully: %s\n", newID)
+		if exists {
+			return nil
 		}
 	}
+	if s.Image == "recac-agent:latest" || (refresh && strings.HasPrefix(s.Image, "recac-agent:")) {
+		tag, err := s.buildCachedImage(ctx, "recac-agent", []byte(docker.DefaultAgentDockerfile), refresh)
+		if err != nil {
+			return fmt.Errorf("failed to build legacy agent image: %w", err)
+		}
+		s.Image = tag
+	}
 
 	return nil
 }
 
+// buildCachedImage builds dockerfile as repo:<content hash>, reusing an existing
+// image with that tag instead of rebuilding unless rebuild is set. It returns the tag.
+func (s *Session) buildCachedImage(ctx context.Context, repo string, dockerfile []byte, rebuild bool) (string, error) {
+	sum := sha256.Sum256(dockerfile)
+	tag := fmt.Sprintf("%s:%s", repo, hex.EncodeToString(sum[:])[:12])
+
+	if !rebuild {
+		exists, err := s.Docker.ImageExists(ctx, tag)
+		if err != nil {
+			return "", fmt.Errorf("failed to check image existence: %w", err)
+		}
+		if exists {
+			fmt.Printf("Using cached image %s\n", tag)
+			return tag, nil
+		}
+	}
+
+	fmt.Printf("Building image %s...\n", tag)
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	_ = tw.WriteHeader(&tar.Header{Name: "Dockerfile", Size: int64(len(dockerfile)), Mode: 0644})
+	_, _ = tw.Write(dockerfile)
+	_ = tw.Close()
+
+	newID, err := s.Docker.ImageBuild(ctx, docker.ImageBuildOptions{
+		BuildContext: &buf,
+		Tag:          tag,
+		Dockerfile:   "Dockerfile",
+		NoCache:      rebuild,
+	})
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("Image built successfully: %s\n", newID)
+	return tag, nil
+}
+
 // Stop cleans up the Docker container.
 func (s *Session) Stop(ctx context.Context) error {
 	if s.DBStore != nil && s.OwnsDB {
@@ -736,6 +1011,12 @@ func (s *Session) Stop(ctx context.Context) error {
 		}
 	}
 
+	if s.EventLog != nil {
+		if err := s.EventLog.Close(); err != nil {
+			fmt.Printf("Warning: Failed to close event log: %v\n", err)
+		}
+	}
+
 	s.mu.Lock()
 	containerID := s.ContainerID
 	s.mu.Unlock()
@@ -774,6 +1055,18 @@ func (s *Session) IncrementIteration() int {
 	return s.Iteration
 }
 
+func (s *Session) getRole() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.role
+}
+
+func (s *Session) setRole(role string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.role = role
+}
+
 func (s *Session) GetSlackThreadTS() string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -801,6 +1094,10 @@ func (s *Session) SetContainerID(id string) {
 
 
 func (s *Session) loadFeatures() []db.Feature {
+	return s.guardFeatureList(s.readFeatures())
+}
+
+func (s *Session) readFeatures() []db.Feature {
 	// 1. Try to fetch from DB first (Authoritative source)
 	var fromDB []db.Feature
 	if s.DBStore != nil {
@@ -2,21 +2,107 @@ package runner
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"recac/internal/agent"
+	"recac/internal/git"
+)
+
+// Backoff bounds between iterations after a failed agent call.
+const (
+	agentErrorBackoffBase = 5 * time.Second
+	agentErrorBackoffMax  = 2 * time.Minute
+)
+
+// Idle detection modes (noop_detection)
+const (
+	NoOpDetectChanges  = "changes"  // Commands or workspace file changes count as progress (default)
+	NoOpDetectCommands = "commands" // Only executed commands count as progress
 )
 
-// checkNoOpBreaker checks if the agent is looping without action.
+// defaultNoOpLimit is how many idle iterations in a row trip the no-op breaker
+// when noop_limit is unset.
+const defaultNoOpLimit = 3
+
+// checkNoOpBreaker checks if the agent is looping without action. An iteration
+// is idle when it ran no commands and, unless noop_detection is "commands",
+// left the workspace unchanged: agents that edit files directly are working.
 func (s *Session) checkNoOpBreaker(executionOutput string) error {
-	if executionOutput == "" {
-		s.NoOpCount++
-		if s.NoOpCount >= 3 {
-			return fmt.Errorf("CIRCUIT BREAKER TRIPPED: NO-OP LOOP (Agent has produced 3 consecutive responses with no commands)")
-		}
-	} else {
+	changed := false
+	if s.config().GetString("noop_detection") != NoOpDetectCommands {
+		changed = s.workspaceChanged()
+	}
+
+	if executionOutput != "" || changed {
 		s.NoOpCount = 0 // Reset on valid action
+		return nil
+	}
+
+	s.NoOpCount++
+	limit := s.config().GetInt("noop_limit")
+	if limit <= 0 {
+		limit = defaultNoOpLimit
+	}
+	if s.NoOpCount >= limit {
+		return fmt.Errorf("CIRCUIT BREAKER TRIPPED: NO-OP LOOP (Agent has produced %d consecutive responses with no commands or file changes)", s.NoOpCount)
 	}
 	return nil
 }
 
+// workspaceChanged reports whether the workspace's git state differs from the
+// last time it was checked. The first check only records a baseline.
+func (s *Session) workspaceChanged() bool {
+	fingerprint := s.workspaceFingerprint()
+	previous := s.lastWorkspaceState
+	s.lastWorkspaceState = fingerprint
+	return previous != "" && fingerprint != "" && fingerprint != previous
+}
+
+// workspaceFingerprint summarizes the workspace's git state: HEAD, the diff
+// stat of uncommitted changes, and the modification time and size of every
+// changed or untracked file, so rewriting a file with the same line counts
+// still registers. It returns "" when the workspace is not a git repository.
+func (s *Session) workspaceFingerprint() string {
+	if s.Workspace == "" {
+		return ""
+	}
+	gitClient := git.NewClient()
+
+	head, err := gitClient.CurrentCommitSHA(s.Workspace)
+	if err != nil {
+		return ""
+	}
+	stat, err := gitClient.DiffStat(s.Workspace, "HEAD", "")
+	if err != nil {
+		return ""
+	}
+	status, err := gitClient.Run(s.Workspace, "status", "--porcelain", "--untracked-files=all")
+	if err != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(head + "\n" + stat + "\n")
+	for _, line := range strings.Split(status, "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		path := line[3:]
+		if i := strings.Index(path, " -> "); i >= 0 {
+			path = path[i+4:] // renamed: the new path
+		}
+		fmt.Fprintf(&b, "%s", line)
+		if info, err := os.Stat(filepath.Join(s.Workspace, strings.Trim(path, `"`))); err == nil {
+			fmt.Fprintf(&b, " %d %d", info.ModTime().UnixNano(), info.Size())
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 // checkStalledBreaker checks if the agent is making progress on features.
 func (s *Session) checkFeatures() int {
 	features := s.loadFeatures()
@@ -58,3 +144,21 @@ func (s *Session) checkStalledBreaker(role string, passingCount int) error {
 
 	return nil
 }
+
+// checkAgentErrorBreaker classifies a failed agent call. It returns how long to back off
+// before the next iteration, or an error if the session should abort: either because the
+// failure is fatal (bad API key, malformed request) or because agent_max_retries
+// consecutive calls have failed.
+func (s *Session) checkAgentErrorBreaker(err error) (time.Duration, error) {
+	if agent.IsFatal(err) {
+		s.AgentErrorCount = 0
+		return 0, fmt.Errorf("agent call failed with a non-retryable error: %w", err)
+	}
+
+	s.AgentErrorCount++
+	maxRetries := s.config().GetInt("agent_max_retries")
+	if maxRetries > 0 && s.AgentErrorCount > maxRetries {
+		return 0, fmt.Errorf("%w (%d consecutive agent errors): %v", ErrAgentUnavailable, s.AgentErrorCount, err)
+	}
+	return agent.Backoff(s.AgentErrorCount, agentErrorBackoffBase, agentErrorBackoffMax), nil
+}
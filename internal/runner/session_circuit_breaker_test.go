@@ -1,10 +1,18 @@
 package runner
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"recac/internal/agent"
 	"recac/internal/notify"
 	"recac/internal/telemetry"
 	"strings"
 	"testing"
+
+	"github.com/spf13/viper"
 )
 
 func TestSession_CheckNoOpBreaker(t *testing.T) {
@@ -52,6 +60,60 @@ func TestSession_CheckNoOpBreaker(t *testing.T) {
 	}
 }
 
+func TestSession_CheckNoOpBreaker_WorkspaceChanges(t *testing.T) {
+	workspace := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"commit", "--allow-empty", "-m", "initial"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", workspace}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	viper.Set("noop_limit", 2)
+	defer viper.Set("noop_limit", nil)
+
+	s := &Session{Workspace: workspace}
+	s.workspaceChanged() // baseline, as RunLoop does
+
+	// Editing a file without running commands is progress
+	if err := os.WriteFile(filepath.Join(workspace, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.checkNoOpBreaker(""); err != nil || s.NoOpCount != 0 {
+		t.Errorf("Expected a file change to count as progress, got count %d, err %v", s.NoOpCount, err)
+	}
+
+	// Rewriting the same file again is still progress
+	if err := os.WriteFile(filepath.Join(workspace, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.checkNoOpBreaker(""); err != nil || s.NoOpCount != 0 {
+		t.Errorf("Expected an edit to count as progress, got count %d, err %v", s.NoOpCount, err)
+	}
+
+	// Neither commands nor changes trips the breaker at noop_limit
+	s.checkNoOpBreaker("")
+	err := s.checkNoOpBreaker("")
+	if err == nil || !strings.Contains(err.Error(), "2 consecutive") {
+		t.Errorf("Expected the breaker to trip after 2 idle iterations, got %v", err)
+	}
+
+	// With noop_detection: commands, file changes are not progress
+	viper.Set("noop_detection", NoOpDetectCommands)
+	defer viper.Set("noop_detection", nil)
+	s.NoOpCount = 0
+	if err := os.Remove(filepath.Join(workspace, "main.go")); err != nil {
+		t.Fatal(err)
+	}
+	s.checkNoOpBreaker("")
+	if s.NoOpCount != 1 {
+		t.Errorf("Expected file changes to be ignored in commands mode, got count %d", s.NoOpCount)
+	}
+}
+
 func TestSession_CheckStalledBreaker(t *testing.T) {
 	workspace := t.TempDir()
 
@@ -127,3 +189,35 @@ func TestSession_CheckStalledBreaker_ManagerReset(t *testing.T) {
 		t.Errorf("Expected StalledCount to be reset to 0 by Manager, got %d", s.StalledCount)
 	}
 }
+
+func TestSession_CheckAgentErrorBreaker(t *testing.T) {
+	viper.Set("agent_max_retries", 2)
+	defer viper.Set("agent_max_retries", 0)
+
+	s := &Session{
+		Notifier: notify.NewManager(func(string, ...interface{}) {}),
+		Logger:   telemetry.NewLogger(true, "", false),
+	}
+
+	// Retryable errors back off with increasing delays until the cap is hit
+	transient := fmt.Errorf("read: connection reset by peer")
+	first, err := s.checkAgentErrorBreaker(transient)
+	if err != nil || first <= 0 {
+		t.Fatalf("Expected backoff for 1st error, got %v, %v", first, err)
+	}
+	if _, err := s.checkAgentErrorBreaker(transient); err != nil {
+		t.Fatalf("Expected backoff for 2nd error, got %v", err)
+	}
+	if _, err := s.checkAgentErrorBreaker(transient); !errors.Is(err, ErrAgentUnavailable) {
+		t.Errorf("Expected ErrAgentUnavailable after exceeding the cap, got %v", err)
+	}
+
+	// Fatal auth errors abort immediately and keep their type
+	s.AgentErrorCount = 0
+	authErr := &agent.AuthError{StatusCode: 401, Err: fmt.Errorf("invalid api key")}
+	_, err = s.checkAgentErrorBreaker(authErr)
+	var target *agent.AuthError
+	if !errors.As(err, &target) {
+		t.Errorf("Expected AuthError to abort the session, got %v", err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/viper"
 )
 
 type execCall struct {
@@ -66,6 +67,7 @@ func TestSession_Start_RunsInitScript(t *testing.T) {
 	foundPasswdFix := false
 	foundGitRoot := false
 	foundChmod := false
+	foundExec := false
 
 	for _, call := range execCalls {
 		if strings.Contains(call.Cmd, "useradd") && call.User == "root" {
@@ -77,8 +79,9 @@ func TestSession_Start_RunsInitScript(t *testing.T) {
 		if strings.Contains(call.Cmd, "chmod +x init.sh") {
 			foundChmod = true
 		}
-		// init.sh runs async, so we can't reliably check for it here without a race condition.
-		// if strings.Contains(call.Cmd, "./init.sh") { foundExec = true }
+		if strings.Contains(call.Cmd, "./init.sh") {
+			foundExec = true
+		}
 	}
 
 	if !foundPasswdFix {
@@ -90,7 +93,9 @@ func TestSession_Start_RunsInitScript(t *testing.T) {
 	if !foundChmod {
 		t.Errorf("Expected chmod +x init.sh call, but not found in %v", execCalls)
 	}
-	// if !foundExec { ... }
+	if !foundExec {
+		t.Errorf("Expected ./init.sh call, but not found in %v", execCalls)
+	}
 }
 
 func TestSession_Start_NoInitScript(t *testing.T) {
@@ -140,9 +145,23 @@ func TestSession_Start_InitScriptFails(t *testing.T) {
 		return types.IDResponse{ID: "mock-exec-id"}, nil
 	}
 
-	session := NewSession(d, &MockAgent{}, tmpDir, "alpine", "test-project", "gemini", "gemini-pro", 1)
+	t.Run("fail_on_error disabled", func(t *testing.T) {
+		viper.Set("init_script_fail_on_error", false)
+		defer viper.Set("init_script_fail_on_error", nil)
 
-	if err := session.Start(context.Background()); err != nil {
-		t.Fatalf("Start should NOT fail even if init.sh fails, but got: %v", err)
-	}
+		session := NewSession(d, &MockAgent{}, tmpDir, "alpine", "test-project", "gemini", "gemini-pro", 1)
+		if err := session.Start(context.Background()); err != nil {
+			t.Fatalf("Start should NOT fail even if init.sh fails, but got: %v", err)
+		}
+	})
+
+	t.Run("fail_on_error enabled", func(t *testing.T) {
+		viper.Set("init_script_fail_on_error", true)
+		defer viper.Set("init_script_fail_on_error", nil)
+
+		session := NewSession(d, &MockAgent{}, tmpDir, "alpine", "test-project", "gemini", "gemini-pro", 1)
+		if err := session.Start(context.Background()); err == nil || !strings.Contains(err.Error(), "init script init.sh failed") {
+			t.Fatalf("Expected Start to fail with the init script error, got: %v", err)
+		}
+	})
 }
@@ -0,0 +1,172 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/viper"
+)
+
+// SessionLogEnv tells the process of a detached session where its output is
+// logged, so it can rotate the log while it runs.
+const SessionLogEnv = "RECAC_SESSION_LOG"
+
+// sessionLogCheckInterval is how often a running session checks the size of its log.
+var sessionLogCheckInterval = 10 * time.Second
+
+// SessionLogRotation configures the size-based rotation of session logs.
+type SessionLogRotation struct {
+	MaxSize  uint64 // Rotate once the log grows beyond this many bytes (0 = never)
+	MaxFiles int    // Rotated files kept next to the log (<name>.log.1 is the newest)
+}
+
+// sessionLogRotation returns the configured rotation from session_log.max_size
+// and session_log.max_files.
+func sessionLogRotation() (SessionLogRotation, error) {
+	rotation := SessionLogRotation{MaxFiles: viper.GetInt("session_log.max_files")}
+	raw := strings.TrimSpace(viper.GetString("session_log.max_size"))
+	if raw == "" || raw == "0" {
+		return rotation, nil
+	}
+	size, err := humanize.ParseBytes(raw)
+	if err != nil {
+		return rotation, fmt.Errorf("invalid session_log.max_size %q: %w", raw, err)
+	}
+	rotation.MaxSize = size
+	return rotation, nil
+}
+
+// rotatedLogPath returns the path of the n-th rotated file of a log.
+func rotatedLogPath(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
+
+// RotateSessionLog moves the content of the log at path to <path>.1, shifting
+// older rotated files up and deleting those beyond maxFiles. The log is copied
+// and truncated rather than renamed, since the session process keeps writing
+// to its open file descriptor; StartSession opens it in append mode so those
+// writes continue at the start of the truncated file. With maxFiles < 1 the
+// log is only truncated.
+func RotateSessionLog(path string, maxFiles int) error {
+	if maxFiles < 1 {
+		return os.Truncate(path, 0)
+	}
+
+	if err := os.Remove(rotatedLogPath(path, maxFiles)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove oldest rotated log: %w", err)
+	}
+	for n := maxFiles - 1; n >= 1; n-- {
+		if err := os.Rename(rotatedLogPath(path, n), rotatedLogPath(path, n+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to shift rotated log: %w", err)
+		}
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open log: %w", err)
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(rotatedLogPath(path, 1), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create rotated log: %w", err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return fmt.Errorf("failed to copy log: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("failed to write rotated log: %w", err)
+	}
+	return os.Truncate(path, 0)
+}
+
+// WatchSessionLog rotates the log at path whenever it grows beyond
+// session_log.max_size, until ctx is done. Detached sessions run it on the log
+// named by SessionLogEnv.
+func WatchSessionLog(ctx context.Context, path string) {
+	rotation, err := sessionLogRotation()
+	if err != nil {
+		fmt.Printf("Warning: session log rotation disabled: %v\n", err)
+		return
+	}
+	if rotation.MaxSize == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(sessionLogCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil || uint64(info.Size()) <= rotation.MaxSize {
+				continue
+			}
+			if err := RotateSessionLog(path, rotation.MaxFiles); err != nil {
+				fmt.Printf("Warning: failed to rotate session log: %v\n", err)
+			}
+		}
+	}
+}
+
+// SessionLogFiles returns the existing files of the log at path, oldest
+// rotated file first and the log itself last.
+func SessionLogFiles(path string) []string {
+	var rotated []string
+	for n := 1; ; n++ {
+		if _, err := os.Stat(rotatedLogPath(path, n)); err != nil {
+			break
+		}
+		rotated = append(rotated, rotatedLogPath(path, n))
+	}
+
+	files := make([]string, 0, len(rotated)+1)
+	for i := len(rotated) - 1; i >= 0; i-- {
+		files = append(files, rotated[i])
+	}
+	return append(files, path)
+}
+
+// ReadSessionLog returns the content of the log at path, including its
+// rotated files, oldest first.
+func ReadSessionLog(path string) ([]byte, error) {
+	var content []byte
+	for _, file := range SessionLogFiles(path) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		content = append(content, data...)
+	}
+	return content, nil
+}
+
+// moveSessionLog renames the log at oldPath and its rotated files to newPath.
+// Rotated files are moved best effort once the log itself has been.
+func moveSessionLog(oldPath, newPath string) error {
+	rotated := SessionLogFiles(oldPath)
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return err
+	}
+	for n := 1; n < len(rotated); n++ {
+		os.Rename(rotatedLogPath(oldPath, n), rotatedLogPath(newPath, n))
+	}
+	return nil
+}
+
+// removeSessionLog deletes the log at path and its rotated files.
+func removeSessionLog(path string) error {
+	for _, file := range SessionLogFiles(path) {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateSessionLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "s.log")
+
+	// Keep writing through one append-mode descriptor, as the session process does
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	defer f.Close()
+
+	for _, chunk := range []string{"one\n", "two\n", "three\n"} {
+		_, err := f.WriteString(chunk)
+		require.NoError(t, err)
+		require.NoError(t, RotateSessionLog(path, 2))
+	}
+	_, err = f.WriteString("four\n")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{path + ".2", path + ".1", path}, SessionLogFiles(path))
+	content, err := ReadSessionLog(path)
+	require.NoError(t, err)
+	assert.Equal(t, "two\nthree\nfour\n", string(content), "the oldest rotation is dropped and writes continue in the log")
+
+	require.NoError(t, RotateSessionLog(path, 0))
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, content)
+}
+
+func TestWatchSessionLog(t *testing.T) {
+	viper.Set("session_log.max_size", "10B")
+	viper.Set("session_log.max_files", 1)
+	defer viper.Set("session_log.max_size", nil)
+	defer viper.Set("session_log.max_files", nil)
+
+	orig := sessionLogCheckInterval
+	sessionLogCheckInterval = 10 * time.Millisecond
+	defer func() { sessionLogCheckInterval = orig }()
+
+	path := filepath.Join(t.TempDir(), "s.log")
+	require.NoError(t, os.WriteFile(path, []byte("more than ten bytes\n"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		WatchSessionLog(ctx, path)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(path + ".1")
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	content, err := ReadSessionLog(path)
+	require.NoError(t, err)
+	assert.Equal(t, "more than ten bytes\n", string(content))
+}
+
+func TestSessionLogRotation_Config(t *testing.T) {
+	viper.Set("session_log.max_size", "bogus")
+	defer viper.Set("session_log.max_size", nil)
+	_, err := sessionLogRotation()
+	assert.Error(t, err)
+
+	viper.Set("session_log.max_size", "1MB")
+	rotation, err := sessionLogRotation()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000000), rotation.MaxSize)
+
+	viper.Set("session_log.max_size", "0")
+	rotation, err = sessionLogRotation()
+	require.NoError(t, err)
+	assert.Zero(t, rotation.MaxSize)
+}
+
+func TestSessionManager_RotatedLogs(t *testing.T) {
+	sm, err := NewSessionManagerWithDir(t.TempDir())
+	require.NoError(t, err)
+
+	logFile := filepath.Join(sm.SessionsDir(), "rotated.log")
+	require.NoError(t, os.WriteFile(logFile+".1", []byte("old 1\nold 2\n"), 0600))
+	require.NoError(t, os.WriteFile(logFile, []byte("new 1\n"), 0600))
+	require.NoError(t, sm.SaveSession(&SessionState{Name: "rotated", LogFile: logFile, Status: "completed"}))
+
+	content, err := sm.GetSessionLogContent("rotated", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "old 2\nnew 1", content)
+
+	require.NoError(t, sm.RenameSession("rotated", "renamed"))
+	renamedLog := filepath.Join(sm.SessionsDir(), "renamed.log")
+	assert.FileExists(t, renamedLog+".1")
+	assert.NoFileExists(t, logFile+".1")
+
+	require.NoError(t, sm.RemoveSession("renamed", false))
+	assert.NoFileExists(t, renamedLog)
+	assert.NoFileExists(t, renamedLog+".1")
+}
@@ -40,6 +40,7 @@ type SessionState struct {
 	StartCommitSHA string    `json:"start_commit_sha,omitempty"`
 	EndCommitSHA   string    `json:"end_commit_sha,omitempty"`
 	ContainerID    string    `json:"container_id,omitempty"`
+	Tags           []string  `json:"tags,omitempty"`
 }
 
 // SessionManager handles background session management
@@ -65,9 +66,11 @@ type ISessionManager interface {
 	RenameSession(oldName, newName string) error
 	SessionsDir() string
 	GetSessionGitDiffStat(name string) (string, error)
+	GetSessionGitDiff(name string) (string, error)
 	ArchiveSession(name string) error
 	UnarchiveSession(name string) error
 	ListArchivedSessions() ([]*SessionState, error)
+	RemoveArchivedSession(name string) error
 }
 
 // NewSessionManager creates a new session manager
@@ -144,7 +147,8 @@ func (sm *SessionManager) StartSession(name, goal string, command []string, work
 	logFile := filepath.Join(sm.sessionsDir, name+".log")
 	// Use OpenFile with O_EXCL to prevent TOCTOU race conditions and overwriting existing logs atomically
 	// Use 0600 to restrict access to the owner only
-	logFd, err := os.OpenFile(logFile, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	// Use O_APPEND so writes continue at the start of the log after it is rotated (see RotateSessionLog)
+	logFd, err := os.OpenFile(logFile, os.O_RDWR|os.O_CREATE|os.O_EXCL|os.O_APPEND, 0600)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create log file (safe): %w", err)
 	}
@@ -179,7 +183,7 @@ func (sm *SessionManager) StartSession(name, goal string, command []string, work
 	cmd.Stdout = logFd
 	cmd.Stderr = logFd
 	cmd.Dir = workspace
-	cmd.Env = os.Environ() // Preserve environment
+	cmd.Env = append(os.Environ(), SessionLogEnv+"="+logFile) // Preserve environment; the session rotates its log
 
 	// Start process in new session (detached from terminal)
 	// Note: Setsid may not work in all environments (e.g., Docker containers without proper capabilities)
@@ -340,7 +344,7 @@ func (sm *SessionManager) ArchiveSession(name string) error {
 	// Move log file (.log)
 	oldLogPath := session.LogFile
 	newLogPath := filepath.Join(sm.archivedSessionsDir, filepath.Base(oldLogPath))
-	if err := os.Rename(oldLogPath, newLogPath); err != nil {
+	if err := moveSessionLog(oldLogPath, newLogPath); err != nil {
 		// Rollback session file move
 		os.Rename(newSessionPath, oldSessionPath)
 		return fmt.Errorf("failed to move session log file to archive: %w", err)
@@ -370,7 +374,7 @@ func (sm *SessionManager) UnarchiveSession(name string) error {
 	// Move log file (.log)
 	archivedLogPath := filepath.Join(sm.archivedSessionsDir, name+".log")
 	activeLogPath := filepath.Join(sm.sessionsDir, name+".log")
-	if err := os.Rename(archivedLogPath, activeLogPath); err != nil {
+	if err := moveSessionLog(archivedLogPath, activeLogPath); err != nil {
 		// Rollback session file move
 		os.Rename(activeSessionPath, archivedSessionPath)
 		return fmt.Errorf("failed to move session log file from archive: %w", err)
@@ -379,6 +383,24 @@ func (sm *SessionManager) UnarchiveSession(name string) error {
 	return nil
 }
 
+// RemoveArchivedSession deletes an archived session's state and log files from disk.
+func (sm *SessionManager) RemoveArchivedSession(name string) error {
+	archivedSessionPath := filepath.Join(sm.archivedSessionsDir, name+".json")
+	if err := os.Remove(archivedSessionPath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("archived session '%s' not found", name)
+		}
+		return fmt.Errorf("failed to remove archived session state file %s: %w", archivedSessionPath, err)
+	}
+
+	archivedLogPath := filepath.Join(sm.archivedSessionsDir, name+".log")
+	if err := removeSessionLog(archivedLogPath); err != nil {
+		return fmt.Errorf("failed to remove archived session log file %s: %w", archivedLogPath, err)
+	}
+
+	return nil
+}
+
 // RenameSession renames a session, including its state and log files.
 func (sm *SessionManager) RenameSession(oldName, newName string) error {
 	if err := validateSessionName(oldName); err != nil {
@@ -420,7 +442,7 @@ func (sm *SessionManager) RenameSession(oldName, newName string) error {
 	// 5. Rename the log file (.log).
 	oldLogPath := session.LogFile
 	newLogPath := filepath.Join(sm.sessionsDir, newName+".log")
-	if err := os.Rename(oldLogPath, newLogPath); err != nil {
+	if err := moveSessionLog(oldLogPath, newLogPath); err != nil {
 		// Attempt to roll back the session file rename.
 		os.Rename(newSessionPath, oldSessionPath)
 		return fmt.Errorf("failed to rename session log file: %w", err)
@@ -434,7 +456,7 @@ func (sm *SessionManager) RenameSession(oldName, newName string) error {
 	if err := sm.SaveSession(session); err != nil {
 		// Attempt to roll back both renames.
 		os.Rename(newSessionPath, oldSessionPath)
-		os.Rename(newLogPath, oldLogPath)
+		moveSessionLog(newLogPath, oldLogPath)
 		return fmt.Errorf("failed to save updated session state: %w", err)
 	}
 
@@ -442,19 +464,21 @@ func (sm *SessionManager) RenameSession(oldName, newName string) error {
 }
 
 // GetSessionGitDiffStat returns the `git diff --stat` output between a session's start and end commits.
+// Running sessions are compared against HEAD.
 func (sm *SessionManager) GetSessionGitDiffStat(name string) (string, error) {
 	session, err := sm.LoadSession(name)
 	if err != nil {
 		return "", fmt.Errorf("could not load session '%s': %w", name, err)
 	}
 
-	if session.StartCommitSHA == "" || session.EndCommitSHA == "" || session.Workspace == "" {
+	start, end := sessionDiffRange(session)
+	if start == "" || end == "" || session.Workspace == "" {
 		return "", nil // Not an error, just no diff to show
 	}
 
 	// Use the git client for consistency, though direct exec is also fine here.
 	gitClient := git.NewClient()
-	diff, err := gitClient.DiffStat(session.Workspace, session.StartCommitSHA, session.EndCommitSHA)
+	diff, err := gitClient.DiffStat(session.Workspace, start, end)
 	if err != nil {
 		return "", fmt.Errorf("failed to get git diff stat: %w", err)
 	}
@@ -462,6 +486,36 @@ func (sm *SessionManager) GetSessionGitDiffStat(name string) (string, error) {
 	return diff, nil
 }
 
+// GetSessionGitDiff returns the unified diff between a session's start and end commits.
+// Running sessions are compared against HEAD.
+func (sm *SessionManager) GetSessionGitDiff(name string) (string, error) {
+	session, err := sm.LoadSession(name)
+	if err != nil {
+		return "", fmt.Errorf("could not load session '%s': %w", name, err)
+	}
+
+	start, end := sessionDiffRange(session)
+	if start == "" || end == "" || session.Workspace == "" {
+		return "", nil
+	}
+
+	diff, err := git.NewClient().Diff(session.Workspace, start, end)
+	if err != nil {
+		return "", fmt.Errorf("failed to get git diff: %w", err)
+	}
+	return diff, nil
+}
+
+// sessionDiffRange returns the commits bounding a session's changes. A running
+// session has no end commit yet, so its changes so far end at HEAD.
+func sessionDiffRange(session *SessionState) (string, string) {
+	end := session.EndCommitSHA
+	if end == "" && session.Status == "running" {
+		end = "HEAD"
+	}
+	return session.StartCommitSHA, end
+}
+
 // ListSessions returns all sessions
 func (sm *SessionManager) ListSessions() ([]*SessionState, error) {
 	entries, err := os.ReadDir(sm.sessionsDir)
@@ -614,14 +668,15 @@ func (sm *SessionManager) GetSessionLogs(name string) (string, error) {
 	return session.LogFile, nil
 }
 
-// GetSessionLogContent returns the last N lines of the log file for a session.
+// GetSessionLogContent returns the last N lines of the log file for a session,
+// reading across its rotated files.
 func (sm *SessionManager) GetSessionLogContent(name string, lines int) (string, error) {
 	session, err := sm.LoadSession(name)
 	if err != nil {
 		return "", fmt.Errorf("session not found: %w", err)
 	}
 
-	logData, err := os.ReadFile(session.LogFile)
+	logData, err := ReadSessionLog(session.LogFile)
 	if err != nil {
 		return "", fmt.Errorf("could not read log file %s: %w", session.LogFile, err)
 	}
@@ -666,10 +721,10 @@ func (sm *SessionManager) RemoveSession(name string, force bool) error {
 		return fmt.Errorf("failed to remove session state file %s: %w", sessionPath, err)
 	}
 
-	// Remove log file (.log)
+	// Remove log file (.log) and its rotated files
 	logPath := session.LogFile
-	err = os.Remove(logPath)
-	if err != nil && !os.IsNotExist(err) {
+	err = removeSessionLog(logPath)
+	if err != nil {
 		return fmt.Errorf("failed to remove session log file %s: %w", logPath, err)
 	}
 
@@ -222,6 +222,21 @@ func (m *MockGitClient) CreatePR(directory, title, body, base string) (string, e
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockGitClient) AddWorktree(directory, path, branch string) error {
+	args := m.Called(directory, path, branch)
+	return args.Error(0)
+}
+
+func (m *MockGitClient) RemoveWorktree(directory, path string) error {
+	args := m.Called(directory, path)
+	return args.Error(0)
+}
+
+func (m *MockGitClient) DefaultBranch(directory string) (string, error) {
+	args := m.Called(directory)
+	return args.String(0), args.Error(1)
+}
+
 // setupSessionManager creates a new SessionManager in a temporary directory for isolated testing.
 func setupSessionManager(t *testing.T) (*SessionManager, func()) {
 	t.Helper()
@@ -738,6 +753,53 @@ func TestGetSessionGitDiffStat(t *testing.T) {
 	})
 }
 
+func TestGetSessionGitDiff(t *testing.T) {
+	originalNewClient := git.NewClient
+	defer func() { git.NewClient = originalNewClient }()
+
+	t.Run("diffs between start and end commits", func(t *testing.T) {
+		sm, cleanup := setupSessionManager(t)
+		defer cleanup()
+
+		mockClient := new(MockGitClient)
+		mockClient.On("Diff", "/tmp", "start", "end").Return("diff --git a/x b/x\n", nil)
+		git.NewClient = func() git.IClient {
+			return mockClient
+		}
+
+		require.NoError(t, sm.SaveSession(&SessionState{Name: "done", Status: "completed", Workspace: "/tmp", StartCommitSHA: "start", EndCommitSHA: "end"}))
+		diff, err := sm.GetSessionGitDiff("done")
+		assert.NoError(t, err)
+		assert.Equal(t, "diff --git a/x b/x\n", diff)
+	})
+
+	t.Run("diffs running sessions against HEAD", func(t *testing.T) {
+		sm, cleanup := setupSessionManager(t)
+		defer cleanup()
+
+		mockClient := new(MockGitClient)
+		mockClient.On("Diff", "/tmp", "start", "HEAD").Return("patch", nil)
+		git.NewClient = func() git.IClient {
+			return mockClient
+		}
+
+		require.NoError(t, sm.SaveSession(&SessionState{Name: "live", Status: "running", PID: os.Getpid(), Workspace: "/tmp", StartCommitSHA: "start"}))
+		diff, err := sm.GetSessionGitDiff("live")
+		assert.NoError(t, err)
+		assert.Equal(t, "patch", diff)
+	})
+
+	t.Run("returns empty string without a start commit", func(t *testing.T) {
+		sm, cleanup := setupSessionManager(t)
+		defer cleanup()
+
+		require.NoError(t, sm.SaveSession(&SessionState{Name: "new", Status: "running", Workspace: "/tmp"}))
+		diff, err := sm.GetSessionGitDiff("new")
+		assert.NoError(t, err)
+		assert.Equal(t, "", diff)
+	})
+}
+
 func TestSessionManager_PauseResume(t *testing.T) {
 	sm, cleanup := setupSessionManager(t)
 	defer cleanup()
@@ -2,6 +2,7 @@ package runner
 
 import (
 	"context"
+	"errors"
 	"encoding/json"
 	"os"
 	"os/exec"
@@ -12,6 +13,7 @@ import (
 	"recac/internal/telemetry"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
@@ -713,21 +715,9 @@ func TestSession_RunInitScript(t *testing.T) {
 	session := NewSession(d, &MockAgent{}, tmpDir, "alpine", "test-project", "gemini", "gemini-pro", 1)
 	session.ContainerID = "test-container"
 
-	// It runs in background, so we need to wait a bit or just verify it didn't crash
-	// Since runInitScript launches a goroutine, verifying side effects is racy without sync.
-	// But we can check that it ATTEMPTED to run if we mock ExecAsUser to notify us.
-	// However, ensuring the goroutine runs before we assert is hard.
-	// We can skip the goroutine wait logic for unit test and just verify logic paths if possible,
-	// OR we can make runInitScript synchronous for testing? No, code modification.
-
-	// We'll trust that if we call it, it spawns.
-	// Actually, checking "Found init.sh" in stdout might be hard.
-	// Let's just run it to ensure no panic.
-	session.runInitScript(context.Background())
-
-	// We can't easily assert execCalled is true immediately.
-	// Use assertion with eventually if testify supported it, or simple sleep.
-	// time.Sleep(100 * time.Millisecond) // Flaky?
+	if err := session.runInitScript(context.Background()); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
 }
 
 func TestSession_FixPasswdDatabase(t *testing.T) {
@@ -801,6 +791,9 @@ func TestSession_EnsureImage_CustomDockerfile(t *testing.T) {
 
 	d := &MockDockerClient{}
 	buildCalled := false
+	d.ImageExistsFunc = func(ctx context.Context, image string) (bool, error) {
+		return false, nil
+	}
 	d.ImageBuildFunc = func(ctx context.Context, options docker.ImageBuildOptions) (string, error) {
 		buildCalled = true
 		if options.Dockerfile != "Dockerfile" {
@@ -820,11 +813,241 @@ func TestSession_EnsureImage_CustomDockerfile(t *testing.T) {
 		t.Error("Expected ImageBuild to be called for custom Dockerfile")
 	}
 
-	if !strings.Contains(session.Image, "recac-custom-") {
+	if !strings.HasPrefix(session.Image, "recac-custom:") {
 		t.Errorf("Expected session image to be updated, got: %s", session.Image)
 	}
 }
 
+func TestSession_EnsureImage_CachedByDockerfileHash(t *testing.T) {
+	built := map[string]bool{}
+	d := &MockDockerClient{}
+	d.ImageExistsFunc = func(ctx context.Context, image string) (bool, error) {
+		return built[image], nil
+	}
+	buildCount := 0
+	d.ImageBuildFunc = func(ctx context.Context, options docker.ImageBuildOptions) (string, error) {
+		buildCount++
+		built[options.Tag] = true
+		return "image-id", nil
+	}
+
+	newWorkspace := func(content string) *Session {
+		dir := t.TempDir()
+		os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte(content), 0644)
+		return NewSession(d, &MockAgent{}, dir, "alpine", "test-project", "gemini", "gemini-pro", 1)
+	}
+
+	first := newWorkspace("FROM alpine")
+	second := newWorkspace("FROM alpine")
+	changed := newWorkspace("FROM ubuntu")
+	for _, s := range []*Session{first, second, changed} {
+		if err := s.ensureImage(context.Background()); err != nil {
+			t.Fatalf("ensureImage failed: %v", err)
+		}
+	}
+
+	if first.Image != second.Image {
+		t.Errorf("Expected identical Dockerfiles to share an image, got %s and %s", first.Image, second.Image)
+	}
+	if changed.Image == first.Image {
+		t.Errorf("Expected a changed Dockerfile to get a new image tag")
+	}
+	if buildCount != 2 {
+		t.Errorf("Expected 2 builds, got %d", buildCount)
+	}
+}
+
+func TestSession_EnsureImage_ExistingLegacyImage(t *testing.T) {
+	d := &MockDockerClient{}
+	d.ImageExistsFunc = func(ctx context.Context, image string) (bool, error) {
+		return image == "recac-agent:latest", nil
+	}
+	d.ImageBuildFunc = func(ctx context.Context, options docker.ImageBuildOptions) (string, error) {
+		t.Errorf("Expected no build when recac-agent:latest exists, got %s", options.Tag)
+		return "image-id", nil
+	}
+
+	session := NewSession(d, &MockAgent{}, t.TempDir(), "recac-agent:latest", "test-project", "gemini", "gemini-pro", 1)
+	if err := session.ensureImage(context.Background()); err != nil {
+		t.Fatalf("ensureImage failed: %v", err)
+	}
+	if session.Image != "recac-agent:latest" {
+		t.Errorf("Expected the existing image to be used, got %s", session.Image)
+	}
+}
+
+func TestSession_EnsureImage_DigestVerification(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	d := &MockDockerClient{}
+	d.ImageDigestsFunc = func(ctx context.Context, image string) ([]string, error) {
+		return []string{"ghcr.io/process-failed-successfully/recac-agent@" + digest, "sha256:imageid"}, nil
+	}
+
+	session := NewSession(d, &MockAgent{}, t.TempDir(), "ghcr.io/process-failed-successfully/recac-agent:latest", "test-project", "gemini", "gemini-pro", 1)
+	session.ImageDigest = digest
+	if err := session.ensureImage(context.Background()); err != nil {
+		t.Errorf("expected matching digest to pass, got: %v", err)
+	}
+
+	session.ImageDigest = "sha256:" + strings.Repeat("cd", 32)
+	if err := session.ensureImage(context.Background()); err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Errorf("expected digest mismatch error, got: %v", err)
+	}
+
+	// Digest pinned in the image reference is verified too
+	session.ImageDigest = ""
+	session.Image = "ghcr.io/process-failed-successfully/recac-agent@" + digest
+	if err := session.ensureImage(context.Background()); err != nil {
+		t.Errorf("expected pinned reference to pass, got: %v", err)
+	}
+}
+
+func TestSession_Start_DigestMismatchFails(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "app_spec.txt"), []byte("test"), 0644)
+
+	started := false
+	d := &MockDockerClient{}
+	d.ImageDigestsFunc = func(ctx context.Context, image string) ([]string, error) {
+		return []string{"ghcr.io/process-failed-successfully/recac-agent@sha256:" + strings.Repeat("ab", 32)}, nil
+	}
+	d.RunContainerFunc = func(ctx context.Context, image, workspace string, extraBinds, env []string, user string) (string, error) {
+		started = true
+		return "id", nil
+	}
+
+	session := NewSession(d, &MockAgent{}, tmpDir, "ghcr.io/process-failed-successfully/recac-agent:latest", "test-project", "gemini", "gemini-pro", 1)
+	session.ImageDigest = "sha256:" + strings.Repeat("cd", 32)
+
+	err := session.Start(context.Background())
+	if !errors.Is(err, ErrImageDigest) {
+		t.Fatalf("expected Start to fail on the digest mismatch, got: %v", err)
+	}
+	if started {
+		t.Error("expected no container to run with an unverified image")
+	}
+}
+
+func TestSession_Start_HostModeSkipsImage(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "app_spec.txt"), []byte("test"), 0644)
+
+	var calls []string
+	d := &MockDockerClient{}
+	d.ImageExistsFunc = func(ctx context.Context, image string) (bool, error) {
+		calls = append(calls, "ImageExists")
+		return false, nil
+	}
+	d.PullImageFunc = func(ctx context.Context, image string) error {
+		calls = append(calls, "PullImage")
+		return nil
+	}
+	d.ImageDigestsFunc = func(ctx context.Context, image string) ([]string, error) {
+		calls = append(calls, "ImageDigests")
+		return nil, nil
+	}
+	d.RunContainerFunc = func(ctx context.Context, image, workspace string, extraBinds, env []string, user string) (string, error) {
+		calls = append(calls, "RunContainer")
+		return "id", nil
+	}
+
+	session := NewSession(d, &MockAgent{}, tmpDir, "recac-agent:latest", "test-project", "gemini", "gemini-pro", 1)
+	session.UseLocalAgent = true
+	session.CheckpointInterval = time.Minute
+	if err := writeCheckpoint(tmpDir, Checkpoint{Image: CheckpointImage("test-project", tmpDir), ImageID: "sha256:abc"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := session.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if len(calls) > 0 {
+		t.Errorf("expected no image or container calls in host mode, got %v", calls)
+	}
+	if session.ContainerID != "local" || session.Image != "recac-agent:latest" {
+		t.Errorf("expected a local session on the configured image, got container %q image %q", session.ContainerID, session.Image)
+	}
+}
+
+func TestSession_RunContainer_CustomCommand(t *testing.T) {
+	var gotOpts *docker.ContainerOptions
+	d := &MockDockerClient{}
+	d.RunContainerWithOptionsFunc = func(ctx context.Context, image, workspace string, extraBinds, env []string, user string, opts docker.ContainerOptions) (string, error) {
+		gotOpts = &opts
+		return "custom-id", nil
+	}
+
+	session := NewSession(d, &MockAgent{}, t.TempDir(), "alpine", "test-project", "gemini", "gemini-pro", 1)
+
+	// Without overrides the plain RunContainer path is used
+	id, err := session.runContainer(context.Background(), nil, nil, "")
+	if err != nil || id != "mock-container-id" || gotOpts != nil {
+		t.Fatalf("expected default RunContainer, got id=%q err=%v opts=%v", id, err, gotOpts)
+	}
+
+	session.ContainerEntrypoint = []string{"/usr/bin/perf", "record", "--"}
+	session.ContainerCommand = []string{"/bin/bash"}
+	id, err = session.runContainer(context.Background(), nil, nil, "")
+	if err != nil || id != "custom-id" {
+		t.Fatalf("expected custom container, got id=%q err=%v", id, err)
+	}
+	if strings.Join(gotOpts.Entrypoint, " ") != "/usr/bin/perf record --" || strings.Join(gotOpts.Cmd, " ") != "/bin/bash" {
+		t.Errorf("unexpected container options: %+v", gotOpts)
+	}
+}
+
+func TestSession_RunContainer_Network(t *testing.T) {
+	var gotOpts *docker.ContainerOptions
+	d := &MockDockerClient{}
+	d.RunContainerWithOptionsFunc = func(ctx context.Context, image, workspace string, extraBinds, env []string, user string, opts docker.ContainerOptions) (string, error) {
+		gotOpts = &opts
+		return "isolated-id", nil
+	}
+
+	session := NewSession(d, &MockAgent{}, t.TempDir(), "alpine", "test-project", "gemini", "gemini-pro", 1)
+
+	// bridge is Docker's default and needs no options
+	session.ContainerNetwork = "bridge"
+	id, err := session.runContainer(context.Background(), nil, nil, "")
+	if err != nil || id != "mock-container-id" || gotOpts != nil {
+		t.Fatalf("expected default RunContainer, got id=%q err=%v opts=%v", id, err, gotOpts)
+	}
+
+	session.ContainerNetwork = "none"
+	id, err = session.runContainer(context.Background(), nil, nil, "")
+	if err != nil || id != "isolated-id" {
+		t.Fatalf("expected isolated container, got id=%q err=%v", id, err)
+	}
+	if gotOpts.Network != "none" || len(gotOpts.Cmd) != 0 {
+		t.Errorf("unexpected container options: %+v", gotOpts)
+	}
+}
+
+func TestSession_RunContainer_Proxy(t *testing.T) {
+	var gotOpts *docker.ContainerOptions
+	d := &MockDockerClient{}
+	d.RunContainerWithOptionsFunc = func(ctx context.Context, image, workspace string, extraBinds, env []string, user string, opts docker.ContainerOptions) (string, error) {
+		gotOpts = &opts
+		return "proxied-id", nil
+	}
+
+	session := NewSession(d, &MockAgent{}, t.TempDir(), "alpine", "test-project", "gemini", "gemini-pro", 1)
+	session.ContainerProxy = docker.ProxyConfig{HTTP: "http://egress:3128"}
+	id, err := session.runContainer(context.Background(), nil, nil, "")
+	if err != nil || id != "proxied-id" {
+		t.Fatalf("expected proxied container, got id=%q err=%v", id, err)
+	}
+	if !strings.Contains(strings.Join(gotOpts.Env, " "), "HTTPS_PROXY=http://egress:3128") {
+		t.Errorf("expected proxy env, got %v", gotOpts.Env)
+	}
+
+	// A malformed proxy stops the session instead of running unproxied
+	session.ContainerProxy = docker.ProxyConfig{HTTP: "egress"}
+	if _, err := session.runContainer(context.Background(), nil, nil, ""); err == nil {
+		t.Error("expected an error for an invalid proxy URL")
+	}
+}
+
 func TestSession_RunLoop_QAPassed(t *testing.T) {
 	tmpDir := t.TempDir()
 	d := &MockDockerClient{}
@@ -872,3 +1095,19 @@ func TestSession_RunLoop_QAPassed(t *testing.T) {
 		t.Errorf("Expected PROJECT_SIGNED_OFF signal to be true, got %s (err: %v)", val, err)
 	}
 }
+
+func TestSession_SpecSummary(t *testing.T) {
+	tmpDir := t.TempDir()
+	session := &Session{Workspace: tmpDir, SpecFile: "app_spec.txt"}
+
+	session.SpecContent = "# Jira Ticket: RECAC-1\n# Summary: Add login page\n\nDetails here"
+	if got := session.specSummary(); got != "Add login page" {
+		t.Errorf("Expected 'Add login page', got '%s'", got)
+	}
+
+	session.SpecContent = ""
+	os.WriteFile(filepath.Join(tmpDir, "app_spec.txt"), []byte("\nBuild a calculator\nMore text"), 0644)
+	if got := session.specSummary(); got != "Build a calculator" {
+		t.Errorf("Expected 'Build a calculator', got '%s'", got)
+	}
+}
@@ -29,11 +29,13 @@ func (s *Session) hasSignal(name string) bool {
 		// Found file-based signal.
 		// Security Check: Only migrate non-privileged signals from filesystem
 		privilegedSignals := map[string]bool{
-			"PROJECT_SIGNED_OFF": true,
-			"QA_PASSED":          true,
-			"COMPLETED":          true,
-			"TRIGGER_QA":         true,
-			"TRIGGER_MANAGER":    true,
+			"PROJECT_SIGNED_OFF":    true,
+			"QA_PASSED":             true,
+			"COMPLETED":             true,
+			"TRIGGER_QA":            true,
+			"TRIGGER_MANAGER":       true,
+			"PENDING_HUMAN_SIGNOFF": true,
+			"PENDING_COMMAND":       true,
 		}
 
 		if privilegedSignals[name] {
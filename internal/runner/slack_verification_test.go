@@ -2,8 +2,12 @@ package runner
 
 import (
 	"context"
+	"fmt"
 	"recac/internal/telemetry"
+	"strings"
 	"testing"
+
+	"github.com/spf13/viper"
 )
 
 // SpyNotifier captures notification calls for verification
@@ -92,3 +96,53 @@ func TestCompleteJiraTicket_AddsCheckmark(t *testing.T) {
 		t.Error("Expected completion notification message, but none found")
 	}
 }
+
+// MockGitHubIssueClient records issue updates
+type MockGitHubIssueClient struct {
+	Comments []string
+	Closed   bool
+}
+
+func (m *MockGitHubIssueClient) AddComment(ctx context.Context, number int, body string) error {
+	m.Comments = append(m.Comments, body)
+	return nil
+}
+
+func (m *MockGitHubIssueClient) CloseIssue(ctx context.Context, number int) error {
+	m.Closed = true
+	return nil
+}
+
+func (m *MockGitHubIssueClient) IssueURL(number int) string {
+	return fmt.Sprintf("https://github.com/example/repo/issues/%d", number)
+}
+
+func TestCompleteTicket_GitHubIssue(t *testing.T) {
+	for _, closeIssues := range []bool{false, true} {
+		viper.Set("github.close_issues", closeIssues)
+
+		spy := &SpyNotifier{}
+		gh := &MockGitHubIssueClient{}
+		session := &Session{
+			Project:      "gh-7",
+			Notifier:     spy,
+			JiraTicketID: "gh-7",
+			GitHubClient: gh,
+			GitHubIssue:  7,
+			Logger:       telemetry.NewLogger(true, "", false),
+		}
+
+		session.completeTicket(context.Background(), "https://github.com/example/repo/tree/agent/gh-7")
+
+		if len(gh.Comments) != 1 || !strings.Contains(gh.Comments[0], "https://github.com/example/repo/tree/agent/gh-7") {
+			t.Errorf("Expected one comment with the git link, got %v", gh.Comments)
+		}
+		if gh.Closed != closeIssues {
+			t.Errorf("Expected closed=%v, got %v", closeIssues, gh.Closed)
+		}
+		if len(spy.Messages) != 1 || !strings.Contains(spy.Messages[0].Message, "https://github.com/example/repo/issues/7") {
+			t.Errorf("Expected completion notification with issue link, got %v", spy.Messages)
+		}
+	}
+	viper.Set("github.close_issues", false)
+}
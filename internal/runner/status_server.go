@@ -0,0 +1,173 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"net"
+	"net/http"
+	"time"
+
+	"recac/internal/db"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// statusSignals are the signals shown on the status page, in display order.
+var statusSignals = []string{
+	"TRIGGER_QA",
+	"QA_PASSED",
+	"TRIGGER_MANAGER",
+	HumanSignoffSignal,
+	"PROJECT_SIGNED_OFF",
+	"COMPLETED",
+	"CLEANUP_REQUIRED",
+	"STALLED_WARNING",
+	"BLOCKER",
+}
+
+// maxStatusObservationLen caps the last observation shown on the status page.
+const maxStatusObservationLen = 4000
+
+// SessionStatus is a point-in-time view of a running session.
+type SessionStatus struct {
+	Project         string            `json:"project"`
+	Iteration       int               `json:"iteration"`
+	MaxIterations   int               `json:"max_iterations"`
+	Role            string            `json:"role"`
+	PassingFeatures int               `json:"passing_features"`
+	TotalFeatures   int               `json:"total_features"`
+	LastObservation *db.Observation   `json:"last_observation,omitempty"`
+	Signals         map[string]string `json:"signals"`
+	Timestamp       time.Time         `json:"timestamp"`
+}
+
+// Status reads the session's current state from the session and its DB store.
+func (s *Session) Status() SessionStatus {
+	status := SessionStatus{
+		Project:       s.Project,
+		Iteration:     s.GetIteration(),
+		MaxIterations: s.MaxIterations,
+		Role:          s.getRole(),
+		Signals:       make(map[string]string),
+		Timestamp:     time.Now().UTC(),
+	}
+	if s.DBStore == nil {
+		return status
+	}
+
+	if content, err := s.DBStore.GetFeatures(s.Project); err == nil && content != "" {
+		var fl db.FeatureList
+		if err := json.Unmarshal([]byte(content), &fl); err == nil {
+			status.TotalFeatures = len(fl.Features)
+			for _, f := range fl.Features {
+				if f.Passes {
+					status.PassingFeatures++
+				}
+			}
+		}
+	}
+
+	if history, err := s.DBStore.QueryHistory(s.Project, 1); err == nil && len(history) > 0 {
+		obs := history[0]
+		if len(obs.Content) > maxStatusObservationLen {
+			obs.Content = obs.Content[:maxStatusObservationLen] + "\n... (truncated)"
+		}
+		status.LastObservation = &obs
+	}
+
+	for _, name := range statusSignals {
+		if val, err := s.DBStore.GetSignal(s.Project, name); err == nil && val != "" {
+			status.Signals[name] = val
+		}
+	}
+	return status
+}
+
+var statusPage = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="5">
+<title>recac: {{.Project}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+td { padding: 0.2em 1em 0.2em 0; vertical-align: top; }
+pre { background: #f4f4f4; padding: 1em; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>{{.Project}}</h1>
+<table>
+<tr><td>Iteration</td><td>{{.Iteration}}{{if .MaxIterations}} / {{.MaxIterations}}{{end}}</td></tr>
+<tr><td>Role</td><td>{{if .Role}}{{.Role}}{{else}}-{{end}}</td></tr>
+<tr><td>Features</td><td>{{.PassingFeatures}} / {{.TotalFeatures}} passing</td></tr>
+<tr><td>Signals</td><td>{{range $name, $value := .Signals}}{{$name}}={{$value}}<br>{{else}}none{{end}}</td></tr>
+<tr><td>Updated</td><td>{{.Timestamp.Format "2006-01-02 15:04:05 MST"}}</td></tr>
+</table>
+{{with .LastObservation}}
+<h2>Last observation ({{.AgentID}}, {{.CreatedAt.Format "15:04:05"}})</h2>
+<pre>{{.Content}}</pre>
+{{end}}
+<p><a href="/status.json">JSON</a></p>
+</body>
+</html>
+`))
+
+// StatusHandler serves the status page at /, its JSON form at /status.json
+// and the process's Prometheus metrics at /metrics.
+func (s *Session) StatusHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/status.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.Status())
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := statusPage.Execute(w, s.Status()); err != nil {
+			s.Logger.Warn("failed to render status page", "error", err)
+		}
+	})
+	return mux
+}
+
+// statusListenAddr binds an address without a host (e.g. ":8090") to the
+// loopback interface, since the page exposes session details without auth.
+// An explicit host such as 0.0.0.0 is kept.
+func statusListenAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host != "" {
+		return addr
+	}
+	return net.JoinHostPort("127.0.0.1", port)
+}
+
+// serveStatus serves the status page on s.StatusAddr until ctx is done.
+// The page is optional, so a bad address is logged rather than failing the session.
+func (s *Session) serveStatus(ctx context.Context) {
+	addr := statusListenAddr(s.StatusAddr)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		s.Logger.Warn("failed to start status page", "addr", addr, "error", err)
+		return
+	}
+	server := &http.Server{Handler: s.StatusHandler(), ReadHeaderTimeout: 10 * time.Second}
+	s.Logger.Info("serving session status", "url", "http://"+listener.Addr().String())
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			s.Logger.Warn("status page stopped", "error", err)
+		}
+	}()
+}
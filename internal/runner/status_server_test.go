@@ -0,0 +1,85 @@
+package runner
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"recac/internal/db"
+)
+
+func TestSessionStatusHandler(t *testing.T) {
+	tmpDir := t.TempDir()
+	session := NewSession(nil, &MockAgent{}, tmpDir, "alpine", "test-project", "gemini", "gemini-pro", 1)
+	if session.DBStore == nil {
+		t.Fatal("DBStore not initialized")
+	}
+	session.MaxIterations = 10
+	session.IncrementIteration()
+	session.IncrementIteration()
+	session.setRole("qa")
+
+	fl := db.FeatureList{Features: []db.Feature{
+		{ID: "1", Description: "login", Passes: true},
+		{ID: "2", Description: "logout"},
+		{ID: "3", Description: "signup", Passes: true},
+	}}
+	data, _ := json.Marshal(fl)
+	session.DBStore.SaveFeatures(session.Project, string(data))
+	session.DBStore.SaveObservation(session.Project, "Agent", "ran <tests>")
+	session.createSignal("TRIGGER_QA")
+
+	server := httptest.NewServer(session.StatusHandler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/status.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var status SessionStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Iteration != 2 || status.MaxIterations != 10 || status.Role != "qa" {
+		t.Errorf("unexpected progress: %+v", status)
+	}
+	if status.PassingFeatures != 2 || status.TotalFeatures != 3 {
+		t.Errorf("expected 2/3 features passing, got %d/%d", status.PassingFeatures, status.TotalFeatures)
+	}
+	if status.LastObservation == nil || status.LastObservation.Content != "ran <tests>" {
+		t.Errorf("unexpected last observation: %+v", status.LastObservation)
+	}
+	if status.Signals["TRIGGER_QA"] != "true" || len(status.Signals) != 1 {
+		t.Errorf("unexpected signals: %v", status.Signals)
+	}
+
+	resp, err = http.Get(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	page := string(body)
+	for _, want := range []string{"2 / 10", "2 / 3 passing", "TRIGGER_QA=true", "ran &lt;tests&gt;"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("status page missing %q:\n%s", want, page)
+		}
+	}
+}
+
+func TestStatusListenAddr(t *testing.T) {
+	for addr, want := range map[string]string{
+		":8090":          "127.0.0.1:8090",
+		"0.0.0.0:8090":   "0.0.0.0:8090",
+		"localhost:8090": "localhost:8090",
+		"bad":            "bad",
+	} {
+		if got := statusListenAddr(addr); got != want {
+			t.Errorf("statusListenAddr(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateSelectedTask checks that SelectedTaskID names a feature in the
+// project's feature list, so a mistyped --select-task fails at startup
+// instead of leaving the session to loop on a task that does not exist.
+// Sessions without a selected task always pass.
+func (s *Session) ValidateSelectedTask() error {
+	if s.SelectedTaskID == "" {
+		return nil
+	}
+	features := s.loadFeatures()
+	ids := make([]string, 0, len(features))
+	for _, f := range features {
+		if f.ID == s.SelectedTaskID {
+			return nil
+		}
+		ids = append(ids, f.ID)
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("unknown task %q: project %s has no features", s.SelectedTaskID, s.Project)
+	}
+	return fmt.Errorf("unknown task %q (available: %s)", s.SelectedTaskID, strings.Join(ids, ", "))
+}
@@ -0,0 +1,31 @@
+package runner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"recac/internal/db"
+	"recac/internal/telemetry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSelectedTask(t *testing.T) {
+	workspace := t.TempDir()
+	store, err := db.NewSQLiteStore(filepath.Join(workspace, ".recac.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	s := &Session{Workspace: workspace, Project: "proj", DBStore: store, Logger: telemetry.NewLogger(true, "", false)}
+	assert.NoError(t, s.ValidateSelectedTask(), "sessions without a selected task pass")
+
+	s.SelectedTaskID = "F1"
+	assert.EqualError(t, s.ValidateSelectedTask(), `unknown task "F1": project proj has no features`)
+
+	require.NoError(t, store.SaveFeatures("proj", `{"project_name": "proj", "features": [{"id": "F1", "status": "todo"}, {"id": "F2", "status": "done"}]}`))
+	assert.NoError(t, s.ValidateSelectedTask())
+
+	s.SelectedTaskID = "F3"
+	assert.EqualError(t, s.ValidateSelectedTask(), `unknown task "F3" (available: F1, F2)`)
+}
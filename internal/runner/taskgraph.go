@@ -77,10 +77,7 @@ func (g *TaskGraph) LoadFromFeatures(features []db.Feature) error {
 			taskID = fmt.Sprintf("task-%s", feature.Category)
 		}
 
-		deps := feature.Dependencies.DependsOnIDs
-		if deps == nil {
-			deps = []string{}
-		}
+		deps := feature.DependencyIDs()
 
 		// Map generic status to TaskStatus
 		var newStatus TaskStatus
@@ -1,6 +1,7 @@
 package runner
 
 import (
+	"encoding/json"
 	"recac/internal/db"
 	"reflect"
 	"testing"
@@ -237,3 +238,39 @@ func TestTaskGraph_LoadFromFeatures(t *testing.T) {
 		t.Errorf("Expected dependency on feat-1, got %v", taskB.Dependencies)
 	}
 }
+
+func TestTaskGraph_LoadFromFeatures_DependsOnShorthand(t *testing.T) {
+	content := `{"features": [
+		{"id": "schema", "status": "pending"},
+		{"id": "api", "status": "pending", "depends_on": ["schema"]},
+		{"id": "ui", "status": "pending", "depends_on": ["api"], "dependencies": {"depends_on_ids": ["schema", "api"]}}
+	]}`
+	var fl db.FeatureList
+	if err := json.Unmarshal([]byte(content), &fl); err != nil {
+		t.Fatal(err)
+	}
+
+	tg := NewTaskGraph()
+	if err := tg.LoadFromFeatures(fl.Features); err != nil {
+		t.Fatalf("LoadFromFeatures failed: %v", err)
+	}
+
+	ui, _ := tg.GetTask("ui")
+	if !reflect.DeepEqual(ui.Dependencies, []string{"schema", "api"}) {
+		t.Errorf("Expected merged dependencies [schema api], got %v", ui.Dependencies)
+	}
+
+	// Only the feature without dependencies may be assigned first
+	if ready := tg.GetReadyTasks(); !reflect.DeepEqual(ready, []string{"schema"}) {
+		t.Errorf("Expected only schema ready, got %v", ready)
+	}
+
+	// Once schema passes, api is unblocked but ui still waits on it
+	fl.Features[0].Passes = true
+	if err := tg.LoadFromFeatures(fl.Features); err != nil {
+		t.Fatalf("LoadFromFeatures failed: %v", err)
+	}
+	if ready := tg.GetReadyTasks(); !reflect.DeepEqual(ready, []string{"api"}) {
+		t.Errorf("Expected only api ready, got %v", ready)
+	}
+}
@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"recac/internal/agent"
+	"recac/internal/telemetry"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ToolCallingAgent answers every prompt with a fixed tool-enabled response.
+type ToolCallingAgent struct {
+	Response  agent.ToolResponse
+	ToolCalls int
+	Sends     int
+}
+
+func (a *ToolCallingAgent) Send(ctx context.Context, prompt string) (string, error) {
+	a.Sends++
+	return a.Response.Text, nil
+}
+
+func (a *ToolCallingAgent) SendStream(ctx context.Context, prompt string, onChunk func(string)) (string, error) {
+	return a.Send(ctx, prompt)
+}
+
+func (a *ToolCallingAgent) SendWithTools(ctx context.Context, prompt string, tools []agent.Tool) (agent.ToolResponse, error) {
+	a.ToolCalls++
+	return a.Response, nil
+}
+
+func TestRunIteration_PrefersToolCalls(t *testing.T) {
+	viper.Set("tool_calling", true)
+	defer viper.Set("tool_calling", nil)
+
+	workspace := t.TempDir()
+	ag := &ToolCallingAgent{Response: agent.ToolResponse{
+		Text: "For example:\n```bash\ntouch from_text.txt\n```",
+		ToolCalls: []agent.ToolCall{
+			{Name: agent.RunShellToolName, Arguments: map[string]interface{}{"command": "touch from_tool.txt"}},
+		},
+	}}
+	s := &Session{Workspace: workspace, Agent: ag, UseLocalAgent: true, Logger: telemetry.NewLogger(true, "", false)}
+
+	_, err := s.RunIteration(context.Background(), "prompt", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, ag.ToolCalls)
+	assert.Equal(t, 0, ag.Sends)
+	assert.FileExists(t, filepath.Join(workspace, "from_tool.txt"))
+	// Fenced blocks are only examples once the model uses tool calls
+	assert.NoFileExists(t, filepath.Join(workspace, "from_text.txt"))
+}
+
+func TestRunIteration_ToolCallingFallsBackToBashBlocks(t *testing.T) {
+	workspace := t.TempDir()
+	ag := &ToolCallingAgent{Response: agent.ToolResponse{Text: "```bash\ntouch from_text.txt\n```"}}
+	s := &Session{Workspace: workspace, Agent: ag, UseLocalAgent: true, Logger: telemetry.NewLogger(true, "", false)}
+
+	t.Run("no tool calls", func(t *testing.T) {
+		viper.Set("tool_calling", true)
+		defer viper.Set("tool_calling", nil)
+
+		_, err := s.RunIteration(context.Background(), "prompt", false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, ag.ToolCalls)
+		assert.FileExists(t, filepath.Join(workspace, "from_text.txt"))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(workspace, "from_text.txt")))
+		viper.Set("tool_calling", false)
+		defer viper.Set("tool_calling", nil)
+
+		_, err := s.RunIteration(context.Background(), "prompt", false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, ag.ToolCalls)
+		assert.Equal(t, 1, ag.Sends)
+		assert.FileExists(t, filepath.Join(workspace, "from_text.txt"))
+	})
+}
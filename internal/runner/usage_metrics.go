@@ -0,0 +1,39 @@
+package runner
+
+import (
+	"recac/internal/agent"
+	"recac/internal/telemetry"
+)
+
+// reportUsageMetrics publishes the session's token usage and estimated cost,
+// read from the agent state, to Prometheus.
+func (s *Session) reportUsageMetrics() {
+	if s.StateManager == nil {
+		return
+	}
+	state, err := s.StateManager.Load()
+	if err != nil {
+		return
+	}
+
+	provider, model := state.Provider, state.Model
+	if provider == "" {
+		provider = s.AgentProvider
+	}
+	if model == "" {
+		model = s.AgentModel
+	}
+
+	usage := state.TokenUsage
+	telemetry.SetSessionTokens(s.Project, provider, model, "prompt", usage.TotalPromptTokens)
+	telemetry.SetSessionTokens(s.Project, provider, model, "response", usage.TotalResponseTokens)
+	telemetry.SetSessionTokens(s.Project, provider, model, "cache_read", usage.CacheReadTokens)
+	telemetry.SetSessionTokens(s.Project, provider, model, "cache_creation", usage.CacheCreationTokens)
+
+	cost := agent.CalculateProviderCost(provider, model, usage)
+	telemetry.SetSessionCost(s.Project, provider, model, cost)
+	if cost > s.reportedCost {
+		telemetry.TrackAgentCost(s.Project, provider, model, cost-s.reportedCost)
+		s.reportedCost = cost
+	}
+}
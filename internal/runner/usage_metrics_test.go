@@ -0,0 +1,69 @@
+package runner
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"recac/internal/agent"
+	"recac/internal/telemetry"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSession_ReportUsageMetrics(t *testing.T) {
+	session := NewSession(&MockDockerClient{}, &MockAgent{}, t.TempDir(), "alpine", "usage-project", "openai", "gpt-4o", 1)
+	usage := agent.TokenUsage{TotalPromptTokens: 1000, TotalResponseTokens: 500, TotalTokens: 1500}
+	if err := session.StateManager.Save(agent.State{TokenUsage: usage}); err != nil {
+		t.Fatal(err)
+	}
+
+	session.reportUsageMetrics()
+
+	if got := testutil.ToFloat64(telemetry.SessionTokens.WithLabelValues("usage-project", "openai", "gpt-4o", "prompt")); got != 1000 {
+		t.Errorf("expected 1000 prompt tokens, got %v", got)
+	}
+	if got := testutil.ToFloat64(telemetry.SessionTokens.WithLabelValues("usage-project", "openai", "gpt-4o", "response")); got != 500 {
+		t.Errorf("expected 500 response tokens, got %v", got)
+	}
+	cost := agent.CalculateProviderCost("openai", "gpt-4o", usage)
+	if got := testutil.ToFloat64(telemetry.SessionCostDollars.WithLabelValues("usage-project", "openai", "gpt-4o")); got != cost || cost <= 0 {
+		t.Errorf("expected session cost %v, got %v", cost, got)
+	}
+
+	// The counter only grows by the cost added since the last report
+	usage.TotalPromptTokens *= 2
+	if err := session.StateManager.Save(agent.State{TokenUsage: usage}); err != nil {
+		t.Fatal(err)
+	}
+	session.reportUsageMetrics()
+	session.reportUsageMetrics()
+
+	total := agent.CalculateProviderCost("openai", "gpt-4o", usage)
+	if got := testutil.ToFloat64(telemetry.AgentCostDollarsTotal.WithLabelValues("usage-project", "openai", "gpt-4o")); got < total-1e-9 || got > total+1e-9 {
+		t.Errorf("expected cost counter %v, got %v", total, got)
+	}
+}
+
+func TestSessionStatusHandler_Metrics(t *testing.T) {
+	session := NewSession(nil, &MockAgent{}, t.TempDir(), "alpine", "metrics-project", "openai", "gpt-4o", 1)
+	if err := session.StateManager.Save(agent.State{TokenUsage: agent.TokenUsage{TotalPromptTokens: 10, TotalTokens: 10}}); err != nil {
+		t.Fatal(err)
+	}
+	session.reportUsageMetrics()
+
+	server := httptest.NewServer(session.StatusHandler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), `recac_session_tokens{model="gpt-4o",project="metrics-project",provider="openai",type="prompt"} 10`) {
+		t.Errorf("expected session token gauge in /metrics, got:\n%s", body)
+	}
+}
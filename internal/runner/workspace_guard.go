@@ -0,0 +1,93 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"recac/internal/notify"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/viper"
+)
+
+// workspaceSizeCheckInterval is the number of iterations between workspace size checks.
+// Walking a workspace with node_modules is not free, so we don't do it every iteration.
+const workspaceSizeCheckInterval = 5
+
+// ErrWorkspaceTooLarge is returned when the workspace grows beyond max_workspace_size.
+var ErrWorkspaceTooLarge = errors.New("workspace exceeds maximum size")
+
+// maxWorkspaceSize returns the configured workspace size limit in bytes (0 = unlimited).
+func maxWorkspaceSize(cfg *viper.Viper) (uint64, error) {
+	raw := strings.TrimSpace(cfg.GetString("max_workspace_size"))
+	if raw == "" || raw == "0" {
+		return 0, nil
+	}
+	limit, err := humanize.ParseBytes(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid max_workspace_size %q: %w", raw, err)
+	}
+	return limit, nil
+}
+
+// dirSize returns the total size of the regular files under root.
+// Unreadable entries are skipped rather than failing the whole walk.
+func dirSize(root string) (uint64, error) {
+	var total uint64
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			total += uint64(info.Size())
+		}
+		return nil
+	})
+	return total, err
+}
+
+// checkWorkspaceSize blocks the session if the workspace has grown beyond
+// max_workspace_size. It only measures every workspaceSizeCheckInterval iterations.
+func (s *Session) checkWorkspaceSize(ctx context.Context) error {
+	limit, err := maxWorkspaceSize(s.config())
+	if err != nil {
+		s.Logger.Warn("workspace size guard disabled", "error", err)
+		return nil
+	}
+	if limit == 0 || s.Workspace == "" || s.GetIteration()%workspaceSizeCheckInterval != 0 {
+		return nil
+	}
+
+	size, err := dirSize(s.Workspace)
+	if err != nil {
+		s.Logger.Warn("failed to measure workspace size", "workspace", s.Workspace, "error", err)
+		return nil
+	}
+	if size <= limit {
+		return nil
+	}
+
+	message := fmt.Sprintf("Workspace is %s, over the %s limit (max_workspace_size). Remove large artifacts (e.g. node_modules, build output) or raise the limit, then resolve this blocker.",
+		humanize.Bytes(size), humanize.Bytes(limit))
+	s.Logger.Error("workspace size limit exceeded", "size", size, "limit", limit)
+
+	if s.DBStore != nil {
+		if err := s.DBStore.SetSignal(s.Project, "BLOCKER", message); err != nil {
+			s.Logger.Warn("failed to set blocker signal", "error", err)
+		}
+	}
+	s.emitEvent(EventBlocker, map[string]interface{}{"source": "workspace_size", "message": message})
+	s.forceNotify(ctx, notify.EventFailure, fmt.Sprintf("Project %s Blocked: %s", s.Project, message))
+
+	return fmt.Errorf("%w: %s", ErrWorkspaceTooLarge, message)
+}
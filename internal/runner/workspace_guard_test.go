@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"recac/internal/db"
+
+	"github.com/spf13/viper"
+)
+
+func TestSession_CheckWorkspaceSize(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	workspace := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(workspace, "node_modules"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workspace, "node_modules", "blob"), make([]byte, 4096), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := db.NewSQLiteStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	s := &Session{
+		Workspace: workspace,
+		Project:   "test-project",
+		DBStore:   store,
+		Logger:    slog.Default(),
+		Notifier:  &MockNotifier{},
+	}
+
+	// Unlimited by default
+	s.IncrementIteration()
+	for s.GetIteration()%workspaceSizeCheckInterval != 0 {
+		s.IncrementIteration()
+	}
+	if err := s.checkWorkspaceSize(context.Background()); err != nil {
+		t.Fatalf("Expected no limit by default, got %v", err)
+	}
+
+	viper.Set("max_workspace_size", "1MB")
+	if err := s.checkWorkspaceSize(context.Background()); err != nil {
+		t.Fatalf("Expected workspace under limit, got %v", err)
+	}
+
+	viper.Set("max_workspace_size", "1KB")
+	err = s.checkWorkspaceSize(context.Background())
+	if !errors.Is(err, ErrWorkspaceTooLarge) {
+		t.Fatalf("Expected ErrWorkspaceTooLarge, got %v", err)
+	}
+	blocker, _ := store.GetSignal("test-project", "BLOCKER")
+	if !strings.Contains(blocker, "max_workspace_size") {
+		t.Errorf("Expected blocker signal explaining the limit, got %q", blocker)
+	}
+
+	// Only measured every workspaceSizeCheckInterval iterations
+	s.IncrementIteration()
+	if err := s.checkWorkspaceSize(context.Background()); err != nil {
+		t.Errorf("Expected no check between intervals, got %v", err)
+	}
+}
+
+func TestMaxWorkspaceSize(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	viper.Set("max_workspace_size", "10GB")
+	if got, err := maxWorkspaceSize(viper.GetViper()); err != nil || got != 10_000_000_000 {
+		t.Errorf("Expected 10GB, got %d (%v)", got, err)
+	}
+
+	viper.Set("max_workspace_size", "lots")
+	if _, err := maxWorkspaceSize(viper.GetViper()); err == nil {
+		t.Error("Expected error for invalid size")
+	}
+}
@@ -0,0 +1,108 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// worktreeDir holds per-task worktrees inside the workspace's .git directory,
+// which keeps them out of the shared working tree while staying reachable
+// through the same mount.
+const worktreeDir = "recac-worktrees"
+
+var unsafeRefChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// worktreeBranch returns the branch a task's worktree works on.
+func worktreeBranch(taskID string) string {
+	return "recac-task/" + unsafeRefChars.ReplaceAllString(taskID, "-")
+}
+
+// taskWorktree returns the worktree for taskID, creating it from the
+// workspace's HEAD on first use. Retries of a task reuse its worktree.
+func (o *Orchestrator) taskWorktree(taskID string) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if path, ok := o.worktrees[taskID]; ok {
+		return path, nil
+	}
+
+	path := filepath.Join(o.Workspace, ".git", worktreeDir, unsafeRefChars.ReplaceAllString(taskID, "-"))
+	if _, err := os.Stat(path); err != nil {
+		if err := o.Git.AddWorktree(o.Workspace, path, worktreeBranch(taskID)); err != nil {
+			return "", err
+		}
+	}
+
+	if o.worktrees == nil {
+		o.worktrees = make(map[string]string)
+	}
+	o.worktrees[taskID] = path
+	return path, nil
+}
+
+// useWorktree points a task session at the shared repository and database
+// from inside its worktree.
+func (o *Orchestrator) useWorktree(session *Session) {
+	// The worktree's .git file refers to the workspace's .git by absolute path
+	session.ExtraBinds = append(session.ExtraBinds, fmt.Sprintf("%s:%s", o.Workspace, o.Workspace))
+
+	// agent-bridge opens .recac.db in its working directory unless told otherwise
+	dbType := os.Getenv("RECAC_DB_TYPE")
+	if (dbType == "" || dbType == "sqlite") && os.Getenv("RECAC_DB_URL") == "" {
+		session.ExtraEnv = append(session.ExtraEnv, "RECAC_DB_URL="+filepath.Join(o.Workspace, ".recac.db"))
+	}
+}
+
+// mergeWorktrees runs at the sprint barrier. It merges the branches of
+// finished tasks into the workspace and removes every worktree. Branches of
+// unfinished tasks are kept so a later sprint resumes their work. A task whose
+// branch conflicts is marked failed and its branch dropped, so it is redone
+// on top of the merged code.
+func (o *Orchestrator) mergeWorktrees() {
+	o.mu.Lock()
+	worktrees := o.worktrees
+	o.worktrees = nil
+	o.mu.Unlock()
+
+	taskIDs := make([]string, 0, len(worktrees))
+	for taskID := range worktrees {
+		taskIDs = append(taskIDs, taskID)
+	}
+	sort.Strings(taskIDs)
+
+	for _, taskID := range taskIDs {
+		path := worktrees[taskID]
+		branch := worktreeBranch(taskID)
+
+		// Commit whatever the agent left uncommitted (fails harmlessly when clean)
+		_ = o.Git.Commit(path, fmt.Sprintf("chore: final changes for task %s", taskID))
+		if err := o.Git.RemoveWorktree(o.Workspace, path); err != nil {
+			fmt.Printf("Warning: Failed to remove worktree %s: %v\n", path, err)
+		}
+
+		if status, _ := o.Graph.GetTaskStatus(taskID); status != TaskDone {
+			fmt.Printf("Task %s did not finish; keeping branch %s.\n", taskID, branch)
+			continue
+		}
+
+		if _, err := o.Git.Run(o.Workspace, "merge", "--no-edit", branch); err != nil {
+			fmt.Printf("Warning: Merging task %s (%s) conflicted; marking it failed: %v\n", taskID, branch, err)
+			_ = o.Git.AbortMerge(o.Workspace)
+			_ = o.Git.DeleteLocalBranch(o.Workspace, branch)
+			o.Graph.MarkTaskStatus(taskID, TaskFailed, fmt.Errorf("merge conflict"))
+			if dbErr := o.DB.UpdateFeatureStatus(o.Project, taskID, "failed", false); dbErr != nil {
+				fmt.Printf("Warning: Failed to update DB status for task %s: %v\n", taskID, dbErr)
+			}
+			continue
+		}
+
+		fmt.Printf("Merged task %s from %s.\n", taskID, branch)
+		if err := o.Git.DeleteLocalBranch(o.Workspace, branch); err != nil {
+			fmt.Printf("Warning: Failed to delete branch %s: %v\n", branch, err)
+		}
+	}
+}
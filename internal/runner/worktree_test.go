@@ -0,0 +1,119 @@
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"recac/internal/agent"
+	"recac/internal/db"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWorktreeTestOrchestrator(t *testing.T) *Orchestrator {
+	t.Helper()
+	t.Setenv("GIT_AUTHOR_NAME", "RECAC Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "RECAC Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	workspace := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "shared.txt"), []byte("base\n"), 0644))
+	for _, args := range [][]string{{"init", "-b", "main"}, {"add", "."}, {"commit", "-m", "initial"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = workspace
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	o := NewOrchestrator(&MockDBStore{}, &MockDockerClient{}, workspace, "img", &agent.MockAgent{}, "proj", "gemini", "gemini-pro", 2, "")
+	o.IsolateWorktrees = true
+	return o
+}
+
+func TestOrchestrator_TaskWorktree(t *testing.T) {
+	o := newWorktreeTestOrchestrator(t)
+
+	path, err := o.taskWorktree("feat 1")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(o.Workspace, ".git", worktreeDir, "feat-1"), path)
+	assert.FileExists(t, filepath.Join(path, "shared.txt"))
+
+	branch, err := o.Git.CurrentBranch(path)
+	require.NoError(t, err)
+	assert.Equal(t, "recac-task/feat-1", branch)
+
+	// Retries reuse the same worktree
+	samePath, err := o.taskWorktree("feat 1")
+	require.NoError(t, err)
+	assert.Equal(t, path, samePath)
+
+	// The shared working tree does not see the worktree
+	status, err := o.Git.Run(o.Workspace, "status", "--porcelain")
+	require.NoError(t, err)
+	assert.Empty(t, status)
+}
+
+func TestOrchestrator_UseWorktree(t *testing.T) {
+	t.Setenv("RECAC_DB_TYPE", "")
+	t.Setenv("RECAC_DB_URL", "")
+	o := &Orchestrator{Workspace: "/projects/app"}
+	session := &Session{}
+
+	o.useWorktree(session)
+	assert.Equal(t, []string{"/projects/app:/projects/app"}, session.ExtraBinds)
+	assert.Equal(t, []string{"RECAC_DB_URL=/projects/app/.recac.db"}, session.ExtraEnv)
+
+	t.Setenv("RECAC_DB_TYPE", "postgres")
+	session = &Session{}
+	o.useWorktree(session)
+	assert.Empty(t, session.ExtraEnv)
+}
+
+func TestOrchestrator_MergeWorktrees(t *testing.T) {
+	o := newWorktreeTestOrchestrator(t)
+	require.NoError(t, o.Graph.LoadFromFeatures([]db.Feature{
+		{ID: "done", Passes: true},
+		{ID: "pending", Status: "pending"},
+		{ID: "conflict", Passes: true},
+	}))
+
+	done, err := o.taskWorktree("done")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(done, "done.txt"), []byte("done\n"), 0644))
+
+	pending, err := o.taskWorktree("pending")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(pending, "pending.txt"), []byte("wip\n"), 0644))
+
+	conflict, err := o.taskWorktree("conflict")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(conflict, "shared.txt"), []byte("theirs\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(o.Workspace, "shared.txt"), []byte("ours\n"), 0644))
+	require.NoError(t, o.Git.Commit(o.Workspace, "diverge"))
+
+	o.mergeWorktrees()
+
+	// Finished work lands in the workspace and its branch is cleaned up
+	assert.FileExists(t, filepath.Join(o.Workspace, "done.txt"))
+	exists, _ := o.Git.LocalBranchExists(o.Workspace, "recac-task/done")
+	assert.False(t, exists)
+
+	// Unfinished work stays on its branch for the next sprint
+	assert.NoFileExists(t, filepath.Join(o.Workspace, "pending.txt"))
+	exists, _ = o.Git.LocalBranchExists(o.Workspace, "recac-task/pending")
+	assert.True(t, exists)
+
+	// A conflicting task is rolled back and redone
+	content, _ := os.ReadFile(filepath.Join(o.Workspace, "shared.txt"))
+	assert.Equal(t, "ours\n", string(content))
+	status, _ := o.Graph.GetTaskStatus("conflict")
+	assert.Equal(t, TaskFailed, status)
+
+	for _, path := range []string{done, pending, conflict} {
+		assert.NoDirExists(t, path)
+	}
+	assert.Empty(t, o.worktrees)
+}
@@ -0,0 +1,178 @@
+package security
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Command policy modes
+const (
+	PolicyModeDeny  = "deny"  // Everything runs unless a deny pattern matches (default)
+	PolicyModeAllow = "allow" // Only commands matching an allow pattern run
+)
+
+// DefaultConfirmPatterns are the risky commands safe mode asks a human to
+// confirm when a policy lists no confirm patterns of its own.
+var DefaultConfirmPatterns = []string{
+	`\brm\s+(.*\s)?(-[a-zA-Z]*[rR]|--recursive\b)`,      // recursive deletes
+	`\bfind\b.*\s-delete\b`,                             // bulk deletes
+	`\bgit\s+push\b.*(\s-f\b|\s--force|\s\+\S)`,         // force pushes, including +refspecs
+	`\bgit\s+(reset\s+--hard|clean\s+-[a-zA-Z]*f)`,      // discarding work
+	`(?i)\b(drop|truncate)\s+(table|database|schema)\b`, // destructive SQL
+	`\b(kubectl|helm)\s+(delete|uninstall)\b`,
+	`\bterraform\s+(apply|destroy)\b`,
+	`\b(mkfs(\.\w+)?|dd\s+if=)`,
+}
+
+var defaultConfirm = mustCompileAll(DefaultConfirmPatterns)
+
+// CommandPolicy decides which agent commands may be executed.
+// Patterns are regular expressions matched against each line of a command block.
+type CommandPolicy struct {
+	Mode              string   `yaml:"mode"`
+	Deny              []string `yaml:"deny"`
+	Allow             []string `yaml:"allow"`
+	ProtectedBranches []string `yaml:"protected_branches"`
+
+	// SafeMode makes commands matching a Confirm pattern wait for a human to
+	// confirm them. Confirm defaults to DefaultConfirmPatterns.
+	SafeMode bool     `yaml:"safe_mode"`
+	Confirm  []string `yaml:"confirm"`
+
+	deny    []*regexp.Regexp
+	allow   []*regexp.Regexp
+	confirm []*regexp.Regexp
+}
+
+// LoadCommandPolicy reads a policy from a YAML file.
+// It returns (nil, nil) if the file does not exist.
+func LoadCommandPolicy(path string) (*CommandPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read command policy: %w", err)
+	}
+	return ParseCommandPolicy(data)
+}
+
+// ParseCommandPolicy parses and compiles a YAML command policy.
+func ParseCommandPolicy(data []byte) (*CommandPolicy, error) {
+	var p CommandPolicy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid command policy: %w", err)
+	}
+	if err := p.compile(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (p *CommandPolicy) compile() error {
+	switch p.Mode {
+	case "":
+		p.Mode = PolicyModeDeny
+	case PolicyModeDeny, PolicyModeAllow:
+	default:
+		return fmt.Errorf("invalid command policy mode %q: expected %q or %q", p.Mode, PolicyModeDeny, PolicyModeAllow)
+	}
+
+	denyPatterns := append([]string{}, p.Deny...)
+	for _, branch := range p.ProtectedBranches {
+		// e.g. "git push origin main", "git push -f origin HEAD:main"
+		denyPatterns = append(denyPatterns, `\bgit\s+push\b.*[\s:/]`+regexp.QuoteMeta(branch)+`(\s|$)`)
+	}
+
+	for _, pattern := range denyPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid deny pattern %q: %w", pattern, err)
+		}
+		p.deny = append(p.deny, re)
+	}
+	for _, pattern := range p.Allow {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid allow pattern %q: %w", pattern, err)
+		}
+		p.allow = append(p.allow, re)
+	}
+	for _, pattern := range p.Confirm {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid confirm pattern %q: %w", pattern, err)
+		}
+		p.confirm = append(p.confirm, re)
+	}
+	return nil
+}
+
+// Check returns an error describing why script is rejected, or nil if it may run.
+// A nil policy allows everything.
+func (p *CommandPolicy) Check(script string) error {
+	if p == nil {
+		return nil
+	}
+
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		for _, re := range p.deny {
+			if re.MatchString(line) {
+				return fmt.Errorf("command %q matches deny pattern %q", line, re.String())
+			}
+		}
+
+		if p.Mode == PolicyModeAllow && !matchesAny(p.allow, line) {
+			return fmt.Errorf("command %q is not in the allowlist", line)
+		}
+	}
+	return nil
+}
+
+// NeedsConfirmation returns the first line of script that safe mode must
+// have confirmed before it runs, or "" if there is none. A nil policy, or
+// one without confirm patterns, uses DefaultConfirmPatterns.
+func (p *CommandPolicy) NeedsConfirmation(script string) string {
+	patterns := defaultConfirm
+	if p != nil && len(p.confirm) > 0 {
+		patterns = p.confirm
+	}
+
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if matchesAny(patterns, line) {
+			return line
+		}
+	}
+	return ""
+}
+
+func mustCompileAll(patterns []string) []*regexp.Regexp {
+	res := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		res[i] = regexp.MustCompile(pattern)
+	}
+	return res
+}
+
+func matchesAny(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
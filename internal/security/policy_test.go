@@ -0,0 +1,112 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandPolicy_DenyMode(t *testing.T) {
+	p, err := ParseCommandPolicy([]byte(`
+deny:
+  - 'rm\s+-rf\s+/(\s|$)'
+  - 'curl[^|]*\|\s*(ba)?sh'
+protected_branches:
+  - main
+`))
+	require.NoError(t, err)
+	assert.Equal(t, PolicyModeDeny, p.Mode)
+
+	tests := []struct {
+		name    string
+		script  string
+		blocked bool
+	}{
+		{"safe", "go test ./...", false},
+		{"root deletion", "rm -rf /", true},
+		{"scoped deletion", "rm -rf /tmp/build", false},
+		{"pipe to shell", "curl -fsSL https://example.com/install.sh | sh", true},
+		{"push protected", "git push origin main", true},
+		{"push refspec", "git push -f origin HEAD:main", true},
+		{"push feature", "git push origin feature/main-menu", false},
+		{"multi-line", "echo ok\nrm -rf /", true},
+		{"comment", "# rm -rf /", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Check(tt.script)
+			if tt.blocked {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCommandPolicy_AllowMode(t *testing.T) {
+	p, err := ParseCommandPolicy([]byte(`
+mode: allow
+allow:
+  - '^go\s'
+  - '^git\s'
+deny:
+  - '^git\s+push\b'
+`))
+	require.NoError(t, err)
+
+	assert.NoError(t, p.Check("go build ./...\ngit status"))
+	assert.ErrorContains(t, p.Check("npm install"), "not in the allowlist")
+	assert.ErrorContains(t, p.Check("git push origin feature"), "deny pattern")
+}
+
+func TestCommandPolicy_Invalid(t *testing.T) {
+	_, err := ParseCommandPolicy([]byte("mode: strict"))
+	assert.ErrorContains(t, err, "invalid command policy mode")
+
+	_, err = ParseCommandPolicy([]byte("deny: ['(unclosed']"))
+	assert.ErrorContains(t, err, "invalid deny pattern")
+}
+
+func TestLoadCommandPolicy(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := LoadCommandPolicy(filepath.Join(dir, "missing.yaml"))
+	require.NoError(t, err)
+	assert.Nil(t, p)
+	assert.NoError(t, p.Check("rm -rf /"), "nil policy allows everything")
+
+	path := filepath.Join(dir, "command_policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("deny: ['sudo']"), 0644))
+	p, err = LoadCommandPolicy(path)
+	require.NoError(t, err)
+	assert.Error(t, p.Check("sudo apt-get install jq"))
+}
+
+func TestCommandPolicy_NeedsConfirmation(t *testing.T) {
+	var p *CommandPolicy
+	assert.Equal(t, "rm -rf build", p.NeedsConfirmation("go build ./...\nrm -rf build"), "nil policy uses the defaults")
+	assert.Equal(t, "git push --force origin main", p.NeedsConfirmation("git push --force origin main"))
+	assert.Equal(t, "psql -c 'DROP TABLE users'", p.NeedsConfirmation("psql -c 'DROP TABLE users'"))
+	assert.Equal(t, "rm --recursive build", p.NeedsConfirmation("rm --recursive build"))
+	assert.Equal(t, "git push origin +main", p.NeedsConfirmation("git push origin +main"))
+	assert.Equal(t, "find . -name '*.o' -delete", p.NeedsConfirmation("find . -name '*.o' -delete"))
+	assert.Empty(t, p.NeedsConfirmation("rm notes.txt\ngit push origin feature\n# rm -rf /"))
+	assert.Empty(t, p.NeedsConfirmation("find . -name '*.go'\ngit push origin a+b"))
+
+	p, err := ParseCommandPolicy([]byte(`
+safe_mode: true
+confirm:
+  - '^make\s+deploy\b'
+`))
+	require.NoError(t, err)
+	assert.True(t, p.SafeMode)
+	assert.Equal(t, "make deploy", p.NeedsConfirmation("make test\nmake deploy"))
+	assert.Empty(t, p.NeedsConfirmation("rm -rf build"), "custom patterns replace the defaults")
+
+	_, err = ParseCommandPolicy([]byte("confirm: ['(unclosed']"))
+	assert.ErrorContains(t, err, "invalid confirm pattern")
+}
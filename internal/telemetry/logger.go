@@ -46,7 +46,7 @@ func NewLogger(debug bool, logFile string, silenceStdout bool) *slog.Logger {
 		handler = slog.NewJSONHandler(os.NewFile(0, os.DevNull), &slog.HandlerOptions{Level: level})
 	}
 
-	return slog.New(handler)
+	return slog.New(NewRedactingHandler(handler))
 }
 
 // InitLogger configures the default logger with optional file output.
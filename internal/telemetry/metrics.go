@@ -102,6 +102,20 @@ var (
 		Name: "recac_uptime_seconds",
 		Help: "Session duration in seconds.",
 	}, []string{"project"})
+
+	// 5. Cost
+	SessionTokens = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "recac_session_tokens",
+		Help: "Tokens consumed by the session so far, by type (prompt, response, cache_read, cache_creation).",
+	}, []string{"project", "provider", "model", "type"})
+	SessionCostDollars = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "recac_session_cost_dollars",
+		Help: "Estimated cost of the session so far in US dollars.",
+	}, []string{"project", "provider", "model"})
+	AgentCostDollarsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "recac_agent_cost_dollars_total",
+		Help: "Total estimated agent cost in US dollars.",
+	}, []string{"project", "provider", "model"})
 )
 
 var (
@@ -223,3 +237,15 @@ func TrackDockerOp(project string) {
 func TrackDockerError(project string) {
 	DockerErrorsTotal.WithLabelValues(project).Inc()
 }
+
+func SetSessionTokens(project, provider, model, tokenType string, count int) {
+	SessionTokens.WithLabelValues(project, provider, model, tokenType).Set(float64(count))
+}
+
+func SetSessionCost(project, provider, model string, dollars float64) {
+	SessionCostDollars.WithLabelValues(project, provider, model).Set(dollars)
+}
+
+func TrackAgentCost(project, provider, model string, dollars float64) {
+	AgentCostDollarsTotal.WithLabelValues(project, provider, model).Add(dollars)
+}
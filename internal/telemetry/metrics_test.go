@@ -27,6 +27,9 @@ func TestMetricsHelpers(t *testing.T) {
 	TrackDBOp(project)
 	TrackDockerOp(project)
 	TrackDockerError(project)
+	SetSessionTokens(project, "openai", "gpt-4o", "prompt", 1000)
+	SetSessionCost(project, "openai", "gpt-4o", 0.25)
+	TrackAgentCost(project, "openai", "gpt-4o", 0.25)
 }
 
 func TestStartMetricsServer(t *testing.T) {
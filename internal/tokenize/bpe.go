@@ -0,0 +1,137 @@
+package tokenize
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dlclark/regexp2"
+)
+
+// Supported tiktoken encodings.
+const (
+	Cl100kBase = "cl100k_base"
+	O200kBase  = "o200k_base"
+)
+
+// splitPatterns are the pre-tokenization patterns of each encoding, as
+// published with tiktoken. They need lookahead, hence regexp2.
+var splitPatterns = map[string]string{
+	Cl100kBase: `(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+`,
+	O200kBase: strings.Join([]string{
+		`[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?`,
+		`[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?`,
+		`\p{N}{1,3}`,
+		` ?[^\s\p{L}\p{N}]+[\r\n/]*`,
+		`\s*[\r\n]+`,
+		`\s+(?!\S)`,
+		`\s+`,
+	}, "|"),
+}
+
+// maxPieceBytes caps the pieces merged with BPE. Merging is quadratic in the
+// piece length, so longer pieces (e.g. minified code or base64 blobs without
+// whitespace) are estimated with the Heuristic instead.
+const maxPieceBytes = 4096
+
+// Encoding is a byte-pair encoding compatible with OpenAI's tiktoken.
+type Encoding struct {
+	name  string
+	ranks map[string]int
+	split *regexp2.Regexp
+}
+
+// LoadEncoding reads a tiktoken rank file (one "<base64 token> <rank>" pair
+// per line, as published by OpenAI) for the named encoding.
+func LoadEncoding(name, path string) (*Encoding, error) {
+	pattern, ok := splitPatterns[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported encoding %q", name)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		token, rank, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid rank line %q in %s", line, path)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token %q in %s: %w", token, path, err)
+		}
+		r, err := strconv.Atoi(rank)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rank %q in %s: %w", rank, path, err)
+		}
+		ranks[string(decoded)] = r
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return &Encoding{
+		name:  name,
+		ranks: ranks,
+		split: regexp2.MustCompile(pattern, regexp2.None),
+	}, nil
+}
+
+// Name returns the encoding name.
+func (e *Encoding) Name() string { return e.name }
+
+// Count returns the number of tokens text encodes to. Special tokens such as
+// <|endoftext|> are counted as ordinary text.
+func (e *Encoding) Count(text string) int {
+	count := 0
+	m, _ := e.split.FindStringMatch(text)
+	for m != nil {
+		count += e.pieceTokens([]byte(m.String()))
+		m, _ = e.split.FindNextMatch(m)
+	}
+	return count
+}
+
+// pieceTokens counts the tokens of one pre-tokenized piece by repeatedly
+// merging the adjacent pair with the lowest rank, as tiktoken does.
+func (e *Encoding) pieceTokens(piece []byte) int {
+	if _, ok := e.ranks[string(piece)]; ok {
+		return 1
+	}
+	if len(piece) > maxPieceBytes {
+		return Heuristic{}.Count(string(piece))
+	}
+
+	// parts holds the start offsets of the current tokens, plus the end
+	parts := make([]int, len(piece)+1)
+	for i := range parts {
+		parts[i] = i
+	}
+	for len(parts) > 2 {
+		minRank, minIdx := math.MaxInt, -1
+		for i := 0; i < len(parts)-2; i++ {
+			if r, ok := e.ranks[string(piece[parts[i]:parts[i+2]])]; ok && r < minRank {
+				minRank, minIdx = r, i
+			}
+		}
+		if minIdx < 0 {
+			break
+		}
+		parts = append(parts[:minIdx+1], parts[minIdx+2:]...)
+	}
+	return len(parts) - 1
+}
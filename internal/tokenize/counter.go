@@ -0,0 +1,126 @@
+// Package tokenize counts the tokens a model sees in a piece of text.
+package tokenize
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"recac/internal/telemetry"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// Counter counts the tokens in text.
+type Counter interface {
+	Count(text string) int
+	// Name identifies the tokenizer (e.g. "cl100k_base" or "heuristic").
+	Name() string
+}
+
+// Heuristic approximates the token count at ~4 bytes per token, which holds
+// reasonably well for English text and code across model families.
+type Heuristic struct{}
+
+// Count estimates the number of tokens in text. Counting bytes rather than
+// runes slightly overestimates non-ASCII text, which is safer for limits.
+func (Heuristic) Count(text string) int {
+	n := len(text)
+	if n == 0 {
+		return 0
+	}
+	return (n / 4) + 1
+}
+
+// Name returns "heuristic".
+func (Heuristic) Name() string { return "heuristic" }
+
+// encodingPrefixes maps model name prefixes to their tiktoken encoding.
+// Longer prefixes are listed first so "gpt-4o" is not taken for "gpt-4".
+var encodingPrefixes = []struct {
+	prefix   string
+	encoding string
+}{
+	{"chatgpt-4o", O200kBase},
+	{"gpt-4o", O200kBase},
+	{"gpt-4.1", O200kBase},
+	{"gpt-4.5", O200kBase},
+	{"gpt-5", O200kBase},
+	{"gpt-oss", O200kBase},
+	{"o1", O200kBase},
+	{"o3", O200kBase},
+	{"o4", O200kBase},
+	{"gpt-4", Cl100kBase},
+	{"gpt-3.5", Cl100kBase},
+	{"gpt-35", Cl100kBase},
+	{"text-embedding-3", Cl100kBase},
+	{"text-embedding-ada-002", Cl100kBase},
+}
+
+// EncodingForModel returns the tiktoken encoding used by model, or "" for
+// model families without a public tokenizer. OpenRouter-style names such as
+// "openai/gpt-4o" are matched on the part after the slash.
+func EncodingForModel(model string) string {
+	model = strings.ToLower(model)
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+	for _, p := range encodingPrefixes {
+		if strings.HasPrefix(model, p.prefix) {
+			return p.encoding
+		}
+	}
+	return ""
+}
+
+// Dir returns the directory holding tiktoken rank files, named after their
+// encoding (e.g. cl100k_base.tiktoken). It is set with tokenizer_dir
+// (RECAC_TOKENIZER_DIR) and defaults to ~/.recac/tokenizers.
+func Dir() string {
+	if dir := viper.GetString("tokenizer_dir"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".recac", "tokenizers")
+}
+
+var (
+	encodingsMu sync.Mutex
+	encodings   = make(map[string]*Encoding) // By rank file path; nil when it failed to load
+)
+
+// ForModel returns the most accurate Counter available for model: a BPE
+// encoding for OpenAI model families whose rank file is installed in Dir,
+// and the Heuristic otherwise. Rank files are loaded once and shared.
+func ForModel(model string) Counter {
+	name := EncodingForModel(model)
+	if name == "" {
+		return Heuristic{}
+	}
+	path := filepath.Join(Dir(), name+".tiktoken")
+
+	encodingsMu.Lock()
+	defer encodingsMu.Unlock()
+	enc, ok := encodings[path]
+	if !ok {
+		var err error
+		if enc, err = LoadEncoding(name, path); err != nil {
+			// Logged once per rank file since the failure is cached
+			if errors.Is(err, fs.ErrNotExist) {
+				telemetry.LogDebug("Tokenizer rank file not installed, estimating tokens", "encoding", name, "path", path)
+			} else {
+				telemetry.LogInfo("Warning: failed to load tokenizer rank file, estimating tokens", "encoding", name, "path", path, "error", err)
+			}
+		}
+		encodings[path] = enc
+	}
+	if enc == nil {
+		return Heuristic{}
+	}
+	return enc
+}
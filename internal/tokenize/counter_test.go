@@ -0,0 +1,102 @@
+package tokenize
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRankFile writes a tiny rank file: every single byte, then merges.
+func writeRankFile(t *testing.T, dir, name string, merges ...string) string {
+	t.Helper()
+	var b strings.Builder
+	rank := 0
+	for i := 0; i < 256; i++ {
+		fmt.Fprintf(&b, "%s %d\n", base64.StdEncoding.EncodeToString([]byte{byte(i)}), rank)
+		rank++
+	}
+	for _, m := range merges {
+		fmt.Fprintf(&b, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(m)), rank)
+		rank++
+	}
+	path := filepath.Join(dir, name+".tiktoken")
+	require.NoError(t, os.WriteFile(path, []byte(b.String()), 0644))
+	return path
+}
+
+func TestHeuristic(t *testing.T) {
+	assert.Equal(t, 0, Heuristic{}.Count(""))
+	assert.Equal(t, 1, Heuristic{}.Count("abc"))
+	assert.Equal(t, 3, Heuristic{}.Count("abcdefgh"))
+}
+
+func TestEncoding_Count(t *testing.T) {
+	path := writeRankFile(t, t.TempDir(), Cl100kBase, "ab", "abc")
+	enc, err := LoadEncoding(Cl100kBase, path)
+	require.NoError(t, err)
+
+	// "abc" is a single token; " abc" merges to " " + "abc"
+	assert.Equal(t, 1, enc.Count("abc"))
+	assert.Equal(t, 3, enc.Count("abc abc"))
+	// Without merges every byte is a token
+	assert.Equal(t, 3, enc.Count("xyz"))
+	assert.Equal(t, 0, enc.Count(""))
+
+	// Pieces over maxPieceBytes are estimated rather than merged
+	long := strings.Repeat("x", maxPieceBytes+1)
+	assert.Equal(t, Heuristic{}.Count(long), enc.Count(long))
+}
+
+func TestLoadEncoding_Errors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := LoadEncoding("p50k_base", filepath.Join(dir, "p50k_base.tiktoken"))
+	assert.ErrorContains(t, err, "unsupported encoding")
+
+	_, err = LoadEncoding(Cl100kBase, filepath.Join(dir, "missing.tiktoken"))
+	assert.Error(t, err)
+
+	bad := filepath.Join(dir, "bad.tiktoken")
+	require.NoError(t, os.WriteFile(bad, []byte("YWI=\n"), 0644))
+	_, err = LoadEncoding(Cl100kBase, bad)
+	assert.ErrorContains(t, err, "invalid rank line")
+}
+
+func TestEncodingForModel(t *testing.T) {
+	tests := map[string]string{
+		"gpt-4o-mini":            O200kBase,
+		"openai/gpt-4.1":         O200kBase,
+		"o3-mini":                O200kBase,
+		"gpt-4-turbo":            Cl100kBase,
+		"GPT-3.5-turbo":          Cl100kBase,
+		"claude-sonnet-4":        "",
+		"gemini-2.5-pro":         "",
+		"mistralai/devstral":     "",
+		"text-embedding-3-small": Cl100kBase,
+	}
+	for model, want := range tests {
+		assert.Equal(t, want, EncodingForModel(model), model)
+	}
+}
+
+func TestForModel(t *testing.T) {
+	dir := t.TempDir()
+	viper.Set("tokenizer_dir", dir)
+	defer viper.Set("tokenizer_dir", "")
+
+	// No rank file installed yet
+	assert.Equal(t, "heuristic", ForModel("gpt-4").Name())
+
+	otherDir := t.TempDir()
+	writeRankFile(t, otherDir, Cl100kBase)
+	viper.Set("tokenizer_dir", otherDir)
+	assert.Equal(t, Cl100kBase, ForModel("gpt-4").Name())
+	assert.Equal(t, "heuristic", ForModel("gpt-4o").Name())
+	assert.Equal(t, "heuristic", ForModel("claude-sonnet-4").Name())
+}
@@ -170,7 +170,7 @@ func (m *costModel) updateTable() {
 				"N/A",
 			}
 		} else {
-			cost := agent.CalculateCost(agentState.Model, agentState.TokenUsage)
+			cost := agent.CalculateProviderCost(agentState.Provider, agentState.Model, agentState.TokenUsage)
 			rows[i] = table.Row{
 				session.Name,
 				session.Status,
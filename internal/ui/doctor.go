@@ -3,8 +3,13 @@ package ui
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
+	"time"
+
+	"recac/internal/agent"
+	"recac/internal/cmdutils"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
@@ -17,8 +22,33 @@ var (
 	clientNewClientWithOpts = client.NewClientWithOpts
 	viperConfigFileUsed     = viper.ConfigFileUsed
 	checkDockerConnectivity = checkDockerConnectivityFunc
+	checkProvider           = checkProviderFunc
+	checkJira               = checkJiraFunc
 )
 
+// doctorCheckTimeout bounds each network check so doctor never hangs.
+const doctorCheckTimeout = 15 * time.Second
+
+// dependencyHints maps each dependency to a remediation hint; optional ones only warn.
+var dependencyHints = []struct {
+	name     string
+	hint     string
+	optional bool
+}{
+	{name: "git", hint: "Install git: https://git-scm.com/downloads"},
+	{name: "docker", hint: "Install Docker: https://docs.docker.com/get-docker/"},
+	{name: "gh", hint: "Install the GitHub CLI for PR workflows: https://cli.github.com/", optional: true},
+	{name: "kubectl", hint: "Install kubectl for k8s mode: https://kubernetes.io/docs/tasks/tools/", optional: true},
+}
+
+// providerKeyEnv maps providers to the env var holding their API key.
+var providerKeyEnv = map[string]string{
+	"gemini":     "GEMINI_API_KEY",
+	"openai":     "OPENAI_API_KEY",
+	"openrouter": "OPENROUTER_API_KEY",
+	"anthropic":  "ANTHROPIC_API_KEY",
+}
+
 // DockerClient defines the interface for Docker client operations needed by the doctor.
 type DockerClient interface {
 	Ping(ctx context.Context) (types.Ping, error)
@@ -41,25 +71,40 @@ func GetDoctor() string {
 	dockerCli, err := clientNewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	builder.WriteString(checkDockerConnectivity(dockerCli, err))
 
+	// Check 4: Agent Provider
+	builder.WriteString(checkProvider())
+
+	// Check 5: Jira
+	builder.WriteString(checkJira())
+
 	return builder.String()
 }
 
+// hint formats a remediation hint shown under a failed check.
+func hint(text string) string {
+	return fmt.Sprintf("    → %s\n", text)
+}
+
 func checkConfig() string {
 	if cfgFile := viperConfigFileUsed(); cfgFile != "" {
 		return fmt.Sprintf("[✔] Configuration: %s found\n", cfgFile)
 	}
-	return "[✖] Configuration: Missing config file\n"
+	return "[✖] Configuration: Missing config file\n" + hint("Run 'recac setup' or pass --config")
 }
 
 func checkDependencies() string {
 	var builder strings.Builder
-	dependencies := []string{"git", "docker"}
-	for _, dep := range dependencies {
-		_, err := execLookPath(dep)
-		if err != nil {
-			builder.WriteString(fmt.Sprintf("[✖] Dependency: %s not found in PATH\n", dep))
-		} else {
-			builder.WriteString(fmt.Sprintf("[✔] Dependency: %s found in PATH\n", dep))
+	for _, dep := range dependencyHints {
+		_, err := execLookPath(dep.name)
+		switch {
+		case err == nil:
+			builder.WriteString(fmt.Sprintf("[✔] Dependency: %s found in PATH\n", dep.name))
+		case dep.optional:
+			builder.WriteString(fmt.Sprintf("[!] Dependency: %s not found in PATH (optional)\n", dep.name))
+			builder.WriteString(hint(dep.hint))
+		default:
+			builder.WriteString(fmt.Sprintf("[✖] Dependency: %s not found in PATH\n", dep.name))
+			builder.WriteString(hint(dep.hint))
 		}
 	}
 	return builder.String()
@@ -73,10 +118,56 @@ func checkDockerConnectivityFunc(cli DockerClient, err error) string {
 	_, err = cli.Ping(context.Background())
 	if err != nil {
 		if strings.Contains(err.Error(), "Is the docker daemon running?") {
-			return "[✖] Docker: Daemon not running or socket permission error\n"
+			return "[✖] Docker: Daemon not running or socket permission error\n" + hint("Start Docker and make sure your user can access the socket (e.g. add it to the docker group)")
 		}
 		return fmt.Sprintf("[✖] Docker: Failed to ping daemon: %v\n", err)
 	}
 
 	return "[✔] Docker: Daemon is responsive\n"
 }
+
+func checkProviderFunc() string {
+	provider := viper.GetString("provider")
+	if provider == "" {
+		provider = "gemini"
+	}
+
+	if keyEnv, ok := providerKeyEnv[provider]; ok {
+		if viper.GetString("api_key") == "" && os.Getenv("API_KEY") == "" && os.Getenv(keyEnv) == "" && os.Getenv(keyEnv+"S") == "" {
+			return fmt.Sprintf("[✖] Provider: no API key configured for %s\n", provider) +
+				hint(fmt.Sprintf("Set %s (or api_key in your config)", keyEnv))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorCheckTimeout)
+	defer cancel()
+
+	ag, err := cmdutils.GetAgentClient(ctx, provider, "", "", "recac-doctor")
+	if err != nil {
+		return fmt.Sprintf("[✖] Provider: %v\n", err) + hint("Check the provider setting in your config")
+	}
+	if err := agent.Ping(ctx, ag); err != nil {
+		return fmt.Sprintf("[✖] Provider: %s is not usable: %v\n", provider, err) +
+			hint("Verify the API key and that the configured model exists")
+	}
+	return fmt.Sprintf("[✔] Provider: %s is reachable\n", provider)
+}
+
+func checkJiraFunc() string {
+	if viper.GetString("jira.url") == "" && os.Getenv("JIRA_URL") == "" {
+		return "[-] Jira: Not configured (skipped)\n"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorCheckTimeout)
+	defer cancel()
+
+	client, err := cmdutils.GetJiraClient(ctx)
+	if err != nil {
+		return fmt.Sprintf("[✖] Jira: %v\n", err) + hint("Set JIRA_URL, JIRA_USERNAME and JIRA_API_TOKEN")
+	}
+	if err := client.Authenticate(ctx); err != nil {
+		return fmt.Sprintf("[✖] Jira: %v\n", err) +
+			hint("Check JIRA_USERNAME and JIRA_API_TOKEN (https://id.atlassian.com/manage-profile/security/api-tokens)")
+	}
+	return "[✔] Jira: Authenticated\n"
+}
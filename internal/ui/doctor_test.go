@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os/exec"
 	"testing"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,12 +31,20 @@ func TestGetDoctor(t *testing.T) {
 		originalClientNewClientWithOpts := clientNewClientWithOpts
 		originalViperConfigFileUsed := viperConfigFileUsed
 		originalCheckDockerConnectivity := checkDockerConnectivity
+		originalCheckProvider := checkProvider
+		originalCheckJira := checkJira
+
+		// Network checks are covered separately
+		checkProvider = func() string { return "[✔] Provider: gemini is reachable\n" }
+		checkJira = func() string { return "[-] Jira: Not configured (skipped)\n" }
 
 		return func() {
 			execLookPath = originalExecLookPath
 			clientNewClientWithOpts = originalClientNewClientWithOpts
 			viperConfigFileUsed = originalViperConfigFileUsed
 			checkDockerConnectivity = originalCheckDockerConnectivity
+			checkProvider = originalCheckProvider
+			checkJira = originalCheckJira
 		}
 	}
 
@@ -59,6 +70,9 @@ func TestGetDoctor(t *testing.T) {
 		assert.Contains(t, output, "[✔] Dependency: git found in PATH")
 		assert.Contains(t, output, "[✔] Dependency: docker found in PATH")
 		assert.Contains(t, output, "[✔] Docker: Daemon is responsive")
+		assert.Contains(t, output, "[✔] Dependency: kubectl found in PATH")
+		assert.Contains(t, output, "[✔] Provider: gemini is reachable")
+		assert.Contains(t, output, "Jira:")
 	})
 
 	t.Run("Missing config file", func(t *testing.T) {
@@ -88,6 +102,24 @@ func TestGetDoctor(t *testing.T) {
 
 		output := GetDoctor()
 		assert.Contains(t, output, "[✖] Dependency: git not found in PATH")
+		assert.Contains(t, output, "→ Install git")
+	})
+
+	t.Run("Missing optional dependency only warns", func(t *testing.T) {
+		teardown := setup(t)
+		defer teardown()
+
+		viperConfigFileUsed = func() string { return "config.yaml" }
+		execLookPath = func(file string) (string, error) {
+			if file == "kubectl" {
+				return "", exec.ErrNotFound
+			}
+			return "/usr/bin/" + file, nil
+		}
+		checkDockerConnectivity = func(cli DockerClient, err error) string { return "" }
+
+		output := GetDoctor()
+		assert.Contains(t, output, "[!] Dependency: kubectl not found in PATH (optional)")
 	})
 
 	t.Run("Docker client creation fails", func(t *testing.T) {
@@ -124,7 +156,7 @@ func TestCheckDockerConnectivity(t *testing.T) {
 			name:           "Ping fails with daemon error",
 			cli:            &MockDockerClient{PingErr: errors.New("Is the docker daemon running?")},
 			err:            nil,
-			expectedOutput: "[✖] Docker: Daemon not running or socket permission error\n",
+			expectedOutput: "[✖] Docker: Daemon not running or socket permission error\n    → Start Docker and make sure your user can access the socket (e.g. add it to the docker group)\n",
 		},
 		{
 			name:           "Ping fails with other error",
@@ -147,3 +179,46 @@ func TestCheckDockerConnectivity(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckProvider_MissingKey(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("provider", "openrouter")
+	t.Setenv("API_KEY", "")
+	t.Setenv("OPENROUTER_API_KEY", "")
+	t.Setenv("OPENROUTER_API_KEYS", "")
+
+	output := checkProviderFunc()
+	assert.Contains(t, output, "[✖] Provider: no API key configured for openrouter")
+	assert.Contains(t, output, "→ Set OPENROUTER_API_KEY")
+}
+
+func TestCheckJira(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	t.Run("Not configured", func(t *testing.T) {
+		t.Setenv("JIRA_URL", "")
+		assert.Equal(t, "[-] Jira: Not configured (skipped)\n", checkJiraFunc())
+	})
+
+	t.Run("Authentication", func(t *testing.T) {
+		status := http.StatusOK
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		t.Setenv("JIRA_URL", server.URL)
+		t.Setenv("JIRA_USERNAME", "user")
+		t.Setenv("JIRA_API_TOKEN", "token")
+
+		assert.Equal(t, "[✔] Jira: Authenticated\n", checkJiraFunc())
+
+		status = http.StatusUnauthorized
+		output := checkJiraFunc()
+		assert.Contains(t, output, "[✖] Jira: authentication failed with status: 401")
+		assert.Contains(t, output, "→ Check JIRA_USERNAME")
+	})
+}
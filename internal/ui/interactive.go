@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -460,6 +461,8 @@ func (m *InteractiveModel) initAgentCmd() tea.Cmd {
 					apiKey = os.Getenv("OPENAI_API_KEY")
 				case "openrouter":
 					apiKey = os.Getenv("OPENROUTER_API_KEY")
+				case "anthropic":
+					apiKey = os.Getenv("ANTHROPIC_API_KEY")
 				}
 			}
 		}
@@ -1082,6 +1085,53 @@ func (m *InteractiveModel) ClearHistory() {
 	m.conversation("Conversation history cleared.", false)
 }
 
+// SetTheme switches the markdown style and re-renders the conversation with it.
+func (m *InteractiveModel) SetTheme(style string) error {
+	if err := SetMarkdownStyle(style); err != nil {
+		return err
+	}
+	for i := range m.messages {
+		m.messages[i].Rendered = m.renderSingleMessage(m.messages[i])
+	}
+	m.conversation(fmt.Sprintf("Markdown style set to: %s", style), false)
+	return nil
+}
+
+// ExportMarkdown writes the conversation to path as Markdown, one section per
+// message headed by its sender, so it can be kept as documentation.
+func (m InteractiveModel) ExportMarkdown(path string) error {
+	var b strings.Builder
+	b.WriteString("# RECAC Conversation\n\n")
+	fmt.Fprintf(&b, "_Exported %s (%s, model %s)_\n\n", time.Now().Format("2006-01-02 15:04"), m.currentAgent, m.currentModel)
+
+	for _, msg := range m.messages {
+		content := strings.TrimSpace(msg.Content)
+		if content == "" {
+			continue // e.g. the placeholder of a response still streaming
+		}
+		header := "Recac"
+		switch msg.Role {
+		case RoleUser:
+			header = "You"
+		case RoleSystem:
+			header = "System"
+		case RoleError:
+			header = "Error"
+		}
+		fmt.Fprintf(&b, "## %s\n\n%s\n\n", header, content)
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create export directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to export conversation: %w", err)
+	}
+	return nil
+}
+
 func (m InteractiveModel) View() string {
 	var views []string
 
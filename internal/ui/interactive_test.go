@@ -3,6 +3,8 @@ package ui
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -627,3 +629,63 @@ func TestInteractiveModel_Update_ListSelection(t *testing.T) {
 		t.Error("Expected list selection to execute command")
 	}
 }
+
+func TestInteractiveModel_SetTheme(t *testing.T) {
+	defer SetMarkdownStyle(DefaultMarkdownStyle)
+
+	m := NewInteractiveModel(nil, "", "")
+	m.conversation("**Bold**", true)
+	before := m.messages[0].Rendered
+
+	if err := m.SetTheme("notty"); err != nil {
+		t.Fatalf("SetTheme(notty) failed: %v", err)
+	}
+	if m.messages[0].Rendered == before {
+		t.Error("Expected existing messages to be re-rendered with the new style")
+	}
+	if !strings.Contains(m.messages[len(m.messages)-1].Content, "notty") {
+		t.Error("Expected a confirmation message naming the new style")
+	}
+
+	if err := m.SetTheme("no-such-style"); err == nil {
+		t.Error("Expected an error for an unknown style")
+	}
+}
+
+func TestInteractiveModel_ExportMarkdown(t *testing.T) {
+	m := NewInteractiveModel(nil, "gemini", "gemini-2.5-pro")
+	m.thinking = false
+	m.conversation("How should we **cache** responses?", true)
+	m.conversation("Key them by the prompt hash.", false)
+	m.messages = append(m.messages, ChatMessage{Role: RoleError, Content: "rate limited"})
+	m.messages = append(m.messages, ChatMessage{Role: RoleBot, Content: ""}) // Streaming placeholder
+
+	path := filepath.Join(t.TempDir(), "docs", "design.md")
+	if err := m.ExportMarkdown(path); err != nil {
+		t.Fatalf("ExportMarkdown failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+
+	for _, want := range []string{
+		"# RECAC Conversation",
+		"gemini, model gemini-2.5-pro",
+		"## You\n\nHow should we **cache** responses?\n",
+		"## Recac\n\nKey them by the prompt hash.\n",
+		"## Error\n\nrate limited\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Export missing %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "## Recac") != 1 {
+		t.Errorf("Expected empty messages to be skipped, got:\n%s", out)
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Error("Export should contain Markdown, not ANSI escapes")
+	}
+}
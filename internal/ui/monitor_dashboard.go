@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -18,6 +19,9 @@ type ActionCallbacks struct {
 	Pause       func(name string) error
 	Resume      func(name string) error
 	GetLogs     func(name string) (string, error)
+	Archive     func(name string) error
+	Rename      func(oldName, newName string) error
+	GetDiff     func(name string) (string, error)
 }
 
 type MonitorDashboardModel struct {
@@ -30,10 +34,13 @@ type MonitorDashboardModel struct {
 	width         int
 	height        int
 	selectedRow   int
-	viewMode      string // "list", "logs", "confirm_kill"
+	viewMode      string // "list", "logs", "confirm_kill", "rename"
+	viewerTitle   string
 	logContent    string
 	message       string // Status message (e.g., "Session stopped")
 	sessionToKill string
+	renameInput   textinput.Model
+	renameFrom    string
 }
 
 type monitorTickMsg time.Time
@@ -42,6 +49,10 @@ type actionResultMsg struct {
 	err error
 	msg string
 }
+type diffContentMsg struct {
+	name string
+	diff string
+}
 
 var (
 	monitorTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62"))
@@ -79,11 +90,16 @@ func NewMonitorDashboardModel(callbacks ActionCallbacks) MonitorDashboardModel {
 
 	vp := viewport.New(0, 0)
 
+	ti := textinput.New()
+	ti.Placeholder = "new-session-name"
+	ti.CharLimit = 64
+
 	return MonitorDashboardModel{
-		table:     t,
-		viewport:  vp,
-		callbacks: callbacks,
-		viewMode:  "list",
+		table:       t,
+		viewport:    vp,
+		callbacks:   callbacks,
+		viewMode:    "list",
+		renameInput: ti,
 	}
 }
 
@@ -121,6 +137,33 @@ func (m MonitorDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if m.viewMode == "rename" {
+			switch msg.String() {
+			case "enter":
+				oldName, newName := m.renameFrom, m.renameInput.Value()
+				m.renameFrom = ""
+				m.viewMode = "list"
+				m.renameInput.Blur()
+				if newName == "" || newName == oldName {
+					return m, nil
+				}
+				return m, m.runAction(fmt.Sprintf("Renamed session %s to %s", oldName, newName), func() error {
+					if m.callbacks.Rename == nil {
+						return fmt.Errorf("rename is not supported")
+					}
+					return m.callbacks.Rename(oldName, newName)
+				})
+			case "esc":
+				m.renameFrom = ""
+				m.viewMode = "list"
+				m.renameInput.Blur()
+				return m, nil
+			}
+			var tiCmd tea.Cmd
+			m.renameInput, tiCmd = m.renameInput.Update(msg)
+			return m, tiCmd
+		}
+
 		if m.viewMode == "confirm_kill" {
 			switch msg.String() {
 			case "y", "Y":
@@ -173,6 +216,39 @@ func (m MonitorDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return actionResultMsg{msg: fmt.Sprintf("%s session %s", action, name)}
 				}
 			}
+		case "a":
+			if selected := m.table.SelectedRow(); selected != nil {
+				name := selected[0]
+				return m, m.runAction(fmt.Sprintf("Archived session %s", name), func() error {
+					if m.callbacks.Archive == nil {
+						return fmt.Errorf("archive is not supported")
+					}
+					return m.callbacks.Archive(name)
+				})
+			}
+		case "r":
+			if selected := m.table.SelectedRow(); selected != nil {
+				m.renameFrom = selected[0]
+				m.renameInput.SetValue(selected[0])
+				m.renameInput.CursorEnd()
+				m.renameInput.Focus()
+				m.viewMode = "rename"
+				return m, textinput.Blink
+			}
+		case "d":
+			if selected := m.table.SelectedRow(); selected != nil {
+				name := selected[0]
+				return m, func() tea.Msg {
+					if m.callbacks.GetDiff == nil {
+						return actionResultMsg{err: fmt.Errorf("diff is not supported")}
+					}
+					diff, err := m.callbacks.GetDiff(name)
+					if err != nil {
+						return actionResultMsg{err: err}
+					}
+					return diffContentMsg{name: name, diff: diff}
+				}
+			}
 		case "l", "enter":
 			if selected := m.table.SelectedRow(); selected != nil {
 				name := selected[0]
@@ -206,12 +282,28 @@ func (m MonitorDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.message = msg.msg
 		}
 		// Clear message after 3 seconds
-		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+		clearCmd := tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
 			return actionResultMsg{msg: ""}
 		})
+		if msg.err == nil && msg.msg != "" {
+			// Refresh right away so the action's effect (e.g. an archived or renamed session) shows up
+			return m, tea.Batch(refreshMonitorSessionsCmd(m.callbacks.GetSessions), clearCmd)
+		}
+		return m, clearCmd
 
 	case string: // Logs content (from 'l' key)
 		m.logContent = msg
+		m.viewerTitle = "Session Logs"
+		m.viewMode = "logs"
+		m.viewport.SetContent(m.logContent)
+		return m, nil
+
+	case diffContentMsg:
+		m.logContent = msg.diff
+		if m.logContent == "" {
+			m.logContent = "No changes."
+		}
+		m.viewerTitle = fmt.Sprintf("Session Diff: %s", msg.name)
 		m.viewMode = "logs"
 		m.viewport.SetContent(m.logContent)
 		return m, nil
@@ -221,6 +313,16 @@ func (m MonitorDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// runAction runs fn in the background and reports the outcome as an actionResultMsg.
+func (m MonitorDashboardModel) runAction(success string, fn func() error) tea.Cmd {
+	return func() tea.Msg {
+		if err := fn(); err != nil {
+			return actionResultMsg{err: err}
+		}
+		return actionResultMsg{msg: success}
+	}
+}
+
 func (m *MonitorDashboardModel) updateTableRows() {
 	rows := []table.Row{}
 	for _, s := range m.sessions {
@@ -247,8 +349,12 @@ func (m *MonitorDashboardModel) updateTableRows() {
 
 func (m MonitorDashboardModel) View() string {
 	if m.viewMode == "logs" {
+		title := m.viewerTitle
+		if title == "" {
+			title = "Session Logs"
+		}
 		return fmt.Sprintf("%s\n\n%s\n\n(Press q/esc to back)",
-			monitorTitleStyle.Render("Session Logs"),
+			monitorTitleStyle.Render(title),
 			m.viewport.View())
 	}
 
@@ -258,6 +364,13 @@ func (m MonitorDashboardModel) View() string {
 			m.sessionToKill)
 	}
 
+	if m.viewMode == "rename" {
+		return fmt.Sprintf("%s\n\nRename session '%s' to:\n\n%s\n\n(enter to confirm, esc to cancel)",
+			monitorTitleStyle.Render("Rename Session"),
+			m.renameFrom,
+			m.renameInput.View())
+	}
+
 	s := monitorTitleStyle.Render("RECAC Control Center") + "\n"
 	s += fmt.Sprintf("Last updated: %s\n\n", m.lastUpdate.Format("15:04:05"))
 
@@ -271,7 +384,7 @@ func (m MonitorDashboardModel) View() string {
 		s += messageStyle.Render(m.message) + "\n"
 	}
 
-	s += monitorHelpStyle.Render("Keys: ↑/↓ navigate • k: kill • p: pause/resume • l/enter: logs • q: quit")
+	s += monitorHelpStyle.Render("Keys: ↑/↓ navigate • k: kill • p: pause/resume • a: archive • r: rename • d: diff • l/enter: logs • q: quit")
 
 	return s
 }
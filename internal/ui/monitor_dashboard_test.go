@@ -169,3 +169,61 @@ func TestRefreshMonitorSessionsCmd(t *testing.T) {
 	assert.IsType(t, actionResultMsg{}, msgErr)
 	assert.Error(t, msgErr.(actionResultMsg).err)
 }
+
+func TestMonitorDashboardModel_Update_Archive(t *testing.T) {
+	var archived string
+	callbacks := ActionCallbacks{Archive: func(name string) error {
+		archived = name
+		return nil
+	}}
+	m := NewMonitorDashboardModel(callbacks)
+	m, _ = updateModelWithSessions(m, []model.UnifiedSession{{Name: "sess1", Status: "completed"}})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	resMsg := cmd()
+
+	assert.Equal(t, "sess1", archived)
+	assert.Contains(t, resMsg.(actionResultMsg).msg, "Archived session sess1")
+}
+
+func TestMonitorDashboardModel_Update_Rename(t *testing.T) {
+	var from, to string
+	callbacks := ActionCallbacks{Rename: func(oldName, newName string) error {
+		from, to = oldName, newName
+		return nil
+	}}
+	m := NewMonitorDashboardModel(callbacks)
+	m, _ = updateModelWithSessions(m, []model.UnifiedSession{{Name: "sess1"}})
+
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = newM.(MonitorDashboardModel)
+	assert.Equal(t, "rename", m.viewMode)
+	assert.Contains(t, m.View(), "Rename session 'sess1'")
+
+	newM, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-', '2'}})
+	m = newM.(MonitorDashboardModel)
+	newM, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newM.(MonitorDashboardModel)
+	resMsg := cmd()
+
+	assert.Equal(t, "list", m.viewMode)
+	assert.Equal(t, "sess1", from)
+	assert.Equal(t, "sess1-2", to)
+	assert.Contains(t, resMsg.(actionResultMsg).msg, "Renamed session sess1 to sess1-2")
+}
+
+func TestMonitorDashboardModel_Update_Diff(t *testing.T) {
+	callbacks := ActionCallbacks{GetDiff: func(name string) (string, error) {
+		return " main.go | 2 +-", nil
+	}}
+	m := NewMonitorDashboardModel(callbacks)
+	m, _ = updateModelWithSessions(m, []model.UnifiedSession{{Name: "sess1"}})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	newM, _ := m.Update(cmd())
+	finalM := newM.(MonitorDashboardModel)
+
+	assert.Equal(t, "logs", finalM.viewMode)
+	assert.Contains(t, finalM.View(), "Session Diff: sess1")
+	assert.Contains(t, finalM.logContent, "main.go")
+}
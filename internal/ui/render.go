@@ -1,11 +1,16 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/glamour"
+	"github.com/spf13/viper"
 )
 
+// DefaultMarkdownStyle is the glamour style used when ui.markdown_style is unset.
+const DefaultMarkdownStyle = "dark"
+
 var (
 	// Keep existing styles if needed, but glamour handles most
 	interactiveRenderer *glamour.TermRenderer
@@ -28,8 +33,13 @@ func RenderMarkdown(text string, width int) string {
 	// Ideally we keep one, but word-wrap needs dynamic width.
 	// For basic char/TUI, creating one is 'okay', or we can set it.
 
+	// Cap the width so long lines stay readable on wide terminals
+	if maxWidth := viper.GetInt("ui.markdown_max_width"); maxWidth > 0 && (width <= 0 || width > maxWidth) {
+		width = maxWidth
+	}
+
 	r, err := glamour.NewTermRenderer(
-		glamour.WithStandardStyle("dark"),
+		glamour.WithStylePath(MarkdownStyle()),
 		glamour.WithWordWrap(width),
 	)
 	if err != nil {
@@ -44,3 +54,23 @@ func RenderMarkdown(text string, width int) string {
 	// Glamour adds a newline at the end usually, trim it for chat bubbles
 	return strings.TrimRight(out, "\n")
 }
+
+// MarkdownStyle returns the configured glamour style (ui.markdown_style): a
+// standard style such as "dark", "light", "notty" or "auto", or the path to a
+// custom JSON style.
+func MarkdownStyle() string {
+	if style := viper.GetString("ui.markdown_style"); style != "" {
+		return style
+	}
+	return DefaultMarkdownStyle
+}
+
+// SetMarkdownStyle switches the style used by RenderMarkdown. An unknown style
+// or unreadable style file is rejected and the current style is kept.
+func SetMarkdownStyle(style string) error {
+	if _, err := glamour.NewTermRenderer(glamour.WithStylePath(style)); err != nil {
+		return fmt.Errorf("unknown markdown style %q: %w", style, err)
+	}
+	viper.Set("ui.markdown_style", style)
+	return nil
+}
@@ -1,8 +1,12 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/spf13/viper"
 )
 
 func TestRenderMarkdown(t *testing.T) {
@@ -18,3 +22,58 @@ func TestRenderMarkdown(t *testing.T) {
 		t.Error("Rendered markdown should contain content")
 	}
 }
+
+func TestRenderMarkdown_MaxWidth(t *testing.T) {
+	viper.Set("ui.markdown_style", "notty")
+	viper.Set("ui.markdown_max_width", 20)
+	defer viper.Set("ui.markdown_style", nil)
+	defer viper.Set("ui.markdown_max_width", nil)
+
+	output := RenderMarkdown(strings.Repeat("word ", 20), 120)
+	for _, line := range strings.Split(output, "\n") {
+		if len(strings.TrimRight(line, " ")) > 20 {
+			t.Errorf("line exceeds max width of 20: %q", line)
+		}
+	}
+}
+
+func TestSetMarkdownStyle(t *testing.T) {
+	defer viper.Set("ui.markdown_style", nil)
+
+	if got := MarkdownStyle(); got != DefaultMarkdownStyle {
+		t.Errorf("MarkdownStyle() = %q, want %q", got, DefaultMarkdownStyle)
+	}
+
+	if err := SetMarkdownStyle("notty"); err != nil {
+		t.Fatalf("SetMarkdownStyle(notty) failed: %v", err)
+	}
+	if got := MarkdownStyle(); got != "notty" {
+		t.Errorf("MarkdownStyle() = %q, want notty", got)
+	}
+	// notty renders plain text without ANSI escapes
+	if output := RenderMarkdown("**Bold**", 80); strings.Contains(output, "\x1b[") {
+		t.Errorf("notty output should not contain ANSI escapes: %q", output)
+	}
+
+	if err := SetMarkdownStyle("no-such-style"); err == nil {
+		t.Error("expected an error for an unknown style")
+	}
+	if got := MarkdownStyle(); got != "notty" {
+		t.Errorf("an unknown style should keep the current one, got %q", got)
+	}
+}
+
+func TestSetMarkdownStyle_JSONFile(t *testing.T) {
+	defer viper.Set("ui.markdown_style", nil)
+
+	path := filepath.Join(t.TempDir(), "style.json")
+	if err := os.WriteFile(path, []byte(`{"document": {"margin": 0}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetMarkdownStyle(path); err != nil {
+		t.Fatalf("SetMarkdownStyle(%s) failed: %v", path, err)
+	}
+	if output := RenderMarkdown("Hello", 80); !strings.Contains(output, "Hello") {
+		t.Errorf("custom style output should contain content, got %q", output)
+	}
+}
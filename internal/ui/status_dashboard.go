@@ -175,7 +175,7 @@ func renderUsageInfo(state *agent.State) string {
 	var b strings.Builder
 	b.WriteString(sectionStyle.Render("\n--- Usage ---") + "\n")
 
-	cost := agent.CalculateCost(state.Model, state.TokenUsage)
+	cost := agent.CalculateProviderCost(state.Provider, state.Model, state.TokenUsage)
 	costStr := fmt.Sprintf("$%.6f", cost)
 	if cost > 0.5 {
 		costStr = lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Render(costStr) // Yellow
@@ -90,7 +90,7 @@ func (m summaryModel) View() string {
 		if session.AgentStateFile != "" {
 			state, err := agent.LoadState(session.AgentStateFile)
 			if err == nil {
-				cost := agent.CalculateCost(state.Model, state.TokenUsage)
+				cost := agent.CalculateProviderCost(state.Provider, state.Model, state.TokenUsage)
 				totalCost += cost
 				totalTokens += state.TokenUsage.TotalTokens
 				sessionCosts[session.Name] = cost
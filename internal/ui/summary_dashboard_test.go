@@ -50,11 +50,15 @@ func (m *mockSessionManager) SessionsDir() string                         { retu
 func (m *mockSessionManager) GetSessionGitDiffStat(name string) (string, error) {
 	return "", nil
 }
+func (m *mockSessionManager) GetSessionGitDiff(name string) (string, error) {
+	return "", nil
+}
 func (m *mockSessionManager) ArchiveSession(name string) error   { return nil }
 func (m *mockSessionManager) UnarchiveSession(name string) error { return nil }
 func (m *mockSessionManager) ListArchivedSessions() ([]*runner.SessionState, error) {
 	return nil, nil
 }
+func (m *mockSessionManager) RemoveArchivedSession(name string) error { return nil }
 
 func TestSummaryDashboard(t *testing.T) {
 	// Mock agent.LoadState
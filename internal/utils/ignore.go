@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFileName is the workspace file listing paths, in gitignore syntax,
+// that recac must never delete or overwrite on its own.
+const IgnoreFileName = ".recacignore"
+
+// IgnoreMatcher matches workspace-relative paths against gitignore patterns.
+type IgnoreMatcher struct {
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+	// basename patterns (without a slash) match at any depth
+	basename bool
+}
+
+// LoadIgnore reads the .recacignore at the root of dir. A missing file yields
+// a matcher that matches nothing.
+func LoadIgnore(dir string) (*IgnoreMatcher, error) {
+	data, err := os.ReadFile(filepath.Join(dir, IgnoreFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &IgnoreMatcher{}, nil
+		}
+		return nil, err
+	}
+	return ParseIgnore(string(data)), nil
+}
+
+// ParseIgnore parses gitignore-style patterns, one per line. Invalid
+// patterns are skipped.
+func ParseIgnore(content string) *IgnoreMatcher {
+	m := &IgnoreMatcher{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var p ignorePattern
+		if strings.HasPrefix(line, "!") {
+			p.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			line = line[1:] // Escaped leading "#" or "!"
+		}
+		if strings.HasSuffix(line, "/") {
+			p.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		p.basename = !strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+
+		re, err := regexp.Compile("^" + globToRegexp(line) + "$")
+		if err != nil {
+			continue
+		}
+		p.re = re
+		m.patterns = append(m.patterns, p)
+	}
+	return m
+}
+
+// globToRegexp translates a gitignore glob, including "**", to a regexp.
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if strings.HasPrefix(glob[i:], "**") {
+				rest := glob[i+2:]
+				switch {
+				case strings.HasPrefix(rest, "/"):
+					sb.WriteString("(?:.*/)?") // "**/" matches zero or more directories
+					i += 2
+				case rest == "":
+					sb.WriteString(".*")
+					i++
+				default:
+					sb.WriteString("[^/]*")
+					i++
+				}
+				continue
+			}
+			sb.WriteString("[^/]*")
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
+
+// Match reports whether the slash-separated, workspace-relative path is
+// ignored, either itself or because one of its parent directories is.
+func (m *IgnoreMatcher) Match(path string, isDir bool) bool {
+	if m == nil || len(m.patterns) == 0 {
+		return false
+	}
+	path = strings.Trim(filepath.ToSlash(filepath.Clean(path)), "/")
+	if path == "" || path == "." {
+		return false
+	}
+
+	// As in git, nothing inside an ignored directory can be re-included
+	parts := strings.Split(path, "/")
+	for i := 1; i < len(parts); i++ {
+		if m.matchOne(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return m.matchOne(path, isDir)
+}
+
+// matchOne applies the patterns to a single path; the last match wins.
+func (m *IgnoreMatcher) matchOne(path string, isDir bool) bool {
+	base := path[strings.LastIndex(path, "/")+1:]
+	ignored := false
+	for _, p := range m.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		subject := path
+		if p.basename {
+			subject = base
+		}
+		if p.re.MatchString(subject) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIgnoreMatcher_Match(t *testing.T) {
+	m := ParseIgnore(`# fixtures the agent must keep
+fixtures/
+*.env
+!example.env
+/config.yaml
+docs/**/*.md
+data/**
+\#notes
+`)
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"fixtures", true, true},
+		{"fixtures/users.json", false, true},
+		{"test/fixtures/users.json", false, true},
+		{"fixtures", false, false}, // A file named like the directory
+		{"prod.env", false, true},
+		{"sub/prod.env", false, true},
+		{"example.env", false, false},
+		{"config.yaml", false, true},
+		{"sub/config.yaml", false, false},
+		{"docs/guide.md", false, true},
+		{"docs/a/b/guide.md", false, true},
+		{"docs/guide.txt", false, false},
+		{"data/x/y.bin", false, true},
+		{"#notes", false, true},
+		{"main.go", false, false},
+		{".", true, false},
+	}
+	for _, tt := range tests {
+		if got := m.Match(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("Match(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestLoadIgnore(t *testing.T) {
+	dir := t.TempDir()
+
+	m, err := LoadIgnore(dir)
+	if err != nil {
+		t.Fatalf("LoadIgnore without a file failed: %v", err)
+	}
+	if m.Match("anything.txt", false) {
+		t.Errorf("empty matcher should match nothing")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, IgnoreFileName), []byte("keep.txt\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m, err = LoadIgnore(dir)
+	if err != nil {
+		t.Fatalf("LoadIgnore failed: %v", err)
+	}
+	if !m.Match("keep.txt", false) {
+		t.Errorf("expected keep.txt to be matched")
+	}
+}
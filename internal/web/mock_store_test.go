@@ -20,6 +20,9 @@ func (m *MockStore) GetFeatures(projectID string) (string, error) {
 func (m *MockStore) Close() error { return nil }
 func (m *MockStore) SaveObservation(projectID, agentID, content string) error { return nil }
 func (m *MockStore) QueryHistory(projectID string, limit int) ([]db.Observation, error) { return nil, nil }
+func (m *MockStore) QueryHistoryPage(projectID string, q db.HistoryQuery) ([]db.Observation, error) {
+	return nil, nil
+}
 func (m *MockStore) SetSignal(projectID, key, value string) error { return nil }
 func (m *MockStore) GetSignal(projectID, key string) (string, error) { return "", nil }
 func (m *MockStore) DeleteSignal(projectID, key string) error { return nil }
@@ -32,3 +35,4 @@ func (m *MockStore) ReleaseLock(projectID, path, agentID string) error { return
 func (m *MockStore) ReleaseAllLocks(projectID, agentID string) error { return nil }
 func (m *MockStore) GetActiveLocks(projectID string) ([]db.Lock, error) { return nil, nil }
 func (m *MockStore) Cleanup() error { return nil }
+func (m *MockStore) ResetProject(projectID string) error { return nil }
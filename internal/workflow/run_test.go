@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"recac/internal/runner"
@@ -88,17 +89,25 @@ func TestRunWorkflow_Detached_CommandConstruction(t *testing.T) {
 			}
 			assert.True(t, foundDirty, "Should contain --allow-dirty")
 
+			joined := strings.Join(command, " ")
+			assert.Contains(t, joined, "--manager-provider anthropic --manager-model claude-x")
+			assert.Contains(t, joined, "--qa-provider openai --qa-model gpt-x")
+
 			return &runner.SessionState{PID: 1}, nil
 		},
 	}
 
 	cfg := SessionConfig{
-		SessionName:    "test-flags",
-		ProjectPath:    tmpDir,
-		Detached:       true,
-		MaxIterations:  50,
-		AllowDirty:     true,
-		SessionManager: mockSM,
+		SessionName:     "test-flags",
+		ProjectPath:     tmpDir,
+		Detached:        true,
+		MaxIterations:   50,
+		AllowDirty:      true,
+		ManagerProvider: "anthropic",
+		ManagerModel:    "claude-x",
+		QAProvider:      "openai",
+		QAModel:         "gpt-x",
+		SessionManager:  mockSM,
 	}
 
 	err := RunWorkflow(context.Background(), cfg)
@@ -12,6 +12,7 @@ import (
 
 	"recac/internal/agent"
 	"recac/internal/cmdutils"
+	"recac/internal/config"
 	"recac/internal/docker"
 	"recac/internal/git"
 	"recac/internal/jira"
@@ -23,35 +24,53 @@ import (
 
 // SessionConfig holds all parameters for a RECAC session
 type SessionConfig struct {
-	Goal              string
-	ProjectPath       string
-	ProjectName       string
-	IsMock            bool
-	MaxIterations     int
-	ManagerFrequency  int
-	MaxAgents         int
-	TaskMaxIterations int
-	Detached          bool
-	SessionName       string
-	JiraEpicKey       string
-	AllowDirty        bool
-	Stream            bool
-	AutoMerge         bool
-	SkipQA            bool
-	ManagerFirst      bool
-	Debug             bool
-	JiraClient        *jira.Client
-	JiraTicketID      string
-	RepoURL           string
-	Image             string
-	Provider          string
-	Model             string
-	Cleanup           bool
-	Summary           string
-	Description       string
-	Logger            *slog.Logger
-	CommandPrefix     []string // Command arguments to prepend (e.g. "start")
-	SessionManager    ISessionManager
+	Goal                string
+	ProjectPath         string
+	ProjectName         string
+	IsMock              bool
+	MaxIterations       int
+	ManagerFrequency    int
+	MaxAgents           int
+	TaskMaxIterations   int
+	Detached            bool
+	SessionName         string
+	JiraEpicKey         string
+	AllowDirty          bool
+	Fresh               bool // Wipe the previous session's database and agent state before starting
+	Stream              bool
+	PrintPrompt         bool   // Print the full prompt sent to each agent role
+	SelectedTaskID      string // Focus the session on this feature (--select-task)
+	AutoMerge           bool
+	SkipQA              bool
+	ManagerFirst        bool
+	RequireHumanSignoff bool
+	Debug               bool
+	JiraClient          *jira.Client
+	JiraTicketID        string
+	GitHubIssue         int    // Source GitHub issue number, commented on when the session completes
+	GitHubRepo          string // Repository ("owner/repo") hosting GitHubIssue
+	RepoURL             string
+	RepoURLs            []string // Additional repositories, each cloned into a subdirectory of the workspace
+	Image               string
+	ImageDigest         string             // Expected image digest (sha256:...), verified before running
+	ContainerEntrypoint []string           // Overrides the agent image entrypoint
+	ContainerCommand    []string           // Overrides the agent container command
+	Network             string             // Network of the agent container: bridge (default), none, host or a named network
+	Proxy               docker.ProxyConfig // Egress proxy for outbound HTTP(S) traffic from the agent container
+	Provider            string
+	Model               string
+	ManagerProvider     string // Defaults to Provider when unset
+	ManagerModel        string // Defaults to Model when unset
+	QAProvider          string // Defaults to Provider when unset
+	QAModel             string // Defaults to Model when unset
+	Cleanup             bool
+	CleanupPolicy       string // always, on-success or never; overrides Cleanup when set
+	Summary             string
+	Description         string
+	Logger              *slog.Logger
+	CommandPrefix       []string // Command arguments to prepend (e.g. "start")
+	SessionManager      ISessionManager
+	StatusAddr          string // Serve a live status page on this address (empty = disabled)
 }
 
 // ProcessDirectTask handles a coding session from a direct repository and task description
@@ -89,6 +108,10 @@ var ProcessDirectTask = func(ctx context.Context, cfg SessionConfig) error {
 		logger.Error("Error: Failed to setup workspace", "error", err)
 		return err
 	}
+	if _, err := cmdutils.SetupComponentRepos(ctx, gitClient, cfg.RepoURLs, cfg.ProjectPath, workID, timestamp); err != nil {
+		logger.Error("Error: Failed to setup component repositories", "error", err)
+		return err
+	}
 
 	// Force task context: Overwrite app_spec.txt
 	if cfg.Summary != "" || cfg.Description != "" {
@@ -121,6 +144,12 @@ var ProcessJiraTicket = func(ctx context.Context, jiraTicketID string, jClient *
 	logger := cfg.Logger.With("ticket_id", jiraTicketID)
 	cfg.Logger = logger // Pass it down
 
+	cleanupPolicy, err := runner.ResolveCleanupPolicy(cfg.CleanupPolicy, cfg.Cleanup)
+	if err != nil {
+		logger.Error("Invalid cleanup policy", "error", err)
+		return err
+	}
+
 	// 2. Fetch Ticket
 	ticket, err := jClient.GetTicket(ctx, jiraTicketID)
 	if err != nil {
@@ -205,6 +234,10 @@ var ProcessJiraTicket = func(ctx context.Context, jiraTicketID string, jClient *
 		logger.Error("Error: Failed to setup workspace", "error", err)
 		return err
 	}
+	if _, err := cmdutils.SetupComponentRepos(ctx, gitClient, cfg.RepoURLs, tempWorkspace, jiraTicketID, timestamp); err != nil {
+		logger.Error("Error: Failed to setup component repositories", "error", err)
+		return err
+	}
 
 	// 5. Create app_spec.txt
 	specContent := fmt.Sprintf("# Jira Ticket: %s\n# Summary: %s\n\n%s", jiraTicketID, summary, description)
@@ -216,15 +249,11 @@ var ProcessJiraTicket = func(ctx context.Context, jiraTicketID string, jClient *
 
 	logger.Info("Workspace created", "path", tempWorkspace)
 
-	// Auto-cleanup
-	if cfg.Cleanup {
-		defer func() {
-			logger.Info("Cleaning up workspace", "path", tempWorkspace)
-			if err := os.RemoveAll(tempWorkspace); err != nil {
-				logger.Error("Failed to cleanup workspace", "path", tempWorkspace, "error", err)
-			}
-		}()
-	}
+	// Auto-cleanup, depending on the session outcome
+	var sessionErr error
+	defer func() {
+		cleanupPolicy.CleanupWorkspace(logger, tempWorkspace, sessionErr)
+	}()
 
 	// 5. Transition Ticket Status
 	transition := viper.GetString("jira.transition")
@@ -249,15 +278,35 @@ var ProcessJiraTicket = func(ctx context.Context, jiraTicketID string, jClient *
 	cfg.RepoURL = repoURL
 
 	// Run Workflow
-	if err := RunWorkflow(ctx, cfg); err != nil {
-		logger.Error("Session failed", "error", err)
-		return err
+	if sessionErr = RunWorkflow(ctx, cfg); sessionErr != nil {
+		logger.Error("Session failed", "error", sessionErr)
+		return sessionErr
 	} else {
 		logger.Info("Session completed successfully")
 		return nil
 	}
 }
 
+// mergeProjectConfig applies the provider and model pinned by the workspace's
+// .recac/config.yaml; the session reads its other settings from the same file.
+// Values set explicitly by the caller are kept.
+func mergeProjectConfig(cfg *SessionConfig, projectPath string) {
+	settings, path, err := config.ProjectSettings(projectPath)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+		return
+	}
+	if path == "" {
+		return
+	}
+	if cfg.Provider == viper.GetString("provider") {
+		cfg.Provider = settings.GetString("provider")
+	}
+	if cfg.Model == viper.GetString("model") {
+		cfg.Model = settings.GetString("model")
+	}
+}
+
 // ISessionManager defines the interface for session management.
 type ISessionManager interface {
 	StartSession(name, goal string, command []string, cwd string) (*runner.SessionState, error)
@@ -274,6 +323,20 @@ var NewSessionManagerFunc = func() (ISessionManager, error) {
 // Allow mocking Session creation
 var NewSessionFunc = runner.NewSession
 
+// wipeWorkspaceState deletes the previous session's database and agent state
+// from projectPath, warning loudly since the progress cannot be recovered.
+func wipeWorkspaceState(projectPath string) error {
+	fmt.Printf("WARNING: --fresh set; discarding previous session state in %s (features, signals, history and agent state)\n", projectPath)
+	removed, err := runner.WipeWorkspaceState(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to wipe session state: %w", err)
+	}
+	for _, path := range removed {
+		fmt.Printf("Removed %s\n", path)
+	}
+	return nil
+}
+
 // RunWorkflow handles the execution of a single project session (local or Jira-based)
 var RunWorkflow = func(ctx context.Context, cfg SessionConfig) error {
 	// Handle detached mode
@@ -344,6 +407,39 @@ var RunWorkflow = func(ctx context.Context, cfg SessionConfig) error {
 		if cfg.AllowDirty {
 			command = append(command, "--allow-dirty")
 		}
+		if cfg.Fresh {
+			command = append(command, "--fresh")
+		}
+		if cfg.PrintPrompt {
+			command = append(command, "--print-prompt")
+		}
+		if cfg.SelectedTaskID != "" {
+			command = append(command, "--select-task", cfg.SelectedTaskID)
+		}
+		if cfg.Network != "" && cfg.Network != "bridge" {
+			command = append(command, "--network", cfg.Network)
+		}
+		if cfg.Proxy.HTTP != "" {
+			command = append(command, "--http-proxy", cfg.Proxy.HTTP)
+		}
+		if cfg.Proxy.HTTPS != "" {
+			command = append(command, "--https-proxy", cfg.Proxy.HTTPS)
+		}
+		if cfg.Proxy.NoProxy != "" {
+			command = append(command, "--no-proxy", cfg.Proxy.NoProxy)
+		}
+		if cfg.ManagerProvider != "" {
+			command = append(command, "--manager-provider", cfg.ManagerProvider)
+		}
+		if cfg.ManagerModel != "" {
+			command = append(command, "--manager-model", cfg.ManagerModel)
+		}
+		if cfg.QAProvider != "" {
+			command = append(command, "--qa-provider", cfg.QAProvider)
+		}
+		if cfg.QAModel != "" {
+			command = append(command, "--qa-model", cfg.QAModel)
+		}
 
 		projectPath := cfg.ProjectPath
 		if projectPath == "" {
@@ -385,22 +481,49 @@ var RunWorkflow = func(ctx context.Context, cfg SessionConfig) error {
 			projectName = "mock-project"
 		}
 
+		if cfg.Fresh {
+			if err := wipeWorkspaceState(projectPath); err != nil {
+				return err
+			}
+		}
+
 		session := NewSessionFunc(dockerCli, agentClient, projectPath, cfg.Image, projectName, cfg.Provider, cfg.Model, cfg.MaxAgents)
 		if cfg.Logger != nil {
 			session.Logger = cfg.Logger
 		}
+		if cfg.Fresh {
+			if err := session.ResetProjectState(); err != nil {
+				return fmt.Errorf("failed to reset project state: %w", err)
+			}
+		}
 		session.MaxIterations = cfg.MaxIterations
 		session.TaskMaxIterations = cfg.TaskMaxIterations
 		session.ManagerFrequency = cfg.ManagerFrequency
 		session.StreamOutput = cfg.Stream
+		session.PrintPrompt = cfg.PrintPrompt
+		session.SelectedTaskID = cfg.SelectedTaskID
 		session.AutoMerge = cfg.AutoMerge
 		session.SkipQA = cfg.SkipQA
+		session.RequireHumanSignoff = session.RequireHumanSignoff || cfg.RequireHumanSignoff
+		session.ImageDigest = cfg.ImageDigest
+		session.ContainerEntrypoint = cfg.ContainerEntrypoint
+		session.ContainerCommand = cfg.ContainerCommand
+		session.ContainerNetwork = cfg.Network
+		session.ContainerProxy = cfg.Proxy
 		session.ManagerFirst = cfg.ManagerFirst
+		session.ManagerProvider = cfg.ManagerProvider
+		session.ManagerModel = cfg.ManagerModel
+		session.QAProvider = cfg.QAProvider
+		session.QAModel = cfg.QAModel
+		session.StatusAddr = cfg.StatusAddr
 
 		if cfg.JiraEpicKey != "" {
 			session.BaseBranch = fmt.Sprintf("agent-epic/%s", cfg.JiraEpicKey)
 		}
 
+		if err := session.ValidateSelectedTask(); err != nil {
+			return err
+		}
 		if err := session.Start(ctx); err != nil {
 			if ctx.Err() != nil {
 				return nil
@@ -417,6 +540,7 @@ var RunWorkflow = func(ctx context.Context, cfg SessionConfig) error {
 	if projectPath == "" {
 		projectPath = "."
 	}
+	mergeProjectConfig(&cfg, projectPath)
 
 	// Pre-flight check
 	if !cfg.AllowDirty {
@@ -451,6 +575,8 @@ var RunWorkflow = func(ctx context.Context, cfg SessionConfig) error {
 	if err != nil {
 		fmt.Printf("Warning: Failed to initialize Docker client: %v. Proceeding in restricted mode.\n", err)
 		dockerCli = nil
+	} else {
+		dockerCli.RegistryAuth = cmdutils.GetRegistryAuth()
 	}
 
 	provider := cfg.Provider
@@ -460,20 +586,63 @@ var RunWorkflow = func(ctx context.Context, cfg SessionConfig) error {
 		return fmt.Errorf("failed to initialize agent: %v", err)
 	}
 
+	if cfg.Fresh {
+		if err := wipeWorkspaceState(projectPath); err != nil {
+			return err
+		}
+	}
+
 	session := NewSessionFunc(dockerCli, agentClient, projectPath, cfg.Image, projectName, provider, model, cfg.MaxAgents)
 	if cfg.Logger != nil {
 		session.Logger = cfg.Logger
 	}
+	if cfg.Fresh {
+		if err := session.ResetProjectState(); err != nil {
+			return fmt.Errorf("failed to reset project state: %w", err)
+		}
+	}
 	session.MaxIterations = cfg.MaxIterations
 	session.TaskMaxIterations = cfg.TaskMaxIterations
 	session.ManagerFrequency = cfg.ManagerFrequency
 	session.ManagerFirst = cfg.ManagerFirst
+	session.ManagerProvider = cfg.ManagerProvider
+	session.ManagerModel = cfg.ManagerModel
+	session.QAProvider = cfg.QAProvider
+	session.QAModel = cfg.QAModel
 	session.StreamOutput = cfg.Stream
+	session.PrintPrompt = cfg.PrintPrompt
+	session.SelectedTaskID = cfg.SelectedTaskID
 	session.AutoMerge = cfg.AutoMerge
 	session.SkipQA = cfg.SkipQA
+	session.RequireHumanSignoff = session.RequireHumanSignoff || cfg.RequireHumanSignoff
+	session.ImageDigest = cfg.ImageDigest
+	session.ContainerEntrypoint = cfg.ContainerEntrypoint
+	session.ContainerCommand = cfg.ContainerCommand
+	session.ContainerNetwork = cfg.Network
+	session.ContainerProxy = cfg.Proxy
 	session.JiraClient = cfg.JiraClient
 	session.JiraTicketID = cfg.JiraTicketID
 	session.RepoURL = cfg.RepoURL
+	for _, repoURL := range cfg.RepoURLs {
+		session.ComponentRepos = append(session.ComponentRepos, cmdutils.ComponentDir(repoURL))
+	}
+	session.StatusAddr = cfg.StatusAddr
+
+	if app, err := cmdutils.GetGitHubApp(); err != nil {
+		fmt.Printf("Warning: GitHub App auth disabled: %v\n", err)
+	} else {
+		session.GitHubApp = app
+	}
+
+	if cfg.GitHubIssue > 0 {
+		ghClient, err := cmdutils.GetGitHubIssueClient(cfg.GitHubRepo)
+		if err != nil {
+			fmt.Printf("Warning: GitHub issue #%d will not be updated: %v\n", cfg.GitHubIssue, err)
+		} else {
+			session.GitHubClient = ghClient
+			session.GitHubIssue = cfg.GitHubIssue
+		}
+	}
 
 	if cfg.JiraEpicKey != "" {
 		session.BaseBranch = fmt.Sprintf("agent-epic/%s", cfg.JiraEpicKey)
@@ -496,6 +665,9 @@ var RunWorkflow = func(ctx context.Context, cfg SessionConfig) error {
 		}
 	}
 
+	if err := session.ValidateSelectedTask(); err != nil {
+		return err
+	}
 	if err := session.Start(ctx); err != nil {
 		if ctx.Err() != nil {
 			return nil
@@ -124,3 +124,54 @@ func TestRunWorkflow_NormalMode_Mocked(t *testing.T) {
 		t.Errorf("Expected ErrMaxIterations, got %v", err)
 	}
 }
+
+func TestRunWorkflow_Fresh(t *testing.T) {
+	originalNewSession := NewSessionFunc
+	defer func() { NewSessionFunc = originalNewSession }()
+	originalGetAgentClient := cmdutils.GetAgentClient
+	defer func() { cmdutils.GetAgentClient = originalGetAgentClient }()
+
+	mockDocker, _ := docker.NewMockClient()
+	mockAgent := agent.NewMockAgent()
+	mockAgent.SetResponse("exit")
+	cmdutils.GetAgentClient = func(ctx context.Context, provider, model, projectPath, projectName string) (agent.Agent, error) {
+		return mockAgent, nil
+	}
+
+	tmpDir := t.TempDir()
+	stale := []string{".recac.db", ".agent_state.json", ".agent_state_task-1.json"}
+	for _, name := range stale {
+		os.WriteFile(filepath.Join(tmpDir, name), []byte("stale"), 0644)
+	}
+
+	var leftover []string
+	NewSessionFunc = func(d runner.DockerClient, a agent.Agent, workspace, image, project, provider, model string, maxAgents int) *runner.Session {
+		// The wipe must happen before the session opens its database
+		for _, name := range stale {
+			if _, err := os.Stat(filepath.Join(workspace, name)); err == nil {
+				leftover = append(leftover, name)
+			}
+		}
+		s := runner.NewSession(mockDocker, mockAgent, workspace, image, project, provider, model, maxAgents)
+		s.MaxIterations = 1
+		s.Logger = telemetry.NewLogger(true, "", false)
+		os.WriteFile(filepath.Join(workspace, "app_spec.txt"), []byte("spec"), 0644)
+		return s
+	}
+
+	cfg := SessionConfig{
+		SessionName:   "fresh-run",
+		ProjectPath:   tmpDir,
+		Provider:      "mock",
+		MaxIterations: 1,
+		AllowDirty:    true,
+		Fresh:         true,
+	}
+
+	if err := RunWorkflow(context.Background(), cfg); err != runner.ErrMaxIterations {
+		t.Errorf("Expected ErrMaxIterations, got %v", err)
+	}
+	if len(leftover) > 0 {
+		t.Errorf("Expected stale state to be wiped before the session started, found %v", leftover)
+	}
+}
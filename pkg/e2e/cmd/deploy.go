@@ -55,12 +55,6 @@ func RunDeploy(args []string) error {
 	log.Println("=== Cleaning up old Jobs ===")
 	_ = runCommand("kubectl", "delete", "jobs", "-n", namespace, "-l", "app=recac-agent", "--cascade=foreground", "--wait=true")
 
-	// Wipe Database if postgres is used
-	// (Simplification: assuming default setup for now, copying runner logic)
-	log.Println("=== Wiping PostgreSQL Database ===")
-	wipeCmd := "PGPASSWORD=changeit psql -U recac -d recac -c \"TRUNCATE observations, signals, project_features, file_locks;\""
-	_ = runCommand("sh", "-c", fmt.Sprintf("POD_NAME=$(kubectl get pods -l app.kubernetes.io/instance=recac,app.kubernetes.io/name=postgresql -n default -o jsonpath='{.items[0].metadata.name}' 2>/dev/null) && [ -n \"$POD_NAME\" ] && kubectl exec $POD_NAME -n default -- sh -c %s", fmt.Sprintf("'%s'", wipeCmd)))
-
 	log.Println("=== Deploying Helm Chart ===")
 	lastColon := strings.LastIndex(imageName, ":")
 	repoPart := imageName[:lastColon]
@@ -83,13 +77,14 @@ func RunDeploy(args []string) error {
 		"--set", "config.imagePullPolicy=IfNotPresent",
 		"--set", "config.poller=jira",
 		"--set", fmt.Sprintf("config.jira_label=%s", e2eCtx.JiraLabel),
-		"--set", fmt.Sprintf("config.jira_query=labels = \"%s\" AND issuetype != Epic AND statusCategory != Done ORDER BY created ASC", e2eCtx.JiraLabel),
+		"--set", "config.jira_exclude_types=Epic",
 		"--set", "config.verbose=true",
 		"--set", "config.interval=10s",
 		"--set", fmt.Sprintf("config.maxIterations=%s", GetEnvOrDefault("MAX_ITERATIONS", "60")),
 		"--set", fmt.Sprintf("config.provider=%s", e2eCtx.Provider),
 		"--set", fmt.Sprintf("config.model=%s", e2eCtx.Model),
 		"--set", "config.dbType=postgres",
+		"--set", "config.fresh=true", // Agents start from a clean slate instead of a stale database
 		"--set", "postgresql.enabled=true",
 		"--set", "postgresql.image.repository=bitnami/postgresql",
 		"--set", "postgresql.image.tag=latest",
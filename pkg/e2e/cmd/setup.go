@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"recac/internal/jira"
+	"recac/internal/telemetry"
 	"recac/pkg/e2e/manager"
 	"recac/pkg/e2e/scenarios"
 	"recac/pkg/e2e/state"
@@ -127,7 +128,7 @@ func prepareRepo(repoURL string, ticketMap map[string]string) error {
 	cmd := exec.Command("git", "ls-remote", "--heads", authRepo)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("failed to ls-remote: %w\nOutput: %s", err, string(output))
+		return fmt.Errorf("failed to ls-remote: %w\nOutput: %s", err, telemetry.Redact(string(output)))
 	}
 
 	lines := strings.Split(string(output), "\n")
@@ -155,7 +156,7 @@ func prepareRepo(repoURL string, ticketMap map[string]string) error {
 		log.Printf("Deleting remote branch: %s", branch)
 		delCmd := exec.Command("git", "push", authRepo, "--delete", branch)
 		if out, err := delCmd.CombinedOutput(); err != nil {
-			log.Printf("Warning: Failed to delete branch %s: %v\nOutput: %s", branch, err, string(out))
+			log.Printf("Warning: Failed to delete branch %s: %v\nOutput: %s", branch, err, telemetry.Redact(string(out)))
 		}
 	}
 
@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"recac/internal/telemetry"
 	"recac/pkg/e2e/scenarios"
 	"recac/pkg/e2e/state"
 
@@ -64,9 +65,14 @@ func RunVerify(args []string) error {
 	return scenario.Verify(tmpDir, e2eCtx.TicketMap)
 }
 
+// runCommand runs an external command, scrubbing secrets (e.g. tokens passed via
+// helm --set or embedded in repo URLs) from its output.
 func runCommand(name string, args ...string) error {
 	cmd := exec.Command(name, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	stdout, stderr := telemetry.NewRedactingWriter(os.Stdout), telemetry.NewRedactingWriter(os.Stderr)
+	defer stdout.Close()
+	defer stderr.Close()
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
 	return cmd.Run()
 }